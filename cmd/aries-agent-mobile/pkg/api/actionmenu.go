@@ -0,0 +1,27 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+)
+
+// ActionMenuController defines methods for the action-menu protocol controller.
+type ActionMenuController interface {
+
+	// SendMenu publishes a menu to the connection
+	SendMenu(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// RequestMenu requests the current menu of the connection
+	RequestMenu(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// Perform performs an option of the menu received from the connection
+	Perform(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// GetMenu returns the last menu received from the connection
+	GetMenu(request *models.RequestEnvelope) *models.ResponseEnvelope
+}
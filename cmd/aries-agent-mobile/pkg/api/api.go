@@ -12,6 +12,9 @@ type AriesController interface {
 	// GetIntroduceController returns an implementation of IntroduceController
 	GetIntroduceController() (IntroduceController, error)
 
+	// GetActionMenuController returns an implementation of ActionMenuController
+	GetActionMenuController() (ActionMenuController, error)
+
 	// GetVerifiableController returns an implementation of VerifiableController
 	GetVerifiableController() (VerifiableController, error)
 
@@ -44,12 +44,21 @@ type VCWalletController interface {
 	// runs query against wallet credential contents and returns presentation containing credential results.
 	Query(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// searches wallet contents by field, tag, date range and free text filters with sorting and pagination.
+	Search(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// adds proof to a Verifiable Credential.
 	Issue(request *models.RequestEnvelope) *models.ResponseEnvelope
 
 	// produces a Verifiable Presentation.
 	Prove(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// produces a Verifiable Presentation in response to a Verifiable Presentation Request.
+	CreatePresentationResponse(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// responds to a Verifiable Presentation Request through its interact service.
+	RespondPresentationRequest(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// verifies a Verifiable Credential or a Verifiable Presentation.
 	Verify(request *models.RequestEnvelope) *models.ResponseEnvelope
 
@@ -68,4 +77,38 @@ type VCWalletController interface {
 
 	// sends message present proof message from wallet to relying party.
 	PresentProof(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// exports all wallet contents and key material as an encrypted wallet.
+	Export(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// imports contents of an encrypted wallet into wallet.
+	Import(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// creates a new DID from wallet key, created DID is managed by wallet and can be used for signing.
+	CreateDID(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// lists all DIDs managed by wallet.
+	ListDIDs(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// sets default wallet DID for signing.
+	SetDefaultDID(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// updates DID managed by wallet.
+	UpdateDID(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// deactivates DID managed by wallet.
+	DeactivateDID(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// lists all active sessions of wallet.
+	ListSessions(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// replaces wallet session token with a new token.
+	RefreshSession(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// revokes wallet session by session ID.
+	RevokeSession(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// locks all wallet sessions unlocked with 'lockOnBackground' option,
+	// to be called by mobile application when it goes to background.
+	LockBackgroundSessions(request *models.RequestEnvelope) *models.ResponseEnvelope
 }
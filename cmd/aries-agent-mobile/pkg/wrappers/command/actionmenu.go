@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package command
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	cmdactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+)
+
+// ActionMenu contains handler function for action-menu protocol commands.
+type ActionMenu struct {
+	handlers map[string]command.Exec
+}
+
+// SendMenu publishes a menu to the connection.
+func (a *ActionMenu) SendMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.SendMenuArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(a.handlers[cmdactionmenu.SendMenu], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// RequestMenu requests the current menu of the connection.
+func (a *ActionMenu) RequestMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.RequestMenuArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(a.handlers[cmdactionmenu.RequestMenu], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// Perform performs an option of the menu received from the connection.
+func (a *ActionMenu) Perform(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.PerformArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(a.handlers[cmdactionmenu.Perform], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// GetMenu returns the last menu received from the connection.
+func (a *ActionMenu) GetMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.GetMenuArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(a.handlers[cmdactionmenu.GetMenu], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
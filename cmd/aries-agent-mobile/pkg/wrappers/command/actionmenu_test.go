@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	cmdactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+)
+
+const actionMenuDIDs = `"my_did":"did:example:alice","their_did":"did:example:bob"`
+
+func getActionMenuController(t *testing.T) *ActionMenu {
+	a, err := getAgent()
+	require.NotNil(t, a)
+	require.NoError(t, err)
+
+	amc, err := a.GetActionMenuController()
+	require.NoError(t, err)
+	require.NotNil(t, amc)
+
+	am, ok := amc.(*ActionMenu)
+	require.Equal(t, ok, true)
+
+	return am
+}
+
+func TestActionMenu_SendMenu(t *testing.T) {
+	t.Run("test it performs a send menu request", func(t *testing.T) {
+		am := getActionMenuController(t)
+
+		fakeHandler := mockCommandRunner{data: []byte(``)}
+		am.handlers[cmdactionmenu.SendMenu] = fakeHandler.exec
+
+		resp := am.SendMenu(&models.RequestEnvelope{Payload: []byte(`{` + actionMenuDIDs +
+			`,"menu":{"title":"Services","options":[{"name":"balance","title":"Check balance"}]}}`)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+	})
+
+	t.Run("test it fails with an invalid payload", func(t *testing.T) {
+		am := getActionMenuController(t)
+
+		resp := am.SendMenu(&models.RequestEnvelope{Payload: []byte(`}`)})
+		require.NotNil(t, resp.Error)
+	})
+}
+
+func TestActionMenu_RequestMenu(t *testing.T) {
+	t.Run("test it performs a request menu request", func(t *testing.T) {
+		am := getActionMenuController(t)
+
+		fakeHandler := mockCommandRunner{data: []byte(``)}
+		am.handlers[cmdactionmenu.RequestMenu] = fakeHandler.exec
+
+		resp := am.RequestMenu(&models.RequestEnvelope{Payload: []byte(`{` + actionMenuDIDs + `}`)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+	})
+}
+
+func TestActionMenu_Perform(t *testing.T) {
+	t.Run("test it performs a perform request", func(t *testing.T) {
+		am := getActionMenuController(t)
+
+		fakeHandler := mockCommandRunner{data: []byte(``)}
+		am.handlers[cmdactionmenu.Perform] = fakeHandler.exec
+
+		resp := am.Perform(&models.RequestEnvelope{Payload: []byte(`{` + actionMenuDIDs +
+			`,"perform":{"name":"balance","params":{"account":"123"}}}`)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+	})
+}
+
+func TestActionMenu_GetMenu(t *testing.T) {
+	t.Run("test it performs a get menu request", func(t *testing.T) {
+		am := getActionMenuController(t)
+
+		mockResponse := `{"menu":{"title":"Services","options":[{"name":"balance"}]}}`
+
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		am.handlers[cmdactionmenu.GetMenu] = fakeHandler.exec
+
+		resp := am.GetMenu(&models.RequestEnvelope{Payload: []byte(`{` + actionMenuDIDs + `}`)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
@@ -241,6 +242,16 @@ func (a *Aries) GetIntroduceController() (api.IntroduceController, error) {
 	return &Introduce{handlers: handlers}, nil
 }
 
+// GetActionMenuController returns an ActionMenu instance.
+func (a *Aries) GetActionMenuController() (api.ActionMenuController, error) {
+	handlers, ok := a.handlers[actionmenu.CommandName]
+	if !ok {
+		return nil, fmt.Errorf("no handlers found for controller [%s]", actionmenu.CommandName)
+	}
+
+	return &ActionMenu{handlers: handlers}, nil
+}
+
 // GetVerifiableController returns a Verifiable instance.
 func (a *Aries) GetVerifiableController() (api.VerifiableController, error) {
 	handlers, ok := a.handlers[verifiable.CommandName]
@@ -179,6 +179,22 @@ func (v *VCWallet) Query(request *models.RequestEnvelope) *models.ResponseEnvelo
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// Search searches wallet contents by field, tag, date range and free text filters.
+func (v *VCWallet) Search(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.SearchRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.SearchMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // Issue adds proof to a Verifiable Credential from wallet.
 func (v *VCWallet) Issue(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdvcwallet.IssueRequest{}
@@ -211,6 +227,40 @@ func (v *VCWallet) Prove(request *models.RequestEnvelope) *models.ResponseEnvelo
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// CreatePresentationResponse produces a Verifiable Presentation from wallet in response to a
+// Verifiable Presentation Request.
+func (v *VCWallet) CreatePresentationResponse(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.CreatePresentationResponseRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.CreateVPRResponseMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// RespondPresentationRequest responds to a Verifiable Presentation Request by sending presentation
+// from wallet to interact service of the request.
+func (v *VCWallet) RespondPresentationRequest(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.RespondPresentationRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.RespondVPRMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // Verify verifies credential/presentation from wallet.
 func (v *VCWallet) Verify(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdvcwallet.VerifyRequest{}
@@ -307,3 +357,174 @@ func (v *VCWallet) PresentProof(request *models.RequestEnvelope) *models.Respons
 
 	return &models.ResponseEnvelope{Payload: response}
 }
+
+// Export exports all wallet contents and key material as an encrypted wallet.
+func (v *VCWallet) Export(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.ExportRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.ExportMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// Import imports contents of an encrypted wallet into wallet.
+func (v *VCWallet) Import(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.ImportRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.ImportMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// CreateDID creates a new DID from wallet key, created DID is managed by wallet and can be used for signing.
+func (v *VCWallet) CreateDID(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.CreateDIDRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.CreateDIDMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// ListDIDs lists all DIDs managed by wallet.
+func (v *VCWallet) ListDIDs(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.ListDIDsRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.ListDIDsMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// SetDefaultDID sets default wallet DID for signing.
+func (v *VCWallet) SetDefaultDID(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.SetDefaultDIDRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.SetDefaultDIDMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// UpdateDID updates DID managed by wallet.
+func (v *VCWallet) UpdateDID(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.UpdateDIDRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.UpdateDIDMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// DeactivateDID deactivates DID managed by wallet.
+func (v *VCWallet) DeactivateDID(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.DeactivateDIDRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.DeactivateDIDMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// ListSessions lists all active sessions of wallet.
+func (v *VCWallet) ListSessions(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.ListSessionsRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.ListSessionsMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// RefreshSession replaces wallet session token with a new token.
+func (v *VCWallet) RefreshSession(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.RefreshSessionRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.RefreshSessionMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// RevokeSession revokes wallet session by session ID.
+func (v *VCWallet) RevokeSession(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.RevokeSessionRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.RevokeSessionMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// LockBackgroundSessions locks all wallet sessions unlocked with 'lockOnBackground' option,
+// to be called by mobile application when it goes to background.
+func (v *VCWallet) LockBackgroundSessions(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	response, cmdErr := exec(v.handlers[cmdvcwallet.LockBackgroundMethod], struct{}{})
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
@@ -429,3 +429,335 @@ func TestVCWallet_Add_Get_GetAll(t *testing.T) {
 		require.NotNil(t, resp.Error)
 	})
 }
+
+func TestVCWallet_Export_Import(t *testing.T) {
+	vcwalletController := getVCWalletController(t)
+	require.NotNil(t, vcwalletController)
+
+	const (
+		sampleExportUserAuth = `{"userID":"export-user", "localKMSPassphrase": "fakepassphrase"}`
+		sampleImportUserAuth = `{"userID":"import-user", "localKMSPassphrase": "fakepassphrase"}`
+	)
+
+	tokens := make(map[string]string)
+
+	for user, auth := range map[string]string{"export-user": sampleExportUserAuth, "import-user": sampleImportUserAuth} {
+		createProfileResp := vcwalletController.CreateProfile(&models.RequestEnvelope{Payload: []byte(auth)})
+		require.NotNil(t, createProfileResp)
+		require.Nil(t, createProfileResp.Error)
+
+		openResp := vcwalletController.Open(&models.RequestEnvelope{Payload: []byte(auth)})
+		require.NotNil(t, openResp)
+		require.Nil(t, openResp.Error)
+
+		var tokenResponse cmdvcwallet.UnlockWalletResponse
+		require.NoError(t, json.Unmarshal(openResp.Payload, &tokenResponse))
+
+		tokens[user] = tokenResponse.Token
+	}
+
+	defer func() {
+		for _, user := range []string{"export-user", "import-user"} {
+			vcwalletController.Close(&models.RequestEnvelope{Payload: []byte(fmt.Sprintf(`{"userID":"%s"}`, user))})
+		}
+	}()
+
+	addPayload := fmt.Sprintf(`{"userID":"export-user", "auth": "%s", "contentType":"credential", "content":%s}`, tokens["export-user"], sampleUDCVC)
+	addResp := vcwalletController.Add(&models.RequestEnvelope{Payload: []byte(addPayload)})
+	require.NotNil(t, addResp)
+	require.Nil(t, addResp.Error)
+
+	var exportResponse cmdvcwallet.ExportResponse
+
+	t.Run("export", func(t *testing.T) {
+		payload := fmt.Sprintf(`{"userID":"export-user", "auth": "%s", "passphrase": "fakepassphrase"}`, tokens["export-user"])
+		resp := vcwalletController.Export(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+
+		require.NoError(t, json.Unmarshal(resp.Payload, &exportResponse))
+		require.NotEmpty(t, exportResponse.Wallet)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		payload := fmt.Sprintf(`{"userID":"import-user", "auth": "%s", "passphrase": "fakepassphrase", "wallet": %s}`,
+			tokens["import-user"], exportResponse.Wallet)
+		resp := vcwalletController.Import(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+
+		getPayload := fmt.Sprintf(`{"userID":"import-user", "auth": "%s", "contentType": "credential", "contentID": "http://example.edu/credentials/1877"}`, tokens["import-user"])
+		getResp := vcwalletController.Get(&models.RequestEnvelope{Payload: []byte(getPayload)})
+		require.NotNil(t, getResp)
+		require.Nil(t, getResp.Error)
+	})
+
+	t.Run("export and import with invalid request", func(t *testing.T) {
+		resp := vcwalletController.Export(&models.RequestEnvelope{Payload: []byte("--")})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+
+		resp = vcwalletController.Import(&models.RequestEnvelope{Payload: []byte("--")})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+
+		payload := fmt.Sprintf(`{"userID":"import-user", "auth": "%s", "passphrase": "invalid", "wallet": %s}`,
+			tokens["import-user"], exportResponse.Wallet)
+		resp = vcwalletController.Import(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+	})
+}
+
+func TestVCWallet_DIDManagement(t *testing.T) {
+	vcwalletController := getVCWalletController(t)
+	require.NotNil(t, vcwalletController)
+
+	const sampleDIDUserAuth = `{"userID":"did-user", "localKMSPassphrase": "fakepassphrase"}`
+
+	createProfileResp := vcwalletController.CreateProfile(&models.RequestEnvelope{Payload: []byte(sampleDIDUserAuth)})
+	require.NotNil(t, createProfileResp)
+	require.Nil(t, createProfileResp.Error)
+
+	openResp := vcwalletController.Open(&models.RequestEnvelope{Payload: []byte(sampleDIDUserAuth)})
+	require.NotNil(t, openResp)
+	require.Nil(t, openResp.Error)
+
+	var tokenResponse cmdvcwallet.UnlockWalletResponse
+	require.NoError(t, json.Unmarshal(openResp.Payload, &tokenResponse))
+
+	defer vcwalletController.Close(&models.RequestEnvelope{Payload: []byte(`{"userID":"did-user"}`)})
+
+	var didID string
+
+	t.Run("create, list and set default DID", func(t *testing.T) {
+		payload := fmt.Sprintf(`{"userID":"did-user", "auth": "%s", "method": "key"}`, tokenResponse.Token)
+		resp := vcwalletController.CreateDID(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+
+		var createResponse cmdvcwallet.CreateDIDResponse
+		require.NoError(t, json.Unmarshal(resp.Payload, &createResponse))
+		require.NotEmpty(t, createResponse.DIDResolution)
+
+		payload = fmt.Sprintf(`{"userID":"did-user", "auth": "%s"}`, tokenResponse.Token)
+		resp = vcwalletController.ListDIDs(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+
+		var listResponse cmdvcwallet.ListDIDsResponse
+		require.NoError(t, json.Unmarshal(resp.Payload, &listResponse))
+		require.Len(t, listResponse.DIDs, 1)
+
+		didID = listResponse.DIDs[0].ID
+
+		payload = fmt.Sprintf(`{"userID":"did-user", "auth": "%s", "did": "%s"}`, tokenResponse.Token, didID)
+		resp = vcwalletController.SetDefaultDID(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+	})
+
+	t.Run("update and deactivate DID not supported by DID method", func(t *testing.T) {
+		payload := fmt.Sprintf(`{"userID":"did-user", "auth": "%s", "did": "%s"}`, tokenResponse.Token, didID)
+
+		resp := vcwalletController.UpdateDID(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+		require.Contains(t, resp.Error.Message, "not supported")
+
+		resp = vcwalletController.DeactivateDID(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+		require.Contains(t, resp.Error.Message, "not supported")
+	})
+
+	t.Run("DID management with invalid request", func(t *testing.T) {
+		for _, fn := range []func(*models.RequestEnvelope) *models.ResponseEnvelope{
+			vcwalletController.CreateDID, vcwalletController.ListDIDs, vcwalletController.SetDefaultDID,
+			vcwalletController.UpdateDID, vcwalletController.DeactivateDID,
+		} {
+			resp := fn(&models.RequestEnvelope{Payload: []byte("--")})
+			require.NotNil(t, resp)
+			require.NotNil(t, resp.Error)
+		}
+	})
+}
+
+func TestVCWallet_SessionManagement(t *testing.T) {
+	vcwalletController := getVCWalletController(t)
+	require.NotNil(t, vcwalletController)
+
+	const sampleSessionUserAuth = `{"userID":"session-user", "localKMSPassphrase": "fakepassphrase"}`
+
+	createProfileResp := vcwalletController.CreateProfile(&models.RequestEnvelope{Payload: []byte(sampleSessionUserAuth)})
+	require.NotNil(t, createProfileResp)
+	require.Nil(t, createProfileResp.Error)
+
+	openResp := vcwalletController.Open(&models.RequestEnvelope{
+		Payload: []byte(`{"userID":"session-user", "localKMSPassphrase": "fakepassphrase", "lockOnBackground": true}`),
+	})
+	require.NotNil(t, openResp)
+	require.Nil(t, openResp.Error)
+
+	var tokenResponse cmdvcwallet.UnlockWalletResponse
+	require.NoError(t, json.Unmarshal(openResp.Payload, &tokenResponse))
+
+	defer vcwalletController.Close(&models.RequestEnvelope{Payload: []byte(`{"userID":"session-user"}`)})
+
+	t.Run("list, refresh, lock on background and revoke sessions", func(t *testing.T) {
+		resp := vcwalletController.ListSessions(&models.RequestEnvelope{Payload: []byte(`{"userID":"session-user"}`)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+
+		var listResponse cmdvcwallet.ListSessionsResponse
+		require.NoError(t, json.Unmarshal(resp.Payload, &listResponse))
+		require.Len(t, listResponse.Sessions, 1)
+		require.True(t, listResponse.Sessions[0].LockOnBackground)
+
+		payload := fmt.Sprintf(`{"userID":"session-user", "auth": "%s"}`, tokenResponse.Token)
+		resp = vcwalletController.RefreshSession(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+
+		var refreshResponse cmdvcwallet.RefreshSessionResponse
+		require.NoError(t, json.Unmarshal(resp.Payload, &refreshResponse))
+		require.NotEqual(t, tokenResponse.Token, refreshResponse.Token)
+
+		resp = vcwalletController.LockBackgroundSessions(&models.RequestEnvelope{})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+
+		var lockResponse cmdvcwallet.LockBackgroundSessionsResponse
+		require.NoError(t, json.Unmarshal(resp.Payload, &lockResponse))
+		require.Equal(t, 1, lockResponse.Locked)
+
+		payload = fmt.Sprintf(`{"userID":"session-user", "sessionID": "%s"}`, listResponse.Sessions[0].ID)
+		resp = vcwalletController.RevokeSession(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+		require.Contains(t, resp.Error.Message, "wallet session not found")
+	})
+
+	t.Run("session management with invalid request", func(t *testing.T) {
+		for _, fn := range []func(*models.RequestEnvelope) *models.ResponseEnvelope{
+			vcwalletController.ListSessions, vcwalletController.RefreshSession, vcwalletController.RevokeSession,
+		} {
+			resp := fn(&models.RequestEnvelope{Payload: []byte("--")})
+			require.NotNil(t, resp)
+			require.NotNil(t, resp.Error)
+		}
+	})
+}
+
+func TestVCWallet_Search(t *testing.T) {
+	vcwalletController := getVCWalletController(t)
+	require.NotNil(t, vcwalletController)
+
+	const sampleSearchUserAuth = `{"userID":"search-user", "localKMSPassphrase": "fakepassphrase"}`
+
+	createProfileResp := vcwalletController.CreateProfile(&models.RequestEnvelope{Payload: []byte(sampleSearchUserAuth)})
+	require.NotNil(t, createProfileResp)
+	require.Nil(t, createProfileResp.Error)
+
+	openResp := vcwalletController.Open(&models.RequestEnvelope{Payload: []byte(sampleSearchUserAuth)})
+	require.NotNil(t, openResp)
+	require.Nil(t, openResp.Error)
+
+	var tokenResponse cmdvcwallet.UnlockWalletResponse
+	require.NoError(t, json.Unmarshal(openResp.Payload, &tokenResponse))
+
+	defer vcwalletController.Close(&models.RequestEnvelope{Payload: []byte(`{"userID":"search-user"}`)})
+
+	addPayload := fmt.Sprintf(`{"userID":"search-user", "auth": "%s", "contentType":"credential", "content":%s}`,
+		tokenResponse.Token, sampleUDCVC)
+	addResp := vcwalletController.Add(&models.RequestEnvelope{Payload: []byte(addPayload)})
+	require.NotNil(t, addResp)
+	require.Nil(t, addResp.Error)
+
+	t.Run("search wallet contents", func(t *testing.T) {
+		payload := fmt.Sprintf(`{"userID":"search-user", "auth": "%s", "contentType":"credential",
+			"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f", "sortBy": "issuanceDate", "limit": 10}`,
+			tokenResponse.Token)
+
+		resp := vcwalletController.Search(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+
+		var searchResponse cmdvcwallet.SearchResponse
+		require.NoError(t, json.Unmarshal(resp.Payload, &searchResponse))
+		require.Equal(t, 1, searchResponse.Total)
+		require.Equal(t, "http://example.edu/credentials/1877", searchResponse.Contents[0].ID)
+	})
+
+	t.Run("search wallet contents failures", func(t *testing.T) {
+		resp := vcwalletController.Search(&models.RequestEnvelope{Payload: []byte("--")})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+
+		resp = vcwalletController.Search(&models.RequestEnvelope{
+			Payload: []byte(`{"userID":"search-user", "auth": "invalid", "contentType":"credential"}`),
+		})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+		require.Contains(t, resp.Error.Message, "invalid auth token")
+	})
+}
+
+func TestVCWallet_PresentationRequest(t *testing.T) {
+	vcwalletController := getVCWalletController(t)
+	require.NotNil(t, vcwalletController)
+
+	const (
+		sampleVPRUserAuth = `{"userID":"vpr-user", "localKMSPassphrase": "fakepassphrase"}`
+		sampleVPR         = `{"query": {"type": "DIDAuth"}, "challenge": "sample-challenge"}`
+	)
+
+	createProfileResp := vcwalletController.CreateProfile(&models.RequestEnvelope{Payload: []byte(sampleVPRUserAuth)})
+	require.NotNil(t, createProfileResp)
+	require.Nil(t, createProfileResp.Error)
+
+	openResp := vcwalletController.Open(&models.RequestEnvelope{Payload: []byte(sampleVPRUserAuth)})
+	require.NotNil(t, openResp)
+	require.Nil(t, openResp.Error)
+
+	var tokenResponse cmdvcwallet.UnlockWalletResponse
+	require.NoError(t, json.Unmarshal(openResp.Payload, &tokenResponse))
+
+	defer vcwalletController.Close(&models.RequestEnvelope{Payload: []byte(`{"userID":"vpr-user"}`)})
+
+	t.Run("create presentation response failures", func(t *testing.T) {
+		resp := vcwalletController.CreatePresentationResponse(&models.RequestEnvelope{Payload: []byte("--")})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+
+		resp = vcwalletController.CreatePresentationResponse(&models.RequestEnvelope{
+			Payload: []byte(fmt.Sprintf(`{"userID":"vpr-user", "auth": "%s", "presentationRequest": {}}`,
+				tokenResponse.Token)),
+		})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+		require.Contains(t, resp.Error.Message, "query")
+
+		resp = vcwalletController.CreatePresentationResponse(&models.RequestEnvelope{
+			Payload: []byte(fmt.Sprintf(`{"userID":"vpr-user", "auth": "invalid", "presentationRequest": %s}`,
+				sampleVPR)),
+		})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+		require.Contains(t, resp.Error.Message, "invalid auth token")
+	})
+
+	t.Run("respond presentation request failures", func(t *testing.T) {
+		resp := vcwalletController.RespondPresentationRequest(&models.RequestEnvelope{Payload: []byte("--")})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+
+		resp = vcwalletController.RespondPresentationRequest(&models.RequestEnvelope{
+			Payload: []byte(fmt.Sprintf(`{"userID":"vpr-user", "auth": "%s", "presentationRequest": %s}`,
+				tokenResponse.Token, sampleVPR)),
+		})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+		require.Contains(t, resp.Error.Message, "interact service")
+	})
+}
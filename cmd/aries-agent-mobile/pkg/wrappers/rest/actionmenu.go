@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	cmdactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+)
+
+// ActionMenu contains necessary fields for each of its operations.
+type ActionMenu struct {
+	httpClient httpClient
+	endpoints  map[string]*endpoint
+
+	URL   string
+	Token string
+}
+
+// SendMenu publishes a menu to the connection via HTTP.
+func (am *ActionMenu) SendMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.SendMenu)
+}
+
+// RequestMenu requests the current menu of the connection via HTTP.
+func (am *ActionMenu) RequestMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.RequestMenu)
+}
+
+// Perform performs an option of the menu received from the connection via HTTP.
+func (am *ActionMenu) Perform(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.Perform)
+}
+
+// GetMenu returns the last menu received from the connection via HTTP.
+func (am *ActionMenu) GetMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.GetMenu)
+}
+
+func (am *ActionMenu) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
+	return exec(&restOperation{
+		url:        am.URL,
+		token:      am.Token,
+		httpClient: am.httpClient,
+		endpoint:   am.endpoints[endpoint],
+		request:    request,
+	})
+}
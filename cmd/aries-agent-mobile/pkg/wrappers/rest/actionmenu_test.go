@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	opactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/rest/actionmenu"
+)
+
+const actionMenuDIDs = `"my_did":"did:example:alice","their_did":"did:example:bob"`
+
+func getActionMenuController(t *testing.T) *ActionMenu {
+	a, err := getAgent()
+	require.NoError(t, err)
+	require.NotNil(t, a)
+
+	amc, err := a.GetActionMenuController()
+	require.NoError(t, err)
+	require.NotNil(t, amc)
+
+	am, ok := amc.(*ActionMenu)
+	require.Equal(t, ok, true)
+
+	return am
+}
+
+func TestActionMenu_SendMenu(t *testing.T) {
+	t.Run("test it performs a send menu request", func(t *testing.T) {
+		am := getActionMenuController(t)
+
+		am.httpClient = &mockHTTPClient{data: ``, method: http.MethodPost, url: mockAgentURL + opactionmenu.SendMenu}
+
+		resp := am.SendMenu(&models.RequestEnvelope{Payload: []byte(`{` + actionMenuDIDs +
+			`,"menu":{"title":"Services","options":[{"name":"balance","title":"Check balance"}]}}`)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+	})
+}
+
+func TestActionMenu_RequestMenu(t *testing.T) {
+	t.Run("test it performs a request menu request", func(t *testing.T) {
+		am := getActionMenuController(t)
+
+		am.httpClient = &mockHTTPClient{data: ``, method: http.MethodPost, url: mockAgentURL + opactionmenu.RequestMenu}
+
+		resp := am.RequestMenu(&models.RequestEnvelope{Payload: []byte(`{` + actionMenuDIDs + `}`)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+	})
+}
+
+func TestActionMenu_Perform(t *testing.T) {
+	t.Run("test it performs a perform request", func(t *testing.T) {
+		am := getActionMenuController(t)
+
+		am.httpClient = &mockHTTPClient{data: ``, method: http.MethodPost, url: mockAgentURL + opactionmenu.Perform}
+
+		resp := am.Perform(&models.RequestEnvelope{Payload: []byte(`{` + actionMenuDIDs +
+			`,"perform":{"name":"balance","params":{"account":"123"}}}`)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+	})
+}
+
+func TestActionMenu_GetMenu(t *testing.T) {
+	t.Run("test it performs a get menu request", func(t *testing.T) {
+		am := getActionMenuController(t)
+
+		mockResponse := `{"menu":{"title":"Services","options":[{"name":"balance"}]}}`
+
+		am.httpClient = &mockHTTPClient{data: mockResponse, method: http.MethodPost, url: mockAgentURL + opactionmenu.GetMenu}
+
+		resp := am.GetMenu(&models.RequestEnvelope{Payload: []byte(`{` + actionMenuDIDs + `}`)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
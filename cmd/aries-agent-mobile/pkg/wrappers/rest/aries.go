@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/api"
 	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/config"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/actionmenu"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
@@ -179,6 +180,16 @@ func (ar *Aries) GetIntroduceController() (api.IntroduceController, error) {
 	return &Introduce{endpoints: endpoints, URL: ar.URL, Token: ar.Token, httpClient: &http.Client{}}, nil
 }
 
+// GetActionMenuController returns an ActionMenu instance.
+func (ar *Aries) GetActionMenuController() (api.ActionMenuController, error) {
+	endpoints, ok := ar.endpoints[actionmenu.OperationID]
+	if !ok {
+		return nil, fmt.Errorf("no endpoints found for controller [%s]", actionmenu.OperationID)
+	}
+
+	return &ActionMenu{endpoints: endpoints, URL: ar.URL, Token: ar.Token, httpClient: &http.Client{}}, nil
+}
+
 // GetVerifiableController returns an Verifiable instance.
 func (ar *Aries) GetVerifiableController() (api.VerifiableController, error) {
 	endpoints, ok := ar.endpoints[verifiable.VerifiableOperationID]
@@ -9,6 +9,7 @@ package rest
 import (
 	"net/http"
 
+	cmdactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
 	cmddidexch "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
 	cmdintroduce "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	cmdisscred "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
@@ -21,6 +22,7 @@ import (
 	cmdvcwallet "github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
 	cmdvdr "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	cmdverifiable "github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	opactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/rest/actionmenu"
 	opdidexch "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
 	opintroduce "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	opisscred "github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
@@ -45,6 +47,7 @@ func getControllerEndpoints() map[string]map[string]*endpoint {
 	allEndpoints := make(map[string]map[string]*endpoint)
 
 	allEndpoints[opintroduce.OperationID] = getIntroduceEndpoints()
+	allEndpoints[opactionmenu.OperationID] = getActionMenuEndpoints()
 	allEndpoints[opverifiable.VerifiableOperationID] = getVerifiableEndpoints()
 	allEndpoints[opdidexch.OperationID] = getDIDExchangeEndpoints()
 	allEndpoints[opisscred.OperationID] = getIssueCredentialEndpoints()
@@ -109,6 +112,27 @@ func getIntroduceEndpoints() map[string]*endpoint {
 	}
 }
 
+func getActionMenuEndpoints() map[string]*endpoint {
+	return map[string]*endpoint{
+		cmdactionmenu.SendMenu: {
+			Path:   opactionmenu.SendMenu,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.RequestMenu: {
+			Path:   opactionmenu.RequestMenu,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.Perform: {
+			Path:   opactionmenu.Perform,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.GetMenu: {
+			Path:   opactionmenu.GetMenu,
+			Method: http.MethodPost,
+		},
+	}
+}
+
 func getVerifiableEndpoints() map[string]*endpoint {
 	return map[string]*endpoint{
 		cmdverifiable.ValidateCredentialCommandMethod: {
@@ -503,12 +527,21 @@ func getVCWalletEndpoints() map[string]*endpoint {
 		cmdvcwallet.QueryMethod: {
 			Path: opvcwallet.QueryPath, Method: http.MethodPost,
 		},
+		cmdvcwallet.SearchMethod: {
+			Path: opvcwallet.SearchPath, Method: http.MethodPost,
+		},
 		cmdvcwallet.IssueMethod: {
 			Path: opvcwallet.IssuePath, Method: http.MethodPost,
 		},
 		cmdvcwallet.ProveMethod: {
 			Path: opvcwallet.ProvePath, Method: http.MethodPost,
 		},
+		cmdvcwallet.CreateVPRResponseMethod: {
+			Path: opvcwallet.CreateVPRResponsePath, Method: http.MethodPost,
+		},
+		cmdvcwallet.RespondVPRMethod: {
+			Path: opvcwallet.RespondVPRPath, Method: http.MethodPost,
+		},
 		cmdvcwallet.VerifyMethod: {
 			Path: opvcwallet.VerifyPath, Method: http.MethodPost,
 		},
@@ -527,5 +560,38 @@ func getVCWalletEndpoints() map[string]*endpoint {
 		cmdvcwallet.PresentProofMethod: {
 			Path: opvcwallet.PresentProofPath, Method: http.MethodPost,
 		},
+		cmdvcwallet.ExportMethod: {
+			Path: opvcwallet.ExportPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.ImportMethod: {
+			Path: opvcwallet.ImportPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.CreateDIDMethod: {
+			Path: opvcwallet.CreateDIDPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.ListDIDsMethod: {
+			Path: opvcwallet.ListDIDsPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.SetDefaultDIDMethod: {
+			Path: opvcwallet.SetDefaultDIDPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.UpdateDIDMethod: {
+			Path: opvcwallet.UpdateDIDPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.DeactivateDIDMethod: {
+			Path: opvcwallet.DeactivateDIDPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.ListSessionsMethod: {
+			Path: opvcwallet.ListSessionsPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.RefreshSessionMethod: {
+			Path: opvcwallet.RefreshSessionPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.RevokeSessionMethod: {
+			Path: opvcwallet.RevokeSessionPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.LockBackgroundMethod: {
+			Path: opvcwallet.LockBackgroundPath, Method: http.MethodPost,
+		},
 	}
 }
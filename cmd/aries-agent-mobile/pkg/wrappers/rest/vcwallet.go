@@ -70,6 +70,11 @@ func (wallet *VCWallet) Query(request *models.RequestEnvelope) *models.ResponseE
 	return wallet.createRespEnvelope(request, cmdvcwallet.QueryMethod)
 }
 
+// Search searches wallet contents by field, tag, date range and free text filters.
+func (wallet *VCWallet) Search(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.SearchMethod)
+}
+
 // Issue adds proof to a Verifiable Credential from wallet.
 func (wallet *VCWallet) Issue(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return wallet.createRespEnvelope(request, cmdvcwallet.IssueMethod)
@@ -80,6 +85,18 @@ func (wallet *VCWallet) Prove(request *models.RequestEnvelope) *models.ResponseE
 	return wallet.createRespEnvelope(request, cmdvcwallet.ProveMethod)
 }
 
+// CreatePresentationResponse produces a Verifiable Presentation from wallet in response to a
+// Verifiable Presentation Request.
+func (wallet *VCWallet) CreatePresentationResponse(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.CreateVPRResponseMethod)
+}
+
+// RespondPresentationRequest responds to a Verifiable Presentation Request by sending presentation
+// from wallet to interact service of the request.
+func (wallet *VCWallet) RespondPresentationRequest(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.RespondVPRMethod)
+}
+
 // Verify verifies credential/presentation from wallet.
 func (wallet *VCWallet) Verify(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return wallet.createRespEnvelope(request, cmdvcwallet.VerifyMethod)
@@ -111,6 +128,61 @@ func (wallet *VCWallet) PresentProof(request *models.RequestEnvelope) *models.Re
 	return wallet.createRespEnvelope(request, cmdvcwallet.PresentProofMethod)
 }
 
+// Export exports all wallet contents and key material as an encrypted wallet.
+func (wallet *VCWallet) Export(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.ExportMethod)
+}
+
+// Import imports contents of an encrypted wallet into wallet.
+func (wallet *VCWallet) Import(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.ImportMethod)
+}
+
+// CreateDID creates a new DID from wallet key, created DID is managed by wallet and can be used for signing.
+func (wallet *VCWallet) CreateDID(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.CreateDIDMethod)
+}
+
+// ListDIDs lists all DIDs managed by wallet.
+func (wallet *VCWallet) ListDIDs(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.ListDIDsMethod)
+}
+
+// SetDefaultDID sets default wallet DID for signing.
+func (wallet *VCWallet) SetDefaultDID(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.SetDefaultDIDMethod)
+}
+
+// UpdateDID updates DID managed by wallet.
+func (wallet *VCWallet) UpdateDID(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.UpdateDIDMethod)
+}
+
+// DeactivateDID deactivates DID managed by wallet.
+func (wallet *VCWallet) DeactivateDID(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.DeactivateDIDMethod)
+}
+
+// ListSessions lists all active sessions of wallet.
+func (wallet *VCWallet) ListSessions(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.ListSessionsMethod)
+}
+
+// RefreshSession replaces wallet session token with a new token.
+func (wallet *VCWallet) RefreshSession(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.RefreshSessionMethod)
+}
+
+// RevokeSession revokes wallet session by session ID.
+func (wallet *VCWallet) RevokeSession(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.RevokeSessionMethod)
+}
+
+// LockBackgroundSessions locks all wallet sessions unlocked with 'lockOnBackground' option.
+func (wallet *VCWallet) LockBackgroundSessions(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.LockBackgroundMethod)
+}
+
 func (wallet *VCWallet) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
 	return exec(&restOperation{
 		url:        wallet.URL,
@@ -7,10 +7,13 @@ package startcmd
 
 import (
 	"crypto/subtle"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -25,6 +28,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcapi"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
@@ -34,6 +39,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/web"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -195,6 +201,28 @@ const (
 		" Alternatively, this can be set with the following environment variable (in CSV format): " +
 		agentMediaTypeProfilesEnvKey
 
+	// did:web hosting flag.
+	agentDIDWebHostingFlagName  = "did-web-hosting"
+	agentDIDWebHostingEnvKey    = "ARIESD_DID_WEB_HOSTING"
+	agentDIDWebHostingFlagUsage = "Enables creation of did:web DIDs through the VDR API." +
+		" Hosted DID documents are served at /.well-known/did.json and /<path>/did.json of the api host." +
+		" Possible values [true] [false]. Defaults to false if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentDIDWebHostingEnvKey
+
+	// did:web document directory flag.
+	agentDIDWebDirFlagName  = "did-web-dir"
+	agentDIDWebDirEnvKey    = "ARIESD_DID_WEB_DIR"
+	agentDIDWebDirFlagUsage = "Directory hosted did:web DID documents are written to, enables did:web hosting." +
+		" Alternatively, this can be set with the following environment variable: " + agentDIDWebDirEnvKey
+
+	// VC-API configuration flag.
+	agentVCAPIConfigFlagName  = "vc-api-config"
+	agentVCAPIConfigEnvKey    = "ARIESD_VC_API_CONFIG"
+	agentVCAPIConfigFlagUsage = "Path to JSON file configuring VC-API endpoints: base URL of the agent," +
+		" issuer DID, proof type and status list of issuance, holder DID and proof type of proving, and exchanges." +
+		" Issuance, proving and exchanges are disabled if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentVCAPIConfigEnvKey
+
 	httpProtocol      = "http"
 	websocketProtocol = "ws"
 
@@ -238,6 +266,9 @@ type agentParameters struct {
 	msgHandler                                     command.MessageHandler
 	dbParam                                        *dbParam
 	autoExecuteRFC0593                             bool
+	didWebHosting                                  bool
+	didWebDir                                      string
+	vcAPIConfig                                    *vcapi.Config
 }
 
 type dbParam struct {
@@ -391,6 +422,16 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 				return err
 			}
 
+			didWebHosting, didWebDir, err := getDIDWebHosting(cmd)
+			if err != nil {
+				return err
+			}
+
+			vcAPIConfig, err := getVCAPIConfig(cmd)
+			if err != nil {
+				return err
+			}
+
 			parameters := &agentParameters{
 				server:               server,
 				host:                 host,
@@ -411,6 +452,9 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 				keyType:              keyType,
 				keyAgreementType:     keyAgreementType,
 				mediaTypeProfiles:    mediaTypeProfiles,
+				didWebHosting:        didWebHosting,
+				didWebDir:            didWebDir,
+				vcAPIConfig:          vcAPIConfig,
 			}
 
 			return startAgent(parameters)
@@ -418,6 +462,26 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 	}
 }
 
+func getVCAPIConfig(cmd *cobra.Command) (*vcapi.Config, error) {
+	configFile, err := getUserSetVar(cmd, agentVCAPIConfigFlagName, agentVCAPIConfigEnvKey, true)
+	if err != nil || configFile == "" {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(configFile))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", agentVCAPIConfigFlagName, err)
+	}
+
+	config := &vcapi.Config{}
+
+	if err = json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", agentVCAPIConfigFlagName, err)
+	}
+
+	return config, nil
+}
+
 func getDBParam(cmd *cobra.Command) (*dbParam, error) {
 	dbParam := &dbParam{}
 
@@ -479,6 +543,29 @@ func getAutoExecuteRFC0593(cmd *cobra.Command) (bool, error) {
 	return strconv.ParseBool(autoExecuteRFC0593Str)
 }
 
+func getDIDWebHosting(cmd *cobra.Command) (bool, string, error) {
+	didWebDir, err := getUserSetVar(cmd, agentDIDWebDirFlagName, agentDIDWebDirEnvKey, true)
+	if err != nil {
+		return false, "", err
+	}
+
+	didWebHostingStr, err := getUserSetVar(cmd, agentDIDWebHostingFlagName, agentDIDWebHostingEnvKey, true)
+	if err != nil {
+		return false, "", err
+	}
+
+	if didWebHostingStr == "" {
+		return didWebDir != "", didWebDir, nil
+	}
+
+	didWebHosting, err := strconv.ParseBool(didWebHostingStr)
+	if err != nil {
+		return false, "", fmt.Errorf("invalid %s value: %w", agentDIDWebHostingFlagName, err)
+	}
+
+	return didWebHosting || didWebDir != "", didWebDir, nil
+}
+
 //nolint:funlen
 func createFlags(startCmd *cobra.Command) {
 	// agent host flag
@@ -530,6 +617,13 @@ func createFlags(startCmd *cobra.Command) {
 
 	startCmd.Flags().StringP(agentAutoExecuteRFC0593FlagName, "", "", agentAutoExecuteRFC0593FlagUsage)
 
+	// did:web hosting flags
+	startCmd.Flags().StringP(agentDIDWebHostingFlagName, "", "", agentDIDWebHostingFlagUsage)
+	startCmd.Flags().StringP(agentDIDWebDirFlagName, "", "", agentDIDWebDirFlagUsage)
+
+	// VC-API configuration flag
+	startCmd.Flags().StringP(agentVCAPIConfigFlagName, "", "", agentVCAPIConfigFlagUsage)
+
 	// tls cert file
 	startCmd.Flags().StringP(agentTLSCertFileFlagName,
 		agentTLSCertFileFlagShorthand, "", agentTLSCertFileFlagUsage)
@@ -733,6 +827,10 @@ func authorizationMiddleware(token string) mux.MiddlewareFunc {
 	return middleware
 }
 
+func isDIDWebDocumentRequest(r *http.Request, _ *mux.RouteMatch) bool {
+	return r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/did.json")
+}
+
 func startAgent(parameters *agentParameters) error {
 	if parameters.host == "" {
 		return errMissingHost
@@ -750,7 +848,8 @@ func startAgent(parameters *agentParameters) error {
 	handlers, err := controller.GetRESTHandlers(ctx, controller.WithWebhookURLs(parameters.webhookURLs...),
 		controller.WithDefaultLabel(parameters.defaultLabel), controller.WithAutoAccept(parameters.autoAccept),
 		controller.WithMessageHandler(parameters.msgHandler),
-		controller.WithAutoExecuteRFC0593(parameters.autoExecuteRFC0593))
+		controller.WithAutoExecuteRFC0593(parameters.autoExecuteRFC0593),
+		controller.WithVCAPIConfiguration(parameters.vcAPIConfig))
 	if err != nil {
 		return fmt.Errorf("failed to start aries agent rest on port [%s], failed to get rest service api :  %w",
 			parameters.host, err)
@@ -758,12 +857,34 @@ func startAgent(parameters *agentParameters) error {
 
 	router := mux.NewRouter()
 
+	// hosted did:web documents are public, so they are served without authorization
+	if parameters.didWebHosting {
+		didWebHandler, e := web.NewHandler(ctx.StorageProvider())
+		if e != nil {
+			return fmt.Errorf("failed to start aries agent rest on port [%s], failed to create did:web handler : %w",
+				parameters.host, e)
+		}
+
+		router.MatcherFunc(isDIDWebDocumentRequest).Handler(didWebHandler)
+	}
+
+	// endpoints called by other parties (e.g. VC-API exchanges) are served without authorization too
+	for _, handler := range handlers {
+		if rest.IsPublic(handler) {
+			router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+		}
+	}
+
+	apiRouter := router.NewRoute().Subrouter()
+
 	if parameters.token != "" {
-		router.Use(authorizationMiddleware(parameters.token))
+		apiRouter.Use(authorizationMiddleware(parameters.token))
 	}
 
 	for _, handler := range handlers {
-		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+		if !rest.IsPublic(handler) {
+			apiRouter.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+		}
 	}
 
 	logger.Infof("Starting aries agent rest on host [%s]", parameters.host)
@@ -840,6 +961,10 @@ func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
 		opts = append(opts, aries.WithMediaTypeProfiles(parameters.mediaTypeProfiles))
 	}
 
+	if parameters.didWebHosting {
+		opts = append(opts, aries.WithDIDWebHosting(web.WithDocumentDir(parameters.didWebDir)))
+	}
+
 	framework, err := aries.New(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to initialize framework :  %w",
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package startcmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -896,6 +898,215 @@ func TestStartCmdInvalidAutoExecuteRFC0593Value(t *testing.T) {
 	require.Contains(t, err.Error(), "invalid syntax")
 }
 
+func TestStartCmdInvalidDIDWebHostingValue(t *testing.T) {
+	startCmd, err := Cmd(&mockServer{})
+	require.NoError(t, err)
+
+	args := []string{
+		"--" + agentHostFlagName,
+		randomURL(),
+		"--" + agentInboundHostFlagName,
+		httpProtocol + "@" + randomURL(),
+		"--" + databaseTypeFlagName,
+		databaseTypeMemOption,
+		"--" + agentWebhookFlagName,
+		"",
+		"--" + agentDIDWebHostingFlagName,
+		"INVALID",
+	}
+	startCmd.SetArgs(args)
+
+	err = startCmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid did-web-hosting value")
+}
+
+func TestStartAriesWithDIDWebHosting(t *testing.T) {
+	const token = "ABCD"
+
+	testHostURL := randomURL()
+	testInboundHostURL := randomURL()
+	dir := t.TempDir()
+
+	go func() {
+		parameters := &agentParameters{
+			server:               &HTTPServer{},
+			host:                 testHostURL,
+			token:                token,
+			inboundHostInternals: []string{httpProtocol + "@" + testInboundHostURL},
+			dbParam:              &dbParam{dbType: databaseTypeMemOption},
+			didWebHosting:        true,
+			didWebDir:            dir,
+		}
+
+		err := startAgent(parameters)
+		require.NoError(t, err)
+		require.FailNow(t, agentUnexpectedExitErrMsg+": "+err.Error())
+	}()
+
+	waitForServerToStart(t, testHostURL, testInboundHostURL)
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s/vdr/did/create", testHostURL),
+		bytes.NewBufferString(`{"method":"web","opts":{"domain":"example.com","path":"issuer"}}`))
+	require.NoError(t, err)
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.FileExists(t, filepath.Join(dir, "issuer", "did.json"))
+
+	// hosted DID documents are served without authorization, for the host of the DID only
+	resp, err = http.Get(fmt.Sprintf("http://%s/issuer/did.json", testHostURL)) //nolint:noctx
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/issuer/did.json", testHostURL), nil)
+	require.NoError(t, err)
+	req.Host = "example.com"
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, string(body), "did:web:example.com:issuer")
+
+	resp, err = http.Get(fmt.Sprintf("http://%s/connections", testHostURL)) //nolint:noctx
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestStartCmdInvalidVCAPIConfig(t *testing.T) {
+	invalidConfig := filepath.Join(t.TempDir(), "vc-api.json")
+	require.NoError(t, ioutil.WriteFile(invalidConfig, []byte("--"), 0o600))
+
+	for configFile, expectedErr := range map[string]string{
+		filepath.Join(t.TempDir(), "missing.json"): "read vc-api-config",
+		invalidConfig: "invalid vc-api-config",
+	} {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName,
+			randomURL(),
+			"--" + agentInboundHostFlagName,
+			httpProtocol + "@" + randomURL(),
+			"--" + databaseTypeFlagName,
+			databaseTypeMemOption,
+			"--" + agentWebhookFlagName,
+			"",
+			"--" + agentVCAPIConfigFlagName,
+			configFile,
+		})
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), expectedErr)
+	}
+}
+
+func TestStartAriesWithVCAPIConfig(t *testing.T) {
+	const token = "ABCD"
+
+	testHostURL := randomURL()
+	testInboundHostURL := randomURL()
+
+	configFile := filepath.Join(t.TempDir(), "vc-api.json")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte(`{
+		"baseURL": "https://agent.example.com",
+		"exchanges": {"login": {"query": [{"type": "DIDAuth"}]}}
+	}`), 0o600))
+
+	startCmd, err := Cmd(&mockServer{})
+	require.NoError(t, err)
+
+	require.NoError(t, startCmd.Flags().Set(agentVCAPIConfigFlagName, configFile))
+
+	vcAPIConfig, err := getVCAPIConfig(startCmd)
+	require.NoError(t, err)
+	require.Equal(t, "https://agent.example.com", vcAPIConfig.BaseURL)
+
+	go func() {
+		parameters := &agentParameters{
+			server:               &HTTPServer{},
+			host:                 testHostURL,
+			token:                token,
+			inboundHostInternals: []string{httpProtocol + "@" + testInboundHostURL},
+			dbParam:              &dbParam{dbType: databaseTypeMemOption},
+			vcAPIConfig:          vcAPIConfig,
+		}
+
+		err := startAgent(parameters)
+		require.NoError(t, err)
+		require.FailNow(t, agentUnexpectedExitErrMsg+": "+err.Error())
+	}()
+
+	waitForServerToStart(t, testHostURL, testInboundHostURL)
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s/exchanges/login", testHostURL), nil)
+	require.NoError(t, err)
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, string(body), "https://agent.example.com/exchanges/login/")
+
+	// exchanges are initiated by holders, without authorization
+	resp, err = http.Post(fmt.Sprintf("http://%s/exchanges/login", testHostURL), "", nil) //nolint:noctx
+	require.NoError(t, err)
+
+	var vpr struct {
+		VerifiablePresentationRequest struct {
+			Interact struct {
+				Service []struct {
+					ServiceEndpoint string `json:"serviceEndpoint"`
+				} `json:"service"`
+			} `json:"interact"`
+		} `json:"verifiablePresentationRequest"`
+	}
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&vpr))
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, vpr.VerifiablePresentationRequest.Interact.Service, 1)
+
+	transactionPath := strings.TrimPrefix(vpr.VerifiablePresentationRequest.Interact.Service[0].ServiceEndpoint,
+		"https://agent.example.com")
+
+	// exchange transactions hold presentations of holders, they are read with authorization only
+	resp, err = http.Get(fmt.Sprintf("http://%s%s", testHostURL, transactionPath)) //nolint:noctx
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s%s", testHostURL, transactionPath), nil)
+	require.NoError(t, err)
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Post(fmt.Sprintf("http://%s/credentials/issue", testHostURL), "", nil) //nolint:noctx
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
 func waitForServerToStart(t *testing.T, host, inboundHost string) {
 	if err := listenFor(host); err != nil {
 		t.Fatal(err)
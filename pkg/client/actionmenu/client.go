@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+)
+
+type (
+	// Menu is the menu of actions published by the responder.
+	Menu = actionmenu.Menu
+	// MenuOption is an action of the menu which can be performed by the requester.
+	MenuOption = actionmenu.MenuOption
+	// Form describes the parameters the requester has to provide to perform a menu option.
+	Form = actionmenu.Form
+	// FormParam is a field of a form.
+	FormParam = actionmenu.FormParam
+	// Perform is sent by the requester to perform a menu option.
+	Perform = actionmenu.Perform
+)
+
+// ErrMenuNotFound is returned when no menu was received from the connection.
+var ErrMenuNotFound = actionmenu.ErrMenuNotFound
+
+// Provider contains dependencies for the action-menu protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the action-menu service.
+type ProtocolService interface {
+	service.Event
+	SendMenu(menu *actionmenu.Menu, myDID, theirDID string) error
+	RequestMenu(myDID, theirDID string) error
+	Perform(perform *actionmenu.Perform, myDID, theirDID string) error
+	GetMenu(myDID, theirDID string) (*actionmenu.Menu, error)
+}
+
+// Client enable access to action-menu API.
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New return new instance of action-menu client.
+func New(ctx Provider) (*Client, error) {
+	svc, err := ctx.Service(actionmenu.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	menuSvc, ok := svc.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to Action Menu Service failed")
+	}
+
+	return &Client{
+		Event:   menuSvc,
+		service: menuSvc,
+	}, nil
+}
+
+// SendMenu publishes the menu to the connection.
+// The connection gets the menu again each time it requests the menu.
+func (c *Client) SendMenu(menu *Menu, myDID, theirDID string) error {
+	return c.service.SendMenu(menu, myDID, theirDID)
+}
+
+// RequestMenu requests the current menu of the connection.
+// The menu is delivered as a menu-received event and can be read with GetMenu.
+func (c *Client) RequestMenu(myDID, theirDID string) error {
+	return c.service.RequestMenu(myDID, theirDID)
+}
+
+// Perform performs the menu option of the menu received from the connection.
+// The responder gets the selection as a perform-received event.
+func (c *Client) Perform(perform *Perform, myDID, theirDID string) error {
+	return c.service.Perform(perform, myDID, theirDID)
+}
+
+// GetMenu returns the last menu received from the connection.
+func (c *Client) GetMenu(myDID, theirDID string) (*Menu, error) {
+	return c.service.GetMenu(myDID, theirDID)
+}
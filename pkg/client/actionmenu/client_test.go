@@ -0,0 +1,77 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockservice "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("get service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("test error")})
+		require.EqualError(t, err, "test error")
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: "not a service"})
+		require.EqualError(t, err, "cast service to Action Menu Service failed")
+	})
+}
+
+func TestClient(t *testing.T) {
+	var replied service.DIDCommMsgMap
+
+	svc, err := actionmenu.New(&protocol.MockProvider{
+		StoreProvider: mem.NewProvider(),
+		CustomMessenger: &mockservice.MockMessenger{
+			ReplyToMsgFunc: func(_, out service.DIDCommMsgMap, _, _ string) error {
+				replied = out
+
+				return nil
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	client, err := New(&mockprovider.Provider{ServiceValue: svc})
+	require.NoError(t, err)
+
+	require.NoError(t, client.SendMenu(&Menu{
+		Title:   "Services",
+		Options: []MenuOption{{Name: "balance", Title: "Check balance"}},
+	}, "Alice", "Bob"))
+
+	require.NoError(t, client.RequestMenu("Bob", "Alice"))
+
+	_, err = client.GetMenu("Bob", "Alice")
+	require.True(t, errors.Is(err, ErrMenuNotFound))
+
+	_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Menu{
+		Type:    actionmenu.MenuMsgType,
+		ID:      "menu-id",
+		Title:   "Services",
+		Options: []MenuOption{{Name: "balance", Title: "Check balance"}},
+	}), service.NewDIDCommContext("Bob", "Alice", nil))
+	require.NoError(t, err)
+
+	menu, err := client.GetMenu("Bob", "Alice")
+	require.NoError(t, err)
+	require.Equal(t, "balance", menu.Options[0].Name)
+
+	require.NoError(t, client.Perform(&Perform{Name: menu.Options[0].Name}, "Bob", "Alice"))
+	require.Equal(t, actionmenu.PerformMsgType, replied.Type())
+}
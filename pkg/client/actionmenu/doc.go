@@ -0,0 +1,25 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package actionmenu provides support for the Action Menu protocol (Aries RFC 0509).
+// The responder publishes a menu of actions to a connection, the requester displays it and
+// performs one of the options. The example below shows how to use the client.
+// 	client := actionmenu.New(...)
+// 	client.RegisterMsgEvent(events)
+// 	// responder
+// 	client.SendMenu(&actionmenu.Menu{Title: "Services", Options: ...}, myDID, theirDID)
+// 	// requester
+// 	client.RequestMenu(myDID, theirDID)
+// 	for event := range events {
+// 	  switch event.StateID {
+// 	  case "menu-received":
+// 	    menu, _ := client.GetMenu(myDID, theirDID)
+// 	    client.Perform(&actionmenu.Perform{Name: menu.Options[0].Name}, myDID, theirDID)
+// 	  case "perform-received":
+// 	    // responder handles the selection
+// 	  }
+// 	}
+package actionmenu
@@ -7,12 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package ld
 
 import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/remote"
 	"github.com/hyperledger/aries-framework-go/pkg/ld"
 	ldstore "github.com/hyperledger/aries-framework-go/pkg/store/ld"
 )
 
+var errContextBundlesNotSupported = errors.New("JSON-LD service doesn't support context bundles")
+
 // provider contains dependencies for the JSON-LD service.
 type provider interface {
 	JSONLDContextStore() ldstore.ContextStore
@@ -67,6 +72,26 @@ func (c *Client) RefreshAllRemoteProviders(opts ...remote.ProviderOpt) error {
 	return c.service.RefreshAllRemoteProviders(opts...)
 }
 
+// ImportContextBundle verifies the signed context bundle and imports its JSON-LD contexts as pinned contexts.
+func (c *Client) ImportContextBundle(bundle string, verifier jose.SignatureVerifier) error {
+	svc, ok := c.service.(ld.ContextBundleService)
+	if !ok {
+		return errContextBundlesNotSupported
+	}
+
+	return svc.ImportContextBundle(bundle, verifier)
+}
+
+// ExportContextBundle exports JSON-LD contexts with the given URLs as a context bundle signed by the issuer.
+func (c *Client) ExportContextBundle(urls []string, issuer string, signer jose.Signer) (string, error) {
+	svc, ok := c.service.(ld.ContextBundleService)
+	if !ok {
+		return "", errContextBundlesNotSupported
+	}
+
+	return svc.ExportContextBundle(urls, issuer, signer)
+}
+
 // Option configures the JSON-LD client.
 type Option func(c *Client)
 
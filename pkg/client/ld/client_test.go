@@ -99,6 +99,29 @@ func TestClient_RefreshAllRemoteProviders(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClient_ContextBundle(t *testing.T) {
+	t.Run("Export and import context bundle", func(t *testing.T) {
+		c := ld.NewClient(createMockProvider())
+
+		_, err := c.ExportContextBundle(nil, "did:example:issuer", nil)
+		require.EqualError(t, err, "export context bundle: context URLs are required")
+
+		err = c.ImportContextBundle("invalid", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "import context bundle")
+	})
+
+	t.Run("Context bundles not supported by the service", func(t *testing.T) {
+		c := createLDClient(t)
+
+		_, err := c.ExportContextBundle([]string{"https://example.com/context.jsonld"}, "did:example:issuer", nil)
+		require.EqualError(t, err, "JSON-LD service doesn't support context bundles")
+
+		err = c.ImportContextBundle("bundle", nil)
+		require.EqualError(t, err, "JSON-LD service doesn't support context bundles")
+	})
+}
+
 func createLDClient(t *testing.T) *ld.Client {
 	t.Helper()
 
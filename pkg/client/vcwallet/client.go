@@ -9,7 +9,6 @@ package vcwallet
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/piprate/json-gold/ld"
 
@@ -17,6 +16,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -144,11 +146,41 @@ func (c *Client) Close() bool {
 	return c.wallet.Close()
 }
 
+// Sessions returns all active sessions of this wallet.
+func (c *Client) Sessions() ([]*wallet.Session, error) {
+	return c.wallet.Sessions()
+}
+
+// RefreshSession replaces token issued to this VC wallet client with a new token.
+// Idle timer of the session will be reset, but absolute expiry of the session remains unchanged.
+func (c *Client) RefreshSession() error {
+	auth, err := c.auth()
+	if err != nil {
+		return err
+	}
+
+	authToken, err := c.wallet.RefreshSession(auth)
+	if err != nil {
+		return err
+	}
+
+	c.auth = func() (s string, e error) {
+		return authToken, nil
+	}
+
+	return nil
+}
+
+// RevokeSession revokes wallet session by session ID.
+func (c *Client) RevokeSession(sessionID string) error {
+	return c.wallet.RevokeSession(sessionID)
+}
+
 // Export produces a serialized exported wallet representation.
-// Only ciphertext wallet contents can be exported.
+// All wallet contents and the key material of keys added to wallet key manager are encrypted as JWE.
 //
 //	Args:
-//		- auth: token to be used to lock the wallet before exporting.
+//		- options: options for encrypting wallet contents, either passphrase or key manager key ID is required.
 //
 //	Returns exported locked wallet.
 //
@@ -158,18 +190,23 @@ func (c *Client) Close() bool {
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#DIDResolutionResponse
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
+//	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Client) Export(auth string) (json.RawMessage, error) {
-	// TODO to be added #2433
-	return nil, fmt.Errorf("to be implemented")
+func (c *Client) Export(options ...wallet.ExportOptions) (json.RawMessage, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.Export(auth, options...)
 }
 
 // Import Takes a serialized exported wallet representation as input
 // and imports all contents into wallet.
 //
 //	Args:
-//		- contents: wallet content to be imported.
-//		- auth: token used while exporting the wallet.
+//		- contents: exported wallet to be imported.
+//		- options: options for decrypting wallet contents and for handling contents already present in wallet.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -179,9 +216,13 @@ func (c *Client) Export(auth string) (json.RawMessage, error) {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Client) Import(auth string, contents json.RawMessage) error {
-	// TODO to be added #2433
-	return fmt.Errorf("to be implemented")
+func (c *Client) Import(contents json.RawMessage, options ...wallet.ImportOptions) error {
+	auth, err := c.auth()
+	if err != nil {
+		return err
+	}
+
+	return c.wallet.Import(auth, contents, options...)
 }
 
 // Add adds given data model to wallet contents store.
@@ -258,6 +299,17 @@ func (c *Client) GetAll(contentType wallet.ContentType, options ...wallet.GetAll
 	return c.wallet.GetAll(auth, contentType, options...)
 }
 
+// Search searches wallet contents of given type by field, tag, date range and free text filters.
+// Results can be sorted and paginated by using search options.
+func (c *Client) Search(contentType wallet.ContentType, options ...wallet.SearchOptions) (*wallet.SearchResult, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.Search(auth, contentType, options...)
+}
+
 // Query runs query against wallet credential contents and returns presentation containing credential results.
 //
 // https://w3c-ccg.github.io/universal-wallet-interop-spec/#query
@@ -276,6 +328,33 @@ func (c *Client) Query(params ...*wallet.QueryParams) ([]*verifiable.Presentatio
 	return c.wallet.Query(auth, params...)
 }
 
+// MatchPresentationDefinition evaluates wallet credentials against presentation definition and returns for each
+// submission requirement and input descriptor the candidate credentials, the checks they failed and their disclosure.
+//
+// https://identity.foundation/presentation-exchange
+//
+func (c *Client) MatchPresentationDefinition(
+	definition json.RawMessage) ([]*presexch.MatchSubmissionRequirement, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.MatchPresentationDefinition(auth, definition)
+}
+
+// PresentSelection creates presentation of the wallet credentials selected for input descriptors of presentation
+// definition, selection maps input descriptor ID to credential IDs. Resulting presentation can be signed by Prove.
+func (c *Client) PresentSelection(definition json.RawMessage,
+	selection map[string][]string) (*verifiable.Presentation, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.PresentSelection(auth, definition, selection)
+}
+
 // Issue adds proof to a Verifiable Credential.
 //
 //	Args:
@@ -308,6 +387,41 @@ func (c *Client) Prove(opts *wallet.ProofOptions, creds ...wallet.ProveOptions)
 	return c.wallet.Prove(auth, opts, creds...)
 }
 
+// CreatePresentationResponse produces a Verifiable Presentation in response to a Verifiable Presentation Request.
+//
+//	Args:
+//		- verifiable presentation request containing queries, challenge and domain.
+//		- proof options
+//
+func (c *Client) CreatePresentationResponse(request *wallet.VerifiablePresentationRequest,
+	opts *wallet.ProofOptions) (*verifiable.Presentation, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.CreatePresentationResponse(auth, request, opts)
+}
+
+// RespondPresentationRequest responds to a Verifiable Presentation Request by sending presentation to
+// interact service of the request.
+//
+//	Args:
+//		- verifiable presentation request containing queries, challenge, domain and interact services.
+//		- proof options
+//		- options for interacting with interact service.
+//
+// Returns: response of the interact service.
+func (c *Client) RespondPresentationRequest(request *wallet.VerifiablePresentationRequest, opts *wallet.ProofOptions,
+	options ...wallet.InteractOptions) (*wallet.InteractResponse, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.RespondPresentationRequest(auth, request, opts, options...)
+}
+
 // Verify takes Takes a Verifiable Credential or Verifiable Presentation as input,.
 //
 //	Args:
@@ -353,6 +467,68 @@ func (c *Client) CreateKeyPair(keyType kms.KeyType) (*wallet.KeyPair, error) {
 	return c.wallet.CreateKeyPair(auth, keyType)
 }
 
+// CreateDID creates a new DID of given method from a new wallet key, created DID is managed by wallet and
+// can be used as controller in proof options.
+//
+// Args:
+// 		- method: DID method, like 'key', 'jwk', 'peer', 'web'.
+// 		- options: options for creating DID, like key type, method options or setting DID as default.
+//
+func (c *Client) CreateDID(method string, options ...wallet.CreateDIDOptions) (*did.DocResolution, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.CreateDID(auth, method, options...)
+}
+
+// ListDIDs returns all DIDs managed by wallet.
+func (c *Client) ListDIDs() ([]*wallet.DIDRecord, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.ListDIDs(auth)
+}
+
+// SetDefaultDID sets given wallet DID as default DID for signing, which will be used as controller
+// when proof options have no controller.
+func (c *Client) SetDefaultDID(didID string) error {
+	auth, err := c.auth()
+	if err != nil {
+		return err
+	}
+
+	return c.wallet.SetDefaultDID(auth, didID)
+}
+
+// UpdateDID updates given wallet DID.
+//
+// Args:
+// 		- didID: ID of the DID managed by wallet.
+// 		- options: options for updating DID, like updated DID document, key rotation or method options.
+//
+func (c *Client) UpdateDID(didID string, options ...wallet.UpdateDIDOptions) (*did.DocResolution, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.UpdateDID(auth, didID, options...)
+}
+
+// DeactivateDID deactivates given wallet DID, deactivated DID can not be used for signing anymore.
+func (c *Client) DeactivateDID(didID string, options ...vdr.DIDMethodOption) error {
+	auth, err := c.auth()
+	if err != nil {
+		return err
+	}
+
+	return c.wallet.DeactivateDID(auth, didID, options...)
+}
+
 // Connect accepts out-of-band invitations and performs DID exchange.
 //
 // Args:
@@ -466,3 +642,36 @@ func (c *Client) RequestCredential(thID string, options ...wallet.ConcludeIntera
 
 	return c.wallet.RequestCredential(auth, thID, options...)
 }
+
+// CreateCredentialApplication creates credential application for the credential manifest attached to an
+// issue credential 3.0 offer, resulting application can be sent to issuer by RequestCredential.
+//
+// Args:
+// 		- offer: offer credential message holding credential manifest.
+// 		- options: options for selecting credentials to be submitted and signing credential application.
+//
+// https://identity.foundation/credential-manifest/#credential-application
+//
+func (c *Client) CreateCredentialApplication(offer *service.DIDCommMsgMap, options ...wallet.CredentialApplicationOptions) (*verifiable.Presentation, error) { // nolint: lll
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.CreateCredentialApplication(auth, offer, options...)
+}
+
+// ResolveCredentialManifest resolves output descriptors of given credential manifest for display, from a
+// credential fulfillment received from issuer or from a credential.
+//
+// https://identity.foundation/credential-manifest/wallet-rendering
+//
+func (c *Client) ResolveCredentialManifest(manifest json.RawMessage,
+	resolve wallet.ResolveManifestOption) ([][]cm.ResolvedDataDisplayDescriptor, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.ResolveCredentialManifest(auth, manifest, resolve)
+}
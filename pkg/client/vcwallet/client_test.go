@@ -12,6 +12,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -26,9 +28,11 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	issuecredentialsvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	issuecredentialmw "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
 	outofbandSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 	oobv2 "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
 	presentproofSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
@@ -49,6 +53,7 @@ import (
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/pbkdf2"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	vdrpkg "github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/wallet"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -60,7 +65,6 @@ const (
 	sampleRemoteKMSAuth = "sample-auth-token"
 	sampleKeyServerURL  = "sample/keyserver/test"
 	sampleUserID        = "sample-user01"
-	toBeImplementedErr  = "to be implemented"
 	sampleClientErr     = "sample client err"
 	sampleDIDKey        = "did:key:z6MknC1wwS6DEYwtGbZZo2QvjQjkh2qSBjb4GYmbye8dv4S5"
 	sampleDIDKey2       = "did:key:z6MkwFKUCsf8wvn6eSSu1WFAKatN1yexiDM7bf7pZLSFjdz6"
@@ -736,33 +740,50 @@ func TestClient_OpenClose(t *testing.T) {
 	})
 }
 
-func TestClient_Export(t *testing.T) {
+func TestClient_ExportImport(t *testing.T) {
 	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
 	require.NoError(t, err)
 
-	vcWalletClient, err := New(sampleUserID, mockctx)
+	vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
 	require.NotEmpty(t, vcWalletClient)
 	require.NoError(t, err)
 
-	result, err := vcWalletClient.Export("")
-	require.Empty(t, result)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
-}
+	require.NoError(t, vcWalletClient.Add(wallet.Metadata, []byte(sampleContentValid)))
 
-func TestClient_Import(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
+	exported, err := vcWalletClient.Export(wallet.WithExportPassphrase(samplePassPhrase))
 	require.NoError(t, err)
+	require.NotEmpty(t, exported)
 
-	vcWalletClient, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, vcWalletClient)
-	require.NoError(t, err)
+	t.Run("import into new wallet", func(t *testing.T) {
+		otherctx := newMockProvider(t)
+		require.NoError(t, CreateProfile(sampleUserID, otherctx, wallet.WithPassphrase(samplePassPhrase)))
 
-	err = vcWalletClient.Import("", nil)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
+		otherClient, err := New(sampleUserID, otherctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		require.NoError(t, otherClient.Import(exported, wallet.WithImportPassphrase(samplePassPhrase)))
+
+		content, err := otherClient.Get(wallet.Metadata, "did:example:123456789abcdefghi")
+		require.NoError(t, err)
+		require.JSONEq(t, sampleContentValid, string(content))
+
+		err = otherClient.Import(exported, wallet.WithImportPassphrase(samplePassPhrase),
+			wallet.WithImportConflictPolicy(wallet.ImportFailOnConflict))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already exists")
+	})
+
+	t.Run("locked wallet", func(t *testing.T) {
+		require.True(t, vcWalletClient.Close())
+
+		result, err := vcWalletClient.Export(wallet.WithExportPassphrase(samplePassPhrase))
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, result)
+
+		err = vcWalletClient.Import(exported, wallet.WithImportPassphrase(samplePassPhrase))
+		require.True(t, errors.Is(err, ErrWalletLocked))
+	})
 }
 
 func TestClient_Add(t *testing.T) {
@@ -880,6 +901,52 @@ func TestClient_GetAll(t *testing.T) {
 	require.Empty(t, vcs)
 }
 
+func TestClient_Search(t *testing.T) {
+	const vcContent = `{
+      "@context": ["https://www.w3.org/2018/credentials/v1"],
+      "id": "%s",
+      "name": "%s",
+      "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+      "issuanceDate": "2020-0%d-01T00:00:00Z",
+      "type": ["VerifiableCredential", "%s"]
+    }`
+
+	mockctx := newMockProvider(t)
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
+	require.NotEmpty(t, vcWalletClient)
+	require.NoError(t, err)
+
+	require.NoError(t, vcWalletClient.Add(wallet.Credential, []byte(fmt.Sprintf(vcContent,
+		"http://example.edu/credentials/1", "University Degree", 1, "UniversityDegreeCredential"))))
+	require.NoError(t, vcWalletClient.Add(wallet.Credential, []byte(fmt.Sprintf(vcContent,
+		"http://example.edu/credentials/2", "Permanent Resident Card", 2, "PermanentResidentCard"))))
+	require.NoError(t, vcWalletClient.Add(wallet.Credential, []byte(fmt.Sprintf(vcContent,
+		"http://example.edu/credentials/3", "Another University Degree", 3, "UniversityDegreeCredential"))))
+
+	// search by type with sorting & pagination
+	result, err := vcWalletClient.Search(wallet.Credential, wallet.WithTypeFilter("UniversityDegreeCredential"),
+		wallet.WithSortBy(wallet.SortByIssuanceDate, true), wallet.WithPagination(0, 1))
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Total)
+	require.Len(t, result.Contents, 1)
+	require.Equal(t, "http://example.edu/credentials/3", result.Contents[0].ID)
+
+	// search by free text
+	result, err = vcWalletClient.Search(wallet.Credential, wallet.WithTextFilter("resident"))
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Total)
+	require.Equal(t, "http://example.edu/credentials/2", result.Contents[0].ID)
+
+	// try locked wallet
+	require.True(t, vcWalletClient.Close())
+	result, err = vcWalletClient.Search(wallet.Credential)
+	require.True(t, errors.Is(err, ErrWalletLocked))
+	require.Empty(t, result)
+}
+
 func TestClient_Remove(t *testing.T) {
 	mockctx := newMockProvider(t)
 	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
@@ -1089,6 +1156,122 @@ func TestClient_Query(t *testing.T) {
 	})
 }
 
+func TestClient_MatchPresentationDefinition(t *testing.T) {
+	mockctx := newMockProvider(t)
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
+	require.NotEmpty(t, vcWalletClient)
+	require.NoError(t, err)
+
+	require.NoError(t, vcWalletClient.Add(wallet.Credential, []byte(`{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"id": "urn:uuid:degree",
+		"type": ["VerifiableCredential"],
+		"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21", "degree": "MIT"}
+	}`)))
+
+	definition := []byte(`{
+		"id": "degree-definition",
+		"input_descriptors": [{
+			"id": "degree",
+			"constraints": {"fields": [{"path": ["$.credentialSubject.degree"]}]}
+		}]
+	}`)
+
+	result, err := vcWalletClient.MatchPresentationDefinition(definition)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.True(t, result[0].Satisfied)
+	require.Len(t, result[0].Descriptors[0].MatchedVCs, 1)
+
+	vp, err := vcWalletClient.PresentSelection(definition, map[string][]string{"degree": {"urn:uuid:degree"}})
+	require.NoError(t, err)
+	require.Len(t, vp.Credentials(), 1)
+
+	// try locked wallet
+	require.True(t, vcWalletClient.Close())
+
+	result, err = vcWalletClient.MatchPresentationDefinition(definition)
+	require.True(t, errors.Is(err, ErrWalletLocked))
+	require.Empty(t, result)
+
+	vp, err = vcWalletClient.PresentSelection(definition, map[string][]string{"degree": {"urn:uuid:degree"}})
+	require.True(t, errors.Is(err, ErrWalletLocked))
+	require.Empty(t, vp)
+}
+
+func TestClient_CredentialManifest(t *testing.T) {
+	mockctx := newMockProvider(t)
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
+	require.NotEmpty(t, vcWalletClient)
+	require.NoError(t, err)
+
+	vc := []byte(`{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"id": "urn:uuid:identity",
+		"type": ["VerifiableCredential"],
+		"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21", "name": "Jayden Doe"}
+	}`)
+
+	require.NoError(t, vcWalletClient.Add(wallet.Credential, vc))
+
+	manifest := []byte(`{
+		"id": "driver-license-manifest",
+		"issuer": {"id": "did:example:issuer"},
+		"output_descriptors": [{
+			"id": "driver_license_output",
+			"schema": "https://example.org/driver-license.json",
+			"display": {"title": {"text": "Driver License"}}
+		}],
+		"presentation_definition": {
+			"id": "identity-definition",
+			"input_descriptors": [{
+				"id": "identity_input",
+				"schema": [{"uri": "https://www.w3.org/2018/credentials#VerifiableCredential"}],
+				"constraints": {"fields": [{"path": ["$.credentialSubject.name"]}]}
+			}]
+		}
+	}`)
+
+	credentialManifest := &cm.CredentialManifest{}
+	require.NoError(t, json.Unmarshal(manifest, credentialManifest))
+
+	params, err := issuecredentialmw.NewCredentialManifestOffer(credentialManifest, nil)
+	require.NoError(t, err)
+
+	offer := service.NewDIDCommMsgMap(params.AsV3())
+
+	application, err := vcWalletClient.CreateCredentialApplication(&offer)
+	require.NoError(t, err)
+	require.Contains(t, application.Type, "CredentialApplication")
+	require.Len(t, application.Credentials(), 1)
+
+	resolved, err := vcWalletClient.ResolveCredentialManifest(manifest, wallet.ResolveRawCredential(vc))
+	require.NoError(t, err)
+	require.Len(t, resolved, 1)
+	require.Equal(t, "Driver License", resolved[0][0].Title)
+
+	// try locked wallet
+	require.True(t, vcWalletClient.Close())
+
+	application, err = vcWalletClient.CreateCredentialApplication(&offer)
+	require.True(t, errors.Is(err, ErrWalletLocked))
+	require.Empty(t, application)
+
+	resolved, err = vcWalletClient.ResolveCredentialManifest(manifest, wallet.ResolveRawCredential(vc))
+	require.True(t, errors.Is(err, ErrWalletLocked))
+	require.Empty(t, resolved)
+}
+
 func TestClient_Issue(t *testing.T) {
 	customVDR := &mockvdr.MockVDRegistry{
 		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
@@ -1254,6 +1437,74 @@ func TestClient_Prove(t *testing.T) {
 	})
 }
 
+func TestClient_PresentationRequest(t *testing.T) {
+	customVDR := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			return key.New().Read(didID)
+		},
+	}
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = customVDR
+	mockctx.CryptoValue = &cryptomock.Crypto{}
+
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"redirectUrl": "https://example.com/success"}`))
+	}))
+	defer server.Close()
+
+	request := &wallet.VerifiablePresentationRequest{
+		Query:     []*wallet.VPRQuery{{QueryParams: wallet.QueryParams{Type: "DIDAuth"}}},
+		Challenge: "sample-challenge",
+		Domain:    server.URL,
+		Interact: &wallet.VPRInteract{Service: []*wallet.VPRInteractService{{
+			Type:            wallet.UnmediatedHTTPPresentationService,
+			ServiceEndpoint: server.URL,
+		}}},
+	}
+
+	t.Run("Test VC wallet client presentation request - success", func(t *testing.T) {
+		vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
+		require.NotEmpty(t, vcWalletClient)
+		require.NoError(t, err)
+
+		defer vcWalletClient.Close()
+
+		require.NoError(t, vcWalletClient.Add(wallet.Key, []byte(sampleKeyContentBase58)))
+
+		vp, err := vcWalletClient.CreatePresentationResponse(request, &wallet.ProofOptions{Controller: sampleDIDKey})
+		require.NoError(t, err)
+		require.NotEmpty(t, vp)
+		require.Len(t, vp.Proofs, 1)
+		require.Equal(t, "sample-challenge", vp.Proofs[0]["challenge"])
+
+		response, err := vcWalletClient.RespondPresentationRequest(request,
+			&wallet.ProofOptions{Controller: sampleDIDKey}, wallet.WithInteractHTTPClient(server.Client()))
+		require.NoError(t, err)
+		require.NotEmpty(t, response)
+		require.Equal(t, "https://example.com/success", response.RedirectURL)
+	})
+
+	t.Run("Test VC wallet client presentation request - wallet locked", func(t *testing.T) {
+		vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
+		require.NotEmpty(t, vcWalletClient)
+		require.NoError(t, err)
+
+		vcWalletClient.Close()
+
+		vp, err := vcWalletClient.CreatePresentationResponse(request, &wallet.ProofOptions{Controller: sampleDIDKey})
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, vp)
+
+		response, err := vcWalletClient.RespondPresentationRequest(request, &wallet.ProofOptions{Controller: sampleDIDKey})
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, response)
+	})
+}
+
 func TestClient_Verify(t *testing.T) {
 	customVDR := &mockvdr.MockVDRegistry{
 		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
@@ -1510,6 +1761,84 @@ func TestWallet_Derive(t *testing.T) {
 	})
 }
 
+func TestClient_DIDManagement(t *testing.T) {
+	sampleUser := uuid.New().String()
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx := newMockProvider(t)
+	mockctx.CryptoValue = cryptoSvc
+	mockctx.VDRegistryValue = &mockvdr.MockVDRegistry{
+		CreateFunc: vdrpkg.New(vdrpkg.WithVDR(key.New())).Create,
+		UpdateFunc: func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
+			return nil
+		},
+		DeactivateFunc: func(didID string, opts ...vdrapi.DIDMethodOption) error {
+			return nil
+		},
+	}
+
+	err = CreateProfile(sampleUser, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWallet, err := New(sampleUser, mockctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, vcWallet)
+
+	t.Run("test DID management with locked wallet", func(t *testing.T) {
+		docResolution, err := vcWallet.CreateDID(key.DIDMethod)
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, docResolution)
+
+		records, err := vcWallet.ListDIDs()
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, records)
+
+		require.True(t, errors.Is(vcWallet.SetDefaultDID(sampleDIDKey), ErrWalletLocked))
+
+		docResolution, err = vcWallet.UpdateDID(sampleDIDKey)
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, docResolution)
+
+		require.True(t, errors.Is(vcWallet.DeactivateDID(sampleDIDKey), ErrWalletLocked))
+	})
+
+	require.NoError(t, vcWallet.Open(wallet.WithUnlockByPassphrase(samplePassPhrase)))
+
+	defer vcWallet.Close()
+
+	t.Run("test creating DID and issuing credential by default DID", func(t *testing.T) {
+		docResolution, err := vcWallet.CreateDID(key.DIDMethod)
+		require.NoError(t, err)
+
+		didID := docResolution.DIDDocument.ID
+
+		require.NoError(t, vcWallet.SetDefaultDID(didID))
+
+		records, err := vcWallet.ListDIDs()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.True(t, records[0].Default)
+
+		vc, err := vcWallet.Issue([]byte(sampleUDCVC), &wallet.ProofOptions{})
+		require.NoError(t, err)
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, records[0].KeyBindings[0].VerificationMethod, vc.Proofs[0]["verificationMethod"])
+
+		docResolution, err = vcWallet.UpdateDID(didID, wallet.WithKeyRotation())
+		require.NoError(t, err)
+		require.Equal(t, didID, docResolution.DIDDocument.ID)
+
+		require.NoError(t, vcWallet.DeactivateDID(didID))
+
+		vc, err = vcWallet.Issue([]byte(sampleUDCVC), &wallet.ProofOptions{Controller: didID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is deactivated")
+		require.Empty(t, vc)
+	})
+}
+
 func TestClient_CreateKeyPair(t *testing.T) {
 	sampleUser := uuid.New().String()
 	mockctx := newMockProvider(t)
@@ -2061,3 +2390,45 @@ func (m *mockMsg) ParentThreadID() string {
 func (m *mockMsg) ThreadID() (string, error) {
 	return m.thID, nil
 }
+
+func TestClient_SessionManagement(t *testing.T) {
+	sampleUser := uuid.New().String()
+	mockctx := newMockProvider(t)
+
+	err := CreateProfile(sampleUser, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWallet, err := New(sampleUser, mockctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, vcWallet)
+
+	t.Run("test session management with locked wallet", func(t *testing.T) {
+		sessions, err := vcWallet.Sessions()
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+
+		require.True(t, errors.Is(vcWallet.RefreshSession(), ErrWalletLocked))
+		require.True(t, errors.Is(vcWallet.RevokeSession(uuid.New().String()), wallet.ErrSessionNotFound))
+	})
+
+	t.Run("test refresh and revoke session", func(t *testing.T) {
+		require.NoError(t, vcWallet.Open(wallet.WithUnlockByPassphrase(samplePassPhrase),
+			wallet.WithUnlockIdleTimeout(time.Minute)))
+		defer vcWallet.Close()
+
+		sessions, err := vcWallet.Sessions()
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		require.Equal(t, time.Minute, sessions[0].IdleTimeout)
+
+		require.NoError(t, vcWallet.RefreshSession())
+		require.NoError(t, vcWallet.Add(wallet.Metadata, []byte(sampleContentValid)))
+
+		require.NoError(t, vcWallet.RevokeSession(sessions[0].ID))
+
+		err = vcWallet.Add(wallet.Metadata, []byte(sampleContentValid))
+		require.Error(t, err)
+
+		require.True(t, errors.Is(vcWallet.RefreshSession(), wallet.ErrInvalidAuthToken))
+	})
+}
@@ -0,0 +1,224 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/controller/actionmenu")
+
+const (
+	// InvalidRequestErrorCode is typically a code for validation errors
+	// for invalid action-menu controller requests.
+	InvalidRequestErrorCode = command.Code(iota + command.ActionMenu)
+	// SendMenuErrorCode is for failures in send menu command.
+	SendMenuErrorCode
+	// RequestMenuErrorCode is for failures in request menu command.
+	RequestMenuErrorCode
+	// PerformErrorCode is for failures in perform command.
+	PerformErrorCode
+	// GetMenuErrorCode is for failures in get menu command.
+	GetMenuErrorCode
+)
+
+// constants for command action-menu.
+const (
+	CommandName = "actionmenu"
+
+	SendMenu    = "SendMenu"
+	RequestMenu = "RequestMenu"
+	Perform     = "Perform"
+	GetMenu     = "GetMenu"
+	// error messages.
+	errEmptyMyDID    = "empty my_did"
+	errEmptyTheirDID = "empty their_did"
+	errEmptyMenu     = "empty menu"
+	errEmptyPerform  = "empty perform"
+	errEmptyName     = "empty perform name"
+	// log constants.
+	successString = "success"
+
+	_states = "_states"
+)
+
+// Command is controller command for action-menu.
+type Command struct {
+	client *actionmenu.Client
+}
+
+// New returns new action-menu controller command instance.
+func New(ctx actionmenu.Provider, notifier command.Notifier) (*Command, error) {
+	client, err := actionmenu.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a client: %w", err)
+	}
+
+	// creates state channel
+	states := make(chan service.StateMsg)
+	// registers state channel to listen for events
+	if err := client.RegisterMsgEvent(states); err != nil {
+		return nil, fmt.Errorf("register msg event: %w", err)
+	}
+
+	obs := webnotifier.NewObserver(notifier)
+	obs.RegisterStateMsg(protocol.Name+_states, states)
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, SendMenu, c.SendMenu),
+		cmdutil.NewCommandHandler(CommandName, RequestMenu, c.RequestMenu),
+		cmdutil.NewCommandHandler(CommandName, Perform, c.Perform),
+		cmdutil.NewCommandHandler(CommandName, GetMenu, c.GetMenu),
+	}
+}
+
+// SendMenu publishes the menu to the connection.
+func (c *Command) SendMenu(rw io.Writer, req io.Reader) command.Error {
+	var args SendMenuArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SendMenu, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if err := validateDIDs(SendMenu, args.MyDID, args.TheirDID); err != nil {
+		return err
+	}
+
+	if args.Menu == nil {
+		logutil.LogDebug(logger, CommandName, SendMenu, errEmptyMenu)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMenu))
+	}
+
+	if err := c.client.SendMenu(args.Menu, args.MyDID, args.TheirDID); err != nil {
+		logutil.LogError(logger, CommandName, SendMenu, err.Error())
+		return command.NewExecuteError(SendMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, SendMenu, successString)
+
+	return nil
+}
+
+// RequestMenu requests the current menu of the connection.
+func (c *Command) RequestMenu(rw io.Writer, req io.Reader) command.Error {
+	var args RequestMenuArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, RequestMenu, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if err := validateDIDs(RequestMenu, args.MyDID, args.TheirDID); err != nil {
+		return err
+	}
+
+	if err := c.client.RequestMenu(args.MyDID, args.TheirDID); err != nil {
+		logutil.LogError(logger, CommandName, RequestMenu, err.Error())
+		return command.NewExecuteError(RequestMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RequestMenu, successString)
+
+	return nil
+}
+
+// Perform performs the menu option of the menu received from the connection.
+func (c *Command) Perform(rw io.Writer, req io.Reader) command.Error {
+	var args PerformArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Perform, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if err := validateDIDs(Perform, args.MyDID, args.TheirDID); err != nil {
+		return err
+	}
+
+	if args.Perform == nil {
+		logutil.LogDebug(logger, CommandName, Perform, errEmptyPerform)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPerform))
+	}
+
+	if args.Perform.Name == "" {
+		logutil.LogDebug(logger, CommandName, Perform, errEmptyName)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyName))
+	}
+
+	if err := c.client.Perform(args.Perform, args.MyDID, args.TheirDID); err != nil {
+		logutil.LogError(logger, CommandName, Perform, err.Error())
+		return command.NewExecuteError(PerformErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, Perform, successString)
+
+	return nil
+}
+
+// GetMenu returns the last menu received from the connection.
+func (c *Command) GetMenu(rw io.Writer, req io.Reader) command.Error {
+	var args GetMenuArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, GetMenu, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if err := validateDIDs(GetMenu, args.MyDID, args.TheirDID); err != nil {
+		return err
+	}
+
+	menu, err := c.client.GetMenu(args.MyDID, args.TheirDID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetMenu, err.Error())
+		return command.NewExecuteError(GetMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &GetMenuResponse{Menu: menu}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetMenu, successString)
+
+	return nil
+}
+
+func validateDIDs(method, myDID, theirDID string) command.Error {
+	if myDID == "" {
+		logutil.LogDebug(logger, CommandName, method, errEmptyMyDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyDID))
+	}
+
+	if theirDID == "" {
+		logutil.LogDebug(logger, CommandName, method, errEmptyTheirDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyTheirDID))
+	}
+
+	return nil
+}
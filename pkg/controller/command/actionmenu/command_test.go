@@ -0,0 +1,292 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/actionmenu"
+	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+)
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		require.Len(t, cmd.GetHandlers(), 4)
+	})
+
+	t.Run("Create client (error)", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.EqualError(t, err, "cannot create a client: cast service to Action Menu Service failed")
+		require.Nil(t, cmd)
+	})
+
+	t.Run("Register msg event (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(errors.New("error"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.EqualError(t, err, "register msg event: error")
+		require.Nil(t, cmd)
+	})
+}
+
+func newCommand(t *testing.T, ctrl *gomock.Controller) (*Command, *mocks.MockProtocolService) {
+	t.Helper()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+	cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	return cmd, service
+}
+
+func requireValidationError(t *testing.T, cmdErr command.Error, msg string) {
+	t.Helper()
+
+	require.Error(t, cmdErr)
+	require.Contains(t, cmdErr.Error(), msg)
+	require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	require.Equal(t, command.ValidationError, cmdErr.Type())
+}
+
+func TestCommand_SendMenu(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.SendMenu(&b, bytes.NewBufferString("}")), "invalid character")
+	})
+
+	t.Run("Empty MyDID", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.SendMenu(&b, bytes.NewBufferString("{}")), errEmptyMyDID)
+	})
+
+	t.Run("Empty TheirDID", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.SendMenu(&b, bytes.NewBufferString(`{"my_did":"my-did"}`)), errEmptyTheirDID)
+	})
+
+	t.Run("Empty Menu", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.SendMenu(&b, bytes.NewBufferString(
+			`{"my_did":"my-did","their_did":"their-did"}`)), errEmptyMenu)
+	})
+
+	t.Run("SendMenu (error)", func(t *testing.T) {
+		cmd, service := newCommand(t, ctrl)
+		service.EXPECT().SendMenu(gomock.Any(), "my-did", "their-did").Return(errors.New("some error"))
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendMenu(&b, bytes.NewBufferString(
+			`{"my_did":"my-did","their_did":"their-did","menu":{"title":"Services"}}`))
+		require.EqualError(t, cmdErr, "some error")
+		require.Equal(t, SendMenuErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		cmd, service := newCommand(t, ctrl)
+		service.EXPECT().SendMenu(gomock.Any(), "my-did", "their-did").
+			DoAndReturn(func(menu *protocol.Menu, _, _ string) error {
+				require.Equal(t, "Services", menu.Title)
+				require.Equal(t, "balance", menu.Options[0].Name)
+
+				return nil
+			})
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.SendMenu(&b, bytes.NewBufferString(
+			`{"my_did":"my-did","their_did":"their-did","menu":{"title":"Services","options":[{"name":"balance"}]}}`)))
+	})
+}
+
+func TestCommand_RequestMenu(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.RequestMenu(&b, bytes.NewBufferString("}")), "invalid character")
+	})
+
+	t.Run("Empty MyDID", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.RequestMenu(&b, bytes.NewBufferString("{}")), errEmptyMyDID)
+	})
+
+	t.Run("RequestMenu (error)", func(t *testing.T) {
+		cmd, service := newCommand(t, ctrl)
+		service.EXPECT().RequestMenu("my-did", "their-did").Return(errors.New("some error"))
+
+		var b bytes.Buffer
+		cmdErr := cmd.RequestMenu(&b, bytes.NewBufferString(`{"my_did":"my-did","their_did":"their-did"}`))
+		require.EqualError(t, cmdErr, "some error")
+		require.Equal(t, RequestMenuErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		cmd, service := newCommand(t, ctrl)
+		service.EXPECT().RequestMenu("my-did", "their-did").Return(nil)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.RequestMenu(&b, bytes.NewBufferString(`{"my_did":"my-did","their_did":"their-did"}`)))
+	})
+}
+
+func TestCommand_Perform(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.Perform(&b, bytes.NewBufferString("}")), "invalid character")
+	})
+
+	t.Run("Empty TheirDID", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.Perform(&b, bytes.NewBufferString(`{"my_did":"my-did"}`)), errEmptyTheirDID)
+	})
+
+	t.Run("Empty Perform", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.Perform(&b, bytes.NewBufferString(
+			`{"my_did":"my-did","their_did":"their-did"}`)), errEmptyPerform)
+	})
+
+	t.Run("Empty Name", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.Perform(&b, bytes.NewBufferString(
+			`{"my_did":"my-did","their_did":"their-did","perform":{}}`)), errEmptyName)
+	})
+
+	t.Run("Perform (error)", func(t *testing.T) {
+		cmd, service := newCommand(t, ctrl)
+		service.EXPECT().Perform(gomock.Any(), "my-did", "their-did").Return(errors.New("some error"))
+
+		var b bytes.Buffer
+		cmdErr := cmd.Perform(&b, bytes.NewBufferString(
+			`{"my_did":"my-did","their_did":"their-did","perform":{"name":"balance"}}`))
+		require.EqualError(t, cmdErr, "some error")
+		require.Equal(t, PerformErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		cmd, service := newCommand(t, ctrl)
+		service.EXPECT().Perform(gomock.Any(), "my-did", "their-did").
+			DoAndReturn(func(perform *protocol.Perform, _, _ string) error {
+				require.Equal(t, "balance", perform.Name)
+				require.Equal(t, "123", perform.Params["account"])
+
+				return nil
+			})
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Perform(&b, bytes.NewBufferString(
+			`{"my_did":"my-did","their_did":"their-did","perform":{"name":"balance","params":{"account":"123"}}}`)))
+	})
+}
+
+func TestCommand_GetMenu(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.GetMenu(&b, bytes.NewBufferString("}")), "invalid character")
+	})
+
+	t.Run("Empty MyDID", func(t *testing.T) {
+		cmd, _ := newCommand(t, ctrl)
+
+		var b bytes.Buffer
+		requireValidationError(t, cmd.GetMenu(&b, bytes.NewBufferString("{}")), errEmptyMyDID)
+	})
+
+	t.Run("GetMenu (error)", func(t *testing.T) {
+		cmd, service := newCommand(t, ctrl)
+		service.EXPECT().GetMenu("my-did", "their-did").Return(nil, protocol.ErrMenuNotFound)
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetMenu(&b, bytes.NewBufferString(`{"my_did":"my-did","their_did":"their-did"}`))
+		require.EqualError(t, cmdErr, protocol.ErrMenuNotFound.Error())
+		require.Equal(t, GetMenuErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		cmd, service := newCommand(t, ctrl)
+		service.EXPECT().GetMenu("my-did", "their-did").Return(&protocol.Menu{
+			Title:   "Services",
+			Options: []protocol.MenuOption{{Name: "balance"}},
+		}, nil)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.GetMenu(&b, bytes.NewBufferString(`{"my_did":"my-did","their_did":"their-did"}`)))
+
+		res := GetMenuResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Equal(t, "Services", res.Menu.Title)
+		require.Equal(t, "balance", res.Menu.Options[0].Name)
+	})
+}
@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import "github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+
+// SendMenuArgs model
+//
+// This is used for publishing a menu to a connection.
+//
+type SendMenuArgs struct {
+	// MyDID sender's did
+	MyDID string `json:"my_did"`
+	// TheirDID receiver's did
+	TheirDID string `json:"their_did"`
+	// Menu is the menu of actions
+	Menu *actionmenu.Menu `json:"menu"`
+}
+
+// RequestMenuArgs model
+//
+// This is used for requesting the menu of a connection.
+//
+type RequestMenuArgs struct {
+	// MyDID sender's did
+	MyDID string `json:"my_did"`
+	// TheirDID receiver's did
+	TheirDID string `json:"their_did"`
+}
+
+// PerformArgs model
+//
+// This is used for performing a menu option.
+//
+type PerformArgs struct {
+	// MyDID sender's did
+	MyDID string `json:"my_did"`
+	// TheirDID receiver's did
+	TheirDID string `json:"their_did"`
+	// Perform is the selected menu option and its parameters
+	Perform *actionmenu.Perform `json:"perform"`
+}
+
+// GetMenuArgs model
+//
+// This is used for getting the menu received from a connection.
+//
+type GetMenuArgs struct {
+	// MyDID my did in the connection
+	MyDID string `json:"my_did"`
+	// TheirDID did of the connection which sent the menu
+	TheirDID string `json:"their_did"`
+}
+
+// GetMenuResponse model
+//
+// Represents a GetMenu response message.
+//
+type GetMenuResponse struct {
+	// Menu is the last menu received from the connection
+	Menu *actionmenu.Menu `json:"menu"`
+}
@@ -79,6 +79,12 @@ const (
 
 	// Connection error group for connection management errors.
 	Connection = 15000
+
+	// ActionMenu error group for action-menu command errors.
+	ActionMenu = 16000
+
+	// VCAPI error group for VC-API endpoint errors.
+	VCAPI = 17000
 )
 
 // Error is the  interface for representing an command error condition, with the nil value representing no error.
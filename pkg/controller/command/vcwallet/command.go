@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -95,6 +96,45 @@ const (
 
 	// RequestCredentialErrorCode for errors while request credential from wallet for issue credential protocol.
 	RequestCredentialErrorCode
+
+	// ExportWalletErrorCode for errors while exporting wallet contents.
+	ExportWalletErrorCode
+
+	// ImportWalletErrorCode for errors while importing wallet contents.
+	ImportWalletErrorCode
+
+	// CreateDIDErrorCode for errors while creating DID from wallet.
+	CreateDIDErrorCode
+
+	// ListDIDsErrorCode for errors while listing DIDs managed by wallet.
+	ListDIDsErrorCode
+
+	// SetDefaultDIDErrorCode for errors while setting default DID of wallet.
+	SetDefaultDIDErrorCode
+
+	// UpdateDIDErrorCode for errors while updating DID managed by wallet.
+	UpdateDIDErrorCode
+
+	// DeactivateDIDErrorCode for errors while deactivating DID managed by wallet.
+	DeactivateDIDErrorCode
+
+	// ListSessionsErrorCode for errors while listing wallet sessions.
+	ListSessionsErrorCode
+
+	// RefreshSessionErrorCode for errors while refreshing wallet session token.
+	RefreshSessionErrorCode
+
+	// RevokeSessionErrorCode for errors while revoking wallet session.
+	RevokeSessionErrorCode
+
+	// SearchErrorCode for errors while searching wallet contents.
+	SearchErrorCode
+
+	// CreatePresentationResponseErrorCode for errors while creating response to presentation request.
+	CreatePresentationResponseErrorCode
+
+	// RespondPresentationRequestErrorCode for errors while responding to presentation request.
+	RespondPresentationRequestErrorCode
 )
 
 // All command operations.
@@ -122,6 +162,20 @@ const (
 	PresentProofMethod        = "PresentProof"
 	ProposeCredentialMethod   = "ProposeCredential"
 	RequestCredentialMethod   = "RequestCredential"
+	ExportMethod              = "Export"
+	ImportMethod              = "Import"
+	CreateDIDMethod           = "CreateDID"
+	ListDIDsMethod            = "ListDIDs"
+	SetDefaultDIDMethod       = "SetDefaultDID"
+	UpdateDIDMethod           = "UpdateDID"
+	DeactivateDIDMethod       = "DeactivateDID"
+	ListSessionsMethod        = "ListSessions"
+	RefreshSessionMethod      = "RefreshSession"
+	RevokeSessionMethod       = "RevokeSession"
+	LockBackgroundMethod      = "LockBackgroundSessions"
+	SearchMethod              = "Search"
+	CreateVPRResponseMethod   = "CreatePresentationResponse"
+	RespondVPRMethod          = "RespondPresentationRequest"
 )
 
 // miscellaneous constants for the vc wallet command controller.
@@ -168,6 +222,15 @@ type Config struct {
 	// Default token expiry for all wallet profiles created.
 	// Will be used only if wallet unlock request doesn't supply default timeout value.
 	DefaultTokenExpiry time.Duration
+	// Default idle timeout of wallet sessions, idle timeout is disabled by default.
+	// Will be used only if wallet unlock request doesn't supply idle timeout value.
+	DefaultIdleTimeout time.Duration
+	// Disables persisting wallet session details in wallet storage,
+	// if disabled then sessions can't be listed or revoked by other wallet instances sharing the same storage.
+	DisableSessionPersistence bool
+	// HTTP client for sending presentations to interact services of presentation requests.
+	// Default HTTP client will be used if not provided.
+	HTTPClient wallet.HTTPClient
 }
 
 // provider contains dependencies for the verifiable credential wallet command controller
@@ -227,8 +290,11 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, GetMethod, o.Get),
 		cmdutil.NewCommandHandler(CommandName, GetAllMethod, o.GetAll),
 		cmdutil.NewCommandHandler(CommandName, QueryMethod, o.Query),
+		cmdutil.NewCommandHandler(CommandName, SearchMethod, o.Search),
 		cmdutil.NewCommandHandler(CommandName, IssueMethod, o.Issue),
 		cmdutil.NewCommandHandler(CommandName, ProveMethod, o.Prove),
+		cmdutil.NewCommandHandler(CommandName, CreateVPRResponseMethod, o.CreatePresentationResponse),
+		cmdutil.NewCommandHandler(CommandName, RespondVPRMethod, o.RespondPresentationRequest),
 		cmdutil.NewCommandHandler(CommandName, VerifyMethod, o.Verify),
 		cmdutil.NewCommandHandler(CommandName, DeriveMethod, o.Derive),
 		cmdutil.NewCommandHandler(CommandName, CreateKeyPairMethod, o.CreateKeyPair),
@@ -237,6 +303,17 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, PresentProofMethod, o.PresentProof),
 		cmdutil.NewCommandHandler(CommandName, ProposeCredentialMethod, o.ProposeCredential),
 		cmdutil.NewCommandHandler(CommandName, RequestCredentialMethod, o.RequestCredential),
+		cmdutil.NewCommandHandler(CommandName, ExportMethod, o.Export),
+		cmdutil.NewCommandHandler(CommandName, ImportMethod, o.Import),
+		cmdutil.NewCommandHandler(CommandName, CreateDIDMethod, o.CreateDID),
+		cmdutil.NewCommandHandler(CommandName, ListDIDsMethod, o.ListDIDs),
+		cmdutil.NewCommandHandler(CommandName, SetDefaultDIDMethod, o.SetDefaultDID),
+		cmdutil.NewCommandHandler(CommandName, UpdateDIDMethod, o.UpdateDID),
+		cmdutil.NewCommandHandler(CommandName, DeactivateDIDMethod, o.DeactivateDID),
+		cmdutil.NewCommandHandler(CommandName, ListSessionsMethod, o.ListSessions),
+		cmdutil.NewCommandHandler(CommandName, RefreshSessionMethod, o.RefreshSession),
+		cmdutil.NewCommandHandler(CommandName, RevokeSessionMethod, o.RevokeSession),
+		cmdutil.NewCommandHandler(CommandName, LockBackgroundMethod, o.LockBackgroundSessions),
 	}
 }
 
@@ -521,6 +598,39 @@ func (o *Command) GetAll(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// Search searches wallet contents by field, tag, date range and free text filters with sorting and pagination.
+func (o *Command) Search(rw io.Writer, req io.Reader) command.Error {
+	request := &SearchRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SearchMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SearchMethod, err.Error())
+
+		return command.NewExecuteError(SearchErrorCode, err)
+	}
+
+	result, err := vcWallet.Search(request.Auth, request.ContentType, prepareSearchOpts(request)...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SearchMethod, err.Error())
+
+		return command.NewExecuteError(SearchErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &SearchResponse{Total: result.Total, Contents: result.Contents}, logger)
+
+	logutil.LogDebug(logger, CommandName, SearchMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
 // Query runs credential queries against wallet credential contents and
 // returns presentation containing credential results.
 func (o *Command) Query(rw io.Writer, req io.Reader) command.Error {
@@ -621,6 +731,97 @@ func (o *Command) Prove(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// CreatePresentationResponse creates signed presentation responding to verifiable presentation request.
+func (o *Command) CreatePresentationResponse(rw io.Writer, req io.Reader) command.Error {
+	request := &CreatePresentationResponseRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateVPRResponseMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vpr, err := wallet.ParseVerifiablePresentationRequest(request.PresentationRequest)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateVPRResponseMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateVPRResponseMethod, err.Error())
+
+		return command.NewExecuteError(CreatePresentationResponseErrorCode, err)
+	}
+
+	vp, err := vcWallet.CreatePresentationResponse(request.Auth, vpr, request.ProofOptions)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateVPRResponseMethod, err.Error())
+
+		return command.NewExecuteError(CreatePresentationResponseErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ProveResponse{Presentation: vp}, logger)
+
+	logutil.LogDebug(logger, CommandName, CreateVPRResponseMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// RespondPresentationRequest creates signed presentation responding to verifiable presentation request and
+// sends it to interact service of the presentation request.
+func (o *Command) RespondPresentationRequest(rw io.Writer, req io.Reader) command.Error {
+	request := &RespondPresentationRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RespondVPRMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vpr, err := wallet.ParseVerifiablePresentationRequest(request.PresentationRequest)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RespondVPRMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RespondVPRMethod, err.Error())
+
+		return command.NewExecuteError(RespondPresentationRequestErrorCode, err)
+	}
+
+	options := []wallet.InteractOptions{wallet.WithInteractServiceType(request.ServiceType)}
+	if o.config.HTTPClient != nil {
+		options = append(options, wallet.WithInteractHTTPClient(o.config.HTTPClient))
+	}
+
+	response, err := vcWallet.RespondPresentationRequest(request.Auth, vpr, request.ProofOptions, options...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RespondVPRMethod, err.Error())
+
+		return command.NewExecuteError(RespondPresentationRequestErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RespondPresentationResponse{
+		Presentation:                  response.Presentation,
+		VerifiablePresentation:        response.VerifiablePresentation,
+		VerifiablePresentationRequest: response.VerifiablePresentationRequest,
+		RedirectURL:                   response.RedirectURL,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, RespondVPRMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
 // Verify verifies credential/presentation from wallet.
 func (o *Command) Verify(rw io.Writer, req io.Reader) command.Error {
 	request := &VerifyRequest{}
@@ -934,6 +1135,437 @@ func (o *Command) RequestCredential(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// Export exports all wallet contents as an encrypted wallet.
+func (o *Command) Export(rw io.Writer, req io.Reader) command.Error {
+	request := &ExportRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	options := []wallet.ExportOptions{
+		wallet.WithExportPassphrase(request.Passphrase), wallet.WithExportKeyID(request.KeyID),
+	}
+
+	if request.SkipUnexportableKeys {
+		options = append(options, wallet.WithExportSkippingUnexportableKeys())
+	}
+
+	exported, err := vcWallet.Export(request.Auth, options...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportResponse{Wallet: exported}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Import imports contents of an exported wallet into wallet.
+func (o *Command) Import(rw io.Writer, req io.Reader) command.Error {
+	request := &ImportRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewExecuteError(ImportWalletErrorCode, err)
+	}
+
+	options := []wallet.ImportOptions{wallet.WithImportPassphrase(request.Passphrase)}
+	if request.ConflictPolicy != "" {
+		options = append(options, wallet.WithImportConflictPolicy(request.ConflictPolicy))
+	}
+
+	err = vcWallet.Import(request.Auth, request.Wallet, options...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewExecuteError(ImportWalletErrorCode, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, ImportMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// CreateDID creates a new DID from wallet key, the DID will be managed by wallet and can be used for signing.
+func (o *Command) CreateDID(rw io.Writer, req io.Reader) command.Error {
+	request := &CreateDIDRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateDIDMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateDIDMethod, err.Error())
+
+		return command.NewExecuteError(CreateDIDErrorCode, err)
+	}
+
+	options := []wallet.CreateDIDOptions{wallet.WithCreateDIDMethodOptions(didMethodOptions(request.Opts)...)}
+
+	if request.KeyType != "" {
+		options = append(options, wallet.WithDIDKeyType(request.KeyType))
+	}
+
+	if request.Default {
+		options = append(options, wallet.WithDefaultDID())
+	}
+
+	docResolution, err := vcWallet.CreateDID(request.Auth, request.Method, options...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateDIDMethod, err.Error())
+
+		return command.NewExecuteError(CreateDIDErrorCode, err)
+	}
+
+	docResolutionBytes, err := docResolution.JSONBytes()
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateDIDMethod, err.Error())
+
+		return command.NewExecuteError(CreateDIDErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &CreateDIDResponse{DIDResolution: docResolutionBytes}, logger)
+
+	logutil.LogDebug(logger, CommandName, CreateDIDMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// ListDIDs lists all DIDs managed by wallet.
+func (o *Command) ListDIDs(rw io.Writer, req io.Reader) command.Error {
+	request := &ListDIDsRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ListDIDsMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ListDIDsMethod, err.Error())
+
+		return command.NewExecuteError(ListDIDsErrorCode, err)
+	}
+
+	records, err := vcWallet.ListDIDs(request.Auth)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ListDIDsMethod, err.Error())
+
+		return command.NewExecuteError(ListDIDsErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ListDIDsResponse{DIDs: records}, logger)
+
+	logutil.LogDebug(logger, CommandName, ListDIDsMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// SetDefaultDID sets default wallet DID for signing.
+func (o *Command) SetDefaultDID(rw io.Writer, req io.Reader) command.Error {
+	request := &SetDefaultDIDRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SetDefaultDIDMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SetDefaultDIDMethod, err.Error())
+
+		return command.NewExecuteError(SetDefaultDIDErrorCode, err)
+	}
+
+	err = vcWallet.SetDefaultDID(request.Auth, request.DID)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SetDefaultDIDMethod, err.Error())
+
+		return command.NewExecuteError(SetDefaultDIDErrorCode, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, SetDefaultDIDMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// UpdateDID updates DID managed by wallet.
+func (o *Command) UpdateDID(rw io.Writer, req io.Reader) command.Error {
+	request := &UpdateDIDRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UpdateDIDMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	options := []wallet.UpdateDIDOptions{wallet.WithUpdateDIDMethodOptions(didMethodOptions(request.Opts)...)}
+
+	if len(request.DIDDocument) > 0 {
+		didDoc, e := did.ParseDocument(request.DIDDocument)
+		if e != nil {
+			logutil.LogInfo(logger, CommandName, UpdateDIDMethod, e.Error())
+
+			return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("parse did doc: %w", e))
+		}
+
+		options = append(options, wallet.WithUpdatedDIDDocument(didDoc))
+	}
+
+	if request.RotateKeys {
+		options = append(options, wallet.WithKeyRotation())
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UpdateDIDMethod, err.Error())
+
+		return command.NewExecuteError(UpdateDIDErrorCode, err)
+	}
+
+	docResolution, err := vcWallet.UpdateDID(request.Auth, request.DID, options...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UpdateDIDMethod, err.Error())
+
+		return command.NewExecuteError(UpdateDIDErrorCode, err)
+	}
+
+	docResolutionBytes, err := docResolution.JSONBytes()
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UpdateDIDMethod, err.Error())
+
+		return command.NewExecuteError(UpdateDIDErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &UpdateDIDResponse{DIDResolution: docResolutionBytes}, logger)
+
+	logutil.LogDebug(logger, CommandName, UpdateDIDMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// DeactivateDID deactivates DID managed by wallet.
+func (o *Command) DeactivateDID(rw io.Writer, req io.Reader) command.Error {
+	request := &DeactivateDIDRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DeactivateDIDMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DeactivateDIDMethod, err.Error())
+
+		return command.NewExecuteError(DeactivateDIDErrorCode, err)
+	}
+
+	err = vcWallet.DeactivateDID(request.Auth, request.DID, didMethodOptions(request.Opts)...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DeactivateDIDMethod, err.Error())
+
+		return command.NewExecuteError(DeactivateDIDErrorCode, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, DeactivateDIDMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// ListSessions lists all active sessions of given user's wallet.
+func (o *Command) ListSessions(rw io.Writer, req io.Reader) command.Error {
+	request := &ListSessionsRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ListSessionsMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ListSessionsMethod, err.Error())
+
+		return command.NewExecuteError(ListSessionsErrorCode, err)
+	}
+
+	sessions, err := vcWallet.Sessions()
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ListSessionsMethod, err.Error())
+
+		return command.NewExecuteError(ListSessionsErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ListSessionsResponse{Sessions: sessions}, logger)
+
+	logutil.LogDebug(logger, CommandName, ListSessionsMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// RefreshSession replaces given wallet session token with a new token.
+func (o *Command) RefreshSession(rw io.Writer, req io.Reader) command.Error {
+	request := &RefreshSessionRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RefreshSessionMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RefreshSessionMethod, err.Error())
+
+		return command.NewExecuteError(RefreshSessionErrorCode, err)
+	}
+
+	token, err := vcWallet.RefreshSession(request.Auth)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RefreshSessionMethod, err.Error())
+
+		return command.NewExecuteError(RefreshSessionErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RefreshSessionResponse{Token: token}, logger)
+
+	logutil.LogDebug(logger, CommandName, RefreshSessionMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// RevokeSession revokes given user's wallet session by session ID.
+func (o *Command) RevokeSession(rw io.Writer, req io.Reader) command.Error {
+	request := &RevokeSessionRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RevokeSessionMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RevokeSessionMethod, err.Error())
+
+		return command.NewExecuteError(RevokeSessionErrorCode, err)
+	}
+
+	err = vcWallet.RevokeSession(request.SessionID)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RevokeSessionMethod, err.Error())
+
+		return command.NewExecuteError(RevokeSessionErrorCode, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, RevokeSessionMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// LockBackgroundSessions locks all wallet sessions unlocked with 'lockOnBackground' option,
+// to be used when wallet application goes to background.
+func (o *Command) LockBackgroundSessions(rw io.Writer, req io.Reader) command.Error {
+	locked := wallet.LockBackgroundSessions()
+
+	command.WriteNillableResponse(rw, &LockBackgroundSessionsResponse{Locked: locked}, logger)
+
+	logutil.LogDebug(logger, CommandName, LockBackgroundMethod, logSuccess)
+
+	return nil
+}
+
+// prepareSearchOpts prepares wallet search options from search request.
+func prepareSearchOpts(request *SearchRequest) []wallet.SearchOptions {
+	options := []wallet.SearchOptions{
+		wallet.WithIssuanceDateRange(request.IssuedFrom, request.IssuedTo),
+		wallet.WithExpirationDateRange(request.ExpiresFrom, request.ExpiresTo),
+		wallet.WithSortBy(request.SortBy, request.SortDescending),
+		wallet.WithPagination(request.Offset, request.Limit),
+	}
+
+	if len(request.Types) > 0 {
+		options = append(options, wallet.WithTypeFilter(request.Types...))
+	}
+
+	if request.Issuer != "" {
+		options = append(options, wallet.WithIssuerFilter(request.Issuer))
+	}
+
+	for property, value := range request.Subject {
+		options = append(options, wallet.WithSubjectFilter(property, value))
+	}
+
+	if request.CollectionID != "" {
+		options = append(options, wallet.WithCollectionFilter(request.CollectionID))
+	}
+
+	if request.Text != "" {
+		options = append(options, wallet.WithTextFilter(request.Text))
+	}
+
+	return options
+}
+
+// didMethodOptions prepares DID method options from request options.
+func didMethodOptions(opts map[string]interface{}) []vdr.DIDMethodOption {
+	options := make([]vdr.DIDMethodOption, 0, len(opts))
+
+	for k, v := range opts {
+		options = append(options, vdr.WithOption(k, v))
+	}
+
+	return options
+}
+
 // prepareProfileOptions prepares options for creating wallet profile.
 func prepareProfileOptions(rqst *CreateOrUpdateProfileRequest) []wallet.ProfileOptions {
 	var options []wallet.ProfileOptions
@@ -1039,6 +1671,27 @@ func prepareUnlockOptions(rqst *UnlockWalletRequest, conf *Config) ([]wallet.Unl
 	options = append(options, wallet.WithUnlockWebKMSOptions(webkmsOpts...), wallet.WithUnlockEDVOptions(edvOpts...),
 		wallet.WithUnlockExpiry(tokenExpiry))
 
+	idleTimeout := conf.DefaultIdleTimeout
+	if rqst.IdleTimeout > 0 {
+		idleTimeout = rqst.IdleTimeout
+	}
+
+	if idleTimeout > 0 {
+		options = append(options, wallet.WithUnlockIdleTimeout(idleTimeout))
+	}
+
+	if rqst.ConcurrentSession {
+		options = append(options, wallet.WithConcurrentSession())
+	}
+
+	if rqst.LockOnBackground {
+		options = append(options, wallet.WithUnlockLockOnBackground())
+	}
+
+	if conf.DisableSessionPersistence {
+		options = append(options, wallet.WithoutSessionPersistence())
+	}
+
 	return options, nil
 }
 
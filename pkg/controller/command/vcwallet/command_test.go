@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -46,6 +47,7 @@ import (
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	vdrpkg "github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/wallet"
 )
@@ -259,7 +261,7 @@ func TestNew(t *testing.T) {
 		cmd := New(newMockProvider(t), &Config{})
 		require.NotNil(t, cmd)
 
-		require.Len(t, cmd.GetHandlers(), 20)
+		require.Len(t, cmd.GetHandlers(), 34)
 	})
 }
 
@@ -2517,6 +2519,163 @@ func TestCommand_RequestCredential(t *testing.T) {
 	})
 }
 
+func TestCommand_ExportImport(t *testing.T) {
+	const (
+		sampleUser1 = "sample-user-01"
+		sampleUser2 = "sample-user-02"
+	)
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser2,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token1, lock1 := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock1()
+
+	token2, lock2 := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser2,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock2()
+
+	addContent(t, mockctx, &AddContentRequest{
+		Content:     []byte(sampleMetadata),
+		ContentType: wallet.Metadata,
+		WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token1},
+	})
+
+	var exported json.RawMessage
+
+	t.Run("successfully export and import wallet", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token1},
+			Passphrase: samplePassPhrase,
+		}))
+		require.NoError(t, cmdErr)
+
+		var response ExportResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.NotEmpty(t, response.Wallet)
+
+		exported = response.Wallet
+
+		b.Reset()
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser2, Auth: token2},
+			Wallet:     exported,
+			Passphrase: samplePassPhrase,
+		}))
+		require.NoError(t, cmdErr)
+
+		cmdErr = cmd.Get(&b, getReader(t, &GetContentRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUser2, Auth: token2},
+			ContentType: wallet.Metadata,
+			ContentID:   "urn:uuid:2905324a-9524-11ea-bb37-0242ac130002",
+		}))
+		require.NoError(t, cmdErr)
+
+		var content GetContentResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&content))
+		require.JSONEq(t, sampleMetadata, string(content.Content))
+	})
+
+	t.Run("export wallet failures", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.Export(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+
+		cmdErr = cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: token1},
+			Passphrase: samplePassPhrase,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token1},
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "passphrase or key ID is required")
+		require.Empty(t, b.Bytes())
+	})
+
+	t.Run("import wallet failures", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.Import(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: token2},
+			Wallet:     exported,
+			Passphrase: samplePassPhrase,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportRequest{
+			WalletAuth:     WalletAuth{UserID: sampleUser2, Auth: token2},
+			Wallet:         exported,
+			Passphrase:     samplePassPhrase,
+			ConflictPolicy: wallet.ImportFailOnConflict,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "already exists")
+		require.Empty(t, b.Bytes())
+	})
+
+	t.Run("export wallet having keys which can't be exported", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.CreateKeyPair(&b, getReader(t, &CreateKeyPairRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token1},
+			KeyType:    kms.X25519ECDHKWType,
+		}))
+		require.NoError(t, cmdErr)
+
+		b.Reset()
+
+		cmdErr = cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token1},
+			Passphrase: samplePassPhrase,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "key material is not exportable")
+		require.Empty(t, b.Bytes())
+
+		cmdErr = cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth:           WalletAuth{UserID: sampleUser1, Auth: token1},
+			Passphrase:           samplePassPhrase,
+			SkipUnexportableKeys: true,
+		}))
+		require.NoError(t, cmdErr)
+
+		var response ExportResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.NotEmpty(t, response.Wallet)
+	})
+}
+
 func createSampleUserProfile(t *testing.T, ctx *mockprovider.Provider, request *CreateOrUpdateProfileRequest) {
 	cmd := New(ctx, &Config{})
 	require.NotNil(t, cmd)
@@ -2578,6 +2737,297 @@ func validateError(t *testing.T, err command.Error,
 	}
 }
 
+func TestCommand_DIDManagement(t *testing.T) {
+	const sampleUser1 = "sample-user-01"
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	var updated *did.Doc
+
+	mockctx := newMockProvider(t)
+	mockctx.CryptoValue = cryptoSvc
+	mockctx.VDRegistryValue = &mockvdr.MockVDRegistry{
+		CreateFunc: vdrpkg.New(vdrpkg.WithVDR(key.New())).Create,
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			return key.New().Read(didID)
+		},
+		UpdateFunc: func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
+			updated = didDoc
+
+			return nil
+		},
+		DeactivateFunc: func(didID string, opts ...vdrapi.DIDMethodOption) error {
+			return nil
+		},
+	}
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	walletAuth := WalletAuth{UserID: sampleUser1, Auth: token}
+
+	var didID string
+
+	t.Run("successfully create, list, update and deactivate DIDs", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.CreateDID(&b, getReader(t, &CreateDIDRequest{
+			WalletAuth: walletAuth,
+			Method:     key.DIDMethod,
+			KeyType:    kms.ED25519Type,
+			Default:    true,
+		}))
+		require.NoError(t, cmdErr)
+
+		var created CreateDIDResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&created))
+
+		docResolution, err := did.ParseDocumentResolution(created.DIDResolution)
+		require.NoError(t, err)
+
+		didID = docResolution.DIDDocument.ID
+
+		b.Reset()
+
+		cmdErr = cmd.ListDIDs(&b, getReader(t, &ListDIDsRequest{WalletAuth: walletAuth}))
+		require.NoError(t, cmdErr)
+
+		var list ListDIDsResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&list))
+		require.Len(t, list.DIDs, 1)
+		require.Equal(t, didID, list.DIDs[0].ID)
+		require.True(t, list.DIDs[0].Default)
+
+		// default DID is used as controller.
+		b.Reset()
+
+		cmdErr = cmd.Issue(&b, getReader(t, &IssueRequest{
+			WalletAuth:   walletAuth,
+			Credential:   []byte(sampleUDCVC),
+			ProofOptions: &wallet.ProofOptions{},
+		}))
+		require.NoError(t, cmdErr)
+
+		credential := parseCredential(t, b)
+		require.Len(t, credential.Proofs, 1)
+		require.Equal(t, list.DIDs[0].KeyBindings[0].VerificationMethod, credential.Proofs[0]["verificationMethod"])
+
+		b.Reset()
+
+		cmdErr = cmd.SetDefaultDID(&b, getReader(t, &SetDefaultDIDRequest{WalletAuth: walletAuth, DID: didID}))
+		require.NoError(t, cmdErr)
+
+		cmdErr = cmd.UpdateDID(&b, getReader(t, &UpdateDIDRequest{
+			WalletAuth: walletAuth,
+			DID:        didID,
+			RotateKeys: true,
+			Opts:       map[string]interface{}{"sample": "value"},
+		}))
+		require.NoError(t, cmdErr)
+
+		var updateResponse UpdateDIDResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&updateResponse))
+		require.NotEmpty(t, updateResponse.DIDResolution)
+		require.Equal(t, didID, updated.ID)
+
+		b.Reset()
+
+		cmdErr = cmd.DeactivateDID(&b, getReader(t, &DeactivateDIDRequest{WalletAuth: walletAuth, DID: didID}))
+		require.NoError(t, cmdErr)
+		require.Empty(t, b.Bytes())
+	})
+
+	t.Run("DID management failures", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+
+		for _, fn := range []func(io.Writer, io.Reader) command.Error{
+			cmd.CreateDID, cmd.ListDIDs, cmd.SetDefaultDID, cmd.UpdateDID, cmd.DeactivateDID,
+		} {
+			validateError(t, fn(&b, bytes.NewBufferString("--")), command.ValidationError,
+				InvalidRequestErrorCode, "invalid character")
+		}
+
+		invalidUser := WalletAuth{UserID: sampleUserID, Auth: token}
+
+		cmdErr := cmd.CreateDID(&b, getReader(t, &CreateDIDRequest{WalletAuth: invalidUser, Method: key.DIDMethod}))
+		validateError(t, cmdErr, command.ExecuteError, CreateDIDErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.CreateDID(&b, getReader(t, &CreateDIDRequest{WalletAuth: walletAuth, Method: "invalid"}))
+		validateError(t, cmdErr, command.ExecuteError, CreateDIDErrorCode, "failed to create DID")
+
+		cmdErr = cmd.ListDIDs(&b, getReader(t, &ListDIDsRequest{WalletAuth: invalidUser}))
+		validateError(t, cmdErr, command.ExecuteError, ListDIDsErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.ListDIDs(&b, getReader(t, &ListDIDsRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ListDIDsErrorCode, "invalid auth token")
+
+		cmdErr = cmd.SetDefaultDID(&b, getReader(t, &SetDefaultDIDRequest{WalletAuth: invalidUser, DID: didID}))
+		validateError(t, cmdErr, command.ExecuteError, SetDefaultDIDErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.SetDefaultDID(&b, getReader(t, &SetDefaultDIDRequest{WalletAuth: walletAuth, DID: didID}))
+		validateError(t, cmdErr, command.ExecuteError, SetDefaultDIDErrorCode, "is deactivated")
+
+		cmdErr = cmd.UpdateDID(&b, getReader(t, &UpdateDIDRequest{
+			WalletAuth: walletAuth, DID: didID, DIDDocument: []byte("{}"),
+		}))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "parse did doc")
+
+		cmdErr = cmd.UpdateDID(&b, getReader(t, &UpdateDIDRequest{WalletAuth: invalidUser, DID: didID}))
+		validateError(t, cmdErr, command.ExecuteError, UpdateDIDErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.UpdateDID(&b, getReader(t, &UpdateDIDRequest{WalletAuth: walletAuth, DID: didID}))
+		validateError(t, cmdErr, command.ExecuteError, UpdateDIDErrorCode, "is deactivated")
+
+		cmdErr = cmd.DeactivateDID(&b, getReader(t, &DeactivateDIDRequest{WalletAuth: invalidUser, DID: didID}))
+		validateError(t, cmdErr, command.ExecuteError, DeactivateDIDErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.DeactivateDID(&b, getReader(t, &DeactivateDIDRequest{WalletAuth: walletAuth, DID: didID}))
+		validateError(t, cmdErr, command.ExecuteError, DeactivateDIDErrorCode, "already deactivated")
+		require.Empty(t, b.Bytes())
+	})
+}
+
+func TestCommand_SessionManagement(t *testing.T) {
+	const sampleUser1 = "sample-user-s01"
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	t.Run("successfully open concurrent sessions, list, refresh and revoke", func(t *testing.T) {
+		cmd := New(mockctx, &Config{DefaultIdleTimeout: time.Minute})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.Open(&b, getReader(t, &UnlockWalletRequest{
+			UserID:             sampleUser1,
+			LocalKMSPassphrase: samplePassPhrase,
+			ConcurrentSession:  true,
+			LockOnBackground:   true,
+		}))
+		require.NoError(t, cmdErr)
+
+		backgroundToken := getUnlockToken(t, b)
+		require.NotEqual(t, token, backgroundToken)
+
+		b.Reset()
+
+		cmdErr = cmd.ListSessions(&b, getReader(t, &ListSessionsRequest{UserID: sampleUser1}))
+		require.NoError(t, cmdErr)
+
+		var list ListSessionsResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&list))
+		require.Len(t, list.Sessions, 2)
+		require.False(t, list.Sessions[0].LockOnBackground)
+		require.Zero(t, list.Sessions[0].IdleTimeout)
+		require.True(t, list.Sessions[1].LockOnBackground)
+		require.Equal(t, time.Minute, list.Sessions[1].IdleTimeout)
+
+		b.Reset()
+
+		cmdErr = cmd.RefreshSession(&b, getReader(t, &RefreshSessionRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token},
+		}))
+		require.NoError(t, cmdErr)
+
+		var refreshed RefreshSessionResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&refreshed))
+		require.NotEmpty(t, refreshed.Token)
+		require.NotEqual(t, token, refreshed.Token)
+
+		token = refreshed.Token
+
+		b.Reset()
+
+		cmdErr = cmd.LockBackgroundSessions(&b, bytes.NewBufferString("{}"))
+		require.NoError(t, cmdErr)
+
+		var locked LockBackgroundSessionsResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&locked))
+		require.Equal(t, 1, locked.Locked)
+
+		b.Reset()
+
+		cmdErr = cmd.GetAll(&b, getReader(t, &GetAllContentRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: backgroundToken},
+			ContentType: wallet.Metadata,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, GetAllFromWalletErrorCode, "invalid auth token")
+
+		cmdErr = cmd.RevokeSession(&b, getReader(t, &RevokeSessionRequest{
+			UserID:    sampleUser1,
+			SessionID: list.Sessions[0].ID,
+		}))
+		require.NoError(t, cmdErr)
+		require.Empty(t, b.Bytes())
+
+		cmdErr = cmd.GetAll(&b, getReader(t, &GetAllContentRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token},
+			ContentType: wallet.Metadata,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, GetAllFromWalletErrorCode, "wallet locked")
+	})
+
+	t.Run("session management failures", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+
+		for _, fn := range []func(io.Writer, io.Reader) command.Error{
+			cmd.ListSessions, cmd.RefreshSession, cmd.RevokeSession,
+		} {
+			validateError(t, fn(&b, bytes.NewBufferString("--")), command.ValidationError,
+				InvalidRequestErrorCode, "invalid character")
+		}
+
+		cmdErr := cmd.ListSessions(&b, getReader(t, &ListSessionsRequest{UserID: sampleUserID}))
+		validateError(t, cmdErr, command.ExecuteError, ListSessionsErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.RefreshSession(&b, getReader(t, &RefreshSessionRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: token},
+		}))
+		validateError(t, cmdErr, command.ExecuteError, RefreshSessionErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.RefreshSession(&b, getReader(t, &RefreshSessionRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+		}))
+		validateError(t, cmdErr, command.ExecuteError, RefreshSessionErrorCode, "invalid auth token")
+
+		cmdErr = cmd.RevokeSession(&b, getReader(t, &RevokeSessionRequest{UserID: sampleUserID}))
+		validateError(t, cmdErr, command.ExecuteError, RevokeSessionErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.RevokeSession(&b, getReader(t, &RevokeSessionRequest{UserID: sampleUser1, SessionID: "invalid"}))
+		validateError(t, cmdErr, command.ExecuteError, RevokeSessionErrorCode, "wallet session not found")
+		require.Empty(t, b.Bytes())
+	})
+}
+
 func newMockProvider(t *testing.T) *mockprovider.Provider {
 	t.Helper()
 
@@ -2683,3 +3133,249 @@ func (m *mockMsg) ParentThreadID() string {
 func (m *mockMsg) ThreadID() (string, error) {
 	return m.thID, nil
 }
+
+func TestCommand_Search(t *testing.T) {
+	const sampleUser1 = "sample-user-search01"
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	cmd := New(mockctx, &Config{})
+
+	for i := 1; i <= 3; i++ {
+		var b bytes.Buffer
+
+		vc := strings.ReplaceAll(sampleUDCVC, `"http://example.edu/credentials/1877"`,
+			fmt.Sprintf(`"http://example.edu/credentials/1877%d"`, i))
+		vc = strings.ReplaceAll(vc, `"2010-01-01T19:23:24Z"`, fmt.Sprintf(`"201%d-01-01T19:23:24Z"`, i))
+
+		cmdErr := cmd.Add(&b, getReader(t, &AddContentRequest{
+			Content:     []byte(vc),
+			ContentType: wallet.Credential,
+			WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token},
+		}))
+		require.NoError(t, cmdErr)
+	}
+
+	t.Run("successfully search wallet contents", func(t *testing.T) {
+		issuedFrom := time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.Search(&b, getReader(t, &SearchRequest{
+			WalletAuth:     WalletAuth{UserID: sampleUser1, Auth: token},
+			ContentType:    wallet.Credential,
+			Types:          []string{"UniversityDegreeCredential"},
+			Issuer:         "did:example:76e12ec712ebc6f1c221ebfeb1f",
+			Subject:        map[string]string{"name": "Jayden Doe"},
+			IssuedFrom:     &issuedFrom,
+			SortBy:         wallet.SortByIssuanceDate,
+			SortDescending: true,
+			Limit:          1,
+		}))
+		require.NoError(t, cmdErr)
+
+		var response SearchResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, 2, response.Total)
+		require.Len(t, response.Contents, 1)
+		require.Equal(t, "http://example.edu/credentials/18773", response.Contents[0].ID)
+
+		b.Reset()
+
+		cmdErr = cmd.Search(&b, getReader(t, &SearchRequest{
+			WalletAuth:   WalletAuth{UserID: sampleUser1, Auth: token},
+			ContentType:  wallet.Credential,
+			CollectionID: "did:example:unknown",
+		}))
+		require.NoError(t, cmdErr)
+
+		response = SearchResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Zero(t, response.Total)
+		require.Empty(t, response.Contents)
+	})
+
+	t.Run("search failures", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.Search(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+
+		cmdErr = cmd.Search(&b, getReader(t, &SearchRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUserID, Auth: token},
+			ContentType: wallet.Credential,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, SearchErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.Search(&b, getReader(t, &SearchRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token},
+			ContentType: wallet.Credential,
+			SortBy:      "invalid",
+		}))
+		validateError(t, cmdErr, command.ExecuteError, SearchErrorCode, "invalid sort field")
+
+		cmdErr = cmd.Search(&b, getReader(t, &SearchRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			ContentType: wallet.Credential,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, SearchErrorCode, "invalid auth token")
+	})
+}
+
+func TestCommand_PresentationRequest(t *testing.T) {
+	const (
+		sampleUser1 = "sample-user-vpr01"
+		sampleVPR   = `{
+			"query": [{
+				"type": "QueryByExample",
+				"credentialQuery": {"example": {
+					"@context": ["https://www.w3.org/2018/credentials/v1"],
+					"type": ["UniversityDegreeCredential"]
+				}}
+			}, {"type": "DIDAuth"}],
+			"challenge": "sample-vpr-challenge",
+			"interact": {"service": [{"type": "UnmediatedHttpPresentationService2021", "serviceEndpoint": "%s"}]}
+		}`
+	)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx := newMockProvider(t)
+	mockctx.CryptoValue = cryptoSvc
+	mockctx.VDRegistryValue = &mockvdr.MockVDRegistry{
+		CreateFunc: vdrpkg.New(vdrpkg.WithVDR(key.New())).Create,
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			return key.New().Read(didID)
+		},
+	}
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	walletAuth := WalletAuth{UserID: sampleUser1, Auth: token}
+
+	cmd := New(mockctx, &Config{})
+
+	var b bytes.Buffer
+
+	require.NoError(t, cmd.CreateDID(&b, getReader(t, &CreateDIDRequest{
+		WalletAuth: walletAuth,
+		Method:     key.DIDMethod,
+		Default:    true,
+	})))
+
+	b.Reset()
+
+	require.NoError(t, cmd.Add(&b, getReader(t, &AddContentRequest{
+		Content:     []byte(strings.ReplaceAll(sampleUDCVC, `"credentialSchema": [],`, "")),
+		ContentType: wallet.Credential,
+		WalletAuth:  walletAuth,
+	})))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"redirectUrl": "https://verifier.example/done"}`))
+	}))
+	defer server.Close()
+
+	vpr := json.RawMessage(fmt.Sprintf(sampleVPR, server.URL))
+
+	t.Run("successfully create presentation response", func(t *testing.T) {
+		b.Reset()
+
+		cmdErr := cmd.CreatePresentationResponse(&b, getReader(t, &CreatePresentationResponseRequest{
+			WalletAuth:          walletAuth,
+			PresentationRequest: vpr,
+		}))
+		require.NoError(t, cmdErr)
+
+		vp := parsePresentation(t, b)
+		require.Len(t, vp.Credentials(), 1)
+		require.Len(t, vp.Proofs, 1)
+		require.Equal(t, "sample-vpr-challenge", vp.Proofs[0]["challenge"])
+	})
+
+	t.Run("successfully respond to presentation request", func(t *testing.T) {
+		b.Reset()
+
+		cmd := New(mockctx, &Config{HTTPClient: server.Client()})
+
+		cmdErr := cmd.RespondPresentationRequest(&b, getReader(t, &RespondPresentationRequest{
+			WalletAuth:          walletAuth,
+			PresentationRequest: vpr,
+		}))
+		require.NoError(t, cmdErr)
+
+		var response struct {
+			Presentation json.RawMessage
+			RedirectURL  string
+		}
+
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.NotEmpty(t, response.Presentation)
+		require.Equal(t, "https://verifier.example/done", response.RedirectURL)
+	})
+
+	t.Run("presentation request failures", func(t *testing.T) {
+		for _, fn := range []func(io.Writer, io.Reader) command.Error{
+			cmd.CreatePresentationResponse, cmd.RespondPresentationRequest,
+		} {
+			validateError(t, fn(&b, bytes.NewBufferString("--")), command.ValidationError,
+				InvalidRequestErrorCode, "invalid character")
+
+			validateError(t, fn(&b, getReader(t, &CreatePresentationResponseRequest{
+				WalletAuth:          walletAuth,
+				PresentationRequest: json.RawMessage(`{}`),
+			})), command.ValidationError, InvalidRequestErrorCode, "failed to parse presentation request")
+		}
+
+		cmdErr := cmd.CreatePresentationResponse(&b, getReader(t, &CreatePresentationResponseRequest{
+			WalletAuth:          WalletAuth{UserID: sampleUserID, Auth: token},
+			PresentationRequest: vpr,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, CreatePresentationResponseErrorCode,
+			"failed to get VC wallet profile")
+
+		cmdErr = cmd.CreatePresentationResponse(&b, getReader(t, &CreatePresentationResponseRequest{
+			WalletAuth:          WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			PresentationRequest: vpr,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, CreatePresentationResponseErrorCode, "invalid auth token")
+
+		cmdErr = cmd.RespondPresentationRequest(&b, getReader(t, &RespondPresentationRequest{
+			WalletAuth:          WalletAuth{UserID: sampleUserID, Auth: token},
+			PresentationRequest: vpr,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, RespondPresentationRequestErrorCode,
+			"failed to get VC wallet profile")
+
+		cmdErr = cmd.RespondPresentationRequest(&b, getReader(t, &RespondPresentationRequest{
+			WalletAuth:          walletAuth,
+			PresentationRequest: vpr,
+			ServiceType:         wallet.MediatedHTTPPresentationService,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, RespondPresentationRequestErrorCode,
+			"no supported interact service")
+	})
+}
@@ -72,6 +72,18 @@ type UnlockWalletRequest struct {
 
 	// Time duration in milliseconds after which wallet will expire its unlock status.
 	Expiry time.Duration `json:"expiry,omitempty"`
+
+	// Time duration of inactivity after which wallet will expire its unlock status.
+	// Optional, idle timeout is disabled by default unless configured in command controller.
+	IdleTimeout time.Duration `json:"idleTimeout,omitempty"`
+
+	// ConcurrentSession if true, then wallet will be unlocked in a new session even if it is already unlocked.
+	// Optional, by default wallet can be unlocked only once until it is closed or expired.
+	ConcurrentSession bool `json:"concurrentSession,omitempty"`
+
+	// LockOnBackground if true, then this session will be locked when wallet application goes to background.
+	// Optional, typically used by mobile wallet applications.
+	LockOnBackground bool `json:"lockOnBackground,omitempty"`
 }
 
 // UnlockAuth contains different options for authorizing access to wallet's EDV content store & webkms.
@@ -206,6 +218,58 @@ type ContentQueryResponse struct {
 	Results []*verifiable.Presentation `json:"results"`
 }
 
+// SearchRequest is request model for searching wallet contents.
+type SearchRequest struct {
+	WalletAuth
+
+	// type of the contents to be searched.
+	// supported types: collection, credential, didResolutionResponse, metadata, connection, managedDID
+	ContentType wallet.ContentType `json:"contentType"`
+
+	// (optional) types which contents should have, all given types should match.
+	Types []string `json:"types,omitempty"`
+
+	// (optional) ID of the issuer of the contents.
+	Issuer string `json:"issuer,omitempty"`
+
+	// (optional) top level credential subject properties and their values, all given properties should match.
+	Subject map[string]string `json:"subject,omitempty"`
+
+	// (optional) ID of the collection to which contents belong.
+	CollectionID string `json:"collectionID,omitempty"`
+
+	// (optional) words to be searched in name and description of the contents.
+	Text string `json:"text,omitempty"`
+
+	// (optional) issuance date range of the contents.
+	IssuedFrom *time.Time `json:"issuedFrom,omitempty"`
+	IssuedTo   *time.Time `json:"issuedTo,omitempty"`
+
+	// (optional) expiration date range of the contents.
+	ExpiresFrom *time.Time `json:"expiresFrom,omitempty"`
+	ExpiresTo   *time.Time `json:"expiresTo,omitempty"`
+
+	// (optional) field by which search results to be sorted.
+	// supported fields: issuanceDate, expirationDate, name
+	SortBy wallet.SortField `json:"sortBy,omitempty"`
+
+	// (optional) sort search results in descending order.
+	SortDescending bool `json:"sortDescending,omitempty"`
+
+	// (optional) pagination offset and limit of search results, limit '0' returns all results from offset.
+	Offset int `json:"offset,omitempty"`
+	Limit  int `json:"limit,omitempty"`
+}
+
+// SearchResponse is response model for searching wallet contents.
+type SearchResponse struct {
+	// total number of contents matching search filters.
+	Total int `json:"total"`
+
+	// contents found in requested page of search results.
+	Contents []*wallet.SearchResultItem `json:"contents"`
+}
+
 // IssueRequest is request model for issuing credential from wallet.
 type IssueRequest struct {
 	WalletAuth
@@ -247,6 +311,51 @@ type ProveResponse struct {
 	Presentation *verifiable.Presentation `json:"presentation"`
 }
 
+// CreatePresentationResponseRequest is request model for creating presentation responding to
+// verifiable presentation request.
+type CreatePresentationResponseRequest struct {
+	WalletAuth
+
+	// verifiable presentation request, or message containing it under 'verifiablePresentationRequest'.
+	// https://w3c-ccg.github.io/vp-request-spec/#format
+	PresentationRequest json.RawMessage `json:"presentationRequest"`
+
+	// (optional) proof options for signing presentation, challenge and domain are taken from presentation request.
+	ProofOptions *wallet.ProofOptions `json:"proofOptions,omitempty"`
+}
+
+// RespondPresentationRequest is request model for responding to verifiable presentation request through
+// interact service of the presentation request.
+type RespondPresentationRequest struct {
+	WalletAuth
+
+	// verifiable presentation request having interact services,
+	// or message containing it under 'verifiablePresentationRequest'.
+	PresentationRequest json.RawMessage `json:"presentationRequest"`
+
+	// (optional) proof options for signing presentation, challenge and domain are taken from presentation request.
+	ProofOptions *wallet.ProofOptions `json:"proofOptions,omitempty"`
+
+	// (optional) type of the interact service to be used,
+	// by default 'UnmediatedHttpPresentationService2021' is preferred over 'MediatedHttpPresentationService2021'.
+	ServiceType string `json:"serviceType,omitempty"`
+}
+
+// RespondPresentationResponse is response model for responding to verifiable presentation request.
+type RespondPresentationResponse struct {
+	// presentation sent to interact service.
+	Presentation *verifiable.Presentation `json:"presentation"`
+
+	// presentation received from interact service, usually containing credentials issued in exchange.
+	VerifiablePresentation json.RawMessage `json:"verifiablePresentation,omitempty"`
+
+	// presentation request received from interact service for continuing the exchange.
+	VerifiablePresentationRequest *wallet.VerifiablePresentationRequest `json:"verifiablePresentationRequest,omitempty"`
+
+	// URL where holder has to continue the exchange.
+	RedirectURL string `json:"redirectUrl,omitempty"`
+}
+
 // VerifyRequest request for verifying a credential or presentation from wallet.
 // Any one of the credential option should be used.
 type VerifyRequest struct {
@@ -441,3 +550,157 @@ type RequestCredentialRequest struct {
 type RequestCredentialResponse struct {
 	wallet.CredentialInteractionStatus
 }
+
+// ExportRequest is request model for exporting wallet contents.
+type ExportRequest struct {
+	WalletAuth
+
+	// passphrase for encrypting exported wallet contents.
+	Passphrase string `json:"passphrase,omitempty"`
+
+	// ID of the wallet key manager key for encrypting exported wallet contents.
+	// Used only if passphrase is not provided.
+	KeyID string `json:"keyID,omitempty"`
+
+	// (optional) export wallet without keys of wallet key manager which can't be exported, like key agreement keys,
+	// instead of failing export.
+	SkipUnexportableKeys bool `json:"skipUnexportableKeys,omitempty"`
+}
+
+// ExportResponse is response model for exporting wallet contents.
+type ExportResponse struct {
+	// exported wallet with encrypted contents.
+	Wallet json.RawMessage `json:"wallet"`
+}
+
+// ImportRequest is request model for importing wallet contents.
+type ImportRequest struct {
+	WalletAuth
+
+	// exported wallet to be imported.
+	Wallet json.RawMessage `json:"wallet"`
+
+	// passphrase used for exporting the wallet.
+	// If not provided then wallet key manager key used for exporting will be used for decryption.
+	Passphrase string `json:"passphrase,omitempty"`
+
+	// policy for handling imported contents already present in wallet.
+	// supported policies: skip, replace, fail. Default is skip.
+	ConflictPolicy wallet.ImportConflictPolicy `json:"conflictPolicy,omitempty"`
+}
+
+// CreateDIDRequest is request model for creating DID from wallet.
+type CreateDIDRequest struct {
+	WalletAuth
+
+	// DID method of the DID to be created, like 'key', 'jwk', 'peer'.
+	Method string `json:"method"`
+
+	// Type of the wallet key to be created for the DID.
+	// Optional, by default 'ED25519' key will be created.
+	KeyType kms.KeyType `json:"keyType,omitempty"`
+
+	// Default is true for setting created DID as default DID for signing.
+	Default bool `json:"default,omitempty"`
+
+	// DID method specific options.
+	Opts map[string]interface{} `json:"opts,omitempty"`
+}
+
+// CreateDIDResponse is response model for creating DID from wallet.
+type CreateDIDResponse struct {
+	// DID resolution of created DID.
+	DIDResolution json.RawMessage `json:"didResolution"`
+}
+
+// ListDIDsRequest is request model for listing DIDs managed by wallet.
+type ListDIDsRequest struct {
+	WalletAuth
+}
+
+// ListDIDsResponse is response model for listing DIDs managed by wallet.
+type ListDIDsResponse struct {
+	// DIDs managed by wallet.
+	DIDs []*wallet.DIDRecord `json:"dids"`
+}
+
+// SetDefaultDIDRequest is request model for setting default wallet DID for signing.
+type SetDefaultDIDRequest struct {
+	WalletAuth
+
+	// ID of the DID managed by wallet.
+	DID string `json:"did"`
+}
+
+// UpdateDIDRequest is request model for updating DID managed by wallet.
+type UpdateDIDRequest struct {
+	WalletAuth
+
+	// ID of the DID managed by wallet.
+	DID string `json:"did"`
+
+	// DID document to replace current DID document of the DID.
+	// Optional, by default current DID document saved in wallet will be updated.
+	DIDDocument json.RawMessage `json:"didDocument,omitempty"`
+
+	// RotateKeys is true for rotating wallet keys bound to the DID.
+	RotateKeys bool `json:"rotateKeys,omitempty"`
+
+	// DID method specific options.
+	Opts map[string]interface{} `json:"opts,omitempty"`
+}
+
+// UpdateDIDResponse is response model for updating DID managed by wallet.
+type UpdateDIDResponse struct {
+	// DID resolution of updated DID.
+	DIDResolution json.RawMessage `json:"didResolution"`
+}
+
+// DeactivateDIDRequest is request model for deactivating DID managed by wallet.
+type DeactivateDIDRequest struct {
+	WalletAuth
+
+	// ID of the DID managed by wallet.
+	DID string `json:"did"`
+
+	// DID method specific options.
+	Opts map[string]interface{} `json:"opts,omitempty"`
+}
+
+// ListSessionsRequest is request model for listing wallet sessions.
+type ListSessionsRequest struct {
+	// user ID of the wallet.
+	UserID string `json:"userID"`
+}
+
+// ListSessionsResponse is response model for listing wallet sessions.
+type ListSessionsResponse struct {
+	// active sessions of the wallet, session tokens are not included.
+	Sessions []*wallet.Session `json:"sessions"`
+}
+
+// RefreshSessionRequest is request model for refreshing wallet session token.
+type RefreshSessionRequest struct {
+	WalletAuth
+}
+
+// RefreshSessionResponse is response model for refreshing wallet session token.
+type RefreshSessionResponse struct {
+	// new token of the session, token used in request can't be used anymore.
+	Token string `json:"token"`
+}
+
+// RevokeSessionRequest is request model for revoking wallet session.
+type RevokeSessionRequest struct {
+	// user ID of the wallet.
+	UserID string `json:"userID"`
+
+	// ID of the session to be revoked.
+	SessionID string `json:"sessionID"`
+}
+
+// LockBackgroundSessionsResponse is response model for locking wallet sessions when application goes to background.
+type LockBackgroundSessionsResponse struct {
+	// number of sessions locked.
+	Locked int `json:"locked"`
+}
@@ -11,6 +11,7 @@ import (
 	"net/http"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	actionmenucmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/connection"
 	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
 	introducecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
@@ -26,6 +27,7 @@ import (
 	vdrcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	actionmenurest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/actionmenu"
 	connectionrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/connection"
 	didexchangerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
 	introducerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
@@ -38,6 +40,7 @@ import (
 	outofbandv2rest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofbandv2"
 	presentproofrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/rfc0593"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcapi"
 	vcwalletrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcwallet"
 	vdrrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdr"
 	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
@@ -59,6 +62,7 @@ type allOpts struct {
 	msgHandler         command.MessageHandler
 	notifier           command.Notifier
 	walletConf         *vcwalletcmd.Config
+	vcAPIConf          *vcapi.Config
 	httpClient         HTTPClient
 	ldService          ldsvc.Service
 }
@@ -117,6 +121,13 @@ func WithWalletConfiguration(conf *vcwalletcmd.Config) Opt {
 	}
 }
 
+// WithVCAPIConfiguration is an option for configuring VC-API endpoints of REST controller.
+func WithVCAPIConfiguration(conf *vcapi.Config) Opt {
+	return func(opts *allOpts) {
+		opts.vcAPIConf = conf
+	}
+}
+
 // WithHTTPClient is an option for setting up a custom HTTP client.
 func WithHTTPClient(client HTTPClient) Opt {
 	return func(opts *allOpts) {
@@ -205,6 +216,12 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		return nil, fmt.Errorf("create introduce rest command : %w", err)
 	}
 
+	// action-menu REST operation
+	actionMenuOp, err := actionmenurest.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("create action-menu rest command : %w", err)
+	}
+
 	// outofband REST operation
 	outofbandOp, err := outofbandrest.New(ctx, notifier)
 	if err != nil {
@@ -223,6 +240,12 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	// vc wallet command controller
 	wallet := vcwalletrest.New(ctx, restAPIOpts.walletConf)
 
+	// VC-API REST operation
+	vcAPIOp, err := vcapi.New(ctx, restAPIOpts.vcAPIConf)
+	if err != nil {
+		return nil, fmt.Errorf("create vc-api rest command : %w", err)
+	}
+
 	// JSON-LD REST operation
 	ldOp := ldrest.New(restAPIOpts.ldService, ldrest.WithHTTPClient(restAPIOpts.httpClient))
 
@@ -242,10 +265,12 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	allHandlers = append(allHandlers, rfc0593Op.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, presentproofOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, introduceOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, actionMenuOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, outofbandOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, outofbandV2Op.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, kmscmd.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, wallet.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, vcAPIOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, ldOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, connOp.GetRESTHandlers()...)
 
@@ -327,6 +352,12 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		return nil, fmt.Errorf("create introduce command : %w", err)
 	}
 
+	// action-menu command operation
+	actionMenu, err := actionmenucmd.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("create action-menu command : %w", err)
+	}
+
 	// outofband command operation
 	outofband, err := outofbandcmd.New(ctx, notifier)
 	if err != nil {
@@ -364,6 +395,7 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	allHandlers = append(allHandlers, issuecredential.GetHandlers()...)
 	allHandlers = append(allHandlers, presentproof.GetHandlers()...)
 	allHandlers = append(allHandlers, introduce.GetHandlers()...)
+	allHandlers = append(allHandlers, actionMenu.GetHandlers()...)
 	allHandlers = append(allHandlers, outofband.GetHandlers()...)
 	allHandlers = append(allHandlers, outofbandv2.GetHandlers()...)
 	allHandlers = append(allHandlers, conncmd.GetHandlers()...)
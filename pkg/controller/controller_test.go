@@ -15,6 +15,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcapi"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
@@ -166,3 +167,14 @@ func TestWithWalletConfiguration(t *testing.T) {
 	require.NotNil(t, controllerOpts.walletConf)
 	require.Equal(t, controllerOpts.walletConf.WebKMSCacheSize, 99)
 }
+
+func TestWithVCAPIConfiguration(t *testing.T) {
+	controllerOpts := &allOpts{}
+
+	opt := WithVCAPIConfiguration(&vcapi.Config{BaseURL: "https://agent.example.com"})
+
+	opt(controllerOpts)
+
+	require.NotNil(t, controllerOpts.vcAPIConf)
+	require.Equal(t, "https://agent.example.com", controllerOpts.vcAPIConf.BaseURL)
+}
@@ -24,6 +24,13 @@ type HTTPHandler struct {
 	path   string
 	method string
 	handle http.HandlerFunc
+	public bool
+}
+
+// NewPublicHTTPHandler returns instance of HTTPHandler of the endpoint called by other parties,
+// it is served without the authorization of the controller API.
+func NewPublicHTTPHandler(path, method string, handle http.HandlerFunc) *HTTPHandler {
+	return &HTTPHandler{path: path, method: method, handle: handle, public: true}
 }
 
 // Path returns http request path.
@@ -41,6 +48,11 @@ func (h *HTTPHandler) Handle() http.HandlerFunc {
 	return h.handle
 }
 
+// Public returns true if the endpoint is called by other parties.
+func (h *HTTPHandler) Public() bool {
+	return h.public
+}
+
 // NewCommandHandler returns instance of CommandHandler which can be used handle
 // controller commands.
 func NewCommandHandler(name, method string, exec command.Exec) *CommandHandler {
@@ -30,6 +30,8 @@ func TestNewHTTPHandler(t *testing.T) {
 	require.Equal(t, path, handler.Path())
 	require.Equal(t, method, handler.Method())
 	require.NotNil(t, handler.Handle())
+	require.False(t, handler.Public())
+	require.True(t, NewPublicHTTPHandler(path, method, handlerFn).Public())
 
 	go handler.Handle()(nil, nil)
 
@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+
+// actionMenuSendMenuRequest model
+//
+// This is used for operation to publish a menu.
+//
+// swagger:parameters actionMenuSendMenu
+type actionMenuSendMenuRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MyDID sender's did
+		// required: true
+		MyDID string `json:"my_did"`
+		// TheirDID receiver's did
+		// required: true
+		TheirDID string `json:"their_did"`
+		// Menu is the menu of actions
+		// required: true
+		Menu *protocol.Menu `json:"menu"`
+	}
+}
+
+// actionMenuSendMenuResponse model
+//
+// Represents a SendMenu response message.
+//
+// swagger:response actionMenuSendMenuResponse
+type actionMenuSendMenuResponse struct{} // nolint: unused,deadcode
+
+// actionMenuRequestMenuRequest model
+//
+// This is used for operation to request the menu of a connection.
+//
+// swagger:parameters actionMenuRequestMenu
+type actionMenuRequestMenuRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MyDID sender's did
+		// required: true
+		MyDID string `json:"my_did"`
+		// TheirDID receiver's did
+		// required: true
+		TheirDID string `json:"their_did"`
+	}
+}
+
+// actionMenuRequestMenuResponse model
+//
+// Represents a RequestMenu response message.
+//
+// swagger:response actionMenuRequestMenuResponse
+type actionMenuRequestMenuResponse struct{} // nolint: unused,deadcode
+
+// actionMenuPerformRequest model
+//
+// This is used for operation to perform a menu option.
+//
+// swagger:parameters actionMenuPerform
+type actionMenuPerformRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MyDID sender's did
+		// required: true
+		MyDID string `json:"my_did"`
+		// TheirDID receiver's did
+		// required: true
+		TheirDID string `json:"their_did"`
+		// Perform is the selected menu option and its parameters
+		// required: true
+		Perform *protocol.Perform `json:"perform"`
+	}
+}
+
+// actionMenuPerformResponse model
+//
+// Represents a Perform response message.
+//
+// swagger:response actionMenuPerformResponse
+type actionMenuPerformResponse struct{} // nolint: unused,deadcode
+
+// actionMenuGetMenuRequest model
+//
+// This is used for operation to get the menu received from a connection.
+//
+// swagger:parameters actionMenuGetMenu
+type actionMenuGetMenuRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MyDID my did in the connection
+		// required: true
+		MyDID string `json:"my_did"`
+		// TheirDID did of the connection which sent the menu
+		// required: true
+		TheirDID string `json:"their_did"`
+	}
+}
+
+// actionMenuGetMenuResponse model
+//
+// Represents a GetMenu response message.
+//
+// swagger:response actionMenuGetMenuResponse
+type actionMenuGetMenuResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Menu *protocol.Menu `json:"menu"`
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"fmt"
+	"net/http"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for operation action-menu.
+const (
+	OperationID = "/actionmenu"
+	SendMenu    = OperationID + "/send-menu"
+	RequestMenu = OperationID + "/request-menu"
+	Perform     = OperationID + "/perform"
+	GetMenu     = OperationID + "/get-menu"
+)
+
+// Operation is controller REST service controller for the action-menu.
+type Operation struct {
+	command  *actionmenu.Command
+	handlers []rest.Handler
+}
+
+// New returns new action-menu rest client protocol instance.
+func New(ctx client.Provider, notifier command.Notifier) (*Operation, error) {
+	cmd, err := actionmenu.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("action-menu command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this protocol service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(SendMenu, http.MethodPost, c.SendMenu),
+		cmdutil.NewHTTPHandler(RequestMenu, http.MethodPost, c.RequestMenu),
+		cmdutil.NewHTTPHandler(Perform, http.MethodPost, c.Perform),
+		cmdutil.NewHTTPHandler(GetMenu, http.MethodPost, c.GetMenu),
+	}
+}
+
+// SendMenu swagger:route POST /actionmenu/send-menu action-menu actionMenuSendMenu
+//
+// Publishes a menu to the connection.
+//
+// Responses:
+//    default: genericError
+//        200: actionMenuSendMenuResponse
+func (c *Operation) SendMenu(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.SendMenu, rw, req.Body)
+}
+
+// RequestMenu swagger:route POST /actionmenu/request-menu action-menu actionMenuRequestMenu
+//
+// Requests the menu of the connection.
+//
+// Responses:
+//    default: genericError
+//        200: actionMenuRequestMenuResponse
+func (c *Operation) RequestMenu(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.RequestMenu, rw, req.Body)
+}
+
+// Perform swagger:route POST /actionmenu/perform action-menu actionMenuPerform
+//
+// Performs an option of the menu received from the connection.
+//
+// Responses:
+//    default: genericError
+//        200: actionMenuPerformResponse
+func (c *Operation) Perform(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.Perform, rw, req.Body)
+}
+
+// GetMenu swagger:route POST /actionmenu/get-menu action-menu actionMenuGetMenu
+//
+// Returns the last menu received from the connection.
+//
+// Responses:
+//    default: genericError
+//        200: actionMenuGetMenuResponse
+func (c *Operation) GetMenu(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.GetMenu, rw, req.Body)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/actionmenu"
+	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+)
+
+const validDIDs = `"my_did":"my_did", "their_did":"their_did"`
+
+func provider(ctrl *gomock.Controller) client.Provider {
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+	service.EXPECT().SendMenu(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	service.EXPECT().RequestMenu(gomock.Any(), gomock.Any()).AnyTimes()
+	service.EXPECT().Perform(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	service.EXPECT().GetMenu(gomock.Any(), gomock.Any()).Return(&client.Menu{Title: "Services"}, nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+	return provider
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(nil, errors.New("test error"))
+
+	_, err := New(provider, mocknotifier.NewMockNotifier(nil))
+	require.EqualError(t, err, "action-menu command : cannot create a client: test error")
+}
+
+func TestOperation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{path: SendMenu, body: `{` + validDIDs + `, "menu":{"title":"Services"}}`},
+		{path: RequestMenu, body: `{` + validDIDs + `}`},
+		{path: Perform, body: `{` + validDIDs + `, "perform":{"name":"balance"}}`},
+		{path: GetMenu, body: `{` + validDIDs + `}`},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.path, func(t *testing.T) {
+			operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+			require.NoError(t, err)
+
+			_, code, err := sendRequestToHandler(
+				handlerLookup(t, operation, tc.path),
+				bytes.NewBufferString(tc.body),
+				tc.path,
+			)
+
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, code)
+
+			_, code, err = sendRequestToHandler(
+				handlerLookup(t, operation, tc.path),
+				bytes.NewBufferString(`{}`),
+				tc.path,
+			)
+
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, code)
+		})
+	}
+}
+
+func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == lookup {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
@@ -24,6 +24,19 @@ type Handler interface {
 	Handle() http.HandlerFunc
 }
 
+// PublicHandler is implemented by the handlers of endpoints called by other parties (e.g. holders of
+// the credentials), these are served without the authorization of the controller API.
+type PublicHandler interface {
+	Public() bool
+}
+
+// IsPublic returns true if the handler serves an endpoint called by other parties.
+func IsPublic(handler Handler) bool {
+	h, ok := handler.(PublicHandler)
+
+	return ok && h.Public()
+}
+
 // Execute executes given command with args provided and writes error to
 // response writer.
 func Execute(exec command.Exec, rw http.ResponseWriter, req io.Reader) {
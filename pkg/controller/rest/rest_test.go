@@ -136,3 +136,22 @@ func (m *mockRWriter) Write([]byte) (int, error) {
 }
 
 func (m *mockRWriter) WriteHeader(statusCode int) {}
+
+func TestIsPublic(t *testing.T) {
+	require.False(t, IsPublic(&mockHandler{}))
+	require.False(t, IsPublic(&publicMockHandler{}))
+	require.True(t, IsPublic(&publicMockHandler{public: true}))
+}
+
+type mockHandler struct{}
+
+func (m *mockHandler) Path() string             { return "/sample" }
+func (m *mockHandler) Method() string           { return http.MethodGet }
+func (m *mockHandler) Handle() http.HandlerFunc { return nil }
+
+type publicMockHandler struct {
+	mockHandler
+	public bool
+}
+
+func (m *publicMockHandler) Public() bool { return m.public }
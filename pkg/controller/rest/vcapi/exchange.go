@@ -0,0 +1,225 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/wallet"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const transactionKeyPrefix = "transaction_"
+
+// errTransactionNotFound is returned when exchange transaction doesn't exist.
+var errTransactionNotFound = errors.New("exchange transaction not found")
+
+// InitiateExchange swagger:route POST /exchanges/{exchangeId} vc-api initiateExchangeReq
+//
+// Initiates an exchange by starting a new transaction. The response is a verifiable presentation request
+// whose interact service is the endpoint of the transaction.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: exchangeRes
+func (o *Operation) InitiateExchange(rw http.ResponseWriter, req *http.Request) {
+	exchangeID := mux.Vars(req)["exchangeId"]
+
+	conf, ok := o.config.Exchanges[exchangeID]
+	if !ok {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, ExchangeErrorCode,
+			fmt.Errorf("exchange '%s' not found", exchangeID))
+
+		return
+	}
+
+	transaction := &ExchangeTransaction{
+		ID:         uuid.New().String(),
+		ExchangeID: exchangeID,
+		Challenge:  uuid.New().String(),
+		State:      transactionPending,
+	}
+
+	if err := o.putTransaction(transaction); err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, ExchangeErrorCode, err)
+
+		return
+	}
+
+	writeResponse(rw, http.StatusOK, &ExchangeResponse{
+		VerifiablePresentationRequest: o.presentationRequest(conf, transaction),
+	})
+}
+
+// ContinueExchange swagger:route PUT /exchanges/{exchangeId}/{transactionId} vc-api continueExchangeReq
+//
+// Continues an exchange transaction with a presentation answering the presentation request of the exchange.
+// The presentation request is returned again if no presentation is provided. POST is accepted as well.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: exchangeRes
+func (o *Operation) ContinueExchange(rw http.ResponseWriter, req *http.Request) {
+	exchangeID, transactionID := mux.Vars(req)["exchangeId"], mux.Vars(req)["transactionId"]
+
+	conf, transaction, ok := o.lookupTransaction(rw, exchangeID, transactionID)
+	if !ok {
+		return
+	}
+
+	if transaction.State != transactionPending {
+		sendValidationError(rw, ExchangeErrorCode, fmt.Errorf("exchange transaction '%s' is %s", transactionID,
+			transaction.State))
+
+		return
+	}
+
+	var request ExchangeTransactionRequest
+
+	// empty body asks for the presentation request again.
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		sendValidationError(rw, InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+
+		return
+	}
+
+	if len(request.VerifiablePresentation) == 0 {
+		writeResponse(rw, http.StatusOK, &ExchangeResponse{
+			VerifiablePresentationRequest: o.presentationRequest(conf, transaction),
+		})
+
+		return
+	}
+
+	result := o.verifyPresentation(request.VerifiablePresentation, &VerifyOptions{
+		Challenge: transaction.Challenge,
+		Domain:    conf.Domain,
+	})
+	if len(result.Errors) > 0 {
+		sendValidationError(rw, ExchangeErrorCode,
+			fmt.Errorf("invalid presentation: %s", strings.Join(result.Errors, "; ")))
+
+		return
+	}
+
+	transaction.State = transactionComplete
+	transaction.VerifiablePresentation = request.VerifiablePresentation
+
+	if err := o.putTransaction(transaction); err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, ExchangeErrorCode, err)
+
+		return
+	}
+
+	writeResponse(rw, http.StatusOK, &ExchangeResponse{RedirectURL: conf.RedirectURL})
+}
+
+// GetExchangeTransaction swagger:route GET /exchanges/{exchangeId}/{transactionId} vc-api exchangeTransactionReq
+//
+// Returns state of an exchange transaction, including the presentation received from the holder once complete.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: exchangeTransactionRes
+func (o *Operation) GetExchangeTransaction(rw http.ResponseWriter, req *http.Request) {
+	_, transaction, ok := o.lookupTransaction(rw, mux.Vars(req)["exchangeId"], mux.Vars(req)["transactionId"])
+	if !ok {
+		return
+	}
+
+	writeResponse(rw, http.StatusOK, transaction)
+}
+
+// lookupTransaction returns configuration and transaction of given exchange, error response is sent if not found.
+func (o *Operation) lookupTransaction(rw http.ResponseWriter, exchangeID,
+	transactionID string) (*ExchangeConfig, *ExchangeTransaction, bool) {
+	conf, ok := o.config.Exchanges[exchangeID]
+	if !ok {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, ExchangeErrorCode,
+			fmt.Errorf("exchange '%s' not found", exchangeID))
+
+		return nil, nil, false
+	}
+
+	transaction, err := o.getTransaction(transactionID)
+	if err == nil && transaction.ExchangeID != exchangeID {
+		err = errTransactionNotFound
+	}
+
+	if errors.Is(err, errTransactionNotFound) {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, ExchangeErrorCode, err)
+
+		return nil, nil, false
+	}
+
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, ExchangeErrorCode, err)
+
+		return nil, nil, false
+	}
+
+	return conf, transaction, true
+}
+
+// presentationRequest returns presentation request of given exchange transaction.
+func (o *Operation) presentationRequest(conf *ExchangeConfig,
+	transaction *ExchangeTransaction) *wallet.VerifiablePresentationRequest {
+	return &wallet.VerifiablePresentationRequest{
+		Query:     conf.Query,
+		Challenge: transaction.Challenge,
+		Domain:    conf.Domain,
+		Interact: &wallet.VPRInteract{Service: []*wallet.VPRInteractService{{
+			Type: wallet.UnmediatedHTTPPresentationService,
+			ServiceEndpoint: strings.TrimSuffix(o.config.BaseURL, "/") + "/exchanges/" + transaction.ExchangeID +
+				"/" + transaction.ID,
+		}}},
+	}
+}
+
+func (o *Operation) getTransaction(id string) (*ExchangeTransaction, error) {
+	data, err := o.store.Get(transactionKeyPrefix + id)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, errTransactionNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get exchange transaction: %w", err)
+	}
+
+	var transaction ExchangeTransaction
+
+	if err = json.Unmarshal(data, &transaction); err != nil {
+		return nil, fmt.Errorf("unmarshal exchange transaction: %w", err)
+	}
+
+	return &transaction, nil
+}
+
+func (o *Operation) putTransaction(transaction *ExchangeTransaction) error {
+	data, err := json.Marshal(transaction)
+	if err != nil {
+		return fmt.Errorf("marshal exchange transaction: %w", err)
+	}
+
+	if err = o.store.Put(transactionKeyPrefix+transaction.ID, data); err != nil {
+		return fmt.Errorf("save exchange transaction: %w", err)
+	}
+
+	return nil
+}
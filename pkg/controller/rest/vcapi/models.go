@@ -0,0 +1,320 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcapi
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/wallet"
+)
+
+// IssueCredentialRequest is request model of VC-API credential issuance.
+type IssueCredentialRequest struct {
+	// Credential to be issued, issuer and status are added if missing.
+	Credential json.RawMessage `json:"credential"`
+
+	// Options of issuance.
+	Options *IssueCredentialOptions `json:"options,omitempty"`
+}
+
+// IssueCredentialOptions is options model of VC-API credential issuance.
+type IssueCredentialOptions struct {
+	// Created date of the proof, current system time is used if omitted.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Challenge of the proof.
+	Challenge string `json:"challenge,omitempty"`
+
+	// Domain of the proof.
+	Domain string `json:"domain,omitempty"`
+
+	// CredentialStatus requests status of given type for issued credential.
+	CredentialStatus *CredentialStatusOption `json:"credentialStatus,omitempty"`
+}
+
+// CredentialStatusOption is status type requested for issued credential.
+type CredentialStatusOption struct {
+	Type string `json:"type"`
+}
+
+// IssueCredentialResponse is response model of VC-API credential issuance.
+type IssueCredentialResponse struct {
+	VerifiableCredential json.RawMessage `json:"verifiableCredential"`
+}
+
+// VerifyCredentialRequest is request model of VC-API credential verification.
+type VerifyCredentialRequest struct {
+	VerifiableCredential json.RawMessage `json:"verifiableCredential"`
+
+	// Options of verification.
+	Options *VerifyOptions `json:"options,omitempty"`
+}
+
+// VerifyOptions is options model of VC-API credential and presentation verification.
+type VerifyOptions struct {
+	// Checks to be performed, all supported checks are performed if omitted.
+	Checks []string `json:"checks,omitempty"`
+
+	// Challenge expected in presentation proof.
+	Challenge string `json:"challenge,omitempty"`
+
+	// Domain expected in presentation proof.
+	Domain string `json:"domain,omitempty"`
+}
+
+// VerificationResult is response model of VC-API credential and presentation verification.
+type VerificationResult struct {
+	Checks   []string `json:"checks"`
+	Warnings []string `json:"warnings"`
+	Errors   []string `json:"errors"`
+}
+
+// UpdateStatusRequest is request model of VC-API credential status update.
+type UpdateStatusRequest struct {
+	CredentialID     string          `json:"credentialId"`
+	CredentialStatus []*StatusUpdate `json:"credentialStatus"`
+}
+
+// StatusUpdate is status update of credential, status '1' revokes and '0' reinstates the credential.
+type StatusUpdate struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+// ProvePresentationRequest is request model of VC-API presentation proving.
+type ProvePresentationRequest struct {
+	Presentation json.RawMessage `json:"presentation"`
+
+	// Options of proving.
+	Options *ProveOptions `json:"options,omitempty"`
+}
+
+// ProveOptions is options model of VC-API presentation proving.
+type ProveOptions struct {
+	// Created date of the proof, current system time is used if omitted.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Challenge of the proof.
+	Challenge string `json:"challenge,omitempty"`
+
+	// Domain of the proof.
+	Domain string `json:"domain,omitempty"`
+}
+
+// ProvePresentationResponse is response model of VC-API presentation proving.
+type ProvePresentationResponse struct {
+	VerifiablePresentation json.RawMessage `json:"verifiablePresentation"`
+}
+
+// VerifyPresentationRequest is request model of VC-API presentation verification.
+type VerifyPresentationRequest struct {
+	VerifiablePresentation json.RawMessage `json:"verifiablePresentation"`
+
+	// Options of verification.
+	Options *VerifyOptions `json:"options,omitempty"`
+}
+
+// ExchangeResponse is response model of VC-API exchange steps.
+type ExchangeResponse struct {
+	// VerifiablePresentationRequest is request of verifier to be answered by holder.
+	VerifiablePresentationRequest *wallet.VerifiablePresentationRequest `json:"verifiablePresentationRequest,omitempty"`
+
+	// RedirectURL is URL holder is redirected to on completion of the exchange.
+	RedirectURL string `json:"redirectUrl,omitempty"`
+}
+
+// ExchangeTransactionRequest is request model of VC-API exchange transaction continuation.
+type ExchangeTransactionRequest struct {
+	VerifiablePresentation json.RawMessage `json:"verifiablePresentation,omitempty"`
+}
+
+// ExchangeTransaction is state of VC-API exchange transaction.
+type ExchangeTransaction struct {
+	ID                     string          `json:"transactionId"`
+	ExchangeID             string          `json:"exchangeId"`
+	Challenge              string          `json:"challenge"`
+	State                  string          `json:"state"`
+	VerifiablePresentation json.RawMessage `json:"verifiablePresentation,omitempty"`
+}
+
+// issueCredentialReq model
+//
+// This is used to issue a credential.
+//
+// swagger:parameters issueCredentialReq
+type issueCredentialReq struct { // nolint: unused,deadcode
+	// in: body
+	Params IssueCredentialRequest
+}
+
+// issueCredentialRes model
+//
+// This is used for returning the issued credential.
+//
+// swagger:response issueCredentialRes
+type issueCredentialRes struct { // nolint: unused,deadcode
+	// in: body
+	Response IssueCredentialResponse
+}
+
+// verifyCredentialReq model
+//
+// This is used to verify a credential.
+//
+// swagger:parameters verifyCredentialReq
+type verifyCredentialReq struct { // nolint: unused,deadcode
+	// in: body
+	Params VerifyCredentialRequest
+}
+
+// verificationRes model
+//
+// This is used for returning the result of credential or presentation verification.
+//
+// swagger:response verificationRes
+type verificationRes struct { // nolint: unused,deadcode
+	// in: body
+	Response VerificationResult
+}
+
+// updateStatusReq model
+//
+// This is used to update status of an issued credential.
+//
+// swagger:parameters updateStatusReq
+type updateStatusReq struct { // nolint: unused,deadcode
+	// in: body
+	Params UpdateStatusRequest
+}
+
+// statusListReq model
+//
+// This is used to get a status list credential.
+//
+// swagger:parameters statusListReq
+type statusListReq struct { // nolint: unused,deadcode
+	// Status list ID
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// statusListRes model
+//
+// This is used for returning the signed status list credential.
+//
+// swagger:response statusListRes
+type statusListRes struct { // nolint: unused,deadcode
+	// in: body
+	Credential json.RawMessage
+}
+
+// provePresentationReq model
+//
+// This is used to prove a presentation.
+//
+// swagger:parameters provePresentationReq
+type provePresentationReq struct { // nolint: unused,deadcode
+	// in: body
+	Params ProvePresentationRequest
+}
+
+// provePresentationRes model
+//
+// This is used for returning the proved presentation.
+//
+// swagger:response provePresentationRes
+type provePresentationRes struct { // nolint: unused,deadcode
+	// in: body
+	Response ProvePresentationResponse
+}
+
+// verifyPresentationReq model
+//
+// This is used to verify a presentation.
+//
+// swagger:parameters verifyPresentationReq
+type verifyPresentationReq struct { // nolint: unused,deadcode
+	// in: body
+	Params VerifyPresentationRequest
+}
+
+// initiateExchangeReq model
+//
+// This is used to initiate an exchange.
+//
+// swagger:parameters initiateExchangeReq
+type initiateExchangeReq struct { // nolint: unused,deadcode
+	// Exchange ID
+	//
+	// in: path
+	// required: true
+	ExchangeID string `json:"exchangeId"`
+}
+
+// exchangeRes model
+//
+// This is used for returning the next step of an exchange.
+//
+// swagger:response exchangeRes
+type exchangeRes struct { // nolint: unused,deadcode
+	// in: body
+	Response ExchangeResponse
+}
+
+// continueExchangeReq model
+//
+// This is used to continue an exchange transaction with a presentation.
+//
+// swagger:parameters continueExchangeReq
+type continueExchangeReq struct { // nolint: unused,deadcode
+	// Exchange ID
+	//
+	// in: path
+	// required: true
+	ExchangeID string `json:"exchangeId"`
+
+	// Transaction ID
+	//
+	// in: path
+	// required: true
+	TransactionID string `json:"transactionId"`
+
+	// in: body
+	Params ExchangeTransactionRequest
+}
+
+// exchangeTransactionReq model
+//
+// This is used to get state of an exchange transaction.
+//
+// swagger:parameters exchangeTransactionReq
+type exchangeTransactionReq struct { // nolint: unused,deadcode
+	// Exchange ID
+	//
+	// in: path
+	// required: true
+	ExchangeID string `json:"exchangeId"`
+
+	// Transaction ID
+	//
+	// in: path
+	// required: true
+	TransactionID string `json:"transactionId"`
+}
+
+// exchangeTransactionRes model
+//
+// This is used for returning state of an exchange transaction.
+//
+// swagger:response exchangeTransactionRes
+type exchangeTransactionRes struct { // nolint: unused,deadcode
+	// in: body
+	Response ExchangeTransaction
+}
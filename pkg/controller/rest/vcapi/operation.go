@@ -0,0 +1,817 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	ariescrypto "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	docverifiable "github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/wallet"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/rest/vcapi")
+
+// constants for the VC-API endpoints.
+const (
+	IssueCredentialPath     = "/credentials/issue"
+	VerifyCredentialPath    = "/credentials/verify"
+	UpdateStatusPath        = "/credentials/status"
+	StatusListPath          = UpdateStatusPath + "/{id}"
+	ProvePresentationPath   = "/presentations/prove"
+	VerifyPresentationPath  = "/presentations/verify"
+	ExchangePath            = "/exchanges/{exchangeId}"
+	ExchangeTransactionPath = ExchangePath + "/{transactionId}"
+
+	// StoreName is name of the store keeping status lists and exchange transactions.
+	StoreName = "vcapi"
+
+	// verification checks.
+	proofCheck            = "proof"
+	challengeCheck        = "challenge"
+	domainCheck           = "domain"
+	credentialStatusCheck = "credentialStatus"
+
+	// exchange transaction states.
+	transactionPending  = "pending"
+	transactionComplete = "complete"
+
+	defaultProofType = verifiable.Ed25519Signature2018
+)
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.VCAPI)
+
+	// IssueCredentialErrorCode for errors while issuing credentials.
+	IssueCredentialErrorCode
+
+	// UpdateStatusErrorCode for errors while updating status of credentials.
+	UpdateStatusErrorCode
+
+	// ProvePresentationErrorCode for errors while proving presentations.
+	ProvePresentationErrorCode
+
+	// ExchangeErrorCode for errors of exchanges.
+	ExchangeErrorCode
+)
+
+// Config is configuration of VC-API endpoints.
+type Config struct {
+	// BaseURL is public URL of the agent, used for URLs of status lists and exchange transactions.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// Issue configures the credential issuance and status endpoints, issuance is disabled if not set.
+	Issue *IssueConfig `json:"issue,omitempty"`
+
+	// Prove configures the presentation proving endpoint, proving is disabled if not set.
+	Prove *ProveConfig `json:"prove,omitempty"`
+
+	// Exchanges configures exchanges by exchange ID.
+	Exchanges map[string]*ExchangeConfig `json:"exchanges,omitempty"`
+}
+
+// IssueConfig configures signing of issued credentials.
+type IssueConfig struct {
+	// IssuerDID is DID signing issued credentials.
+	IssuerDID string `json:"issuerDID"`
+
+	// ProofType is signature type of proofs, Ed25519Signature2018 by default.
+	ProofType string `json:"proofType,omitempty"`
+
+	// VerificationMethod of issuer DID used for signing, default verification method of the DID if not set.
+	VerificationMethod string `json:"verificationMethod,omitempty"`
+
+	// StatusList adds status to issued credentials if set.
+	StatusList *StatusListConfig `json:"statusList,omitempty"`
+}
+
+// StatusListConfig configures status list of issued credentials.
+type StatusListConfig struct {
+	// ID of the status list, status list credential is served at BaseURL + /credentials/status/{id}.
+	ID string `json:"id"`
+
+	// Size of the status list, 131072 by default.
+	Size int `json:"size,omitempty"`
+}
+
+// ProveConfig configures signing of proved presentations.
+type ProveConfig struct {
+	// HolderDID is DID signing presentations.
+	HolderDID string `json:"holderDID"`
+
+	// ProofType is signature type of proofs, Ed25519Signature2018 by default.
+	ProofType string `json:"proofType,omitempty"`
+
+	// VerificationMethod of holder DID used for signing, default verification method of the DID if not set.
+	VerificationMethod string `json:"verificationMethod,omitempty"`
+}
+
+// ExchangeConfig configures presentation request of an exchange.
+type ExchangeConfig struct {
+	// Query of presentation request sent to holder.
+	Query []*wallet.VPRQuery `json:"query"`
+
+	// Domain of presentation request sent to holder.
+	Domain string `json:"domain,omitempty"`
+
+	// RedirectURL holder is redirected to on completion of the exchange.
+	RedirectURL string `json:"redirectUrl,omitempty"`
+}
+
+// provider contains dependencies for the VC-API endpoints and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	VDRegistry() vdr.Registry
+	KMS() kms.KeyManager
+	Crypto() ariescrypto.Crypto
+	JSONLDDocumentLoader() ld.DocumentLoader
+}
+
+// Operation contains W3C CCG VC-API issuer, verifier and holder endpoints mapped onto the verifiable command.
+type Operation struct {
+	handlers       []rest.Handler
+	command        *verifiable.Command
+	config         *Config
+	store          storage.Store
+	statuses       *statusStore
+	vdr            vdr.Registry
+	documentLoader ld.DocumentLoader
+}
+
+// New returns new VC-API rest client instance.
+func New(p provider, config *Config) (*Operation, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	if config.BaseURL == "" && (len(config.Exchanges) > 0 || config.Issue != nil && config.Issue.StatusList != nil) {
+		return nil, errors.New("base URL is required for status lists and exchanges")
+	}
+
+	cmd, err := verifiable.New(p)
+	if err != nil {
+		return nil, fmt.Errorf("verifiable new: %w", err)
+	}
+
+	store, err := p.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open vc-api store: %w", err)
+	}
+
+	o := &Operation{
+		command:        cmd,
+		config:         config,
+		store:          store,
+		statuses:       &statusStore{store: store},
+		vdr:            p.VDRegistry(),
+		documentLoader: p.JSONLDDocumentLoader(),
+	}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+// The status lists and the exchange steps are public, they are called by verifiers and holders. Exchange
+// transactions hold presentations of holders, only the API token allows reading them.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(IssueCredentialPath, http.MethodPost, o.IssueCredential),
+		cmdutil.NewHTTPHandler(VerifyCredentialPath, http.MethodPost, o.VerifyCredential),
+		cmdutil.NewHTTPHandler(UpdateStatusPath, http.MethodPost, o.UpdateStatus),
+		cmdutil.NewPublicHTTPHandler(StatusListPath, http.MethodGet, o.GetStatusList),
+		cmdutil.NewHTTPHandler(ProvePresentationPath, http.MethodPost, o.ProvePresentation),
+		cmdutil.NewHTTPHandler(VerifyPresentationPath, http.MethodPost, o.VerifyPresentation),
+		cmdutil.NewPublicHTTPHandler(ExchangePath, http.MethodPost, o.InitiateExchange),
+		cmdutil.NewPublicHTTPHandler(ExchangeTransactionPath, http.MethodPut, o.ContinueExchange),
+		cmdutil.NewPublicHTTPHandler(ExchangeTransactionPath, http.MethodPost, o.ContinueExchange),
+		cmdutil.NewHTTPHandler(ExchangeTransactionPath, http.MethodGet, o.GetExchangeTransaction),
+	}
+}
+
+// IssueCredential swagger:route POST /credentials/issue vc-api issueCredentialReq
+//
+// Issues a credential signed by the configured issuer DID. Issuer and issuance date are added if missing,
+// status is added if a status list is configured.
+//
+// Responses:
+//
+//	default: genericError
+//	    201: issueCredentialRes
+func (o *Operation) IssueCredential(rw http.ResponseWriter, req *http.Request) {
+	var request IssueCredentialRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		sendValidationError(rw, InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+
+		return
+	}
+
+	if len(request.Credential) == 0 {
+		sendValidationError(rw, InvalidRequestErrorCode, errors.New("credential is required"))
+
+		return
+	}
+
+	if request.Options == nil {
+		request.Options = &IssueCredentialOptions{}
+	}
+
+	conf := o.config.Issue
+	if conf == nil {
+		sendValidationError(rw, IssueCredentialErrorCode, errors.New("credential issuance is not configured"))
+
+		return
+	}
+
+	credential, err := o.prepareCredential(request.Credential, request.Options.CredentialStatus)
+	if err != nil {
+		sendValidationError(rw, IssueCredentialErrorCode, err)
+
+		return
+	}
+
+	var response verifiable.SignCredentialResponse
+
+	cmdErr := execute(o.command.SignCredential, &verifiable.SignCredentialRequest{
+		Credential: credential,
+		DID:        conf.IssuerDID,
+		ProofOptions: &verifiable.ProofOptions{
+			VerificationMethod: conf.VerificationMethod,
+			SignatureType:      proofType(conf.ProofType),
+			Created:            request.Options.Created,
+			Challenge:          request.Options.Challenge,
+			Domain:             request.Options.Domain,
+		},
+	}, &response)
+	if cmdErr != nil {
+		rest.SendError(rw, cmdErr)
+
+		return
+	}
+
+	writeResponse(rw, http.StatusCreated, &IssueCredentialResponse{VerifiableCredential: response.VerifiableCredential})
+}
+
+// VerifyCredential swagger:route POST /credentials/verify vc-api verifyCredentialReq
+//
+// Verifies proof of a credential, status is checked if the credential was issued with a status list of this agent.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: verificationRes
+//	    400: verificationRes
+func (o *Operation) VerifyCredential(rw http.ResponseWriter, req *http.Request) {
+	var request VerifyCredentialRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		sendValidationError(rw, InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+
+		return
+	}
+
+	if len(request.VerifiableCredential) == 0 {
+		sendValidationError(rw, InvalidRequestErrorCode, errors.New("verifiable credential is required"))
+
+		return
+	}
+
+	writeVerificationResult(rw, o.verifyCredential(request.VerifiableCredential, request.Options))
+}
+
+// UpdateStatus swagger:route POST /credentials/status vc-api updateStatusReq
+//
+// Revokes (status '1') or reinstates (status '0') a credential issued with a status list of this agent.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: emptyRes
+func (o *Operation) UpdateStatus(rw http.ResponseWriter, req *http.Request) {
+	var request UpdateStatusRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		sendValidationError(rw, InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+
+		return
+	}
+
+	if request.CredentialID == "" || len(request.CredentialStatus) == 0 {
+		sendValidationError(rw, InvalidRequestErrorCode, errors.New("credential ID and status are required"))
+
+		return
+	}
+
+	for _, update := range request.CredentialStatus {
+		if update.Type != RevocationList2020Status {
+			sendValidationError(rw, UpdateStatusErrorCode, fmt.Errorf("unsupported status type '%s'", update.Type))
+
+			return
+		}
+
+		if update.Status != "0" && update.Status != "1" {
+			sendValidationError(rw, UpdateStatusErrorCode, fmt.Errorf("invalid status '%s'", update.Status))
+
+			return
+		}
+
+		err := o.statuses.update(request.CredentialID, update.Status == "1")
+		if errors.Is(err, errStatusNotFound) {
+			rest.SendHTTPStatusError(rw, http.StatusNotFound, UpdateStatusErrorCode, err)
+
+			return
+		}
+
+		if err != nil {
+			rest.SendHTTPStatusError(rw, http.StatusInternalServerError, UpdateStatusErrorCode, err)
+
+			return
+		}
+	}
+
+	writeResponse(rw, http.StatusOK, nil)
+}
+
+// GetStatusList swagger:route GET /credentials/status/{id} vc-api statusListReq
+//
+// Returns the status list credential signed by the configured issuer DID.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: statusListRes
+func (o *Operation) GetStatusList(rw http.ResponseWriter, req *http.Request) {
+	id := mux.Vars(req)["id"]
+
+	conf := o.config.Issue
+	if conf == nil || conf.StatusList == nil || conf.StatusList.ID != id {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, UpdateStatusErrorCode,
+			fmt.Errorf("status list '%s' not found", id))
+
+		return
+	}
+
+	list, err := o.statuses.list(id, conf.StatusList.Size)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, UpdateStatusErrorCode, err)
+
+		return
+	}
+
+	encoded, err := list.encode()
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, UpdateStatusErrorCode, err)
+
+		return
+	}
+
+	listURL := o.statusListURL(id)
+
+	credential, err := json.Marshal(map[string]interface{}{
+		"@context":     []string{docverifiable.ContextURI, revocationList2020Context},
+		"id":           listURL,
+		"type":         []string{docverifiable.VCType, revocationList2020Credential},
+		"issuer":       conf.IssuerDID,
+		"issuanceDate": time.Now().UTC().Format(time.RFC3339),
+		"credentialSubject": map[string]interface{}{
+			"id":          listURL + "#list",
+			"type":        revocationList2020,
+			"encodedList": encoded,
+		},
+	})
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, UpdateStatusErrorCode, err)
+
+		return
+	}
+
+	var response verifiable.SignCredentialResponse
+
+	cmdErr := execute(o.command.SignCredential, &verifiable.SignCredentialRequest{
+		Credential: credential,
+		DID:        conf.IssuerDID,
+		ProofOptions: &verifiable.ProofOptions{
+			VerificationMethod: conf.VerificationMethod,
+			SignatureType:      proofType(conf.ProofType),
+		},
+	}, &response)
+	if cmdErr != nil {
+		rest.SendError(rw, cmdErr)
+
+		return
+	}
+
+	writeResponse(rw, http.StatusOK, response.VerifiableCredential)
+}
+
+// ProvePresentation swagger:route POST /presentations/prove vc-api provePresentationReq
+//
+// Proves a presentation with the configured holder DID.
+//
+// Responses:
+//
+//	default: genericError
+//	    201: provePresentationRes
+func (o *Operation) ProvePresentation(rw http.ResponseWriter, req *http.Request) {
+	var request ProvePresentationRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		sendValidationError(rw, InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+
+		return
+	}
+
+	if len(request.Presentation) == 0 {
+		sendValidationError(rw, InvalidRequestErrorCode, errors.New("presentation is required"))
+
+		return
+	}
+
+	if request.Options == nil {
+		request.Options = &ProveOptions{}
+	}
+
+	conf := o.config.Prove
+	if conf == nil {
+		sendValidationError(rw, ProvePresentationErrorCode, errors.New("presentation proving is not configured"))
+
+		return
+	}
+
+	var response verifiable.Presentation
+
+	cmdErr := execute(o.command.GeneratePresentation, &verifiable.PresentationRequest{
+		Presentation: request.Presentation,
+		DID:          conf.HolderDID,
+		ProofOptions: &verifiable.ProofOptions{
+			VerificationMethod: conf.VerificationMethod,
+			SignatureType:      proofType(conf.ProofType),
+			Created:            request.Options.Created,
+			Challenge:          request.Options.Challenge,
+			Domain:             request.Options.Domain,
+		},
+	}, &response)
+	if cmdErr != nil {
+		rest.SendError(rw, cmdErr)
+
+		return
+	}
+
+	writeResponse(rw, http.StatusCreated,
+		&ProvePresentationResponse{VerifiablePresentation: response.VerifiablePresentation})
+}
+
+// VerifyPresentation swagger:route POST /presentations/verify vc-api verifyPresentationReq
+//
+// Verifies proof, challenge and domain of a presentation and the credentials in the presentation.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: verificationRes
+//	    400: verificationRes
+func (o *Operation) VerifyPresentation(rw http.ResponseWriter, req *http.Request) {
+	var request VerifyPresentationRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		sendValidationError(rw, InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+
+		return
+	}
+
+	if len(request.VerifiablePresentation) == 0 {
+		sendValidationError(rw, InvalidRequestErrorCode, errors.New("verifiable presentation is required"))
+
+		return
+	}
+
+	writeVerificationResult(rw, o.verifyPresentation(request.VerifiablePresentation, request.Options))
+}
+
+// prepareCredential adds issuer, issuance date and status to given credential.
+func (o *Operation) prepareCredential(raw json.RawMessage, status *CredentialStatusOption) (json.RawMessage, error) {
+	conf := o.config.Issue
+
+	var credential map[string]interface{}
+
+	if err := json.Unmarshal(raw, &credential); err != nil {
+		return nil, fmt.Errorf("invalid credential: %w", err)
+	}
+
+	if credential == nil {
+		return nil, errors.New("invalid credential")
+	}
+
+	switch issuer := credential["issuer"].(type) {
+	case nil:
+		credential["issuer"] = conf.IssuerDID
+	case string:
+		if issuer != conf.IssuerDID {
+			return nil, fmt.Errorf("credential issuer '%s' is not the configured issuer", issuer)
+		}
+	case map[string]interface{}:
+		if issuer["id"] != conf.IssuerDID {
+			return nil, fmt.Errorf("credential issuer '%v' is not the configured issuer", issuer["id"])
+		}
+	}
+
+	if _, ok := credential["issuanceDate"]; !ok {
+		credential["issuanceDate"] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	if status != nil && status.Type != RevocationList2020Status {
+		return nil, fmt.Errorf("unsupported credential status type '%s'", status.Type)
+	}
+
+	if _, ok := credential["credentialStatus"]; !ok && conf.StatusList != nil {
+		if err := o.addCredentialStatus(credential); err != nil {
+			return nil, err
+		}
+	} else if status != nil && conf.StatusList == nil {
+		return nil, errors.New("status list is not configured")
+	}
+
+	return json.Marshal(credential)
+}
+
+// addCredentialStatus allocates index of configured status list to given credential.
+func (o *Operation) addCredentialStatus(credential map[string]interface{}) error {
+	conf := o.config.Issue.StatusList
+
+	id, ok := credential["id"].(string)
+	if !ok || id == "" {
+		id = "urn:uuid:" + uuid.New().String()
+		credential["id"] = id
+	}
+
+	index, err := o.statuses.allocate(conf.ID, conf.Size, id)
+	if err != nil {
+		return fmt.Errorf("allocate credential status: %w", err)
+	}
+
+	listURL := o.statusListURL(conf.ID)
+
+	credential["credentialStatus"] = map[string]interface{}{
+		"id":                       listURL + "#" + strconv.Itoa(index),
+		"type":                     RevocationList2020Status,
+		"revocationListIndex":      strconv.Itoa(index),
+		"revocationListCredential": listURL,
+	}
+
+	contexts, ok := credential["@context"].([]interface{})
+	if !ok {
+		return errors.New("invalid credential context")
+	}
+
+	for _, c := range contexts {
+		if c == revocationList2020Context {
+			return nil
+		}
+	}
+
+	credential["@context"] = append(contexts, revocationList2020Context)
+
+	return nil
+}
+
+// verifyCredential verifies proof of given credential through the verifiable command and status of the credential
+// if it was issued with a status list of this agent.
+func (o *Operation) verifyCredential(raw json.RawMessage, options *VerifyOptions) *VerificationResult {
+	result := &VerificationResult{Checks: []string{}, Warnings: []string{}, Errors: []string{}}
+
+	// JWT credentials are JSON strings.
+	vc := string(raw)
+
+	var jwt string
+	if err := json.Unmarshal(raw, &jwt); err == nil {
+		vc = jwt
+	}
+
+	cmdErr := execute(o.command.ValidateCredential, &verifiable.Credential{VerifiableCredential: vc}, nil)
+	if cmdErr != nil {
+		result.Errors = append(result.Errors, cmdErr.Error())
+
+		return result
+	}
+
+	result.Checks = append(result.Checks, proofCheck)
+
+	if options != nil && len(options.Checks) > 0 && !contains(options.Checks, credentialStatusCheck) {
+		return result
+	}
+
+	var credential struct {
+		Status *struct {
+			Type    string          `json:"type"`
+			Index   json.RawMessage `json:"revocationListIndex"`
+			ListURL string          `json:"revocationListCredential"`
+		} `json:"credentialStatus"`
+	}
+
+	if err := json.Unmarshal(raw, &credential); err != nil || credential.Status == nil {
+		return result
+	}
+
+	listPrefix := o.statusListURL("")
+
+	if credential.Status.Type != RevocationList2020Status || o.config.BaseURL == "" ||
+		!strings.HasPrefix(credential.Status.ListURL, listPrefix) {
+		result.Warnings = append(result.Warnings, "credential status is not checked")
+
+		return result
+	}
+
+	revoked, err := o.revoked(strings.TrimPrefix(credential.Status.ListURL, listPrefix), credential.Status.Index)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+
+		return result
+	}
+
+	if revoked {
+		result.Errors = append(result.Errors, "credential is revoked")
+
+		return result
+	}
+
+	result.Checks = append(result.Checks, credentialStatusCheck)
+
+	return result
+}
+
+func (o *Operation) revoked(listID string, rawIndex json.RawMessage) (bool, error) {
+	index, err := strconv.Atoi(strings.Trim(string(rawIndex), `"`))
+	if err != nil {
+		return false, fmt.Errorf("invalid revocation list index: %w", err)
+	}
+
+	list, err := o.statuses.list(listID, 0)
+	if err != nil {
+		return false, err
+	}
+
+	if index < 0 || index >= list.Size {
+		return false, fmt.Errorf("revocation list index %d is out of range", index)
+	}
+
+	return list.revoked(index), nil
+}
+
+// verifyPresentation verifies proof, challenge and domain of given presentation and the credentials in it.
+func (o *Operation) verifyPresentation(raw json.RawMessage, options *VerifyOptions) *VerificationResult {
+	result := &VerificationResult{Checks: []string{}, Warnings: []string{}, Errors: []string{}}
+
+	if options == nil {
+		options = &VerifyOptions{}
+	}
+
+	vp, err := docverifiable.ParsePresentation(raw,
+		docverifiable.WithPresPublicKeyFetcher(docverifiable.NewVDRKeyResolver(o.vdr).PublicKeyFetcher()),
+		docverifiable.WithPresJSONLDDocumentLoader(o.documentLoader))
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("verify presentation: %s", err))
+
+		return result
+	}
+
+	if len(vp.Proofs) == 0 {
+		result.Errors = append(result.Errors, "presentation has no proof")
+
+		return result
+	}
+
+	result.Checks = append(result.Checks, proofCheck)
+
+	for _, check := range []struct{ name, expected string }{
+		{challengeCheck, options.Challenge},
+		{domainCheck, options.Domain},
+	} {
+		if check.expected == "" {
+			continue
+		}
+
+		if vp.Proofs[0][check.name] != check.expected {
+			result.Errors = append(result.Errors, fmt.Sprintf("presentation %s doesn't match", check.name))
+		} else {
+			result.Checks = append(result.Checks, check.name)
+		}
+	}
+
+	credentials, err := vp.MarshalledCredentials()
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("presentation credentials: %s", err))
+
+		return result
+	}
+
+	for _, credential := range credentials {
+		credentialResult := o.verifyCredential(json.RawMessage(credential), &VerifyOptions{Checks: options.Checks})
+
+		for _, e := range credentialResult.Errors {
+			result.Errors = append(result.Errors, "credential: "+e)
+		}
+
+		for _, w := range credentialResult.Warnings {
+			result.Warnings = append(result.Warnings, "credential: "+w)
+		}
+	}
+
+	return result
+}
+
+func (o *Operation) statusListURL(id string) string {
+	return strings.TrimSuffix(o.config.BaseURL, "/") + UpdateStatusPath + "/" + id
+}
+
+// execute executes given command with request and decodes command response into response if provided.
+func execute(exec command.Exec, request, response interface{}) command.Error {
+	reqBytes, err := json.Marshal(request)
+	if err != nil {
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("marshal request: %w", err))
+	}
+
+	var buf bytes.Buffer
+
+	if cmdErr := exec(&buf, bytes.NewBuffer(reqBytes)); cmdErr != nil {
+		return cmdErr
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	if err = json.Unmarshal(buf.Bytes(), response); err != nil {
+		return command.NewExecuteError(InvalidRequestErrorCode, fmt.Errorf("unmarshal response: %w", err))
+	}
+
+	return nil
+}
+
+func proofType(configured string) string {
+	if configured == "" {
+		return defaultProofType
+	}
+
+	return configured
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sendValidationError(rw http.ResponseWriter, code command.Code, err error) {
+	rest.SendHTTPStatusError(rw, http.StatusBadRequest, code, err)
+}
+
+func writeVerificationResult(rw http.ResponseWriter, result *VerificationResult) {
+	status := http.StatusOK
+	if len(result.Errors) > 0 {
+		status = http.StatusBadRequest
+	}
+
+	writeResponse(rw, status, result)
+}
+
+func writeResponse(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+
+	command.WriteNillableResponse(rw, v, logger)
+}
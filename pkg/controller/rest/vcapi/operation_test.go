@@ -0,0 +1,556 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+	"github.com/hyperledger/aries-framework-go/pkg/wallet"
+)
+
+const (
+	sampleBaseURL = "https://agent.example.com/"
+
+	sampleCredential = `{
+		"@context": [
+			"https://www.w3.org/2018/credentials/v1",
+			"https://www.w3.org/2018/credentials/examples/v1"
+		],
+		"id": "http://example.edu/credentials/1872",
+		"type": ["VerifiableCredential", "UniversityDegreeCredential"],
+		"credentialSubject": {
+			"id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"degree": {"type": "BachelorDegree", "name": "Bachelor of Science and Arts"}
+		}
+	}`
+
+	samplePresentation = `{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"type": ["VerifiablePresentation"],
+		"verifiableCredential": [%s]
+	}`
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(newMockProvider(t), nil)
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 10)
+
+		var public []string
+
+		for _, h := range op.GetRESTHandlers() {
+			if rest.IsPublic(h) {
+				public = append(public, h.Method()+" "+h.Path())
+			}
+		}
+
+		require.ElementsMatch(t, []string{
+			"GET " + StatusListPath,
+			"POST " + ExchangePath,
+			"PUT " + ExchangeTransactionPath,
+			"POST " + ExchangeTransactionPath,
+		}, public)
+	})
+
+	t.Run("base URL required", func(t *testing.T) {
+		op, err := New(newMockProvider(t), &Config{Exchanges: map[string]*ExchangeConfig{"login": {}}})
+		require.EqualError(t, err, "base URL is required for status lists and exchanges")
+		require.Nil(t, op)
+
+		op, err = New(newMockProvider(t), &Config{Issue: &IssueConfig{StatusList: &StatusListConfig{ID: "1"}}})
+		require.Error(t, err)
+		require.Nil(t, op)
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		p := newMockProvider(t)
+		p.StorageProviderValue = &mockstorage.MockStoreProvider{FailNamespace: StoreName}
+
+		op, err := New(p, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open vc-api store")
+		require.Nil(t, op)
+	})
+}
+
+func TestOperation_Credentials(t *testing.T) {
+	p := newMockProvider(t)
+	issuerDID := createDIDKey(t, p)
+
+	op, err := New(p, &Config{
+		BaseURL: sampleBaseURL,
+		Issue: &IssueConfig{
+			IssuerDID:  issuerDID,
+			StatusList: &StatusListConfig{ID: "1", Size: 16},
+		},
+	})
+	require.NoError(t, err)
+
+	var issued IssueCredentialResponse
+
+	t.Run("issue credential", func(t *testing.T) {
+		rw := serve(t, op, http.MethodPost, IssueCredentialPath, &IssueCredentialRequest{
+			Credential: json.RawMessage(sampleCredential),
+			Options: &IssueCredentialOptions{
+				CredentialStatus: &CredentialStatusOption{Type: RevocationList2020Status},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &issued))
+
+		var vc map[string]interface{}
+		require.NoError(t, json.Unmarshal(issued.VerifiableCredential, &vc))
+		require.Equal(t, issuerDID, vc["issuer"])
+		require.NotEmpty(t, vc["issuanceDate"])
+		require.NotEmpty(t, vc["proof"])
+		require.Equal(t, map[string]interface{}{
+			"id":                       "https://agent.example.com/credentials/status/1#0",
+			"type":                     RevocationList2020Status,
+			"revocationListIndex":      "0",
+			"revocationListCredential": "https://agent.example.com/credentials/status/1",
+		}, vc["credentialStatus"])
+	})
+
+	t.Run("issue credential failures", func(t *testing.T) {
+		rw := serve(t, op, http.MethodPost, IssueCredentialPath, map[string]interface{}{})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "credential is required")
+
+		rw = serve(t, op, http.MethodPost, IssueCredentialPath, &IssueCredentialRequest{
+			Credential: json.RawMessage("null"),
+		})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "invalid credential")
+
+		// already issued with status.
+		rw = serve(t, op, http.MethodPost, IssueCredentialPath, &IssueCredentialRequest{
+			Credential: json.RawMessage(sampleCredential),
+		})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "already has status")
+
+		rw = serve(t, op, http.MethodPost, IssueCredentialPath, &IssueCredentialRequest{
+			Credential: json.RawMessage(strings.Replace(sampleCredential, `"id": "http`,
+				`"issuer": "did:example:other", "id": "http`, 1)),
+		})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "is not the configured issuer")
+
+		rw = serve(t, op, http.MethodPost, IssueCredentialPath, &IssueCredentialRequest{
+			Credential: json.RawMessage(sampleCredential),
+			Options:    &IssueCredentialOptions{CredentialStatus: &CredentialStatusOption{Type: "StatusList2017"}},
+		})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "unsupported credential status type")
+
+		disabled, err := New(p, nil)
+		require.NoError(t, err)
+
+		rw = serve(t, disabled, http.MethodPost, IssueCredentialPath, &IssueCredentialRequest{
+			Credential: json.RawMessage(sampleCredential),
+		})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "credential issuance is not configured")
+	})
+
+	t.Run("verify credential and update status", func(t *testing.T) {
+		verify := func() (int, *VerificationResult) {
+			rw := serve(t, op, http.MethodPost, VerifyCredentialPath, &VerifyCredentialRequest{
+				VerifiableCredential: issued.VerifiableCredential,
+			})
+
+			var result VerificationResult
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &result))
+
+			return rw.Code, &result
+		}
+
+		code, result := verify()
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, []string{proofCheck, credentialStatusCheck}, result.Checks)
+		require.Empty(t, result.Errors)
+
+		rw := serve(t, op, http.MethodPost, UpdateStatusPath, &UpdateStatusRequest{
+			CredentialID:     "http://example.edu/credentials/1872",
+			CredentialStatus: []*StatusUpdate{{Type: RevocationList2020Status, Status: "1"}},
+		})
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+		code, result = verify()
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Equal(t, []string{"credential is revoked"}, result.Errors)
+
+		rw = serve(t, op, http.MethodGet, "/credentials/status/1", nil)
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+		var statusList struct {
+			ID                string `json:"id"`
+			CredentialSubject struct {
+				EncodedList string `json:"encodedList"`
+			} `json:"credentialSubject"`
+			Proof json.RawMessage `json:"proof"`
+		}
+
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &statusList))
+		require.Equal(t, "https://agent.example.com/credentials/status/1", statusList.ID)
+		require.NotEmpty(t, statusList.Proof)
+		require.Equal(t, []byte{0x80, 0}, decodeStatusList(t, statusList.CredentialSubject.EncodedList))
+
+		rw = serve(t, op, http.MethodPost, UpdateStatusPath, &UpdateStatusRequest{
+			CredentialID:     "http://example.edu/credentials/1872",
+			CredentialStatus: []*StatusUpdate{{Type: RevocationList2020Status, Status: "0"}},
+		})
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+		code, _ = verify()
+		require.Equal(t, http.StatusOK, code)
+
+		// status checks can be skipped.
+		rw = serve(t, op, http.MethodPost, VerifyCredentialPath, &VerifyCredentialRequest{
+			VerifiableCredential: issued.VerifiableCredential,
+			Options:              &VerifyOptions{Checks: []string{proofCheck}},
+		})
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.JSONEq(t, `{"checks": ["proof"], "warnings": [], "errors": []}`, rw.Body.String())
+	})
+
+	t.Run("verify credential failures", func(t *testing.T) {
+		rw := serve(t, op, http.MethodPost, VerifyCredentialPath, &VerifyCredentialRequest{})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+
+		tampered := strings.Replace(string(issued.VerifiableCredential), "Bachelor", "Master", 1)
+
+		rw = serve(t, op, http.MethodPost, VerifyCredentialPath, &VerifyCredentialRequest{
+			VerifiableCredential: json.RawMessage(tampered),
+		})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+
+		var result VerificationResult
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &result))
+		require.Empty(t, result.Checks)
+		require.Len(t, result.Errors, 1)
+	})
+
+	t.Run("update status failures", func(t *testing.T) {
+		for _, request := range []*UpdateStatusRequest{
+			{},
+			{CredentialID: "http://example.edu/credentials/1872"},
+			{
+				CredentialID:     "http://example.edu/credentials/1872",
+				CredentialStatus: []*StatusUpdate{{Type: "StatusList2021Entry", Status: "1"}},
+			},
+			{
+				CredentialID:     "http://example.edu/credentials/1872",
+				CredentialStatus: []*StatusUpdate{{Type: RevocationList2020Status, Status: "revoked"}},
+			},
+		} {
+			rw := serve(t, op, http.MethodPost, UpdateStatusPath, request)
+			require.Equal(t, http.StatusBadRequest, rw.Code)
+		}
+
+		rw := serve(t, op, http.MethodPost, UpdateStatusPath, &UpdateStatusRequest{
+			CredentialID:     "http://example.edu/credentials/unknown",
+			CredentialStatus: []*StatusUpdate{{Type: RevocationList2020Status, Status: "1"}},
+		})
+		require.Equal(t, http.StatusNotFound, rw.Code)
+
+		rw = serve(t, op, http.MethodGet, "/credentials/status/2", nil)
+		require.Equal(t, http.StatusNotFound, rw.Code)
+	})
+}
+
+func TestOperation_Presentations(t *testing.T) {
+	p := newMockProvider(t)
+	didKey := createDIDKey(t, p)
+
+	op, err := New(p, &Config{
+		BaseURL: sampleBaseURL,
+		Issue:   &IssueConfig{IssuerDID: didKey},
+		Prove:   &ProveConfig{HolderDID: didKey},
+		Exchanges: map[string]*ExchangeConfig{
+			"degree": {
+				Query:       []*wallet.VPRQuery{{QueryParams: wallet.QueryParams{Type: "QueryByExample"}}},
+				Domain:      "agent.example.com",
+				RedirectURL: "https://agent.example.com/done",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	rw := serve(t, op, http.MethodPost, IssueCredentialPath, &IssueCredentialRequest{
+		Credential: json.RawMessage(sampleCredential),
+	})
+	require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+
+	var issued IssueCredentialResponse
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &issued))
+
+	prove := func(challenge, domain string) json.RawMessage {
+		rw := serve(t, op, http.MethodPost, ProvePresentationPath, &ProvePresentationRequest{
+			Presentation: json.RawMessage(strings.Replace(samplePresentation, "%s",
+				string(issued.VerifiableCredential), 1)),
+			Options: &ProveOptions{Challenge: challenge, Domain: domain},
+		})
+		require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+
+		var proved ProvePresentationResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &proved))
+
+		return proved.VerifiablePresentation
+	}
+
+	t.Run("prove and verify presentation", func(t *testing.T) {
+		vp := prove("sample-challenge", "")
+
+		rw := serve(t, op, http.MethodPost, VerifyPresentationPath, &VerifyPresentationRequest{
+			VerifiablePresentation: vp,
+			Options:                &VerifyOptions{Challenge: "sample-challenge"},
+		})
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+		require.JSONEq(t, `{"checks": ["proof", "challenge"], "warnings": [], "errors": []}`, rw.Body.String())
+
+		rw = serve(t, op, http.MethodPost, VerifyPresentationPath, &VerifyPresentationRequest{
+			VerifiablePresentation: vp,
+			Options:                &VerifyOptions{Challenge: "other-challenge", Domain: "example.com"},
+		})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.JSONEq(t, `{"checks": ["proof"], "warnings": [],
+			"errors": ["presentation challenge doesn't match", "presentation domain doesn't match"]}`, rw.Body.String())
+	})
+
+	t.Run("prove and verify presentation failures", func(t *testing.T) {
+		rw := serve(t, op, http.MethodPost, ProvePresentationPath, &ProvePresentationRequest{})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+
+		rw = serve(t, op, http.MethodPost, VerifyPresentationPath, &VerifyPresentationRequest{})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+
+		rw = serve(t, op, http.MethodPost, VerifyPresentationPath, &VerifyPresentationRequest{
+			VerifiablePresentation: json.RawMessage(strings.Replace(samplePresentation, "%s",
+				string(issued.VerifiableCredential), 1)),
+		})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "presentation has no proof")
+
+		disabled, err := New(p, nil)
+		require.NoError(t, err)
+
+		rw = serve(t, disabled, http.MethodPost, ProvePresentationPath, &ProvePresentationRequest{
+			Presentation: json.RawMessage(strings.Replace(samplePresentation, "%s", "", 1)),
+		})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "presentation proving is not configured")
+	})
+
+	t.Run("exchange", func(t *testing.T) {
+		rw := serve(t, op, http.MethodPost, "/exchanges/degree", nil)
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+		vpr, err := wallet.ParseVerifiablePresentationRequest(rw.Body.Bytes())
+		require.NoError(t, err)
+		require.NotEmpty(t, vpr.Challenge)
+		require.Equal(t, "agent.example.com", vpr.Domain)
+		require.Len(t, vpr.Interact.Service, 1)
+
+		transactionPath := strings.TrimPrefix(vpr.Interact.Service[0].ServiceEndpoint, "https://agent.example.com")
+		require.True(t, strings.HasPrefix(transactionPath, "/exchanges/degree/"))
+
+		// empty request returns presentation request again.
+		rw = serve(t, op, http.MethodPut, transactionPath, nil)
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+		require.Contains(t, rw.Body.String(), vpr.Challenge)
+
+		rw = serve(t, op, http.MethodPut, transactionPath, &ExchangeTransactionRequest{
+			VerifiablePresentation: prove("wrong-challenge", vpr.Domain),
+		})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "presentation challenge doesn't match")
+
+		vp := prove(vpr.Challenge, vpr.Domain)
+
+		rw = serve(t, op, http.MethodPost, transactionPath, &ExchangeTransactionRequest{VerifiablePresentation: vp})
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+		require.JSONEq(t, `{"redirectUrl": "https://agent.example.com/done"}`, rw.Body.String())
+
+		rw = serve(t, op, http.MethodGet, transactionPath, nil)
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+		var transaction ExchangeTransaction
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &transaction))
+		require.Equal(t, transactionComplete, transaction.State)
+		require.JSONEq(t, string(vp), string(transaction.VerifiablePresentation))
+
+		rw = serve(t, op, http.MethodPut, transactionPath, &ExchangeTransactionRequest{VerifiablePresentation: vp})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "is complete")
+	})
+
+	t.Run("exchange failures", func(t *testing.T) {
+		rw := serve(t, op, http.MethodPost, "/exchanges/unknown", nil)
+		require.Equal(t, http.StatusNotFound, rw.Code)
+
+		rw = serve(t, op, http.MethodGet, "/exchanges/unknown/123", nil)
+		require.Equal(t, http.StatusNotFound, rw.Code)
+
+		rw = serve(t, op, http.MethodGet, "/exchanges/degree/123", nil)
+		require.Equal(t, http.StatusNotFound, rw.Code)
+		require.Contains(t, rw.Body.String(), errTransactionNotFound.Error())
+
+		rw = serve(t, op, http.MethodPost, "/exchanges/degree", nil)
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		vpr, err := wallet.ParseVerifiablePresentationRequest(rw.Body.Bytes())
+		require.NoError(t, err)
+
+		transactionPath := strings.TrimPrefix(vpr.Interact.Service[0].ServiceEndpoint, "https://agent.example.com")
+
+		req := httptest.NewRequest(http.MethodPut, transactionPath, strings.NewReader("--"))
+		rw = httptest.NewRecorder()
+		router(op).ServeHTTP(rw, req)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+	})
+}
+
+func TestStatusStore(t *testing.T) {
+	s := &statusStore{store: mockstorage.NewMockStoreProvider().Store}
+
+	for i := 0; i < 3; i++ {
+		index, err := s.allocate("1", 3, "credential-"+string(rune('a'+i)))
+		require.NoError(t, err)
+		require.Equal(t, i, index)
+	}
+
+	_, err := s.allocate("1", 3, "credential-d")
+	require.EqualError(t, err, "status list '1' is full")
+
+	require.NoError(t, s.update("credential-c", true))
+
+	list, err := s.list("1", 0)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x20}, list.Bits)
+	require.True(t, list.revoked(2))
+	require.False(t, list.revoked(0))
+
+	require.True(t, errors.Is(s.update("credential-x", true), errStatusNotFound))
+
+	s.store = &mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}, ErrGet: errors.New("get error")}
+
+	_, err = s.allocate("1", 3, "credential-e")
+	require.Contains(t, err.Error(), "get error")
+	require.Contains(t, s.update("credential-a", true).Error(), "get error")
+
+	_, err = s.list("1", 0)
+	require.Contains(t, err.Error(), "get error")
+}
+
+func newMockProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	p := &mockprovider.Provider{
+		StorageProviderValue: mem.NewProvider(),
+		VDRegistryValue:      vdr.New(vdr.WithVDR(key.New())),
+		CryptoValue:          cryptoSvc,
+		SecretLockValue:      &noop.NoLock{},
+		DocumentLoaderValue:  loader,
+	}
+
+	p.KMSValue, err = localkms.New("local-lock://custom/master/key/", p)
+	require.NoError(t, err)
+
+	return p
+}
+
+func createDIDKey(t *testing.T, p *mockprovider.Provider) string {
+	t.Helper()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	didKey, keyID := fingerprint.CreateDIDKey(pubKey)
+
+	// KMS key ID is the fragment of did:key verification method.
+	_, _, err = p.KMSValue.ImportPrivateKey(privKey, kms.ED25519Type, kms.WithKeyID(strings.Split(keyID, "#")[1]))
+	require.NoError(t, err)
+
+	return didKey
+}
+
+func router(op *Operation) *mux.Router {
+	r := mux.NewRouter()
+
+	for _, h := range op.GetRESTHandlers() {
+		r.HandleFunc(h.Path(), h.Handle()).Methods(h.Method())
+	}
+
+	return r
+}
+
+func serve(t *testing.T, op *Operation, method, path string, request interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body io.Reader = http.NoBody
+
+	if request != nil {
+		reqBytes, err := json.Marshal(request)
+		require.NoError(t, err)
+
+		body = bytes.NewReader(reqBytes)
+	}
+
+	rw := httptest.NewRecorder()
+	router(op).ServeHTTP(rw, httptest.NewRequest(method, path, body))
+
+	return rw
+}
+
+func decodeStatusList(t *testing.T, encoded string) []byte {
+	t.Helper()
+
+	compressed, err := base64.RawURLEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+
+	bits, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return bits
+}
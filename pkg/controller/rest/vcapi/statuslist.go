@@ -0,0 +1,206 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// RevocationList2020Status is the only credential status type supported by status lists of VC-API endpoints.
+	RevocationList2020Status = "RevocationList2020Status"
+
+	revocationList2020Context    = "https://w3id.org/vc-revocation-list-2020/v1"
+	revocationList2020Credential = "RevocationList2020Credential"
+	revocationList2020           = "RevocationList2020"
+
+	// minimum size of status list recommended for herd privacy, 16KB.
+	defaultStatusListSize = 131072
+
+	statusListKeyPrefix       = "statuslist_"
+	credentialStatusKeyPrefix = "credentialstatus_"
+
+	bitsPerByte = 8
+)
+
+// errStatusNotFound is returned when credential wasn't issued with status from status lists of the agent.
+var errStatusNotFound = errors.New("credential status not found")
+
+// statusList is bitstring of revocation statuses of issued credentials.
+type statusList struct {
+	ID   string `json:"id"`
+	Size int    `json:"size"`
+	Next int    `json:"next"`
+	Bits []byte `json:"bits"`
+}
+
+func newStatusList(id string, size int) *statusList {
+	if size <= 0 {
+		size = defaultStatusListSize
+	}
+
+	return &statusList{ID: id, Size: size, Bits: make([]byte, (size+bitsPerByte-1)/bitsPerByte)}
+}
+
+// set sets revocation status of given index, index '0' is the left-most bit of the list.
+func (l *statusList) set(index int, revoked bool) {
+	mask := byte(1 << (bitsPerByte - 1 - index%bitsPerByte))
+
+	if revoked {
+		l.Bits[index/bitsPerByte] |= mask
+	} else {
+		l.Bits[index/bitsPerByte] &^= mask
+	}
+}
+
+func (l *statusList) revoked(index int) bool {
+	return l.Bits[index/bitsPerByte]&byte(1<<(bitsPerByte-1-index%bitsPerByte)) != 0
+}
+
+// encode returns GZIP compressed and base64url encoded bitstring as used in 'encodedList' of RevocationList2020.
+func (l *statusList) encode() (string, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	if _, err := w.Write(l.Bits); err != nil {
+		return "", fmt.Errorf("compress status list: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("compress status list: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// credentialStatus is index of issued credential in a status list.
+type credentialStatus struct {
+	ListID string `json:"listId"`
+	Index  int    `json:"index"`
+}
+
+// statusStore keeps status lists and statuses of issued credentials.
+type statusStore struct {
+	store storage.Store
+	mutex sync.Mutex
+}
+
+// allocate assigns next free index of given status list to the credential.
+func (s *statusStore) allocate(listID string, size int, credentialID string) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, err := s.store.Get(credentialStatusKeyPrefix + credentialID)
+	if err == nil {
+		return 0, fmt.Errorf("credential '%s' already has status", credentialID)
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return 0, fmt.Errorf("get credential status: %w", err)
+	}
+
+	list, err := s.getList(listID, size)
+	if err != nil {
+		return 0, err
+	}
+
+	if list.Next >= list.Size {
+		return 0, fmt.Errorf("status list '%s' is full", listID)
+	}
+
+	index := list.Next
+	list.Next++
+
+	if err = s.put(statusListKeyPrefix+listID, list); err != nil {
+		return 0, err
+	}
+
+	if err = s.put(credentialStatusKeyPrefix+credentialID, &credentialStatus{ListID: listID, Index: index}); err != nil {
+		return 0, err
+	}
+
+	return index, nil
+}
+
+// update revokes or reinstates given credential.
+func (s *statusStore) update(credentialID string, revoked bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := s.store.Get(credentialStatusKeyPrefix + credentialID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return errStatusNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("get credential status: %w", err)
+	}
+
+	var status credentialStatus
+
+	if err = json.Unmarshal(data, &status); err != nil {
+		return fmt.Errorf("unmarshal credential status: %w", err)
+	}
+
+	list, err := s.getList(status.ListID, 0)
+	if err != nil {
+		return err
+	}
+
+	list.set(status.Index, revoked)
+
+	return s.put(statusListKeyPrefix+status.ListID, list)
+}
+
+// list returns status list of given ID, an empty list of given size is returned if the list doesn't exist yet.
+func (s *statusStore) list(listID string, size int) (*statusList, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.getList(listID, size)
+}
+
+func (s *statusStore) getList(listID string, size int) (*statusList, error) {
+	data, err := s.store.Get(statusListKeyPrefix + listID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return newStatusList(listID, size), nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get status list: %w", err)
+	}
+
+	var list statusList
+
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("unmarshal status list: %w", err)
+	}
+
+	return &list, nil
+}
+
+func (s *statusStore) put(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal status: %w", err)
+	}
+
+	if err = s.store.Put(key, data); err != nil {
+		return fmt.Errorf("save status: %w", err)
+	}
+
+	return nil
+}
@@ -150,6 +150,26 @@ type contentQueryResponse struct { // nolint: unused,deadcode
 	Results []json.RawMessage `json:"results"`
 }
 
+// searchRequest is request model for searching wallet contents.
+//
+// swagger:parameters searchReq
+type searchRequest struct { // nolint: unused,deadcode
+	// Params for searching wallet contents.
+	//
+	// in: body
+	Params *vcwallet.SearchRequest
+}
+
+// searchResponse is response model for searching wallet contents.
+//
+// swagger:response searchRes
+type searchResponse struct {
+	// total number of matching contents and contents of requested page.
+	//
+	// in: body
+	Response *vcwallet.SearchResponse `json:"response"`
+}
+
 // issueRequest is request model for adding proof to credential from wallet.
 //
 // swagger:parameters issueReq
@@ -191,6 +211,43 @@ type proveResponse struct { // nolint: unused,deadcode
 	Presentation json.RawMessage `json:"presentation"`
 }
 
+// createPresentationResponseRequest is request model for creating presentation responding to
+// verifiable presentation request.
+//
+// swagger:parameters createVPRResponseReq
+type createPresentationResponseRequest struct { // nolint: unused,deadcode
+	// Params for creating presentation response.
+	//
+	// in: body
+	Params *vcwallet.CreatePresentationResponseRequest
+}
+
+// respondPresentationRequest is request model for responding to verifiable presentation request through
+// interact service of the presentation request.
+//
+// swagger:parameters respondVPRReq
+type respondPresentationRequest struct { // nolint: unused,deadcode
+	// Params for responding to presentation request.
+	//
+	// in: body
+	Params *vcwallet.RespondPresentationRequest
+}
+
+// respondPresentationResponse is response model for responding to verifiable presentation request.
+//
+// swagger:response respondVPRRes
+type respondPresentationResponse struct {
+	// presentation sent and response of interact service.
+	//
+	// in: body
+	Response struct {
+		Presentation                  json.RawMessage                       `json:"presentation"`
+		VerifiablePresentation        json.RawMessage                       `json:"verifiablePresentation,omitempty"`
+		VerifiablePresentationRequest *wallet.VerifiablePresentationRequest `json:"verifiablePresentationRequest,omitempty"`
+		RedirectURL                   string                                `json:"redirectUrl,omitempty"`
+	} `json:"response"`
+}
+
 // verifyRequest request for verifying a credential or presentation from wallet.
 // Any one of the credential option should be used.
 //
@@ -366,6 +423,181 @@ type requestCredentialResponse struct {
 	Response *wallet.CredentialInteractionStatus `json:"response"`
 }
 
+// exportRequest is request model for exporting wallet contents.
+//
+// swagger:parameters exportReq
+type exportRequest struct { // nolint: unused,deadcode
+	// Params for exporting wallet contents.
+	//
+	// in: body
+	Params *vcwallet.ExportRequest
+}
+
+// exportResponse is response model for exporting wallet contents.
+//
+// swagger:response exportRes
+type exportResponse struct {
+	// exported wallet with encrypted contents.
+	//
+	// in: body
+	Response *vcwallet.ExportResponse `json:"response"`
+}
+
+// importRequest is request model for importing wallet contents.
+//
+// swagger:parameters importReq
+type importRequest struct { // nolint: unused,deadcode
+	// Params for importing wallet contents.
+	//
+	// in: body
+	Params *vcwallet.ImportRequest
+}
+
+// createDIDRequest is request model for creating DID from wallet.
+//
+// swagger:parameters createDIDReq
+type createDIDRequest struct { // nolint: unused,deadcode
+	// Params for creating DID from wallet.
+	//
+	// in: body
+	Params *vcwallet.CreateDIDRequest
+}
+
+// createDIDResponse is response model for creating DID from wallet.
+//
+// swagger:response createDIDRes
+type createDIDResponse struct {
+	// DID resolution of created DID.
+	//
+	// in: body
+	Response *vcwallet.CreateDIDResponse `json:"response"`
+}
+
+// listDIDsRequest is request model for listing DIDs managed by wallet.
+//
+// swagger:parameters listDIDsReq
+type listDIDsRequest struct { // nolint: unused,deadcode
+	// Params for listing DIDs managed by wallet.
+	//
+	// in: body
+	Params *vcwallet.ListDIDsRequest
+}
+
+// listDIDsResponse is response model for listing DIDs managed by wallet.
+//
+// swagger:response listDIDsRes
+type listDIDsResponse struct {
+	// DIDs managed by wallet.
+	//
+	// in: body
+	Response *vcwallet.ListDIDsResponse `json:"response"`
+}
+
+// setDefaultDIDRequest is request model for setting default wallet DID for signing.
+//
+// swagger:parameters setDefaultDIDReq
+type setDefaultDIDRequest struct { // nolint: unused,deadcode
+	// Params for setting default wallet DID.
+	//
+	// in: body
+	Params *vcwallet.SetDefaultDIDRequest
+}
+
+// updateDIDRequest is request model for updating DID managed by wallet.
+//
+// swagger:parameters updateDIDReq
+type updateDIDRequest struct { // nolint: unused,deadcode
+	// Params for updating DID managed by wallet.
+	//
+	// in: body
+	Params *vcwallet.UpdateDIDRequest
+}
+
+// updateDIDResponse is response model for updating DID managed by wallet.
+//
+// swagger:response updateDIDRes
+type updateDIDResponse struct {
+	// DID resolution of updated DID.
+	//
+	// in: body
+	Response *vcwallet.UpdateDIDResponse `json:"response"`
+}
+
+// deactivateDIDRequest is request model for deactivating DID managed by wallet.
+//
+// swagger:parameters deactivateDIDReq
+type deactivateDIDRequest struct { // nolint: unused,deadcode
+	// Params for deactivating DID managed by wallet.
+	//
+	// in: body
+	Params *vcwallet.DeactivateDIDRequest
+}
+
+// listSessionsRequest is request model for listing wallet sessions.
+//
+// swagger:parameters listSessionsReq
+type listSessionsRequest struct { // nolint: unused,deadcode
+	// Params for listing wallet sessions.
+	//
+	// in: body
+	Params *vcwallet.ListSessionsRequest
+}
+
+// listSessionsResponse is response model for listing wallet sessions.
+//
+// swagger:response listSessionsRes
+type listSessionsResponse struct {
+	// active sessions of wallet.
+	//
+	// in: body
+	Response *vcwallet.ListSessionsResponse `json:"response"`
+}
+
+// refreshSessionRequest is request model for refreshing wallet session token.
+//
+// swagger:parameters refreshSessionReq
+type refreshSessionRequest struct { // nolint: unused,deadcode
+	// Params for refreshing wallet session token.
+	//
+	// in: body
+	Params *vcwallet.RefreshSessionRequest
+}
+
+// refreshSessionResponse is response model for refreshing wallet session token.
+//
+// swagger:response refreshSessionRes
+type refreshSessionResponse struct {
+	// new token of wallet session.
+	//
+	// in: body
+	Response *vcwallet.RefreshSessionResponse `json:"response"`
+}
+
+// revokeSessionRequest is request model for revoking wallet session.
+//
+// swagger:parameters revokeSessionReq
+type revokeSessionRequest struct { // nolint: unused,deadcode
+	// Params for revoking wallet session.
+	//
+	// in: body
+	Params *vcwallet.RevokeSessionRequest
+}
+
+// lockBackgroundSessionsRequest is request model for locking wallet sessions when application goes to background.
+//
+// swagger:parameters lockBackgroundSessionsReq
+type lockBackgroundSessionsRequest struct{} // nolint: unused,deadcode
+
+// lockBackgroundSessionsResponse is response model for locking wallet sessions when application goes to background.
+//
+// swagger:response lockBackgroundSessionsRes
+type lockBackgroundSessionsResponse struct {
+	// number of sessions locked.
+	//
+	// in: body
+	Response *vcwallet.LockBackgroundSessionsResponse `json:"response"`
+}
+
 // emptyRes model
 //
 // swagger:response emptyRes
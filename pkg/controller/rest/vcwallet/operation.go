@@ -38,8 +38,11 @@ const (
 	GetPath                 = OperationID + "/get"
 	GetAllPath              = OperationID + "/getall"
 	QueryPath               = OperationID + "/query"
+	SearchPath              = OperationID + "/search"
 	IssuePath               = OperationID + "/issue"
 	ProvePath               = OperationID + "/prove"
+	CreateVPRResponsePath   = OperationID + "/create-presentation-response"
+	RespondVPRPath          = OperationID + "/respond-presentation-request"
 	VerifyPath              = OperationID + "/verify"
 	DerivePath              = OperationID + "/derive"
 	CreateKeyPairPath       = OperationID + "/create-key-pair"
@@ -48,6 +51,17 @@ const (
 	PresentProofPath        = OperationID + "/present-proof"
 	ProposeCredentialPath   = OperationID + "/propose-credential"
 	RequestCredentialPath   = OperationID + "/request-credential"
+	ExportPath              = OperationID + "/export"
+	ImportPath              = OperationID + "/import"
+	CreateDIDPath           = OperationID + "/create-did"
+	ListDIDsPath            = OperationID + "/list-dids"
+	SetDefaultDIDPath       = OperationID + "/set-default-did"
+	UpdateDIDPath           = OperationID + "/update-did"
+	DeactivateDIDPath       = OperationID + "/deactivate-did"
+	ListSessionsPath        = OperationID + "/list-sessions"
+	RefreshSessionPath      = OperationID + "/refresh-session"
+	RevokeSessionPath       = OperationID + "/revoke-session"
+	LockBackgroundPath      = OperationID + "/lock-background-sessions"
 )
 
 // provider contains dependencies for the verifiable credential wallet command controller
@@ -108,8 +122,11 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(GetPath, http.MethodPost, o.Get),
 		cmdutil.NewHTTPHandler(GetAllPath, http.MethodPost, o.GetAll),
 		cmdutil.NewHTTPHandler(QueryPath, http.MethodPost, o.Query),
+		cmdutil.NewHTTPHandler(SearchPath, http.MethodPost, o.Search),
 		cmdutil.NewHTTPHandler(IssuePath, http.MethodPost, o.Issue),
 		cmdutil.NewHTTPHandler(ProvePath, http.MethodPost, o.Prove),
+		cmdutil.NewHTTPHandler(CreateVPRResponsePath, http.MethodPost, o.CreatePresentationResponse),
+		cmdutil.NewHTTPHandler(RespondVPRPath, http.MethodPost, o.RespondPresentationRequest),
 		cmdutil.NewHTTPHandler(VerifyPath, http.MethodPost, o.Verify),
 		cmdutil.NewHTTPHandler(DerivePath, http.MethodPost, o.Derive),
 		cmdutil.NewHTTPHandler(CreateKeyPairPath, http.MethodPost, o.CreateKeyPair),
@@ -118,6 +135,17 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(PresentProofPath, http.MethodPost, o.PresentProof),
 		cmdutil.NewHTTPHandler(ProposeCredentialPath, http.MethodPost, o.ProposeCredential),
 		cmdutil.NewHTTPHandler(RequestCredentialPath, http.MethodPost, o.RequestCredential),
+		cmdutil.NewHTTPHandler(ExportPath, http.MethodPost, o.Export),
+		cmdutil.NewHTTPHandler(ImportPath, http.MethodPost, o.Import),
+		cmdutil.NewHTTPHandler(CreateDIDPath, http.MethodPost, o.CreateDID),
+		cmdutil.NewHTTPHandler(ListDIDsPath, http.MethodPost, o.ListDIDs),
+		cmdutil.NewHTTPHandler(SetDefaultDIDPath, http.MethodPost, o.SetDefaultDID),
+		cmdutil.NewHTTPHandler(UpdateDIDPath, http.MethodPost, o.UpdateDID),
+		cmdutil.NewHTTPHandler(DeactivateDIDPath, http.MethodPost, o.DeactivateDID),
+		cmdutil.NewHTTPHandler(ListSessionsPath, http.MethodPost, o.ListSessions),
+		cmdutil.NewHTTPHandler(RefreshSessionPath, http.MethodPost, o.RefreshSession),
+		cmdutil.NewHTTPHandler(RevokeSessionPath, http.MethodPost, o.RevokeSession),
+		cmdutil.NewHTTPHandler(LockBackgroundPath, http.MethodPost, o.LockBackgroundSessions),
 	}
 }
 
@@ -283,6 +311,17 @@ func (o *Operation) Query(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Query, rw, req.Body)
 }
 
+// Search swagger:route POST /vcwallet/search vcwallet searchReq
+//
+// searches wallet contents by field, tag, date range and free text filters with sorting and pagination.
+//
+// Responses:
+//    default: genericError
+//        200: searchRes
+func (o *Operation) Search(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Search, rw, req.Body)
+}
+
 // Issue swagger:route POST /vcwallet/issue vcwallet issueReq
 //
 // adds proof to a Verifiable Credential.
@@ -309,6 +348,33 @@ func (o *Operation) Prove(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Prove, rw, req.Body)
 }
 
+// CreatePresentationResponse swagger:route POST /vcwallet/create-presentation-response vcwallet createVPRResponseReq
+//
+// creates signed presentation responding to verifiable presentation request.
+//
+// https://w3c-ccg.github.io/vp-request-spec
+//
+// Responses:
+//    default: genericError
+//        200: proveRes
+func (o *Operation) CreatePresentationResponse(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreatePresentationResponse, rw, req.Body)
+}
+
+// RespondPresentationRequest swagger:route POST /vcwallet/respond-presentation-request vcwallet respondVPRReq
+//
+// creates signed presentation responding to verifiable presentation request and sends it to interact service of
+// the presentation request.
+//
+// https://w3c-ccg.github.io/vp-request-spec/#interaction-types
+//
+// Responses:
+//    default: genericError
+//        200: respondVPRRes
+func (o *Operation) RespondPresentationRequest(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RespondPresentationRequest, rw, req.Body)
+}
+
 // Verify swagger:route POST /vcwallet/verify vcwallet verifyReq
 //
 // verifies a Verifiable Credential or a Verifiable Presentation.
@@ -429,3 +495,126 @@ func getIDFromRequest(rw http.ResponseWriter, req *http.Request) (string, bool)
 
 	return id, true
 }
+
+// Export swagger:route POST /vcwallet/export vcwallet exportReq
+//
+// exports all wallet contents and key material as an encrypted wallet.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#export
+//
+// Responses:
+//    default: genericError
+//        200: exportRes
+func (o *Operation) Export(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Export, rw, req.Body)
+}
+
+// Import swagger:route POST /vcwallet/import vcwallet importReq
+//
+// imports contents of an encrypted wallet into wallet.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#import
+//
+// Responses:
+//    default: genericError
+//        200: emptyRes
+func (o *Operation) Import(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Import, rw, req.Body)
+}
+
+// CreateDID swagger:route POST /vcwallet/create-did vcwallet createDIDReq
+//
+// creates a new DID from wallet key, created DID is managed by wallet and can be used for signing.
+//
+// Responses:
+//    default: genericError
+//        200: createDIDRes
+func (o *Operation) CreateDID(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreateDID, rw, req.Body)
+}
+
+// ListDIDs swagger:route POST /vcwallet/list-dids vcwallet listDIDsReq
+//
+// lists all DIDs managed by wallet.
+//
+// Responses:
+//    default: genericError
+//        200: listDIDsRes
+func (o *Operation) ListDIDs(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ListDIDs, rw, req.Body)
+}
+
+// SetDefaultDID swagger:route POST /vcwallet/set-default-did vcwallet setDefaultDIDReq
+//
+// sets default wallet DID for signing, used as controller when proof options have no controller.
+//
+// Responses:
+//    default: genericError
+//        200: emptyRes
+func (o *Operation) SetDefaultDID(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.SetDefaultDID, rw, req.Body)
+}
+
+// UpdateDID swagger:route POST /vcwallet/update-did vcwallet updateDIDReq
+//
+// updates DID managed by wallet, optionally rotating wallet keys bound to the DID.
+//
+// Responses:
+//    default: genericError
+//        200: updateDIDRes
+func (o *Operation) UpdateDID(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.UpdateDID, rw, req.Body)
+}
+
+// DeactivateDID swagger:route POST /vcwallet/deactivate-did vcwallet deactivateDIDReq
+//
+// deactivates DID managed by wallet.
+//
+// Responses:
+//    default: genericError
+//        200: emptyRes
+func (o *Operation) DeactivateDID(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.DeactivateDID, rw, req.Body)
+}
+
+// ListSessions swagger:route POST /vcwallet/list-sessions vcwallet listSessionsReq
+//
+// lists all active sessions of wallet, session tokens are not included.
+//
+// Responses:
+//    default: genericError
+//        200: listSessionsRes
+func (o *Operation) ListSessions(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ListSessions, rw, req.Body)
+}
+
+// RefreshSession swagger:route POST /vcwallet/refresh-session vcwallet refreshSessionReq
+//
+// replaces wallet session token with a new token and resets idle timer of the session.
+//
+// Responses:
+//    default: genericError
+//        200: refreshSessionRes
+func (o *Operation) RefreshSession(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RefreshSession, rw, req.Body)
+}
+
+// RevokeSession swagger:route POST /vcwallet/revoke-session vcwallet revokeSessionReq
+//
+// revokes wallet session by session ID.
+//
+// Responses:
+//    default: genericError
+//        200: emptyRes
+func (o *Operation) RevokeSession(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RevokeSession, rw, req.Body)
+}
+
+// LockBackgroundSessions swagger:route POST /vcwallet/lock-background-sessions vcwallet lockBackgroundSessionsReq
+//
+// locks all wallet sessions unlocked with 'lockOnBackground' option.
+//
+// Responses:
+//    default: genericError
+//        200: lockBackgroundSessionsRes
+func (o *Operation) LockBackgroundSessions(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.LockBackgroundSessions, rw, req.Body)
+}
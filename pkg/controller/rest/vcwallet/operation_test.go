@@ -48,6 +48,7 @@ import (
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	vdrpkg "github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/wallet"
 )
@@ -260,7 +261,7 @@ func TestNew(t *testing.T) {
 		cmd := New(newMockProvider(t), &vcwallet.Config{})
 		require.NotNil(t, cmd)
 
-		require.Len(t, cmd.GetRESTHandlers(), 20)
+		require.Len(t, cmd.GetRESTHandlers(), 34)
 	})
 }
 
@@ -1935,6 +1936,310 @@ func TestOperation_RequestCredential(t *testing.T) {
 	})
 }
 
+func TestOperation_ExportImport(t *testing.T) {
+	const (
+		sampleUser1 = "sample-user-01"
+		sampleUser2 = "sample-user-02"
+	)
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser2,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token1, lock1 := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock1()
+
+	token2, lock2 := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser2,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock2()
+
+	addContent(t, mockctx, &vcwallet.AddContentRequest{
+		Content:     []byte(sampleMetadata),
+		ContentType: wallet.Metadata,
+		WalletAuth:  vcwallet.WalletAuth{UserID: sampleUser1, Auth: token1},
+	})
+
+	var exported json.RawMessage
+
+	t.Run("export wallet", func(t *testing.T) {
+		request := &vcwallet.ExportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token1},
+			Passphrase: samplePassPhrase,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, ExportPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Export(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var r exportResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+		require.NotEmpty(t, r.Response)
+		require.NotEmpty(t, r.Response.Wallet)
+
+		exported = r.Response.Wallet
+	})
+
+	t.Run("import wallet", func(t *testing.T) {
+		request := &vcwallet.ImportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser2, Auth: token2},
+			Wallet:     exported,
+			Passphrase: samplePassPhrase,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, ImportPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Import(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+	})
+
+	t.Run("export wallet using invalid auth", func(t *testing.T) {
+		request := &vcwallet.ExportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Passphrase: samplePassPhrase,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, ExportPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Export(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "invalid auth token")
+	})
+
+	t.Run("import wallet using invalid passphrase", func(t *testing.T) {
+		request := &vcwallet.ImportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser2, Auth: token2},
+			Wallet:     exported,
+			Passphrase: "invalid",
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, ImportPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Import(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "failed to decrypt wallet contents")
+	})
+}
+
+func TestOperation_DIDManagement(t *testing.T) {
+	const sampleUser1 = "sample-user-01"
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = &mockvdr.MockVDRegistry{
+		CreateFunc: vdrpkg.New(vdrpkg.WithVDR(key.New())).Create,
+		UpdateFunc: func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
+			return nil
+		},
+		DeactivateFunc: func(didID string, opts ...vdrapi.DIDMethodOption) error {
+			return nil
+		},
+	}
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	walletAuth := vcwallet.WalletAuth{UserID: sampleUser1, Auth: token}
+
+	var didID string
+
+	t.Run("create DID", func(t *testing.T) {
+		request := &vcwallet.CreateDIDRequest{WalletAuth: walletAuth, Method: key.DIDMethod}
+
+		rq := httptest.NewRequest(http.MethodPost, CreateDIDPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.CreateDID(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var r createDIDResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+		require.NotEmpty(t, r.Response)
+
+		docResolution, err := did.ParseDocumentResolution(r.Response.DIDResolution)
+		require.NoError(t, err)
+
+		didID = docResolution.DIDDocument.ID
+	})
+
+	t.Run("list DIDs", func(t *testing.T) {
+		request := &vcwallet.ListDIDsRequest{WalletAuth: walletAuth}
+
+		rq := httptest.NewRequest(http.MethodPost, ListDIDsPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.ListDIDs(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var r listDIDsResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+		require.Len(t, r.Response.DIDs, 1)
+		require.Equal(t, didID, r.Response.DIDs[0].ID)
+	})
+
+	t.Run("set default DID", func(t *testing.T) {
+		request := &vcwallet.SetDefaultDIDRequest{WalletAuth: walletAuth, DID: didID}
+
+		rq := httptest.NewRequest(http.MethodPost, SetDefaultDIDPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.SetDefaultDID(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+	})
+
+	t.Run("update DID", func(t *testing.T) {
+		request := &vcwallet.UpdateDIDRequest{WalletAuth: walletAuth, DID: didID, RotateKeys: true}
+
+		rq := httptest.NewRequest(http.MethodPost, UpdateDIDPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.UpdateDID(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var r updateDIDResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+		require.NotEmpty(t, r.Response.DIDResolution)
+	})
+
+	t.Run("deactivate DID", func(t *testing.T) {
+		request := &vcwallet.DeactivateDIDRequest{WalletAuth: walletAuth, DID: didID}
+
+		rq := httptest.NewRequest(http.MethodPost, DeactivateDIDPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.DeactivateDID(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+	})
+
+	t.Run("set deactivated DID as default", func(t *testing.T) {
+		request := &vcwallet.SetDefaultDIDRequest{WalletAuth: walletAuth, DID: didID}
+
+		rq := httptest.NewRequest(http.MethodPost, SetDefaultDIDPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.SetDefaultDID(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "is deactivated")
+	})
+}
+
+func TestOperation_SessionManagement(t *testing.T) {
+	const sampleUser1 = "sample-user-s01"
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+		LockOnBackground:   true,
+	})
+
+	defer lock()
+
+	var sessionID string
+
+	t.Run("list sessions", func(t *testing.T) {
+		request := &vcwallet.ListSessionsRequest{UserID: sampleUser1}
+
+		rq := httptest.NewRequest(http.MethodPost, ListSessionsPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.ListSessions(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var r listSessionsResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+		require.Len(t, r.Response.Sessions, 1)
+		require.True(t, r.Response.Sessions[0].LockOnBackground)
+
+		sessionID = r.Response.Sessions[0].ID
+	})
+
+	t.Run("refresh session", func(t *testing.T) {
+		request := &vcwallet.RefreshSessionRequest{WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token}}
+
+		rq := httptest.NewRequest(http.MethodPost, RefreshSessionPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.RefreshSession(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var r refreshSessionResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+		require.NotEmpty(t, r.Response.Token)
+		require.NotEqual(t, token, r.Response.Token)
+	})
+
+	t.Run("lock background sessions", func(t *testing.T) {
+		rq := httptest.NewRequest(http.MethodPost, LockBackgroundPath, bytes.NewBufferString("{}"))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.LockBackgroundSessions(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var r lockBackgroundSessionsResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+		require.Equal(t, 1, r.Response.Locked)
+	})
+
+	t.Run("revoke session", func(t *testing.T) {
+		request := &vcwallet.RevokeSessionRequest{UserID: sampleUser1, SessionID: sessionID}
+
+		rq := httptest.NewRequest(http.MethodPost, RevokeSessionPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.RevokeSession(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "wallet session not found")
+	})
+}
+
 func createSampleUserProfile(t *testing.T, ctx *mockprovider.Provider, request *vcwallet.CreateOrUpdateProfileRequest) {
 	cmd := New(ctx, &vcwallet.Config{})
 	require.NotNil(t, cmd)
@@ -2074,3 +2379,162 @@ func (m *mockMsg) ParentThreadID() string {
 func (m *mockMsg) ThreadID() (string, error) {
 	return m.thID, nil
 }
+
+func TestOperation_Search(t *testing.T) {
+	const sampleUser1 = "sample-user-search01"
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	addContent(t, mockctx, &vcwallet.AddContentRequest{
+		Content:     []byte(sampleUDCVC),
+		ContentType: wallet.Credential,
+		WalletAuth:  vcwallet.WalletAuth{UserID: sampleUser1, Auth: token},
+	})
+
+	t.Run("search wallet contents", func(t *testing.T) {
+		request := &vcwallet.SearchRequest{
+			WalletAuth:  vcwallet.WalletAuth{UserID: sampleUser1, Auth: token},
+			ContentType: wallet.Credential,
+			Types:       []string{"UniversityDegreeCredential"},
+			SortBy:      wallet.SortByName,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, SearchPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Search(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var r searchResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+		require.Equal(t, 1, r.Response.Total)
+		require.Len(t, r.Response.Contents, 1)
+		require.Equal(t, "http://example.edu/credentials/1877", r.Response.Contents[0].ID)
+	})
+
+	t.Run("search wallet contents failure", func(t *testing.T) {
+		request := &vcwallet.SearchRequest{
+			WalletAuth:  vcwallet.WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			ContentType: wallet.Credential,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, SearchPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Search(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "invalid auth token")
+	})
+}
+
+func TestOperation_PresentationRequest(t *testing.T) {
+	const (
+		sampleUser1 = "sample-user-vpr01"
+		sampleVPR   = `{
+			"query": {"type": "DIDAuth"},
+			"challenge": "sample-vpr-challenge",
+			"interact": {"service": [{"type": "UnmediatedHttpPresentationService2021", "serviceEndpoint": "%s"}]}
+		}`
+	)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx := newMockProvider(t)
+	mockctx.CryptoValue = cryptoSvc
+	mockctx.VDRegistryValue = &mockvdr.MockVDRegistry{
+		CreateFunc: vdrpkg.New(vdrpkg.WithVDR(key.New())).Create,
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			return key.New().Read(didID)
+		},
+	}
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	walletAuth := vcwallet.WalletAuth{UserID: sampleUser1, Auth: token}
+
+	rq := httptest.NewRequest(http.MethodPost, CreateDIDPath, getReader(t, &vcwallet.CreateDIDRequest{
+		WalletAuth: walletAuth, Method: key.DIDMethod, Default: true,
+	}))
+	rw := httptest.NewRecorder()
+
+	New(mockctx, &vcwallet.Config{}).CreateDID(rw, rq)
+	require.Equal(t, rw.Code, http.StatusOK)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"verifiablePresentationRequest": {"query": {"type": "DIDAuth"}, "challenge": "next"}}`))
+	}))
+	defer server.Close()
+
+	vpr := json.RawMessage(fmt.Sprintf(sampleVPR, server.URL))
+
+	t.Run("create presentation response", func(t *testing.T) {
+		request := &vcwallet.CreatePresentationResponseRequest{WalletAuth: walletAuth, PresentationRequest: vpr}
+
+		rq := httptest.NewRequest(http.MethodPost, CreateVPRResponsePath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.CreatePresentationResponse(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var r proveResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r))
+		require.Contains(t, string(r.Presentation), "sample-vpr-challenge")
+	})
+
+	t.Run("respond to presentation request", func(t *testing.T) {
+		request := &vcwallet.RespondPresentationRequest{WalletAuth: walletAuth, PresentationRequest: vpr}
+
+		rq := httptest.NewRequest(http.MethodPost, RespondVPRPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{HTTPClient: server.Client()})
+		cmd.RespondPresentationRequest(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var r respondPresentationResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+		require.Contains(t, string(r.Response.Presentation), "sample-vpr-challenge")
+		require.Equal(t, "next", r.Response.VerifiablePresentationRequest.Challenge)
+	})
+
+	t.Run("respond to presentation request failure", func(t *testing.T) {
+		request := &vcwallet.RespondPresentationRequest{
+			WalletAuth:          vcwallet.WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			PresentationRequest: vpr,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, RespondVPRPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.RespondPresentationRequest(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "invalid auth token")
+	})
+}
@@ -12,6 +12,7 @@ import (
 	vdrcommand "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 )
 
 // saveDIDReq model
@@ -64,6 +65,25 @@ type resolveDIDReq struct { // nolint: unused,deadcode
 	ID string `json:"id"`
 }
 
+// resolveIdentifierReq model
+//
+// This is used to resolve the DID or dereference the DID URL.
+//
+// swagger:parameters resolveIdentifierReq
+type resolveIdentifierReq struct { // nolint: unused,deadcode
+	// DID or DID URL, the fragment is passed percent-encoded
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+
+	// Media type of the result: application/ld+json;profile="https://w3id.org/did-resolution" (default)
+	// for the DID resolution result, application/did+ld+json for the DID document
+	//
+	// in: header
+	Accept string `json:"Accept"`
+}
+
 // documentRes model
 //
 // This is used for returning query connection result for single record search
@@ -86,6 +106,17 @@ type resolveDIDRes struct { // nolint: unused,deadcode
 	Result json.RawMessage `json:"result,omitempty"`
 }
 
+// resolutionResultRes model
+//
+// This is used for returning the DID resolution result, or the resolution error.
+//
+// swagger:response resolutionResultRes
+type resolutionResultRes struct { // nolint: unused,deadcode
+
+	// in: body
+	Result *httpbinding.ResolutionResult
+}
+
 // docResolutionResponse model
 //
 // This is used for returning DID document resolution response.
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -30,6 +31,9 @@ const (
 	ResolveDIDPath    = vdrDIDPath + "/resolve/{id}"
 	CreateDIDPath     = vdrDIDPath + "/create"
 	GetDIDRecordsPath = vdrDIDPath + "/records"
+
+	// ResolveIdentifierPath is the Universal Resolver driver compatible endpoint, see httpbinding.ResolverHandler.
+	ResolveIdentifierPath = httpbinding.IdentifiersPath + "{id:.+}"
 )
 
 // provider contains dependencies for the common controller operations
@@ -43,6 +47,7 @@ type provider interface {
 type Operation struct {
 	handlers []rest.Handler
 	command  *vdr.Command
+	resolver *httpbinding.ResolverHandler
 }
 
 // New returns new common operations rest client instance.
@@ -52,7 +57,7 @@ func New(ctx provider) (*Operation, error) {
 		return nil, fmt.Errorf("new vdr : %w", err)
 	}
 
-	o := &Operation{command: cmd}
+	o := &Operation{command: cmd, resolver: httpbinding.NewResolverHandler(ctx.VDRegistry())}
 	o.registerHandler()
 
 	return o, nil
@@ -72,6 +77,7 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(CreateDIDPath, http.MethodPost, o.CreateDID),
 		cmdutil.NewHTTPHandler(GetDIDRecordsPath, http.MethodGet, o.GetDIDRecords),
 		cmdutil.NewHTTPHandler(GetDIDPath, http.MethodGet, o.GetDID),
+		cmdutil.NewHTTPHandler(ResolveIdentifierPath, http.MethodGet, o.ResolveIdentifier),
 	}
 }
 
@@ -148,3 +154,15 @@ func (o *Operation) ResolveDID(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) GetDIDRecords(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.GetDIDRecords, rw, req.Body)
 }
+
+// ResolveIdentifier swagger:route GET /1.0/identifiers/{id} vdr resolveIdentifierReq
+//
+// Resolves the DID or dereferences the DID URL as a Universal Resolver driver. The DID resolution result,
+// the DID document or the dereferenced content is returned depending on the Accept header.
+//
+// Responses:
+//    default: resolutionResultRes
+//        200: resolutionResultRes
+func (o *Operation) ResolveIdentifier(rw http.ResponseWriter, req *http.Request) {
+	o.resolver.ServeHTTP(rw, req)
+}
@@ -27,6 +27,7 @@ import (
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 )
 
 const sampleDIDName = "sampleDIDName"
@@ -58,7 +59,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 5, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 6, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -259,6 +260,42 @@ func TestResolveDID(t *testing.T) {
 	})
 }
 
+func TestResolveIdentifier(t *testing.T) {
+	didDoc, err := did.ParseDocument([]byte(doc))
+	require.NoError(t, err)
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue:      &mockvdr.MockVDRegistry{ResolveValue: didDoc},
+	})
+	require.NoError(t, err)
+
+	handler := lookupHandler(t, cmd, ResolveIdentifierPath, http.MethodGet)
+
+	t.Run("test resolve identifier - success", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, nil, httpbinding.IdentifiersPath+didDoc.ID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		result := &httpbinding.ResolutionResult{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), result))
+
+		resolved, err := did.ParseDocument(result.DIDDocument)
+		require.NoError(t, err)
+		require.Equal(t, didDoc.ID, resolved.ID)
+	})
+
+	t.Run("test resolve identifier - invalid DID", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, nil, httpbinding.IdentifiersPath+"invalid")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+
+		result := &httpbinding.ResolutionResult{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), result))
+		require.Equal(t, httpbinding.ErrorInvalidDID, result.DIDResolutionMetadata.Error)
+	})
+}
+
 func TestGetDIDRecords(t *testing.T) {
 	t.Run("test get did records", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{
@@ -18,6 +18,8 @@ type ProblemReport struct {
 // Code represents a problem report code.
 type Code struct {
 	Code string `json:"code"`
+	// En is an optional human readable explanation of the problem.
+	En string `json:"en,omitempty"`
 }
 
 // ProblemReportV2 problem report definition.
@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// Menu is the menu of actions published by the responder
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0509-action-menu#menu
+type Menu struct {
+	Type        string            `json:"@type,omitempty"`
+	ID          string            `json:"@id,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	ErrorMsg    string            `json:"errormsg,omitempty"`
+	Options     []MenuOption      `json:"options"`
+	Thread      *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// MenuOption is an action of the menu which can be performed by the requester.
+type MenuOption struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Form        *Form  `json:"form,omitempty"`
+}
+
+// Form describes the parameters the requester has to provide to perform a menu option.
+type Form struct {
+	Title       string      `json:"title,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Params      []FormParam `json:"params,omitempty"`
+	SubmitLabel string      `json:"submit-label,omitempty"`
+}
+
+// FormParam is a field of a form.
+type FormParam struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// MenuRequest is sent by the requester to ask for the current menu
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0509-action-menu#menu-request
+type MenuRequest struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Perform is sent by the requester to perform a menu option
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0509-action-menu#perform
+type Perform struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Name   string            `json:"name"`
+	Params map[string]string `json:"params,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
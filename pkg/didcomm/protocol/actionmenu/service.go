@@ -0,0 +1,322 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// Name defines the protocol name.
+	Name = "action-menu"
+	// Spec defines the protocol spec.
+	Spec = "https://didcomm.org/action-menu/1.0/"
+	// MenuMsgType defines the protocol menu message type.
+	MenuMsgType = Spec + "menu"
+	// MenuRequestMsgType defines the protocol menu-request message type.
+	MenuRequestMsgType = Spec + "menu-request"
+	// PerformMsgType defines the protocol perform message type.
+	PerformMsgType = Spec + "perform"
+)
+
+const (
+	// StateNameMenuReceived is the state of the requester when a menu is received.
+	StateNameMenuReceived = "menu-received"
+	// StateNameMenuRequestReceived is the state of the responder when a menu-request is received.
+	StateNameMenuRequestReceived = "menu-request-received"
+	// StateNamePerformReceived is the state of the responder when a perform is received.
+	StateNamePerformReceived = "perform-received"
+
+	myDIDPropKey    = "myDID"
+	theirDIDPropKey = "theirDID"
+
+	receivedMenuKey  = "received_menu_%s_%s"
+	publishedMenuKey = "published_menu_%s_%s"
+)
+
+// ErrMenuNotFound is returned when no menu was received from the connection.
+var ErrMenuNotFound = errors.New("menu not found")
+
+var logger = log.New("aries-framework/actionmenu/service")
+
+// Provider contains dependencies for the protocol and is typically created by using aries.Context().
+type Provider interface {
+	Messenger() service.Messenger
+	StorageProvider() storage.Provider
+}
+
+// Service for the action-menu protocol.
+type Service struct {
+	service.Action
+	service.Message
+	store       storage.Store
+	messenger   service.Messenger
+	initialized bool
+}
+
+// New returns the action-menu service.
+func New(p Provider) (*Service, error) {
+	svc := Service{}
+
+	err := svc.Initialize(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &svc, nil
+}
+
+// Initialize initializes the Service. If Initialize succeeds, any further call is a no-op.
+func (s *Service) Initialize(prov interface{}) error {
+	if s.initialized {
+		return nil
+	}
+
+	p, ok := prov.(Provider)
+	if !ok {
+		return fmt.Errorf("expected provider of type `%T`, got type `%T`", Provider(nil), prov)
+	}
+
+	store, err := p.StorageProvider().OpenStore(Name)
+	if err != nil {
+		return fmt.Errorf("open store: %w", err)
+	}
+
+	s.store = store
+	s.messenger = p.Messenger()
+	s.initialized = true
+
+	return nil
+}
+
+// SendMenu publishes the menu to the connection. The menu is sent again when the connection requests it.
+func (s *Service) SendMenu(menu *Menu, myDID, theirDID string) error {
+	menu.Type = MenuMsgType
+
+	msg := service.NewDIDCommMsgMap(menu)
+
+	if err := s.saveMsg(fmt.Sprintf(publishedMenuKey, myDID, theirDID), msg); err != nil {
+		return fmt.Errorf("save menu: %w", err)
+	}
+
+	if err := s.messenger.Send(msg, myDID, theirDID); err != nil {
+		return fmt.Errorf("send menu: %w", err)
+	}
+
+	return nil
+}
+
+// RequestMenu requests the current menu of the connection.
+func (s *Service) RequestMenu(myDID, theirDID string) error {
+	msg := service.NewDIDCommMsgMap(&MenuRequest{Type: MenuRequestMsgType})
+
+	if err := s.messenger.Send(msg, myDID, theirDID); err != nil {
+		return fmt.Errorf("send menu-request: %w", err)
+	}
+
+	return nil
+}
+
+// Perform performs the menu option of the menu received from the connection.
+func (s *Service) Perform(perform *Perform, myDID, theirDID string) error {
+	perform.Type = PerformMsgType
+
+	msg := service.NewDIDCommMsgMap(perform)
+
+	menu, err := s.getMsg(fmt.Sprintf(receivedMenuKey, myDID, theirDID))
+	if errors.Is(err, ErrMenuNotFound) {
+		if err = s.messenger.Send(msg, myDID, theirDID); err != nil {
+			return fmt.Errorf("send perform: %w", err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("get menu: %w", err)
+	}
+
+	// the perform message is sent on the thread of the menu
+	if err = s.messenger.ReplyToMsg(menu, msg, myDID, theirDID); err != nil {
+		return fmt.Errorf("send perform: %w", err)
+	}
+
+	return nil
+}
+
+// GetMenu returns the last menu received from the connection.
+func (s *Service) GetMenu(myDID, theirDID string) (*Menu, error) {
+	msg, err := s.getMsg(fmt.Sprintf(receivedMenuKey, myDID, theirDID))
+	if err != nil {
+		return nil, err
+	}
+
+	menu := &Menu{}
+
+	if err = msg.Decode(menu); err != nil {
+		return nil, fmt.Errorf("decode menu: %w", err)
+	}
+
+	return menu, nil
+}
+
+// HandleInbound handles inbound action-menu messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	logger.Debugf("service.HandleInbound() input: msg=%+v myDID=%s theirDID=%s", msg, ctx.MyDID(), ctx.TheirDID())
+
+	msgMap := msg.Clone()
+
+	var (
+		stateID string
+		err     error
+	)
+
+	switch msg.Type() {
+	case MenuMsgType:
+		stateID = StateNameMenuReceived
+		err = s.saveMsg(fmt.Sprintf(receivedMenuKey, ctx.MyDID(), ctx.TheirDID()), msgMap)
+	case MenuRequestMsgType:
+		stateID = StateNameMenuRequestReceived
+		err = s.replyWithMenu(msgMap, ctx.MyDID(), ctx.TheirDID())
+	case PerformMsgType:
+		stateID = StateNamePerformReceived
+	default:
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("handle %s: %w", msg.Type(), err)
+	}
+
+	s.sendMsgEvents(msgMap, stateID, ctx.MyDID(), ctx.TheirDID())
+
+	return msgMap.ThreadID()
+}
+
+// HandleOutbound handles outbound action-menu messages.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	var err error
+
+	switch msg.Type() {
+	case MenuMsgType:
+		menu := &Menu{}
+		if err = msg.Decode(menu); err == nil {
+			err = s.SendMenu(menu, myDID, theirDID)
+		}
+	case MenuRequestMsgType:
+		err = s.RequestMenu(myDID, theirDID)
+	case PerformMsgType:
+		perform := &Perform{}
+		if err = msg.Decode(perform); err == nil {
+			err = s.Perform(perform, myDID, theirDID)
+		}
+	default:
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return msg.ID(), nil
+}
+
+// Name returns service name.
+func (s *Service) Name() string {
+	return Name
+}
+
+// Accept msg checks the msg type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case MenuMsgType, MenuRequestMsgType, PerformMsgType:
+		return true
+	}
+
+	return false
+}
+
+func (s *Service) replyWithMenu(request service.DIDCommMsgMap, myDID, theirDID string) error {
+	menu, err := s.getMsg(fmt.Sprintf(publishedMenuKey, myDID, theirDID))
+	if errors.Is(err, ErrMenuNotFound) {
+		// no menu was published to the connection, the menu-request event lets the application send one
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("get menu: %w", err)
+	}
+
+	return s.messenger.ReplyToMsg(request, menu, myDID, theirDID)
+}
+
+func (s *Service) sendMsgEvents(msg service.DIDCommMsgMap, stateID, myDID, theirDID string) {
+	for _, handler := range s.MsgEvents() {
+		handler <- service.StateMsg{
+			ProtocolName: Name,
+			Type:         service.PostState,
+			Msg:          msg,
+			StateID:      stateID,
+			Properties:   &eventProps{myDID: myDID, theirDID: theirDID},
+		}
+	}
+}
+
+func (s *Service) saveMsg(key string, msg service.DIDCommMsgMap) error {
+	src, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Put(key, src)
+}
+
+func (s *Service) getMsg(key string) (service.DIDCommMsgMap, error) {
+	src, err := s.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrMenuNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	msg := service.DIDCommMsgMap{}
+
+	if err = json.Unmarshal(src, &msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+type eventProps struct {
+	myDID    string
+	theirDID string
+}
+
+func (e *eventProps) MyDID() string {
+	return e.myDID
+}
+
+func (e *eventProps) TheirDID() string {
+	return e.theirDID
+}
+
+// All implements EventProperties interface.
+func (e *eventProps) All() map[string]interface{} {
+	return map[string]interface{}{
+		myDIDPropKey:    e.myDID,
+		theirDIDPropKey: e.theirDID,
+	}
+}
@@ -0,0 +1,321 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockservice "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, err := actionmenu.New(&protocol.MockProvider{StoreProvider: mem.NewProvider()})
+		require.NoError(t, err)
+		require.Equal(t, actionmenu.Name, svc.Name())
+
+		require.NoError(t, svc.Initialize(&protocol.MockProvider{}))
+	})
+
+	t.Run("fail: provider of wrong type", func(t *testing.T) {
+		svc := actionmenu.Service{}
+
+		err := svc.Initialize("this is not a provider")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expected provider of type")
+	})
+
+	t.Run("fail: open store", func(t *testing.T) {
+		_, err := actionmenu.New(&protocol.MockProvider{
+			StoreProvider: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("test error")},
+		})
+		require.EqualError(t, err, "open store: test error")
+	})
+}
+
+func TestService_Accept(t *testing.T) {
+	svc, err := actionmenu.New(&protocol.MockProvider{StoreProvider: mem.NewProvider()})
+	require.NoError(t, err)
+
+	require.True(t, svc.Accept(actionmenu.MenuMsgType))
+	require.True(t, svc.Accept(actionmenu.MenuRequestMsgType))
+	require.True(t, svc.Accept(actionmenu.PerformMsgType))
+	require.False(t, svc.Accept("unknown"))
+}
+
+func TestService_SendMenu(t *testing.T) {
+	t.Run("menu is sent again on request", func(t *testing.T) {
+		var replied service.DIDCommMsgMap
+
+		messenger := &mockservice.MockMessenger{
+			ReplyToMsgFunc: func(in, out service.DIDCommMsgMap, myDID, theirDID string) error {
+				require.Equal(t, Alice, myDID)
+				require.Equal(t, Bob, theirDID)
+				require.Equal(t, actionmenu.MenuRequestMsgType, in.Type())
+
+				replied = out
+
+				return nil
+			},
+		}
+
+		svc, err := actionmenu.New(&protocol.MockProvider{
+			StoreProvider:   mem.NewProvider(),
+			CustomMessenger: messenger,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.SendMenu(testMenu(), Alice, Bob))
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		request := service.NewDIDCommMsgMap(&actionmenu.MenuRequest{
+			Type: actionmenu.MenuRequestMsgType,
+			ID:   "request-id",
+		})
+
+		thID, err := svc.HandleInbound(request, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+		require.Equal(t, "request-id", thID)
+
+		require.NotNil(t, replied)
+		require.Equal(t, actionmenu.MenuMsgType, replied.Type())
+
+		menu := &actionmenu.Menu{}
+		require.NoError(t, replied.Decode(menu))
+		require.Equal(t, testMenu().Options, menu.Options)
+
+		event := <-events
+		require.Equal(t, actionmenu.StateNameMenuRequestReceived, event.StateID)
+		require.Equal(t, Alice, event.Properties.All()["myDID"])
+		require.Equal(t, Bob, event.Properties.All()["theirDID"])
+	})
+
+	t.Run("menu request without published menu", func(t *testing.T) {
+		svc, err := actionmenu.New(&protocol.MockProvider{
+			StoreProvider: mem.NewProvider(),
+			CustomMessenger: &mockservice.MockMessenger{
+				ReplyToMsgFunc: func(service.DIDCommMsgMap, service.DIDCommMsgMap, string, string) error {
+					return errors.New("unexpected reply")
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		request := service.NewDIDCommMsgMap(&actionmenu.MenuRequest{Type: actionmenu.MenuRequestMsgType, ID: "id"})
+
+		_, err = svc.HandleInbound(request, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("fail: send", func(t *testing.T) {
+		svc, err := actionmenu.New(&protocol.MockProvider{
+			StoreProvider:   mem.NewProvider(),
+			CustomMessenger: &mockservice.MockMessenger{ErrSend: errors.New("test error")},
+		})
+		require.NoError(t, err)
+
+		require.EqualError(t, svc.SendMenu(testMenu(), Alice, Bob), "send menu: test error")
+	})
+
+	t.Run("fail: save", func(t *testing.T) {
+		svc, err := actionmenu.New(&protocol.MockProvider{
+			StoreProvider: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+				Store:  map[string]mockstore.DBEntry{},
+				ErrPut: errors.New("test error"),
+			}),
+		})
+		require.NoError(t, err)
+
+		require.EqualError(t, svc.SendMenu(testMenu(), Alice, Bob), "save menu: test error")
+	})
+}
+
+func TestService_RequestMenu(t *testing.T) {
+	svc, err := actionmenu.New(&protocol.MockProvider{StoreProvider: mem.NewProvider()})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.RequestMenu(Bob, Alice))
+
+	svc, err = actionmenu.New(&protocol.MockProvider{
+		StoreProvider:   mem.NewProvider(),
+		CustomMessenger: &mockservice.MockMessenger{ErrSend: errors.New("test error")},
+	})
+	require.NoError(t, err)
+
+	require.EqualError(t, svc.RequestMenu(Bob, Alice), "send menu-request: test error")
+}
+
+func TestService_Perform(t *testing.T) {
+	t.Run("perform is sent on the thread of the received menu", func(t *testing.T) {
+		var replied service.DIDCommMsgMap
+
+		svc, err := actionmenu.New(&protocol.MockProvider{
+			StoreProvider: mem.NewProvider(),
+			CustomMessenger: &mockservice.MockMessenger{
+				ReplyToMsgFunc: func(in, out service.DIDCommMsgMap, _, _ string) error {
+					require.Equal(t, "menu-id", in.ID())
+
+					replied = out
+
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = svc.GetMenu(Bob, Alice)
+		require.True(t, errors.Is(err, actionmenu.ErrMenuNotFound))
+
+		menu := testMenu()
+		menu.Type = actionmenu.MenuMsgType
+		menu.ID = "menu-id"
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		thID, err := svc.HandleInbound(service.NewDIDCommMsgMap(menu), service.NewDIDCommContext(Bob, Alice, nil))
+		require.NoError(t, err)
+		require.Equal(t, "menu-id", thID)
+		require.Equal(t, actionmenu.StateNameMenuReceived, (<-events).StateID)
+
+		received, err := svc.GetMenu(Bob, Alice)
+		require.NoError(t, err)
+		require.Equal(t, "Services", received.Title)
+		require.Equal(t, menu.Options, received.Options)
+
+		require.NoError(t, svc.Perform(&actionmenu.Perform{
+			Name:   "balance",
+			Params: map[string]string{"account": "123"},
+		}, Bob, Alice))
+
+		perform := &actionmenu.Perform{}
+		require.NoError(t, replied.Decode(perform))
+		require.Equal(t, actionmenu.PerformMsgType, perform.Type)
+		require.Equal(t, "balance", perform.Name)
+		require.Equal(t, "123", perform.Params["account"])
+	})
+
+	t.Run("perform without a received menu", func(t *testing.T) {
+		svc, err := actionmenu.New(&protocol.MockProvider{StoreProvider: mem.NewProvider()})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.Perform(&actionmenu.Perform{Name: "balance"}, Bob, Alice))
+	})
+
+	t.Run("fail: send", func(t *testing.T) {
+		svc, err := actionmenu.New(&protocol.MockProvider{
+			StoreProvider:   mem.NewProvider(),
+			CustomMessenger: &mockservice.MockMessenger{ErrSend: errors.New("test error")},
+		})
+		require.NoError(t, err)
+
+		require.EqualError(t, svc.Perform(&actionmenu.Perform{Name: "balance"}, Bob, Alice),
+			"send perform: test error")
+	})
+
+	t.Run("perform is received", func(t *testing.T) {
+		svc, err := actionmenu.New(&protocol.MockProvider{StoreProvider: mem.NewProvider()})
+		require.NoError(t, err)
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		perform := service.NewDIDCommMsgMap(&actionmenu.Perform{
+			Type:   actionmenu.PerformMsgType,
+			ID:     "perform-id",
+			Name:   "balance",
+			Params: map[string]string{"account": "123"},
+		})
+
+		_, err = svc.HandleInbound(perform, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		event := <-events
+		require.Equal(t, actionmenu.StateNamePerformReceived, event.StateID)
+
+		received := &actionmenu.Perform{}
+		require.NoError(t, event.Msg.Decode(received))
+		require.Equal(t, "balance", received.Name)
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	svc, err := actionmenu.New(&protocol.MockProvider{StoreProvider: mem.NewProvider()})
+	require.NoError(t, err)
+
+	_, err = svc.HandleInbound(service.NewDIDCommMsgMap(struct {
+		Type string `json:"@type"`
+	}{Type: "unknown"}), service.NewDIDCommContext(Alice, Bob, nil))
+	require.EqualError(t, err, "unsupported message type unknown")
+}
+
+func TestService_HandleOutbound(t *testing.T) {
+	svc, err := actionmenu.New(&protocol.MockProvider{StoreProvider: mem.NewProvider()})
+	require.NoError(t, err)
+
+	menu := testMenu()
+	menu.Type = actionmenu.MenuMsgType
+
+	_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(menu), Alice, Bob)
+	require.NoError(t, err)
+
+	_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&actionmenu.MenuRequest{
+		Type: actionmenu.MenuRequestMsgType,
+	}), Bob, Alice)
+	require.NoError(t, err)
+
+	_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&actionmenu.Perform{
+		Type: actionmenu.PerformMsgType,
+		Name: "balance",
+	}), Bob, Alice)
+	require.NoError(t, err)
+
+	_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(struct {
+		Type string `json:"@type"`
+	}{Type: "unknown"}), Alice, Bob)
+	require.EqualError(t, err, "unsupported message type unknown")
+}
+
+func testMenu() *actionmenu.Menu {
+	return &actionmenu.Menu{
+		Title:       "Services",
+		Description: "Banking services",
+		Options: []actionmenu.MenuOption{{
+			Name:  "balance",
+			Title: "Check balance",
+			Form: &actionmenu.Form{
+				Title:       "Account",
+				SubmitLabel: "Check",
+				Params: []actionmenu.FormParam{{
+					Name:     "account",
+					Title:    "Account number",
+					Type:     "int",
+					Required: true,
+				}},
+			},
+		}, {
+			Name:     "transfer",
+			Title:    "Transfer funds",
+			Disabled: true,
+		}},
+	}
+}
@@ -0,0 +1,610 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	stateNameOfferSent       = "offer-sent"
+	stateNameRequestReceived = "request-received"
+
+	// Ed25519Signature2018 ed25519 signature suite.
+	Ed25519Signature2018 = "Ed25519Signature2018"
+	// JSONWebSignature2020 json web signature suite.
+	JSONWebSignature2020 = "JsonWebSignature2020"
+	// EcdsaSecp256k1Signature2019 secp256k1 signature suite.
+	EcdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
+	// BbsBlsSignature2020 BBS signature suite.
+	BbsBlsSignature2020 = "BbsBlsSignature2020"
+
+	mimeTypeApplicationLdJSON = "application/ld+json"
+	mimeTypeApplicationJWT    = "application/jwt"
+
+	credentialsContext = "https://www.w3.org/2018/credentials/v1"
+	bbsContext         = "https://w3id.org/security/bbs/v1"
+)
+
+// ProofFormat is the format of the proof added to issued credentials.
+type ProofFormat int
+
+const (
+	// LinkedDataProof adds an embedded linked data proof to the credential (default).
+	LinkedDataProof ProofFormat = iota
+	// JWTProof issues the credential as a JWT.
+	JWTProof
+)
+
+// IssuerProvider contains dependencies for the IssueCredentials middleware function.
+type IssuerProvider interface {
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	JSONLDDocumentLoader() ld.DocumentLoader
+	VDRegistry() vdrapi.Registry
+	StorageProvider() storage.Provider
+}
+
+// CredentialRequest is the information about a request-credential message given to a CredentialDataSource.
+type CredentialRequest struct {
+	// Message is the request-credential message received from the holder.
+	Message service.DIDCommMsg
+	// Attachments are the contents of the request attachments (eg: a credential detail or a credential application).
+	Attachments [][]byte
+	// MyDID is the DID of the issuer in the connection.
+	MyDID string
+	// TheirDID is the DID of the holder in the connection.
+	TheirDID string
+	// Properties are the protocol properties, including the ones provided through the Continue function.
+	Properties map[string]interface{}
+	// CredentialApplication is the credential application of the holder, set when issuing against
+	// a credential manifest (see WithCredentialManifest).
+	CredentialApplication *verifiable.Presentation
+	// SubmittedCredentials are the credentials submitted in the credential application by input descriptor ID,
+	// set when issuing against a credential manifest having a presentation definition.
+	SubmittedCredentials map[string]*verifiable.Credential
+}
+
+// CredentialDataSource provides the data of the credential issued in response to a credential request.
+type CredentialDataSource interface {
+	// CredentialData returns the top level credential fields (eg: "credentialSubject", "expirationDate")
+	// which are merged into the credential template.
+	CredentialData(request *CredentialRequest) (map[string]interface{}, error)
+}
+
+// CredentialDataSourceFunc is a helper type which implements the CredentialDataSource interface.
+type CredentialDataSourceFunc func(request *CredentialRequest) (map[string]interface{}, error)
+
+// CredentialData implements function to satisfy the CredentialDataSource interface.
+func (f CredentialDataSourceFunc) CredentialData(request *CredentialRequest) (map[string]interface{}, error) {
+	return f(request)
+}
+
+// StatusProvider allocates a credential status entry (eg: a status list index) for a credential being issued.
+// Any JSON-LD context required by the status entry must be added to the credential by the provider.
+type StatusProvider interface {
+	CreateStatusEntry(vc *verifiable.Credential) (*verifiable.TypedID, error)
+}
+
+// OptIssuer represents option function for the IssueCredentials middleware.
+type OptIssuer func(o *issuerOptions)
+
+type issuerOptions struct {
+	template           json.RawMessage
+	verificationMethod string
+	keyID              string
+	format             ProofFormat
+	signatureType      string
+	jwtAlgorithm       verifiable.JWSAlgorithm
+	statusProvider     StatusProvider
+	manifest           *cm.CredentialManifest
+	publicKeyFetcher   verifiable.PublicKeyFetcher
+}
+
+// WithCredentialTemplate sets the credential template filled by the credential data source. The template defaults to
+// a credential having the W3C credentials context and the VerifiableCredential type.
+func WithCredentialTemplate(template json.RawMessage) OptIssuer {
+	return func(o *issuerOptions) {
+		o.template = template
+	}
+}
+
+// WithVerificationMethod sets the DID URL of the issuer key (eg: "did:example:123#key-1"), mandatory.
+// The issuer of the credential defaults to the DID of the verification method.
+func WithVerificationMethod(verificationMethod string) OptIssuer {
+	return func(o *issuerOptions) {
+		o.verificationMethod = verificationMethod
+	}
+}
+
+// WithKeyID sets the KMS key ID of the issuer key, it defaults to the fragment of the verification method.
+func WithKeyID(keyID string) OptIssuer {
+	return func(o *issuerOptions) {
+		o.keyID = keyID
+	}
+}
+
+// WithLDProof issues credentials with an embedded linked data proof of the given signature type
+// (Ed25519Signature2018, JsonWebSignature2020, EcdsaSecp256k1Signature2019 or BbsBlsSignature2020).
+func WithLDProof(signatureType string) OptIssuer {
+	return func(o *issuerOptions) {
+		o.format = LinkedDataProof
+		o.signatureType = signatureType
+	}
+}
+
+// WithJWTProof issues credentials as JWTs signed with the given algorithm.
+func WithJWTProof(alg verifiable.JWSAlgorithm) OptIssuer {
+	return func(o *issuerOptions) {
+		o.format = JWTProof
+		o.jwtAlgorithm = alg
+	}
+}
+
+// WithStatusProvider adds a credential status entry created by the provider to issued credentials.
+func WithStatusProvider(statusProvider StatusProvider) OptIssuer {
+	return func(o *issuerOptions) {
+		o.statusProvider = statusProvider
+	}
+}
+
+// WithCredentialManifest issues credentials against the credential manifest (WACI style): the request-credential
+// message must attach a credential application valid against the manifest offered in the thread and signed with
+// the challenge and domain of the offer (see NewCredentialManifestOffer and NewCredentialApplicationRequest),
+// and the issued credential is attached wrapped into a credential fulfillment.
+// The manifest must have a single output descriptor as one credential is issued per request, and credentials
+// are issued with linked data proofs only.
+func WithCredentialManifest(manifest *cm.CredentialManifest) OptIssuer {
+	return func(o *issuerOptions) {
+		o.manifest = manifest
+	}
+}
+
+// WithPublicKeyFetcher sets the public key fetcher used to verify the proofs of credential applications and of
+// the credentials they submit, the keys are resolved through the VDR by default.
+func WithPublicKeyFetcher(fetcher verifiable.PublicKeyFetcher) OptIssuer {
+	return func(o *issuerOptions) {
+		o.publicKeyFetcher = fetcher
+	}
+}
+
+// IssueCredentials the helper function for the issue credential protocol which issues credentials on the issuer side.
+// When a request-credential is continued with an issue-credential message without attachments
+// (see AutoIssueCredentials), the credential template is filled with the data source, signed with the issuer key
+// and attached to the issue-credential message.
+func IssueCredentials(p IssuerProvider, source CredentialDataSource,
+	opts ...OptIssuer) (issuecredential.Middleware, error) {
+	options := &issuerOptions{
+		signatureType: Ed25519Signature2018,
+		jwtAlgorithm:  verifiable.EdDSA,
+	}
+
+	for i := range opts {
+		opts[i](options)
+	}
+
+	if source == nil {
+		return nil, errors.New("credential data source is mandatory")
+	}
+
+	issuerDID, keyID, err := parseVerificationMethod(options.verificationMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.keyID == "" {
+		options.keyID = keyID
+	}
+
+	if err = validateCredentialManifest(options); err != nil {
+		return nil, err
+	}
+
+	if options.format == LinkedDataProof {
+		if _, err = newSignatureSuite(options.signatureType, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.publicKeyFetcher == nil {
+		options.publicKeyFetcher = verifiable.NewVDRKeyResolver(p.VDRegistry()).PublicKeyFetcher()
+	}
+
+	iss := &issuer{
+		km:             p.KMS(),
+		cr:             p.Crypto(),
+		documentLoader: p.JSONLDDocumentLoader(),
+		source:         source,
+		issuerDID:      issuerDID,
+		options:        options,
+	}
+
+	if options.manifest != nil {
+		iss.offers, err = p.StorageProvider().OpenStore(ManifestOffersStoreName)
+		if err != nil {
+			return nil, fmt.Errorf("open store: %w", err)
+		}
+	}
+
+	return func(next issuecredential.Handler) issuecredential.Handler {
+		return issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+			switch metadata.StateName() {
+			case stateNameOfferSent:
+				if options.manifest == nil {
+					break
+				}
+
+				if err := iss.saveOffer(metadata); err != nil {
+					return fmt.Errorf("issue credentials: save offer: %w", err)
+				}
+			case stateNameRequestReceived:
+				if err := iss.handle(metadata); err != nil {
+					return fmt.Errorf("issue credentials: %w", err)
+				}
+			}
+
+			return next.Handle(metadata)
+		})
+	}, nil
+}
+
+// AutoIssueCredentials continues request-credential actions with an empty issue-credential message which is then
+// filled by the IssueCredentials middleware. Other actions are passed through to 'next'.
+//
+// Usage:
+//     events := make(chan service.DIDCommAction)
+//     err := client.RegisterActionEvent(events)
+//     if err != nil {
+//         panic(err)
+//     }
+//     next := make(chan service.DIDCommAction)
+//     go AutoIssueCredentials(next)(events)
+//     for event := range next {
+//         // handle other issue-credential actions
+//     }
+func AutoIssueCredentials(next chan service.DIDCommAction) func(chan service.DIDCommAction) {
+	return func(events chan service.DIDCommAction) {
+		for event := range events {
+			switch event.Message.Type() {
+			case issuecredential.RequestCredentialMsgTypeV2, issuecredential.RequestCredentialMsgTypeV3:
+				event.Continue(issuecredential.WithIssueCredential(&issuecredential.IssueCredentialParams{}))
+			default:
+				next <- event
+			}
+		}
+	}
+}
+
+type issuer struct {
+	km             kms.KeyManager
+	cr             crypto.Crypto
+	documentLoader ld.DocumentLoader
+	source         CredentialDataSource
+	issuerDID      string
+	offers         storage.Store
+	options        *issuerOptions
+}
+
+func (i *issuer) handle(metadata issuecredential.Metadata) error {
+	issueV2, issueV3 := metadata.IssueCredentialV2(), metadata.IssueCredentialV3()
+
+	// credentials were provided through the Continue function
+	if (issueV2 == nil || len(issueV2.CredentialsAttach) != 0) && (issueV3 == nil || len(issueV3.Attachments) != 0) {
+		return nil
+	}
+
+	request, err := i.credentialRequest(metadata)
+	if err != nil {
+		return err
+	}
+
+	data, err := i.source.CredentialData(request)
+	if err != nil {
+		return fmt.Errorf("credential data source: %w", err)
+	}
+
+	vc, err := i.createCredential(data)
+	if err != nil {
+		return err
+	}
+
+	attachData, mediaType, err := i.sign(vc)
+	if err != nil {
+		return err
+	}
+
+	attachID := uuid.New().String()
+
+	if i.options.manifest != nil {
+		attachData, err = i.fulfill(vc)
+		if err != nil {
+			return err
+		}
+
+		issueV3.Attachments = append(issueV3.Attachments, decorator.AttachmentV2{
+			ID:        attachID,
+			MediaType: mediaType,
+			Format:    CredentialFulfillmentAttachmentFormat,
+			Data:      attachData,
+		})
+
+		return nil
+	}
+
+	if issueV3 != nil {
+		issueV3.Attachments = append(issueV3.Attachments, decorator.AttachmentV2{
+			ID:        attachID,
+			MediaType: mediaType,
+			Data:      attachData,
+		})
+
+		return nil
+	}
+
+	issueV2.CredentialsAttach = append(issueV2.CredentialsAttach, decorator.Attachment{
+		ID:       attachID,
+		MimeType: mediaType,
+		Data:     attachData,
+	})
+
+	return nil
+}
+
+func (i *issuer) credentialRequest(metadata issuecredential.Metadata) (*CredentialRequest, error) {
+	msg := metadata.Message()
+
+	var attachments []decorator.AttachmentData
+
+	if strings.HasPrefix(msg.Type(), issuecredential.SpecV3) {
+		request := issuecredential.RequestCredentialV3{}
+		if err := msg.Decode(&request); err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		attachments = filterByMediaType(request.Attachments, mimeTypeAll)
+	} else {
+		request := issuecredential.RequestCredentialV2{}
+		if err := msg.Decode(&request); err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		attachments = filterByMimeType(request.RequestsAttach, mimeTypeAll)
+	}
+
+	properties := metadata.Properties()
+
+	req := &CredentialRequest{
+		Message:    msg,
+		Properties: properties,
+	}
+
+	// nolint: errcheck
+	req.MyDID, _ = properties[myDIDKey].(string)
+	// nolint: errcheck
+	req.TheirDID, _ = properties[theirDIDKey].(string)
+
+	if i.options.manifest != nil {
+		if err := i.credentialApplication(msg, req); err != nil {
+			return nil, err
+		}
+	}
+
+	for j := range attachments {
+		data, err := attachments[j].Fetch()
+		if err != nil {
+			return nil, fmt.Errorf("fetch: %w", err)
+		}
+
+		req.Attachments = append(req.Attachments, data)
+	}
+
+	return req, nil
+}
+
+func (i *issuer) createCredential(data map[string]interface{}) (*verifiable.Credential, error) {
+	vcMap := map[string]interface{}{
+		"@context": []interface{}{credentialsContext},
+		"type":     []interface{}{"VerifiableCredential"},
+	}
+
+	if len(i.options.template) != 0 {
+		vcMap = map[string]interface{}{}
+
+		if err := json.Unmarshal(i.options.template, &vcMap); err != nil {
+			return nil, fmt.Errorf("unmarshal credential template: %w", err)
+		}
+	}
+
+	for k, v := range data {
+		vcMap[k] = v
+	}
+
+	if _, ok := vcMap["id"]; !ok {
+		vcMap["id"] = "urn:uuid:" + uuid.New().String()
+	}
+
+	if _, ok := vcMap["issuer"]; !ok {
+		vcMap["issuer"] = i.issuerDID
+	}
+
+	if _, ok := vcMap["issuanceDate"]; !ok {
+		vcMap["issuanceDate"] = util.NewTime(time.Now().UTC())
+	}
+
+	raw, err := json.Marshal(vcMap)
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential: %w", err)
+	}
+
+	vc, err := verifiable.ParseCredential(raw,
+		verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(i.documentLoader))
+	if err != nil {
+		return nil, fmt.Errorf("parse credential: %w", err)
+	}
+
+	if i.options.statusProvider != nil {
+		status, err := i.options.statusProvider.CreateStatusEntry(vc)
+		if err != nil {
+			return nil, fmt.Errorf("create status entry: %w", err)
+		}
+
+		vc.Status = status
+	}
+
+	return vc, nil
+}
+
+func (i *issuer) sign(vc *verifiable.Credential) (decorator.AttachmentData, string, error) {
+	kh, err := i.km.Get(i.options.keyID)
+	if err != nil {
+		return decorator.AttachmentData{}, "", fmt.Errorf("get issuer key: %w", err)
+	}
+
+	if i.options.format == JWTProof {
+		claims, err := vc.JWTClaims(false)
+		if err != nil {
+			return decorator.AttachmentData{}, "", fmt.Errorf("jwt claims: %w", err)
+		}
+
+		jws, err := claims.MarshalJWS(i.options.jwtAlgorithm, suite.NewCryptoSigner(i.cr, kh),
+			i.options.verificationMethod)
+		if err != nil {
+			return decorator.AttachmentData{}, "", fmt.Errorf("sign jwt: %w", err)
+		}
+
+		return decorator.AttachmentData{
+			Base64: base64.StdEncoding.EncodeToString([]byte(jws)),
+		}, mimeTypeApplicationJWT, nil
+	}
+
+	representation := verifiable.SignatureJWS
+
+	var s suite.Opt
+
+	if i.options.signatureType == BbsBlsSignature2020 {
+		vc.Context = append(vc.Context, bbsContext)
+		representation = verifiable.SignatureProofValue
+		s = suite.WithSigner(&bbsSigner{cr: i.cr, kh: kh})
+	} else {
+		s = suite.WithSigner(suite.NewCryptoSigner(i.cr, kh))
+	}
+
+	signatureSuite, err := newSignatureSuite(i.options.signatureType, s)
+	if err != nil {
+		return decorator.AttachmentData{}, "", err
+	}
+
+	created := time.Now()
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           i.options.signatureType,
+		Suite:                   signatureSuite,
+		SignatureRepresentation: representation,
+		Created:                 &created,
+		VerificationMethod:      i.options.verificationMethod,
+		Purpose:                 "assertionMethod",
+	}, jsonld.WithDocumentLoader(i.documentLoader))
+	if err != nil {
+		return decorator.AttachmentData{}, "", fmt.Errorf("add linked data proof: %w", err)
+	}
+
+	return decorator.AttachmentData{JSON: vc}, mimeTypeApplicationLdJSON, nil
+}
+
+func validateCredentialManifest(options *issuerOptions) error {
+	if options.manifest == nil {
+		return nil
+	}
+
+	if len(options.manifest.OutputDescriptors) != 1 {
+		return errors.New("credential manifest must have a single output descriptor")
+	}
+
+	if options.format != LinkedDataProof {
+		return errors.New("credential manifest issuance requires linked data proofs")
+	}
+
+	return nil
+}
+
+func newSignatureSuite(signatureType string, opt suite.Opt) (signer.SignatureSuite, error) {
+	var opts []suite.Opt
+
+	if opt != nil {
+		opts = append(opts, opt)
+	}
+
+	switch signatureType {
+	case Ed25519Signature2018:
+		return ed25519signature2018.New(opts...), nil
+	case JSONWebSignature2020:
+		return jsonwebsignature2020.New(opts...), nil
+	case EcdsaSecp256k1Signature2019:
+		return ecdsasecp256k1signature2019.New(opts...), nil
+	case BbsBlsSignature2020:
+		return bbsblssignature2020.New(opts...), nil
+	default:
+		return nil, fmt.Errorf("unsupported signature type '%s'", signatureType)
+	}
+}
+
+func parseVerificationMethod(verificationMethod string) (string, string, error) {
+	parts := strings.Split(verificationMethod, "#")
+
+	const partsCount = 2
+
+	if len(parts) != partsCount || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid verification method '%s'", verificationMethod)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+type bbsSigner struct {
+	cr crypto.Crypto
+	kh interface{}
+}
+
+func (s *bbsSigner) Sign(data []byte) ([]byte, error) {
+	lines := strings.Split(string(data), "\n")
+	linesBytes := make([][]byte, 0, len(lines))
+
+	for i := range lines {
+		if strings.TrimSpace(lines[i]) != "" {
+			linesBytes = append(linesBytes, []byte(lines[i]))
+		}
+	}
+
+	return s.cr.SignMulti(linesBytes, s.kh)
+}
@@ -0,0 +1,367 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/piprate/json-gold/ld"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+	spistorage "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const issuerDID = "did:example:issuer"
+
+type issuerProvider struct {
+	km      kms.KeyManager
+	cr      crypto.Crypto
+	loader  ld.DocumentLoader
+	vdr     vdrapi.Registry
+	storage spistorage.Provider
+}
+
+func (p *issuerProvider) KMS() kms.KeyManager                     { return p.km }
+func (p *issuerProvider) Crypto() crypto.Crypto                   { return p.cr }
+func (p *issuerProvider) JSONLDDocumentLoader() ld.DocumentLoader { return p.loader }
+func (p *issuerProvider) VDRegistry() vdrapi.Registry             { return p.vdr }
+func (p *issuerProvider) StorageProvider() spistorage.Provider    { return p.storage }
+
+type statusProvider func(vc *verifiable.Credential) (*verifiable.TypedID, error)
+
+func (f statusProvider) CreateStatusEntry(vc *verifiable.Credential) (*verifiable.TypedID, error) {
+	return f(vc)
+}
+
+func newIssuerProvider(t *testing.T) *issuerProvider {
+	t.Helper()
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	km, err := localkms.New("local-lock://custom/master/key/",
+		mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	return &issuerProvider{
+		km:      km,
+		cr:      cr,
+		loader:  loader,
+		vdr:     vdr.New(vdr.WithVDR(key.New())),
+		storage: storage.NewMockStoreProvider(),
+	}
+}
+
+func subjectSource(request *CredentialRequest) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"credentialSubject": map[string]interface{}{
+			"id": request.TheirDID,
+		},
+	}, nil
+}
+
+func TestIssueCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := newIssuerProvider(t)
+
+	kid, pubKey, err := provider.km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	verificationMethod := issuerDID + "#" + kid
+
+	next := issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+		return nil
+	})
+
+	properties := map[string]interface{}{
+		myDIDKey:    issuerDID,
+		theirDIDKey: "did:example:holder",
+	}
+
+	t.Run("Invalid options", func(t *testing.T) {
+		_, err := IssueCredentials(provider, nil, WithVerificationMethod(verificationMethod))
+		require.EqualError(t, err, "credential data source is mandatory")
+
+		_, err = IssueCredentials(provider, CredentialDataSourceFunc(subjectSource))
+		require.EqualError(t, err, "invalid verification method ''")
+
+		_, err = IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(verificationMethod), WithLDProof("UnknownSignature"))
+		require.EqualError(t, err, "unsupported signature type 'UnknownSignature'")
+	})
+
+	t.Run("Ignores processing", func(t *testing.T) {
+		mw, err := IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(verificationMethod))
+		require.NoError(t, err)
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("state-name")
+		require.NoError(t, mw(next).Handle(metadata))
+
+		metadata = mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV2().Return(&issuecredential.IssueCredentialV2{
+			CredentialsAttach: []decorator.Attachment{{ID: "provided"}},
+		})
+		metadata.EXPECT().IssueCredentialV3().Return(nil)
+		require.NoError(t, mw(next).Handle(metadata))
+	})
+
+	t.Run("Issues credential with LD proof (V2)", func(t *testing.T) {
+		template := json.RawMessage(`{
+			"@context": [
+				"https://www.w3.org/2018/credentials/v1",
+				"https://www.w3.org/2018/credentials/examples/v1",
+				"https://trustbloc.github.io/context/vc/examples-v1.jsonld"
+			],
+			"type": ["VerifiableCredential", "UniversityDegreeCredential"]
+		}`)
+
+		var request *CredentialRequest
+
+		mw, err := IssueCredentials(provider, CredentialDataSourceFunc(
+			func(r *CredentialRequest) (map[string]interface{}, error) {
+				request = r
+
+				return subjectSource(r)
+			}),
+			WithVerificationMethod(verificationMethod),
+			WithCredentialTemplate(template),
+			WithStatusProvider(statusProvider(func(*verifiable.Credential) (*verifiable.TypedID, error) {
+				return &verifiable.TypedID{ID: "https://example.com/status/1#3", Type: "CredentialStatusList2017"}, nil
+			})),
+		)
+		require.NoError(t, err)
+
+		issue := &issuecredential.IssueCredentialV2{}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV2().Return(issue)
+		metadata.EXPECT().IssueCredentialV3().Return(nil)
+		metadata.EXPECT().Properties().Return(properties)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.RequestCredentialV2{
+			Type: issuecredential.RequestCredentialMsgTypeV2,
+			RequestsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{JSON: map[string]interface{}{"claim": "value"}}},
+			},
+		}))
+
+		require.NoError(t, mw(next).Handle(metadata))
+
+		require.Equal(t, "did:example:holder", request.TheirDID)
+		require.Equal(t, issuerDID, request.MyDID)
+		require.Len(t, request.Attachments, 1)
+		require.JSONEq(t, `{"claim":"value"}`, string(request.Attachments[0]))
+
+		require.Len(t, issue.CredentialsAttach, 1)
+		require.Equal(t, mimeTypeApplicationLdJSON, issue.CredentialsAttach[0].MimeType)
+
+		raw, err := issue.CredentialsAttach[0].Data.Fetch()
+		require.NoError(t, err)
+
+		vc, err := verifiable.ParseCredential(raw,
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKey, kms.ED25519)),
+			verifiable.WithJSONLDDocumentLoader(provider.loader))
+		require.NoError(t, err)
+		require.Equal(t, issuerDID, vc.Issuer.ID)
+		require.Equal(t, "https://example.com/status/1#3", vc.Status.ID)
+		require.Contains(t, vc.Types, "UniversityDegreeCredential")
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, verificationMethod, vc.Proofs[0]["verificationMethod"])
+	})
+
+	t.Run("Issues credential as JWT (V3)", func(t *testing.T) {
+		mw, err := IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(verificationMethod), WithJWTProof(verifiable.EdDSA))
+		require.NoError(t, err)
+
+		issue := &issuecredential.IssueCredentialV3{}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV2().Return(nil)
+		metadata.EXPECT().IssueCredentialV3().Return(issue)
+		metadata.EXPECT().Properties().Return(properties)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.RequestCredentialV3{
+			Type: issuecredential.RequestCredentialMsgTypeV3,
+		}))
+
+		require.NoError(t, mw(next).Handle(metadata))
+
+		require.Len(t, issue.Attachments, 1)
+		require.Equal(t, mimeTypeApplicationJWT, issue.Attachments[0].MediaType)
+
+		raw, err := issue.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+
+		vc, err := verifiable.ParseCredential(raw,
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKey, kms.ED25519)),
+			verifiable.WithJSONLDDocumentLoader(provider.loader))
+		require.NoError(t, err)
+		require.Equal(t, issuerDID, vc.Issuer.ID)
+		require.Equal(t, "did:example:holder", vc.Subject.([]verifiable.Subject)[0].ID)
+	})
+
+	t.Run("Issues credential with BBS+ proof", func(t *testing.T) {
+		bbsKID, _, err := provider.km.CreateAndExportPubKeyBytes(kms.BLS12381G2Type)
+		require.NoError(t, err)
+
+		mw, err := IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(issuerDID+"#bbs-key"), WithKeyID(bbsKID), WithLDProof(BbsBlsSignature2020))
+		require.NoError(t, err)
+
+		issue := &issuecredential.IssueCredentialV2{}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV2().Return(issue)
+		metadata.EXPECT().IssueCredentialV3().Return(nil)
+		metadata.EXPECT().Properties().Return(properties)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.RequestCredentialV2{
+			Type: issuecredential.RequestCredentialMsgTypeV2,
+		}))
+
+		require.NoError(t, mw(next).Handle(metadata))
+		require.Len(t, issue.CredentialsAttach, 1)
+
+		raw, err := issue.CredentialsAttach[0].Data.Fetch()
+		require.NoError(t, err)
+
+		vc, err := verifiable.ParseCredential(raw, verifiable.WithDisabledProofCheck(),
+			verifiable.WithJSONLDDocumentLoader(provider.loader))
+		require.NoError(t, err)
+		require.Equal(t, BbsBlsSignature2020, vc.Proofs[0]["type"])
+		require.Contains(t, vc.Context, bbsContext)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		newMetadata := func(issue *issuecredential.IssueCredentialV2, msg service.DIDCommMsg) *mocks.MockMetadata {
+			metadata := mocks.NewMockMetadata(ctrl)
+			metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+			metadata.EXPECT().IssueCredentialV2().Return(issue)
+			metadata.EXPECT().IssueCredentialV3().Return(nil)
+			metadata.EXPECT().Properties().Return(properties).AnyTimes()
+			metadata.EXPECT().Message().Return(msg)
+
+			return metadata
+		}
+
+		request := service.NewDIDCommMsgMap(issuecredential.RequestCredentialV2{
+			Type: issuecredential.RequestCredentialMsgTypeV2,
+		})
+
+		mw, err := IssueCredentials(provider, CredentialDataSourceFunc(
+			func(*CredentialRequest) (map[string]interface{}, error) {
+				return nil, errors.New("no data")
+			}), WithVerificationMethod(verificationMethod))
+		require.NoError(t, err)
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV2{}, request))
+		require.EqualError(t, err, "issue credentials: credential data source: no data")
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV2{},
+			service.DIDCommMsgMap{"@type": issuecredential.RequestCredentialMsgTypeV2, "requests~attach": "invalid"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode")
+
+		mw, err = IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(verificationMethod), WithCredentialTemplate(json.RawMessage("{")))
+		require.NoError(t, err)
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV2{}, request))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal credential template")
+
+		mw, err = IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(verificationMethod),
+			WithStatusProvider(statusProvider(func(*verifiable.Credential) (*verifiable.TypedID, error) {
+				return nil, errors.New("status list is full")
+			})))
+		require.NoError(t, err)
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV2{}, request))
+		require.EqualError(t, err, "issue credentials: create status entry: status list is full")
+
+		mw, err = IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(issuerDID+"#unknown"))
+		require.NoError(t, err)
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV2{}, request))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get issuer key")
+	})
+}
+
+func TestAutoIssueCredentials(t *testing.T) {
+	events := make(chan service.DIDCommAction)
+	next := make(chan service.DIDCommAction)
+
+	go AutoIssueCredentials(next)(events)
+
+	continued := make(chan interface{})
+
+	events <- service.DIDCommAction{
+		Message: service.NewDIDCommMsgMap(issuecredential.RequestCredentialV2{
+			Type: issuecredential.RequestCredentialMsgTypeV2,
+		}),
+		Continue: func(args interface{}) {
+			continued <- args
+		},
+	}
+
+	select {
+	case args := <-continued:
+		_, ok := args.(issuecredential.Opt)
+		require.True(t, ok)
+	case <-time.After(time.Second):
+		require.Fail(t, "request was not continued")
+	}
+
+	offer := service.NewDIDCommMsgMap(issuecredential.OfferCredentialV2{
+		Type: issuecredential.OfferCredentialMsgTypeV2,
+	})
+
+	go func() {
+		events <- service.DIDCommAction{Message: offer}
+	}()
+
+	select {
+	case event := <-next:
+		require.Equal(t, issuecredential.OfferCredentialMsgTypeV2, event.Message.Type())
+	case <-time.After(time.Second):
+		require.Fail(t, "offer was not passed through")
+	}
+
+	close(events)
+}
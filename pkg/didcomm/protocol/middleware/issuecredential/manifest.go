@@ -0,0 +1,350 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Attachment formats of the credential manifest issuance (WACI), see
+// https://identity.foundation/waci-didcomm/#issue-credential-v3.
+const (
+	// CredentialManifestAttachmentFormat is the format of the offer-credential attachment holding the manifest.
+	CredentialManifestAttachmentFormat = "dif/credential-manifest/manifest@v1.0"
+	// CredentialApplicationAttachmentFormat is the format of the request-credential attachment holding
+	// the credential application.
+	CredentialApplicationAttachmentFormat = "dif/credential-manifest/application@v1.0"
+	// CredentialFulfillmentAttachmentFormat is the format of the issue-credential attachment holding
+	// the credential fulfillment.
+	CredentialFulfillmentAttachmentFormat = "dif/credential-manifest/fulfillment@v1.0"
+
+	// ManifestOffersStoreName is the name of the store keeping options of the sent credential manifest offers.
+	ManifestOffersStoreName = "issuecredential_manifest_offers"
+
+	mimeTypeApplicationJSON = "application/json"
+)
+
+// ManifestOptions are the options of a credential manifest offer, the holder uses them for the proof
+// of the credential application.
+type ManifestOptions struct {
+	Challenge string `json:"challenge,omitempty"`
+	Domain    string `json:"domain,omitempty"`
+}
+
+// CredentialManifestOffer is the content of the offer-credential attachment holding the manifest.
+type CredentialManifestOffer struct {
+	Options            *ManifestOptions       `json:"options,omitempty"`
+	CredentialManifest *cm.CredentialManifest `json:"credential_manifest"`
+}
+
+// NewCredentialManifestOffer creates an issue credential 3.0 offer attaching the credential manifest,
+// options are optional.
+func NewCredentialManifestOffer(manifest *cm.CredentialManifest,
+	options *ManifestOptions) (*issuecredential.OfferCredentialParams, error) {
+	if manifest == nil {
+		return nil, errors.New("credential manifest is mandatory")
+	}
+
+	return &issuecredential.OfferCredentialParams{
+		Type: issuecredential.OfferCredentialMsgTypeV3,
+		Attachments: []decorator.GenericAttachment{{
+			ID:        uuid.New().String(),
+			MediaType: mimeTypeApplicationJSON,
+			Format:    CredentialManifestAttachmentFormat,
+			Data: decorator.AttachmentData{
+				JSON: &CredentialManifestOffer{
+					Options:            options,
+					CredentialManifest: manifest,
+				},
+			},
+		}},
+	}, nil
+}
+
+// ParseCredentialManifestOffer reads the credential manifest attached to an issue credential 3.0 offer.
+func ParseCredentialManifestOffer(msg service.DIDCommMsg) (*CredentialManifestOffer, error) {
+	if msg.Type() != issuecredential.OfferCredentialMsgTypeV3 {
+		return nil, fmt.Errorf("unexpected message type '%s', credential manifest requires %s",
+			msg.Type(), issuecredential.OfferCredentialMsgTypeV3)
+	}
+
+	offer := issuecredential.OfferCredentialV3{}
+	if err := msg.Decode(&offer); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	data, err := getAttachmentByFormat(offer.Attachments, CredentialManifestAttachmentFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CredentialManifestOffer{}
+	if err = json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("unmarshal credential manifest offer: %w", err)
+	}
+
+	if result.CredentialManifest == nil {
+		return nil, errors.New("credential manifest offer has no credential manifest")
+	}
+
+	return result, nil
+}
+
+// NewCredentialApplicationRequest creates an issue credential 3.0 request attaching the credential application
+// (see cm.PresentCredentialApplication).
+func NewCredentialApplicationRequest(application *verifiable.Presentation) *issuecredential.RequestCredentialParams {
+	return &issuecredential.RequestCredentialParams{
+		Type:        issuecredential.RequestCredentialMsgTypeV3,
+		Attachments: []decorator.GenericAttachment{NewCredentialApplicationAttachment(application)},
+	}
+}
+
+// NewCredentialApplicationAttachment creates the request-credential attachment holding the credential application.
+func NewCredentialApplicationAttachment(application interface{}) decorator.GenericAttachment {
+	return decorator.GenericAttachment{
+		ID:        uuid.New().String(),
+		MediaType: mimeTypeApplicationLdJSON,
+		Format:    CredentialApplicationAttachmentFormat,
+		Data: decorator.AttachmentData{
+			JSON: application,
+		},
+	}
+}
+
+// credentialApplication parses and validates the credential application of a request-credential message.
+func (i *issuer) credentialApplication(msg service.DIDCommMsg, request *CredentialRequest) error {
+	if !strings.HasPrefix(msg.Type(), issuecredential.SpecV3) {
+		return fmt.Errorf("unexpected message type '%s', credential manifest requires issue credential 3.0",
+			msg.Type())
+	}
+
+	req := issuecredential.RequestCredentialV3{}
+	if err := msg.Decode(&req); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	data, err := getAttachmentByFormat(req.Attachments, CredentialApplicationAttachmentFormat)
+	if err != nil {
+		return err
+	}
+
+	options, err := i.offeredOptions(msg)
+	if err != nil {
+		return err
+	}
+
+	application, err := verifiable.ParsePresentation(data,
+		verifiable.WithPresJSONLDDocumentLoader(i.documentLoader),
+		verifiable.WithPresPublicKeyFetcher(i.options.publicKeyFetcher),
+	)
+	if err != nil {
+		return fmt.Errorf("parse credential application: %w", err)
+	}
+
+	if err = checkApplicationProofs(application, options); err != nil {
+		return err
+	}
+
+	credentialOpts := []verifiable.CredentialOpt{
+		verifiable.WithJSONLDDocumentLoader(i.documentLoader),
+		verifiable.WithPublicKeyFetcher(i.options.publicKeyFetcher),
+	}
+
+	credentials, err := cm.ValidateCredentialApplication(application, i.options.manifest, i.documentLoader,
+		presexch.WithCredentialOptions(credentialOpts...))
+	if err != nil {
+		return fmt.Errorf("validate credential application: %w", err)
+	}
+
+	request.CredentialApplication = application
+	request.SubmittedCredentials = credentials
+
+	return nil
+}
+
+// saveOffer keeps the options of the sent credential manifest offer by the thread ID, the proof of the credential
+// application is checked against them.
+func (i *issuer) saveOffer(metadata issuecredential.Metadata) error {
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	offer := metadata.OfferCredentialV3()
+
+	// the offer is provided through the Continue function when responding to a proposal.
+	if offer == nil || metadata.Message().Type() == issuecredential.OfferCredentialMsgTypeV3 {
+		offer = &issuecredential.OfferCredentialV3{}
+
+		if err = metadata.Message().Decode(offer); err != nil {
+			return fmt.Errorf("decode: %w", err)
+		}
+	}
+
+	data, err := getAttachmentByFormat(offer.Attachments, CredentialManifestAttachmentFormat)
+	if err != nil {
+		return err
+	}
+
+	options := &ManifestOptions{}
+
+	manifestOffer := struct {
+		Options *ManifestOptions `json:"options,omitempty"`
+	}{Options: options}
+
+	if err = json.Unmarshal(data, &manifestOffer); err != nil {
+		return fmt.Errorf("unmarshal credential manifest offer: %w", err)
+	}
+
+	src, err := json.Marshal(options)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	return i.offers.Put(thID, src)
+}
+
+// offeredOptions returns the options of the credential manifest offer sent in the thread.
+func (i *issuer) offeredOptions(msg service.DIDCommMsg) (*ManifestOptions, error) {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return nil, fmt.Errorf("thread ID: %w", err)
+	}
+
+	src, err := i.offers.Get(thID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, errors.New("credential manifest was not offered")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get offer: %w", err)
+	}
+
+	options := &ManifestOptions{}
+
+	if err = json.Unmarshal(src, options); err != nil {
+		return nil, fmt.Errorf("unmarshal offer: %w", err)
+	}
+
+	return options, nil
+}
+
+// checkApplicationProofs checks that the credential application is signed with the challenge and domain
+// of the offer, i.e. it is not taken from another exchange.
+func checkApplicationProofs(application *verifiable.Presentation, options *ManifestOptions) error {
+	if len(application.Proofs) == 0 {
+		return errors.New("credential application is not signed")
+	}
+
+	for _, proof := range application.Proofs {
+		// nolint: errcheck
+		challenge, _ := proof["challenge"].(string)
+		// nolint: errcheck
+		domain, _ := proof["domain"].(string)
+
+		if challenge != options.Challenge {
+			return fmt.Errorf("credential application challenge %q does not match the offered challenge %q",
+				challenge, options.Challenge)
+		}
+
+		if domain != options.Domain {
+			return fmt.Errorf("credential application domain %q does not match the offered domain %q",
+				domain, options.Domain)
+		}
+	}
+
+	return nil
+}
+
+// fulfill wraps the issued credential into a credential fulfillment.
+func (i *issuer) fulfill(vc *verifiable.Credential) (decorator.AttachmentData, error) {
+	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vc))
+	if err != nil {
+		return decorator.AttachmentData{}, fmt.Errorf("new presentation: %w", err)
+	}
+
+	fulfillment, err := cm.PresentCredentialFulfillment(i.options.manifest,
+		cm.WithExistingPresentationForPresentCredentialFulfillment(vp))
+	if err != nil {
+		return decorator.AttachmentData{}, fmt.Errorf("present credential fulfillment: %w", err)
+	}
+
+	return decorator.AttachmentData{JSON: fulfillment}, nil
+}
+
+// fulfillmentCredentials replaces credential fulfillment attachments by the credentials they hold.
+func fulfillmentCredentials(attachments []decorator.AttachmentV2) ([]decorator.AttachmentData, error) {
+	var result []decorator.AttachmentData
+
+	for i := range attachments {
+		if attachments[i].Format != CredentialFulfillmentAttachmentFormat {
+			result = append(result, attachments[i].Data)
+
+			continue
+		}
+
+		data, err := attachments[i].Data.Fetch()
+		if err != nil {
+			return nil, fmt.Errorf("fetch: %w", err)
+		}
+
+		fulfillment := struct {
+			VerifiableCredential json.RawMessage `json:"verifiableCredential"`
+		}{}
+
+		if err = json.Unmarshal(data, &fulfillment); err != nil {
+			return nil, fmt.Errorf("unmarshal credential fulfillment: %w", err)
+		}
+
+		credentials := []json.RawMessage{fulfillment.VerifiableCredential}
+
+		// a single credential may not be wrapped into an array.
+		if strings.HasPrefix(strings.TrimSpace(string(fulfillment.VerifiableCredential)), "[") {
+			if err = json.Unmarshal(fulfillment.VerifiableCredential, &credentials); err != nil {
+				return nil, fmt.Errorf("unmarshal credential fulfillment credentials: %w", err)
+			}
+		}
+
+		for _, vc := range credentials {
+			if len(vc) != 0 {
+				result = append(result, decorator.AttachmentData{JSON: vc})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func getAttachmentByFormat(attachments []decorator.AttachmentV2, format string) ([]byte, error) {
+	for i := range attachments {
+		if attachments[i].Format != format {
+			continue
+		}
+
+		data, err := attachments[i].Data.Fetch()
+		if err != nil {
+			return nil, fmt.Errorf("fetch: %w", err)
+		}
+
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("attachment of format '%s' not found", format)
+}
@@ -0,0 +1,373 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const (
+	offerThreadID  = "offer-1"
+	offerChallenge = "challenge-1"
+	offerDomain    = "issuer.example.com"
+)
+
+const sampleHolderCredential = `{
+	"@context": ["https://www.w3.org/2018/credentials/v1"],
+	"id": "urn:uuid:holder-vc",
+	"type": ["VerifiableCredential"],
+	"issuer": "did:example:other-issuer",
+	"issuanceDate": "2010-01-01T19:23:24Z",
+	"credentialSubject": {"id": "did:example:holder", "name": "Jayden Doe"}
+}`
+
+func newCredentialManifest() *cm.CredentialManifest {
+	return &cm.CredentialManifest{
+		ID:      "driver-license-manifest",
+		Version: "0.1.0",
+		Issuer:  cm.Issuer{ID: issuerDID, Name: "Example DMV"},
+		OutputDescriptors: []cm.OutputDescriptor{{
+			ID:     "driver_license_output",
+			Schema: "https://example.org/driver-license.json",
+			Display: cm.DataDisplayDescriptor{
+				Title: cm.DisplayMappingObject{Text: "Driver License"},
+			},
+		}},
+		PresentationDefinition: &presexch.PresentationDefinition{
+			ID: "identity-definition",
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID:     "identity_input",
+				Schema: []*presexch.Schema{{URI: verifiable.ContextID + "#" + verifiable.VCType}},
+				Constraints: &presexch.Constraints{
+					Fields: []*presexch.Field{{Path: []string{"$.credentialSubject.name"}}},
+				},
+			}},
+		},
+	}
+}
+
+func TestCredentialManifestOffer(t *testing.T) {
+	manifest := newCredentialManifest()
+
+	params, err := NewCredentialManifestOffer(manifest, &ManifestOptions{Challenge: "challenge", Domain: "example.com"})
+	require.NoError(t, err)
+
+	offer, err := ParseCredentialManifestOffer(service.NewDIDCommMsgMap(params.AsV3()))
+	require.NoError(t, err)
+	require.Equal(t, manifest.ID, offer.CredentialManifest.ID)
+	require.Equal(t, "challenge", offer.Options.Challenge)
+	require.Equal(t, "example.com", offer.Options.Domain)
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := NewCredentialManifestOffer(nil, nil)
+		require.EqualError(t, err, "credential manifest is mandatory")
+
+		_, err = ParseCredentialManifestOffer(service.NewDIDCommMsgMap(issuecredential.OfferCredentialV2{
+			Type: issuecredential.OfferCredentialMsgTypeV2,
+		}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential manifest requires "+issuecredential.OfferCredentialMsgTypeV3)
+
+		_, err = ParseCredentialManifestOffer(service.NewDIDCommMsgMap(issuecredential.OfferCredentialV3{
+			Type: issuecredential.OfferCredentialMsgTypeV3,
+		}))
+		require.EqualError(t, err, "attachment of format '"+CredentialManifestAttachmentFormat+"' not found")
+
+		_, err = ParseCredentialManifestOffer(service.NewDIDCommMsgMap(issuecredential.OfferCredentialV3{
+			Type: issuecredential.OfferCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{
+				Format: CredentialManifestAttachmentFormat,
+				Data:   decorator.AttachmentData{JSON: map[string]interface{}{}},
+			}},
+		}))
+		require.EqualError(t, err, "credential manifest offer has no credential manifest")
+	})
+}
+
+func TestIssueCredentials_CredentialManifest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := newIssuerProvider(t)
+
+	kid, pubKey, err := provider.km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	verificationMethod := issuerDID + "#" + kid
+
+	next := issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+		return nil
+	})
+
+	properties := map[string]interface{}{
+		myDIDKey:    issuerDID,
+		theirDIDKey: "did:example:holder",
+	}
+
+	manifest := newCredentialManifest()
+
+	holderVC, err := verifiable.ParseCredential([]byte(sampleHolderCredential), verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(provider.loader))
+	require.NoError(t, err)
+
+	holderKID, holderPubKey, err := provider.km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	holderKH, err := provider.km.Get(holderKID)
+	require.NoError(t, err)
+
+	_, holderKeyID := fingerprint.CreateDIDKey(holderPubKey)
+
+	proofContext := func(challenge, domain string) *verifiable.LinkedDataProofContext {
+		return &verifiable.LinkedDataProofContext{
+			SignatureType:           Ed25519Signature2018,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(suite.NewCryptoSigner(provider.cr, holderKH))),
+			SignatureRepresentation: verifiable.SignatureJWS,
+			VerificationMethod:      holderKeyID,
+			Challenge:               challenge,
+			Domain:                  domain,
+		}
+	}
+
+	newUnsignedApplication := func(t *testing.T, manifest *cm.CredentialManifest) *verifiable.Presentation {
+		t.Helper()
+
+		vp, err := manifest.PresentationDefinition.CreateVP([]*verifiable.Credential{holderVC}, provider.loader,
+			verifiable.WithJSONLDDocumentLoader(provider.loader))
+		require.NoError(t, err)
+
+		application, err := cm.PresentCredentialApplication(manifest,
+			cm.WithExistingPresentationForPresentCredentialApplication(vp))
+		require.NoError(t, err)
+
+		return application
+	}
+
+	signApplication := func(t *testing.T, application *verifiable.Presentation, challenge, domain string) {
+		t.Helper()
+
+		require.NoError(t, application.AddLinkedDataProof(proofContext(challenge, domain),
+			jsonld.WithDocumentLoader(provider.loader)))
+	}
+
+	newApplication := func(t *testing.T, manifest *cm.CredentialManifest) *verifiable.Presentation {
+		t.Helper()
+
+		application := newUnsignedApplication(t, manifest)
+		signApplication(t, application, offerChallenge, offerDomain)
+
+		return application
+	}
+
+	newRequest := func(application *verifiable.Presentation) service.DIDCommMsgMap {
+		msg := service.NewDIDCommMsgMap(NewCredentialApplicationRequest(application).AsV3())
+		msg.SetID("request-1")
+		msg.SetThread(offerThreadID, "")
+
+		return msg
+	}
+
+	newMetadata := func(issue *issuecredential.IssueCredentialV3, msg service.DIDCommMsg) *mocks.MockMetadata {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived).AnyTimes()
+		metadata.EXPECT().IssueCredentialV2().Return(nil)
+		metadata.EXPECT().IssueCredentialV3().Return(issue)
+		metadata.EXPECT().Properties().Return(properties)
+		metadata.EXPECT().Message().Return(msg).AnyTimes()
+
+		return metadata
+	}
+
+	sendOffer := func(t *testing.T, mw issuecredential.Middleware) {
+		t.Helper()
+
+		params, err := NewCredentialManifestOffer(manifest, &ManifestOptions{
+			Challenge: offerChallenge,
+			Domain:    offerDomain,
+		})
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(params.AsV3())
+		msg.SetID(offerThreadID)
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameOfferSent).AnyTimes()
+		metadata.EXPECT().OfferCredentialV3().Return(nil)
+		metadata.EXPECT().Message().Return(msg).AnyTimes()
+
+		require.NoError(t, mw(next).Handle(metadata))
+	}
+
+	t.Run("Invalid options", func(t *testing.T) {
+		twoOutputs := newCredentialManifest()
+		twoOutputs.OutputDescriptors = append(twoOutputs.OutputDescriptors, cm.OutputDescriptor{ID: "other"})
+
+		_, err := IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(verificationMethod), WithCredentialManifest(twoOutputs))
+		require.EqualError(t, err, "credential manifest must have a single output descriptor")
+
+		_, err = IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(verificationMethod), WithCredentialManifest(manifest),
+			WithJWTProof(verifiable.EdDSA))
+		require.EqualError(t, err, "credential manifest issuance requires linked data proofs")
+	})
+
+	t.Run("Issues credential fulfillment", func(t *testing.T) {
+		var request *CredentialRequest
+
+		mw, err := IssueCredentials(provider, CredentialDataSourceFunc(
+			func(r *CredentialRequest) (map[string]interface{}, error) {
+				request = r
+
+				return subjectSource(r)
+			}),
+			WithVerificationMethod(verificationMethod),
+			WithCredentialManifest(manifest),
+		)
+		require.NoError(t, err)
+
+		sendOffer(t, mw)
+
+		issue := &issuecredential.IssueCredentialV3{}
+
+		require.NoError(t, mw(next).Handle(newMetadata(issue, newRequest(newApplication(t, manifest)))))
+
+		require.NotNil(t, request.CredentialApplication)
+		require.Contains(t, request.CredentialApplication.Type, "CredentialApplication")
+		require.Equal(t, holderVC.ID, request.SubmittedCredentials["identity_input"].ID)
+
+		require.Len(t, issue.Attachments, 1)
+		require.Equal(t, CredentialFulfillmentAttachmentFormat, issue.Attachments[0].Format)
+
+		raw, err := issue.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+
+		fulfillment, err := verifiable.ParsePresentation(raw, verifiable.WithPresDisabledProofCheck(),
+			verifiable.WithPresJSONLDDocumentLoader(provider.loader))
+		require.NoError(t, err)
+		require.Contains(t, fulfillment.Type, "CredentialFulfillment")
+		require.Contains(t, fulfillment.CustomFields, "credential_fulfillment")
+
+		credentials, err := fulfillmentCredentials(issue.Attachments)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+
+		vcBytes, err := credentials[0].Fetch()
+		require.NoError(t, err)
+
+		vc, err := verifiable.ParseCredential(vcBytes,
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKey, kms.ED25519)),
+			verifiable.WithJSONLDDocumentLoader(provider.loader))
+		require.NoError(t, err)
+		require.Equal(t, issuerDID, vc.Issuer.ID)
+		require.Equal(t, "did:example:holder", vc.Subject.([]verifiable.Subject)[0].ID)
+	})
+
+	t.Run("Invalid credential application", func(t *testing.T) {
+		mw, err := IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(verificationMethod), WithCredentialManifest(manifest))
+		require.NoError(t, err)
+
+		sendOffer(t, mw)
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV3{},
+			service.NewDIDCommMsgMap(issuecredential.RequestCredentialV3{
+				Type: issuecredential.RequestCredentialMsgTypeV3,
+			})))
+		require.EqualError(t, err, "issue credentials: attachment of format '"+
+			CredentialApplicationAttachmentFormat+"' not found")
+
+		otherManifest := newCredentialManifest()
+		otherManifest.ID = "other-manifest"
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV3{},
+			newRequest(newApplication(t, otherManifest))))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validate credential application: the Manifest ID of the Credential "+
+			"Application (other-manifest) does not match")
+
+		application := newUnsignedApplication(t, manifest)
+		application.CustomFields["presentation_submission"] = map[string]interface{}{
+			"id":             "submission",
+			"definition_id":  manifest.PresentationDefinition.ID,
+			"descriptor_map": []interface{}{},
+		}
+		signApplication(t, application, offerChallenge, offerDomain)
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV3{}, newRequest(application)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the presentation submission does not satisfy")
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV2().Return(&issuecredential.IssueCredentialV2{})
+		metadata.EXPECT().IssueCredentialV3().Return(nil)
+		metadata.EXPECT().Properties().Return(properties)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.RequestCredentialV2{
+			Type: issuecredential.RequestCredentialMsgTypeV2,
+		}))
+
+		err = mw(next).Handle(metadata)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential manifest requires issue credential 3.0")
+	})
+
+	t.Run("Credential application of another exchange", func(t *testing.T) {
+		mw, err := IssueCredentials(provider, CredentialDataSourceFunc(subjectSource),
+			WithVerificationMethod(verificationMethod), WithCredentialManifest(manifest))
+		require.NoError(t, err)
+
+		sendOffer(t, mw)
+
+		msg := newRequest(newApplication(t, manifest))
+		msg.SetThread("offer-2", "")
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV3{}, msg))
+		require.EqualError(t, err, "issue credentials: credential manifest was not offered")
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV3{},
+			newRequest(newUnsignedApplication(t, manifest))))
+		require.EqualError(t, err, "issue credentials: credential application is not signed")
+
+		application := newUnsignedApplication(t, manifest)
+		signApplication(t, application, "challenge-2", offerDomain)
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV3{}, newRequest(application)))
+		require.EqualError(t, err, `issue credentials: credential application challenge "challenge-2" `+
+			`does not match the offered challenge "challenge-1"`)
+
+		application = newUnsignedApplication(t, manifest)
+		signApplication(t, application, offerChallenge, "other.example.com")
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV3{}, newRequest(application)))
+		require.EqualError(t, err, `issue credentials: credential application domain "other.example.com" `+
+			`does not match the offered domain "issuer.example.com"`)
+
+		application = newApplication(t, manifest)
+		application.Holder = "did:example:tampered"
+
+		err = mw(next).Handle(newMetadata(&issuecredential.IssueCredentialV3{}, newRequest(application)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse credential application")
+	})
+}
@@ -117,7 +117,7 @@ func getAttachments(msg service.DIDCommMsg) ([]decorator.AttachmentData, error)
 			return nil, fmt.Errorf("decode: %w", err)
 		}
 
-		return filterByMediaType(cred.Attachments, mimeTypeAll), nil
+		return fulfillmentCredentials(cred.Attachments)
 	}
 
 	cred := issuecredential.IssueCredentialV2{}
@@ -280,6 +280,42 @@ func TestSaveCredentials(t *testing.T) {
 		require.Equal(t, props["names"], []string{vcName})
 	})
 
+	t.Run("Success V3 (credential fulfillment)", func(t *testing.T) {
+		props := map[string]interface{}{
+			myDIDKey:    myDIDKey,
+			theirDIDKey: theirDIDKey,
+		}
+
+		fulfillment, err := verifiable.NewPresentation(verifiable.WithCredentials(getCredential()))
+		require.NoError(t, err)
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialNames().Return([]string{})
+		metadata.EXPECT().Properties().Return(props)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredentialV3{
+			Type: issuecredential.IssueCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{
+				{Format: CredentialFulfillmentAttachmentFormat, Data: decorator.AttachmentData{JSON: fulfillment}},
+			},
+		}))
+
+		verifiableStore := mockstore.NewMockStore(ctrl)
+		verifiableStore.EXPECT().SaveCredential(getCredential().ID, gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		loader, err := ldtestutil.DocumentLoader()
+		require.NoError(t, err)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().VDRegistry().Return(nil).AnyTimes()
+		provider.EXPECT().VerifiableStore().Return(verifiableStore)
+		provider.EXPECT().JSONLDDocumentLoader().Return(loader)
+
+		require.NoError(t, SaveCredentials(provider)(next).Handle(metadata))
+		require.Equal(t, props["names"], []string{getCredential().ID})
+	})
+
 	t.Run("Success (no ID)", func(t *testing.T) {
 		props := map[string]interface{}{
 			myDIDKey:    myDIDKey,
@@ -37,7 +37,7 @@ import (
 
 // nolint: gochecknoglobals
 var pubKey = did.VerificationMethod{
-	ID: "key-1",
+	ID: "#key-1",
 	Value: []byte{
 		61, 133, 23, 17, 77, 132, 169, 196, 47, 203, 19, 71, 145, 144, 92, 145,
 		131, 101, 36, 251, 89, 216, 117, 140, 132, 226, 78, 187, 59, 58, 200, 255,
@@ -0,0 +1,594 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	submissionProperty   = "presentation_submission"
+	stateNameRequestSent = "request-sent"
+
+	// VerifierStoreName is the name of the store keeping challenge and domain of the sent request presentations.
+	VerifierStoreName = "presentproof_verifier"
+)
+
+// VerifierProvider contains dependencies for the VerifyPresentations middleware function.
+type VerifierProvider interface {
+	VDRegistry() vdrapi.Registry
+	JSONLDDocumentLoader() ld.DocumentLoader
+	StorageProvider() storage.Provider
+}
+
+// PolicyInput is the verified presentation given to a Policy.
+type PolicyInput struct {
+	// Presentation is the received presentation, its proof is verified.
+	Presentation *verifiable.Presentation
+	// Credentials are the credentials enclosed into the presentation, their proofs are verified.
+	Credentials []*verifiable.Credential
+	// MyDID is the DID of the verifier in the connection.
+	MyDID string
+	// TheirDID is the DID of the prover in the connection.
+	TheirDID string
+	// JWTKeyID is the key ID ("kid" header) the JWT presentation is signed with,
+	// it is empty for presentations with embedded proofs.
+	JWTKeyID string
+}
+
+// Policy decides whether a verified presentation is accepted.
+// The error returned by the policy is the reason of the rejection sent to the prover.
+type Policy interface {
+	Evaluate(input *PolicyInput) error
+}
+
+// PolicyFunc is a helper type which implements the Policy interface.
+type PolicyFunc func(input *PolicyInput) error
+
+// Evaluate implements function to satisfy the Policy interface.
+func (f PolicyFunc) Evaluate(input *PolicyInput) error {
+	return f(input)
+}
+
+// StatusChecker checks the status (eg: revocation) of a credential having a credential status entry.
+type StatusChecker interface {
+	CheckStatus(vc *verifiable.Credential) error
+}
+
+// TrustedIssuers is a policy which accepts credentials issued by the given DIDs only.
+func TrustedIssuers(issuers ...string) Policy {
+	return PolicyFunc(func(input *PolicyInput) error {
+		for _, vc := range input.Credentials {
+			if !contains(issuers, vc.Issuer.ID) {
+				return fmt.Errorf("credential %s: issuer %s is not trusted", vc.ID, vc.Issuer.ID)
+			}
+		}
+
+		return nil
+	})
+}
+
+// CredentialStatus is a policy which checks the status of the credentials having a credential status entry.
+func CredentialStatus(checker StatusChecker) Policy {
+	return PolicyFunc(func(input *PolicyInput) error {
+		for _, vc := range input.Credentials {
+			if vc.Status == nil {
+				continue
+			}
+
+			if err := checker.CheckStatus(vc); err != nil {
+				return fmt.Errorf("credential %s: status: %w", vc.ID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// NotExpired is a policy which rejects expired credentials.
+func NotExpired() Policy {
+	return PolicyFunc(func(input *PolicyInput) error {
+		now := time.Now()
+
+		for _, vc := range input.Credentials {
+			if vc.Expired != nil && vc.Expired.Time.Before(now) {
+				return fmt.Errorf("credential %s: expired on %s", vc.ID, vc.Expired.Time.Format(time.RFC3339))
+			}
+		}
+
+		return nil
+	})
+}
+
+// HolderBinding is a policy which requires the presentation to be held and signed by the DID of the connection.
+// Holder of JWT presentation is its "iss" claim, "kid" header given by absolute DID URL must be of the same DID.
+func HolderBinding() Policy {
+	return PolicyFunc(func(input *PolicyInput) error {
+		if input.Presentation.Holder != input.TheirDID {
+			return fmt.Errorf("holder %q does not match the connection DID %s",
+				input.Presentation.Holder, input.TheirDID)
+		}
+
+		if strings.HasPrefix(input.JWTKeyID, "did:") && strings.Split(input.JWTKeyID, "#")[0] != input.TheirDID {
+			return fmt.Errorf("presentation is signed with %q which does not belong to the connection DID %s",
+				input.JWTKeyID, input.TheirDID)
+		}
+
+		for _, proof := range input.Presentation.Proofs {
+			// nolint: errcheck
+			vm, _ := proof["verificationMethod"].(string)
+
+			if strings.Split(vm, "#")[0] != input.TheirDID {
+				return fmt.Errorf("presentation is signed with %q which does not belong to the connection DID %s",
+					vm, input.TheirDID)
+			}
+		}
+
+		return nil
+	})
+}
+
+// OptVerifier represents option function for the VerifyPresentations middleware.
+type OptVerifier func(o *verifierOptions)
+
+type verifierOptions struct {
+	definitions   map[string]*presexch.PresentationDefinition
+	statusChecker presexch.CredentialStatusChecker
+	policies      []Policy
+}
+
+// WithPresentationDefinitions sets the presentation definitions the presentations are matched against.
+// A presentation must have a presentation submission for one of the definitions.
+func WithPresentationDefinitions(definitions ...*presexch.PresentationDefinition) OptVerifier {
+	return func(o *verifierOptions) {
+		for _, definition := range definitions {
+			o.definitions[definition.ID] = definition
+		}
+	}
+}
+
+// WithDefinitionStatusChecker sets the credential status checker enforcing statuses constraints of
+// the presentation definitions. Without it, presentations do not match definitions having statuses constraints.
+func WithDefinitionStatusChecker(checker presexch.CredentialStatusChecker) OptVerifier {
+	return func(o *verifierOptions) {
+		o.statusChecker = checker
+	}
+}
+
+// WithPolicies adds policies evaluated in order once the presentation proofs are verified.
+func WithPolicies(policies ...Policy) OptVerifier {
+	return func(o *verifierOptions) {
+		o.policies = append(o.policies, policies...)
+	}
+}
+
+// VerifyPresentations the helper function for the present proof protocol which verifies the presentations
+// on the verifier side. The challenge and domain of the sent request presentation are kept, then the proofs of
+// the presentations are verified to be made for that request, the proofs of the enclosed credentials are verified,
+// the presentations are matched against the presentation definitions and the policies are evaluated.
+// When a check fails the protocol is abandoned with a problem report stating the reason.
+// The middleware needs to be used before SavePresentation to not save the rejected presentations.
+func VerifyPresentations(p VerifierProvider, opts ...OptVerifier) presentproof.Middleware {
+	options := &verifierOptions{definitions: map[string]*presexch.PresentationDefinition{}}
+
+	for i := range opts {
+		opts[i](options)
+	}
+
+	store, storeErr := p.StorageProvider().OpenStore(VerifierStoreName)
+
+	v := &verifier{
+		keyFetcher:     verifiable.NewVDRKeyResolver(p.VDRegistry()).PublicKeyFetcher(),
+		documentLoader: p.JSONLDDocumentLoader(),
+		store:          store,
+		options:        options,
+	}
+
+	return func(next presentproof.Handler) presentproof.Handler {
+		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+			switch metadata.StateName() {
+			case stateNameRequestSent:
+				if storeErr != nil {
+					return fmt.Errorf("verify presentations: open store: %w", storeErr)
+				}
+
+				if err := v.saveRequest(metadata); err != nil {
+					return fmt.Errorf("verify presentations: save request: %w", err)
+				}
+			case stateNamePresentationReceived:
+				if storeErr != nil {
+					return fmt.Errorf("verify presentations: open store: %w", storeErr)
+				}
+
+				if err := v.verify(metadata); err != nil {
+					return fmt.Errorf("verify presentations: %w", err)
+				}
+			}
+
+			return next.Handle(metadata)
+		})
+	}
+}
+
+// AutoAcceptPresentations continues presentation actions which are then verified by the VerifyPresentations
+// middleware. Other actions are passed through to 'next'.
+//
+// Usage:
+//     events := make(chan service.DIDCommAction)
+//     err := client.RegisterActionEvent(events)
+//     if err != nil {
+//         panic(err)
+//     }
+//     next := make(chan service.DIDCommAction)
+//     go AutoAcceptPresentations(next)(events)
+//     for event := range next {
+//         // handle other present-proof actions
+//     }
+func AutoAcceptPresentations(next chan service.DIDCommAction) func(chan service.DIDCommAction) {
+	return func(events chan service.DIDCommAction) {
+		for event := range events {
+			switch event.Message.Type() {
+			case presentproof.PresentationMsgTypeV2, presentproof.PresentationMsgTypeV3:
+				event.Continue(presentproof.WithFriendlyNames())
+			default:
+				next <- event
+			}
+		}
+	}
+}
+
+type verifier struct {
+	keyFetcher     verifiable.PublicKeyFetcher
+	documentLoader ld.DocumentLoader
+	store          storage.Store
+	options        *verifierOptions
+}
+
+// requestedProof is the challenge and domain the presentations are requested to be signed with.
+type requestedProof struct {
+	Challenge string `json:"challenge,omitempty"`
+	Domain    string `json:"domain,omitempty"`
+}
+
+// saveRequest keeps the challenge and domain of the sent request presentation by the thread ID.
+func (v *verifier) saveRequest(metadata presentproof.Metadata) error {
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	requested, err := requestedProofOf(metadata)
+	if err != nil {
+		return err
+	}
+
+	src, err := json.Marshal(requested)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	return v.store.Put(thID, src)
+}
+
+// requestedProofOf returns the challenge and domain of the presentation exchange attachment of the request
+// presentation, which is either the outbound message or the request given in response to the proposal.
+func requestedProofOf(metadata presentproof.Metadata) (*requestedProof, error) {
+	var (
+		msg = metadata.Message()
+		src []byte
+		err error
+	)
+
+	switch {
+	case msg.Type() == presentproof.RequestPresentationMsgTypeV3:
+		request := presentproof.RequestPresentationV3{}
+		if err = msg.Decode(&request); err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		src, err = definitionPayloadV3(&request)
+	case msg.Type() == presentproof.RequestPresentationMsgTypeV2:
+		request := presentproof.RequestPresentationV2{}
+		if err = msg.Decode(&request); err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		src, err = definitionPayloadV2(&request)
+	case metadata.RequestPresentationV3() != nil:
+		src, err = definitionPayloadV3(metadata.RequestPresentationV3())
+	case metadata.RequestPresentation() != nil:
+		src, err = definitionPayloadV2(metadata.RequestPresentation())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get attachment by format: %w", err)
+	}
+
+	requested := &requestedProof{}
+
+	// challenge and domain are not requested without presentation exchange attachment.
+	if src == nil {
+		return requested, nil
+	}
+
+	var payload *presentationExchangePayload
+
+	if err = json.Unmarshal(src, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshal definition: %w", err)
+	}
+
+	requested.Challenge = payload.Challenge
+	requested.Domain = payload.Domain
+
+	return requested, nil
+}
+
+func definitionPayloadV2(request *presentproof.RequestPresentationV2) ([]byte, error) {
+	if !hasFormat(request.Formats, peDefinitionFormat) {
+		return nil, nil
+	}
+
+	src, _, err := getAttachmentByFormat(request.Formats, request.RequestPresentationsAttach, peDefinitionFormat)
+
+	return src, err
+}
+
+func definitionPayloadV3(request *presentproof.RequestPresentationV3) ([]byte, error) {
+	formats := toFormats(request.Attachments)
+	if !hasFormat(formats, peDefinitionFormat) {
+		return nil, nil
+	}
+
+	return getAttachmentByFormatV2(formats, request.Attachments, peDefinitionFormat)
+}
+
+// requestedProof returns the challenge and domain of the request presentation sent in the thread.
+func (v *verifier) requestedProof(metadata presentproof.Metadata) (*requestedProof, error) {
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return nil, fmt.Errorf("thread ID: %w", err)
+	}
+
+	src, err := v.store.Get(thID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, reject(errors.New("presentation was not requested"))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get request: %w", err)
+	}
+
+	requested := &requestedProof{}
+
+	if err = json.Unmarshal(src, requested); err != nil {
+		return nil, fmt.Errorf("unmarshal request: %w", err)
+	}
+
+	return requested, nil
+}
+
+func (v *verifier) verify(metadata presentproof.Metadata) error {
+	attachments, err := getAttachments(metadata.Message())
+	if err != nil {
+		return fmt.Errorf("get attachments: %w", err)
+	}
+
+	if len(attachments) == 0 {
+		return reject(errors.New("presentations were not provided"))
+	}
+
+	requested, err := v.requestedProof(metadata)
+	if err != nil {
+		return err
+	}
+
+	properties := metadata.Properties()
+
+	// nolint: errcheck
+	myDID, _ := properties[myDIDKey].(string)
+	// nolint: errcheck
+	theirDID, _ := properties[theirDIDKey].(string)
+
+	for i := range attachments {
+		raw, err := attachments[i].Fetch()
+		if err != nil {
+			return fmt.Errorf("fetch: %w", err)
+		}
+
+		input, err := v.verifyPresentation(raw, requested)
+		if err != nil {
+			return reject(err)
+		}
+
+		input.MyDID = myDID
+		input.TheirDID = theirDID
+
+		for _, policy := range v.options.policies {
+			if err := policy.Evaluate(input); err != nil {
+				return reject(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *verifier) verifyPresentation(raw []byte, requested *requestedProof) (*PolicyInput, error) {
+	presentation, err := verifiable.ParsePresentation(raw,
+		verifiable.WithPresPublicKeyFetcher(v.keyFetcher),
+		verifiable.WithPresJSONLDDocumentLoader(v.documentLoader),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid presentation: %w", err)
+	}
+
+	// JWT presentations are verified while parsing, others are signed with embedded proofs.
+	vpJWT := string(raw)
+	if !jwt.IsJWS(vpJWT) && len(presentation.Proofs) == 0 {
+		return nil, errors.New("presentation is not signed")
+	}
+
+	var jwtKeyID string
+
+	if jwt.IsJWS(vpJWT) {
+		jwtKeyID, err = checkRequestedJWT(vpJWT, requested)
+	} else {
+		err = checkRequestedProofs(presentation, requested)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	credentialOpts := []verifiable.CredentialOpt{
+		verifiable.WithPublicKeyFetcher(v.keyFetcher),
+		verifiable.WithJSONLDDocumentLoader(v.documentLoader),
+	}
+
+	if len(v.options.definitions) != 0 {
+		credentials, err := v.match(presentation, credentialOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &PolicyInput{Presentation: presentation, Credentials: credentials, JWTKeyID: jwtKeyID}, nil
+	}
+
+	marshalled, err := presentation.MarshalledCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("invalid presentation: %w", err)
+	}
+
+	credentials := make([]*verifiable.Credential, len(marshalled))
+
+	for i := range marshalled {
+		credentials[i], err = verifiable.ParseCredential(marshalled[i], credentialOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential: %w", err)
+		}
+	}
+
+	return &PolicyInput{Presentation: presentation, Credentials: credentials, JWTKeyID: jwtKeyID}, nil
+}
+
+// checkRequestedProofs checks that embedded proofs of the presentation are made with the requested
+// challenge and domain, i.e. the presentation is not taken from another exchange.
+func checkRequestedProofs(presentation *verifiable.Presentation, requested *requestedProof) error {
+	for _, proof := range presentation.Proofs {
+		// nolint: errcheck
+		challenge, _ := proof["challenge"].(string)
+		// nolint: errcheck
+		domain, _ := proof["domain"].(string)
+
+		if challenge != requested.Challenge {
+			return fmt.Errorf("presentation challenge %q does not match the requested challenge %q",
+				challenge, requested.Challenge)
+		}
+
+		if domain != requested.Domain {
+			return fmt.Errorf("presentation domain %q does not match the requested domain %q",
+				domain, requested.Domain)
+		}
+	}
+
+	return nil
+}
+
+// presentationJWTClaims are the claims of JWT presentation binding it to the request.
+type presentationJWTClaims struct {
+	*jwt.Claims
+
+	Nonce string `json:"nonce,omitempty"`
+}
+
+// checkRequestedJWT checks that JWT presentation has the requested challenge as "nonce" claim and
+// the requested domain among "aud" claim, returns the key ID the presentation is signed with.
+func checkRequestedJWT(vpJWT string, requested *requestedProof) (string, error) {
+	// signature is verified while parsing the presentation.
+	token, err := jwt.Parse(vpJWT, jwt.WithSignatureVerifier(jose.SignatureVerifierFunc(
+		func(jose.Headers, []byte, []byte, []byte) error {
+			return nil
+		})))
+	if err != nil {
+		return "", fmt.Errorf("invalid presentation: %w", err)
+	}
+
+	claims := &presentationJWTClaims{}
+
+	if err = token.DecodeClaims(claims); err != nil {
+		return "", fmt.Errorf("invalid presentation: %w", err)
+	}
+
+	if claims.Nonce != requested.Challenge {
+		return "", fmt.Errorf("presentation nonce %q does not match the requested challenge %q",
+			claims.Nonce, requested.Challenge)
+	}
+
+	if requested.Domain != "" && (claims.Claims == nil || !claims.Audience.Contains(requested.Domain)) {
+		return "", fmt.Errorf("presentation audience does not contain the requested domain %q", requested.Domain)
+	}
+
+	// nolint: errcheck
+	keyID, _ := token.Headers.KeyID()
+
+	return keyID, nil
+}
+
+func (v *verifier) match(presentation *verifiable.Presentation,
+	credentialOpts []verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
+	submission, ok := presentation.CustomFields[submissionProperty].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("presentation submission was not provided")
+	}
+
+	// nolint: errcheck
+	definitionID, _ := submission["definition_id"].(string)
+
+	definition, ok := v.options.definitions[definitionID]
+	if !ok {
+		return nil, fmt.Errorf("unknown presentation definition %q", definitionID)
+	}
+
+	matchOpts := []presexch.MatchOption{presexch.WithCredentialOptions(credentialOpts...)}
+	if v.options.statusChecker != nil {
+		matchOpts = append(matchOpts, presexch.WithCredentialStatusChecker(v.options.statusChecker))
+	}
+
+	matched, err := definition.Match(presentation, v.documentLoader, matchOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("presentation does not match definition %s: %w", definitionID, err)
+	}
+
+	credentials := make([]*verifiable.Credential, 0, len(matched))
+
+	for _, descriptor := range definition.InputDescriptors {
+		if vc, ok := matched[descriptor.ID]; ok {
+			credentials = append(credentials, vc)
+		}
+	}
+
+	return credentials, nil
+}
+
+func reject(err error) error {
+	return &presentproof.RejectionError{Reason: err.Error()}
+}
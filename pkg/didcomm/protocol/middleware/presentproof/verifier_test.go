@@ -0,0 +1,588 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/piprate/json-gold/ld"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+	spistorage "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	requestThreadID  = "request-1"
+	requestChallenge = "challenge-1"
+	requestDomain    = "verifier.example.com"
+)
+
+type verifierProvider struct {
+	vdr     vdrapi.Registry
+	loader  ld.DocumentLoader
+	storage spistorage.Provider
+}
+
+func (p *verifierProvider) VDRegistry() vdrapi.Registry             { return p.vdr }
+func (p *verifierProvider) JSONLDDocumentLoader() ld.DocumentLoader { return p.loader }
+func (p *verifierProvider) StorageProvider() spistorage.Provider    { return p.storage }
+
+type statusChecker func(vc *verifiable.Credential) error
+
+func (f statusChecker) CheckStatus(vc *verifiable.Credential) error {
+	return f(vc)
+}
+
+type testSigner struct {
+	km kms.KeyManager
+	cr crypto.Crypto
+}
+
+func (s *testSigner) newDID(t *testing.T) (string, string, interface{}) {
+	t.Helper()
+
+	kid, pub, err := s.km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	kh, err := s.km.Get(kid)
+	require.NoError(t, err)
+
+	didKey, keyID := fingerprint.CreateDIDKey(pub)
+
+	return didKey, keyID, kh
+}
+
+func (s *testSigner) ldpContext(keyID string, kh interface{}) *verifiable.LinkedDataProofContext {
+	return &verifiable.LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		Suite:                   ed25519signature2018.New(suite.WithSigner(suite.NewCryptoSigner(s.cr, kh))),
+		SignatureRepresentation: verifiable.SignatureJWS,
+		VerificationMethod:      keyID,
+	}
+}
+
+func (s *testSigner) vpContext(keyID string, kh interface{}) *verifiable.LinkedDataProofContext {
+	ctx := s.ldpContext(keyID, kh)
+	ctx.Challenge = requestChallenge
+	ctx.Domain = requestDomain
+
+	return ctx
+}
+
+type jwtSigner struct {
+	cr    crypto.Crypto
+	kh    interface{}
+	keyID string
+}
+
+func (s *jwtSigner) Sign(data []byte) ([]byte, error) {
+	return s.cr.Sign(data, s.kh)
+}
+
+func (s *jwtSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA", jose.HeaderKeyID: s.keyID}
+}
+
+func TestVerifyPresentations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	km, err := localkms.New("local-lock://custom/master/key/",
+		mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	signer := &testSigner{km: km, cr: cr}
+	provider := &verifierProvider{
+		vdr:     vdr.New(vdr.WithVDR(key.New())),
+		loader:  loader,
+		storage: storage.NewMockStoreProvider(),
+	}
+
+	issuerDID, issuerKeyID, issuerKH := signer.newDID(t)
+	holderDID, holderKeyID, holderKH := signer.newDID(t)
+
+	issueCredential := func(t *testing.T, expired *time.Time, status *verifiable.TypedID) *verifiable.Credential {
+		t.Helper()
+
+		vc := &verifiable.Credential{
+			ID:      "http://example.edu/credentials/1872",
+			Context: []string{verifiable.ContextURI},
+			Types:   []string{verifiable.VCType},
+			Subject: holderDID,
+			Issued:  util.NewTime(time.Now()),
+			Issuer:  verifiable.Issuer{ID: issuerDID},
+			Status:  status,
+		}
+
+		if expired != nil {
+			vc.Expired = util.NewTime(*expired)
+		}
+
+		if status != nil {
+			vc.Context = append(vc.Context, "https://w3id.org/vc-revocation-list-2020/v1")
+		}
+
+		require.NoError(t, vc.AddLinkedDataProof(signer.ldpContext(issuerKeyID, issuerKH),
+			jsonld.WithDocumentLoader(loader)))
+
+		return vc
+	}
+
+	present := func(t *testing.T, sign bool, vcs ...*verifiable.Credential) *verifiable.Presentation {
+		t.Helper()
+
+		vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vcs...))
+		require.NoError(t, err)
+
+		vp.Holder = holderDID
+
+		if sign {
+			require.NoError(t, vp.AddLinkedDataProof(signer.vpContext(holderKeyID, holderKH),
+				jsonld.WithDocumentLoader(loader)))
+		}
+
+		return vp
+	}
+
+	newMetadata := func(vps ...*verifiable.Presentation) *mocks.MockMetadata {
+		var attachments []decorator.Attachment
+
+		for _, vp := range vps {
+			attachments = append(attachments, decorator.Attachment{
+				MimeType: mimeTypeApplicationLdJSON,
+				Data:     decorator.AttachmentData{JSON: vp},
+			})
+		}
+
+		msg := service.NewDIDCommMsgMap(presentproof.PresentationV2{
+			ID:                  "presentation-1",
+			Type:                presentproof.PresentationMsgTypeV2,
+			PresentationsAttach: attachments,
+		})
+		msg.SetThread(requestThreadID, "")
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived).AnyTimes()
+		metadata.EXPECT().Message().Return(msg).AnyTimes()
+		metadata.EXPECT().Properties().Return(map[string]interface{}{
+			myDIDKey:    "did:example:verifier",
+			theirDIDKey: holderDID,
+		}).AnyTimes()
+
+		return metadata
+	}
+
+	requireRejected := func(t *testing.T, err error, reason string) {
+		t.Helper()
+
+		var rejection *presentproof.RejectionError
+
+		require.True(t, errors.As(err, &rejection), err)
+		require.Contains(t, rejection.Reason, reason)
+	}
+
+	nextCalled := false
+	next := presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+		nextCalled = true
+
+		return nil
+	})
+
+	t.Run("Ignores processing", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("state-name")
+		require.NoError(t, VerifyPresentations(provider)(next).Handle(metadata))
+	})
+
+	t.Run("Saves request", func(t *testing.T) {
+		payload, err := json.Marshal(&presentationExchangePayload{
+			Challenge:              requestChallenge,
+			Domain:                 requestDomain,
+			PresentationDefinition: &presexch.PresentationDefinition{ID: "definition-1"},
+		})
+		require.NoError(t, err)
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestSent)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.RequestPresentationV2{
+			ID:      requestThreadID,
+			Type:    presentproof.RequestPresentationMsgTypeV2,
+			Formats: []presentproof.Format{{AttachID: "attach-1", Format: peDefinitionFormat}},
+			RequestPresentationsAttach: []decorator.Attachment{{
+				ID:       "attach-1",
+				MimeType: "application/json",
+				Data:     decorator.AttachmentData{JSON: json.RawMessage(payload)},
+			}},
+		})).AnyTimes()
+
+		require.NoError(t, VerifyPresentations(provider)(next).Handle(metadata))
+
+		src, err := provider.storage.(*storage.MockStoreProvider).Store.Get(requestThreadID)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"challenge":"challenge-1","domain":"verifier.example.com"}`, string(src))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		nextCalled = false
+		checked := 0
+
+		mw := VerifyPresentations(provider, WithPolicies(
+			TrustedIssuers(issuerDID),
+			NotExpired(),
+			HolderBinding(),
+			CredentialStatus(statusChecker(func(*verifiable.Credential) error {
+				checked++
+
+				return nil
+			})),
+		))
+
+		expires := time.Now().Add(time.Hour)
+		status := &verifiable.TypedID{ID: "https://example.com/status/1#3", Type: "RevocationList2020Status"}
+
+		require.NoError(t, mw(next).Handle(newMetadata(present(t, true,
+			issueCredential(t, &expires, nil), issueCredential(t, nil, status)))))
+		require.True(t, nextCalled)
+		require.Equal(t, 1, checked)
+	})
+
+	t.Run("Success v3", func(t *testing.T) {
+		vp := present(t, true, issueCredential(t, nil, nil))
+
+		msg := service.NewDIDCommMsgMap(presentproof.PresentationV3{
+			Type: presentproof.PresentationMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{
+				MediaType: mimeTypeApplicationLdJSON,
+				Data:      decorator.AttachmentData{JSON: vp},
+			}},
+		})
+		msg.SetID("presentation-1")
+		msg.SetThread(requestThreadID, "")
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(msg).AnyTimes()
+		metadata.EXPECT().Properties().Return(map[string]interface{}{theirDIDKey: holderDID})
+
+		require.NoError(t, VerifyPresentations(provider, WithPolicies(HolderBinding()))(next).Handle(metadata))
+	})
+
+	t.Run("Presentations not provided", func(t *testing.T) {
+		err := VerifyPresentations(provider)(next).Handle(newMetadata())
+		requireRejected(t, err, "presentations were not provided")
+	})
+
+	t.Run("Presentation is not signed", func(t *testing.T) {
+		err := VerifyPresentations(provider)(next).Handle(newMetadata(present(t, false, issueCredential(t, nil, nil))))
+		requireRejected(t, err, "presentation is not signed")
+	})
+
+	t.Run("Presentation was not requested", func(t *testing.T) {
+		metadata := newMetadata(present(t, true, issueCredential(t, nil, nil)))
+		metadata.Message().SetThread("request-2", "")
+
+		err := VerifyPresentations(provider)(next).Handle(metadata)
+		requireRejected(t, err, "presentation was not requested")
+	})
+
+	t.Run("Presentation of another request", func(t *testing.T) {
+		vp := present(t, false, issueCredential(t, nil, nil))
+
+		ctx := signer.vpContext(holderKeyID, holderKH)
+		ctx.Challenge = "challenge-2"
+		require.NoError(t, vp.AddLinkedDataProof(ctx, jsonld.WithDocumentLoader(loader)))
+
+		err := VerifyPresentations(provider)(next).Handle(newMetadata(vp))
+		requireRejected(t, err, `presentation challenge "challenge-2" does not match the requested challenge`)
+
+		vp = present(t, false, issueCredential(t, nil, nil))
+
+		ctx = signer.vpContext(holderKeyID, holderKH)
+		ctx.Domain = "other.example.com"
+		require.NoError(t, vp.AddLinkedDataProof(ctx, jsonld.WithDocumentLoader(loader)))
+
+		err = VerifyPresentations(provider)(next).Handle(newMetadata(vp))
+		requireRejected(t, err, `presentation domain "other.example.com" does not match the requested domain`)
+	})
+
+	t.Run("JWT presentation", func(t *testing.T) {
+		signJWT := func(t *testing.T, nonce, audience string) string {
+			t.Helper()
+
+			vp := present(t, false, issueCredential(t, nil, nil))
+
+			claims, err := vp.JWTClaims([]string{audience}, false)
+			require.NoError(t, err)
+
+			token, err := jwt.NewSigned(&struct {
+				*verifiable.JWTPresClaims
+				Nonce string `json:"nonce"`
+			}{claims, nonce}, nil, &jwtSigner{cr: cr, kh: holderKH, keyID: holderKeyID})
+			require.NoError(t, err)
+
+			vpJWT, err := token.Serialize(false)
+			require.NoError(t, err)
+
+			return vpJWT
+		}
+
+		newJWTMetadata := func(vpJWT string) *mocks.MockMetadata {
+			msg := service.NewDIDCommMsgMap(presentproof.PresentationV2{
+				ID:   "presentation-1",
+				Type: presentproof.PresentationMsgTypeV2,
+				PresentationsAttach: []decorator.Attachment{{
+					MimeType: mimeTypeApplicationLdJSON,
+					Data:     decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(vpJWT))},
+				}},
+			})
+			msg.SetThread(requestThreadID, "")
+
+			metadata := mocks.NewMockMetadata(ctrl)
+			metadata.EXPECT().StateName().Return(stateNamePresentationReceived).AnyTimes()
+			metadata.EXPECT().Message().Return(msg).AnyTimes()
+			metadata.EXPECT().Properties().Return(map[string]interface{}{theirDIDKey: holderDID}).AnyTimes()
+
+			return metadata
+		}
+
+		var input *PolicyInput
+
+		mw := VerifyPresentations(provider, WithPolicies(HolderBinding(), PolicyFunc(func(i *PolicyInput) error {
+			input = i
+
+			return nil
+		})))
+
+		require.NoError(t, mw(next).Handle(newJWTMetadata(signJWT(t, requestChallenge, requestDomain))))
+		require.Equal(t, holderKeyID, input.JWTKeyID)
+
+		err := mw(next).Handle(newJWTMetadata(signJWT(t, "challenge-2", requestDomain)))
+		requireRejected(t, err, `presentation nonce "challenge-2" does not match the requested challenge`)
+
+		err = mw(next).Handle(newJWTMetadata(signJWT(t, requestChallenge, "other.example.com")))
+		requireRejected(t, err, "presentation audience does not contain the requested domain")
+	})
+
+	t.Run("JWT presentation signed by another DID", func(t *testing.T) {
+		err := HolderBinding().Evaluate(&PolicyInput{
+			Presentation: &verifiable.Presentation{Holder: holderDID},
+			TheirDID:     holderDID,
+			JWTKeyID:     issuerKeyID,
+		})
+		require.EqualError(t, err, fmt.Sprintf("presentation is signed with %q which does not belong to "+
+			"the connection DID %s", issuerKeyID, holderDID))
+	})
+
+	t.Run("Invalid credential proof", func(t *testing.T) {
+		vc := issueCredential(t, nil, nil)
+		vc.ID = "http://example.edu/credentials/tampered"
+
+		err := VerifyPresentations(provider)(next).Handle(newMetadata(present(t, true, vc)))
+		requireRejected(t, err, "invalid credential")
+	})
+
+	t.Run("Untrusted issuer", func(t *testing.T) {
+		mw := VerifyPresentations(provider, WithPolicies(TrustedIssuers("did:example:trusted")))
+
+		err := mw(next).Handle(newMetadata(present(t, true, issueCredential(t, nil, nil))))
+		requireRejected(t, err, fmt.Sprintf("issuer %s is not trusted", issuerDID))
+	})
+
+	t.Run("Expired credential", func(t *testing.T) {
+		expired := time.Now().Add(-time.Hour)
+
+		err := VerifyPresentations(provider, WithPolicies(NotExpired()))(next).Handle(
+			newMetadata(present(t, true, issueCredential(t, &expired, nil))))
+		requireRejected(t, err, "expired on")
+	})
+
+	t.Run("Revoked credential", func(t *testing.T) {
+		mw := VerifyPresentations(provider, WithPolicies(
+			CredentialStatus(statusChecker(func(*verifiable.Credential) error {
+				return errors.New("revoked")
+			})),
+		))
+
+		status := &verifiable.TypedID{ID: "https://example.com/status/1#3", Type: "RevocationList2020Status"}
+
+		err := mw(next).Handle(newMetadata(present(t, true, issueCredential(t, nil, status))))
+		requireRejected(t, err, "status: revoked")
+	})
+
+	t.Run("Holder is not the connection DID", func(t *testing.T) {
+		vp := present(t, false, issueCredential(t, nil, nil))
+		vp.Holder = "did:example:other"
+		require.NoError(t, vp.AddLinkedDataProof(signer.vpContext(holderKeyID, holderKH),
+			jsonld.WithDocumentLoader(loader)))
+
+		err := VerifyPresentations(provider, WithPolicies(HolderBinding()))(next).Handle(newMetadata(vp))
+		requireRejected(t, err, "does not match the connection DID")
+	})
+
+	t.Run("Presentation signed by another DID", func(t *testing.T) {
+		vp := present(t, false, issueCredential(t, nil, nil))
+		require.NoError(t, vp.AddLinkedDataProof(signer.vpContext(issuerKeyID, issuerKH),
+			jsonld.WithDocumentLoader(loader)))
+
+		err := VerifyPresentations(provider, WithPolicies(HolderBinding()))(next).Handle(newMetadata(vp))
+		requireRejected(t, err, "does not belong to the connection DID")
+	})
+
+	t.Run("Presentation definition", func(t *testing.T) {
+		definition := &presexch.PresentationDefinition{
+			ID: "definition-1",
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID: "descriptor-1",
+				Schema: []*presexch.Schema{{
+					URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+				}},
+			}},
+		}
+
+		vp, err := definition.CreateVP([]*verifiable.Credential{issueCredential(t, nil, nil)}, loader,
+			verifiable.WithJSONLDDocumentLoader(loader), verifiable.WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		vp.Holder = holderDID
+		require.NoError(t, vp.AddLinkedDataProof(signer.vpContext(holderKeyID, holderKH),
+			jsonld.WithDocumentLoader(loader)))
+
+		var input *PolicyInput
+
+		mw := VerifyPresentations(provider, WithPresentationDefinitions(definition),
+			WithPolicies(PolicyFunc(func(i *PolicyInput) error {
+				input = i
+
+				return nil
+			})))
+
+		require.NoError(t, mw(next).Handle(newMetadata(vp)))
+		require.Len(t, input.Credentials, 1)
+		require.Equal(t, issuerDID, input.Credentials[0].Issuer.ID)
+		require.Equal(t, holderDID, input.TheirDID)
+
+		definition.ID = "definition-2"
+
+		mw = VerifyPresentations(provider, WithPresentationDefinitions(definition))
+		requireRejected(t, mw(next).Handle(newMetadata(vp)), `unknown presentation definition "definition-1"`)
+
+		err = mw(next).Handle(newMetadata(present(t, true, issueCredential(t, nil, nil))))
+		requireRejected(t, err, "presentation submission was not provided")
+	})
+
+	t.Run("Presentation definition with statuses constraints", func(t *testing.T) {
+		definition := &presexch.PresentationDefinition{
+			ID: "definition-statuses",
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID: "descriptor-1",
+				Constraints: &presexch.Constraints{Statuses: &presexch.Statuses{
+					Revoked: &presexch.StatusDirective{Directive: presexch.DirectiveDisallowed},
+				}},
+			}},
+		}
+
+		vp, err := definition.CreateVP([]*verifiable.Credential{issueCredential(t, nil, nil)}, loader,
+			verifiable.WithJSONLDDocumentLoader(loader), verifiable.WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		vp.Holder = holderDID
+		require.NoError(t, vp.AddLinkedDataProof(signer.vpContext(holderKeyID, holderKH),
+			jsonld.WithDocumentLoader(loader)))
+
+		mw := VerifyPresentations(provider, WithPresentationDefinitions(definition))
+		requireRejected(t, mw(next).Handle(newMetadata(vp)), "statuses constraints require credential status checker")
+
+		mw = VerifyPresentations(provider, WithPresentationDefinitions(definition),
+			WithDefinitionStatusChecker(func(*verifiable.Credential) (presexch.CredentialStatus, error) {
+				return presexch.StatusRevoked, nil
+			}))
+		requireRejected(t, mw(next).Handle(newMetadata(vp)), "presentation does not match definition")
+
+		mw = VerifyPresentations(provider, WithPresentationDefinitions(definition),
+			WithDefinitionStatusChecker(func(*verifiable.Credential) (presexch.CredentialStatus, error) {
+				return presexch.StatusActive, nil
+			}))
+		require.NoError(t, mw(next).Handle(newMetadata(vp)))
+	})
+}
+
+func TestAutoAcceptPresentations(t *testing.T) {
+	events := make(chan service.DIDCommAction)
+	next := make(chan service.DIDCommAction)
+
+	go AutoAcceptPresentations(next)(events)
+
+	continued := make(chan interface{})
+
+	events <- service.DIDCommAction{
+		Message: service.NewDIDCommMsgMap(presentproof.PresentationV2{
+			Type: presentproof.PresentationMsgTypeV2,
+		}),
+		Continue: func(args interface{}) {
+			continued <- args
+		},
+	}
+
+	select {
+	case args := <-continued:
+		_, ok := args.(presentproof.Opt)
+		require.True(t, ok)
+	case <-time.After(time.Second):
+		require.Fail(t, "presentation was not continued")
+	}
+
+	go func() {
+		events <- service.DIDCommAction{Message: service.NewDIDCommMsgMap(presentproof.ProposePresentationV2{
+			Type: presentproof.ProposePresentationMsgTypeV2,
+		})}
+	}()
+
+	select {
+	case event := <-next:
+		require.Equal(t, presentproof.ProposePresentationMsgTypeV2, event.Message.Type())
+	case <-time.After(time.Second):
+		require.Fail(t, "proposal was not passed through")
+	}
+
+	close(events)
+}
@@ -79,6 +79,20 @@ var (
 // customError is a wrapper to determine custom error against internal error.
 type customError struct{ error }
 
+func (e customError) Unwrap() error {
+	return e.error
+}
+
+// RejectionError rejects the protocol when it is returned by a middleware or provided to the Stop function.
+// The reason is sent to the other agent in the problem report.
+type RejectionError struct {
+	Reason string
+}
+
+func (e *RejectionError) Error() string {
+	return e.Reason
+}
+
 // transitionalPayload keeps payload needed for Continue function to proceed with the action.
 type transitionalPayload struct {
 	Action
@@ -118,6 +118,12 @@ func (s *abandoned) Execute(md *metaData) (state, stateAction, error) {
 		code = model.Code{Code: codeRejectedError}
 	}
 
+	// the reason of the rejection is explained to the other agent
+	var rejection *RejectionError
+	if errors.As(md.err, &rejection) {
+		code = model.Code{Code: codeRejectedError, En: rejection.Reason}
+	}
+
 	thID, err := md.Msg.ThreadID()
 	if err != nil {
 		return nil, nil, fmt.Errorf("threadID: %w", err)
@@ -127,7 +133,11 @@ func (s *abandoned) Execute(md *metaData) (state, stateAction, error) {
 		if s.V == SpecV3 {
 			return messenger.ReplyToNested(service.NewDIDCommMsgMap(&model.ProblemReportV2{
 				Type: ProblemReportMsgTypeV3,
-				Body: model.ProblemReportV2Body{Code: code.Code, WebRedirect: md.properties[webRedirect]},
+				Body: model.ProblemReportV2Body{
+					Code:        code.Code,
+					Comment:     code.En,
+					WebRedirect: md.properties[webRedirect],
+				},
 			}), &service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID, V: getDIDVersion(s.V)})
 		}
 
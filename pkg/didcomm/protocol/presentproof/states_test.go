@@ -156,6 +156,62 @@ func TestAbandoning_Execute(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("Rejection Error", func(t *testing.T) {
+		md := &metaData{err: fmt.Errorf("middleware: %w", &RejectionError{Reason: "issuer is not trusted"})}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+		md.Msg.SetID(uuid.New().String())
+
+		followup, action, err := (&abandoned{V: SpecV2, Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().
+			ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeRejectedError, r.Description.Code)
+				require.Equal(t, "issuer is not trusted", r.Description.En)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Rejection Error (v3)", func(t *testing.T) {
+		md := &metaData{err: customError{error: &RejectionError{Reason: "credential expired"}}}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+		md.Msg.SetID(uuid.New().String())
+
+		followup, action, err := (&abandoned{V: SpecV3, Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().
+			ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReportV2{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeRejectedError, r.Body.Code)
+				require.Equal(t, "credential expired", r.Body.Comment)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
 	t.Run("No error code", func(t *testing.T) {
 		md := &metaData{}
 		md.Msg = service.NewDIDCommMsgMap(struct{}{})
@@ -17,7 +17,7 @@ import (
 
 var logger = log.New("didcomm/transport/internal")
 
-// UnpackMessage using 'pack' with a 'source' of either 'ws' or 'http'.
+// UnpackMessage using 'pack' with a 'source' transport name (eg: 'ws', 'http' or 'queue').
 func UnpackMessage(message []byte, pack transport.Packager, source string) (*transport.Envelope, error) {
 	doubleQuote := []byte("\"")
 	msg := message
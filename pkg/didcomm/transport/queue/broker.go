@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package queue
+
+import (
+	"errors"
+)
+
+// MessageHandler handles a message consumed from a broker topic. Returning an error signals the broker that the
+// message was not processed; brokers supporting acknowledgements may redeliver it.
+type MessageHandler func(message []byte) error
+
+// Subscription is a subscription to a broker topic.
+type Subscription interface {
+	// Unsubscribe stops the delivery of messages to the subscription handler.
+	Unsubscribe() error
+}
+
+// Broker is the minimal message broker abstraction used by the queue transport. Messages published to a topic must
+// be delivered to exactly one of the topic subscribers (competing consumers), which lets several agent instances
+// consume inbound envelopes from a shared queue.
+type Broker interface {
+	// Publish publishes the message to the topic.
+	Publish(topic string, message []byte) error
+	// Subscribe subscribes the handler to the topic.
+	Subscribe(topic string, handler MessageHandler) (Subscription, error)
+}
+
+// BrokerAdapter adapts the functions of a broker client library (eg: an AMQP channel or a NATS queue group
+// subscription) to the Broker interface.
+type BrokerAdapter struct {
+	// PublishFunc publishes a message to a topic (eg: AMQP queue, NATS subject).
+	PublishFunc func(topic string, message []byte) error
+	// SubscribeFunc subscribes a handler to a topic and returns the function cancelling the subscription.
+	SubscribeFunc func(topic string, handler MessageHandler) (unsubscribe func() error, err error)
+}
+
+// Publish publishes the message to the topic.
+func (a *BrokerAdapter) Publish(topic string, message []byte) error {
+	if a.PublishFunc == nil {
+		return errors.New("broker adapter: publish is not supported")
+	}
+
+	return a.PublishFunc(topic, message)
+}
+
+// Subscribe subscribes the handler to the topic.
+func (a *BrokerAdapter) Subscribe(topic string, handler MessageHandler) (Subscription, error) {
+	if a.SubscribeFunc == nil {
+		return nil, errors.New("broker adapter: subscribe is not supported")
+	}
+
+	unsubscribe, err := a.SubscribeFunc(topic, handler)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptionFunc(unsubscribe), nil
+}
+
+type subscriptionFunc func() error
+
+func (f subscriptionFunc) Unsubscribe() error {
+	if f == nil {
+		return nil
+	}
+
+	return f()
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package queue
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/internal"
+)
+
+// Inbound queue inbound transport, it consumes envelopes from a broker topic.
+type Inbound struct {
+	broker       Broker
+	topic        string
+	externalAddr string
+	subscription Subscription
+}
+
+// NewInbound creates a new queue inbound transport consuming the topic. The externalAddr is the endpoint published
+// to other agents, it defaults to queue://topic.
+func NewInbound(broker Broker, topic, externalAddr string) (*Inbound, error) {
+	if broker == nil {
+		return nil, errors.New("queue broker is mandatory")
+	}
+
+	if topic == "" {
+		return nil, errors.New("queue topic is mandatory")
+	}
+
+	if externalAddr == "" {
+		externalAddr = Endpoint(topic)
+	}
+
+	return &Inbound{
+		broker:       broker,
+		topic:        topic,
+		externalAddr: externalAddr,
+	}, nil
+}
+
+// Start subscribes to the topic.
+func (i *Inbound) Start(prov transport.Provider) error {
+	if prov == nil || prov.InboundMessageHandler() == nil {
+		return errors.New("creation of inbound handler failed")
+	}
+
+	subscription, err := i.broker.Subscribe(i.topic, func(message []byte) error {
+		return processMessage(message, prov)
+	})
+	if err != nil {
+		return fmt.Errorf("queue subscribe to topic [%s] failed: %w", i.topic, err)
+	}
+
+	i.subscription = subscription
+
+	return nil
+}
+
+// Stop unsubscribes from the topic.
+func (i *Inbound) Stop() error {
+	if i.subscription == nil {
+		return nil
+	}
+
+	if err := i.subscription.Unsubscribe(); err != nil {
+		return fmt.Errorf("queue unsubscribe from topic [%s] failed: %w", i.topic, err)
+	}
+
+	i.subscription = nil
+
+	return nil
+}
+
+// Endpoint provides the queue connection details.
+func (i *Inbound) Endpoint() string {
+	return i.externalAddr
+}
+
+func processMessage(message []byte, prov transport.Provider) error {
+	unpackMsg, err := internal.UnpackMessage(message, prov.Packager(), "queue")
+	if err != nil {
+		logger.Errorf("%v", err)
+
+		// the envelope can't be processed by any consumer, don't request a redelivery
+		return nil
+	}
+
+	err = prov.InboundMessageHandler()(unpackMsg)
+	if err != nil {
+		logger.Errorf("incoming msg processing failed: %s", err)
+
+		return err
+	}
+
+	return nil
+}
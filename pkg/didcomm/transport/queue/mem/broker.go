@@ -0,0 +1,214 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mem
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/queue"
+)
+
+var logger = log.New("aries-framework/queue/mem")
+
+// Broker is an in-process queue.Broker. Messages are delivered in order and round-robin to the subscribers of a
+// topic; messages published to a topic without subscribers are kept until a subscriber is added.
+// Intended for tests and single process deployments.
+type Broker struct {
+	topics map[string]*topic
+	mutex  sync.Mutex
+	closed bool
+}
+
+type topic struct {
+	pending     [][]byte
+	subscribers []*subscription
+	next        int
+}
+
+// NewBroker creates a new in-memory broker.
+func NewBroker() *Broker {
+	return &Broker{topics: make(map[string]*topic)}
+}
+
+// Publish publishes the message to the topic.
+func (b *Broker) Publish(name string, message []byte) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		return errors.New("broker is closed")
+	}
+
+	t := b.topic(name)
+
+	if len(t.subscribers) == 0 {
+		t.pending = append(t.pending, message)
+
+		return nil
+	}
+
+	t.next = (t.next + 1) % len(t.subscribers)
+	t.subscribers[t.next].push(message)
+
+	return nil
+}
+
+// Subscribe subscribes the handler to the topic.
+func (b *Broker) Subscribe(name string, handler queue.MessageHandler) (queue.Subscription, error) {
+	if handler == nil {
+		return nil, errors.New("message handler is mandatory")
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		return nil, errors.New("broker is closed")
+	}
+
+	t := b.topic(name)
+
+	s := &subscription{broker: b, topic: name, handler: handler}
+	s.cond = sync.NewCond(&s.mutex)
+
+	for _, msg := range t.pending {
+		s.push(msg)
+	}
+
+	t.pending = nil
+	t.subscribers = append(t.subscribers, s)
+
+	go s.run()
+
+	return s, nil
+}
+
+// Close stops all the subscriptions; undelivered messages are dropped.
+func (b *Broker) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, t := range b.topics {
+		for _, s := range t.subscribers {
+			s.close()
+		}
+	}
+
+	b.topics = make(map[string]*topic)
+	b.closed = true
+
+	return nil
+}
+
+func (b *Broker) topic(name string) *topic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic{}
+		b.topics[name] = t
+	}
+
+	return t
+}
+
+func (b *Broker) unsubscribe(s *subscription) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	t, ok := b.topics[s.topic]
+	if !ok {
+		return
+	}
+
+	for i, sub := range t.subscribers {
+		if sub == s {
+			t.subscribers = append(t.subscribers[:i], t.subscribers[i+1:]...)
+
+			break
+		}
+	}
+
+	// hand the undelivered messages over to the remaining subscribers
+	for _, msg := range s.close() {
+		if len(t.subscribers) == 0 {
+			t.pending = append(t.pending, msg)
+
+			continue
+		}
+
+		t.next = (t.next + 1) % len(t.subscribers)
+		t.subscribers[t.next].push(msg)
+	}
+}
+
+type subscription struct {
+	broker   *Broker
+	topic    string
+	handler  queue.MessageHandler
+	messages [][]byte
+	closed   bool
+	once     sync.Once
+	mutex    sync.Mutex
+	cond     *sync.Cond
+}
+
+// Unsubscribe stops the delivery of messages to the subscription handler.
+func (s *subscription) Unsubscribe() error {
+	s.once.Do(func() {
+		s.broker.unsubscribe(s)
+	})
+
+	return nil
+}
+
+func (s *subscription) push(message []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.messages = append(s.messages, message)
+	s.cond.Signal()
+}
+
+// close stops the subscription and returns the undelivered messages.
+func (s *subscription) close() [][]byte {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	messages := s.messages
+
+	s.messages = nil
+	s.closed = true
+	s.cond.Signal()
+
+	return messages
+}
+
+func (s *subscription) run() {
+	for {
+		s.mutex.Lock()
+
+		for len(s.messages) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+
+		if s.closed {
+			s.mutex.Unlock()
+
+			return
+		}
+
+		msg := s.messages[0]
+		s.messages = s.messages[1:]
+
+		s.mutex.Unlock()
+
+		if err := s.handler(msg); err != nil {
+			logger.Warnf("queue [%s] message handler failed: %v", s.topic, err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mem
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroker(t *testing.T) {
+	t.Run("test broker - pending messages are delivered in order", func(t *testing.T) {
+		broker := NewBroker()
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, broker.Publish("topic", []byte(fmt.Sprint(i))))
+		}
+
+		received := make(chan string, 3)
+
+		sub, err := broker.Subscribe("topic", func(message []byte) error {
+			received <- string(message)
+			return nil
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			select {
+			case msg := <-received:
+				require.Equal(t, fmt.Sprint(i), msg)
+			case <-time.After(time.Second):
+				require.Fail(t, "message not received")
+			}
+		}
+
+		require.NoError(t, sub.Unsubscribe())
+		require.NoError(t, sub.Unsubscribe())
+	})
+
+	t.Run("test broker - competing consumers", func(t *testing.T) {
+		broker := NewBroker()
+
+		var (
+			wg     sync.WaitGroup
+			mutex  sync.Mutex
+			counts = make(map[string]int)
+		)
+
+		const total = 10
+
+		wg.Add(total)
+
+		for _, name := range []string{"a", "b"} {
+			name := name
+
+			_, err := broker.Subscribe("topic", func(message []byte) error {
+				mutex.Lock()
+				counts[name]++
+				mutex.Unlock()
+
+				wg.Done()
+
+				return errors.New("handler errors are logged")
+			})
+			require.NoError(t, err)
+		}
+
+		for i := 0; i < total; i++ {
+			require.NoError(t, broker.Publish("topic", []byte(fmt.Sprint(i))))
+		}
+
+		wg.Wait()
+
+		require.Equal(t, total/2, counts["a"])
+		require.Equal(t, total/2, counts["b"])
+
+		require.NoError(t, broker.Close())
+
+		require.Error(t, broker.Publish("topic", []byte("data")))
+
+		_, err := broker.Subscribe("topic", func([]byte) error { return nil })
+		require.Error(t, err)
+	})
+
+	t.Run("test broker - undelivered messages are handed over on unsubscribe", func(t *testing.T) {
+		broker := NewBroker()
+
+		block := make(chan struct{})
+		handling := make(chan struct{})
+		received := make(chan string, 2)
+
+		sub, err := broker.Subscribe("topic", func(message []byte) error {
+			close(handling)
+			<-block
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, broker.Publish("topic", []byte("first")))
+		<-handling
+		require.NoError(t, broker.Publish("topic", []byte("second")))
+
+		require.NoError(t, sub.Unsubscribe())
+		close(block)
+
+		_, err = broker.Subscribe("topic", func(message []byte) error {
+			received <- string(message)
+			return nil
+		})
+		require.NoError(t, err)
+
+		select {
+		case msg := <-received:
+			require.Equal(t, "second", msg)
+		case <-time.After(time.Second):
+			require.Fail(t, "message not received")
+		}
+	})
+
+	t.Run("test broker - handler is mandatory", func(t *testing.T) {
+		_, err := NewBroker().Subscribe("topic", nil)
+		require.EqualError(t, err, "message handler is mandatory")
+	})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+const queueScheme = "queue://"
+
+var logger = log.New("aries-framework/queue")
+
+// Outbound queue outbound transport, it publishes envelopes to the topic of queue://topic endpoints.
+type Outbound struct {
+	broker Broker
+}
+
+// NewOutbound creates a new queue outbound transport publishing to the broker.
+func NewOutbound(broker Broker) (*Outbound, error) {
+	if broker == nil {
+		return nil, errors.New("creation of queue outbound transport requires a broker")
+	}
+
+	return &Outbound{broker: broker}, nil
+}
+
+// Start starts the outbound transport.
+func (o *Outbound) Start(prov transport.Provider) error {
+	return nil
+}
+
+// Send publishes the data to the topic of the destination service endpoint.
+func (o *Outbound) Send(data []byte, destination *service.Destination) (string, error) {
+	topic, err := Topic(destination.ServiceEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	err = o.broker.Publish(topic, data)
+	if err != nil {
+		logger.Errorf("didcomm failed : transport=queue serviceEndpoint=%s errMsg=%s",
+			destination.ServiceEndpoint, err.Error())
+
+		return "", fmt.Errorf("queue publish message : %w", err)
+	}
+
+	return "", nil
+}
+
+// AcceptRecipient return route is not supported by the queue transport.
+func (o *Outbound) AcceptRecipient([]string) bool {
+	return false
+}
+
+// Accept checks for the url scheme.
+func (o *Outbound) Accept(url string) bool {
+	return strings.HasPrefix(url, queueScheme)
+}
+
+// Endpoint returns the queue://topic endpoint of the topic.
+func Endpoint(topic string) string {
+	return queueScheme + topic
+}
+
+// Topic returns the topic of a queue://topic endpoint.
+func Topic(endpoint string) (string, error) {
+	if !strings.HasPrefix(endpoint, queueScheme) {
+		return "", fmt.Errorf("invalid queue endpoint '%s': scheme must be %s", endpoint, queueScheme)
+	}
+
+	topic := strings.TrimPrefix(endpoint, queueScheme)
+	if topic == "" {
+		return "", fmt.Errorf("invalid queue endpoint '%s': topic is mandatory", endpoint)
+	}
+
+	return topic, nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package queue_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/queue"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/queue/mem"
+	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+)
+
+func TestOutbound(t *testing.T) {
+	t.Run("test outbound - broker is mandatory", func(t *testing.T) {
+		_, err := queue.NewOutbound(nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "requires a broker")
+	})
+
+	t.Run("test outbound - accept", func(t *testing.T) {
+		outbound, err := queue.NewOutbound(mem.NewBroker())
+		require.NoError(t, err)
+		require.NoError(t, outbound.Start(nil))
+
+		require.True(t, outbound.Accept("queue://agents"))
+		require.False(t, outbound.Accept("http://localhost"))
+		require.False(t, outbound.AcceptRecipient([]string{"key"}))
+	})
+
+	t.Run("test outbound - invalid endpoint", func(t *testing.T) {
+		outbound, err := queue.NewOutbound(mem.NewBroker())
+		require.NoError(t, err)
+
+		_, err = outbound.Send([]byte("data"), &service.Destination{ServiceEndpoint: "queue://"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "topic is mandatory")
+
+		_, err = outbound.Send([]byte("data"), &service.Destination{ServiceEndpoint: "ws://agents"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "scheme must be queue://")
+	})
+
+	t.Run("test outbound - publish error", func(t *testing.T) {
+		outbound, err := queue.NewOutbound(&queue.BrokerAdapter{
+			PublishFunc: func(string, []byte) error {
+				return errors.New("publish error")
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = outbound.Send([]byte("data"), &service.Destination{ServiceEndpoint: "queue://agents"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "publish error")
+	})
+}
+
+func TestInbound(t *testing.T) {
+	t.Run("test inbound - invalid arguments", func(t *testing.T) {
+		_, err := queue.NewInbound(nil, "agents", "")
+		require.EqualError(t, err, "queue broker is mandatory")
+
+		_, err = queue.NewInbound(mem.NewBroker(), "", "")
+		require.EqualError(t, err, "queue topic is mandatory")
+
+		inbound, err := queue.NewInbound(mem.NewBroker(), "agents", "")
+		require.NoError(t, err)
+		require.Equal(t, "queue://agents", inbound.Endpoint())
+		require.NoError(t, inbound.Stop())
+
+		err = inbound.Start(nil)
+		require.EqualError(t, err, "creation of inbound handler failed")
+	})
+
+	t.Run("test inbound - external address", func(t *testing.T) {
+		inbound, err := queue.NewInbound(mem.NewBroker(), "agents", "queue://public-agents")
+		require.NoError(t, err)
+		require.Equal(t, "queue://public-agents", inbound.Endpoint())
+	})
+
+	t.Run("test inbound - subscribe and unsubscribe errors", func(t *testing.T) {
+		inbound, err := queue.NewInbound(&queue.BrokerAdapter{}, "agents", "")
+		require.NoError(t, err)
+
+		err = inbound.Start(&mockProvider{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "subscribe is not supported")
+
+		inbound, err = queue.NewInbound(&queue.BrokerAdapter{
+			SubscribeFunc: func(string, queue.MessageHandler) (func() error, error) {
+				return func() error {
+					return errors.New("unsubscribe error")
+				}, nil
+			},
+		}, "agents", "")
+		require.NoError(t, err)
+
+		require.NoError(t, inbound.Start(&mockProvider{}))
+
+		err = inbound.Stop()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsubscribe error")
+	})
+
+	t.Run("test inbound - receive messages", func(t *testing.T) {
+		broker := mem.NewBroker()
+
+		received := make(chan *transport.Envelope)
+
+		inbound, err := queue.NewInbound(broker, "agents", "")
+		require.NoError(t, err)
+
+		require.NoError(t, inbound.Start(&mockProvider{
+			packager: &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("unpacked")}},
+			handler: func(envelope *transport.Envelope) error {
+				received <- envelope
+				return nil
+			},
+		}))
+
+		outbound, err := queue.NewOutbound(broker)
+		require.NoError(t, err)
+
+		_, err = outbound.Send([]byte("packed"), &service.Destination{ServiceEndpoint: inbound.Endpoint()})
+		require.NoError(t, err)
+
+		select {
+		case envelope := <-received:
+			require.Equal(t, "unpacked", string(envelope.Message))
+		case <-time.After(time.Second):
+			require.Fail(t, "message not received")
+		}
+
+		require.NoError(t, inbound.Stop())
+	})
+
+	t.Run("test inbound - unpack and handler errors", func(t *testing.T) {
+		var handler queue.MessageHandler
+
+		inbound, err := queue.NewInbound(&queue.BrokerAdapter{
+			SubscribeFunc: func(_ string, h queue.MessageHandler) (func() error, error) {
+				handler = h
+				return nil, nil
+			},
+		}, "agents", "")
+		require.NoError(t, err)
+
+		prov := &mockProvider{
+			packager: &mockpackager.Packager{UnpackErr: errors.New("unpack error")},
+		}
+
+		require.NoError(t, inbound.Start(prov))
+
+		// invalid envelopes are not redelivered
+		require.NoError(t, handler([]byte("packed")))
+
+		prov.packager = &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("unpacked")}}
+		prov.handler = func(*transport.Envelope) error {
+			return errors.New("handler error")
+		}
+
+		require.EqualError(t, handler([]byte("packed")), "handler error")
+		require.NoError(t, inbound.Stop())
+	})
+}
+
+type mockProvider struct {
+	packager transport.Packager
+	handler  transport.InboundMessageHandler
+}
+
+func (p *mockProvider) InboundMessageHandler() transport.InboundMessageHandler {
+	if p.handler == nil {
+		return func(*transport.Envelope) error { return nil }
+	}
+
+	return p.handler
+}
+
+func (p *mockProvider) Packager() transport.Packager {
+	return p.packager
+}
+
+func (p *mockProvider) AriesFrameworkID() string {
+	return "framework-instance-1"
+}
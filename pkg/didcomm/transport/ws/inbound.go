@@ -96,7 +96,9 @@ func (i *Inbound) processRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	i.pool.listener(c, false)
+	if _, err = i.pool.listener(c, false, 0); err != nil {
+		logger.Debugf("inbound websocket connection closed : %v", err)
+	}
 }
 
 func upgradeConnection(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
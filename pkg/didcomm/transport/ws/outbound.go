@@ -8,9 +8,14 @@ package ws
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"nhooyr.io/websocket"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -20,15 +25,102 @@ import (
 
 const webSocketScheme = "ws"
 
+// outboundCommWSOpts holds options for the WebSocket outbound transport.
+type outboundCommWSOpts struct {
+	tlsConfig           *tls.Config
+	dialTimeout         time.Duration
+	pingInterval        time.Duration
+	readLimit           int64
+	reconnect           bool
+	reconnectMaxElapsed time.Duration
+	reconnectHandler    func(destination *service.Destination)
+}
+
+// OutboundClientOpt is an outbound WebSocket transport option.
+type OutboundClientOpt func(opts *outboundCommWSOpts)
+
+// WithOutboundTLSConfig option is for creating an Outbound WebSocket transport using a tls.Config instance
+// for wss:// endpoints. It has no effect on JS/WASM targets where TLS is handled by the browser.
+func WithOutboundTLSConfig(tlsConfig *tls.Config) OutboundClientOpt {
+	return func(opts *outboundCommWSOpts) {
+		opts.tlsConfig = tlsConfig
+	}
+}
+
+// WithOutboundDialTimeout option sets the maximum time spent establishing a WebSocket connection.
+// Zero (default) means no timeout.
+func WithOutboundDialTimeout(timeout time.Duration) OutboundClientOpt {
+	return func(opts *outboundCommWSOpts) {
+		opts.dialTimeout = timeout
+	}
+}
+
+// WithOutboundPingInterval option sets the interval of the ping/pong keepalive sent over pooled (return-route)
+// connections. A ping not answered within the same interval closes the connection. Zero disables the keepalive.
+// Defaults to 30 seconds.
+func WithOutboundPingInterval(interval time.Duration) OutboundClientOpt {
+	return func(opts *outboundCommWSOpts) {
+		opts.pingInterval = interval
+	}
+}
+
+// WithOutboundReadLimit option sets the maximum size in bytes of a message read from a pooled connection.
+// Defaults to the websocket library limit (32768 bytes).
+func WithOutboundReadLimit(limit int64) OutboundClientOpt {
+	return func(opts *outboundCommWSOpts) {
+		opts.readLimit = limit
+	}
+}
+
+// WithOutboundReconnect option enables automatic reconnection, with exponential backoff, of pooled return-route
+// connections which were closed abnormally (eg: keepalive failure, network error or NAT timeout). The connection
+// is registered again in the pool for the same keys. Reconnection attempts stop after maxElapsedTime, zero means
+// the client keeps retrying until it is stopped.
+func WithOutboundReconnect(maxElapsedTime time.Duration) OutboundClientOpt {
+	return func(opts *outboundCommWSOpts) {
+		opts.reconnect = true
+		opts.reconnectMaxElapsed = maxElapsedTime
+	}
+}
+
+// WithOutboundReconnectHandler option sets a function called after a pooled connection has been re-established.
+// Since the remote agent links a connection to keys only when it receives a message with a return-route transport
+// decorator, the handler is the place to send such a message again (eg: a message pickup status request).
+func WithOutboundReconnectHandler(handler func(destination *service.Destination)) OutboundClientOpt {
+	return func(opts *outboundCommWSOpts) {
+		opts.reconnectHandler = handler
+	}
+}
+
 // OutboundClient websocket outbound.
 type OutboundClient struct {
-	pool *connPool
-	prov transport.Provider
+	pool  *connPool
+	prov  transport.Provider
+	opts  *outboundCommWSOpts
+	ctx   context.Context
+	stop  context.CancelFunc
+	conns map[*websocket.Conn]struct{}
+	lock  sync.Mutex
 }
 
 // NewOutbound creates a client for Outbound WS transport.
-func NewOutbound() *OutboundClient {
-	return &OutboundClient{}
+func NewOutbound(opts ...OutboundClientOpt) *OutboundClient {
+	clOpts := &outboundCommWSOpts{
+		pingInterval: pingFrequency,
+	}
+
+	for _, opt := range opts {
+		opt(clOpts)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &OutboundClient{
+		opts:  clOpts,
+		ctx:   ctx,
+		stop:  cancel,
+		conns: make(map[*websocket.Conn]struct{}),
+	}
 }
 
 // Start starts the outbound transport.
@@ -39,6 +131,25 @@ func (cs *OutboundClient) Start(prov transport.Provider) error {
 	return nil
 }
 
+// Stop closes the pooled connections opened by this client and cancels pending reconnections.
+func (cs *OutboundClient) Stop() error {
+	cs.stop()
+
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	for conn := range cs.conns {
+		err := conn.Close(websocket.StatusNormalClosure, "closing the connection")
+		if err != nil && websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+			logger.Debugf("failed to close connection: %v", err)
+		}
+
+		delete(cs.conns, conn)
+	}
+
+	return nil
+}
+
 // Send sends a2a data via WS.
 func (cs *OutboundClient) Send(data []byte, destination *service.Destination) (string, error) {
 	conn, cleanup, err := cs.getConnection(destination)
@@ -69,6 +180,16 @@ func (cs *OutboundClient) AcceptRecipient(keys []string) bool {
 	return acceptRecipient(cs.pool, keys)
 }
 
+// Metrics returns a snapshot of the connection pool metrics. The pool is shared with the WebSocket inbound
+// transport of the same framework instance.
+func (cs *OutboundClient) Metrics() PoolMetrics {
+	if cs.pool == nil {
+		return PoolMetrics{}
+	}
+
+	return cs.pool.snapshot()
+}
+
 func (cs *OutboundClient) getConnection(destination *service.Destination) (*websocket.Conn, func(), error) {
 	var conn *websocket.Conn
 
@@ -94,7 +215,7 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 
 	var err error
 
-	conn, _, err = websocket.Dial(context.Background(), destination.ServiceEndpoint, nil)
+	conn, err = cs.dial(destination.ServiceEndpoint)
 	if err != nil {
 		return nil, cleanup, fmt.Errorf("websocket client : %w", err)
 	}
@@ -105,7 +226,7 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 			cs.pool.add(v, conn)
 		}
 
-		go cs.pool.listener(conn, true)
+		go cs.listen(conn, destination)
 
 		return conn, cleanup, nil
 	}
@@ -119,3 +240,104 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 
 	return conn, cleanup, nil
 }
+
+func (cs *OutboundClient) dial(endpoint string) (*websocket.Conn, error) {
+	ctx := context.Background()
+
+	if cs.opts.dialTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, cs.opts.dialTimeout)
+		defer cancel()
+	}
+
+	conn, _, err := websocket.Dial(ctx, endpoint, dialOptions(cs.opts.tlsConfig)) //nolint:bodyclose
+	if err != nil {
+		return nil, err
+	}
+
+	if cs.opts.readLimit > 0 {
+		conn.SetReadLimit(cs.opts.readLimit)
+	}
+
+	return conn, nil
+}
+
+// listen reads messages from a pooled connection and, if enabled, re-establishes the connection when it was
+// closed abnormally.
+func (cs *OutboundClient) listen(conn *websocket.Conn, destination *service.Destination) {
+	for {
+		cs.track(conn, true)
+
+		keys, err := cs.pool.listener(conn, true, cs.opts.pingInterval)
+
+		cs.track(conn, false)
+
+		if err == nil || !cs.opts.reconnect || cs.ctx.Err() != nil {
+			return
+		}
+
+		logger.Warnf("websocket connection to %s lost, reconnecting : %v", destination.ServiceEndpoint, err)
+
+		conn, err = cs.redial(destination.ServiceEndpoint)
+		if err != nil {
+			logger.Errorf("websocket reconnection to %s failed : %v", destination.ServiceEndpoint, err)
+
+			return
+		}
+
+		for _, v := range append(keys, destination.RecipientKeys...) {
+			cs.pool.add(v, conn)
+		}
+
+		if cs.opts.reconnectHandler != nil {
+			go cs.opts.reconnectHandler(destination)
+		}
+	}
+}
+
+func (cs *OutboundClient) redial(endpoint string) (*websocket.Conn, error) {
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = cs.opts.reconnectMaxElapsed
+
+	var conn *websocket.Conn
+
+	err := backoff.Retry(func() error {
+		c, err := cs.dial(endpoint)
+		if err != nil {
+			cs.pool.metrics.reconnectFailures.inc()
+
+			return err
+		}
+
+		conn = c
+
+		return nil
+	}, backoff.WithContext(bo, cs.ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if cs.ctx.Err() != nil {
+		_ = conn.Close(websocket.StatusNormalClosure, "client stopped") //nolint:errcheck
+
+		return nil, errors.New("outbound client stopped")
+	}
+
+	cs.pool.metrics.reconnects.inc()
+
+	return conn, nil
+}
+
+func (cs *OutboundClient) track(conn *websocket.Conn, open bool) {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	if open {
+		cs.conns[conn] = struct{}{}
+
+		return
+	}
+
+	delete(cs.conns, conn)
+}
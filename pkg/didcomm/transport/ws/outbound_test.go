@@ -7,14 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package ws
 
 import (
+	"context"
+	"crypto/tls"
 	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"nhooyr.io/websocket"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
 )
 
@@ -163,3 +168,182 @@ func TestClient(t *testing.T) {
 		require.Equal(t, "", resp)
 	})
 }
+
+func TestClientOptions(t *testing.T) {
+	t.Run("test outbound transport - options", func(t *testing.T) {
+		handler := func(*service.Destination) {}
+
+		outbound := NewOutbound(
+			WithOutboundTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}),
+			WithOutboundDialTimeout(time.Second),
+			WithOutboundPingInterval(time.Minute),
+			WithOutboundReadLimit(1024),
+			WithOutboundReconnect(time.Minute),
+			WithOutboundReconnectHandler(handler),
+		)
+		require.NotNil(t, outbound)
+
+		require.NotNil(t, outbound.opts.tlsConfig)
+		require.Equal(t, time.Second, outbound.opts.dialTimeout)
+		require.Equal(t, time.Minute, outbound.opts.pingInterval)
+		require.EqualValues(t, 1024, outbound.opts.readLimit)
+		require.True(t, outbound.opts.reconnect)
+		require.Equal(t, time.Minute, outbound.opts.reconnectMaxElapsed)
+		require.NotNil(t, outbound.opts.reconnectHandler)
+
+		require.Equal(t, PoolMetrics{}, outbound.Metrics())
+	})
+
+	t.Run("test outbound transport - dial options honour proxy environment", func(t *testing.T) {
+		require.Nil(t, dialOptions(nil))
+
+		opts := dialOptions(&tls.Config{MinVersion: tls.VersionTLS12})
+		require.NotNil(t, opts)
+
+		tr, ok := opts.HTTPClient.Transport.(*http.Transport)
+		require.True(t, ok)
+		require.NotNil(t, tr.Proxy)
+		require.NotNil(t, tr.TLSClientConfig)
+	})
+
+	t.Run("test outbound transport - dial timeout", func(t *testing.T) {
+		outbound := NewOutbound(WithOutboundDialTimeout(time.Nanosecond))
+		require.NotNil(t, outbound)
+
+		addr := startWebSocketServer(t, echo)
+
+		_, err := outbound.Send([]byte("hello"), prepareDestination("ws://"+addr))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "websocket client")
+	})
+
+	t.Run("test outbound transport - read limit", func(t *testing.T) {
+		received := make(chan struct{}, 1)
+
+		outbound := NewOutbound(WithOutboundReadLimit(8))
+		require.NotNil(t, outbound)
+
+		require.NoError(t, outbound.Start(&mockTransportProvider{
+			packagerValue: &mockPackager{verKey: "ABCD"},
+			executeInbound: func(envelope *transport.Envelope) error {
+				received <- struct{}{}
+				return nil
+			},
+		}))
+
+		addr := startWebSocketServer(t, echo)
+
+		_, err := outbound.Send([]byte("message exceeding the read limit"),
+			prepareDestinationWithTransport("ws://"+addr, decorator.TransportReturnRouteAll, []string{"ABCD"}))
+		require.NoError(t, err)
+
+		select {
+		case <-received:
+			require.Fail(t, "message exceeding the read limit must not be received")
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		require.False(t, outbound.AcceptRecipient([]string{"ABCD"}))
+	})
+
+	t.Run("test outbound transport - reconnect pooled connection", func(t *testing.T) {
+		verKey := "XYZ-reconnect"
+		recKey := []string{verKey}
+
+		var connCount int32
+
+		addr := startWebSocketServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&connCount, 1) == 1 {
+				c, err := Accept(w, r)
+				require.NoError(t, err)
+
+				// drop the first connection abnormally
+				require.NoError(t, c.Close(websocket.StatusInternalError, "connection lost"))
+
+				return
+			}
+
+			echo(t, w, r)
+		})
+
+		reconnected := make(chan struct{}, 1)
+
+		outbound := NewOutbound(
+			WithOutboundReconnect(5*time.Second),
+			WithOutboundReconnectHandler(func(dest *service.Destination) {
+				require.Equal(t, "ws://"+addr, dest.ServiceEndpoint)
+				reconnected <- struct{}{}
+			}),
+		)
+		require.NotNil(t, outbound)
+
+		require.NoError(t, outbound.Start(&mockProvider{
+			&mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("data")}},
+		}))
+
+		_, err := outbound.Send(createTransportDecRequest(t, decorator.TransportReturnRouteAll),
+			prepareDestinationWithTransport("ws://"+addr, decorator.TransportReturnRouteAll, recKey))
+		require.NoError(t, err)
+
+		select {
+		case <-reconnected:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "connection was not re-established")
+		}
+
+		require.True(t, outbound.AcceptRecipient(recKey))
+
+		metrics := outbound.Metrics()
+		require.EqualValues(t, 1, metrics.Reconnects)
+		require.EqualValues(t, 1, metrics.ActiveConnections)
+		require.Equal(t, 1, metrics.PooledKeys)
+
+		require.NoError(t, outbound.Stop())
+
+		require.Eventually(t, func() bool {
+			return !outbound.AcceptRecipient(recKey)
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("test outbound transport - keepalive closes unresponsive connection", func(t *testing.T) {
+		verKey := "XYZ-keepalive"
+
+		addr := startWebSocketServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			c, err := Accept(w, r)
+			require.NoError(t, err)
+
+			// never read from the connection, pings are not answered
+			<-time.After(time.Second)
+
+			_ = c.Close(websocket.StatusNormalClosure, "closing the connection") //nolint:errcheck
+		})
+
+		outbound := NewOutbound(WithOutboundPingInterval(50 * time.Millisecond))
+		require.NotNil(t, outbound)
+
+		require.NoError(t, outbound.Start(&mockProvider{
+			&mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("data")}},
+		}))
+
+		_, err := outbound.Send([]byte("data"),
+			prepareDestinationWithTransport("ws://"+addr, decorator.TransportReturnRouteAll, []string{verKey}))
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			return outbound.Metrics().PingFailures > 0
+		}, time.Second, 10*time.Millisecond)
+
+		require.Nil(t, outbound.pool.fetch(verKey))
+	})
+}
+
+func TestKeepConnAlive(t *testing.T) {
+	require.NoError(t, keepConnAlive(nil, false, time.Second))
+	require.NoError(t, keepConnAlive(nil, true, 0))
+
+	conn, _, err := websocket.Dial(context.Background(), "ws://"+startWebSocketServer(t, echo), nil) //nolint:bodyclose
+	require.NoError(t, err)
+	require.NoError(t, conn.Close(websocket.StatusNormalClosure, "closing the connection"))
+
+	require.NoError(t, keepConnAlive(conn, true, time.Millisecond))
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"nhooyr.io/websocket"
@@ -24,7 +25,7 @@ import (
 )
 
 const (
-	// TODO configure ping request frequency.
+	// pingFrequency default ping request frequency of outbound pooled connections.
 	pingFrequency = 30 * time.Second
 
 	// legacyKeyLen key length.
@@ -36,6 +37,41 @@ type connPool struct {
 	sync.RWMutex
 	packager   transport.Packager
 	msgHandler transport.InboundMessageHandler
+	metrics    poolMetrics
+}
+
+// PoolMetrics is a snapshot of the WebSocket connection pool metrics.
+type PoolMetrics struct {
+	// ActiveConnections number of connections currently being listened to (inbound and return-route outbound).
+	ActiveConnections int64
+	// PooledKeys number of keys linked to a pooled connection.
+	PooledKeys int
+	// MessagesReceived number of messages read from pooled connections.
+	MessagesReceived uint64
+	// PingFailures number of keepalive pings which failed or timed out.
+	PingFailures uint64
+	// Reconnects number of pooled connections successfully re-established.
+	Reconnects uint64
+	// ReconnectFailures number of failed reconnection attempts.
+	ReconnectFailures uint64
+}
+
+type counter uint64
+
+func (c *counter) inc() {
+	atomic.AddUint64((*uint64)(c), 1)
+}
+
+func (c *counter) load() uint64 {
+	return atomic.LoadUint64((*uint64)(c))
+}
+
+type poolMetrics struct {
+	activeConnections int64
+	messagesReceived  counter
+	pingFailures      counter
+	reconnects        counter
+	reconnectFailures counter
 }
 
 // nolint: gochecknoglobals
@@ -76,23 +112,51 @@ func (d *connPool) remove(verKey string) {
 	delete(d.connMap, verKey)
 }
 
-func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
-	verKeys := []string{}
+func (d *connPool) snapshot() PoolMetrics {
+	d.RLock()
+	keys := len(d.connMap)
+	d.RUnlock()
+
+	return PoolMetrics{
+		ActiveConnections: atomic.LoadInt64(&d.metrics.activeConnections),
+		PooledKeys:        keys,
+		MessagesReceived:  d.metrics.messagesReceived.load(),
+		PingFailures:      d.metrics.pingFailures.load(),
+		Reconnects:        d.metrics.reconnects.load(),
+		ReconnectFailures: d.metrics.reconnectFailures.load(),
+	}
+}
+
+// listener reads the messages of the connection until it is closed. It returns the keys which were linked to the
+// connection and a non nil error if the connection was not closed normally.
+func (d *connPool) listener(conn *websocket.Conn, outbound bool, frequency time.Duration) ([]string, error) {
+	atomic.AddInt64(&d.metrics.activeConnections, 1)
+	defer atomic.AddInt64(&d.metrics.activeConnections, -1)
 
-	defer d.close(conn, verKeys)
+	go func() {
+		if err := keepConnAlive(conn, outbound, frequency); err != nil {
+			d.metrics.pingFailures.inc()
 
-	go keepConnAlive(conn, outbound, pingFrequency)
+			logger.Warnf("websocket ping error : %v", err)
+		}
+	}()
 
 	for {
 		_, message, err := conn.Read(context.Background())
 		if err != nil {
+			verKeys := d.close(conn)
+
 			if websocket.CloseStatus(err) != websocket.StatusNormalClosure {
 				logger.Errorf("Error reading request message: %v", err)
+
+				return verKeys, err
 			}
 
-			break
+			return verKeys, nil
 		}
 
+		d.metrics.messagesReceived.inc()
+
 		unpackMsg, err := internal.UnpackMessage(message, d.packager, "ws")
 		if err != nil {
 			logger.Errorf("%w", err)
@@ -152,15 +216,27 @@ func (d *connPool) addKey(unpackMsg *transport.Envelope, trans *decorator.Transp
 	}
 }
 
-func (d *connPool) close(conn *websocket.Conn, verKeys []string) {
+// close closes the connection and removes it from the pool. It returns the keys which were linked to it.
+func (d *connPool) close(conn *websocket.Conn) []string {
 	if err := conn.Close(websocket.StatusNormalClosure,
 		"closing the connection"); websocket.CloseStatus(err) != websocket.StatusNormalClosure {
-		logger.Errorf("connection close error")
+		logger.Debugf("connection close error : %v", err)
 	}
 
-	for _, v := range verKeys {
-		d.remove(v)
+	d.Lock()
+	defer d.Unlock()
+
+	var verKeys []string
+
+	for k, c := range d.connMap {
+		if c == conn {
+			verKeys = append(verKeys, k)
+
+			delete(d.connMap, k)
+		}
 	}
+
+	return verKeys
 }
 
 func (d *connPool) checkKeyAgreementIDs(message []byte) []string {
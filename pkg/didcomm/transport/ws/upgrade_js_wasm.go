@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package ws
 
 import (
+	"crypto/tls"
 	"errors"
 	"net/http"
 	"time"
@@ -31,6 +32,12 @@ func acceptRecipient(pool *connPool, keys []string) bool {
 	return false
 }
 
-func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) {
+func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) error {
 	// TODO make sure connection is alive (conn.Ping() doesn't work with JS/WASM build)
+	return nil
+}
+
+// dialOptions TLS is handled by the browser for JS/WASM targets.
+func dialOptions(_ *tls.Config) *websocket.DialOptions {
+	return nil
 }
@@ -10,6 +10,7 @@ package ws
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
 	"time"
 
@@ -48,24 +49,45 @@ func acceptRecipient(pool *connPool, keys []string) bool {
 
 // keepConnAlive sends the pings the server based on time frequency. The web server, load balancer, network routers
 // between the client and server closes the TCP keepalives connection. This function calls websocket ping request
-// directly to the server and keeps the connection active.
-func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) {
-	if outbound {
-		ticker := time.NewTicker(frequency)
-		done := make(chan struct{})
-
-		for {
-			select {
-			case <-done:
-				return
-			case <-ticker.C:
-				if err := conn.Ping(context.Background()); err != nil {
-					logger.Errorf("websocket ping error : %v", err)
-
-					ticker.Stop()
-					done <- struct{}{}
-				}
+// directly to the server and keeps the connection active. A ping which isn't answered within the frequency closes the
+// connection; an error is returned once the connection can't be pinged anymore.
+func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) error {
+	if !outbound || frequency <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), frequency)
+		err := conn.Ping(ctx)
+
+		cancel()
+
+		if err != nil {
+			if websocket.CloseStatus(err) == websocket.StatusNormalClosure {
+				return nil
 			}
+
+			return err
 		}
 	}
+
+	return nil
+}
+
+func dialOptions(tlsConfig *tls.Config) *websocket.DialOptions {
+	if tlsConfig == nil {
+		return nil
+	}
+
+	return &websocket.DialOptions{
+		HTTPClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+	}
 }
@@ -12,6 +12,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -20,6 +21,8 @@ import (
 const (
 	credentialApplicationPresentationContext = "https://identity.foundation/credential-manifest/application/v1"
 	credentialApplicationPresentationType    = "CredentialApplication"
+	credentialApplicationProperty            = "credential_application"
+	presentationSubmissionProperty           = "presentation_submission"
 )
 
 // CredentialApplication represents a credential_application object as defined in
@@ -95,6 +98,69 @@ func (ca *CredentialApplication) ValidateAgainstCredentialManifest(cm *Credentia
 	return nil
 }
 
+// ValidateCredentialApplication verifies that the given presentation is a Credential Application (as created by the
+// PresentCredentialApplication method) that is valid against the given Credential Manifest. The embedded
+// credential_application object must be valid against the Credential Manifest and, if the Credential Manifest has a
+// Presentation Definition, the presentation_submission must satisfy it. The submitted credentials are returned
+// keyed by input descriptor ID. As with presexch.PresentationDefinition.Match, the options must provide a JSON-LD
+// document loader for parsing the submitted credentials.
+// The proofs of the presentation are not checked here, they are expected to be verified when parsing it.
+func ValidateCredentialApplication(application *verifiable.Presentation, credentialManifest *CredentialManifest,
+	contextLoader ld.DocumentLoader, options ...presexch.MatchOption) (map[string]*verifiable.Credential, error) {
+	if credentialManifest == nil {
+		return nil, errors.New("credential manifest argument cannot be nil")
+	}
+
+	if application == nil {
+		return nil, errors.New("credential application presentation cannot be nil")
+	}
+
+	credentialApplicationRaw, ok := application.CustomFields[credentialApplicationProperty]
+	if !ok {
+		return nil, errors.New("the given presentation does not have an embedded Credential Application")
+	}
+
+	credentialApplicationBytes, err := json.Marshal(credentialApplicationRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the raw Credential Application obtained from the "+
+			"presentation into bytes: %w", err)
+	}
+
+	_, err = UnmarshalAndValidateAgainstCredentialManifest(credentialApplicationBytes, credentialManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if credentialManifest.PresentationDefinition == nil {
+		return map[string]*verifiable.Credential{}, nil
+	}
+
+	// PresentCredentialApplication replaces the Presentation Exchange context, which is expected by the
+	// Presentation Definition matching.
+	submission := *application
+	submission.Context = make([]string, len(application.Context))
+
+	for i := range application.Context {
+		submission.Context[i] = application.Context[i]
+
+		if application.Context[i] == credentialApplicationPresentationContext {
+			submission.Context[i] = presexch.PresentationSubmissionJSONLDContextIRI
+		}
+	}
+
+	if !contains(submission.Type, presexch.PresentationSubmissionJSONLDType) {
+		submission.Type = append(append([]string{}, application.Type...), presexch.PresentationSubmissionJSONLDType)
+	}
+
+	credentials, err := credentialManifest.PresentationDefinition.Match(&submission, contextLoader, options...)
+	if err != nil {
+		return nil, fmt.Errorf("the presentation submission does not satisfy the Credential Manifest's "+
+			"Presentation Definition: %w", err)
+	}
+
+	return credentials, nil
+}
+
 func (ca *CredentialApplication) standardUnmarshal(data []byte) error {
 	// The type alias below is used as to allow the standard json.Unmarshal to be called within a custom unmarshal
 	// function without causing infinite recursion. See https://stackoverflow.com/a/43178272 for more information.
@@ -196,9 +262,12 @@ func WithExistingPresentationForPresentCredentialApplication(
 // on credentialManifest. The WithExistingPresentationForPresentCredentialFulfillment can be used to add the Credential
 // Application data to an existing Presentation object instead. If the
 // "https://identity.foundation/presentation-exchange/submission/v1" context is found, it will be replaced with
-// the "https://identity.foundation/credential-manifest/application/v1" context. Note that any existing proofs are
+// the "https://identity.foundation/credential-manifest/application/v1" context and the PresentationSubmission type
+// it defines will be removed. Note that any existing proofs are
 // not updated. Note also the following assumptions/limitations of this method:
 // 1. The format of all claims in the Presentation Submission are assumed to be ldp_vp and will be set as such.
+//    An existing Presentation that already has a presentation_submission (e.g. one created by
+//    presexch.PresentationDefinition.CreateVP) keeps it as is.
 // 2. The format for the Credential Application object will be set to match the format from the Credential Manifest
 //    exactly. If a caller wants to use a smaller subset of the Credential Manifest's format, then they will have to
 //    set it manually.
@@ -262,7 +331,20 @@ func setCredentialApplicationContext(presentation *verifiable.Presentation) {
 
 	if !newContextSet {
 		presentation.Context = append(presentation.Context, credentialApplicationPresentationContext)
+
+		return
+	}
+
+	// the presentation submission type is defined by the replaced context.
+	types := make([]string, 0, len(presentation.Type))
+
+	for _, t := range presentation.Type {
+		if t != presexch.PresentationSubmissionJSONLDType {
+			types = append(types, t)
+		}
 	}
+
+	presentation.Type = types
 }
 
 func setCustomFields(presentation *verifiable.Presentation, credentialManifest *CredentialManifest) {
@@ -276,12 +358,14 @@ func setCustomFields(presentation *verifiable.Presentation, credentialManifest *
 		presentation.CustomFields = make(map[string]interface{})
 	}
 
-	presentation.CustomFields["credential_application"] = application
+	presentation.CustomFields[credentialApplicationProperty] = application
+
+	_, hasSubmission := presentation.CustomFields[presentationSubmissionProperty]
 
-	if credentialManifest.PresentationDefinition != nil {
+	if credentialManifest.PresentationDefinition != nil && !hasSubmission {
 		submission := makePresentationSubmission(credentialManifest.PresentationDefinition)
 
-		presentation.CustomFields["presentation_submission"] = submission
+		presentation.CustomFields[presentationSubmissionProperty] = submission
 	}
 }
 
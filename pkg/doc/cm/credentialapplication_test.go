@@ -15,7 +15,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 )
 
 const unknownFormatName = "SomeUnknownFormat"
@@ -300,6 +302,18 @@ func TestPresentCredentialApplication(t *testing.T) {
 				" the presentation with a Credential Fulfillment added to it differs from what was expected", testName)
 		}
 	})
+	t.Run("Existing presentation with Presentation Submission type", func(t *testing.T) {
+		credentialManifest := makeCredentialManifestFromBytes(t, credentialManifestDriversLicense)
+
+		existingPresentation := makePresentationFromBytes(t, vpWithPRCardVCUsingPresentationExchangeContext, t.Name())
+		existingPresentation.Type = append(existingPresentation.Type, presexch.PresentationSubmissionJSONLDType)
+
+		presentation, err := cm.PresentCredentialApplication(&credentialManifest,
+			cm.WithExistingPresentationForPresentCredentialApplication(existingPresentation))
+		require.NoError(t, err)
+		require.Equal(t, []string{"VerifiablePresentation", "CredentialApplication"}, presentation.Type)
+		require.NotContains(t, presentation.Context, presexch.PresentationSubmissionJSONLDContextIRI)
+	})
 	t.Run("Nil Credential Manifest argument", func(t *testing.T) {
 		presentation, err := cm.PresentCredentialApplication(nil)
 		require.EqualError(t, err, "credential manifest argument cannot be nil")
@@ -307,6 +321,70 @@ func TestPresentCredentialApplication(t *testing.T) {
 	})
 }
 
+func TestValidateCredentialApplication(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	matchOption := presexch.WithCredentialOptions(verifiable.WithDisabledProofCheck(), verifiable.WithJSONLDValidation(),
+		verifiable.WithJSONLDDocumentLoader(loader))
+
+	t.Run("Success, Credential Manifest has a Presentation Definition", func(t *testing.T) {
+		credentialManifest := makeCredentialManifestFromBytes(t,
+			credentialManifestDriversLicenseWithPresentationDefinition)
+
+		application := makePresentationFromBytes(t,
+			vpWithPRCardVCAndCredentialApplicationAndPresentationSubmission, t.Name())
+
+		credentials, err := cm.ValidateCredentialApplication(application, &credentialManifest, loader, matchOption)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		require.Equal(t, "urn:uvci:af5vshde843jf831j128fj", credentials["prc_input"].ID)
+
+		// the presentation is left as is.
+		require.NotContains(t, application.Context, presexch.PresentationSubmissionJSONLDContextIRI)
+		require.NotContains(t, application.Type, presexch.PresentationSubmissionJSONLDType)
+	})
+	t.Run("Success, Credential Manifest has no Presentation Definition", func(t *testing.T) {
+		credentialManifest := makeCredentialManifestFromBytes(t, credentialManifestDriversLicense)
+
+		application := makePresentationFromBytes(t, vpWithPRCardVCAndCredentialApplication, t.Name())
+
+		credentials, err := cm.ValidateCredentialApplication(application, &credentialManifest, loader, matchOption)
+		require.NoError(t, err)
+		require.Empty(t, credentials)
+	})
+	t.Run("Failures", func(t *testing.T) {
+		credentialManifest := makeCredentialManifestFromBytes(t,
+			credentialManifestDriversLicenseWithPresentationDefinition)
+
+		_, err := cm.ValidateCredentialApplication(
+			makePresentationFromBytes(t, vpWithPRCardVC, t.Name()), nil, loader, matchOption)
+		require.EqualError(t, err, "credential manifest argument cannot be nil")
+
+		_, err = cm.ValidateCredentialApplication(nil, &credentialManifest, loader, matchOption)
+		require.EqualError(t, err, "credential application presentation cannot be nil")
+
+		_, err = cm.ValidateCredentialApplication(makePresentationFromBytes(t, vpWithPRCardVC, t.Name()),
+			&credentialManifest, loader, matchOption)
+		require.EqualError(t, err, "the given presentation does not have an embedded Credential Application")
+
+		otherManifest := makeCredentialManifestFromBytes(t, credentialManifestUniversityDegree)
+
+		_, err = cm.ValidateCredentialApplication(makePresentationFromBytes(t,
+			vpWithPRCardVCAndCredentialApplicationAndPresentationSubmission, t.Name()),
+			&otherManifest, loader, matchOption)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match the given Credential Manifest's ID (university_degree)")
+
+		_, err = cm.ValidateCredentialApplication(makePresentationFromBytes(t,
+			vpMinimalWithCredentialApplicationAndPresentationSubmission, t.Name()),
+			&credentialManifest, loader, matchOption)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the presentation submission does not satisfy the Credential "+
+			"Manifest's Presentation Definition")
+	})
+}
+
 func makeCredentialApplicationIDsTheSame(t *testing.T, presentation1,
 	presentation2 *verifiable.Presentation, testName string) {
 	credentialApplicationFromPresentation1, ok :=
@@ -0,0 +1,305 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	serviceIDPrefix       = "#service-"
+	didCommV2MediaProfile = "didcomm/v2"
+)
+
+// KeyCreator creates the keys of verification methods, it is implemented by kms.KeyManager.
+type KeyCreator interface {
+	CreateAndExportPubKeyBytes(kt kms.KeyType) (string, []byte, error)
+}
+
+// BuilderOption configures the Builder.
+type BuilderOption func(b *Builder)
+
+// WithKeyCreator sets the key manager creating keys added by Builder.AddKey.
+func WithKeyCreator(keyCreator KeyCreator) BuilderOption {
+	return func(b *Builder) {
+		b.keyCreator = keyCreator
+	}
+}
+
+// Builder builds the DID document and validates it against the DID Core data model on Build, so that
+// a broken document fails at its creation instead of at resolution on the peer's side.
+// Methods of Builder can be chained, the first failure is returned by Build.
+type Builder struct {
+	doc        *Doc
+	keyCreator KeyCreator
+	// didCommV2 holds the indexes of DIDComm V2 services getting key agreement keys as recipient keys.
+	didCommV2 []int
+	err       error
+}
+
+// NewBuilder creates a builder of the DID document with the given DID. The DID may be empty for documents
+// passed to the VDR Create which assigns the DID, ids of verification methods and services are relative
+// DID URLs then.
+func NewBuilder(id string, opts ...BuilderOption) *Builder {
+	b := &Builder{doc: &Doc{Context: []string{ContextV1}, ID: id}}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// AddContext adds JSON-LD contexts to the document, the DID context comes first.
+func (b *Builder) AddContext(context ...string) *Builder {
+	b.doc.Context = append(b.doc.Context, context...)
+
+	return b
+}
+
+// AddVerificationMethod adds the verification method to the document and references it from
+// the given verification relationships.
+func (b *Builder) AddVerificationMethod(vm *VerificationMethod, relationships ...VerificationRelationship) *Builder {
+	b.doc.VerificationMethod = append(b.doc.VerificationMethod, *vm)
+
+	return b.AddRelationship(vm.ID, relationships...)
+}
+
+// AddEmbeddedVerificationMethod embeds the verification method into the given verification relationship.
+func (b *Builder) AddEmbeddedVerificationMethod(vm *VerificationMethod,
+	relationship VerificationRelationship) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.err = b.addVerification(NewEmbeddedVerification(vm, relationship))
+
+	return b
+}
+
+// AddKey creates a key of the key type with the key creator and adds it as a verification method with
+// the given id (the KMS key ID as the fragment if empty) referenced from the given verification relationships.
+// Ed25519, BLS12-381 G2 and X25519 keys are added as Ed25519VerificationKey2018, Bls12381G2Key2020
+// and X25519KeyAgreementKey2019 verification methods, ECDSA and NIST ECDH keys as JsonWebKey2020.
+func (b *Builder) AddKey(id string, keyType kms.KeyType, relationships ...VerificationRelationship) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	if b.keyCreator == nil {
+		b.err = errors.New("add key: key creator is required")
+
+		return b
+	}
+
+	vm, err := b.createVerificationMethod(id, keyType)
+	if err != nil {
+		if id == "" {
+			id = string(keyType)
+		}
+
+		b.err = fmt.Errorf("add key %s: %w", id, err)
+
+		return b
+	}
+
+	return b.AddVerificationMethod(vm, relationships...)
+}
+
+// AddRelationship references the verification method of the document from the given verification relationships.
+func (b *Builder) AddRelationship(vmID string, relationships ...VerificationRelationship) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	vm := b.lookupVerificationMethod(vmID)
+	if vm == nil {
+		b.err = fmt.Errorf("%w: verification method %s not found", ErrInvalidDocument, vmID)
+
+		return b
+	}
+
+	for _, relationship := range relationships {
+		if err := b.addVerification(NewReferencedVerification(vm, relationship)); err != nil {
+			b.err = err
+
+			return b
+		}
+	}
+
+	return b
+}
+
+// AddService adds the service to the document, a "#service-N" id is generated if the service has no id.
+func (b *Builder) AddService(svc *Service) *Builder {
+	s := *svc
+
+	if s.ID == "" {
+		s.ID = b.nextID(serviceIDPrefix, func(id string) bool {
+			for i := range b.doc.Service {
+				if b.doc.absoluteID(b.doc.Service[i].ID) == b.doc.absoluteID(id) {
+					return true
+				}
+			}
+
+			return false
+		})
+	}
+
+	b.doc.Service = append(b.doc.Service, s)
+
+	return b
+}
+
+// AddDIDCommV2Service adds DIDComm V2 service with the endpoint and routing keys, accepting didcomm/v2
+// unless accept profiles are given. Key agreement keys of the built document are the recipient keys of the service.
+// See https://identity.foundation/didcomm-messaging/spec/#did-document-service-endpoint.
+func (b *Builder) AddDIDCommV2Service(id, endpoint string, routingKeys []string, accept ...string) *Builder {
+	if len(accept) == 0 {
+		accept = []string{didCommV2MediaProfile}
+	}
+
+	b.didCommV2 = append(b.didCommV2, len(b.doc.Service))
+
+	return b.AddService(&Service{
+		ID:              id,
+		Type:            didCommV2ServiceType,
+		ServiceEndpoint: endpoint,
+		RoutingKeys:     routingKeys,
+		Accept:          accept,
+	})
+}
+
+// Build validates and returns the document. The DID of the document is the controller of verification
+// methods without one.
+func (b *Builder) Build() (*Doc, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if b.doc.ID != "" {
+		b.setControllers()
+	}
+
+	for _, i := range b.didCommV2 {
+		var recipientKeys []string
+
+		for _, ka := range b.doc.KeyAgreement {
+			recipientKeys = append(recipientKeys, ka.VerificationMethod.ID)
+		}
+
+		b.doc.Service[i].RecipientKeys = recipientKeys
+	}
+
+	if err := b.doc.validate(false); err != nil {
+		return nil, err
+	}
+
+	return b.doc, nil
+}
+
+func (b *Builder) setControllers() {
+	setController := func(vm *VerificationMethod) {
+		if vm.Controller == "" {
+			vm.Controller = b.doc.ID
+		}
+	}
+
+	for i := range b.doc.VerificationMethod {
+		setController(&b.doc.VerificationMethod[i])
+	}
+
+	for _, verifications := range [][]Verification{
+		b.doc.Authentication, b.doc.AssertionMethod, b.doc.CapabilityDelegation,
+		b.doc.CapabilityInvocation, b.doc.KeyAgreement,
+	} {
+		for i := range verifications {
+			setController(&verifications[i].VerificationMethod)
+		}
+	}
+}
+
+func (b *Builder) addVerification(v *Verification) error {
+	switch v.Relationship {
+	case VerificationRelationshipGeneral:
+		if v.Embedded {
+			return errors.New("embedded verification method requires a verification relationship")
+		}
+	case Authentication:
+		b.doc.Authentication = append(b.doc.Authentication, *v)
+	case AssertionMethod:
+		b.doc.AssertionMethod = append(b.doc.AssertionMethod, *v)
+	case CapabilityDelegation:
+		b.doc.CapabilityDelegation = append(b.doc.CapabilityDelegation, *v)
+	case CapabilityInvocation:
+		b.doc.CapabilityInvocation = append(b.doc.CapabilityInvocation, *v)
+	case KeyAgreement:
+		b.doc.KeyAgreement = append(b.doc.KeyAgreement, *v)
+	default:
+		return fmt.Errorf("unsupported verification relationship: %d", v.Relationship)
+	}
+
+	return nil
+}
+
+func (b *Builder) createVerificationMethod(id string, keyType kms.KeyType) (*VerificationMethod, error) {
+	kid, pubKeyBytes, err := b.keyCreator.CreateAndExportPubKeyBytes(keyType)
+	if err != nil {
+		return nil, fmt.Errorf("create key: %w", err)
+	}
+
+	if id == "" {
+		id = "#" + kid
+	}
+
+	switch keyType { // nolint:exhaustive
+	case kms.ED25519Type:
+		return NewVerificationMethodFromBytes(id, ed25519VerificationKey2018, "", pubKeyBytes), nil
+	case kms.BLS12381G2Type:
+		return NewVerificationMethodFromBytes(id, bls12381G2Key2020, "", pubKeyBytes), nil
+	case kms.X25519ECDHKWType:
+		key := &crypto.PublicKey{}
+
+		if err = json.Unmarshal(pubKeyBytes, key); err != nil {
+			return nil, fmt.Errorf("unmarshal X25519 key: %w", err)
+		}
+
+		return NewVerificationMethodFromBytes(id, x25519KeyAgreementKey2019, "", key.X), nil
+	default:
+		j, err := jwksupport.PubKeyBytesToJWK(pubKeyBytes, keyType)
+		if err != nil {
+			return nil, fmt.Errorf("convert public key to JWK: %w", err)
+		}
+
+		return NewVerificationMethodFromJWK(id, jsonWebKey2020, "", j)
+	}
+}
+
+func (b *Builder) lookupVerificationMethod(id string) *VerificationMethod {
+	for i := range b.doc.VerificationMethod {
+		if b.doc.absoluteID(b.doc.VerificationMethod[i].ID) == b.doc.absoluteID(id) {
+			return &b.doc.VerificationMethod[i]
+		}
+	}
+
+	return nil
+}
+
+func (b *Builder) nextID(prefix string, exists func(id string) bool) string {
+	for i := 1; ; i++ {
+		id := fmt.Sprintf("%s%d", prefix, i)
+		if !exists(id) {
+			return id
+		}
+	}
+}
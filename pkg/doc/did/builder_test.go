@@ -0,0 +1,162 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Run("test build document with keys and services", func(t *testing.T) {
+		keyCreator := newKeyCreator(t)
+
+		doc, err := NewBuilder("did:example:123", WithKeyCreator(keyCreator)).
+			AddKey("#key-1", kms.ED25519Type, Authentication, AssertionMethod).
+			AddKey("", kms.ECDSAP256TypeIEEEP1363, CapabilityInvocation, CapabilityDelegation).
+			AddKey("#agreement", kms.X25519ECDHKWType, KeyAgreement).
+			AddKey("", kms.NISTP256ECDHKWType, KeyAgreement).
+			AddRelationship("did:example:123#key-1", CapabilityInvocation).
+			AddDIDCommV2Service("", "https://agent.example.com", []string{"did:example:mediator#key-1"}).
+			AddService(&Service{Type: "LinkedDomains", ServiceEndpoint: "https://example.com"}).
+			Build()
+		require.NoError(t, err)
+
+		require.Equal(t, "did:example:123", doc.ID)
+		require.Equal(t, []string{ContextV1}, doc.Context)
+		require.Len(t, doc.VerificationMethod, 4)
+
+		for i := range doc.VerificationMethod {
+			require.Equal(t, "did:example:123", doc.VerificationMethod[i].Controller)
+		}
+
+		// verification methods without id are identified by KMS key IDs
+		kmsKeyID := func(vm *VerificationMethod) string {
+			require.True(t, strings.HasPrefix(vm.ID, "#"))
+
+			_, err = keyCreator.(kms.KeyManager).Get(vm.ID[1:])
+			require.NoError(t, err)
+
+			return vm.ID
+		}
+
+		require.Equal(t, "#key-1", doc.VerificationMethod[0].ID)
+		require.Equal(t, ed25519VerificationKey2018, doc.VerificationMethod[0].Type)
+		require.NotEmpty(t, kmsKeyID(&doc.VerificationMethod[1]))
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[1].Type)
+		require.Equal(t, "P-256", doc.VerificationMethod[1].JSONWebKey().Crv)
+		require.Equal(t, "#agreement", doc.VerificationMethod[2].ID)
+		require.Equal(t, x25519KeyAgreementKey2019, doc.VerificationMethod[2].Type)
+		require.Len(t, doc.VerificationMethod[2].Value, x25519KeySize)
+		agreementKeyID := kmsKeyID(&doc.VerificationMethod[3])
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[3].Type)
+
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.CapabilityDelegation, 1)
+		require.Len(t, doc.CapabilityInvocation, 2)
+		require.Equal(t, "#key-1", doc.CapabilityInvocation[1].VerificationMethod.ID)
+		require.Len(t, doc.KeyAgreement, 2)
+		require.Equal(t, "did:example:123", doc.KeyAgreement[1].VerificationMethod.Controller)
+
+		require.Len(t, doc.Service, 2)
+		require.Equal(t, "#service-1", doc.Service[0].ID)
+		require.Equal(t, didCommV2ServiceType, doc.Service[0].Type)
+		require.Equal(t, []string{"#agreement", agreementKeyID}, doc.Service[0].RecipientKeys)
+		require.Equal(t, []string{"did:example:mediator#key-1"}, doc.Service[0].RoutingKeys)
+		require.Equal(t, []string{didCommV2MediaProfile}, doc.Service[0].Accept)
+		require.Equal(t, "#service-2", doc.Service[1].ID)
+
+		// the built document survives serialization
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		parsed, err := ParseDocument(docBytes)
+		require.NoError(t, err)
+		require.NoError(t, parsed.Validate())
+		require.Len(t, parsed.KeyAgreement, 2)
+	})
+
+	t.Run("test build document without DID", func(t *testing.T) {
+		vm := newValidDoc(t).VerificationMethod[0]
+
+		doc, err := NewBuilder("").
+			AddContext("https://w3id.org/security/suites/ed25519-2018/v1").
+			AddVerificationMethod(&vm, Authentication).
+			AddEmbeddedVerificationMethod(NewVerificationMethodFromBytes("#key-2", ed25519VerificationKey2018, "",
+				vm.Value), AssertionMethod).
+			Build()
+		require.NoError(t, err)
+		require.Empty(t, doc.ID)
+		require.Empty(t, doc.AssertionMethod[0].VerificationMethod.Controller)
+		require.Len(t, doc.Context, 2)
+		require.True(t, doc.AssertionMethod[0].Embedded)
+
+		require.True(t, errors.Is(doc.Validate(), ErrInvalidDocument))
+	})
+
+	t.Run("test build failures", func(t *testing.T) {
+		_, err := NewBuilder("did:example:123").AddKey("", kms.ED25519Type).Build()
+		require.EqualError(t, err, "add key: key creator is required")
+
+		_, err = NewBuilder("did:example:123", WithKeyCreator(&mockkms.KeyManager{
+			CrAndExportPubKeyErr: errors.New("kms error"),
+		})).AddKey("", kms.ED25519Type).Build()
+		require.EqualError(t, err, "add key ED25519: create key: kms error")
+
+		keyCreator := &mockkms.KeyManager{CrAndExportPubKeyValue: []byte("invalid")}
+
+		_, err = NewBuilder("did:example:123", WithKeyCreator(keyCreator)).
+			AddKey("", kms.X25519ECDHKWType).Build()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "add key X25519ECDHKW: unmarshal X25519 key")
+
+		_, err = NewBuilder("did:example:123", WithKeyCreator(keyCreator)).
+			AddKey("", kms.ECDSAP256TypeDER).Build()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "add key ECDSAP256DER: convert public key to JWK")
+
+		_, err = NewBuilder("did:example:123").AddRelationship("#key-1", Authentication).Build()
+		require.True(t, errors.Is(err, ErrInvalidDocument))
+		require.EqualError(t, err, "invalid DID document: verification method #key-1 not found")
+
+		vm := newValidDoc(t).VerificationMethod[0]
+
+		_, err = NewBuilder("did:example:123").AddVerificationMethod(&vm, VerificationRelationship(100)).Build()
+		require.EqualError(t, err, "unsupported verification relationship: 100")
+
+		_, err = NewBuilder("did:example:123").
+			AddEmbeddedVerificationMethod(&vm, VerificationRelationshipGeneral).Build()
+		require.EqualError(t, err, "embedded verification method requires a verification relationship")
+
+		_, err = NewBuilder("did:example:123").AddVerificationMethod(&vm, KeyAgreement).Build()
+		require.True(t, errors.Is(err, ErrInvalidDocument))
+		require.Contains(t, err.Error(), "keyAgreement: Ed25519VerificationKey2018 key #key-1")
+
+		_, err = NewBuilder("did:example:123").AddDIDCommV2Service("", "", nil).Build()
+		require.EqualError(t, err, "invalid DID document: service #service-1: serviceEndpoint is required")
+	})
+}
+
+func newKeyCreator(t *testing.T) KeyCreator {
+	t.Helper()
+
+	keyManager, err := localkms.New("local-lock://primary/test/",
+		mockkms.NewProviderForKMS(mem.NewProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	return keyManager
+}
@@ -0,0 +1,256 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DID URL query parameters defined by DID Core (https://www.w3.org/TR/did-core/#did-parameters).
+const (
+	// ServiceQuery selects a service from the DID document by its ID.
+	ServiceQuery = "service"
+	// RelativeRefQuery is a relative URI reference resolved against the endpoint of the selected service.
+	RelativeRefQuery = "relativeRef"
+	// VersionIDQuery selects a specific version of the DID document.
+	VersionIDQuery = "versionId"
+	// VersionTimeQuery selects the version of the DID document which was valid at the given time.
+	VersionTimeQuery = "versionTime"
+)
+
+// Content types of DID URL dereferencing results.
+const (
+	// DIDLDJSONContentType is a content type of DID document and its parts.
+	DIDLDJSONContentType = "application/did+ld+json"
+	// URIListContentType is a content type of service endpoint URL.
+	URIListContentType = "text/uri-list"
+)
+
+var (
+	// ErrInvalidDIDURL is returned when DID URL can't be dereferenced against the resolved DID document.
+	ErrInvalidDIDURL = errors.New("invalid DID URL")
+	// ErrContentNotFound is returned when the DID document has no content the DID URL points to.
+	ErrContentNotFound = errors.New("DID URL content not found")
+)
+
+// DereferenceResult is the result of DID URL dereferencing
+// (https://w3c-ccg.github.io/did-resolution/#dereferencing).
+// Only one of DIDDocument, VerificationMethod, Service and ServiceEndpoint is set.
+type DereferenceResult struct {
+	// DIDDocument is set when DID URL has neither fragment nor service query.
+	DIDDocument *Doc
+	// VerificationMethod is set when fragment of DID URL selects a verification method.
+	VerificationMethod *VerificationMethod
+	// Service is set when fragment of DID URL selects a service.
+	Service *Service
+	// ServiceEndpoint is set when DID URL selects a service by the service query.
+	ServiceEndpoint string
+	// DereferencingMetadata is metadata about the dereferencing process.
+	DereferencingMetadata *DereferencingMetadata
+	// ContentMetadata is metadata of the DID document the content was taken from.
+	ContentMetadata *DocumentMetadata
+}
+
+// DereferencingMetadata is metadata about the DID URL dereferencing process.
+type DereferencingMetadata struct {
+	// ContentType is the media type of the dereferenced content.
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// HasVersion tells if DID URL selects a specific version of the DID document.
+func (u *DIDURL) HasVersion() bool {
+	return len(u.Queries[VersionIDQuery]) > 0 || len(u.Queries[VersionTimeQuery]) > 0
+}
+
+// Dereference selects the part of the resolved DID document the DID URL points to.
+// Versions of the DID document are not selected here, the caller must resolve the DID document
+// of the version requested by DID URL.
+func (docResolution *DocResolution) Dereference(didURL *DIDURL) (*DereferenceResult, error) {
+	doc := docResolution.DIDDocument
+	if doc == nil {
+		return nil, fmt.Errorf("dereference %s: %w", didURL.DID.String(), ErrDIDDocumentNotExist)
+	}
+
+	ids := docResolution.identifiers(didURL.DID.String())
+
+	if didURL.Path != "" {
+		return nil, fmt.Errorf("dereference path %s: %w", didURL.Path, ErrContentNotFound)
+	}
+
+	if serviceID := firstQuery(didURL, ServiceQuery); serviceID != "" {
+		endpoint, err := dereferenceServiceEndpoint(doc, ids, serviceID, firstQuery(didURL, RelativeRefQuery))
+		if err != nil {
+			return nil, err
+		}
+
+		if didURL.Fragment != "" {
+			endpoint += "#" + didURL.Fragment
+		}
+
+		return &DereferenceResult{
+			ServiceEndpoint:       endpoint,
+			DereferencingMetadata: &DereferencingMetadata{ContentType: URIListContentType},
+			ContentMetadata:       docResolution.DocumentMetadata,
+		}, nil
+	}
+
+	result := &DereferenceResult{
+		DereferencingMetadata: &DereferencingMetadata{ContentType: DIDLDJSONContentType},
+		ContentMetadata:       docResolution.DocumentMetadata,
+	}
+
+	if didURL.Fragment == "" {
+		result.DIDDocument = doc
+
+		return result, nil
+	}
+
+	if vm, ok := lookupVerificationMethod(doc, ids, didURL.Fragment); ok {
+		result.VerificationMethod = vm
+
+		return result, nil
+	}
+
+	if svc, ok := lookupServiceByID(doc, ids, didURL.Fragment); ok {
+		result.Service = svc
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("dereference fragment %s: %w", didURL.Fragment, ErrContentNotFound)
+}
+
+// Content returns the dereferenced content serialized in the media type of DereferencingMetadata.ContentType:
+// JSON of the DID document, verification method or service, or the service endpoint URL.
+func (r *DereferenceResult) Content() ([]byte, error) {
+	switch {
+	case r.DIDDocument != nil:
+		return r.DIDDocument.JSONBytes()
+	case r.VerificationMethod != nil:
+		rawVM, err := populateRawVerificationMethod(ContextV1, "", "", r.VerificationMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(rawVM)
+	case r.Service != nil:
+		return json.Marshal(populateRawServices([]Service{*r.Service}, "", "")[0])
+	default:
+		return []byte(r.ServiceEndpoint), nil
+	}
+}
+
+// identifiers returns all identifiers the DID document is known by.
+func (docResolution *DocResolution) identifiers(requestedDID string) map[string]bool {
+	ids := map[string]bool{
+		"":                           true, // relative DID URLs
+		requestedDID:                 true,
+		docResolution.DIDDocument.ID: true,
+	}
+
+	if md := docResolution.DocumentMetadata; md != nil {
+		if md.CanonicalID != "" {
+			ids[md.CanonicalID] = true
+		}
+
+		for _, id := range md.EquivalentID {
+			ids[id] = true
+		}
+	}
+
+	return ids
+}
+
+func firstQuery(didURL *DIDURL, name string) string {
+	if values := didURL.Queries[name]; len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// matchesFragment tells if id is a DID URL with the given fragment of one of the DID document identifiers.
+func matchesFragment(id string, ids map[string]bool, fragment string) bool {
+	i := strings.Index(id, "#")
+	if i < 0 {
+		return false
+	}
+
+	return id[i+1:] == fragment && ids[id[:i]]
+}
+
+func lookupVerificationMethod(doc *Doc, ids map[string]bool, fragment string) (*VerificationMethod, bool) {
+	for i := range doc.VerificationMethod {
+		if matchesFragment(doc.VerificationMethod[i].ID, ids, fragment) {
+			return &doc.VerificationMethod[i], true
+		}
+	}
+
+	// verification methods embedded into verification relationships.
+	for _, verifications := range doc.VerificationMethods() {
+		for i := range verifications {
+			if matchesFragment(verifications[i].VerificationMethod.ID, ids, fragment) {
+				return &verifications[i].VerificationMethod, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func lookupServiceByID(doc *Doc, ids map[string]bool, fragment string) (*Service, bool) {
+	for i := range doc.Service {
+		if matchesFragment(doc.Service[i].ID, ids, fragment) {
+			return &doc.Service[i], true
+		}
+	}
+
+	return nil, false
+}
+
+func dereferenceServiceEndpoint(doc *Doc, ids map[string]bool, serviceID, relativeRef string) (string, error) {
+	svc, ok := lookupServiceByID(doc, ids, serviceID)
+	if !ok {
+		// service IDs are not required to be DID URLs.
+		for i := range doc.Service {
+			if doc.Service[i].ID == serviceID {
+				svc, ok = &doc.Service[i], true
+
+				break
+			}
+		}
+	}
+
+	if !ok {
+		return "", fmt.Errorf("dereference service %s: %w", serviceID, ErrContentNotFound)
+	}
+
+	if svc.ServiceEndpoint == "" {
+		return "", fmt.Errorf("dereference service %s: service endpoint is not an URL: %w",
+			serviceID, ErrContentNotFound)
+	}
+
+	if relativeRef == "" {
+		return svc.ServiceEndpoint, nil
+	}
+
+	endpoint, err := url.Parse(svc.ServiceEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parse service endpoint %s: %w", svc.ServiceEndpoint, err)
+	}
+
+	ref, err := url.Parse(relativeRef)
+	if err != nil || ref.IsAbs() {
+		return "", fmt.Errorf("relativeRef %s must be a relative URI reference: %w", relativeRef, ErrInvalidDIDURL)
+	}
+
+	return endpoint.ResolveReference(ref).String(), nil
+}
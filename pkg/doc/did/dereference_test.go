@@ -0,0 +1,187 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocResolution_Dereference(t *testing.T) {
+	const didID = "did:example:123456789abcdefghi"
+
+	doc := &Doc{
+		ID: didID,
+		VerificationMethod: []VerificationMethod{
+			{ID: didID + "#key-1", Type: "Ed25519VerificationKey2018", Controller: didID, Value: []byte("key-1")},
+			{ID: "#key-2", Type: "Ed25519VerificationKey2018", Controller: didID, Value: []byte("key-2")},
+		},
+		KeyAgreement: []Verification{{
+			VerificationMethod: VerificationMethod{
+				ID: didID + "#key-agreement", Type: "X25519KeyAgreementKey2019", Value: []byte("key-agreement"),
+			},
+			Relationship: KeyAgreement,
+			Embedded:     true,
+		}},
+		Service: []Service{
+			{ID: didID + "#agent", Type: "AgentService", ServiceEndpoint: "https://agent.example.com/8377464"},
+			{ID: "files", Type: "Files", ServiceEndpoint: "https://example.com/files/"},
+			{ID: didID + "#no-endpoint", Type: "Empty"},
+		},
+	}
+
+	docResolution := &DocResolution{
+		DIDDocument:      doc,
+		DocumentMetadata: &DocumentMetadata{CanonicalID: "did:example:canonical"},
+	}
+
+	dereference := func(t *testing.T, didURL string) (*DereferenceResult, error) {
+		t.Helper()
+
+		parsed, err := ParseDIDURL(didURL)
+		require.NoError(t, err)
+
+		return docResolution.Dereference(parsed)
+	}
+
+	t.Run("DID document", func(t *testing.T) {
+		result, err := dereference(t, didID)
+		require.NoError(t, err)
+		require.Equal(t, doc, result.DIDDocument)
+		require.Nil(t, result.VerificationMethod)
+		require.Equal(t, DIDLDJSONContentType, result.DereferencingMetadata.ContentType)
+		require.Equal(t, docResolution.DocumentMetadata, result.ContentMetadata)
+	})
+
+	t.Run("verification method", func(t *testing.T) {
+		result, err := dereference(t, didID+"#key-1")
+		require.NoError(t, err)
+		require.Equal(t, &doc.VerificationMethod[0], result.VerificationMethod)
+		require.Nil(t, result.DIDDocument)
+
+		result, err = dereference(t, didID+"#key-2")
+		require.NoError(t, err)
+		require.Equal(t, []byte("key-2"), result.VerificationMethod.Value)
+
+		result, err = dereference(t, didID+"#key-agreement")
+		require.NoError(t, err)
+		require.Equal(t, []byte("key-agreement"), result.VerificationMethod.Value)
+	})
+
+	t.Run("verification method by canonical ID", func(t *testing.T) {
+		docResolution.DIDDocument.VerificationMethod[0].ID = "did:example:canonical#key-1"
+		defer func() { docResolution.DIDDocument.VerificationMethod[0].ID = didID + "#key-1" }()
+
+		result, err := dereference(t, didID+"#key-1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("key-1"), result.VerificationMethod.Value)
+	})
+
+	t.Run("service by fragment", func(t *testing.T) {
+		result, err := dereference(t, didID+"#agent")
+		require.NoError(t, err)
+		require.Equal(t, &doc.Service[0], result.Service)
+	})
+
+	t.Run("service endpoint", func(t *testing.T) {
+		result, err := dereference(t, didID+"?service=agent")
+		require.NoError(t, err)
+		require.Equal(t, "https://agent.example.com/8377464", result.ServiceEndpoint)
+		require.Equal(t, URIListContentType, result.DereferencingMetadata.ContentType)
+
+		result, err = dereference(t, didID+"?service=files&relativeRef=%2Fresume.pdf#page-2")
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/resume.pdf#page-2", result.ServiceEndpoint)
+
+		result, err = dereference(t, didID+"?service=files&relativeRef=docs%2Fresume.pdf")
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/files/docs/resume.pdf", result.ServiceEndpoint)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		for _, didURL := range []string{
+			didID + "#key-3",
+			didID + "?service=unknown",
+			didID + "?service=no-endpoint",
+			didID + "/path",
+		} {
+			_, err := dereference(t, didURL)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, ErrContentNotFound), didURL)
+		}
+	})
+
+	t.Run("absolute relativeRef", func(t *testing.T) {
+		_, err := dereference(t, didID+"?service=files&relativeRef=https%3A%2F%2Fevil.example.com")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrInvalidDIDURL))
+	})
+
+	t.Run("no DID document", func(t *testing.T) {
+		parsed, err := ParseDIDURL(didID)
+		require.NoError(t, err)
+
+		_, err = (&DocResolution{}).Dereference(parsed)
+		require.True(t, errors.Is(err, ErrDIDDocumentNotExist))
+	})
+}
+
+func TestDereferenceResult_Content(t *testing.T) {
+	const didID = "did:example:123"
+
+	vm := NewVerificationMethodFromBytes(didID+"#key-1", "Ed25519VerificationKey2018", didID, []byte("key-1"))
+	svc := &Service{ID: didID + "#agent", Type: "AgentService", ServiceEndpoint: "https://agent.example.com"}
+
+	t.Run("DID document", func(t *testing.T) {
+		content, err := (&DereferenceResult{DIDDocument: &Doc{Context: []string{ContextV1}, ID: didID}}).Content()
+		require.NoError(t, err)
+
+		doc, err := ParseDocument(content)
+		require.NoError(t, err)
+		require.Equal(t, didID, doc.ID)
+	})
+
+	t.Run("verification method", func(t *testing.T) {
+		content, err := (&DereferenceResult{VerificationMethod: vm}).Content()
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"did:example:123#key-1","type":"Ed25519VerificationKey2018",`+
+			`"controller":"did:example:123","publicKeyBase58":"`+base58.Encode([]byte("key-1"))+`"}`, string(content))
+	})
+
+	t.Run("service", func(t *testing.T) {
+		content, err := (&DereferenceResult{Service: svc}).Content()
+		require.NoError(t, err)
+
+		raw := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(content, &raw))
+		require.Equal(t, svc.ID, raw["id"])
+		require.Equal(t, svc.ServiceEndpoint, raw["serviceEndpoint"])
+	})
+
+	t.Run("service endpoint", func(t *testing.T) {
+		content, err := (&DereferenceResult{ServiceEndpoint: "https://agent.example.com"}).Content()
+		require.NoError(t, err)
+		require.Equal(t, "https://agent.example.com", string(content))
+	})
+}
+
+func TestDIDURL_HasVersion(t *testing.T) {
+	for didURL, hasVersion := range map[string]bool{
+		"did:example:123":                                      false,
+		"did:example:123?versionId=1":                          true,
+		"did:example:123?versionTime=2021-05-10T17:00:00Z":     true,
+		"did:example:123?service=agent&relativeRef=%2Fpath#ab": false,
+	} {
+		parsed, err := ParseDIDURL(didURL)
+		require.NoError(t, err)
+		require.Equal(t, hasVersion, parsed.HasVersion(), didURL)
+	}
+}
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 package did
 
 import (
+	"bytes"
+	"crypto/ed25519"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -18,6 +20,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/multiformats/go-multibase"
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
@@ -55,8 +58,17 @@ const (
 	jsonldPublicKeyHex    = "publicKeyHex"
 	jsonldPublicKeyPem    = "publicKeyPem"
 	jsonldPublicKeyjwk    = "publicKeyJwk"
+
+	jsonldPublicKeyMultibase = "publicKeyMultibase"
+
+	// Ed25519VerificationKey2020 verification method type, its public key is encoded as multibase
+	// value of multicodec 'ed25519-pub' prefixed key bytes.
+	Ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
 )
 
+// ed25519PubKeyMultiCodec is the varint encoded 'ed25519-pub' multicodec prefix of Ed25519VerificationKey2020 keys.
+var ed25519PubKeyMultiCodec = []byte{0xed, 0x01} //nolint:gochecknoglobals
+
 var (
 	schemaLoaderV1     = gojsonschema.NewStringLoader(schemaV1)     //nolint:gochecknoglobals
 	schemaLoaderV011   = gojsonschema.NewStringLoader(schemaV011)   //nolint:gochecknoglobals
@@ -211,6 +223,14 @@ type DocumentMetadata struct {
 	CanonicalID string `json:"canonicalId,omitempty"`
 	// EquivalentID is equivalent ID array.
 	EquivalentID []string `json:"equivalentId,omitempty"`
+	// VersionID is version of the resolved DID document.
+	VersionID string `json:"versionId,omitempty"`
+	// Created is the time of the DID create operation.
+	Created *time.Time `json:"created,omitempty"`
+	// Updated is the time of the last update of the resolved DID document.
+	Updated *time.Time `json:"updated,omitempty"`
+	// NextUpdate is the time of the next update of the resolved DID document, if known.
+	NextUpdate *time.Time `json:"nextUpdate,omitempty"`
 	// Method is used for method metadata within did document metadata.
 	Method *MethodMetadata `json:"method,omitempty"`
 }
@@ -285,6 +305,7 @@ type VerificationMethod struct {
 
 	jsonWebKey  *jwk.JWK
 	relativeURL bool
+	multibase   bool
 }
 
 // NewVerificationMethodFromBytes creates a new VerificationMethod based on raw public key bytes.
@@ -300,6 +321,7 @@ func NewVerificationMethodFromBytes(id, keyType, controller string, value []byte
 		Controller:  controller,
 		Value:       value,
 		relativeURL: relativeURL,
+		multibase:   keyType == Ed25519VerificationKey2020,
 	}
 }
 
@@ -825,6 +847,10 @@ func decodeVM(vm *VerificationMethod, rawPK map[string]interface{}) error {
 		return nil
 	}
 
+	if stringEntry(rawPK[jsonldPublicKeyMultibase]) != "" {
+		return decodeVMMultibase(vm, stringEntry(rawPK[jsonldPublicKeyMultibase]))
+	}
+
 	if stringEntry(rawPK[jsonldPublicKeyHex]) != "" {
 		value, err := hex.DecodeString(stringEntry(rawPK[jsonldPublicKeyHex]))
 		if err != nil {
@@ -854,6 +880,41 @@ func decodeVM(vm *VerificationMethod, rawPK map[string]interface{}) error {
 	return errors.New("public key encoding not supported")
 }
 
+func decodeVMMultibase(vm *VerificationMethod, publicKeyMultibase string) error {
+	_, value, err := multibase.Decode(publicKeyMultibase)
+	if err != nil {
+		return fmt.Errorf("decode public key multibase failed: %w", err)
+	}
+
+	// Ed25519VerificationKey2020 keys are prefixed with the 'ed25519-pub' multicodec,
+	// although early implementations put raw key bytes only.
+	if vm.Type == Ed25519VerificationKey2020 && len(value) == len(ed25519PubKeyMultiCodec)+ed25519.PublicKeySize {
+		if !bytes.HasPrefix(value, ed25519PubKeyMultiCodec) {
+			return errors.New("invalid multicodec of Ed25519VerificationKey2020 public key")
+		}
+
+		value = value[len(ed25519PubKeyMultiCodec):]
+	}
+
+	vm.Value = value
+	vm.multibase = true
+
+	return nil
+}
+
+func encodeVMMultibase(vm *VerificationMethod) string {
+	value := vm.Value
+
+	if vm.Type == Ed25519VerificationKey2020 {
+		value = append(append([]byte{}, ed25519PubKeyMultiCodec...), vm.Value...)
+	}
+
+	// encoding never fails for a known multibase encoding.
+	s, _ := multibase.Encode(multibase.Base58BTC, value) //nolint:errcheck
+
+	return s
+}
+
 func decodeVMJwk(jwkMap map[string]interface{}, vm *VerificationMethod) error {
 	jwkBytes, err := json.Marshal(jwkMap)
 	if err != nil {
@@ -1314,6 +1375,8 @@ func populateRawVerificationMethod(context, didID, baseURI string,
 		}
 
 		rawVM[jsonldPublicKeyjwk] = json.RawMessage(jwkBytes)
+	} else if vm.Value != nil && vm.multibase {
+		rawVM[jsonldPublicKeyMultibase] = encodeVMMultibase(vm)
 	} else if vm.Value != nil {
 		rawVM[jsonldPublicKeyBase58] = base58.Encode(vm.Value)
 	}
@@ -479,10 +479,10 @@ func TestPublicKeys(t *testing.T) {
 
 			if len(raw.PublicKey) != 0 {
 				delete(raw.PublicKey[1], jsonldPublicKeyPem)
-				raw.PublicKey[1]["publicKeyMultibase"] = wrongDataMsg
+				raw.PublicKey[1]["publicKeyUnknown"] = wrongDataMsg
 			} else {
 				delete(raw.VerificationMethod[1], jsonldPublicKeyPem)
-				raw.VerificationMethod[1]["publicKeyMultibase"] = wrongDataMsg
+				raw.VerificationMethod[1]["publicKeyUnknown"] = wrongDataMsg
 			}
 
 			bytes, err := json.Marshal(raw)
@@ -1060,6 +1060,81 @@ func TestNewPublicKeyFromJWK(t *testing.T) {
 	require.Nil(t, signingKey)
 }
 
+func TestPublicKeyMultibase(t *testing.T) {
+	// test vector key pair of Ed25519Signature2020 spec (https://w3id.org/security/suites/ed25519-2020/v1).
+	const (
+		didKey             = "did:key:z6MkrJVnaZkeFzdQyMZu1cgjg7k1pZZ6pvBQ7XJPt4swbTQ2"
+		publicKeyMultibase = "z6MkrJVnaZkeFzdQyMZu1cgjg7k1pZZ6pvBQ7XJPt4swbTQ2"
+		publicKeyHex       = "b00d8d938e7f773d51565aad36a623f5344f7f5d1960f9cf3e8e12620ea2810f"
+	)
+
+	pubKey, err := hex.DecodeString(publicKeyHex)
+	require.NoError(t, err)
+
+	docTemplate := `{
+  "@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/ed25519-2020/v1"],
+  "id": "%[1]s",
+  "verificationMethod": [{
+    "id": "%[1]s#%[2]s",
+    "type": "%[3]s",
+    "controller": "%[1]s",
+    "publicKeyMultibase": "%[2]s"
+  }]
+}`
+
+	t.Run("Ed25519VerificationKey2020", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(fmt.Sprintf(docTemplate, didKey, publicKeyMultibase,
+			"Ed25519VerificationKey2020")))
+		require.NoError(t, err)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, pubKey, doc.VerificationMethod[0].Value)
+		require.Equal(t, *NewVerificationMethodFromBytes(didKey+"#"+publicKeyMultibase,
+			Ed25519VerificationKey2020, didKey, pubKey), doc.VerificationMethod[0])
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		var raw rawDoc
+		require.NoError(t, json.Unmarshal(docBytes, &raw))
+		require.Equal(t, publicKeyMultibase, raw.VerificationMethod[0]["publicKeyMultibase"])
+		require.NotContains(t, raw.VerificationMethod[0], "publicKeyBase58")
+	})
+
+	t.Run("raw Ed25519VerificationKey2020 key bytes", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(fmt.Sprintf(docTemplate, didKey, "z"+base58.Encode(pubKey),
+			"Ed25519VerificationKey2020")))
+		require.NoError(t, err)
+		require.Equal(t, pubKey, doc.VerificationMethod[0].Value)
+	})
+
+	t.Run("other key type", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(fmt.Sprintf(docTemplate, didKey, "z"+base58.Encode(pubKey),
+			"Multikey")))
+		require.NoError(t, err)
+		require.Equal(t, pubKey, doc.VerificationMethod[0].Value)
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		var raw rawDoc
+		require.NoError(t, json.Unmarshal(docBytes, &raw))
+		require.Equal(t, "z"+base58.Encode(pubKey), raw.VerificationMethod[0]["publicKeyMultibase"])
+	})
+
+	t.Run("invalid multicodec", func(t *testing.T) {
+		_, err := ParseDocument([]byte(fmt.Sprintf(docTemplate, didKey,
+			"z"+base58.Encode(append([]byte{0xec, 0x01}, pubKey...)), "Ed25519VerificationKey2020")))
+		require.EqualError(t, err, "populate verification method failed: "+
+			"invalid multicodec of Ed25519VerificationKey2020 public key")
+	})
+
+	t.Run("invalid multibase", func(t *testing.T) {
+		_, err := ParseDocument([]byte(fmt.Sprintf(docTemplate, didKey, "?invalid", "Ed25519VerificationKey2020")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode public key multibase failed")
+	})
+}
+
 func TestJSONWebKey(t *testing.T) {
 	const didContext = "https://w3id.org/did/v1"
 
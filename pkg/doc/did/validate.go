@@ -0,0 +1,315 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Verification method types with known key material.
+const (
+	ed25519VerificationKey2018        = "Ed25519VerificationKey2018"
+	x25519KeyAgreementKey2019         = "X25519KeyAgreementKey2019"
+	x25519KeyAgreementKey2020         = "X25519KeyAgreementKey2020"
+	bls12381G2Key2020                 = "Bls12381G2Key2020"
+	ecdsaSecp256k1VerificationKey2019 = "EcdsaSecp256k1VerificationKey2019"
+	jsonWebKey2020                    = "JsonWebKey2020"
+	didCommV2ServiceType              = "DIDCommMessaging"
+	x25519Curve                       = "X25519"
+	ed25519KeySize                    = 32
+	x25519KeySize                     = 32
+	bls12381G2KeySize                 = 96
+	secp256k1CompressedKeySize        = 33
+	secp256k1UncompressedKeySize      = 65
+)
+
+// ErrInvalidDocument is returned when the DID document doesn't conform to the DID Core data model
+// (https://www.w3.org/TR/did-core/#core-properties).
+var ErrInvalidDocument = errors.New("invalid DID document")
+
+// keyTypes defines the curve of JWK and the size of raw public key of verification method types.
+//
+//nolint:gochecknoglobals
+var keyTypes = map[string]struct {
+	curve string
+	sizes []int
+}{
+	ed25519VerificationKey2018: {curve: "Ed25519", sizes: []int{ed25519KeySize}},
+	Ed25519VerificationKey2020: {curve: "Ed25519", sizes: []int{ed25519KeySize}},
+	x25519KeyAgreementKey2019:  {curve: x25519Curve, sizes: []int{x25519KeySize}},
+	x25519KeyAgreementKey2020:  {curve: x25519Curve, sizes: []int{x25519KeySize}},
+	bls12381G2Key2020:          {curve: "BLS12381_G2", sizes: []int{bls12381G2KeySize}},
+	ecdsaSecp256k1VerificationKey2019: {
+		curve: "secp256k1",
+		sizes: []int{secp256k1CompressedKeySize, secp256k1UncompressedKeySize},
+	},
+}
+
+// Validate checks the DID document against the DID Core data model: the document has a valid DID and
+// a DID context, ids of verification methods and services are unique, verification methods have valid
+// controllers, verification relationships reference verification methods of the document, public keys match
+// their declared types, key agreement uses key agreement keys and DIDComm V2 services reference key agreement
+// keys as recipient keys.
+func (doc *Doc) Validate() error {
+	return doc.validate(true)
+}
+
+func (doc *Doc) validate(requireID bool) error {
+	if err := doc.validateProperties(requireID); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidDocument, err.Error())
+	}
+
+	return nil
+}
+
+func (doc *Doc) validateProperties(requireID bool) error {
+	if doc.ID != "" || requireID {
+		if _, err := Parse(doc.ID); err != nil {
+			return fmt.Errorf("id: %w", err)
+		}
+	}
+
+	if len(doc.Context) == 0 {
+		return errors.New("@context is required")
+	}
+
+	switch doc.Context[0] {
+	case ContextV1, ContextV1Old, contextV011, contextV12019:
+	default:
+		return fmt.Errorf("first @context must be %s but got %s", ContextV1, doc.Context[0])
+	}
+
+	ids := make(map[string]bool)
+	methods := make(map[string]*VerificationMethod)
+
+	for i := range doc.VerificationMethod {
+		vm := &doc.VerificationMethod[i]
+
+		if err := doc.validateVerificationMethod(vm, ids); err != nil {
+			return err
+		}
+
+		methods[doc.absoluteID(vm.ID)] = vm
+	}
+
+	keyAgreement, err := doc.validateVerifications(methods, ids)
+	if err != nil {
+		return err
+	}
+
+	return doc.validateServices(keyAgreement)
+}
+
+func (doc *Doc) validateVerificationMethod(vm *VerificationMethod, ids map[string]bool) error {
+	if err := validateFragmentID(vm.ID); err != nil {
+		return fmt.Errorf("verification method: %w", err)
+	}
+
+	id := doc.absoluteID(vm.ID)
+	if ids[id] {
+		return fmt.Errorf("verification method %s: duplicate id", vm.ID)
+	}
+
+	ids[id] = true
+
+	if vm.Type == "" {
+		return fmt.Errorf("verification method %s: type is required", vm.ID)
+	}
+
+	// the controller is assigned along with the DID of the document by the VDR.
+	if vm.Controller == "" && doc.ID != "" {
+		return fmt.Errorf("verification method %s: controller is required", vm.ID)
+	}
+
+	if vm.Controller != "" {
+		if _, err := Parse(vm.Controller); err != nil {
+			return fmt.Errorf("verification method %s: controller: %w", vm.ID, err)
+		}
+	}
+
+	if err := validateKey(vm); err != nil {
+		return fmt.Errorf("verification method %s: %w", vm.ID, err)
+	}
+
+	return nil
+}
+
+func validateKey(vm *VerificationMethod) error {
+	if len(vm.Value) == 0 && vm.jsonWebKey == nil {
+		return errors.New("public key is required")
+	}
+
+	if vm.Type == jsonWebKey2020 && vm.jsonWebKey == nil {
+		return fmt.Errorf("JWK is required for %s", jsonWebKey2020)
+	}
+
+	keyType, ok := keyTypes[vm.Type]
+	if !ok {
+		return nil
+	}
+
+	if vm.jsonWebKey != nil {
+		if vm.jsonWebKey.Crv != keyType.curve {
+			return fmt.Errorf("JWK curve %s doesn't match %s", vm.jsonWebKey.Crv, vm.Type)
+		}
+
+		return nil
+	}
+
+	for _, size := range keyType.sizes {
+		if len(vm.Value) == size {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("public key of %d bytes doesn't match %s", len(vm.Value), vm.Type)
+}
+
+// validateVerifications checks verification relationships, it returns ids of key agreement verification methods.
+func (doc *Doc) validateVerifications(methods map[string]*VerificationMethod,
+	ids map[string]bool) (map[string]bool, error) {
+	keyAgreement := make(map[string]bool)
+
+	for _, relationship := range []struct {
+		name          string
+		verifications []Verification
+	}{
+		{"authentication", doc.Authentication},
+		{"assertionMethod", doc.AssertionMethod},
+		{"capabilityDelegation", doc.CapabilityDelegation},
+		{"capabilityInvocation", doc.CapabilityInvocation},
+		{"keyAgreement", doc.KeyAgreement},
+	} {
+		isKeyAgreement := relationship.name == "keyAgreement"
+
+		for i := range relationship.verifications {
+			vm := &relationship.verifications[i].VerificationMethod
+
+			if relationship.verifications[i].Embedded {
+				if err := doc.validateVerificationMethod(vm, ids); err != nil {
+					return nil, fmt.Errorf("%s: %w", relationship.name, err)
+				}
+			} else {
+				referenced, ok := methods[doc.absoluteID(vm.ID)]
+				if !ok {
+					return nil, fmt.Errorf("%s: verification method %s not found", relationship.name, vm.ID)
+				}
+
+				vm = referenced
+			}
+
+			if (isKeyAgreement && !isKeyAgreementKey(vm)) || (!isKeyAgreement && isX25519Key(vm)) {
+				return nil, fmt.Errorf("%s: %s key %s can't be used for the relationship",
+					relationship.name, vm.Type, vm.ID)
+			}
+
+			if isKeyAgreement {
+				keyAgreement[doc.absoluteID(vm.ID)] = true
+			}
+		}
+	}
+
+	return keyAgreement, nil
+}
+
+func (doc *Doc) validateServices(keyAgreement map[string]bool) error {
+	ids := make(map[string]bool)
+
+	for i := range doc.Service {
+		svc := &doc.Service[i]
+
+		if svc.ID == "" {
+			return errors.New("service: id is required")
+		}
+
+		if _, err := url.Parse(svc.ID); err != nil {
+			return fmt.Errorf("service %s: invalid id: %w", svc.ID, err)
+		}
+
+		id := doc.absoluteID(svc.ID)
+		if ids[id] {
+			return fmt.Errorf("service %s: duplicate id", svc.ID)
+		}
+
+		ids[id] = true
+
+		if svc.Type == "" {
+			return fmt.Errorf("service %s: type is required", svc.ID)
+		}
+
+		if svc.ServiceEndpoint == "" {
+			return fmt.Errorf("service %s: serviceEndpoint is required", svc.ID)
+		}
+
+		if svc.Type != didCommV2ServiceType {
+			continue
+		}
+
+		for _, key := range svc.RecipientKeys {
+			if doc.isOwnURL(key) && !keyAgreement[doc.absoluteID(key)] {
+				return fmt.Errorf("service %s: recipient key %s is not a key agreement key", svc.ID, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateFragmentID checks that id is either a relative DID URL of a fragment or a DID URL.
+func validateFragmentID(id string) error {
+	if id == "" {
+		return errors.New("id is required")
+	}
+
+	if strings.HasPrefix(id, "#") {
+		if len(id) == 1 {
+			return errors.New("empty fragment id")
+		}
+
+		return nil
+	}
+
+	if _, err := ParseDIDURL(id); err != nil {
+		return fmt.Errorf("invalid id %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func isKeyAgreementKey(vm *VerificationMethod) bool {
+	switch vm.Type {
+	case x25519KeyAgreementKey2019, x25519KeyAgreementKey2020:
+		return true
+	case jsonWebKey2020:
+		// NIST curves are used both for signing and ECDH-1PU/ES key agreement.
+		return vm.jsonWebKey != nil && (vm.jsonWebKey.Crv == x25519Curve || vm.jsonWebKey.Kty == "EC")
+	default:
+		return false
+	}
+}
+
+func isX25519Key(vm *VerificationMethod) bool {
+	return vm.Type == x25519KeyAgreementKey2019 || vm.Type == x25519KeyAgreementKey2020 ||
+		(vm.jsonWebKey != nil && vm.jsonWebKey.Crv == x25519Curve)
+}
+
+// absoluteID resolves the relative DID URL of the fragment against the DID of the document.
+func (doc *Doc) absoluteID(id string) string {
+	if strings.HasPrefix(id, "#") {
+		return doc.ID + id
+	}
+
+	return id
+}
+
+// isOwnURL reports whether the DID URL is relative or points to the document itself.
+func (doc *Doc) isOwnURL(didURL string) bool {
+	return strings.HasPrefix(didURL, "#") || (doc.ID != "" && strings.HasPrefix(didURL, doc.ID+"#"))
+}
@@ -0,0 +1,211 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+)
+
+func TestDoc_Validate(t *testing.T) {
+	t.Run("test valid documents", func(t *testing.T) {
+		for _, d := range []string{validDoc, validDocWithBase} {
+			doc, err := ParseDocument([]byte(d))
+			require.NoError(t, err)
+			require.NoError(t, doc.Validate())
+		}
+
+		require.NoError(t, newValidDoc(t).Validate())
+	})
+
+	t.Run("test invalid documents", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			modify func(doc *Doc)
+			err    string
+		}{
+			{
+				name:   "invalid DID",
+				modify: func(doc *Doc) { doc.ID = "invalid" },
+				err:    "id: invalid did: invalid",
+			},
+			{
+				name:   "missing context",
+				modify: func(doc *Doc) { doc.Context = nil },
+				err:    "@context is required",
+			},
+			{
+				name:   "invalid context",
+				modify: func(doc *Doc) { doc.Context = []string{"https://example.com/context"} },
+				err:    "first @context must be " + ContextV1,
+			},
+			{
+				name:   "duplicate verification method id",
+				modify: func(doc *Doc) { doc.VerificationMethod[1].ID = doc.ID + "#key-1" },
+				err:    "verification method did:example:123#key-1: duplicate id",
+			},
+			{
+				name:   "missing verification method id",
+				modify: func(doc *Doc) { doc.VerificationMethod[0].ID = "" },
+				err:    "verification method: id is required",
+			},
+			{
+				name:   "invalid verification method id",
+				modify: func(doc *Doc) { doc.VerificationMethod[0].ID = "key-1" },
+				err:    "verification method: invalid id key-1",
+			},
+			{
+				name:   "missing verification method type",
+				modify: func(doc *Doc) { doc.VerificationMethod[0].Type = "" },
+				err:    "verification method #key-1: type is required",
+			},
+			{
+				name:   "missing controller",
+				modify: func(doc *Doc) { doc.VerificationMethod[0].Controller = "" },
+				err:    "verification method #key-1: controller is required",
+			},
+			{
+				name:   "invalid controller",
+				modify: func(doc *Doc) { doc.VerificationMethod[0].Controller = "example" },
+				err:    "verification method #key-1: controller: invalid did",
+			},
+			{
+				name:   "missing public key",
+				modify: func(doc *Doc) { doc.VerificationMethod[0].Value = nil },
+				err:    "verification method #key-1: public key is required",
+			},
+			{
+				name:   "public key size doesn't match the type",
+				modify: func(doc *Doc) { doc.VerificationMethod[0].Value = []byte("key") },
+				err:    "verification method #key-1: public key of 3 bytes doesn't match Ed25519VerificationKey2018",
+			},
+			{
+				name:   "JWK curve doesn't match the type",
+				modify: func(doc *Doc) { doc.VerificationMethod[1].Type = ed25519VerificationKey2018 },
+				err:    "verification method #key-2: JWK curve X25519 doesn't match Ed25519VerificationKey2018",
+			},
+			{
+				name: "JWK is required",
+				modify: func(doc *Doc) {
+					doc.VerificationMethod[0].Type = jsonWebKey2020
+					doc.KeyAgreement = nil
+					doc.Service = nil
+				},
+				err: "verification method #key-1: JWK is required for JsonWebKey2020",
+			},
+			{
+				name: "referenced verification method not found",
+				modify: func(doc *Doc) {
+					doc.AssertionMethod[0].VerificationMethod.ID = "#key-3"
+				},
+				err: "assertionMethod: verification method #key-3 not found",
+			},
+			{
+				name: "embedded verification method duplicates id",
+				modify: func(doc *Doc) {
+					doc.CapabilityInvocation = []Verification{
+						*NewEmbeddedVerification(&doc.VerificationMethod[0], CapabilityInvocation),
+					}
+				},
+				err: "capabilityInvocation: verification method #key-1: duplicate id",
+			},
+			{
+				name: "signing key used for key agreement",
+				modify: func(doc *Doc) {
+					doc.KeyAgreement = append(doc.KeyAgreement, doc.Authentication[0])
+				},
+				err: "keyAgreement: Ed25519VerificationKey2018 key #key-1 can't be used for the relationship",
+			},
+			{
+				name: "key agreement key used for authentication",
+				modify: func(doc *Doc) {
+					doc.Authentication = append(doc.Authentication, doc.KeyAgreement[0])
+				},
+				err: "authentication: JsonWebKey2020 key #key-2 can't be used for the relationship",
+			},
+			{
+				name:   "missing service id",
+				modify: func(doc *Doc) { doc.Service[0].ID = "" },
+				err:    "service: id is required",
+			},
+			{
+				name:   "invalid service id",
+				modify: func(doc *Doc) { doc.Service[0].ID = "#%zz" },
+				err:    "service #%zz: invalid id",
+			},
+			{
+				name: "duplicate service id",
+				modify: func(doc *Doc) {
+					doc.Service = append(doc.Service, doc.Service[0])
+					doc.Service[1].ID = doc.ID + doc.Service[0].ID
+				},
+				err: "service did:example:123#didcomm: duplicate id",
+			},
+			{
+				name:   "missing service type",
+				modify: func(doc *Doc) { doc.Service[0].Type = "" },
+				err:    "service #didcomm: type is required",
+			},
+			{
+				name:   "missing service endpoint",
+				modify: func(doc *Doc) { doc.Service[0].ServiceEndpoint = "" },
+				err:    "service #didcomm: serviceEndpoint is required",
+			},
+			{
+				name:   "recipient key isn't a key agreement key",
+				modify: func(doc *Doc) { doc.Service[0].RecipientKeys = []string{doc.ID + "#key-1"} },
+				err:    "service #didcomm: recipient key did:example:123#key-1 is not a key agreement key",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				doc := newValidDoc(t)
+				tc.modify(doc)
+
+				err := doc.Validate()
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrInvalidDocument))
+				require.Contains(t, err.Error(), tc.err)
+			})
+		}
+	})
+}
+
+func newValidDoc(t *testing.T) *Doc {
+	t.Helper()
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	agreementKey, err := jwksupport.JWKFromX25519Key(make([]byte, x25519KeySize))
+	require.NoError(t, err)
+
+	vm := NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2018, "did:example:123", pubKey)
+
+	kaVM, err := NewVerificationMethodFromJWK("#key-2", jsonWebKey2020, "did:example:123", agreementKey)
+	require.NoError(t, err)
+
+	return &Doc{
+		Context:            []string{ContextV1},
+		ID:                 "did:example:123",
+		VerificationMethod: []VerificationMethod{*vm, *kaVM},
+		Authentication:     []Verification{*NewReferencedVerification(vm, Authentication)},
+		AssertionMethod:    []Verification{*NewReferencedVerification(vm, AssertionMethod)},
+		KeyAgreement:       []Verification{*NewReferencedVerification(kaVM, KeyAgreement)},
+		Service: []Service{{
+			ID:              "#didcomm",
+			Type:            didCommV2ServiceType,
+			ServiceEndpoint: "https://agent.example.com",
+			RecipientKeys:   []string{"#key-2"},
+		}},
+	}
+}
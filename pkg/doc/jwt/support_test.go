@@ -14,6 +14,7 @@ import (
 	"errors"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 )
 
 type ed25519Signer struct {
@@ -179,3 +180,23 @@ func prepareJWSHeaders(headers map[string]interface{}, alg string) map[string]in
 
 	return newHeaders
 }
+
+type algSigner struct {
+	signer  signature.Signer
+	headers map[string]interface{}
+}
+
+func newAlgSigner(signer signature.Signer, alg string) *algSigner {
+	return &algSigner{
+		signer:  signer,
+		headers: prepareJWSHeaders(nil, alg),
+	}
+}
+
+func (s algSigner) Sign(data []byte) ([]byte, error) {
+	return s.signer.Sign(data)
+}
+
+func (s algSigner) Headers() jose.Headers {
+	return s.headers
+}
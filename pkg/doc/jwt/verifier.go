@@ -12,8 +12,10 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/square/go-jose/v3/json"
+	"github.com/square/go-jose/v3/jwt"
 	"golang.org/x/crypto/ed25519"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
@@ -26,9 +28,27 @@ const (
 
 	// signatureRS256 defines RS256 alg.
 	signatureRS256 = "RS256"
+
+	// signaturePS256 defines PS256 alg.
+	signaturePS256 = "PS256"
+
+	// signatureES256 defines ES256 alg.
+	signatureES256 = "ES256"
+
+	// signatureES384 defines ES384 alg.
+	signatureES384 = "ES384"
+
+	// signatureES512 defines ES512 alg.
+	signatureES512 = "ES512"
+
+	// signatureES256K defines ES256K alg.
+	signatureES256K = "ES256K"
 )
 
-const issuerClaim = "iss"
+const (
+	issuerClaim = "iss"
+	nonceClaim  = "nonce"
+)
 
 // KeyResolver resolves public key based on what and kid.
 type KeyResolver interface {
@@ -45,41 +65,110 @@ func (k KeyResolverFunc) Resolve(what, kid string) (*verifier.PublicKey, error)
 	return k(what, kid)
 }
 
+// VerifyFunc verifies the signature of the message using the public key.
+type VerifyFunc func(pubKey *verifier.PublicKey, message, signature []byte) error
+
 // BasicVerifier defines basic Signed JWT verifier based on Issuer Claim and Key ID JOSE Header.
 type BasicVerifier struct {
 	resolver          KeyResolver
 	compositeVerifier *jose.CompositeAlgSigVerifier
+	opts              *verifierOpts
+}
+
+type verifierOpts struct {
+	algorithms   map[string]VerifyFunc
+	validateTime bool
+	leeway       time.Duration
+	now          func() time.Time
+	audience     string
+	nonce        string
+}
+
+// VerifierOpt is the JWT Verifier option.
+type VerifierOpt func(opts *verifierOpts)
+
+// WithAlgorithm registers the verification function of the JWS algorithm.
+// It is used to support an additional algorithm or to override the default verification of an algorithm.
+func WithAlgorithm(alg string, verify VerifyFunc) VerifierOpt {
+	return func(opts *verifierOpts) {
+		opts.algorithms[alg] = verify
+	}
+}
+
+// WithTimeValidation enables the validation of the "exp", "nbf" and "iat" claims.
+// The leeway is the allowed clock skew between the issuer and the verifier.
+func WithTimeValidation(leeway time.Duration) VerifierOpt {
+	return func(opts *verifierOpts) {
+		opts.validateTime = true
+		opts.leeway = leeway
+	}
+}
+
+// WithCurrentTime sets the function returning the time the time claims are validated against.
+// Defaults to time.Now.
+func WithCurrentTime(now func() time.Time) VerifierOpt {
+	return func(opts *verifierOpts) {
+		opts.now = now
+	}
+}
+
+// WithAudience requires the "aud" claim to contain the audience.
+func WithAudience(audience string) VerifierOpt {
+	return func(opts *verifierOpts) {
+		opts.audience = audience
+	}
+}
+
+// WithNonce requires the "nonce" claim to be equal to the nonce.
+func WithNonce(nonce string) VerifierOpt {
+	return func(opts *verifierOpts) {
+		opts.nonce = nonce
+	}
 }
 
 // NewVerifier creates a new basic Verifier.
-func NewVerifier(resolver KeyResolver) *BasicVerifier {
-	// TODO Support pluggable JWS verifiers
-	//  (https://github.com/hyperledger/aries-framework-go/issues/1267)
-	compositeVerifier := jose.NewCompositeAlgSigVerifier(
-		jose.AlgSignatureVerifier{
-			Alg:      signatureEdDSA,
-			Verifier: getVerifier(resolver, VerifyEdDSA),
-		},
-		jose.AlgSignatureVerifier{
-			Alg:      signatureRS256,
-			Verifier: getVerifier(resolver, VerifyRS256),
+// EdDSA, RS256, PS256, ES256, ES384, ES512 and ES256K algorithms are supported by default.
+func NewVerifier(resolver KeyResolver, opts ...VerifierOpt) *BasicVerifier {
+	vOpts := &verifierOpts{
+		algorithms: map[string]VerifyFunc{
+			signatureEdDSA:  VerifyEdDSA,
+			signatureRS256:  VerifyRS256,
+			signaturePS256:  verifier.NewRSAPS256SignatureVerifier().Verify,
+			signatureES256:  verifyECDSA(verifier.NewECDSAES256SignatureVerifier()),
+			signatureES384:  verifyECDSA(verifier.NewECDSAES384SignatureVerifier()),
+			signatureES512:  verifyECDSA(verifier.NewECDSAES521SignatureVerifier()),
+			signatureES256K: verifyECDSA(verifier.NewECDSASecp256k1SignatureVerifier()),
 		},
-	)
-	// TODO ECDSA to support NIST P256 curve
-	//  https://github.com/hyperledger/aries-framework-go/issues/1266
+		now: time.Now,
+	}
 
-	return &BasicVerifier{resolver: resolver, compositeVerifier: compositeVerifier}
-}
+	for _, opt := range opts {
+		opt(vOpts)
+	}
+
+	algVerifiers := make([]jose.AlgSignatureVerifier, 0, len(vOpts.algorithms))
 
-type signatureVerifier func(pubKey *verifier.PublicKey, message, signature []byte) error
+	for alg, verify := range vOpts.algorithms {
+		algVerifiers = append(algVerifiers, jose.AlgSignatureVerifier{
+			Alg:      alg,
+			Verifier: getVerifier(resolver, verify),
+		})
+	}
 
-func getVerifier(resolver KeyResolver, signatureVerifier signatureVerifier) jose.SignatureVerifier {
+	return &BasicVerifier{
+		resolver:          resolver,
+		compositeVerifier: jose.NewCompositeAlgSigVerifier(algVerifiers[0], algVerifiers[1:]...),
+		opts:              vOpts,
+	}
+}
+
+func getVerifier(resolver KeyResolver, signatureVerifier VerifyFunc) jose.SignatureVerifier {
 	return jose.SignatureVerifierFunc(func(joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
 		return verifySignature(resolver, signatureVerifier, joseHeaders, payload, signingInput, signature)
 	})
 }
 
-func verifySignature(resolver KeyResolver, signatureVerifier signatureVerifier,
+func verifySignature(resolver KeyResolver, signatureVerifier VerifyFunc,
 	joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
 	claims := make(map[string]interface{})
 
@@ -104,8 +193,51 @@ func verifySignature(resolver KeyResolver, signatureVerifier signatureVerifier,
 }
 
 // Verify verifies JSON Web Token. Public key is fetched using Issuer Claim and Key ID JOSE Header.
+// The claims are validated once the signature is verified.
 func (v BasicVerifier) Verify(joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
-	return v.compositeVerifier.Verify(joseHeaders, payload, signingInput, signature)
+	err := v.compositeVerifier.Verify(joseHeaders, payload, signingInput, signature)
+	if err != nil {
+		return err
+	}
+
+	return v.validateClaims(payload)
+}
+
+func (v BasicVerifier) validateClaims(payload []byte) error {
+	if v.opts == nil || (!v.opts.validateTime && v.opts.audience == "" && v.opts.nonce == "") {
+		return nil
+	}
+
+	var claims struct {
+		jwt.Claims
+		Nonce string `json:"nonce,omitempty"`
+	}
+
+	err := json.Unmarshal(payload, &claims)
+	if err != nil {
+		return fmt.Errorf("read claims from JSON Web Token: %w", err)
+	}
+
+	expected := jwt.Expected{}
+
+	if v.opts.audience != "" {
+		expected.Audience = jwt.Audience{v.opts.audience}
+	}
+
+	if v.opts.validateTime {
+		expected.Time = v.opts.now()
+	}
+
+	err = claims.ValidateWithLeeway(expected, v.opts.leeway)
+	if err != nil {
+		return fmt.Errorf("validate claims: %w", err)
+	}
+
+	if v.opts.nonce != "" && claims.Nonce != v.opts.nonce {
+		return fmt.Errorf("validate claims: invalid %s claim", nonceClaim)
+	}
+
+	return nil
 }
 
 // VerifyEdDSA verifies EdDSA signature.
@@ -144,6 +276,17 @@ func VerifyRS256(pubKey *verifier.PublicKey, message, signature []byte) error {
 	return rsa.VerifyPKCS1v15(pubKeyRsa, crypto.SHA256, hashed, signature)
 }
 
+// verifyECDSA verifies ECDSA signature, the curve of a JWK public key must match the algorithm.
+func verifyECDSA(ecdsaVerifier *verifier.ECDSASignatureVerifier) VerifyFunc {
+	return func(pubKey *verifier.PublicKey, message, signature []byte) error {
+		if pubKey.JWK != nil && pubKey.JWK.Crv != "" && pubKey.JWK.Crv != ecdsaVerifier.Curve() {
+			return fmt.Errorf("ecdsa: public key curve %s does not match %s", pubKey.JWK.Crv, ecdsaVerifier.Curve())
+		}
+
+		return ecdsaVerifier.Verify(pubKey, message, signature)
+	}
+}
+
 func getIssuerClaim(claims map[string]interface{}) (string, error) {
 	v, ok := claims[issuerClaim]
 	if !ok {
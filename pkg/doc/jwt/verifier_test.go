@@ -14,12 +14,16 @@ import (
 	"crypto/x509"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/square/go-jose/v3/json"
+	"github.com/square/go-jose/v3/jwt"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -75,6 +79,215 @@ func TestNewVerifier(t *testing.T) {
 	})
 }
 
+func TestNewVerifier_Algorithms(t *testing.T) {
+	tests := []struct {
+		alg     string
+		keyType kms.KeyType
+	}{
+		{alg: "EdDSA", keyType: kms.ED25519Type},
+		{alg: "RS256", keyType: kms.RSARS256Type},
+		{alg: "PS256", keyType: kms.RSAPS256Type},
+		{alg: "ES256", keyType: kms.ECDSAP256TypeIEEEP1363},
+		{alg: "ES384", keyType: kms.ECDSAP384TypeIEEEP1363},
+		{alg: "ES512", keyType: kms.ECDSAP521TypeIEEEP1363},
+		{alg: "ES256K", keyType: kms.ECDSASecp256k1TypeIEEEP1363},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run("Verify JWT signed by "+tc.alg, func(t *testing.T) {
+			r := require.New(t)
+
+			signer, err := signature.NewSigner(tc.keyType)
+			r.NoError(err)
+
+			token, err := NewSigned(&Claims{Issuer: "Mike"}, nil, newAlgSigner(signer, tc.alg))
+			r.NoError(err)
+			jws, err := token.Serialize(false)
+			r.NoError(err)
+
+			v := NewVerifier(getTestKeyResolver(&verifier.PublicKey{Value: signer.PublicKeyBytes()}, nil))
+			_, err = jose.ParseJWS(jws, v)
+			r.NoError(err)
+
+			anotherSigner, err := signature.NewSigner(tc.keyType)
+			r.NoError(err)
+
+			v = NewVerifier(getTestKeyResolver(&verifier.PublicKey{Value: anotherSigner.PublicKeyBytes()}, nil))
+			_, err = jose.ParseJWS(jws, v)
+			r.Error(err)
+		})
+	}
+
+	t.Run("Verify JWT signed by ES256 with JWK public key", func(t *testing.T) {
+		r := require.New(t)
+
+		signer, err := signature.NewSigner(kms.ECDSAP256TypeIEEEP1363)
+		r.NoError(err)
+
+		token, err := NewSigned(&Claims{Issuer: "Mike"}, nil, newAlgSigner(signer, "ES256"))
+		r.NoError(err)
+		jws, err := token.Serialize(false)
+		r.NoError(err)
+
+		pubKeyJWK, err := jwksupport.JWKFromKey(signer.PublicKey())
+		r.NoError(err)
+
+		v := NewVerifier(getTestKeyResolver(&verifier.PublicKey{Type: "JsonWebKey2020", JWK: pubKeyJWK}, nil))
+		_, err = jose.ParseJWS(jws, v)
+		r.NoError(err)
+
+		// the curve of the key does not match the algorithm
+		token, err = NewSigned(&Claims{Issuer: "Mike"}, nil, newAlgSigner(signer, "ES384"))
+		r.NoError(err)
+		jws, err = token.Serialize(false)
+		r.NoError(err)
+
+		_, err = jose.ParseJWS(jws, v)
+		r.EqualError(err, "ecdsa: public key curve P-256 does not match P-384")
+	})
+
+	t.Run("Custom algorithm", func(t *testing.T) {
+		r := require.New(t)
+
+		signer, err := signature.NewSigner(kms.ED25519Type)
+		r.NoError(err)
+
+		token, err := NewSigned(&Claims{Issuer: "Mike"}, nil, newAlgSigner(signer, "Ed25519"))
+		r.NoError(err)
+		jws, err := token.Serialize(false)
+		r.NoError(err)
+
+		resolver := getTestKeyResolver(&verifier.PublicKey{Value: signer.PublicKeyBytes()}, nil)
+
+		_, err = jose.ParseJWS(jws, NewVerifier(resolver))
+		r.EqualError(err, "no verifier found for Ed25519 algorithm")
+
+		_, err = jose.ParseJWS(jws, NewVerifier(resolver, WithAlgorithm("Ed25519", VerifyEdDSA)))
+		r.NoError(err)
+
+		_, err = jose.ParseJWS(jws, NewVerifier(resolver, WithAlgorithm("Ed25519",
+			func(*verifier.PublicKey, []byte, []byte) error {
+				return errors.New("not supported")
+			})))
+		r.EqualError(err, "not supported")
+	})
+}
+
+func TestNewVerifier_Claims(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := signature.NewSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	resolver := getTestKeyResolver(&verifier.PublicKey{Value: signer.PublicKeyBytes()}, nil)
+
+	now := time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	sign := func(claims interface{}) string {
+		token, e := NewSigned(claims, nil, newAlgSigner(signer, "EdDSA"))
+		r.NoError(e)
+
+		jws, e := token.Serialize(false)
+		r.NoError(e)
+
+		return jws
+	}
+
+	type nonceClaims struct {
+		*Claims
+		Nonce string `json:"nonce,omitempty"`
+	}
+
+	valid := sign(&nonceClaims{
+		Claims: &Claims{
+			Issuer:    "Mike",
+			Audience:  jwt.Audience{"verifier", "another-verifier"},
+			IssuedAt:  jwt.NewNumericDate(now.Add(-time.Hour)),
+			NotBefore: jwt.NewNumericDate(now.Add(-time.Hour)),
+			Expiry:    jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Nonce: "nonce",
+	})
+
+	t.Run("Valid claims", func(t *testing.T) {
+		_, err = jose.ParseJWS(valid, NewVerifier(resolver,
+			WithTimeValidation(0),
+			WithCurrentTime(func() time.Time { return now }),
+			WithAudience("verifier"),
+			WithNonce("nonce"),
+		))
+		require.NoError(t, err)
+	})
+
+	t.Run("Claims are not validated by default", func(t *testing.T) {
+		expired := sign(&Claims{Issuer: "Mike", Expiry: jwt.NewNumericDate(time.Now().Add(-time.Hour))})
+
+		_, err = jose.ParseJWS(expired, NewVerifier(resolver))
+		require.NoError(t, err)
+	})
+
+	t.Run("Time claims", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			now    time.Time
+			leeway time.Duration
+			err    string
+		}{
+			{name: "expired", now: now.Add(2 * time.Hour), err: "token is expired (exp)"},
+			{name: "expired within leeway", now: now.Add(time.Hour + time.Minute), leeway: 2 * time.Minute},
+			{name: "not valid yet", now: now.Add(-2 * time.Hour), err: "token not valid yet (nbf)"},
+			{name: "not valid yet within leeway", now: now.Add(-time.Hour - time.Minute), leeway: 2 * time.Minute},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := jose.ParseJWS(valid, NewVerifier(resolver,
+					WithTimeValidation(tc.leeway),
+					WithCurrentTime(func() time.Time { return tc.now }),
+				))
+
+				if tc.err == "" {
+					require.NoError(t, err)
+
+					return
+				}
+
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			})
+		}
+
+		issuedInTheFuture := sign(&Claims{Issuer: "Mike", IssuedAt: jwt.NewNumericDate(now.Add(time.Hour))})
+
+		_, err = jose.ParseJWS(issuedInTheFuture, NewVerifier(resolver,
+			WithTimeValidation(time.Minute),
+			WithCurrentTime(func() time.Time { return now }),
+		))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validation field, token issued in the future (iat)")
+	})
+
+	t.Run("Invalid audience", func(t *testing.T) {
+		_, err = jose.ParseJWS(valid, NewVerifier(resolver, WithAudience("unknown")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid audience claim (aud)")
+	})
+
+	t.Run("Invalid nonce", func(t *testing.T) {
+		_, err = jose.ParseJWS(valid, NewVerifier(resolver, WithNonce("another nonce")))
+		require.EqualError(t, err, "validate claims: invalid nonce claim")
+
+		withoutNonce := sign(&Claims{Issuer: "Mike"})
+
+		_, err = jose.ParseJWS(withoutNonce, NewVerifier(resolver, WithNonce("nonce")))
+		require.EqualError(t, err, "validate claims: invalid nonce claim")
+	})
+}
+
 func TestBasicVerifier_Verify(t *testing.T) { // error corner cases
 	r := require.New(t)
 
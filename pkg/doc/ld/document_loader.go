@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package ld
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -18,8 +19,27 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
-// ErrContextNotFound is returned when JSON-LD context document is not found in the underlying storage.
-var ErrContextNotFound = errors.New("context not found")
+var (
+	// ErrContextNotFound is returned when JSON-LD context document is not found in the underlying storage.
+	ErrContextNotFound = errors.New("context not found")
+	// ErrContextNotPinned is returned by the loader with PinnedContextsOnly policy when JSON-LD context document
+	// is not pinned or differs from the pinned version.
+	ErrContextNotPinned = errors.New("context not pinned")
+)
+
+// ContextPolicy defines which JSON-LD context documents DocumentLoader loads.
+type ContextPolicy int
+
+const (
+	// AllowAllContexts loads any stored context document and fetches missing documents with the remote
+	// document loader (if specified). It is the default policy.
+	AllowAllContexts ContextPolicy = iota
+	// PinnedContextsOnly loads only pinned context documents: the documents imported from signed context bundles
+	// and the embedded context documents in their embedded version. Unpinned documents (e.g. imported from remote
+	// providers or set with WithExtraContexts), changed embedded documents and missing documents are rejected
+	// with ErrContextNotPinned. Use the policy for loaders verifying proofs to guard them against context drift.
+	PinnedContextsOnly
+)
 
 // provider contains dependencies for the JSON-LD document loader.
 type provider interface {
@@ -31,6 +51,9 @@ type provider interface {
 type DocumentLoader struct {
 	store                ld.ContextStore
 	remoteDocumentLoader jsonld.DocumentLoader
+	policy               ContextPolicy
+	// embedded holds digests of the embedded context documents.
+	embedded map[string]string
 }
 
 // NewDocumentLoader returns a new DocumentLoader instance.
@@ -59,10 +82,23 @@ func NewDocumentLoader(ctx provider, opts ...DocumentLoaderOpts) (*DocumentLoade
 		return nil, fmt.Errorf("import contexts: %w", err)
 	}
 
-	return &DocumentLoader{
+	loader := &DocumentLoader{
 		store:                store,
 		remoteDocumentLoader: loaderOpts.remoteDocumentLoader,
-	}, nil
+		policy:               loaderOpts.policy,
+	}
+
+	if loader.policy == PinnedContextsOnly {
+		loader.embedded = make(map[string]string, len(embed.Contexts))
+
+		for _, c := range embed.Contexts {
+			if loader.embedded[c.URL], err = ldcontext.ComputeDigest(c.Content); err != nil {
+				return nil, fmt.Errorf("compute digest of embedded context %s: %w", c.URL, err)
+			}
+		}
+	}
+
+	return loader, nil
 }
 
 func prepareContexts(providerStore ld.RemoteProviderStore, opts *documentLoaderOpts) ([]ldcontext.Document, error) {
@@ -105,6 +141,10 @@ func (l *DocumentLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error)
 			return nil, fmt.Errorf("load document: %w", err)
 		}
 
+		if l.policy == PinnedContextsOnly {
+			return nil, fmt.Errorf("load document %s: %w", u, ErrContextNotPinned)
+		}
+
 		if l.remoteDocumentLoader == nil { // fetching from the remote URL is disabled
 			return nil, ErrContextNotFound
 		}
@@ -112,9 +152,44 @@ func (l *DocumentLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error)
 		return l.loadDocumentFromURL(u)
 	}
 
+	if l.policy == PinnedContextsOnly {
+		if err = l.checkPinned(u, rd); err != nil {
+			return nil, err
+		}
+	}
+
 	return rd, nil
 }
 
+func (l *DocumentLoader) checkPinned(u string, rd *jsonld.RemoteDocument) error {
+	pinned, err := l.store.Pinned(u)
+	if err != nil {
+		return fmt.Errorf("load document: %w", err)
+	}
+
+	if pinned {
+		return nil
+	}
+
+	if embedded, ok := l.embedded[u]; ok {
+		b, err := json.Marshal(rd.Document)
+		if err != nil {
+			return fmt.Errorf("load document: marshal context: %w", err)
+		}
+
+		digest, err := ldcontext.ComputeDigest(b)
+		if err != nil {
+			return fmt.Errorf("load document: %w", err)
+		}
+
+		if digest == embedded {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("load document %s: %w", u, ErrContextNotPinned)
+}
+
 func (l *DocumentLoader) loadDocumentFromURL(u string) (*jsonld.RemoteDocument, error) {
 	rd, err := l.remoteDocumentLoader.LoadDocument(u)
 	if err != nil {
@@ -129,6 +204,7 @@ func (l *DocumentLoader) loadDocumentFromURL(u string) (*jsonld.RemoteDocument,
 }
 
 type documentLoaderOpts struct {
+	policy               ContextPolicy
 	remoteDocumentLoader jsonld.DocumentLoader
 	extraContexts        []ldcontext.Document
 	remoteProviders      []RemoteProvider
@@ -145,6 +221,13 @@ func WithRemoteDocumentLoader(loader jsonld.DocumentLoader) DocumentLoaderOpts {
 	}
 }
 
+// WithContextPolicy sets the policy of loading JSON-LD context documents, AllowAllContexts by default.
+func WithContextPolicy(policy ContextPolicy) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.policy = policy
+	}
+}
+
 // WithExtraContexts sets the extra contexts (in addition to embedded) for preloading into the underlying storage.
 func WithExtraContexts(contexts ...ldcontext.Document) DocumentLoaderOpts {
 	return func(opts *documentLoaderOpts) {
@@ -246,6 +246,60 @@ func TestLoadDocument(t *testing.T) {
 	})
 }
 
+func TestLoadDocumentWithPinnedContextsOnly(t *testing.T) {
+	const contextURL = "https://example.com/context.jsonld"
+
+	extraContext := ldcontext.Document{URL: contextURL, Content: json.RawMessage(sampleJSONLDContext)}
+
+	t.Run("Load pinned and embedded contexts only", func(t *testing.T) {
+		store, err := ldstore.NewContextStore(mockstorage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+			ld.WithExtraContexts(extraContext),
+			ld.WithRemoteDocumentLoader(&mockRemoteDocumentLoader{}),
+			ld.WithContextPolicy(ld.PinnedContextsOnly))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument(embed.Contexts[0].URL)
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+
+		_, err = loader.LoadDocument(contextURL)
+		require.True(t, errors.Is(err, ld.ErrContextNotPinned))
+
+		_, err = loader.LoadDocument("https://example.com/missing.jsonld")
+		require.True(t, errors.Is(err, ld.ErrContextNotPinned))
+
+		require.NoError(t, store.ImportPinned([]ldcontext.Document{extraContext}))
+
+		rd, err = loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+
+		// embedded context changed by a remote provider
+		require.NoError(t, store.Import([]ldcontext.Document{{
+			URL:     embed.Contexts[0].URL,
+			Content: json.RawMessage(`{"@context": {"name": "https://example.com/name"}}`),
+		}}))
+
+		_, err = loader.LoadDocument(embed.Contexts[0].URL)
+		require.True(t, errors.Is(err, ld.ErrContextNotPinned))
+	})
+
+	t.Run("Fail to get pinned status of context", func(t *testing.T) {
+		store := mockldstore.NewMockContextStore()
+		store.ErrPinned = errors.New("pinned error")
+
+		loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+			ld.WithContextPolicy(ld.PinnedContextsOnly))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument(embed.Contexts[0].URL)
+		require.EqualError(t, err, "load document: pinned error")
+	})
+}
+
 func assertContextInStore(t *testing.T, store storage.Store, url, value string) {
 	t.Helper()
 
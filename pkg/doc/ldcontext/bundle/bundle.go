@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+)
+
+// Bundle is a set of JSON-LD context documents pinned by their digests (see ldcontext.ComputeDigest).
+// Bundles are exchanged as JWTs signed by the issuer of the bundle, the contexts of a bundle with a valid
+// signature of a trusted issuer can't be changed without breaking the signature.
+type Bundle struct {
+	Issuer    string               `json:"iss"`
+	IssuedAt  int64                `json:"iat,omitempty"`
+	Documents []ldcontext.Document `json:"documents"`
+}
+
+// New creates a bundle of the issuer with the context documents, digests of the documents are computed
+// from their content.
+func New(issuer string, documents []ldcontext.Document) (*Bundle, error) {
+	if len(documents) == 0 {
+		return nil, errors.New("bundle has no context documents")
+	}
+
+	pinned := make([]ldcontext.Document, 0, len(documents))
+
+	for _, d := range documents {
+		digest, err := ldcontext.ComputeDigest(d.Content)
+		if err != nil {
+			return nil, fmt.Errorf("context %s: %w", d.URL, err)
+		}
+
+		d.Digest = digest
+
+		pinned = append(pinned, d)
+	}
+
+	return &Bundle{
+		Issuer:    issuer,
+		IssuedAt:  time.Now().Unix(),
+		Documents: pinned,
+	}, nil
+}
+
+// Sign signs the bundle, it returns the bundle as a compact serialized JWT.
+func (b *Bundle) Sign(signer jose.Signer) (string, error) {
+	token, err := jwt.NewSigned(b, nil, signer)
+	if err != nil {
+		return "", fmt.Errorf("sign context bundle: %w", err)
+	}
+
+	signed, err := token.Serialize(false)
+	if err != nil {
+		return "", fmt.Errorf("serialize context bundle: %w", err)
+	}
+
+	return signed, nil
+}
+
+// Parse verifies the signature of the bundle JWT with the verifier (e.g. jwt.NewVerifier resolving keys
+// of trusted issuers) and the digests of its context documents.
+func Parse(bundle string, verifier jose.SignatureVerifier) (*Bundle, error) {
+	if verifier == nil {
+		return nil, errors.New("signature verifier is required")
+	}
+
+	token, err := jwt.Parse(bundle, jwt.WithSignatureVerifier(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("parse context bundle: %w", err)
+	}
+
+	b := &Bundle{}
+
+	if err = token.DecodeClaims(b); err != nil {
+		return nil, fmt.Errorf("decode context bundle: %w", err)
+	}
+
+	for _, d := range b.Documents {
+		digest, err := ldcontext.ComputeDigest(d.Content)
+		if err != nil {
+			return nil, fmt.Errorf("context %s: %w", d.URL, err)
+		}
+
+		if d.Digest != digest {
+			return nil, fmt.Errorf("context %s: digest %s doesn't match the content", d.URL, d.Digest)
+		}
+	}
+
+	return b, nil
+}
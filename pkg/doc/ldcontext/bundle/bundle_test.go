@@ -0,0 +1,146 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bundle_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/bundle"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const (
+	issuer     = "did:example:issuer"
+	contextURL = "https://example.com/context.jsonld"
+)
+
+func TestBundle(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	documents := []ldcontext.Document{{
+		URL:     contextURL,
+		Content: json.RawMessage(`{"@context": {"name": "http://xmlns.com/foaf/0.1/name"}}`),
+	}}
+
+	t.Run("test sign and parse bundle", func(t *testing.T) {
+		b, err := bundle.New(issuer, documents)
+		require.NoError(t, err)
+		require.NotZero(t, b.IssuedAt)
+
+		// the digest doesn't depend on formatting of the content
+		digest, err := ldcontext.ComputeDigest(json.RawMessage(`{"@context":{"name":"http://xmlns.com/foaf/0.1/name"}}`))
+		require.NoError(t, err)
+		require.Equal(t, digest, b.Documents[0].Digest)
+		require.Empty(t, documents[0].Digest)
+
+		signed, err := b.Sign(&ed25519Signer{privKey: privKey})
+		require.NoError(t, err)
+
+		parsed, err := bundle.Parse(signed, newVerifier(pubKey))
+		require.NoError(t, err)
+		require.Equal(t, issuer, parsed.Issuer)
+		require.Len(t, parsed.Documents, 1)
+		require.Equal(t, contextURL, parsed.Documents[0].URL)
+		require.Equal(t, digest, parsed.Documents[0].Digest)
+	})
+
+	t.Run("test parse bundle failures", func(t *testing.T) {
+		b, err := bundle.New(issuer, documents)
+		require.NoError(t, err)
+
+		signed, err := b.Sign(&ed25519Signer{privKey: privKey})
+		require.NoError(t, err)
+
+		_, err = bundle.Parse(signed, nil)
+		require.EqualError(t, err, "signature verifier is required")
+
+		otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, err = bundle.Parse(signed, newVerifier(otherKey))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse context bundle")
+
+		_, err = bundle.Parse("invalid", newVerifier(pubKey))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse context bundle")
+
+		// the document doesn't match its digest
+		b.Documents[0].Content = json.RawMessage(`{"@context": {"name": "https://example.com/name"}}`)
+
+		signed, err = b.Sign(&ed25519Signer{privKey: privKey})
+		require.NoError(t, err)
+
+		_, err = bundle.Parse(signed, newVerifier(pubKey))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "context https://example.com/context.jsonld: digest")
+
+		// the signature doesn't match the changed payload
+		parts := strings.Split(signed, ".")
+		parts[1] = parts[1][:len(parts[1])-2] + "AA"
+
+		_, err = bundle.Parse(strings.Join(parts, "."), newVerifier(pubKey))
+		require.Error(t, err)
+	})
+
+	t.Run("test create bundle failures", func(t *testing.T) {
+		_, err := bundle.New(issuer, nil)
+		require.EqualError(t, err, "bundle has no context documents")
+
+		_, err = bundle.New(issuer, []ldcontext.Document{{URL: contextURL, Content: json.RawMessage("{")}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "context https://example.com/context.jsonld")
+
+		b, err := bundle.New(issuer, documents)
+		require.NoError(t, err)
+
+		_, err = b.Sign(&ed25519Signer{err: errors.New("sign error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "sign error")
+	})
+}
+
+func newVerifier(pubKey ed25519.PublicKey) jose.SignatureVerifier {
+	return jwt.NewVerifier(jwt.KeyResolverFunc(func(what, kid string) (*verifier.PublicKey, error) {
+		if what != issuer {
+			return nil, errors.New("unknown issuer")
+		}
+
+		return &verifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: pubKey}, nil
+	}))
+}
+
+type ed25519Signer struct {
+	privKey ed25519.PrivateKey
+	err     error
+}
+
+func (s *ed25519Signer) Sign(data []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+func (s *ed25519Signer) Headers() jose.Headers {
+	return jose.Headers{
+		jose.HeaderAlgorithm: "EdDSA",
+		jose.HeaderKeyID:     "key-1",
+	}
+}
@@ -6,11 +6,29 @@ SPDX-License-Identifier: Apache-2.0
 
 package ldcontext
 
-import "encoding/json"
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jcs"
+)
 
 // Document is a JSON-LD context document with associated metadata.
 type Document struct {
 	URL         string          `json:"url,omitempty"`         // URL is a context URL that shows up in the documents.
 	DocumentURL string          `json:"documentURL,omitempty"` // The final URL of the loaded context document.
 	Content     json.RawMessage `json:"content,omitempty"`     // Content of the context document.
+	Digest      string          `json:"digest,omitempty"`      // Digest of the content, see ComputeDigest.
+}
+
+// ComputeDigest returns the digest of the context document content: the hex encoded SHA-256 hash of its
+// JSON Canonicalization Scheme (RFC 8785) representation. The digest doesn't depend on the formatting of the content.
+func ComputeDigest(content json.RawMessage) (string, error) {
+	b, err := jcs.Canonicalize(content)
+	if err != nil {
+		return "", fmt.Errorf("canonicalize context document: %w", err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
 }
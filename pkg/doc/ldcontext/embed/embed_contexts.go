@@ -46,6 +46,10 @@ var (
 	credentialFulfillment []byte
 	//go:embed third_party/identity.foundation/credential-application.jsonld
 	credentialApplication []byte
+	//go:embed third_party/w3id.org/data-integrity_v1.jsonld
+	dataIntegrity []byte
+	//go:embed third_party/w3id.org/ed25519-signature-2020_v1.jsonld
+	ed255192020 []byte
 )
 
 // Contexts contains JSON-LD contexts embedded into a Go binary.
@@ -140,4 +144,14 @@ var Contexts = []ldcontext.Document{ //nolint:gochecknoglobals
 		DocumentURL: "https://identity.foundation/credential-manifest/application/v1",
 		Content:     credentialApplication,
 	},
+	{
+		URL:         "https://w3id.org/security/data-integrity/v1",
+		DocumentURL: "https://w3id.org/security/data-integrity/v1",
+		Content:     dataIntegrity,
+	},
+	{
+		URL:         "https://w3id.org/security/suites/ed25519-2020/v1",
+		DocumentURL: "https://w3id.org/security/suites/ed25519-2020/v1",
+		Content:     ed255192020,
+	},
 }
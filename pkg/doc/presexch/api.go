@@ -9,6 +9,7 @@ package presexch
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/PaesslerAG/gval"
@@ -50,6 +51,7 @@ type InputDescriptorMapping struct {
 // MatchOptions is a holder of options that can set when matching a submission against definitions.
 type MatchOptions struct {
 	CredentialOptions []verifiable.CredentialOpt
+	StatusChecker     CredentialStatusChecker
 }
 
 // MatchOption is an option that sets an option for when matching.
@@ -62,6 +64,14 @@ func WithCredentialOptions(options ...verifiable.CredentialOpt) MatchOption {
 	}
 }
 
+// WithCredentialStatusChecker used for enforcing statuses constraints of input descriptors.
+// Without status checker, credentials do not satisfy input descriptors having statuses constraints.
+func WithCredentialStatusChecker(checker CredentialStatusChecker) MatchOption {
+	return func(m *MatchOptions) {
+		m.StatusChecker = checker
+	}
+}
+
 // Match returns the credentials matched against the InputDescriptors ids.
 func (pd *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:gocyclo,funlen
 	contextLoader ld.DocumentLoader, options ...MatchOption) (map[string]*verifiable.Credential, error) {
@@ -115,18 +125,34 @@ func (pd *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:g
 
 		inputDescriptor := pd.inputDescriptor(mapping.ID)
 
-		passed := filterSchema(inputDescriptor.Schema, []*verifiable.Credential{vc}, contextLoader)
-		if len(passed) == 0 {
-			return nil, fmt.Errorf(
-				"input descriptor id [%s] requires schemas %+v which do not match vc with @context [%+v] and types [%+v] selected by path [%s]", // nolint:lll
-				inputDescriptor.ID, inputDescriptor.Schema, vc.Context, vc.Types, mapping.Path)
+		if len(inputDescriptor.Schema) > 0 {
+			passed := filterSchema(inputDescriptor.Schema, []*verifiable.Credential{vc}, contextLoader)
+			if len(passed) == 0 {
+				return nil, fmt.Errorf(
+					"input descriptor id [%s] requires schemas %+v which do not match vc with @context [%+v] and types [%+v] selected by path [%s]", // nolint:lll
+					inputDescriptor.ID, inputDescriptor.Schema, vc.Context, vc.Types, mapping.Path)
+			}
 		}
 
-		// TODO add support for constraints: https://github.com/hyperledger/aries-framework-go/issues/2108
+		if len(filterFormat(pd.descriptorFormat(inputDescriptor), []*verifiable.Credential{vc})) == 0 {
+			return nil, fmt.Errorf("input descriptor id [%s] requires format which does not match vc selected by path [%s]",
+				inputDescriptor.ID, mapping.Path)
+		}
+
+		err = matchSubmittedConstraints(inputDescriptor.Constraints, vc, vp.Holder, opts.StatusChecker)
+		if err != nil {
+			return nil, fmt.Errorf("input descriptor id [%s] constraints are not satisfied by vc selected by path [%s]: %w",
+				inputDescriptor.ID, mapping.Path, err)
+		}
 
 		result[mapping.ID] = vc
 	}
 
+	err = pd.evalSameSubject(result)
+	if err != nil {
+		return nil, err
+	}
+
 	err = pd.evalSubmissionRequirements(result)
 	if err != nil {
 		return nil, fmt.Errorf("failed submission requirements: %w", err)
@@ -150,6 +176,66 @@ func (pd *PresentationDefinition) evalSubmissionRequirements(matched map[string]
 	return nil
 }
 
+// Ensures the matched credentials of input descriptors required to have same subject share a subject.
+func (pd *PresentationDefinition) evalSameSubject(matched map[string]*verifiable.Credential) error {
+	candidates := make(map[string][]*verifiable.Credential, len(matched))
+
+	for id, vc := range matched {
+		candidates[id] = []*verifiable.Credential{vc}
+	}
+
+	pd.filterSameSubject(candidates)
+
+	for id := range matched {
+		if len(candidates[id]) == 0 {
+			return fmt.Errorf("input descriptor id [%s] requires same subject as other input descriptors", id)
+		}
+	}
+
+	return nil
+}
+
+// matchSubmittedConstraints checks if submitted credential satisfies constraints. Limited disclosure of submitted
+// credential is expected, values of the fields with required predicate are expected to be replaced by true.
+func matchSubmittedConstraints(constraints *Constraints, vc *verifiable.Credential, holder string,
+	checker CredentialStatusChecker) error {
+	if constraints == nil {
+		return nil
+	}
+
+	if constraints.Statuses != nil && checker == nil {
+		return errNoStatusChecker
+	}
+
+	submitted := *constraints
+	submitted.Fields = make([]*Field, len(constraints.Fields))
+
+	for i, field := range constraints.Fields {
+		submitted.Fields[i] = field
+
+		if field.Predicate.isRequired() {
+			predicate := *field
+			predicate.Filter = &Filter{Const: true}
+			submitted.Fields[i] = &predicate
+		}
+	}
+
+	_, _, err := matchConstraints(&submitted, vc, checker)
+	if errors.Is(err, errPathNotApplicable) {
+		return errors.New("credential does not satisfy constraints")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if requiresHolder(constraints.IsHolder) && (holder == "" || !stringsContain(identifiedSubjects(vc), holder)) {
+		return fmt.Errorf("holder [%s] is not the subject of credential", holder)
+	}
+
+	return nil
+}
+
 func (pd *PresentationDefinition) inputDescriptor(id string) *InputDescriptor {
 	for i := range pd.InputDescriptors {
 		if pd.InputDescriptors[i].ID == id {
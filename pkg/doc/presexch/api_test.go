@@ -321,6 +321,138 @@ func TestPresentationDefinition_Match(t *testing.T) {
 	})
 }
 
+func TestPresentationDefinition_MatchV2(t *testing.T) {
+	docLoader := createTestDocumentLoader(t, randomURI())
+	required := Required
+
+	submit := func(t *testing.T, defs *PresentationDefinition, holder string,
+		vcs ...*verifiable.Credential) *verifiable.Presentation {
+		t.Helper()
+
+		submission := &PresentationSubmission{}
+
+		for i := range vcs {
+			submission.DescriptorMap = append(submission.DescriptorMap, &InputDescriptorMapping{
+				ID:   defs.InputDescriptors[i].ID,
+				Path: fmt.Sprintf("$.verifiableCredential[%d]", i),
+			})
+		}
+
+		vp := newVP(t, submission, vcs...)
+		vp.Holder = holder
+
+		return vp
+	}
+
+	t.Run("match constraints", func(t *testing.T) {
+		vc := newVC(nil)
+		vc.Subject = map[string]interface{}{"id": "did:example:holder", "name": "Jayden Doe", "age": true}
+
+		defs := &PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Constraints: &Constraints{
+					IsHolder: []*Holder{{FieldID: []string{"name"}, Directive: &required}},
+					Statuses: &Statuses{Revoked: &StatusDirective{Directive: DirectiveDisallowed}},
+					Fields: []*Field{{
+						ID:   "name",
+						Path: []string{"$.credentialSubject.name"},
+					}, {
+						Path:      []string{"$.credentialSubject.age"},
+						Filter:    &Filter{Minimum: 18},
+						Predicate: &required,
+					}, {
+						Path:     []string{"$.credentialSubject.nickname"},
+						Optional: true,
+					}},
+				},
+			}},
+		}
+
+		activeStatus := WithCredentialStatusChecker(func(*verifiable.Credential) (CredentialStatus, error) {
+			return StatusActive, nil
+		})
+
+		matched, err := defs.Match(submit(t, defs, "did:example:holder", vc), docLoader,
+			WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(docLoader)), activeStatus)
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, matched[defs.InputDescriptors[0].ID].ID)
+
+		_, err = defs.Match(submit(t, defs, "did:example:other", vc), docLoader,
+			WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(docLoader)), activeStatus)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "holder [did:example:other] is not the subject of credential")
+
+		// statuses constraints are not satisfied without status checker.
+		_, err = defs.Match(submit(t, defs, "did:example:holder", vc), docLoader,
+			WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(docLoader)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "statuses constraints require credential status checker")
+
+		_, err = defs.Match(submit(t, defs, "did:example:holder", vc), docLoader,
+			WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(docLoader)),
+			WithCredentialStatusChecker(func(*verifiable.Credential) (CredentialStatus, error) {
+				return StatusRevoked, nil
+			}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "constraints are not satisfied")
+
+		vc.Subject = map[string]interface{}{"id": "did:example:holder", "age": true}
+
+		_, err = defs.Match(submit(t, defs, "did:example:holder", vc), docLoader,
+			WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(docLoader)), activeStatus)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "constraints are not satisfied")
+	})
+
+	t.Run("error if format does not match", func(t *testing.T) {
+		defs := &PresentationDefinition{
+			Format: &Format{LdpVC: &LdpType{ProofType: []string{"BbsBlsSignatureProof2020"}}},
+			InputDescriptors: []*InputDescriptor{{
+				ID:          uuid.New().String(),
+				Constraints: &Constraints{},
+			}},
+		}
+
+		_, err := defs.Match(submit(t, defs, "", newVC(nil)), docLoader,
+			WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(docLoader)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "requires format which does not match vc")
+	})
+
+	t.Run("error if subjects are not the same", func(t *testing.T) {
+		defs := &PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Constraints: &Constraints{
+					SameSubject: []*Holder{{FieldID: []string{"first", "second"}, Directive: &required}},
+					Fields:      []*Field{{ID: "first", Path: []string{"$.id"}}},
+				},
+			}, {
+				ID: uuid.New().String(),
+				Constraints: &Constraints{
+					Fields: []*Field{{ID: "second", Path: []string{"$.id"}}},
+				},
+			}},
+		}
+
+		first := newVC(nil)
+		second := newVC(nil)
+
+		_, err := defs.Match(submit(t, defs, "", first, second), docLoader,
+			WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(docLoader)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "requires same subject as other input descriptors")
+
+		second.Subject = first.Subject
+
+		matched, err := defs.Match(submit(t, defs, "", first, second), docLoader,
+			WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(docLoader)))
+		require.NoError(t, err)
+		require.Len(t, matched, 2)
+	})
+}
+
 func TestE2E(t *testing.T) {
 	baseSchemaURI := randomURI()
 
@@ -37,10 +37,32 @@ const (
 	// Preferred predicate`s value.
 	Preferred Preference = "preferred"
 
+	// Version1 is Presentation Exchange v1 (https://identity.foundation/presentation-exchange/spec/v1.0.0/).
+	Version1 Version = "v1"
+	// Version2 is Presentation Exchange v2 (https://identity.foundation/presentation-exchange/spec/v2.0.0/).
+	Version2 Version = "v2"
+
+	// DirectiveRequired status directive`s value, credential must have the status.
+	DirectiveRequired Directive = "required"
+	// DirectiveAllowed status directive`s value, credential may have the status.
+	DirectiveAllowed Directive = "allowed"
+	// DirectiveDisallowed status directive`s value, credential must not have the status.
+	DirectiveDisallowed Directive = "disallowed"
+
+	// StatusActive is status of an active credential.
+	StatusActive CredentialStatus = "active"
+	// StatusSuspended is status of a suspended credential.
+	StatusSuspended CredentialStatus = "suspended"
+	// StatusRevoked is status of a revoked credential.
+	StatusRevoked CredentialStatus = "revoked"
+
 	tmpEnding = "tmp_unique_id_"
 )
 
-var errPathNotApplicable = errors.New("path not applicable")
+var (
+	errPathNotApplicable = errors.New("path not applicable")
+	errNoStatusChecker   = errors.New("statuses constraints require credential status checker")
+)
 
 var logger = log.New("doc/presexch")
 
@@ -51,8 +73,17 @@ type (
 	Preference string
 	// StrOrInt type that defines string or integer.
 	StrOrInt interface{}
+	// Version is Presentation Exchange spec version, can be "v1" or "v2".
+	Version string
+	// Directive can be "required", "allowed" or "disallowed".
+	Directive string
+	// CredentialStatus can be "active", "suspended" or "revoked".
+	CredentialStatus string
 )
 
+// CredentialStatusChecker returns status of given credential, used for enforcing statuses constraints.
+type CredentialStatusChecker func(vc *verifiable.Credential) (CredentialStatus, error)
+
 func (v *Preference) isRequired() bool {
 	if v == nil {
 		return false
@@ -97,6 +128,8 @@ type PresentationDefinition struct {
 	// If not present, all inputs listed in the InputDescriptors array are required for submission.
 	SubmissionRequirements []*SubmissionRequirement `json:"submission_requirements,omitempty"`
 	InputDescriptors       []*InputDescriptor       `json:"input_descriptors,omitempty"`
+	// Frame is JSON-LD frame used for selective disclosure of BBS+ signed credentials (v2 only).
+	Frame map[string]interface{} `json:"frame,omitempty"`
 }
 
 // SubmissionRequirement describes input that must be submitted via a Presentation Submission
@@ -114,13 +147,16 @@ type SubmissionRequirement struct {
 
 // InputDescriptor input descriptors.
 type InputDescriptor struct {
-	ID          string                 `json:"id,omitempty"`
-	Group       []string               `json:"group,omitempty"`
-	Name        string                 `json:"name,omitempty"`
-	Purpose     string                 `json:"purpose,omitempty"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
-	Schema      []*Schema              `json:"schema,omitempty"`
-	Constraints *Constraints           `json:"constraints,omitempty"`
+	ID       string                 `json:"id,omitempty"`
+	Group    []string               `json:"group,omitempty"`
+	Name     string                 `json:"name,omitempty"`
+	Purpose  string                 `json:"purpose,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// Schema is v1 only, v2 input descriptors are matched by Format and Constraints.
+	Schema []*Schema `json:"schema,omitempty"`
+	// Format overrides PresentationDefinition`s Format for this input descriptor (v2 only).
+	Format      *Format      `json:"format,omitempty"`
+	Constraints *Constraints `json:"constraints,omitempty"`
 }
 
 // Schema input descriptor schema.
@@ -140,9 +176,23 @@ type Constraints struct {
 	LimitDisclosure *Preference `json:"limit_disclosure,omitempty"`
 	SubjectIsIssuer *Preference `json:"subject_is_issuer,omitempty"`
 	IsHolder        []*Holder   `json:"is_holder,omitempty"`
+	SameSubject     []*Holder   `json:"same_subject,omitempty"`
+	Statuses        *Statuses   `json:"statuses,omitempty"`
 	Fields          []*Field    `json:"fields,omitempty"`
 }
 
+// Statuses describes Constraints`s statuses field.
+type Statuses struct {
+	Active    *StatusDirective `json:"active,omitempty"`
+	Suspended *StatusDirective `json:"suspended,omitempty"`
+	Revoked   *StatusDirective `json:"revoked,omitempty"`
+}
+
+// StatusDirective describes Statuses`s directive for a credential status.
+type StatusDirective struct {
+	Directive Directive `json:"directive,omitempty"`
+}
+
 // Field describes Constraints`s Fields field.
 type Field struct {
 	Path      []string    `json:"path,omitempty"`
@@ -150,6 +200,10 @@ type Field struct {
 	Purpose   string      `json:"purpose,omitempty"`
 	Filter    *Filter     `json:"filter,omitempty"`
 	Predicate *Preference `json:"predicate,omitempty"`
+	// Optional field doesn't have to be present in credential (v2 only).
+	Optional bool `json:"optional,omitempty"`
+	// IntentToRetain indicates that verifier intends to retain the field value (v2 only).
+	IntentToRetain bool `json:"intent_to_retain,omitempty"`
 }
 
 // Filter describes filter.
@@ -168,10 +222,79 @@ type Filter struct {
 	Not              map[string]interface{} `json:"not,omitempty"`
 }
 
-// ValidateSchema validates presentation definition.
+// ParseOption is an option for parsing presentation definition.
+type ParseOption func(opts *parseOpts)
+
+type parseOpts struct {
+	version Version
+}
+
+// WithVersion option to validate presentation definition against JSON schema of given spec version
+// instead of the version detected from the definition.
+func WithVersion(version Version) ParseOption {
+	return func(opts *parseOpts) {
+		opts.version = version
+	}
+}
+
+// ParsePresentationDefinition parses presentation definition and validates it against JSON schema.
+func ParsePresentationDefinition(data []byte, opts ...ParseOption) (*PresentationDefinition, error) {
+	var pd PresentationDefinition
+
+	err := json.Unmarshal(data, &pd)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal presentation definition: %w", err)
+	}
+
+	options := &parseOpts{version: pd.Version()}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	err = pd.validateSchema(options.version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pd, nil
+}
+
+// Version returns Presentation Exchange spec version of presentation definition.
+// Definitions having a frame or input descriptors without schema or with format are v2, others are v1.
+func (pd *PresentationDefinition) Version() Version {
+	if pd.Frame != nil {
+		return Version2
+	}
+
+	for _, descriptor := range pd.InputDescriptors {
+		if len(descriptor.Schema) == 0 || descriptor.Format != nil {
+			return Version2
+		}
+	}
+
+	return Version1
+}
+
+// ValidateSchema validates presentation definition against JSON schema of its spec version, see Version.
 func (pd *PresentationDefinition) ValidateSchema() error {
+	return pd.validateSchema(pd.Version())
+}
+
+func (pd *PresentationDefinition) validateSchema(version Version) error {
+	var definitionSchema string
+
+	switch version {
+	case Version1:
+		definitionSchema = DefinitionJSONSchema
+	case Version2:
+		definitionSchema = DefinitionJSONSchemaV2
+	default:
+		return fmt.Errorf("unsupported presentation exchange version: %s", version)
+	}
+
 	result, err := gojsonschema.Validate(
-		gojsonschema.NewStringLoader(DefinitionJSONSchema),
+		gojsonschema.NewStringLoader(definitionSchema),
 		gojsonschema.NewGoLoader(struct {
 			PD *PresentationDefinition `json:"presentation_definition"`
 		}{PD: pd}),
@@ -299,6 +422,7 @@ func makeRequirement(requirements []*SubmissionRequirement, descriptors []*Input
 }
 
 // CreateVP creates verifiable presentation.
+// Credentials are considered active when enforcing statuses constraints, credential status is not resolved.
 func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 	documentLoader ld.DocumentLoader, opts ...verifiable.CredentialOpt) (*verifiable.Presentation, error) {
 	if err := pd.ValidateSchema(); err != nil {
@@ -310,13 +434,76 @@ func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 		return nil, err
 	}
 
-	result, err := applyRequirement(req, credentials, documentLoader, opts...)
+	candidates, err := pd.filterCredentials(credentials, documentLoader, presumeActive, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pd.presentCandidates(req, candidates, opts...)
+}
+
+// CreateVPFromSelection creates verifiable presentation of the credentials selected by holder, mapped by
+// input descriptor ID, e.g. among the candidates listed by MatchSubmissionRequirement.
+// Every selected credential has to satisfy its input descriptor and the selection has to satisfy submission
+// requirements, limit disclosure and frame are applied to selected credentials as by CreateVP.
+func (pd *PresentationDefinition) CreateVPFromSelection(selection map[string][]*verifiable.Credential,
+	documentLoader ld.DocumentLoader, opts ...verifiable.CredentialOpt) (*verifiable.Presentation, error) {
+	if err := pd.ValidateSchema(); err != nil {
+		return nil, err
+	}
+
+	req, err := makeRequirement(pd.SubmissionRequirements, pd.InputDescriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string][]*verifiable.Credential, len(selection))
+
+	for id, credentials := range selection {
+		descriptor := pd.inputDescriptor(id)
+		if descriptor == nil {
+			return nil, fmt.Errorf("input descriptor '%s' not found", id)
+		}
+
+		filtered, err := pd.filterDescriptorCredentials(descriptor, credentials, documentLoader, presumeActive, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(filtered) != len(credentials) {
+			return nil, fmt.Errorf("selected credentials do not satisfy input descriptor '%s'", id)
+		}
+
+		candidates[id] = filtered
+	}
+
+	pd.filterSameSubject(candidates)
+
+	for _, descriptor := range pd.InputDescriptors {
+		if len(candidates[descriptor.ID]) != len(selection[descriptor.ID]) {
+			return nil, fmt.Errorf("selected credentials of input descriptor '%s' do not have the same subject",
+				descriptor.ID)
+		}
+	}
+
+	return pd.presentCandidates(req, candidates, opts...)
+}
+
+// presentCandidates creates verifiable presentation of the candidate credentials satisfying submission requirements.
+func (pd *PresentationDefinition) presentCandidates(req *requirement, candidates map[string][]*verifiable.Credential,
+	opts ...verifiable.CredentialOpt) (*verifiable.Presentation, error) {
+	result, err := applyRequirement(req, candidates)
 	if err != nil {
 		return nil, err
 	}
 
 	applicableCredentials, descriptors := merge(result)
 
+	applicableCredentials, err = pd.applyFrame(applicableCredentials, opts...)
+	if err != nil {
+		return nil, err
+	}
+
 	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(applicableCredentials...))
 	if err != nil {
 		return nil, err
@@ -336,23 +523,182 @@ func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 	return vp, nil
 }
 
+// filterCredentials returns credentials satisfying each of input descriptors, mapped by input descriptor ID.
+func (pd *PresentationDefinition) filterCredentials(credentials []*verifiable.Credential,
+	documentLoader ld.DocumentLoader, checker CredentialStatusChecker,
+	opts ...verifiable.CredentialOpt) (map[string][]*verifiable.Credential, error) {
+	result := make(map[string][]*verifiable.Credential)
+
+	for _, descriptor := range pd.InputDescriptors {
+		filtered, err := pd.filterDescriptorCredentials(descriptor, credentials, documentLoader, checker, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		result[descriptor.ID] = filtered
+	}
+
+	pd.filterSameSubject(result)
+
+	return result, nil
+}
+
+// filterDescriptorCredentials returns credentials satisfying input descriptor, limit disclosure and predicates
+// are applied to returned credentials.
+func (pd *PresentationDefinition) filterDescriptorCredentials(descriptor *InputDescriptor,
+	credentials []*verifiable.Credential, documentLoader ld.DocumentLoader, checker CredentialStatusChecker,
+	opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
+	filtered := credentials
+
+	if len(descriptor.Schema) > 0 {
+		filtered = filterSchema(descriptor.Schema, filtered, documentLoader)
+	}
+
+	filtered = filterFormat(pd.descriptorFormat(descriptor), filtered)
+
+	return filterConstraints(descriptor.Constraints, filtered, checker, opts...)
+}
+
+// descriptorFormat returns claim format of input descriptor, defaults to format of presentation definition.
+func (pd *PresentationDefinition) descriptorFormat(descriptor *InputDescriptor) *Format {
+	if descriptor.Format != nil {
+		return descriptor.Format
+	}
+
+	return pd.Format
+}
+
+// filterSameSubject removes credentials whose subject is not shared by the credentials of other input descriptors
+// required to have same subject.
+func (pd *PresentationDefinition) filterSameSubject(candidates map[string][]*verifiable.Credential) {
+	for _, descriptor := range pd.InputDescriptors {
+		if descriptor.Constraints == nil {
+			continue
+		}
+
+		for _, sameSubject := range descriptor.Constraints.SameSubject {
+			if !sameSubject.Directive.isRequired() {
+				continue
+			}
+
+			descriptorIDs := pd.descriptorsByFieldIDs(sameSubject.FieldID)
+
+			var common map[string]struct{}
+
+			for _, id := range descriptorIDs {
+				// input descriptors without credentials are not submitted, so they don't restrict the subject.
+				if len(candidates[id]) == 0 {
+					continue
+				}
+
+				subjects := map[string]struct{}{}
+
+				for _, credential := range candidates[id] {
+					for _, subjectID := range identifiedSubjects(credential) {
+						if common == nil || hasKey(common, subjectID) {
+							subjects[subjectID] = struct{}{}
+						}
+					}
+				}
+
+				common = subjects
+			}
+
+			for _, id := range descriptorIDs {
+				var filtered []*verifiable.Credential
+
+				for _, credential := range candidates[id] {
+					for _, subjectID := range identifiedSubjects(credential) {
+						if hasKey(common, subjectID) {
+							filtered = append(filtered, credential)
+
+							break
+						}
+					}
+				}
+
+				candidates[id] = filtered
+			}
+		}
+	}
+}
+
+// descriptorsByFieldIDs returns IDs of input descriptors having a field with any of the given field IDs.
+func (pd *PresentationDefinition) descriptorsByFieldIDs(fieldIDs []string) []string {
+	var ids []string
+
+	for _, descriptor := range pd.InputDescriptors {
+		if descriptor.Constraints == nil {
+			continue
+		}
+
+		for _, field := range descriptor.Constraints.Fields {
+			if field.ID != "" && contains(fieldIDs, field.ID) {
+				ids = append(ids, descriptor.ID)
+
+				break
+			}
+		}
+	}
+
+	return ids
+}
+
+// applyFrame derives BBS+ signed credentials revealing only the claims selected by JSON-LD frame.
+func (pd *PresentationDefinition) applyFrame(credentials []*verifiable.Credential,
+	opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
+	if pd.Frame == nil {
+		return credentials, nil
+	}
+
+	frameBytes, err := json.Marshal(pd.Frame)
+	if err != nil {
+		return nil, fmt.Errorf("marshal frame: %w", err)
+	}
+
+	for i, credential := range credentials {
+		if !hasBBS(credential) {
+			continue
+		}
+
+		var frame map[string]interface{}
+
+		err = json.Unmarshal(frameBytes, &frame)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal frame: %w", err)
+		}
+
+		credentials[i], err = credential.GenerateBBSSelectiveDisclosure(frame, []byte(uuid.New().String()), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("apply frame to credential '%s': %w", credential.ID, err)
+		}
+	}
+
+	return credentials, nil
+}
+
+func hasKey(set map[string]struct{}, key string) bool {
+	_, ok := set[key]
+
+	return ok
+}
+
+// presumeActive is the credential status checker of holder creating presentation, holder does not resolve
+// credential status and presents its credentials as active, statuses are enforced by verifier on Match.
+func presumeActive(*verifiable.Credential) (CredentialStatus, error) {
+	return StatusActive, nil
+}
+
 // ErrNoCredentials when any credentials do not satisfy requirements.
 var ErrNoCredentials = errors.New("credentials do not satisfy requirements")
 
 // nolint: gocyclo,funlen,gocognit
-func applyRequirement(req *requirement, creds []*verifiable.Credential,
-	documentLoader ld.DocumentLoader, opts ...verifiable.CredentialOpt) (map[string][]*verifiable.Credential, error) {
+func applyRequirement(req *requirement,
+	candidates map[string][]*verifiable.Credential) (map[string][]*verifiable.Credential, error) {
 	result := make(map[string][]*verifiable.Credential)
 
 	for _, descriptor := range req.InputDescriptors {
-		filtered := filterSchema(descriptor.Schema, creds, documentLoader)
-
-		filtered, err := filterConstraints(descriptor.Constraints, filtered, opts...)
-		if err != nil {
-			return nil, err
-		}
-
-		if len(filtered) != 0 {
+		if filtered := candidates[descriptor.ID]; len(filtered) != 0 {
 			result[descriptor.ID] = filtered
 		}
 	}
@@ -371,7 +717,7 @@ func applyRequirement(req *requirement, creds []*verifiable.Credential,
 	set := map[string]map[string]string{}
 
 	for _, r := range req.Nested {
-		res, err := applyRequirement(r, creds, documentLoader, opts...)
+		res, err := applyRequirement(r, candidates)
 		if errors.Is(err, ErrNoCredentials) {
 			continue
 		}
@@ -490,6 +836,19 @@ func getSubjectIDs(subject interface{}) []string { // nolint: gocyclo
 	return nil
 }
 
+// identifiedSubjects returns non-empty subject IDs of the credential.
+func identifiedSubjects(credential *verifiable.Credential) []string {
+	var ids []string
+
+	for _, id := range getSubjectIDs(credential.Subject) {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
 func subjectIsIssuer(credential *verifiable.Credential) bool {
 	for _, ID := range getSubjectIDs(credential.Subject) {
 		if ID != "" && ID == credential.Issuer.ID {
@@ -500,8 +859,7 @@ func subjectIsIssuer(credential *verifiable.Credential) bool {
 	return false
 }
 
-// nolint: gocyclo,funlen,gocognit
-func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
+func filterConstraints(constraints *Constraints, creds []*verifiable.Credential, checker CredentialStatusChecker,
 	opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
 	if constraints == nil {
 		return creds, nil
@@ -510,88 +868,258 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 	var result []*verifiable.Credential
 
 	for _, credential := range creds {
-		if constraints.SubjectIsIssuer.isRequired() && !subjectIsIssuer(credential) {
+		fields, credentialSrc, err := matchConstraints(constraints, credential, checker)
+		if errors.Is(err, errPathNotApplicable) {
 			continue
 		}
 
-		var applicable bool
+		if err != nil {
+			return nil, err
+		}
 
-		credentialSrc, err := json.Marshal(credential)
+		disclosed, err := discloseCredential(constraints, fields, credentialSrc, credential, opts...)
 		if err != nil {
-			continue
+			return nil, err
 		}
 
-		var credentialMap map[string]interface{}
+		if disclosed != credential {
+			disclosed.ID = tmpID(disclosed.ID)
+		}
+
+		result = append(result, disclosed)
+	}
+
+	return result, nil
+}
+
+// discloseCredential applies limit disclosure and predicates of constraints to the fields matched by credential,
+// credential is returned as is if neither is required.
+func discloseCredential(constraints *Constraints, fields []*Field, credentialSrc []byte,
+	credential *verifiable.Credential, opts ...verifiable.CredentialOpt) (*verifiable.Credential, error) {
+	var predicate bool
+
+	for _, field := range fields {
+		if field.Predicate.isRequired() {
+			predicate = true
+		}
+	}
+
+	if !constraints.LimitDisclosure.isRequired() && !predicate {
+		return credential, nil
+	}
+
+	template := credentialSrc
+
+	var contexts []interface{}
+
+	for _, ctx := range credential.Context {
+		contexts = append(contexts, ctx)
+	}
+
+	contexts = append(contexts, credential.CustomContext...)
 
-		err = json.Unmarshal(credentialSrc, &credentialMap)
+	if constraints.LimitDisclosure.isRequired() {
+		var err error
+
+		template, err = json.Marshal(map[string]interface{}{
+			"id":                credential.ID,
+			"type":              credential.Types,
+			"@context":          contexts,
+			"issuer":            credential.Issuer,
+			"credentialSubject": toSubject(credential.Subject),
+			"issuanceDate":      credential.Issued,
+		})
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		var predicate bool
+	// only the fields present in credential are disclosed.
+	matched := *constraints
+	matched.Fields = fields
 
-		for i, field := range constraints.Fields {
-			err = filterField(field, credentialMap)
-			if errors.Is(err, errPathNotApplicable) {
-				applicable = false
+	disclosed, err := createNewCredential(&matched, credentialSrc, template, credential, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create new credential: %w", err)
+	}
 
-				break
-			}
+	return disclosed, nil
+}
 
-			if err != nil {
-				return nil, fmt.Errorf("filter field.%d: %w", i, err)
-			}
+// matchConstraints checks if credential satisfies constraints and returns the fields matched by credential
+// along with the marshalled credential, optional fields not matched by credential are not returned.
+// Returns errPathNotApplicable if credential doesn't satisfy constraints.
+func matchConstraints(constraints *Constraints, credential *verifiable.Credential,
+	checker CredentialStatusChecker) ([]*Field, []byte, error) {
+	fields, credentialSrc, failures, err := checkConstraints(constraints, credential, checker)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(failures) != 0 {
+		return nil, nil, errPathNotApplicable
+	}
+
+	return fields, credentialSrc, nil
+}
+
+// checkConstraints checks credential against constraints and returns the fields matched by credential along with
+// the marshalled credential and the checks credential failed. Fields are checked only if credential passes
+// the other checks.
+// nolint: gocyclo
+func checkConstraints(constraints *Constraints, credential *verifiable.Credential,
+	checker CredentialStatusChecker) ([]*Field, []byte, []*MatchFailure, error) {
+	var failures []*MatchFailure
+
+	if constraints.SubjectIsIssuer.isRequired() && !subjectIsIssuer(credential) {
+		failures = append(failures, &MatchFailure{Check: CheckSubjectIsIssuer})
+	}
+
+	// holder can prove that it is the subject only if the subject is identified.
+	if requiresHolder(constraints.IsHolder) && len(identifiedSubjects(credential)) == 0 {
+		failures = append(failures, &MatchFailure{Check: CheckIsHolder})
+	}
+
+	if constraints.Statuses != nil && checker == nil {
+		failures = append(failures, &MatchFailure{Check: CheckStatuses, Reason: errNoStatusChecker.Error()})
+	} else if !constraints.Statuses.accepts(credential, checker) {
+		failures = append(failures, &MatchFailure{Check: CheckStatuses})
+	}
+
+	if len(failures) != 0 {
+		return nil, nil, failures, nil
+	}
 
-			if field.Predicate.isRequired() {
-				predicate = true
+	credentialSrc, err := json.Marshal(credential)
+	if err != nil {
+		return nil, nil, []*MatchFailure{{Check: CheckFields, Reason: err.Error()}}, nil
+	}
+
+	var credentialMap map[string]interface{}
+
+	err = json.Unmarshal(credentialSrc, &credentialMap)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var fields []*Field
+
+	for i, field := range constraints.Fields {
+		err = filterField(field, credentialMap)
+		if errors.Is(err, errPathNotApplicable) {
+			if !field.Optional {
+				failures = append(failures, &MatchFailure{Check: CheckFields, Field: field})
 			}
 
-			applicable = true
+			continue
 		}
 
-		if !applicable {
-			continue
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("filter field.%d: %w", i, err)
 		}
 
-		if constraints.LimitDisclosure.isRequired() || predicate {
-			template := credentialSrc
+		fields = append(fields, field)
+	}
 
-			var contexts []interface{}
+	return fields, credentialSrc, failures, nil
+}
 
-			for _, ctx := range credential.Context {
-				contexts = append(contexts, ctx)
-			}
+func requiresHolder(holders []*Holder) bool {
+	for _, holder := range holders {
+		if holder.Directive.isRequired() {
+			return true
+		}
+	}
 
-			contexts = append(contexts, credential.CustomContext...)
+	return false
+}
 
-			if constraints.LimitDisclosure.isRequired() {
-				template, err = json.Marshal(map[string]interface{}{
-					"id":                credential.ID,
-					"type":              credential.Types,
-					"@context":          contexts,
-					"issuer":            credential.Issuer,
-					"credentialSubject": toSubject(credential.Subject),
-					"issuanceDate":      credential.Issued,
-				})
-				if err != nil {
-					return nil, err
-				}
-			}
+// accepts checks status of the credential against statuses directives.
+func (s *Statuses) accepts(credential *verifiable.Credential, checker CredentialStatusChecker) bool {
+	if s == nil {
+		return true
+	}
 
-			var err error
+	status, err := checker(credential)
+	if err != nil {
+		logger.Warnf("failed to check status of credential '%s': %s", credential.ID, err)
 
-			credential, err = createNewCredential(constraints, credentialSrc, template, credential, opts...)
-			if err != nil {
-				return nil, fmt.Errorf("create new credential: %w", err)
+		return false
+	}
+
+	for _, directive := range []struct {
+		status    CredentialStatus
+		directive *StatusDirective
+	}{
+		{StatusActive, s.Active},
+		{StatusSuspended, s.Suspended},
+		{StatusRevoked, s.Revoked},
+	} {
+		if directive.directive == nil {
+			continue
+		}
+
+		switch directive.directive.Directive {
+		case DirectiveRequired:
+			if status != directive.status {
+				return false
 			}
+		case DirectiveDisallowed:
+			if status == directive.status {
+				return false
+			}
+		case DirectiveAllowed:
+		}
+	}
+
+	return true
+}
 
-			credential.ID = tmpID(credential.ID)
+// filterFormat returns credentials in one of the claim formats, linked data proof formats are matched by
+// proof type. Credentials without linked data proofs are matched by JWT formats.
+func filterFormat(format *Format, creds []*verifiable.Credential) []*verifiable.Credential {
+	if format == nil || !format.hasCredentialFormat() {
+		return creds
+	}
+
+	var result []*verifiable.Credential
+
+	for _, credential := range creds {
+		if format.matches(credential) {
+			result = append(result, credential)
 		}
+	}
 
-		result = append(result, credential)
+	return result
+}
+
+func (f *Format) hasCredentialFormat() bool {
+	return f.Jwt != nil || f.JwtVC != nil || f.Ldp != nil || f.LdpVC != nil
+}
+
+func (f *Format) matches(credential *verifiable.Credential) bool {
+	if len(credential.Proofs) == 0 {
+		return f.Jwt != nil || f.JwtVC != nil
 	}
 
-	return result, nil
+	for _, ldp := range []*LdpType{f.Ldp, f.LdpVC} {
+		if ldp == nil {
+			continue
+		}
+
+		if len(ldp.ProofType) == 0 {
+			return true
+		}
+
+		for _, proof := range credential.Proofs {
+			proofType, ok := proof["type"].(string)
+			if ok && contains(ldp.ProofType, proofType) {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 func toSubject(subject interface{}) interface{} {
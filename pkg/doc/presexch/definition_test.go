@@ -1430,6 +1430,351 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 	})
 }
 
+func TestParsePresentationDefinition(t *testing.T) {
+	t.Run("v1", func(t *testing.T) {
+		data, err := ioutil.ReadFile("testdata/sample_1.json")
+		require.NoError(t, err)
+
+		pd, err := ParsePresentationDefinition(data)
+		require.NoError(t, err)
+		require.Equal(t, Version1, pd.Version())
+
+		_, err = ParsePresentationDefinition(data, WithVersion(Version2))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Additional property schema is not allowed")
+	})
+
+	t.Run("v2", func(t *testing.T) {
+		pd, err := ParsePresentationDefinition([]byte(`{
+			"id": "32f54163-7166-48f1-93d8-ff217bdb0653",
+			"frame": {"@context": ["https://www.w3.org/2018/credentials/v1"]},
+			"input_descriptors": [{
+				"id": "banking_input",
+				"format": {"ldp_vc": {"proof_type": ["BbsBlsSignature2020"]}},
+				"constraints": {
+					"statuses": {"active": {"directive": "required"}},
+					"fields": [{
+						"path": ["$.credentialSubject.account[*].id"],
+						"optional": true,
+						"intent_to_retain": true,
+						"filter": {"type": "string"}
+					}]
+				}
+			}]
+		}`))
+		require.NoError(t, err)
+		require.Equal(t, Version2, pd.Version())
+		require.Equal(t, DirectiveRequired, pd.InputDescriptors[0].Constraints.Statuses.Active.Directive)
+		require.True(t, pd.InputDescriptors[0].Constraints.Fields[0].Optional)
+		require.True(t, pd.InputDescriptors[0].Constraints.Fields[0].IntentToRetain)
+		require.NoError(t, pd.ValidateSchema())
+	})
+
+	t.Run("v2 definition is not valid v1", func(t *testing.T) {
+		_, err := ParsePresentationDefinition([]byte(`{
+			"id": "32f54163-7166-48f1-93d8-ff217bdb0653",
+			"input_descriptors": [{"id": "banking_input", "constraints": {}}]
+		}`), WithVersion(Version1))
+		require.EqualError(t, err, "presentation_definition.input_descriptors.0: schema is required")
+	})
+
+	t.Run("invalid v2 definition", func(t *testing.T) {
+		_, err := ParsePresentationDefinition([]byte(`{
+			"id": "32f54163-7166-48f1-93d8-ff217bdb0653",
+			"input_descriptors": [{"id": "banking_input"}]
+		}`))
+		require.EqualError(t, err, "presentation_definition.input_descriptors.0: constraints is required")
+
+		_, err = ParsePresentationDefinition([]byte(`{
+			"id": "32f54163-7166-48f1-93d8-ff217bdb0653",
+			"input_descriptors": [{
+				"id": "banking_input",
+				"constraints": {"statuses": {"revoked": {"directive": "unknown"}}}
+			}]
+		}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "directive")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := ParsePresentationDefinition([]byte(`{"id": "1"}`), WithVersion("v3"))
+		require.EqualError(t, err, "unsupported presentation exchange version: v3")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := ParsePresentationDefinition([]byte(`[]`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal presentation definition")
+	})
+}
+
+func TestPresentationDefinition_CreateVPV2(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	t.Run("Matches by format", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID:          uuid.New().String(),
+				Format:      &Format{LdpVC: &LdpType{ProofType: []string{"BbsBlsSignature2020"}}},
+				Constraints: &Constraints{},
+			}},
+		}
+
+		bbs := newTestCredential("did:example:123")
+		bbs.Proofs = []verifiable.Proof{{"type": "BbsBlsSignature2020"}}
+
+		ed25519 := newTestCredential("did:example:123")
+		ed25519.Proofs = []verifiable.Proof{{"type": "Ed25519Signature2018"}}
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{ed25519, newTestCredential("did:example:123"), bbs}, lddl)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+		require.Equal(t, bbs.ID, vp.Credentials()[0].(*verifiable.Credential).ID)
+
+		checkSubmission(t, vp, pd)
+
+		pd.InputDescriptors[0].Format = nil
+		pd.Format = &Format{JwtVC: &JwtType{Alg: []string{"EdDSA"}}}
+
+		vp, err = pd.CreateVP([]*verifiable.Credential{ed25519, bbs}, lddl)
+		require.EqualError(t, err, errMsgSchema)
+		require.Nil(t, vp)
+	})
+
+	t.Run("Optional fields", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						Path: []string{"$.credentialSubject.name"},
+					}, {
+						Path:     []string{"$.credentialSubject.nickname"},
+						Optional: true,
+					}},
+				},
+			}},
+		}
+
+		named := newTestCredential("did:example:123")
+		named.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{"name": "Jayden Doe"}
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{newTestCredential("did:example:123"), named}, lddl)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+		require.Equal(t, named.ID, vp.Credentials()[0].(*verifiable.Credential).ID)
+	})
+
+	t.Run("Statuses", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Constraints: &Constraints{
+					Statuses: &Statuses{Active: &StatusDirective{Directive: DirectiveRequired}},
+				},
+			}},
+		}
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{newTestCredential("did:example:123")}, lddl)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		pd.InputDescriptors[0].Constraints.Statuses = &Statuses{
+			Active: &StatusDirective{Directive: DirectiveDisallowed},
+		}
+
+		vp, err = pd.CreateVP([]*verifiable.Credential{newTestCredential("did:example:123")}, lddl)
+		require.EqualError(t, err, errMsgSchema)
+		require.Nil(t, vp)
+	})
+
+	t.Run("Is holder", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Constraints: &Constraints{
+					IsHolder: []*Holder{{
+						FieldID:   []string{"name"},
+						Directive: &subIsIssuerRequired,
+					}},
+				},
+			}},
+		}
+
+		withoutSubjectID := newTestCredential("")
+		withSubjectID := newTestCredential("did:example:123")
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{withoutSubjectID, withSubjectID}, lddl)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+		require.Equal(t, withSubjectID.ID, vp.Credentials()[0].(*verifiable.Credential).ID)
+	})
+
+	t.Run("Same subject", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: "name",
+				Constraints: &Constraints{
+					SameSubject: []*Holder{{
+						FieldID:   []string{"name", "age"},
+						Directive: &subIsIssuerRequired,
+					}},
+					Fields: []*Field{{
+						ID:   "name",
+						Path: []string{"$.credentialSubject.name"},
+					}},
+				},
+			}, {
+				ID: "age",
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						ID:   "age",
+						Path: []string{"$.credentialSubject.age"},
+					}},
+				},
+			}},
+		}
+
+		name := newTestCredential("did:example:123")
+		name.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{"name": "Jayden Doe"}
+
+		otherName := newTestCredential("did:example:456")
+		otherName.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{"name": "Jamie Doe"}
+
+		age := newTestCredential("did:example:123")
+		age.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{"age": 17}
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{otherName, name, age}, lddl)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 2)
+
+		var ids []string
+		for _, vc := range vp.Credentials() {
+			ids = append(ids, vc.(*verifiable.Credential).ID)
+		}
+
+		require.ElementsMatch(t, []string{name.ID, age.ID}, ids)
+
+		vp, err = pd.CreateVP([]*verifiable.Credential{otherName, age}, lddl)
+		require.EqualError(t, err, errMsgSchema)
+		require.Nil(t, vp)
+	})
+
+	t.Run("Frame", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			Frame: map[string]interface{}{
+				"@context": []interface{}{
+					verifiable.ContextURI,
+					"https://w3id.org/citizenship/v1",
+					"https://w3id.org/security/bbs/v1",
+				},
+				"type":      []interface{}{"VerifiableCredential", "PermanentResidentCard"},
+				"@explicit": true,
+				"issuer":    map[string]interface{}{},
+				"credentialSubject": map[string]interface{}{
+					"@explicit":  true,
+					"type":       []interface{}{"PermanentResident", "Person"},
+					"givenName":  map[string]interface{}{},
+					"familyName": map[string]interface{}{},
+				},
+				"issuanceDate": map[string]interface{}{},
+			},
+			InputDescriptors: []*InputDescriptor{{
+				ID:     uuid.New().String(),
+				Format: &Format{LdpVC: &LdpType{ProofType: []string{"BbsBlsSignature2020"}}},
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						Path: []string{"$.credentialSubject.givenName"},
+					}},
+				},
+			}},
+		}
+
+		vc := &verifiable.Credential{
+			ID: "https://issuer.oidp.uscis.gov/credentials/83627465",
+			Context: []string{
+				verifiable.ContextURI,
+				"https://w3id.org/citizenship/v1",
+				"https://w3id.org/security/bbs/v1",
+			},
+			Types: []string{"VerifiableCredential", "PermanentResidentCard"},
+			Subject: verifiable.Subject{
+				ID: "did:example:b34ca6cd37bbf23",
+				CustomFields: map[string]interface{}{
+					"type":       []interface{}{"PermanentResident", "Person"},
+					"givenName":  "JOHN",
+					"familyName": "SMITH",
+					"gender":     "Male",
+				},
+			},
+			Issued: &util.TimeWrapper{
+				Time: time.Now(),
+			},
+			Issuer: verifiable.Issuer{
+				ID: "did:example:489398593",
+			},
+		}
+
+		publicKey, privateKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+		require.NoError(t, err)
+
+		srcPublicKey, err := publicKey.Marshal()
+		require.NoError(t, err)
+
+		signer, err := newBBSSigner(privateKey)
+		require.NoError(t, err)
+
+		require.NoError(t, vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+			SignatureType:           "BbsBlsSignature2020",
+			SignatureRepresentation: verifiable.SignatureProofValue,
+			Suite:                   bbsblssignature2020.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:123456#key1",
+		}, jsonld.WithDocumentLoader(lddl)))
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{vc}, lddl,
+			verifiable.WithJSONLDDocumentLoader(lddl),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(srcPublicKey, "Bls12381G2Key2020")),
+		)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		derived, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+		require.Equal(t, "BbsBlsSignatureProof2020", derived.Proofs[0]["type"])
+
+		subject := derived.Subject.([]verifiable.Subject)[0]
+		require.Equal(t, "JOHN", subject.CustomFields["givenName"])
+		require.Equal(t, "SMITH", subject.CustomFields["familyName"])
+		require.NotContains(t, subject.CustomFields, "gender")
+
+		vp, err = pd.CreateVP([]*verifiable.Credential{vc}, lddl)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "apply frame to credential")
+		require.Nil(t, vp)
+	})
+}
+
+func newTestCredential(subjectID string) *verifiable.Credential {
+	return &verifiable.Credential{
+		ID:      "http://example.edu/credentials/" + uuid.New().String(),
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType},
+		Subject: []verifiable.Subject{{ID: subjectID}},
+		Issued: &util.TimeWrapper{
+			Time: time.Now(),
+		},
+		Issuer: verifiable.Issuer{
+			ID: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		},
+	}
+}
+
 func checkSubmission(t *testing.T, vp *verifiable.Presentation, pd *PresentationDefinition) {
 	t.Helper()
 
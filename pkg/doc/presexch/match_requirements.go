@@ -0,0 +1,308 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// CheckSchema credential doesn't match any of input descriptor`s schemas.
+	CheckSchema MatchCheck = "schema"
+	// CheckFormat credential isn't in any of the claim formats requested.
+	CheckFormat MatchCheck = "format"
+	// CheckSubjectIsIssuer credential subject is not the issuer of credential.
+	CheckSubjectIsIssuer MatchCheck = "subject_is_issuer"
+	// CheckIsHolder credential subject is not identified, holder can't prove to be the subject.
+	CheckIsHolder MatchCheck = "is_holder"
+	// CheckStatuses credential status is not accepted by statuses directives.
+	CheckStatuses MatchCheck = "statuses"
+	// CheckFields credential doesn't match the field of constraints.
+	CheckFields MatchCheck = "fields"
+	// CheckSameSubject credential subject is not the subject of credentials matching other input descriptors.
+	CheckSameSubject MatchCheck = "same_subject"
+	// CheckDisclosure disclosure of credential can't be created, e.g. BBS+ selective disclosure failed.
+	CheckDisclosure MatchCheck = "disclosure"
+)
+
+// MatchCheck is a check of credential against input descriptor.
+type MatchCheck string
+
+// MatchFailure describes a check failed by credential.
+type MatchFailure struct {
+	Check MatchCheck `json:"check"`
+	// Field is the field not matched by credential, set for CheckFields failures.
+	Field  *Field `json:"field,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// CandidateCredential describes credential evaluated against input descriptor.
+type CandidateCredential struct {
+	Credential *verifiable.Credential `json:"credential"`
+	// Disclosure is the credential as it would be submitted, set only if credential matches input descriptor.
+	Disclosure *verifiable.Credential `json:"disclosure,omitempty"`
+	Failures   []*MatchFailure        `json:"failures,omitempty"`
+}
+
+// MatchedInputDescriptor describes credentials evaluated against input descriptor.
+type MatchedInputDescriptor struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name,omitempty"`
+	Purpose     string       `json:"purpose,omitempty"`
+	Schema      []*Schema    `json:"schema,omitempty"`
+	Format      *Format      `json:"format,omitempty"`
+	Constraints *Constraints `json:"constraints,omitempty"`
+	// MatchedVCs are the credentials matching input descriptor, holder can select among them.
+	MatchedVCs []*verifiable.Credential `json:"matched_vcs,omitempty"`
+	// Candidates are all the credentials evaluated, in the order given.
+	Candidates []*CandidateCredential `json:"candidates,omitempty"`
+}
+
+// MatchSubmissionRequirement describes credentials evaluated against submission requirement.
+type MatchSubmissionRequirement struct {
+	Name        string                        `json:"name,omitempty"`
+	Purpose     string                        `json:"purpose,omitempty"`
+	Rule        Selection                     `json:"rule,omitempty"`
+	Count       int                           `json:"count,omitempty"`
+	Min         int                           `json:"min,omitempty"`
+	Max         int                           `json:"max,omitempty"`
+	Descriptors []*MatchedInputDescriptor     `json:"descriptors,omitempty"`
+	Nested      []*MatchSubmissionRequirement `json:"nested,omitempty"`
+	// Satisfied is true if enough input descriptors, or nested requirements, are satisfied.
+	Satisfied bool `json:"satisfied"`
+}
+
+// MatchSubmissionRequirement evaluates credentials against presentation definition without selecting any of them.
+// Each submission requirement lists its input descriptors with the candidate credentials, the checks they failed
+// and the disclosure they would be submitted with, so holder can choose the credentials to submit by
+// CreateVPFromSelection. Definition without submission requirements is described by a single requirement of all
+// input descriptors.
+func (pd *PresentationDefinition) MatchSubmissionRequirement(credentials []*verifiable.Credential,
+	documentLoader ld.DocumentLoader, options ...MatchOption) ([]*MatchSubmissionRequirement, error) {
+	if err := pd.ValidateSchema(); err != nil {
+		return nil, err
+	}
+
+	if _, err := makeRequirement(pd.SubmissionRequirements, pd.InputDescriptors); err != nil {
+		return nil, err
+	}
+
+	opts := &MatchOptions{}
+
+	for i := range options {
+		options[i](opts)
+	}
+
+	descriptors := make([]*MatchedInputDescriptor, len(pd.InputDescriptors))
+	candidates := make(map[string][]*verifiable.Credential, len(pd.InputDescriptors))
+
+	for i, descriptor := range pd.InputDescriptors {
+		matched, err := pd.matchInputDescriptor(descriptor, credentials, documentLoader, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		descriptors[i] = matched
+		candidates[descriptor.ID] = matched.MatchedVCs
+	}
+
+	pd.filterSameSubject(candidates)
+
+	for _, matched := range descriptors {
+		matched.excludeCandidates(candidates[matched.ID], &MatchFailure{Check: CheckSameSubject})
+
+		if pd.Frame != nil {
+			pd.frameCandidates(matched, opts)
+		}
+	}
+
+	if len(pd.SubmissionRequirements) == 0 {
+		return []*MatchSubmissionRequirement{newMatchSubmissionRequirement(&SubmissionRequirement{
+			Rule: All,
+		}, descriptors, nil)}, nil
+	}
+
+	result := make([]*MatchSubmissionRequirement, len(pd.SubmissionRequirements))
+
+	for i, sr := range pd.SubmissionRequirements {
+		result[i] = pd.matchSubmissionRequirement(sr, descriptors)
+	}
+
+	return result, nil
+}
+
+func (pd *PresentationDefinition) matchSubmissionRequirement(sr *SubmissionRequirement,
+	descriptors []*MatchedInputDescriptor) *MatchSubmissionRequirement {
+	if sr.From != "" {
+		var grouped []*MatchedInputDescriptor
+
+		for i, descriptor := range pd.InputDescriptors {
+			if contains(descriptor.Group, sr.From) {
+				grouped = append(grouped, descriptors[i])
+			}
+		}
+
+		return newMatchSubmissionRequirement(sr, grouped, nil)
+	}
+
+	nested := make([]*MatchSubmissionRequirement, len(sr.FromNested))
+
+	for i, nestedRequirement := range sr.FromNested {
+		nested[i] = pd.matchSubmissionRequirement(nestedRequirement, descriptors)
+	}
+
+	return newMatchSubmissionRequirement(sr, nil, nested)
+}
+
+func newMatchSubmissionRequirement(sr *SubmissionRequirement, descriptors []*MatchedInputDescriptor,
+	nested []*MatchSubmissionRequirement) *MatchSubmissionRequirement {
+	req := &requirement{Count: sr.Count, Min: sr.Min, Max: sr.Max}
+
+	var satisfied int
+
+	for _, descriptor := range descriptors {
+		if len(descriptor.MatchedVCs) != 0 {
+			satisfied++
+		}
+	}
+
+	for _, n := range nested {
+		if n.Satisfied {
+			satisfied++
+		}
+	}
+
+	if sr.Rule == All {
+		req.Count = len(descriptors) + len(nested)
+	}
+
+	return &MatchSubmissionRequirement{
+		Name:        sr.Name,
+		Purpose:     sr.Purpose,
+		Rule:        sr.Rule,
+		Count:       req.Count,
+		Min:         sr.Min,
+		Max:         sr.Max,
+		Descriptors: descriptors,
+		Nested:      nested,
+		Satisfied:   req.isLenApplicable(satisfied),
+	}
+}
+
+// matchInputDescriptor evaluates each of credentials against input descriptor.
+func (pd *PresentationDefinition) matchInputDescriptor(descriptor *InputDescriptor,
+	credentials []*verifiable.Credential, documentLoader ld.DocumentLoader,
+	opts *MatchOptions) (*MatchedInputDescriptor, error) {
+	matched := &MatchedInputDescriptor{
+		ID:          descriptor.ID,
+		Name:        descriptor.Name,
+		Purpose:     descriptor.Purpose,
+		Schema:      descriptor.Schema,
+		Format:      pd.descriptorFormat(descriptor),
+		Constraints: descriptor.Constraints,
+	}
+
+	for _, credential := range credentials {
+		candidate := &CandidateCredential{Credential: credential}
+
+		creds := []*verifiable.Credential{credential}
+
+		if len(descriptor.Schema) > 0 && len(filterSchema(descriptor.Schema, creds, documentLoader)) == 0 {
+			candidate.Failures = append(candidate.Failures, &MatchFailure{Check: CheckSchema})
+		}
+
+		if len(filterFormat(matched.Format, creds)) == 0 {
+			candidate.Failures = append(candidate.Failures, &MatchFailure{Check: CheckFormat})
+		}
+
+		disclosure := credential
+
+		if descriptor.Constraints != nil {
+			fields, credentialSrc, failures, err := checkConstraints(descriptor.Constraints, credential,
+				opts.StatusChecker)
+			if err != nil {
+				return nil, err
+			}
+
+			candidate.Failures = append(candidate.Failures, failures...)
+
+			if len(candidate.Failures) == 0 {
+				disclosure, err = discloseCredential(descriptor.Constraints, fields, credentialSrc, credential,
+					opts.CredentialOptions...)
+				if err != nil {
+					candidate.Failures = append(candidate.Failures, &MatchFailure{
+						Check:  CheckDisclosure,
+						Reason: err.Error(),
+					})
+				}
+			}
+		}
+
+		if len(candidate.Failures) == 0 {
+			candidate.Disclosure = disclosure
+			matched.MatchedVCs = append(matched.MatchedVCs, credential)
+		}
+
+		matched.Candidates = append(matched.Candidates, candidate)
+	}
+
+	return matched, nil
+}
+
+// frameCandidates applies frame to disclosures of matching candidates.
+func (pd *PresentationDefinition) frameCandidates(matched *MatchedInputDescriptor, opts *MatchOptions) {
+	var framed []*verifiable.Credential
+
+	for _, candidate := range matched.Candidates {
+		if candidate.Disclosure == nil {
+			continue
+		}
+
+		disclosures, err := pd.applyFrame([]*verifiable.Credential{candidate.Disclosure}, opts.CredentialOptions...)
+		if err != nil {
+			candidate.Disclosure = nil
+			candidate.Failures = append(candidate.Failures, &MatchFailure{Check: CheckDisclosure, Reason: err.Error()})
+
+			continue
+		}
+
+		candidate.Disclosure = disclosures[0]
+		framed = append(framed, candidate.Credential)
+	}
+
+	matched.MatchedVCs = framed
+}
+
+// excludeCandidates marks matching candidates not kept as failed.
+func (d *MatchedInputDescriptor) excludeCandidates(kept []*verifiable.Credential, failure *MatchFailure) {
+	keep := make(map[*verifiable.Credential]struct{}, len(kept))
+
+	for _, credential := range kept {
+		keep[credential] = struct{}{}
+	}
+
+	var matched []*verifiable.Credential
+
+	for _, candidate := range d.Candidates {
+		if candidate.Disclosure == nil {
+			continue
+		}
+
+		if _, ok := keep[candidate.Credential]; !ok {
+			candidate.Disclosure = nil
+			candidate.Failures = append(candidate.Failures, failure)
+
+			continue
+		}
+
+		matched = append(matched, candidate.Credential)
+	}
+
+	d.MatchedVCs = matched
+}
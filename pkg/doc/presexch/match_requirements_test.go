@@ -0,0 +1,320 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+func TestPresentationDefinition_MatchSubmissionRequirement(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+	loaderOpt := WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(lddl))
+	required := Required
+
+	nameField := &Field{ID: "name", Path: []string{"$.credentialSubject.name"}}
+	ageField := &Field{
+		ID:        "age",
+		Path:      []string{"$.credentialSubject.age"},
+		Filter:    &Filter{Type: &intFilterType, Minimum: 18},
+		Predicate: &required,
+	}
+
+	newDefinition := func() *PresentationDefinition {
+		return &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID:      "name",
+				Group:   []string{"A"},
+				Purpose: "We need your name.",
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+				}},
+				Constraints: &Constraints{
+					LimitDisclosure: &required,
+					Fields:          []*Field{nameField},
+				},
+			}, {
+				ID:    "age",
+				Group: []string{"B"},
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+				}},
+				Constraints: &Constraints{
+					Fields: []*Field{ageField},
+				},
+			}},
+		}
+	}
+
+	name := newTestCredential("did:example:123")
+	name.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{
+		"name": "Jayden Doe", "nickname": "Jay",
+	}
+
+	child := newTestCredential("did:example:123")
+	child.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{"age": 12}
+
+	adult := newTestCredential("did:example:123")
+	adult.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{"age": 21}
+
+	t.Run("explains matches of all input descriptors", func(t *testing.T) {
+		pd := newDefinition()
+
+		result, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{name, child, adult}, lddl, loaderOpt)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, All, result[0].Rule)
+		require.Equal(t, 2, result[0].Count)
+		require.True(t, result[0].Satisfied)
+		require.Len(t, result[0].Descriptors, 2)
+
+		nameDescriptor := result[0].Descriptors[0]
+		require.Equal(t, "name", nameDescriptor.ID)
+		require.Equal(t, "We need your name.", nameDescriptor.Purpose)
+		require.Equal(t, []*verifiable.Credential{name}, nameDescriptor.MatchedVCs)
+		require.Len(t, nameDescriptor.Candidates, 3)
+
+		disclosure := nameDescriptor.Candidates[0].Disclosure
+		require.NotNil(t, disclosure)
+		require.Empty(t, nameDescriptor.Candidates[0].Failures)
+
+		subject := disclosure.Subject.([]verifiable.Subject)[0]
+		require.Equal(t, "Jayden Doe", subject.CustomFields["name"])
+		require.NotContains(t, subject.CustomFields, "nickname")
+
+		for _, candidate := range nameDescriptor.Candidates[1:] {
+			require.Nil(t, candidate.Disclosure)
+			require.Equal(t, []*MatchFailure{{Check: CheckFields, Field: nameField}}, candidate.Failures)
+		}
+
+		ageDescriptor := result[0].Descriptors[1]
+		require.Equal(t, []*verifiable.Credential{adult}, ageDescriptor.MatchedVCs)
+		require.Equal(t, []*MatchFailure{{Check: CheckFields, Field: ageField}}, ageDescriptor.Candidates[1].Failures)
+
+		disclosure = ageDescriptor.Candidates[2].Disclosure
+		require.Equal(t, true, disclosure.Subject.([]verifiable.Subject)[0].CustomFields["age"])
+
+		result, err = pd.MatchSubmissionRequirement([]*verifiable.Credential{name, child}, lddl, loaderOpt)
+		require.NoError(t, err)
+		require.False(t, result[0].Satisfied)
+	})
+
+	t.Run("explains submission requirements", func(t *testing.T) {
+		pd := newDefinition()
+		pd.SubmissionRequirements = []*SubmissionRequirement{{
+			Name: "Name or age",
+			Rule: Pick,
+			Min:  1,
+			FromNested: []*SubmissionRequirement{
+				{Rule: All, From: "A"},
+				{Rule: All, From: "B"},
+			},
+		}}
+
+		result, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{name, child}, lddl, loaderOpt)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "Name or age", result[0].Name)
+		require.True(t, result[0].Satisfied)
+		require.Len(t, result[0].Nested, 2)
+		require.True(t, result[0].Nested[0].Satisfied)
+		require.Equal(t, "name", result[0].Nested[0].Descriptors[0].ID)
+		require.False(t, result[0].Nested[1].Satisfied)
+		require.Equal(t, "age", result[0].Nested[1].Descriptors[0].ID)
+
+		pd.SubmissionRequirements[0].FromNested[1].From = "C"
+
+		_, err = pd.MatchSubmissionRequirement([]*verifiable.Credential{name, child}, lddl, loaderOpt)
+		require.EqualError(t, err, "no descriptors for from: C")
+	})
+
+	t.Run("explains failed checks", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID:     uuid.New().String(),
+			Format: &Format{LdpVC: &LdpType{ProofType: []string{"BbsBlsSignature2020"}}},
+			InputDescriptors: []*InputDescriptor{{
+				ID: "name",
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", verifiable.ContextID, "UniversityDegreeCredential"),
+				}},
+				Constraints: &Constraints{
+					SubjectIsIssuer: &required,
+					Statuses:        &Statuses{Revoked: &StatusDirective{Directive: DirectiveDisallowed}},
+				},
+			}},
+		}
+
+		result, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{name}, lddl, loaderOpt,
+			WithCredentialStatusChecker(func(*verifiable.Credential) (CredentialStatus, error) {
+				return "", errors.New("status list not found")
+			}))
+		require.NoError(t, err)
+		require.False(t, result[0].Satisfied)
+		require.Equal(t, []*MatchFailure{
+			{Check: CheckSchema},
+			{Check: CheckFormat},
+			{Check: CheckSubjectIsIssuer},
+			{Check: CheckStatuses},
+		}, result[0].Descriptors[0].Candidates[0].Failures)
+
+		result, err = pd.MatchSubmissionRequirement([]*verifiable.Credential{name}, lddl, loaderOpt)
+		require.NoError(t, err)
+		require.Contains(t, result[0].Descriptors[0].Candidates[0].Failures,
+			&MatchFailure{Check: CheckStatuses, Reason: "statuses constraints require credential status checker"})
+	})
+
+	t.Run("explains same subject", func(t *testing.T) {
+		pd := newDefinition()
+		pd.InputDescriptors[0].Constraints.SameSubject = []*Holder{{
+			FieldID:   []string{"name", "age"},
+			Directive: &required,
+		}}
+
+		otherAdult := newTestCredential("did:example:456")
+		otherAdult.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{"age": 30}
+
+		result, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{name, otherAdult, adult}, lddl, loaderOpt)
+		require.NoError(t, err)
+		require.True(t, result[0].Satisfied)
+
+		ageDescriptor := result[0].Descriptors[1]
+		require.Equal(t, []*verifiable.Credential{adult}, ageDescriptor.MatchedVCs)
+		require.Equal(t, []*MatchFailure{{Check: CheckSameSubject}}, ageDescriptor.Candidates[1].Failures)
+		require.Nil(t, ageDescriptor.Candidates[1].Disclosure)
+	})
+
+	t.Run("explains frame failure", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID:    uuid.New().String(),
+			Frame: map[string]interface{}{"@explicit": true},
+			InputDescriptors: []*InputDescriptor{{
+				ID:          "bbs",
+				Constraints: &Constraints{},
+			}},
+		}
+
+		bbs := newTestCredential("did:example:123")
+		bbs.Proofs = []verifiable.Proof{{"type": "BbsBlsSignature2020"}}
+
+		result, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{bbs, name}, lddl, loaderOpt)
+		require.NoError(t, err)
+
+		descriptor := result[0].Descriptors[0]
+		require.Equal(t, []*verifiable.Credential{name}, descriptor.MatchedVCs)
+		require.Len(t, descriptor.Candidates[0].Failures, 1)
+		require.Equal(t, CheckDisclosure, descriptor.Candidates[0].Failures[0].Check)
+		require.Contains(t, descriptor.Candidates[0].Failures[0].Reason, "apply frame to credential")
+		require.Equal(t, name, descriptor.Candidates[1].Disclosure)
+	})
+
+	t.Run("invalid definition", func(t *testing.T) {
+		_, err := (&PresentationDefinition{}).MatchSubmissionRequirement(nil, lddl)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "id is required")
+	})
+}
+
+func TestPresentationDefinition_CreateVPFromSelection(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+	required := Required
+
+	pd := &PresentationDefinition{
+		ID: uuid.New().String(),
+		SubmissionRequirements: []*SubmissionRequirement{{
+			Rule: Pick,
+			Min:  1,
+			From: "A",
+		}},
+		InputDescriptors: []*InputDescriptor{{
+			ID:    "name",
+			Group: []string{"A"},
+			Constraints: &Constraints{
+				LimitDisclosure: &required,
+				SameSubject:     []*Holder{{FieldID: []string{"name", "age"}, Directive: &required}},
+				Fields:          []*Field{{ID: "name", Path: []string{"$.credentialSubject.name"}}},
+			},
+		}, {
+			ID:    "age",
+			Group: []string{"A"},
+			Constraints: &Constraints{
+				Fields: []*Field{{ID: "age", Path: []string{"$.credentialSubject.age"}}},
+			},
+		}},
+	}
+
+	name := newTestCredential("did:example:123")
+	name.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{
+		"name": "Jayden Doe", "nickname": "Jay",
+	}
+
+	otherName := newTestCredential("did:example:456")
+	otherName.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{"name": "Jamie Doe"}
+
+	age := newTestCredential("did:example:123")
+	age.Subject.([]verifiable.Subject)[0].CustomFields = verifiable.CustomFields{"age": 21}
+
+	t.Run("creates presentation of selected credentials", func(t *testing.T) {
+		vp, err := pd.CreateVPFromSelection(map[string][]*verifiable.Credential{
+			"name": {name},
+		}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		disclosed, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+		require.Equal(t, name.ID, disclosed.ID)
+		require.NotContains(t, disclosed.Subject.([]verifiable.Subject)[0].CustomFields, "nickname")
+
+		checkSubmission(t, vp, pd)
+
+		vp, err = pd.CreateVPFromSelection(map[string][]*verifiable.Credential{
+			"name": {name},
+			"age":  {age},
+		}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 2)
+	})
+
+	t.Run("selected credentials must satisfy input descriptor", func(t *testing.T) {
+		_, err := pd.CreateVPFromSelection(map[string][]*verifiable.Credential{
+			"name": {name, age},
+		}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.EqualError(t, err, "selected credentials do not satisfy input descriptor 'name'")
+
+		_, err = pd.CreateVPFromSelection(map[string][]*verifiable.Credential{
+			"unknown": {name},
+		}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.EqualError(t, err, "input descriptor 'unknown' not found")
+
+		_, err = pd.CreateVPFromSelection(map[string][]*verifiable.Credential{
+			"name": {otherName},
+			"age":  {age},
+		}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.EqualError(t, err, "selected credentials of input descriptor 'name' do not have the same subject")
+	})
+
+	t.Run("selection must satisfy submission requirements", func(t *testing.T) {
+		all := *pd
+		all.SubmissionRequirements = nil
+
+		_, err := all.CreateVPFromSelection(map[string][]*verifiable.Credential{
+			"name": {name},
+		}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.True(t, errors.Is(err, ErrNoCredentials))
+
+		_, err = (&PresentationDefinition{}).CreateVPFromSelection(nil, lddl)
+		require.Error(t, err)
+	})
+}
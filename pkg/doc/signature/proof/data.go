@@ -85,16 +85,21 @@ func CreateVerifyHash(suite signatureSuite, jsonldDoc, proofOptions map[string]i
 
 func prepareCanonicalProofOptions(suite signatureSuite, proofOptions map[string]interface{},
 	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	// created is optional for Data Integrity proofs
 	value, ok := proofOptions[jsonldCreated]
-	if !ok || value == nil {
+	if (!ok || value == nil) && proofOptions[jsonldCryptosuite] == nil {
 		return nil, errors.New("created is missing")
 	}
 
 	// copy from the original proof options map without specific keys
 	proofOptionsCopy := make(map[string]interface{}, len(proofOptions))
 
+	// Data Integrity proof configuration is the proof without proofValue only
+	isDataIntegrity := proofOptions[jsonldCryptosuite] != nil
+
 	for key, value := range proofOptions {
-		if excludedKeyFromString(key) == 0 {
+		ek := excludedKeyFromString(key)
+		if ek == 0 || (isDataIntegrity && ek != proofValue) {
 			proofOptionsCopy[key] = value
 		}
 	}
@@ -77,6 +77,29 @@ func TestPrepareCanonicalProofOptions(t *testing.T) {
 	require.NotNil(t, err)
 	require.Nil(t, canonicalProofOptions)
 	require.Contains(t, err.Error(), "created is missing")
+
+	// created is optional for Data Integrity proofs
+	proofOptions["cryptosuite"] = "eddsa-rdfc-2022"
+	canonicalProofOptions, err = prepareCanonicalProofOptions(
+		&mockSignatureSuite{}, proofOptions, ldtestutil.WithDocumentLoader(t))
+
+	require.NoError(t, err)
+	require.NotEmpty(t, canonicalProofOptions)
+
+	// nonce is part of Data Integrity proof configuration, proofValue is not
+	proofOptions["proofValue"] = "z58DAdFfa9SkqZMVPxAQp"
+	withProofValue, err := prepareCanonicalProofOptions(
+		&mockSignatureSuite{}, proofOptions, ldtestutil.WithDocumentLoader(t))
+
+	require.NoError(t, err)
+	require.Equal(t, canonicalProofOptions, withProofValue)
+
+	delete(proofOptions, "nonce")
+	withoutNonce, err := prepareCanonicalProofOptions(
+		&mockSignatureSuite{}, proofOptions, ldtestutil.WithDocumentLoader(t))
+
+	require.NoError(t, err)
+	require.NotEqual(t, canonicalProofOptions, withoutNonce)
 }
 
 func TestCreateVerifyData(t *testing.T) {
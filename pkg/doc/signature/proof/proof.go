@@ -10,6 +10,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/multiformats/go-multibase"
+
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 )
 
@@ -36,6 +38,11 @@ const (
 	jsonldChallenge = "challenge"
 	// jsonldCapabilityChain is a key for capabilityChain.
 	jsonldCapabilityChain = "capabilityChain"
+	// jsonldCryptosuite is a key for cryptosuite of Data Integrity proof.
+	jsonldCryptosuite = "cryptosuite"
+
+	// ed25519Signature2020 is a signature type which, like Data Integrity proofs, has multibase proofValue.
+	ed25519Signature2020 = "Ed25519Signature2020"
 )
 
 // Proof is cryptographic proof of the integrity of the DID Document.
@@ -53,13 +60,16 @@ type Proof struct {
 	SignatureRepresentation SignatureRepresentation
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// Cryptosuite is set for Data Integrity proofs only.
+	Cryptosuite string
 }
 
 // NewProof creates new proof.
 func NewProof(emap map[string]interface{}) (*Proof, error) {
-	created := stringEntry(emap[jsonldCreated])
+	proofType := stringEntry(emap[jsonldType])
+	cryptosuite := stringEntry(emap[jsonldCryptosuite])
 
-	timeValue, err := util.ParseTimeWrapper(created)
+	timeValue, err := parseCreated(stringEntry(emap[jsonldCreated]), cryptosuite)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +81,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 	)
 
 	if generalProof, ok := emap[jsonldProofValue]; ok {
-		proofValue, err = decodeBase64(stringEntry(generalProof))
+		proofValue, err = decodeProofValue(stringEntry(generalProof), isMultibase(proofType, cryptosuite))
 		if err != nil {
 			return nil, err
 		}
@@ -97,7 +107,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 	}
 
 	return &Proof{
-		Type:                    stringEntry(emap[jsonldType]),
+		Type:                    proofType,
 		Created:                 timeValue,
 		Creator:                 stringEntry(emap[jsonldCreator]),
 		VerificationMethod:      stringEntry(emap[jsonldVerificationMethod]),
@@ -109,6 +119,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		Nonce:                   nonce,
 		Challenge:               stringEntry(emap[jsonldChallenge]),
 		CapabilityChain:         capabilityChain,
+		Cryptosuite:             cryptosuite,
 	}, nil
 }
 
@@ -128,6 +139,49 @@ func decodeCapabilityChain(proof map[string]interface{}) ([]interface{}, error)
 	return capabilityChain, nil
 }
 
+// MultibaseProofValue tells if proofValue of this proof is multibase encoded
+// (Data Integrity and Ed25519Signature2020 proofs) rather than base64 encoded.
+func (p *Proof) MultibaseProofValue() bool {
+	return isMultibase(p.Type, p.Cryptosuite)
+}
+
+// parseCreated parses the proof creation time. It is optional for Data Integrity proofs only.
+func parseCreated(created, cryptosuite string) (*util.TimeWrapper, error) {
+	if created == "" && cryptosuite != "" {
+		return nil, nil
+	}
+
+	return util.ParseTimeWrapper(created)
+}
+
+func isMultibase(proofType, cryptosuite string) bool {
+	return cryptosuite != "" || proofType == ed25519Signature2020
+}
+
+func decodeProofValue(s string, multibaseEncoded bool) ([]byte, error) {
+	if !multibaseEncoded {
+		return decodeBase64(s)
+	}
+
+	_, value, err := multibase.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode multibase proof value: %w", err)
+	}
+
+	return value, nil
+}
+
+func encodeProofValue(value []byte, multibaseEncoded bool) string {
+	if !multibaseEncoded {
+		return base64.RawURLEncoding.EncodeToString(value)
+	}
+
+	// encoding never fails for a known multibase encoding.
+	s, _ := multibase.Encode(multibase.Base58BTC, value) //nolint:errcheck
+
+	return s
+}
+
 func decodeBase64(s string) ([]byte, error) {
 	allEncodings := []*base64.Encoding{
 		base64.RawURLEncoding, base64.StdEncoding, base64.RawStdEncoding,
@@ -165,12 +219,16 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 		emap[jsonldVerificationMethod] = p.VerificationMethod
 	}
 
+	if p.Cryptosuite != "" {
+		emap[jsonldCryptosuite] = p.Cryptosuite
+	}
+
 	if p.Created != nil {
 		emap[jsonldCreated] = p.Created.FormatToString()
 	}
 
 	if len(p.ProofValue) > 0 {
-		emap[jsonldProofValue] = base64.RawURLEncoding.EncodeToString(p.ProofValue)
+		emap[jsonldProofValue] = encodeProofValue(p.ProofValue, p.MultibaseProofValue())
 	}
 
 	if len(p.JWS) > 0 {
@@ -255,6 +255,66 @@ func TestProof_JSONLdObject(t *testing.T) {
 	})
 }
 
+func TestProof_Cryptosuite(t *testing.T) {
+	created, err := time.Parse(time.RFC3339, "2018-03-15T00:00:00Z")
+	require.NoError(t, err)
+
+	p := &Proof{
+		Type:               "DataIntegrityProof",
+		Cryptosuite:        "eddsa-rdfc-2022",
+		Created:            util.NewTime(created),
+		VerificationMethod: "did:example:123#key-1",
+		ProofValue:         []byte("signature"),
+		ProofPurpose:       "assertionMethod",
+	}
+
+	pJSONLd := p.JSONLdObject()
+	require.Equal(t, "eddsa-rdfc-2022", pJSONLd["cryptosuite"])
+	require.Equal(t, "z2UCrRtf48gBt8", pJSONLd["proofValue"])
+
+	parsed, err := NewProof(pJSONLd)
+	require.NoError(t, err)
+	require.Equal(t, "eddsa-rdfc-2022", parsed.Cryptosuite)
+	require.Equal(t, []byte("signature"), parsed.ProofValue)
+
+	delete(pJSONLd, "created")
+
+	parsed, err = NewProof(pJSONLd)
+	require.NoError(t, err)
+	require.Nil(t, parsed.Created)
+	require.NotContains(t, parsed.JSONLdObject(), "created")
+
+	pJSONLd["proofValue"] = "not multibase"
+
+	_, err = NewProof(pJSONLd)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode multibase proof value")
+}
+
+func TestProof_MultibaseProofValue(t *testing.T) {
+	require.True(t, (&Proof{Type: "DataIntegrityProof", Cryptosuite: "eddsa-rdfc-2022"}).MultibaseProofValue())
+	require.True(t, (&Proof{Type: "Ed25519Signature2020"}).MultibaseProofValue())
+	require.False(t, (&Proof{Type: "Ed25519Signature2018"}).MultibaseProofValue())
+
+	created, err := time.Parse(time.RFC3339, "2018-03-15T00:00:00Z")
+	require.NoError(t, err)
+
+	p := &Proof{
+		Type:               "Ed25519Signature2020",
+		Created:            util.NewTime(created),
+		VerificationMethod: "did:example:123#key-1",
+		ProofValue:         []byte("signature"),
+	}
+
+	pJSONLd := p.JSONLdObject()
+	require.Equal(t, "z2UCrRtf48gBt8", pJSONLd["proofValue"])
+	require.NotContains(t, pJSONLd, "cryptosuite")
+
+	parsed, err := NewProof(pJSONLd)
+	require.NoError(t, err)
+	require.Equal(t, []byte("signature"), parsed.ProofValue)
+}
+
 func TestProof_PublicKeyID(t *testing.T) {
 	p := Proof{
 		Creator:            "creator",
@@ -36,6 +36,12 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// cryptosuite is implemented by Data Integrity signature suites, which share a single
+// "DataIntegrityProof" signature type and are told apart by their cryptosuite.
+type cryptosuite interface {
+	Cryptosuite() string
+}
+
 // DocumentSigner implements signing of JSONLD documents.
 type DocumentSigner struct {
 	signatureSuites []SignatureSuite
@@ -53,6 +59,7 @@ type Context struct {
 	Challenge               string                        // optional
 	Purpose                 string                        // optional
 	CapabilityChain         []interface{}                 // optional
+	Cryptosuite             string                        // optional
 }
 
 // New returns new instance of document verifier.
@@ -89,7 +96,7 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		return err
 	}
 
-	suite, err := signer.getSignatureSuite(context.SignatureType)
+	suite, err := signer.getSignatureSuite(context.SignatureType, context.Cryptosuite)
 	if err != nil {
 		return err
 	}
@@ -113,6 +120,14 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		CapabilityChain:         context.CapabilityChain,
 	}
 
+	if cs, ok := suite.(cryptosuite); ok {
+		p.Cryptosuite = cs.Cryptosuite()
+	}
+
+	if p.MultibaseProofValue() && context.SignatureRepresentation != proof.SignatureProofValue {
+		return fmt.Errorf("%s supports proofValue signature representation only", p.Type)
+	}
+
 	// TODO support custom proof purpose
 	//  (https://github.com/hyperledger/aries-framework-go/issues/1586)
 	if p.ProofPurpose == "" {
@@ -147,12 +162,18 @@ func (signer *DocumentSigner) applySignatureValue(context *Context, p *proof.Pro
 	}
 }
 
-// getSignatureSuite returns signature suite based on signature type.
-func (signer *DocumentSigner) getSignatureSuite(signatureType string) (SignatureSuite, error) {
+// getSignatureSuite returns signature suite based on signature type and, if set, the cryptosuite.
+func (signer *DocumentSigner) getSignatureSuite(signatureType, cryptosuiteName string) (SignatureSuite, error) {
 	for _, s := range signer.signatureSuites {
-		if s.Accept(signatureType) {
-			return s, nil
+		if !s.Accept(signatureType) {
+			continue
+		}
+
+		if cs, ok := s.(cryptosuite); ok && cryptosuiteName != "" && cs.Cryptosuite() != cryptosuiteName {
+			continue
 		}
+
+		return s, nil
 	}
 
 	return nil, fmt.Errorf("signature type %s not supported", signatureType)
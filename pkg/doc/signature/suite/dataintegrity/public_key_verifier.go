@@ -0,0 +1,46 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dataintegrity
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// PublicKeyVerifier verifies Ed25519, ECDSA P-256 and ECDSA P-384 signatures of the Data Integrity cryptosuites.
+// Unlike verifier.NewCompositePublicKeyVerifier, it selects the signature verifier by the key itself,
+// so public key bytes without JSON Web Key are supported for all key types.
+type PublicKeyVerifier struct {
+	ed25519 *verifier.PublicKeyVerifier
+	p256    *verifier.PublicKeyVerifier
+	p384    *verifier.PublicKeyVerifier
+}
+
+// NewPublicKeyVerifier creates a signature verifier that verifies a Ed25519 / ECDSA (P-256, P-384) signature
+// taking public key bytes or JSON Web Key as input.
+func NewPublicKeyVerifier() *PublicKeyVerifier {
+	return &PublicKeyVerifier{
+		ed25519: verifier.NewPublicKeyVerifier(verifier.NewEd25519SignatureVerifier()),
+		p256:    verifier.NewPublicKeyVerifier(verifier.NewECDSAES256SignatureVerifier()),
+		p384:    verifier.NewPublicKeyVerifier(verifier.NewECDSAES384SignatureVerifier()),
+	}
+}
+
+// Verify verifies the signature.
+func (v *PublicKeyVerifier) Verify(pubKey *verifier.PublicKey, msg, signature []byte) error {
+	switch curve := curveName(pubKey); curve {
+	case p256Curve:
+		return v.p256.Verify(pubKey, msg, signature)
+	case p384Curve:
+		return v.p384.Verify(pubKey, msg, signature)
+	case "", "Ed25519":
+		return v.ed25519.Verify(pubKey, msg, signature)
+	default:
+		return fmt.Errorf("unsupported curve of public key: %s", curve)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dataintegrity
+
+import (
+	"testing"
+
+	gojose "github.com/square/go-jose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestPublicKeyVerifier_Verify(t *testing.T) {
+	msg := []byte("test message")
+
+	for _, keyType := range []kmsapi.KeyType{
+		kmsapi.ED25519Type, kmsapi.ECDSAP256TypeIEEEP1363, kmsapi.ECDSAP384TypeIEEEP1363,
+	} {
+		s, err := signature.NewSigner(keyType)
+		require.NoError(t, err)
+
+		msgSig, err := s.Sign(msg)
+		require.NoError(t, err)
+
+		v := NewPublicKeyVerifier()
+
+		err = v.Verify(&verifier.PublicKey{Type: "Multikey", Value: s.PublicKeyBytes()}, msg, msgSig)
+		require.NoError(t, err, keyType)
+
+		err = v.Verify(&verifier.PublicKey{Type: "Multikey", Value: s.PublicKeyBytes()}, []byte("other"), msgSig)
+		require.Error(t, err, keyType)
+	}
+
+	t.Run("unsupported curve", func(t *testing.T) {
+		err := NewPublicKeyVerifier().Verify(&verifier.PublicKey{
+			Type: "JsonWebKey2020",
+			JWK: &jwk.JWK{
+				JSONWebKey: gojose.JSONWebKey{},
+				Kty:        "EC",
+				Crv:        "P-521",
+			},
+		}, []byte("msg"), []byte("signature"))
+		require.EqualError(t, err, "unsupported curve of public key: P-521")
+	})
+}
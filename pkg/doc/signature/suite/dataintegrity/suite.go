@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package dataintegrity implements the DataIntegrityProof proof type of the Verifiable Credential
+// Data Integrity specification (https://www.w3.org/TR/vc-data-integrity/) with the eddsa-rdfc-2022
+// (https://www.w3.org/TR/vc-di-eddsa/) and ecdsa-rdfc-2019 (https://www.w3.org/TR/vc-di-ecdsa/) cryptosuites.
+// Both cryptosuites use the RDF Dataset Canonicalization Algorithm to transform the input document and
+// the proof configuration into their canonical forms.
+// SHA-256 is used as the message digest algorithm for eddsa-rdfc-2022 and for ecdsa-rdfc-2019 with P-256 keys,
+// SHA-384 is used for ecdsa-rdfc-2019 with P-384 keys.
+// The signature is put into "proofValue" as a base58-btc multibase string.
+package dataintegrity
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	_ "crypto/sha256" // register SHA-256 hash function
+	_ "crypto/sha512" // register SHA-384 hash function
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const (
+	// SignatureType is the proof type of Data Integrity proofs.
+	SignatureType = "DataIntegrityProof"
+	// EdDSARDFC2022 is the name of the EdDSA cryptosuite using RDF Dataset Canonicalization.
+	EdDSARDFC2022 = "eddsa-rdfc-2022"
+	// ECDSARDFC2019 is the name of the ECDSA cryptosuite using RDF Dataset Canonicalization.
+	ECDSARDFC2019 = "ecdsa-rdfc-2019"
+	// ContextURI is the JSON-LD context which defines DataIntegrityProof terms.
+	ContextURI = "https://w3id.org/security/data-integrity/v1"
+
+	rdfDataSetAlg = "URDNA2015"
+
+	p256Curve = "P-256"
+	p384Curve = "P-384"
+)
+
+// Suite implements a Data Integrity cryptosuite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+	cryptosuite     string
+	curve           elliptic.Curve
+	hash            crypto.Hash
+}
+
+// NewEdDSARDFC2022 creates an instance of the eddsa-rdfc-2022 cryptosuite.
+func NewEdDSARDFC2022(opts ...suite.Opt) *Suite {
+	return newSuite(EdDSARDFC2022, nil, crypto.SHA256, opts...)
+}
+
+// NewECDSARDFC2019P256 creates an instance of the ecdsa-rdfc-2019 cryptosuite for P-256 keys.
+func NewECDSARDFC2019P256(opts ...suite.Opt) *Suite {
+	return newSuite(ECDSARDFC2019, elliptic.P256(), crypto.SHA256, opts...)
+}
+
+// NewECDSARDFC2019P384 creates an instance of the ecdsa-rdfc-2019 cryptosuite for P-384 keys.
+func NewECDSARDFC2019P384(opts ...suite.Opt) *Suite {
+	return newSuite(ECDSARDFC2019, elliptic.P384(), crypto.SHA384, opts...)
+}
+
+func newSuite(cryptosuite string, curve elliptic.Curve, hash crypto.Hash, opts ...suite.Opt) *Suite {
+	s := &Suite{
+		jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg),
+		cryptosuite:     cryptosuite,
+		curve:           curve,
+		hash:            hash,
+	}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	h := s.hash.New()
+	h.Write(doc) //nolint:errcheck,gosec // hash.Hash never returns an error
+
+	return h.Sum(nil)
+}
+
+// Accept will accept only DataIntegrityProof signature type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
+
+// Cryptosuite returns the name of the cryptosuite put into the "cryptosuite" field of the proof.
+func (s *Suite) Cryptosuite() string {
+	return s.cryptosuite
+}
+
+// AcceptPublicKey checks if the verification key can be used with the cryptosuite.
+// ECDSA cryptosuite instances accept keys of their own curve only as the curve defines the digest.
+func (s *Suite) AcceptPublicKey(pubKey *verifier.PublicKey) bool {
+	if pubKey == nil {
+		return false
+	}
+
+	if s.curve == nil {
+		return pubKey.JWK == nil || pubKey.JWK.Crv == "Ed25519"
+	}
+
+	return curveName(pubKey) == s.curve.Params().Name
+}
+
+// curveName returns the name of the elliptic curve of the public key, or empty string if the key
+// is not a P-256 / P-384 key.
+func curveName(pubKey *verifier.PublicKey) string {
+	if pubKey.JWK != nil {
+		return pubKey.JWK.Crv
+	}
+
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384()} {
+		if x, _ := elliptic.Unmarshal(curve, pubKey.Value); x != nil {
+			return curve.Params().Name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,234 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dataintegrity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	gojose "github.com/square/go-jose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+//nolint:lll
+const vcDoc = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1",
+    "https://w3id.org/security/data-integrity/v1"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {
+      "type": "BachelorDegree",
+      "name": "Bachelor of Science and Arts"
+    }
+  }
+}`
+
+func TestSuite_Accept(t *testing.T) {
+	s := NewEdDSARDFC2022()
+	require.True(t, s.Accept("DataIntegrityProof"))
+	require.False(t, s.Accept("Ed25519Signature2018"))
+}
+
+func TestSuite_Cryptosuite(t *testing.T) {
+	require.Equal(t, "eddsa-rdfc-2022", NewEdDSARDFC2022().Cryptosuite())
+	require.Equal(t, "ecdsa-rdfc-2019", NewECDSARDFC2019P256().Cryptosuite())
+	require.Equal(t, "ecdsa-rdfc-2019", NewECDSARDFC2019P384().Cryptosuite())
+}
+
+func TestSuite_GetDigest(t *testing.T) {
+	require.Len(t, NewEdDSARDFC2022().GetDigest([]byte("test doc")), 32)
+	require.Len(t, NewECDSARDFC2019P256().GetDigest([]byte("test doc")), 32)
+	require.Len(t, NewECDSARDFC2019P384().GetDigest([]byte("test doc")), 48)
+}
+
+func TestSuite_AcceptPublicKey(t *testing.T) {
+	p256Signer, err := signature.NewSigner(kmsapi.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	p384Signer, err := signature.NewSigner(kmsapi.ECDSAP384TypeIEEEP1363)
+	require.NoError(t, err)
+
+	edSigner, err := signature.NewSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	p256Key := &verifier.PublicKey{Type: "JsonWebKey2020", Value: p256Signer.PublicKeyBytes()}
+	p384Key := &verifier.PublicKey{Type: "JsonWebKey2020", Value: p384Signer.PublicKeyBytes()}
+	edKey := &verifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: edSigner.PublicKeyBytes()}
+	p384JWK := &verifier.PublicKey{
+		Type: "JsonWebKey2020",
+		JWK: &jwk.JWK{
+			JSONWebKey: gojose.JSONWebKey{Key: p384Signer.PublicKey()},
+			Kty:        "EC",
+			Crv:        "P-384",
+		},
+	}
+
+	require.True(t, NewEdDSARDFC2022().AcceptPublicKey(edKey))
+	require.False(t, NewEdDSARDFC2022().AcceptPublicKey(p384JWK))
+	require.False(t, NewEdDSARDFC2022().AcceptPublicKey(nil))
+
+	require.True(t, NewECDSARDFC2019P256().AcceptPublicKey(p256Key))
+	require.False(t, NewECDSARDFC2019P256().AcceptPublicKey(p384Key))
+	require.False(t, NewECDSARDFC2019P256().AcceptPublicKey(edKey))
+
+	require.True(t, NewECDSARDFC2019P384().AcceptPublicKey(p384Key))
+	require.True(t, NewECDSARDFC2019P384().AcceptPublicKey(p384JWK))
+	require.False(t, NewECDSARDFC2019P384().AcceptPublicKey(p256Key))
+}
+
+func TestSignVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		keyType     kmsapi.KeyType
+		newSuite    func(opts ...suite.Opt) *Suite
+		cryptosuite string
+	}{
+		{"eddsa-rdfc-2022", kmsapi.ED25519Type, NewEdDSARDFC2022, EdDSARDFC2022},
+		{"ecdsa-rdfc-2019 P-256", kmsapi.ECDSAP256TypeIEEEP1363, NewECDSARDFC2019P256, ECDSARDFC2019},
+		{"ecdsa-rdfc-2019 P-384", kmsapi.ECDSAP384TypeIEEEP1363, NewECDSARDFC2019P384, ECDSARDFC2019},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := signature.NewSigner(tc.keyType)
+			require.NoError(t, err)
+
+			docSigner := signer.New(tc.newSuite(suite.WithSigner(s)))
+
+			signedDoc, err := docSigner.Sign(&signer.Context{
+				SignatureType:      SignatureType,
+				VerificationMethod: "did:example:123456#key1",
+			}, []byte(vcDoc), ldtestutil.WithDocumentLoader(t))
+			require.NoError(t, err)
+
+			var signed map[string]interface{}
+			require.NoError(t, json.Unmarshal(signedDoc, &signed))
+
+			proofs, ok := signed["proof"].([]interface{})
+			require.True(t, ok)
+			require.Len(t, proofs, 1)
+
+			proof, ok := proofs[0].(map[string]interface{})
+			require.True(t, ok)
+			require.Equal(t, SignatureType, proof["type"])
+			require.Equal(t, tc.cryptosuite, proof["cryptosuite"])
+			require.True(t, strings.HasPrefix(proof["proofValue"].(string), "z"))
+
+			docVerifier, err := verifier.New(&testKeyResolver{
+				publicKey: &verifier.PublicKey{Type: "JsonWebKey2020", Value: s.PublicKeyBytes()},
+			},
+				NewEdDSARDFC2022(suite.WithVerifier(NewPublicKeyVerifier())),
+				NewECDSARDFC2019P256(suite.WithVerifier(NewPublicKeyVerifier())),
+				NewECDSARDFC2019P384(suite.WithVerifier(NewPublicKeyVerifier())))
+			require.NoError(t, err)
+
+			require.NoError(t, docVerifier.Verify(signedDoc, ldtestutil.WithDocumentLoader(t)))
+
+			signed["issuer"] = "did:example:another-issuer"
+			tamperedDoc, err := json.Marshal(signed)
+			require.NoError(t, err)
+
+			require.Error(t, docVerifier.Verify(tamperedDoc, ldtestutil.WithDocumentLoader(t)))
+		})
+	}
+
+	t.Run("proof without created", func(t *testing.T) {
+		s, err := signature.NewSigner(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		edSuite := NewEdDSARDFC2022(suite.WithSigner(s), suite.WithVerifier(NewPublicKeyVerifier()))
+
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(vcDoc), &doc))
+
+		p := &proof.Proof{
+			Type:                    SignatureType,
+			Cryptosuite:             EdDSARDFC2022,
+			SignatureRepresentation: proof.SignatureProofValue,
+			VerificationMethod:      "did:example:123456#key1",
+			ProofPurpose:            "assertionMethod",
+		}
+
+		message, err := proof.CreateVerifyData(edSuite, doc, p, ldtestutil.WithDocumentLoader(t))
+		require.NoError(t, err)
+
+		p.ProofValue, err = s.Sign(message)
+		require.NoError(t, err)
+
+		require.NoError(t, proof.AddProof(doc, p))
+
+		signedDoc, err := json.Marshal(doc)
+		require.NoError(t, err)
+		require.NotContains(t, string(signedDoc), "created")
+
+		docVerifier, err := verifier.New(&testKeyResolver{
+			publicKey: &verifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: s.PublicKeyBytes()},
+		}, edSuite)
+		require.NoError(t, err)
+
+		require.NoError(t, docVerifier.Verify(signedDoc, ldtestutil.WithDocumentLoader(t)))
+	})
+
+	t.Run("JWS representation is not supported", func(t *testing.T) {
+		s, err := signature.NewSigner(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = signer.New(NewEdDSARDFC2022(suite.WithSigner(s))).Sign(&signer.Context{
+			SignatureType:           SignatureType,
+			SignatureRepresentation: 1,
+			VerificationMethod:      "did:example:123456#key1",
+		}, []byte(vcDoc), ldtestutil.WithDocumentLoader(t))
+		require.EqualError(t, err, "DataIntegrityProof supports proofValue signature representation only")
+	})
+
+	t.Run("unsupported cryptosuite", func(t *testing.T) {
+		s, err := signature.NewSigner(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		signedDoc, err := signer.New(NewEdDSARDFC2022(suite.WithSigner(s))).Sign(&signer.Context{
+			SignatureType:      SignatureType,
+			VerificationMethod: "did:example:123456#key1",
+		}, []byte(vcDoc), ldtestutil.WithDocumentLoader(t))
+		require.NoError(t, err)
+
+		docVerifier, err := verifier.New(&testKeyResolver{
+			publicKey: &verifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: s.PublicKeyBytes()},
+		}, NewECDSARDFC2019P256(suite.WithVerifier(NewPublicKeyVerifier())))
+		require.NoError(t, err)
+
+		err = docVerifier.Verify(signedDoc, ldtestutil.WithDocumentLoader(t))
+		require.EqualError(t, err,
+			"signature type DataIntegrityProof with cryptosuite eddsa-rdfc-2022 not supported")
+	})
+}
+
+type testKeyResolver struct {
+	publicKey *verifier.PublicKey
+}
+
+func (r *testKeyResolver) Resolve(string) (*verifier.PublicKey, error) {
+	return r.publicKey, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ed25519signature2020 implements the Ed25519Signature2020 signature suite
+// for the Linked Data Signatures specification (https://w3c-ccg.github.io/lds-ed25519-2020/).
+// It uses the RDF Dataset Normalization Algorithm to transform the input document into its canonical form.
+// It uses SHA-256 as the message digest algorithm and Ed25519 as the signature algorithm.
+// Unlike Ed25519Signature2018, signatures are stored in the multibase encoded proofValue
+// and keys are expressed as Ed25519VerificationKey2020 with publicKeyMultibase.
+package ed25519signature2020
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+)
+
+// Suite implements Ed25519Signature2020 signature suite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+}
+
+const (
+	// SignatureType is the Ed25519Signature2020 signature type.
+	SignatureType = "Ed25519Signature2020"
+	// ContextURI is the JSON-LD context which defines Ed25519Signature2020 terms.
+	ContextURI = "https://w3id.org/security/suites/ed25519-2020/v1"
+
+	rdfDataSetAlg = "URDNA2015"
+)
+
+// New an instance of Ed25519Signature2020 signature suite.
+func New(opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// Ed25519Signature2020 signature suite uses RDF Dataset Normalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Accept will accept only Ed25519Signature2020 signature type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
@@ -0,0 +1,192 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ed25519signature2020
+
+import (
+	"crypto/ed25519"
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+)
+
+// key pair of Ed25519Signature2020 specification test vectors (https://w3c-ccg.github.io/lds-ed25519-2020/).
+const (
+	publicKeyMultibase = "z6MkrJVnaZkeFzdQyMZu1cgjg7k1pZZ6pvBQ7XJPt4swbTQ2"
+	secretKeyMultibase = "z3u2en7t5LR2WtQH5PfFqMqwVHBeXouLzo6haApm8XHqvjxq"
+)
+
+// expectedDoc is vcDoc signed with the specification key pair; the proof value is a fixed known answer.
+//go:embed testdata/expected_doc.jsonld
+var expectedDoc string
+
+//nolint:lll
+const vcDoc = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1",
+    "https://w3id.org/security/suites/ed25519-2020/v1"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "issuer": "https://example.edu/issuers/565049",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {
+      "type": "BachelorDegree",
+      "name": "Bachelor of Science and Arts"
+    }
+  }
+}`
+
+func TestSuite_Accept(t *testing.T) {
+	s := New()
+	require.True(t, s.Accept("Ed25519Signature2020"))
+	require.False(t, s.Accept("Ed25519Signature2018"))
+}
+
+func TestSuite_GetDigest(t *testing.T) {
+	require.Len(t, New().GetDigest([]byte("test doc")), 32)
+}
+
+func TestSignVerify(t *testing.T) {
+	privKey, pubKey := specKeyPair(t)
+
+	created := time.Date(2021, 11, 13, 18, 19, 39, 0, time.UTC)
+	ctx := &signer.Context{
+		SignatureType:      SignatureType,
+		VerificationMethod: "https://example.edu/issuers/565049#" + publicKeyMultibase,
+		Created:            &created,
+	}
+
+	docSigner := signer.New(New(suite.WithSigner(signature.GetEd25519Signer(privKey, pubKey))))
+
+	signedDoc, err := docSigner.Sign(ctx, []byte(vcDoc), ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	var signed map[string]interface{}
+	require.NoError(t, json.Unmarshal(signedDoc, &signed))
+
+	proofs, ok := signed["proof"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, proofs, 1)
+
+	proof, ok := proofs[0].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, SignatureType, proof["type"])
+	require.Equal(t, "2021-11-13T18:19:39Z", proof["created"])
+	require.NotContains(t, proof, "cryptosuite")
+	require.NotContains(t, proof, "jws")
+
+	proofValue, ok := proof["proofValue"].(string)
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(proofValue, "z"))
+
+	// Ed25519 signatures are deterministic, so signing again gives the same proof value.
+	signedAgain, err := docSigner.Sign(ctx, []byte(vcDoc), ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+	require.Equal(t, string(signedDoc), string(signedAgain))
+
+	docVerifier, err := verifier.New(&testKeyResolver{
+		publicKey: &verifier.PublicKey{Type: "Ed25519VerificationKey2020", Value: pubKey},
+	}, New(suite.WithVerifier(NewPublicKeyVerifier())))
+	require.NoError(t, err)
+
+	require.NoError(t, docVerifier.Verify(signedDoc, ldtestutil.WithDocumentLoader(t)))
+
+	signed["issuer"] = "https://example.edu/issuers/another"
+	tamperedDoc, err := json.Marshal(signed)
+	require.NoError(t, err)
+
+	require.Error(t, docVerifier.Verify(tamperedDoc, ldtestutil.WithDocumentLoader(t)))
+
+	t.Run("JWS representation is not supported", func(t *testing.T) {
+		_, err = docSigner.Sign(&signer.Context{
+			SignatureType:           SignatureType,
+			SignatureRepresentation: 1,
+			VerificationMethod:      ctx.VerificationMethod,
+		}, []byte(vcDoc), ldtestutil.WithDocumentLoader(t))
+		require.EqualError(t, err, "Ed25519Signature2020 supports proofValue signature representation only")
+	})
+}
+
+func TestSign_KnownAnswer(t *testing.T) {
+	privKey, pubKey := specKeyPair(t)
+
+	var expected map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(expectedDoc), &expected))
+
+	expectedProof, ok := expected["proof"].(map[string]interface{})
+	require.True(t, ok)
+
+	created, err := time.Parse(time.RFC3339, expectedProof["created"].(string))
+	require.NoError(t, err)
+
+	docSigner := signer.New(New(suite.WithSigner(signature.GetEd25519Signer(privKey, pubKey))))
+
+	signedDoc, err := docSigner.Sign(&signer.Context{
+		SignatureType:      SignatureType,
+		VerificationMethod: expectedProof["verificationMethod"].(string),
+		Created:            &created,
+	}, []byte(vcDoc), ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	var signed map[string]interface{}
+	require.NoError(t, json.Unmarshal(signedDoc, &signed))
+
+	proofs, ok := signed["proof"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, proofs, 1)
+
+	proof, ok := proofs[0].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, expectedProof["proofValue"], proof["proofValue"])
+	require.Equal(t, expectedProof, proof)
+
+	docVerifier, err := verifier.New(&testKeyResolver{
+		publicKey: &verifier.PublicKey{Type: "Ed25519VerificationKey2020", Value: pubKey},
+	}, New(suite.WithVerifier(NewPublicKeyVerifier())))
+	require.NoError(t, err)
+
+	require.NoError(t, docVerifier.Verify([]byte(expectedDoc), ldtestutil.WithDocumentLoader(t)))
+}
+
+func specKeyPair(t *testing.T) (ed25519.PrivateKey, ed25519.PublicKey) {
+	t.Helper()
+
+	_, pub, err := multibase.Decode(publicKeyMultibase)
+	require.NoError(t, err)
+
+	_, secret, err := multibase.Decode(secretKeyMultibase)
+	require.NoError(t, err)
+
+	// both keys are prefixed with two bytes of multicodec (ed25519-pub and ed25519-priv).
+	pubKey := ed25519.PublicKey(pub[2:])
+	privKey := ed25519.NewKeyFromSeed(secret[2:])
+	require.Equal(t, pubKey, privKey.Public())
+
+	return privKey, pubKey
+}
+
+type testKeyResolver struct {
+	publicKey *verifier.PublicKey
+}
+
+func (r *testKeyResolver) Resolve(string) (*verifier.PublicKey, error) {
+	return r.publicKey, nil
+}
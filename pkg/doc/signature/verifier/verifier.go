@@ -34,6 +34,14 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// cryptosuite is implemented by Data Integrity signature suites, which share a single
+// "DataIntegrityProof" signature type and are selected by the proof's cryptosuite and
+// the verification key (e.g. the curve of an ECDSA key defines the digest).
+type cryptosuite interface {
+	Cryptosuite() string
+	AcceptPublicKey(pubKey *PublicKey) bool
+}
+
 // PublicKey contains a result of public key resolution.
 type PublicKey struct {
 	Type  string
@@ -96,7 +104,7 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 			return err
 		}
 
-		suite, err := dv.getSignatureSuite(p.Type)
+		suite, err := dv.getSignatureSuite(p, publicKey)
 		if err != nil {
 			return err
 		}
@@ -120,15 +128,26 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 	return nil
 }
 
-// getSignatureSuite returns signature suite based on signature type.
-func (dv *DocumentVerifier) getSignatureSuite(signatureType string) (SignatureSuite, error) {
+// getSignatureSuite returns signature suite based on signature type and, for Data Integrity proofs,
+// on the cryptosuite and the public key.
+func (dv *DocumentVerifier) getSignatureSuite(p *proof.Proof, publicKey *PublicKey) (SignatureSuite, error) {
 	for _, s := range dv.signatureSuites {
-		if s.Accept(signatureType) {
-			return s, nil
+		if !s.Accept(p.Type) {
+			continue
+		}
+
+		if cs, ok := s.(cryptosuite); ok && (cs.Cryptosuite() != p.Cryptosuite || !cs.AcceptPublicKey(publicKey)) {
+			continue
 		}
+
+		return s, nil
+	}
+
+	if p.Cryptosuite != "" {
+		return nil, fmt.Errorf("signature type %s with cryptosuite %s not supported", p.Type, p.Cryptosuite)
 	}
 
-	return nil, fmt.Errorf("signature type %s not supported", signatureType)
+	return nil, fmt.Errorf("signature type %s not supported", p.Type)
 }
 
 func getProofVerifyValue(p *proof.Proof) ([]byte, error) {
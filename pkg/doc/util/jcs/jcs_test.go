@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jcs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	t.Run("test primitive values (RFC 8785 example)", func(t *testing.T) {
+		input := `{
+			"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001, -0],
+			"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+			"literals": [null, true, false]
+		}`
+
+		result, err := Canonicalize(json.RawMessage(input))
+		require.NoError(t, err)
+		require.Equal(t, `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27,0],`+
+			`"string":"€$\u000f\nA'B\"\\\\\"/"}`, string(result))
+	})
+
+	t.Run("test sorting of member names by UTF-16 code units", func(t *testing.T) {
+		input := `{"\u20ac":1,"\r":2,"\ufb33":3,"1":4,"\ud83d\ude00":5,"\u0080":6,"\u00f6":7,"<&>":8}`
+
+		result, err := Canonicalize(json.RawMessage(input))
+		require.NoError(t, err)
+		require.Equal(t, "{\"\\r\":2,\"1\":4,\"<&>\":8,\"\u0080\":6,\"\u00f6\":7,\"\u20ac\":1,\"\U0001F600\":5,\"\ufb33\":3}",
+			string(result))
+	})
+
+	t.Run("test structs", func(t *testing.T) {
+		result, err := Canonicalize(&struct {
+			RecoveryCommitment string `json:"recoveryCommitment"`
+			DeltaHash          string `json:"deltaHash"`
+		}{DeltaHash: "b", RecoveryCommitment: "a"})
+		require.NoError(t, err)
+		require.Equal(t, `{"deltaHash":"b","recoveryCommitment":"a"}`, string(result))
+	})
+
+	t.Run("test invalid JSON", func(t *testing.T) {
+		_, err := Canonicalize(json.RawMessage("{"))
+		require.Error(t, err)
+
+		_, err = Canonicalize(make(chan int))
+		require.Error(t, err)
+	})
+}
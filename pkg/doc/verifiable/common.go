@@ -22,12 +22,11 @@ import (
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
-// TODO https://github.com/square/go-jose/issues/263 support ES256K
-
 // JWSAlgorithm defines JWT signature algorithms of Verifiable Credential.
 type JWSAlgorithm int
 
@@ -37,6 +36,21 @@ const (
 
 	// EdDSA JWT Algorithm.
 	EdDSA
+
+	// PS256 JWT Algorithm.
+	PS256
+
+	// ECDSASecp256k1 JWT Algorithm.
+	ECDSASecp256k1
+
+	// ECDSAP256 JWT Algorithm.
+	ECDSAP256
+
+	// ECDSAP384 JWT Algorithm.
+	ECDSAP384
+
+	// ECDSAP521 JWT Algorithm.
+	ECDSAP521
 )
 
 // name return the name of the signature algorithm.
@@ -46,6 +60,16 @@ func (ja JWSAlgorithm) name() (string, error) {
 		return "RS256", nil
 	case EdDSA:
 		return "EdDSA", nil
+	case PS256:
+		return "PS256", nil
+	case ECDSASecp256k1:
+		return "ES256K", nil
+	case ECDSAP256:
+		return "ES256", nil
+	case ECDSAP384:
+		return "ES384", nil
+	case ECDSAP521:
+		return "ES512", nil
 	default:
 		return "", fmt.Errorf("unsupported algorithm: %v", ja)
 	}
@@ -85,24 +109,112 @@ func NewVDRKeyResolver(vdr vdrapi.Registry) *VDRKeyResolver {
 }
 
 func (r *VDRKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.PublicKey, error) {
-	docResolution, err := r.vdr.Resolve(issuerDID)
+	keyURL := verificationMethodURL(issuerDID, keyID)
+
+	// key ID of another DID is never dereferenced by itself, it is accepted only when
+	// the issuer's DID document lists that very verification method.
+	foreignKey := strings.HasPrefix(keyID, "did:") && didOfURL(keyID) != didOfURL(issuerDID)
+	if foreignKey {
+		keyURL = didOfURL(issuerDID)
+	}
+
+	result, err := vdrapi.Dereference(r.vdr, keyURL)
 	if err != nil {
+		if errors.Is(err, did.ErrContentNotFound) {
+			return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
+		}
+
 		return nil, fmt.Errorf("resolve DID %s: %w", issuerDID, err)
 	}
 
-	for _, verifications := range docResolution.DIDDocument.VerificationMethods() {
-		for _, verification := range verifications {
-			if strings.Contains(verification.VerificationMethod.ID, keyID) {
-				return &verifier.PublicKey{
-					Type:  verification.VerificationMethod.Type,
-					Value: verification.VerificationMethod.Value,
-					JWK:   verification.VerificationMethod.JSONWebKey(),
-				}, nil
+	vm := result.VerificationMethod
+
+	switch {
+	case foreignKey:
+		vm = listedVerificationMethod(result.DIDDocument, keyID)
+		if vm == nil {
+			return nil, fmt.Errorf("public key with KID %s does not belong to DID %s", keyID, issuerDID)
+		}
+	case vm == nil && result.DIDDocument != nil:
+		// no key ID, take the first verification method of the DID document.
+		vm = firstVerificationMethod(result.DIDDocument)
+	}
+
+	if vm == nil {
+		return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
+	}
+
+	return publicKeyOf(vm), nil
+}
+
+// listedVerificationMethod returns verification method of DID document with exactly the given ID.
+func listedVerificationMethod(doc *did.Doc, id string) *did.VerificationMethod {
+	if doc == nil {
+		return nil
+	}
+
+	for i := range doc.VerificationMethod {
+		if doc.VerificationMethod[i].ID == id {
+			return &doc.VerificationMethod[i]
+		}
+	}
+
+	for _, verifications := range doc.VerificationMethods() {
+		for i := range verifications {
+			if verifications[i].VerificationMethod.ID == id {
+				return &verifications[i].VerificationMethod
 			}
 		}
 	}
 
-	return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
+	return nil
+}
+
+func firstVerificationMethod(doc *did.Doc) *did.VerificationMethod {
+	if len(doc.VerificationMethod) > 0 {
+		return &doc.VerificationMethod[0]
+	}
+
+	for _, verifications := range doc.VerificationMethods() {
+		if len(verifications) > 0 {
+			return &verifications[0].VerificationMethod
+		}
+	}
+
+	return nil
+}
+
+func publicKeyOf(vm *did.VerificationMethod) *verifier.PublicKey {
+	return &verifier.PublicKey{
+		Type:  vm.Type,
+		Value: vm.Value,
+		JWK:   vm.JSONWebKey(),
+	}
+}
+
+// verificationMethodURL builds DID URL of the verification method from key ID which is either
+// an absolute DID URL, a relative DID URL (#key-1) or just a fragment (key-1).
+// DID document is dereferenced when key ID is empty.
+func verificationMethodURL(issuerDID, keyID string) string {
+	switch {
+	case keyID == "":
+		return didOfURL(issuerDID)
+	case strings.HasPrefix(keyID, "did:"):
+		return keyID
+	case strings.HasPrefix(keyID, "#"):
+		return didOfURL(issuerDID) + keyID
+	default:
+		return didOfURL(issuerDID) + "#" + keyID
+	}
+}
+
+// didOfURL returns the DID part of DID URL, i.e. without path, query and fragment.
+func didOfURL(didURL string) string {
+	if i := strings.IndexAny(didURL, "/?#"); i >= 0 {
+		return didURL[:i]
+	}
+
+	return didURL
 }
 
 // PublicKeyFetcher returns Public Key Fetcher via DID resolution mechanism.
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -27,6 +28,18 @@ func TestJwtAlgorithm_Name(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "EdDSA", alg)
 
+	for jwsAlg, name := range map[JWSAlgorithm]string{
+		PS256:          "PS256",
+		ECDSASecp256k1: "ES256K",
+		ECDSAP256:      "ES256",
+		ECDSAP384:      "ES384",
+		ECDSAP521:      "ES512",
+	} {
+		alg, err = jwsAlg.name()
+		require.NoError(t, err)
+		require.Equal(t, name, alg)
+	}
+
 	// not supported alg
 	sa, err := JWSAlgorithm(-1).name()
 	require.Error(t, err)
@@ -266,6 +279,28 @@ func TestDIDKeyResolver_Resolve(t *testing.T) {
 	r.Equal(assertionMethod.VerificationMethod.Value, assertMethPubKey.Value)
 	r.Equal("Ed25519VerificationKey2018", assertMethPubKey.Type)
 
+	// key ID relative to the DID.
+	authID := authentication.VerificationMethod.ID
+	fragment := authID[strings.Index(authID, "#")+1:]
+
+	for _, keyID := range []string{"#" + fragment, fragment} {
+		pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, keyID)
+		r.NoError(err)
+		r.Equal(authentication.VerificationMethod.Value, pubKey.Value)
+	}
+
+	// absolute key ID of another DID is rejected even if its fragment matches a key of the issuer.
+	foreignKeyID := "did:test:another#" + fragment
+	pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, foreignKeyID)
+	r.EqualError(err, fmt.Sprintf("public key with KID %s does not belong to DID %s", foreignKeyID, didDoc.ID))
+	r.Nil(pubKey)
+
+	// key ID must select the verification method, not just be a part of its ID.
+	pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, fragment[:len(fragment)-1])
+	r.EqualError(err, fmt.Sprintf("public key with KID %s is not found for DID %s",
+		fragment[:len(fragment)-1], didDoc.ID))
+	r.Nil(pubKey)
+
 	pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, "invalid key")
 	r.Error(err)
 	r.EqualError(err, fmt.Sprintf("public key with KID invalid key is not found for DID %s", didDoc.ID))
@@ -48,6 +48,39 @@ func TestJWTCredClaimsMarshalJWS(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, vc.stringJSON(t), vcRaw.stringJSON(t))
 	})
+
+	t.Run("Marshal JWT signed with ECDSA and PS256", func(t *testing.T) {
+		tests := []struct {
+			alg     JWSAlgorithm
+			keyType kms.KeyType
+		}{
+			{alg: PS256, keyType: kms.RSAPS256Type},
+			{alg: ECDSAP256, keyType: kms.ECDSAP256TypeIEEEP1363},
+			{alg: ECDSAP384, keyType: kms.ECDSAP384TypeIEEEP1363},
+			{alg: ECDSAP521, keyType: kms.ECDSAP521TypeIEEEP1363},
+			{alg: ECDSASecp256k1, keyType: kms.ECDSASecp256k1TypeIEEEP1363},
+		}
+
+		for _, tc := range tests {
+			algSigner, err := newCryptoSigner(tc.keyType)
+			require.NoError(t, err)
+
+			jws, err := jwtClaims.MarshalJWS(tc.alg, algSigner, "any")
+			require.NoError(t, err)
+
+			vcBytes, err := decodeCredJWS(jws, true, func(issuerID, keyID string) (*verifier.PublicKey, error) {
+				return &verifier.PublicKey{
+					Type:  "JsonWebKey2020",
+					Value: algSigner.PublicKeyBytes(),
+				}, nil
+			})
+			require.NoError(t, err)
+
+			vcRaw := new(rawCredential)
+			require.NoError(t, json.Unmarshal(vcBytes, &vcRaw))
+			require.Equal(t, vc.stringJSON(t), vcRaw.stringJSON(t))
+		}
+	})
 }
 
 type invalidCredClaims struct {
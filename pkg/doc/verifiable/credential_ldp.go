@@ -13,6 +13,8 @@ import (
 
 // AddLinkedDataProof appends proof to the Verifiable Credential.
 func (vc *Credential) AddLinkedDataProof(context *LinkedDataProofContext, jsonldOpts ...jsonld.ProcessorOpts) error {
+	vc.Context = appendSignatureTypeContext(vc.Context, context.SignatureType)
+
 	vcBytes, err := vc.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("add linked data proof to VC: %w", err)
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"bytes"
 	"crypto/ed25519"
 	"crypto/sha256"
 	"encoding/base64"
@@ -28,8 +29,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/dataintegrity"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -673,6 +676,109 @@ func TestParseCredentialFromLinkedDataProof_JsonWebSignature2020_ecdsaP256(t *te
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialFromLinkedDataProof_DataIntegrityProof(t *testing.T) {
+	tests := []struct {
+		name     string
+		keyType  kms.KeyType
+		newSuite func(opts ...suite.Opt) *dataintegrity.Suite
+	}{
+		{"eddsa-rdfc-2022", kms.ED25519Type, dataintegrity.NewEdDSARDFC2022},
+		{"ecdsa-rdfc-2019 P-256", kms.ECDSAP256TypeIEEEP1363, dataintegrity.NewECDSARDFC2019P256},
+		{"ecdsa-rdfc-2019 P-384", kms.ECDSAP384TypeIEEEP1363, dataintegrity.NewECDSARDFC2019P384},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			signer, err := newCryptoSigner(tc.keyType)
+			r.NoError(err)
+
+			vc, err := parseTestCredential(t, []byte(validCredential))
+			r.NoError(err)
+
+			err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "DataIntegrityProof",
+				SignatureRepresentation: SignatureProofValue,
+				Suite:                   tc.newSuite(suite.WithSigner(signer)),
+				VerificationMethod:      "did:example:123456#key1",
+			}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+			r.NoError(err)
+			r.Contains(vc.Context, dataintegrity.ContextURI)
+			r.Len(vc.Proofs, 1)
+			r.Equal("DataIntegrityProof", vc.Proofs[0]["type"])
+			r.Equal(tc.newSuite().Cryptosuite(), vc.Proofs[0]["cryptosuite"])
+
+			vcBytes, err := json.Marshal(vc)
+			r.NoError(err)
+
+			vcWithLdp, err := parseTestCredential(t, vcBytes,
+				WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), "JsonWebKey2020")))
+			r.NoError(err)
+			r.Equal(vc, vcWithLdp)
+
+			_, err = parseTestCredential(t, bytes.Replace(vcBytes, []byte("Example University"), []byte("Another University"), 1),
+				WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), "JsonWebKey2020")))
+			r.Error(err)
+			r.Contains(err.Error(), "check embedded proof")
+		})
+	}
+
+	t.Run("JWS signature representation", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "DataIntegrityProof",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   dataintegrity.NewEdDSARDFC2022(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:123456#key1",
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DataIntegrityProof supports proofValue signature representation only")
+	})
+}
+
+func TestParseCredentialFromLinkedDataProof_Ed25519Signature2020(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2020",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   ed25519signature2020.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:123456#key1",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+	r.Contains(vc.Context, ed25519signature2020.ContextURI)
+	r.Len(vc.Proofs, 1)
+	r.Equal("Ed25519Signature2020", vc.Proofs[0]["type"])
+	r.True(strings.HasPrefix(vc.Proofs[0]["proofValue"].(string), "z"))
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	vcWithLdp, err := parseTestCredential(t, vcBytes,
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), "Ed25519VerificationKey2020")))
+	r.NoError(err)
+	r.Equal(vc, vcWithLdp)
+
+	_, err = parseTestCredential(t, bytes.Replace(vcBytes, []byte("Example University"), []byte("Another University"), 1),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), "Ed25519VerificationKey2020")))
+	r.Error(err)
+	r.Contains(err.Error(), "check embedded proof")
+}
+
 func TestParseCredentialFromLinkedDataProof_EcdsaSecp256k1Signature2019(t *testing.T) {
 	r := require.New(t)
 
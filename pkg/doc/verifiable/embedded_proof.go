@@ -15,8 +15,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/dataintegrity"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
@@ -27,6 +29,8 @@ const (
 	ecdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
 	bbsBlsSignature2020         = "BbsBlsSignature2020"
 	bbsBlsSignatureProof2020    = "BbsBlsSignatureProof2020"
+	dataIntegrityProof          = "DataIntegrityProof"
+	ed25519Signature2020        = "Ed25519Signature2020"
 )
 
 func getProofType(proofMap map[string]interface{}) (string, error) {
@@ -38,7 +42,7 @@ func getProofType(proofMap map[string]interface{}) (string, error) {
 	proofTypeStr := safeStringValue(proofType)
 	switch proofTypeStr {
 	case ed25519Signature2018, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
-		bbsBlsSignature2020, bbsBlsSignatureProof2020:
+		bbsBlsSignature2020, bbsBlsSignatureProof2020, dataIntegrityProof, ed25519Signature2020:
 		return proofTypeStr, nil
 	default:
 		return "", fmt.Errorf("unsupported proof type: %s", proofType)
@@ -132,6 +136,11 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 
 				ldpSuites = append(ldpSuites, bbsblssignatureproof2020.New(
 					suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))))
+			case dataIntegrityProof:
+				ldpSuites = append(ldpSuites, getDataIntegritySuites(proofs[i])...)
+			case ed25519Signature2020:
+				ldpSuites = append(ldpSuites, ed25519signature2020.New(
+					suite.WithVerifier(ed25519signature2020.NewPublicKeyVerifier())))
 			}
 		}
 	}
@@ -139,6 +148,24 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 	return ldpSuites, nil
 }
 
+// getDataIntegritySuites returns the suites of the proof's cryptosuite. The ECDSA cryptosuite is
+// returned for both supported curves as the verification key defines which one is used.
+func getDataIntegritySuites(proof map[string]interface{}) []verifier.SignatureSuite {
+	v := dataintegrity.NewPublicKeyVerifier()
+
+	switch safeStringValue(proof["cryptosuite"]) {
+	case dataintegrity.EdDSARDFC2022:
+		return []verifier.SignatureSuite{dataintegrity.NewEdDSARDFC2022(suite.WithVerifier(v))}
+	case dataintegrity.ECDSARDFC2019:
+		return []verifier.SignatureSuite{
+			dataintegrity.NewECDSARDFC2019P256(suite.WithVerifier(v)),
+			dataintegrity.NewECDSARDFC2019P384(suite.WithVerifier(v)),
+		}
+	}
+
+	return nil
+}
+
 func getNonce(proof map[string]interface{}) ([]byte, error) {
 	if nonce, ok := proof["nonce"]; ok {
 		n, err := base64.StdEncoding.DecodeString(nonce.(string))
@@ -14,6 +14,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/dataintegrity"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
@@ -130,6 +132,30 @@ func addLinkedDataProof(context *LinkedDataProofContext, jsonldBytes []byte,
 	return proofs, nil
 }
 
+// signatureTypeContexts are JSON-LD contexts defining terms of signature types
+// which are not part of the default VC context.
+var signatureTypeContexts = map[string]string{ //nolint:gochecknoglobals
+	dataIntegrityProof:   dataintegrity.ContextURI,
+	ed25519Signature2020: ed25519signature2020.ContextURI,
+}
+
+// appendSignatureTypeContext adds the JSON-LD context defining terms of the signature type
+// if it is not present yet.
+func appendSignatureTypeContext(contexts []string, signatureType string) []string {
+	signatureCtx, ok := signatureTypeContexts[signatureType]
+	if !ok {
+		return contexts
+	}
+
+	for _, ctx := range contexts {
+		if ctx == signatureCtx {
+			return contexts
+		}
+	}
+
+	return append(contexts, signatureCtx)
+}
+
 func mapContext(context *LinkedDataProofContext) *signer.Context {
 	return &signer.Context{
 		SignatureType:           context.SignatureType,
@@ -13,6 +13,8 @@ import (
 
 // AddLinkedDataProof appends proof to the Verifiable Presentation.
 func (vp *Presentation) AddLinkedDataProof(context *LinkedDataProofContext, jsonldOpts ...jsonld.ProcessorOpts) error {
+	vp.Context = appendSignatureTypeContext(vp.Context, context.SignatureType)
+
 	vcBytes, err := vp.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("add linked data proof to VP: %w", err)
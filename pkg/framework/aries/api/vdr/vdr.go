@@ -8,12 +8,19 @@ package vdr
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 )
 
-// ErrNotFound is returned when a DID resolver does not find the DID.
-var ErrNotFound = errors.New("DID does not exist")
+var (
+	// ErrNotFound is returned when a DID resolver does not find the DID.
+	ErrNotFound = errors.New("DID does not exist")
+	// ErrMethodNotSupported is returned when no VDR of the registry supports the DID method.
+	ErrMethodNotSupported = errors.New("DID method not supported")
+	// ErrDereferenceNotSupported is returned when DID URL can't be dereferenced by the registry or DID method.
+	ErrDereferenceNotSupported = errors.New("DID URL dereferencing not supported")
+)
 
 const (
 	// DIDCommServiceType default DID Communication service endpoint type.
@@ -21,6 +28,12 @@ const (
 
 	// DIDCommV2ServiceType is the DID Communications V2 service type.
 	DIDCommV2ServiceType = "DIDCommMessaging"
+
+	// VersionIDOpt is a DID method option to resolve the version of DID document with the given ID (string).
+	VersionIDOpt = "versionID"
+	// VersionTimeOpt is a DID method option to resolve the version of DID document
+	// which was valid at the given time (time.Time).
+	VersionTimeOpt = "versionTime"
 )
 
 // Registry vdr registry.
@@ -32,6 +45,37 @@ type Registry interface {
 	Close() error
 }
 
+// Dereferencer is implemented by registries which dereference DID URLs themselves
+// (https://w3c-ccg.github.io/did-resolution/#dereferencing), e.g. resolving the requested
+// version of the DID document. It is optional, see Dereference.
+type Dereferencer interface {
+	Dereference(didURL string, opts ...DIDMethodOption) (*did.DereferenceResult, error)
+}
+
+// Dereference dereferences DID URL using the registry. If the registry is not a Dereferencer,
+// DID URL is dereferenced against the resolved DID document, versioned DID URLs are not supported then.
+func Dereference(registry Registry, didURL string, opts ...DIDMethodOption) (*did.DereferenceResult, error) {
+	if dereferencer, ok := registry.(Dereferencer); ok {
+		return dereferencer.Dereference(didURL, opts...)
+	}
+
+	parsed, err := did.ParseDIDURL(didURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse DID URL: %w", err)
+	}
+
+	if parsed.HasVersion() {
+		return nil, fmt.Errorf("dereference versioned DID URL %s: %w", didURL, ErrDereferenceNotSupported)
+	}
+
+	docResolution, err := registry.Resolve(parsed.DID.String(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return docResolution.Dereference(parsed)
+}
+
 // VDR verifiable data registry interface.
 // TODO https://github.com/hyperledger/aries-framework-go/issues/2475
 type VDR interface {
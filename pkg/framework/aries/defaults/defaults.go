@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/queue"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/ws"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 )
@@ -37,3 +38,15 @@ func WithInboundWSAddr(internalAddr, externalAddr, certFile, keyFile string) ari
 		return aries.WithInboundTransport(inbound)(opts)
 	}
 }
+
+// WithInboundQueue return new queue inbound transport consuming the topic of the broker.
+func WithInboundQueue(broker queue.Broker, topic, externalAddr string) aries.Option {
+	return func(opts *aries.Aries) error {
+		inbound, err := queue.NewInbound(broker, topic, externalAddr)
+		if err != nil {
+			return fmt.Errorf("queue inbound transport initialization failed : %w", err)
+		}
+
+		return aries.WithInboundTransport(inbound)(opts)
+	}
+}
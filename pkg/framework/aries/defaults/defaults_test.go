@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/queue/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 )
 
@@ -41,3 +42,17 @@ func TestWithInboundWSPort(t *testing.T) {
 		require.Contains(t, err.Error(), "ws inbound transport initialization failed")
 	})
 }
+
+func TestWithInboundQueue(t *testing.T) {
+	t.Run("test inbound with queue - success", func(t *testing.T) {
+		a, err := aries.New(WithInboundQueue(mem.NewBroker(), "agent", ""))
+		require.NoError(t, err)
+		require.NoError(t, a.Close())
+	})
+
+	t.Run("test inbound with queue - empty topic", func(t *testing.T) {
+		_, err := aries.New(WithInboundQueue(mem.NewBroker(), "", ""))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "queue inbound transport initialization failed")
+	})
+}
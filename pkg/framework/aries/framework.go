@@ -35,8 +35,10 @@ import (
 	ldstore "github.com/hyperledger/aries-framework-go/pkg/store/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/web"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -69,12 +71,17 @@ type Aries struct {
 	packers                    []packer.Packer
 	vdrRegistry                vdrapi.Registry
 	vdr                        []vdrapi.VDR
+	vdrCacheOpts               []vdr.CacheOption
+	vdrCache                   bool
+	didWebOpts                 []web.Option
+	didWebHosting              bool
 	verifiableStore            verifiable.Store
 	didConnectionStore         did.ConnectionStore
 	contextStore               ldstore.ContextStore
 	remoteProviderStore        ldstore.RemoteProviderStore
 	documentLoader             jsonld.DocumentLoader
 	contextProviderURLs        []string
+	contextPolicy              ld.ContextPolicy
 	transportReturnRoute       string
 	id                         string
 	keyType                    kms.KeyType
@@ -266,6 +273,29 @@ func WithVDR(v vdrapi.VDR) Option {
 	}
 }
 
+// WithVDRCache enables caching of resolved DID documents in the VDR registry of the Aries framework.
+// Cached DID documents are persisted in the framework's storage provider.
+func WithVDRCache(cacheOpts ...vdr.CacheOption) Option {
+	return func(opts *Aries) error {
+		opts.vdrCache = true
+		opts.vdrCacheOpts = cacheOpts
+
+		return nil
+	}
+}
+
+// WithDIDWebHosting enables creating, updating and deactivating did:web DID documents through the VDR registry
+// of the Aries framework. Keys are generated with the framework's KMS and the documents are kept in the framework's
+// storage provider, they can be served with web.NewHandler or written into a directory given by web.WithDocumentDir.
+func WithDIDWebHosting(webOpts ...web.Option) Option {
+	return func(opts *Aries) error {
+		opts.didWebHosting = true
+		opts.didWebOpts = webOpts
+
+		return nil
+	}
+}
+
 // WithMessageServiceProvider injects a message service provider to the Aries framework.
 // Message service provider returns list of message services which can be used to provide custom handle
 // functionality based on incoming messages type and purpose.
@@ -336,6 +366,16 @@ func WithJSONLDContextProviderURL(url ...string) Option {
 	}
 }
 
+// WithJSONLDContextPolicy sets the policy of loading JSON-LD context documents by the default document loader,
+// e.g. ld.PinnedContextsOnly restricts documents to the pinned and embedded contexts. Ignored together with
+// WithJSONLDDocumentLoader.
+func WithJSONLDContextPolicy(policy ld.ContextPolicy) Option {
+	return func(opts *Aries) error {
+		opts.contextPolicy = policy
+		return nil
+	}
+}
+
 // WithKeyType injects a default signing key type.
 func WithKeyType(keyType kms.KeyType) Option {
 	return func(opts *Aries) error {
@@ -432,9 +472,23 @@ func (a *Aries) Close() error {
 		}
 	}
 
+	for _, outbound := range a.outboundTransports {
+		if s, ok := outbound.(stoppableTransport); ok {
+			if err := s.Stop(); err != nil {
+				return fmt.Errorf("outbound transport close failed: %w", err)
+			}
+		}
+	}
+
 	return a.closeVDR()
 }
 
+// stoppableTransport is implemented by outbound transports holding resources (connections, goroutines) which have
+// to be released when the framework is closed.
+type stoppableTransport interface {
+	Stop() error
+}
+
 func (a *Aries) closeVDR() error {
 	if a.vdrRegistry != nil {
 		if err := a.vdrRegistry.Close(); err != nil {
@@ -499,7 +553,27 @@ func createVDR(frameworkOpts *Aries) error {
 	)
 
 	k := key.New()
-	opts = append(opts, vdr.WithVDR(k))
+	opts = append(opts, vdr.WithVDR(k), vdr.WithVDR(jwk.New()))
+
+	if frameworkOpts.didWebHosting {
+		webOpts := append([]web.Option{
+			web.WithKeyManager(ctx.KMS()),
+			web.WithStorageProvider(ctx.StorageProvider()),
+		}, frameworkOpts.didWebOpts...)
+
+		opts = append(opts, vdr.WithVDR(web.New(webOpts...)))
+	}
+
+	if frameworkOpts.vdrCache {
+		cacheStore, err := ctx.StorageProvider().OpenStore(vdr.CacheStoreName)
+		if err != nil {
+			return fmt.Errorf("open vdr cache store failed: %w", err)
+		}
+
+		cacheOpts := append([]vdr.CacheOption{vdr.WithCacheStore(cacheStore)}, frameworkOpts.vdrCacheOpts...)
+
+		opts = append(opts, vdr.WithCache(cacheOpts...))
+	}
 
 	frameworkOpts.vdrRegistry = vdr.New(opts...)
 
@@ -633,7 +707,7 @@ func createJSONLDDocumentLoader(frameworkOpts *Aries) error {
 		return fmt.Errorf("context creation failed: %w", err)
 	}
 
-	var loaderOpts []ld.DocumentLoaderOpts
+	loaderOpts := []ld.DocumentLoaderOpts{ld.WithContextPolicy(frameworkOpts.contextPolicy)}
 
 	if len(frameworkOpts.contextProviderURLs) > 0 {
 		for _, url := range frameworkOpts.contextProviderURLs {
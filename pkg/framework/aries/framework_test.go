@@ -18,6 +18,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -30,7 +31,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/embed"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	didStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/did"
@@ -52,7 +56,9 @@ import (
 	locallock "github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	vdrpkg "github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/web"
 )
 
 //nolint:lll
@@ -156,6 +162,37 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test vdr - with cache", func(t *testing.T) {
+		vdr := &mockvdr.MockVDR{AcceptValue: true}
+		aries, err := New(WithVDR(vdr), WithInboundTransport(&mockInboundTransport{}),
+			WithVDRCache(vdrpkg.WithCacheTTL(time.Minute)))
+		require.NoError(t, err)
+		require.NotEmpty(t, aries)
+
+		require.True(t, aries.vdrCache)
+		require.Len(t, aries.vdrCacheOpts, 1)
+		err = aries.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("test vdr - with did:web hosting", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}), WithDIDWebHosting())
+		require.NoError(t, err)
+		require.NotEmpty(t, aries)
+
+		docResolution, err := aries.vdrRegistry.Create("web", nil, vdrapi.WithOption(web.DomainOpt, "example.com"))
+		require.NoError(t, err)
+		require.Equal(t, "did:web:example.com", docResolution.DIDDocument.ID)
+
+		resolved, err := aries.vdrRegistry.Resolve("did:web:example.com")
+		require.NoError(t, err)
+		require.Equal(t, docResolution.DIDDocument.VerificationMethod[0].ID,
+			resolved.DIDDocument.VerificationMethod[0].ID)
+
+		err = aries.Close()
+		require.NoError(t, err)
+	})
+
 	t.Run("test error create vdr", func(t *testing.T) {
 		sp := storage.NewMockStoreProvider()
 		sp.FailNamespace = peer.StoreNamespace
@@ -167,6 +204,18 @@ func TestFramework(t *testing.T) {
 		require.Contains(t, err.Error(), "create new vdr peer failed")
 	})
 
+	t.Run("test error open vdr cache store", func(t *testing.T) {
+		sp := storage.NewMockStoreProvider()
+		sp.FailNamespace = vdrpkg.CacheStoreName
+
+		_, err := New(
+			WithStoreProvider(sp),
+			WithInboundTransport(&mockInboundTransport{}),
+			WithVDRCache())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open vdr cache store failed")
+	})
+
 	t.Run("test vdr - close error", func(t *testing.T) {
 		vdr := &mockvdr.MockVDR{CloseErr: fmt.Errorf("close vdr error")}
 		aries, err := New(WithVDR(vdr), WithInboundTransport(&mockInboundTransport{}))
@@ -591,6 +640,21 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, aries.Close())
 	})
 
+	t.Run("test close stops outbound transports", func(t *testing.T) {
+		outbound := &mockOutboundTransport{}
+		aries, err := New(WithOutboundTransports(outbound))
+		require.NoError(t, err)
+		require.NoError(t, aries.Close())
+		require.True(t, outbound.stopped)
+
+		aries, err = New(WithOutboundTransports(&mockOutboundTransport{stopError: errors.New("stop error")}))
+		require.NoError(t, err)
+
+		err = aries.Close()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "outbound transport close failed: stop error")
+	})
+
 	t.Run("test new with messenger handler", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -686,6 +750,20 @@ func TestFramework(t *testing.T) {
 		require.Equal(t, loader, aries.documentLoader)
 	})
 
+	t.Run("test JSON-LD context policy option", func(t *testing.T) {
+		aries, err := New(WithJSONLDContextPolicy(ld.PinnedContextsOnly))
+		require.NoError(t, err)
+		require.Equal(t, ld.PinnedContextsOnly, aries.contextPolicy)
+
+		_, err = aries.documentLoader.LoadDocument("https://example.com/missing.jsonld")
+		require.True(t, errors.Is(err, ld.ErrContextNotPinned))
+
+		_, err = aries.documentLoader.LoadDocument(embed.Contexts[0].URL)
+		require.NoError(t, err)
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test KeyType and KeyAgreement option", func(t *testing.T) {
 		aries, err := New(WithKeyType(kms.BLS12381G2Type), WithKeyAgreementType(kms.NISTP384ECDHKWType))
 		require.NoError(t, err)
@@ -794,6 +872,18 @@ func (m *mockInboundTransport) Endpoint() string {
 	return ""
 }
 
+type mockOutboundTransport struct {
+	didcomm.MockOutboundTransport
+	stopError error
+	stopped   bool
+}
+
+func (m *mockOutboundTransport) Stop() error {
+	m.stopped = true
+
+	return m.stopError
+}
+
 type mockProtocolService struct{}
 
 func (m mockProtocolService) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
@@ -131,6 +131,20 @@ func (mr *MockMetadataMockRecorder) IssueCredentialV2() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueCredentialV2", reflect.TypeOf((*MockMetadata)(nil).IssueCredentialV2))
 }
 
+// IssueCredentialV3 mocks base method.
+func (m *MockMetadata) IssueCredentialV3() *issuecredential.IssueCredentialV3 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueCredentialV3")
+	ret0, _ := ret[0].(*issuecredential.IssueCredentialV3)
+	return ret0
+}
+
+// IssueCredentialV3 indicates an expected call of IssueCredentialV3.
+func (mr *MockMetadataMockRecorder) IssueCredentialV3() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueCredentialV3", reflect.TypeOf((*MockMetadata)(nil).IssueCredentialV3))
+}
+
 // Message mocks base method.
 func (m *MockMetadata) Message() service.DIDCommMsg {
 	m.ctrl.T.Helper()
@@ -159,6 +173,20 @@ func (mr *MockMetadataMockRecorder) OfferCredentialV2() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferCredentialV2", reflect.TypeOf((*MockMetadata)(nil).OfferCredentialV2))
 }
 
+// OfferCredentialV3 mocks base method.
+func (m *MockMetadata) OfferCredentialV3() *issuecredential.OfferCredentialV3 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OfferCredentialV3")
+	ret0, _ := ret[0].(*issuecredential.OfferCredentialV3)
+	return ret0
+}
+
+// OfferCredentialV3 indicates an expected call of OfferCredentialV3.
+func (mr *MockMetadataMockRecorder) OfferCredentialV3() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferCredentialV3", reflect.TypeOf((*MockMetadata)(nil).OfferCredentialV3))
+}
+
 // Properties mocks base method.
 func (m *MockMetadata) Properties() map[string]interface{} {
 	m.ctrl.T.Helper()
@@ -187,6 +215,20 @@ func (mr *MockMetadataMockRecorder) ProposeCredentialV2() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeCredentialV2", reflect.TypeOf((*MockMetadata)(nil).ProposeCredentialV2))
 }
 
+// ProposeCredentialV3 mocks base method.
+func (m *MockMetadata) ProposeCredentialV3() *issuecredential.ProposeCredentialV3 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeCredentialV3")
+	ret0, _ := ret[0].(*issuecredential.ProposeCredentialV3)
+	return ret0
+}
+
+// ProposeCredentialV3 indicates an expected call of ProposeCredentialV3.
+func (mr *MockMetadataMockRecorder) ProposeCredentialV3() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeCredentialV3", reflect.TypeOf((*MockMetadata)(nil).ProposeCredentialV3))
+}
+
 // RequestCredentialV2 mocks base method.
 func (m *MockMetadata) RequestCredentialV2() *issuecredential.RequestCredentialV2 {
 	m.ctrl.T.Helper()
@@ -201,6 +243,20 @@ func (mr *MockMetadataMockRecorder) RequestCredentialV2() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCredentialV2", reflect.TypeOf((*MockMetadata)(nil).RequestCredentialV2))
 }
 
+// RequestCredentialV3 mocks base method.
+func (m *MockMetadata) RequestCredentialV3() *issuecredential.RequestCredentialV3 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCredentialV3")
+	ret0, _ := ret[0].(*issuecredential.RequestCredentialV3)
+	return ret0
+}
+
+// RequestCredentialV3 indicates an expected call of RequestCredentialV3.
+func (mr *MockMetadataMockRecorder) RequestCredentialV3() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCredentialV3", reflect.TypeOf((*MockMetadata)(nil).RequestCredentialV3))
+}
+
 // StateName mocks base method.
 func (m *MockMetadata) StateName() string {
 	m.ctrl.T.Helper()
@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package ld
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/bundle"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/remote"
 	"github.com/hyperledger/aries-framework-go/pkg/store/ld"
 )
@@ -30,6 +34,12 @@ type Service interface {
 	RefreshAllRemoteProviders(opts ...remote.ProviderOpt) error
 }
 
+// ContextBundleService is implemented by services that import and export signed context bundles.
+type ContextBundleService interface {
+	ImportContextBundle(bundle string, verifier jose.SignatureVerifier) error
+	ExportContextBundle(urls []string, issuer string, signer jose.Signer) (string, error)
+}
+
 // DefaultService is a default implementation of Service.
 type DefaultService struct {
 	contextStore        ld.ContextStore
@@ -152,3 +162,49 @@ func (s *DefaultService) RefreshAllRemoteProviders(opts ...remote.ProviderOpt) e
 
 	return nil
 }
+
+// ImportContextBundle verifies the signed context bundle (see bundle.Parse) and imports its JSON-LD contexts
+// as pinned contexts.
+func (s *DefaultService) ImportContextBundle(b string, verifier jose.SignatureVerifier) error {
+	contextBundle, err := bundle.Parse(b, verifier)
+	if err != nil {
+		return fmt.Errorf("import context bundle: %w", err)
+	}
+
+	if err := s.contextStore.ImportPinned(contextBundle.Documents); err != nil {
+		return fmt.Errorf("import pinned contexts: %w", err)
+	}
+
+	return nil
+}
+
+// ExportContextBundle exports stored JSON-LD contexts with the given URLs as a context bundle of the issuer
+// signed with the signer.
+func (s *DefaultService) ExportContextBundle(urls []string, issuer string, signer jose.Signer) (string, error) {
+	if len(urls) == 0 {
+		return "", errors.New("export context bundle: context URLs are required")
+	}
+
+	documents := make([]ldcontext.Document, 0, len(urls))
+
+	for _, u := range urls {
+		rd, err := s.contextStore.Get(u)
+		if err != nil {
+			return "", fmt.Errorf("export context bundle: get context %s: %w", u, err)
+		}
+
+		content, err := json.Marshal(rd.Document)
+		if err != nil {
+			return "", fmt.Errorf("export context bundle: marshal context %s: %w", u, err)
+		}
+
+		documents = append(documents, ldcontext.Document{URL: u, DocumentURL: rd.DocumentURL, Content: content})
+	}
+
+	contextBundle, err := bundle.New(issuer, documents)
+	if err != nil {
+		return "", fmt.Errorf("export context bundle: %w", err)
+	}
+
+	return contextBundle.Sign(signer)
+}
@@ -8,6 +8,8 @@ package ld_test
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -17,7 +19,10 @@ import (
 	jsonld "github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/remote"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 	"github.com/hyperledger/aries-framework-go/pkg/ld"
 	mockldstore "github.com/hyperledger/aries-framework-go/pkg/mock/ld"
@@ -518,6 +523,80 @@ func TestDefaultService_RefreshRemoteProvider(t *testing.T) {
 	})
 }
 
+func TestDefaultService_ContextBundle(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer := &ed25519Signer{privKey: privKey}
+	verifier := jwt.NewVerifier(jwt.KeyResolverFunc(func(_, _ string) (*sigverifier.PublicKey, error) {
+		return &sigverifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: pubKey}, nil
+	}))
+
+	contexts := ldtestutil.Contexts()
+
+	t.Run("Export and import context bundle", func(t *testing.T) {
+		store := mockldstore.NewMockContextStore()
+
+		svc := ld.New(createMockProvider(withContextStore(store)))
+		require.NoError(t, svc.AddContexts(contexts))
+
+		bundle, err := svc.ExportContextBundle([]string{contexts[0].URL, contexts[1].URL}, "did:example:issuer", signer)
+		require.NoError(t, err)
+
+		otherStore := mockldstore.NewMockContextStore()
+
+		svc = ld.New(createMockProvider(withContextStore(otherStore)))
+		require.NoError(t, svc.ImportContextBundle(bundle, verifier))
+
+		require.Len(t, otherStore.Store.Store, 2)
+		require.True(t, otherStore.PinnedURL[contexts[0].URL])
+		require.True(t, otherStore.PinnedURL[contexts[1].URL])
+	})
+
+	t.Run("Fail to export context bundle", func(t *testing.T) {
+		svc := ld.New(createMockProvider())
+
+		_, err := svc.ExportContextBundle(nil, "did:example:issuer", signer)
+		require.EqualError(t, err, "export context bundle: context URLs are required")
+
+		_, err = svc.ExportContextBundle([]string{contexts[0].URL}, "did:example:issuer", signer)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get context")
+	})
+
+	t.Run("Fail to import context bundle", func(t *testing.T) {
+		store := mockldstore.NewMockContextStore()
+
+		svc := ld.New(createMockProvider(withContextStore(store)))
+		require.NoError(t, svc.AddContexts(contexts))
+
+		bundle, err := svc.ExportContextBundle([]string{contexts[0].URL}, "did:example:issuer", signer)
+		require.NoError(t, err)
+
+		err = svc.ImportContextBundle(bundle, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "import context bundle")
+
+		store.ErrImport = errors.New("import error")
+
+		err = svc.ImportContextBundle(bundle, verifier)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "import pinned contexts")
+	})
+}
+
+type ed25519Signer struct {
+	privKey ed25519.PrivateKey
+}
+
+func (s *ed25519Signer) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+func (s *ed25519Signer) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA"}
+}
+
 type mockHTTPClient struct {
 	DoFunc func(req *http.Request) (*http.Response, error)
 }
@@ -22,9 +22,11 @@ import (
 // MockContextStore is a mock JSON-LD context store.
 type MockContextStore struct {
 	Store     *mockstorage.MockStore
+	PinnedURL map[string]bool
 	ErrGet    error
 	ErrPut    error
 	ErrImport error
+	ErrPinned error
 	ErrDelete error
 }
 
@@ -34,6 +36,7 @@ func NewMockContextStore() *MockContextStore {
 		Store: &mockstorage.MockStore{
 			Store: make(map[string]mockstorage.DBEntry),
 		},
+		PinnedURL: make(map[string]bool),
 	}
 }
 
@@ -105,6 +108,28 @@ func (m *MockContextStore) Import(documents []ldcontext.Document) error {
 	return nil
 }
 
+// ImportPinned imports contexts into the underlying storage and pins them.
+func (m *MockContextStore) ImportPinned(documents []ldcontext.Document) error {
+	if err := m.Import(documents); err != nil {
+		return err
+	}
+
+	for _, d := range documents {
+		m.PinnedURL[d.URL] = true
+	}
+
+	return nil
+}
+
+// Pinned reports whether context document is pinned.
+func (m *MockContextStore) Pinned(u string) (bool, error) {
+	if m.ErrPinned != nil {
+		return false, m.ErrPinned
+	}
+
+	return m.PinnedURL[u], nil
+}
+
 // Delete deletes context documents in the underlying storage.
 func (m *MockContextStore) Delete(documents []ldcontext.Document) error {
 	if m.ErrDelete != nil {
@@ -18,14 +18,15 @@ import (
 // MockVDRegistry mock implementation of vdr
 // to be used only for unit tests.
 type MockVDRegistry struct {
-	CreateErr      error
-	CreateValue    *did.Doc
-	CreateFunc     func(string, *did.Doc, ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
-	UpdateFunc     func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error
-	DeactivateFunc func(did string, opts ...vdrapi.DIDMethodOption) error
-	ResolveErr     error
-	ResolveValue   *did.Doc
-	ResolveFunc    func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
+	CreateErr       error
+	CreateValue     *did.Doc
+	CreateFunc      func(string, *did.Doc, ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
+	UpdateFunc      func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error
+	DeactivateFunc  func(did string, opts ...vdrapi.DIDMethodOption) error
+	ResolveErr      error
+	ResolveValue    *did.Doc
+	ResolveFunc     func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
+	DereferenceFunc func(didURL string, opts ...vdrapi.DIDMethodOption) (*did.DereferenceResult, error)
 }
 
 // Create mock implementation of create DID.
@@ -64,6 +65,25 @@ func (m *MockVDRegistry) Resolve(didID string, opts ...vdrapi.DIDMethodOption) (
 	return &did.DocResolution{DIDDocument: m.ResolveValue}, nil
 }
 
+// Dereference DID URL against the DID document returned by Resolve.
+func (m *MockVDRegistry) Dereference(didURL string, opts ...vdrapi.DIDMethodOption) (*did.DereferenceResult, error) {
+	if m.DereferenceFunc != nil {
+		return m.DereferenceFunc(didURL, opts...)
+	}
+
+	parsed, err := did.ParseDIDURL(didURL)
+	if err != nil {
+		return nil, err
+	}
+
+	docResolution, err := m.Resolve(parsed.DID.String(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return docResolution.Dereference(parsed)
+}
+
 // Update did.
 func (m *MockVDRegistry) Update(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
 	if m.UpdateFunc != nil {
@@ -8,8 +8,8 @@ package ld
 
 import (
 	"bytes"
-	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	jsonld "github.com/piprate/json-gold/ld"
@@ -29,19 +29,43 @@ const (
 
 var logger = log.New("aries-framework/store/ld")
 
+// ErrContextDigestMismatch is returned when the stored JSON-LD context document doesn't match its digest
+// or the imported context document doesn't match the digest it declares.
+var ErrContextDigestMismatch = errors.New("context digest mismatch")
+
+// ErrContextPinned is returned when a pinned JSON-LD context document would be replaced by a different document
+// not coming from a trusted source.
+var ErrContextPinned = errors.New("context is pinned")
+
 // ContextStore represents a repository for JSON-LD context operations.
 type ContextStore interface {
 	Get(u string) (*jsonld.RemoteDocument, error)
 	Put(u string, rd *jsonld.RemoteDocument) error
 	Import(documents []ldcontext.Document) error
+	ImportPinned(documents []ldcontext.Document) error
+	Pinned(u string) (bool, error)
 	Delete(documents []ldcontext.Document) error
 }
 
 // ContextStoreImpl is a default implementation of JSON-LD context repository.
+//
+// Context documents are stored with the digest of their content (see ldcontext.ComputeDigest) which is verified
+// every time the document is loaded. Pinned context documents (imported from signed context bundles) are not
+// replaced by changed versions of the documents imported later, e.g. by an updated remote provider.
 type ContextStoreImpl struct {
 	store storage.Store
 }
 
+// contextRecord is a stored JSON-LD context document. It is a superset of jsonld.RemoteDocument, so records
+// stored without digest are still readable.
+type contextRecord struct {
+	jsonld.RemoteDocument
+	Digest string `json:"digest,omitempty"`
+	Pinned bool   `json:"pinned,omitempty"`
+
+	withoutDigest bool
+}
+
 // NewContextStore returns a new instance of ContextStoreImpl.
 func NewContextStore(storageProvider storage.Provider) (*ContextStoreImpl, error) {
 	store, err := storageProvider.OpenStore(ContextStoreName)
@@ -59,24 +83,76 @@ func NewContextStore(storageProvider storage.Provider) (*ContextStoreImpl, error
 }
 
 // Get returns JSON-LD remote document from the underlying storage by context url.
+// ErrContextDigestMismatch is returned if the document doesn't match its digest.
 func (s *ContextStoreImpl) Get(u string) (*jsonld.RemoteDocument, error) {
+	record, err := s.get(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return &record.RemoteDocument, nil
+}
+
+// Pinned reports whether JSON-LD context document with the given url is pinned.
+func (s *ContextStoreImpl) Pinned(u string) (bool, error) {
+	record, err := s.get(u)
+	if err != nil {
+		return false, err
+	}
+
+	return record.Pinned, nil
+}
+
+func (s *ContextStoreImpl) get(u string) (*contextRecord, error) {
 	b, err := s.store.Get(u)
 	if err != nil {
 		return nil, fmt.Errorf("get context from store: %w", err)
 	}
 
-	var rd jsonld.RemoteDocument
+	var record contextRecord
 
-	if err := json.Unmarshal(b, &rd); err != nil {
+	if err := json.Unmarshal(b, &record); err != nil {
 		return nil, fmt.Errorf("unmarshal context document: %w", err)
 	}
 
-	return &rd, nil
+	if record.Digest == "" { // stored before digests were introduced
+		return &record, nil
+	}
+
+	digest, err := computeDigest(record.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	if digest != record.Digest {
+		return nil, fmt.Errorf("context %s: %w", u, ErrContextDigestMismatch)
+	}
+
+	return &record, nil
 }
 
 // Put saves JSON-LD remote document into the underlying storage under key u (context url).
+// ErrContextPinned is returned if a pinned document with a different digest is stored under the url.
 func (s *ContextStoreImpl) Put(u string, rd *jsonld.RemoteDocument) error {
-	b, err := json.Marshal(rd)
+	digest, err := computeDigest(rd.Document)
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.get(u)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return err
+	}
+
+	if stored != nil && stored.Pinned {
+		if stored.Digest == digest {
+			return nil
+		}
+
+		return fmt.Errorf("context %s: %w", u, ErrContextPinned)
+	}
+
+	b, err := json.Marshal(&contextRecord{RemoteDocument: *rd, Digest: digest})
 	if err != nil {
 		return fmt.Errorf("marshal remote document: %w", err)
 	}
@@ -88,53 +164,99 @@ func (s *ContextStoreImpl) Put(u string, rd *jsonld.RemoteDocument) error {
 	return nil
 }
 
-// Import imports JSON-LD contexts into the underlying storage.
+// Import imports JSON-LD contexts into the underlying storage. Contexts are verified against the digest
+// they declare, if any. Changed contexts replace stored versions unless stored versions are pinned.
 func (s *ContextStoreImpl) Import(documents []ldcontext.Document) error {
-	hashes, err := computeContextHashes(s.store)
+	return s.importContexts(documents, false)
+}
+
+// ImportPinned imports JSON-LD contexts into the underlying storage and pins them. The contexts should come from
+// a trusted source, e.g. a verified context bundle. Pinned contexts replace any stored versions.
+func (s *ContextStoreImpl) ImportPinned(documents []ldcontext.Document) error {
+	return s.importContexts(documents, true)
+}
+
+func (s *ContextStoreImpl) importContexts(documents []ldcontext.Document, pinned bool) error {
+	records, err := getContextRecords(s.store)
 	if err != nil {
-		return fmt.Errorf("compute context hashes: %w", err)
+		return fmt.Errorf("get context records: %w", err)
 	}
 
-	var contexts []ldcontext.Document
+	var ops []storage.Operation
 
 	for _, c := range documents {
-		b, er := getRemoteDocumentBytes(c)
+		record, er := newContextRecord(c, pinned)
 		if er != nil {
-			return fmt.Errorf("get remote document bytes: %w", er)
+			return er
 		}
 
-		// filter out up-to-date contexts
-		if computeHash(b) == hashes[c.URL] {
+		stored, ok := records[c.URL]
+		if ok && !replace(c.URL, stored, record) {
 			continue
 		}
 
-		contexts = append(contexts, c)
+		b, er := json.Marshal(record)
+		if er != nil {
+			return fmt.Errorf("marshal remote document: %w", er)
+		}
+
+		ops = append(ops, storage.Operation{
+			Key:   c.URL,
+			Value: b,
+			Tags:  []storage.Tag{{Name: ContextRecordTag}},
+		})
+	}
+
+	if len(ops) == 0 {
+		return nil
 	}
 
 	// import context documents into the underlying storage
-	if err = save(s.store, contexts); err != nil {
-		return fmt.Errorf("save context documents: %w", err)
+	if err = s.store.Batch(ops); err != nil {
+		return fmt.Errorf("store batch of contexts: %w", err)
 	}
 
 	return nil
 }
 
+// replace checks if the stored context record should be replaced by the imported one.
+func replace(u string, stored, imported *contextRecord) bool {
+	if stored.Digest == imported.Digest {
+		// up-to-date context, the record is updated to store the digest, to pin it or to change the document URL
+		return stored.withoutDigest || (imported.Pinned && !stored.Pinned) ||
+			(stored.DocumentURL != imported.DocumentURL && !stored.Pinned)
+	}
+
+	if stored.Pinned && !imported.Pinned {
+		logger.Warnf("pinned context %s is not replaced by the changed context with digest %s",
+			u, imported.Digest)
+
+		return false
+	}
+
+	if !stored.withoutDigest {
+		logger.Warnf("context %s changed: digest %s is replaced by %s", u, stored.Digest, imported.Digest)
+	}
+
+	return true
+}
+
 // Delete deletes matched context documents in the underlying storage.
-// Documents are matched by context URL and ld.RemoteDocument content hash.
+// Documents are matched by context URL and content digest.
 func (s *ContextStoreImpl) Delete(documents []ldcontext.Document) error {
-	hashes, err := computeContextHashes(s.store)
+	records, err := getContextRecords(s.store)
 	if err != nil {
-		return fmt.Errorf("compute context hashes: %w", err)
+		return fmt.Errorf("get context records: %w", err)
 	}
 
 	for _, d := range documents {
-		b, er := getRemoteDocumentBytes(d)
+		record, er := newContextRecord(d, false)
 		if er != nil {
-			return fmt.Errorf("get remote document bytes: %w", er)
+			return er
 		}
 
-		// delete document only if content hashes match
-		if computeHash(b) == hashes[d.URL] {
+		// delete document only if content digests match
+		if stored, ok := records[d.URL]; ok && stored.Digest == record.Digest {
 			if err := s.store.Delete(d.URL); err != nil {
 				return fmt.Errorf("delete context document: %w", err)
 			}
@@ -144,7 +266,7 @@ func (s *ContextStoreImpl) Delete(documents []ldcontext.Document) error {
 	return nil
 }
 
-func computeContextHashes(store storage.Store) (map[string]string, error) {
+func getContextRecords(store storage.Store) (map[string]*contextRecord, error) {
 	iter, err := store.Query(ContextRecordTag)
 	if err != nil {
 		return nil, fmt.Errorf("query store: %w", err)
@@ -157,7 +279,7 @@ func computeContextHashes(store storage.Store) (map[string]string, error) {
 		}
 	}()
 
-	contexts := make(map[string]string)
+	records := make(map[string]*contextRecord)
 
 	for {
 		if ok, err := iter.Next(); !ok || err != nil {
@@ -178,59 +300,61 @@ func computeContextHashes(store storage.Store) (map[string]string, error) {
 			return nil, fmt.Errorf("get value: %w", err)
 		}
 
-		contexts[k] = computeHash(v)
-	}
+		record := &contextRecord{}
 
-	return contexts, nil
-}
+		if err = json.Unmarshal(v, record); err != nil {
+			return nil, fmt.Errorf("unmarshal context document: %w", err)
+		}
 
-func computeHash(b []byte) string {
-	return fmt.Sprintf("%x", sha256.Sum256(b))
+		if record.Digest == "" { // stored before digests were introduced
+			if record.Digest, err = computeDigest(record.Document); err != nil {
+				return nil, err
+			}
+
+			record.withoutDigest = true
+		}
+
+		records[k] = record
+	}
+
+	return records, nil
 }
 
-func getRemoteDocumentBytes(d ldcontext.Document) ([]byte, error) {
+func newContextRecord(d ldcontext.Document, pinned bool) (*contextRecord, error) {
 	document, err := jsonld.DocumentFromReader(bytes.NewReader(d.Content))
 	if err != nil {
 		return nil, fmt.Errorf("document from reader: %w", err)
 	}
 
-	rd := jsonld.RemoteDocument{
-		DocumentURL: d.DocumentURL,
-		Document:    document,
+	digest, err := computeDigest(document)
+	if err != nil {
+		return nil, err
 	}
 
-	b, err := json.Marshal(rd)
-	if err != nil {
-		return nil, fmt.Errorf("marshal remote document: %w", err)
+	if d.Digest != "" && d.Digest != digest {
+		return nil, fmt.Errorf("context %s: %w", d.URL, ErrContextDigestMismatch)
 	}
 
-	return b, nil
+	return &contextRecord{
+		RemoteDocument: jsonld.RemoteDocument{
+			DocumentURL: d.DocumentURL,
+			Document:    document,
+		},
+		Digest: digest,
+		Pinned: pinned,
+	}, nil
 }
 
-// save stores contexts into the underlying storage.
-func save(store storage.Store, contexts []ldcontext.Document) error {
-	var ops []storage.Operation
-
-	for _, c := range contexts {
-		b, err := getRemoteDocumentBytes(c)
-		if err != nil {
-			return fmt.Errorf("get remote document bytes: %w", err)
-		}
-
-		ops = append(ops, storage.Operation{
-			Key:   c.URL,
-			Value: b,
-			Tags:  []storage.Tag{{Name: ContextRecordTag}},
-		})
-	}
-
-	if len(ops) == 0 {
-		return nil
+func computeDigest(document interface{}) (string, error) {
+	b, err := json.Marshal(document)
+	if err != nil {
+		return "", fmt.Errorf("marshal context document: %w", err)
 	}
 
-	if err := store.Batch(ops); err != nil {
-		return fmt.Errorf("store batch of contexts: %w", err)
+	digest, err := ldcontext.ComputeDigest(b)
+	if err != nil {
+		return "", fmt.Errorf("compute digest: %w", err)
 	}
 
-	return nil
+	return digest, nil
 }
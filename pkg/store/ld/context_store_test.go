@@ -129,6 +129,45 @@ func TestContextStoreImpl_Put(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "put remote document")
 	})
+
+	t.Run("Fail to get stored remote document", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+		storageProvider.Store.ErrGet = errors.New("get error")
+
+		contextStore, err := ld.NewContextStore(storageProvider)
+		require.NoError(t, err)
+
+		err = contextStore.Put(sampleContextURL, getRemoteDocument(t, json.RawMessage(sampleJSONLDContext)))
+		require.EqualError(t, err, "get context from store: get error")
+	})
+}
+
+func TestContextStoreImpl_PutPinned(t *testing.T) {
+	storageProvider := mockstorage.NewMockStoreProvider()
+
+	contextStore, err := ld.NewContextStore(storageProvider)
+	require.NoError(t, err)
+
+	require.NoError(t, contextStore.ImportPinned([]ldcontext.Document{{
+		URL:     sampleContextURL,
+		Content: json.RawMessage(`{"@context":"original-context"}`),
+	}}))
+
+	// the same document leaves the pinned context as is
+	err = contextStore.Put(sampleContextURL, getRemoteDocument(t, json.RawMessage(`{"@context":"original-context"}`)))
+	require.NoError(t, err)
+
+	pinned, err := contextStore.Pinned(sampleContextURL)
+	require.NoError(t, err)
+	require.True(t, pinned)
+
+	err = contextStore.Put(sampleContextURL, getRemoteDocument(t, json.RawMessage(`{"@context":"updated-context"}`)))
+	require.True(t, errors.Is(err, ld.ErrContextPinned))
+	assertContextInStore(t, storageProvider.Store, sampleContextURL, "original-context")
+
+	pinned, err = contextStore.Pinned(sampleContextURL)
+	require.NoError(t, err)
+	require.True(t, pinned)
 }
 
 func TestContextStoreImpl_Import(t *testing.T) {
@@ -414,6 +453,167 @@ func TestContextStoreImpl_Delete(t *testing.T) {
 	})
 }
 
+func TestContextStoreImpl_Digest(t *testing.T) {
+	contexts := []ldcontext.Document{
+		{
+			URL:     sampleContextURL,
+			Content: json.RawMessage(sampleJSONLDContext),
+		},
+	}
+
+	t.Run("Verify digest of stored context", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		contextStore, err := ld.NewContextStore(storageProvider)
+		require.NoError(t, err)
+
+		require.NoError(t, contextStore.Import(contexts))
+
+		_, err = contextStore.Get(sampleContextURL)
+		require.NoError(t, err)
+
+		// change the stored document without updating its digest
+		entry := storageProvider.Store.Store[sampleContextURL]
+		entry.Value = bytes.Replace(entry.Value, []byte("foaf"), []byte("evil"), 1)
+		storageProvider.Store.Store[sampleContextURL] = entry
+
+		_, err = contextStore.Get(sampleContextURL)
+		require.True(t, errors.Is(err, ld.ErrContextDigestMismatch))
+
+		_, err = contextStore.Pinned(sampleContextURL)
+		require.True(t, errors.Is(err, ld.ErrContextDigestMismatch))
+	})
+
+	t.Run("Verify declared digest of imported context", func(t *testing.T) {
+		contextStore, err := ld.NewContextStore(mockstorage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		digest, err := ldcontext.ComputeDigest(contexts[0].Content)
+		require.NoError(t, err)
+
+		err = contextStore.Import([]ldcontext.Document{{URL: sampleContextURL, Content: contexts[0].Content,
+			Digest: digest}})
+		require.NoError(t, err)
+
+		err = contextStore.Import([]ldcontext.Document{{URL: sampleContextURL,
+			Content: json.RawMessage(`{"@context":"updated-context"}`), Digest: digest}})
+		require.True(t, errors.Is(err, ld.ErrContextDigestMismatch))
+	})
+
+	t.Run("Store digest of context stored without digest", func(t *testing.T) {
+		store := &mockStore{
+			MockStore: &mockstorage.MockStore{
+				Store: make(map[string]mockstorage.DBEntry),
+			},
+		}
+
+		setSampleContextInStore(t, store)
+
+		contextStore, err := ld.NewContextStore(&mockstorage.MockStoreProvider{Custom: store})
+		require.NoError(t, err)
+
+		_, err = contextStore.Get(sampleContextURL)
+		require.NoError(t, err)
+
+		require.NoError(t, contextStore.Import(contexts))
+		require.Equal(t, 1, store.BatchSize)
+		require.Contains(t, string(store.Store[sampleContextURL].Value), `"digest"`)
+
+		store.BatchSize = 0
+
+		require.NoError(t, contextStore.Import(contexts))
+		require.Equal(t, 0, store.BatchSize)
+	})
+
+	t.Run("Put context with digest", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		contextStore, err := ld.NewContextStore(storageProvider)
+		require.NoError(t, err)
+
+		require.NoError(t, contextStore.Put(sampleContextURL, getRemoteDocument(t, contexts[0].Content)))
+		require.Contains(t, string(storageProvider.Store.Store[sampleContextURL].Value), `"digest"`)
+
+		rd, err := contextStore.Get(sampleContextURL)
+		require.NoError(t, err)
+		require.NotNil(t, rd.Document)
+	})
+}
+
+func TestContextStoreImpl_ImportPinned(t *testing.T) {
+	t.Run("Pinned contexts are not replaced by changed contexts", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		contextStore, err := ld.NewContextStore(storageProvider)
+		require.NoError(t, err)
+
+		contexts := []ldcontext.Document{
+			{
+				URL:     sampleContextURL,
+				Content: json.RawMessage(`{"@context":"original-context"}`),
+			},
+		}
+
+		require.NoError(t, contextStore.Import(contexts))
+
+		pinned, err := contextStore.Pinned(sampleContextURL)
+		require.NoError(t, err)
+		require.False(t, pinned)
+
+		// pin the up-to-date context
+		require.NoError(t, contextStore.ImportPinned(contexts))
+
+		pinned, err = contextStore.Pinned(sampleContextURL)
+		require.NoError(t, err)
+		require.True(t, pinned)
+
+		// the same context stays pinned
+		require.NoError(t, contextStore.Import(contexts))
+
+		pinned, err = contextStore.Pinned(sampleContextURL)
+		require.NoError(t, err)
+		require.True(t, pinned)
+
+		changed := []ldcontext.Document{
+			{
+				URL:     sampleContextURL,
+				Content: json.RawMessage(`{"@context":"updated-context"}`),
+			},
+		}
+
+		require.NoError(t, contextStore.Import(changed))
+		assertContextInStore(t, storageProvider.Store, sampleContextURL, "original-context")
+
+		// changed pinned context replaces the pinned one
+		require.NoError(t, contextStore.ImportPinned(changed))
+		assertContextInStore(t, storageProvider.Store, sampleContextURL, "updated-context")
+
+		pinned, err = contextStore.Pinned(sampleContextURL)
+		require.NoError(t, err)
+		require.True(t, pinned)
+	})
+
+	t.Run("Fail to import pinned contexts", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+		storageProvider.Store.ErrQuery = errors.New("query error")
+
+		contextStore, err := ld.NewContextStore(storageProvider)
+		require.NoError(t, err)
+
+		err = contextStore.ImportPinned(embed.Contexts)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "query store")
+	})
+
+	t.Run("Fail to get pinned status of missing context", func(t *testing.T) {
+		contextStore, err := ld.NewContextStore(mockstorage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		_, err = contextStore.Pinned(sampleContextURL)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+}
+
 func assertContextInStore(t *testing.T, store storage.Store, url, value string) {
 	t.Helper()
 
@@ -0,0 +1,324 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// CacheStoreName is the name of the store resolved DID documents are persisted in.
+	CacheStoreName = "vdrcache"
+
+	defaultCacheTTL         = 5 * time.Minute
+	defaultCacheNegativeTTL = time.Minute
+	defaultCacheMaxEntries  = 1000
+)
+
+var logger = log.New("aries-framework/vdr")
+
+// CacheOption configures the cache of resolved DID documents.
+type CacheOption func(opts *cache)
+
+// WithCacheTTL sets the time resolved DID documents are cached for,
+// unless the "nextUpdate" document metadata tells the document changes earlier.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(opts *cache) {
+		opts.ttl = ttl
+	}
+}
+
+// WithCacheMethodTTL sets the cache TTL of DID documents of the given DID method.
+// Zero TTL disables caching for the DID method.
+func WithCacheMethodTTL(method string, ttl time.Duration) CacheOption {
+	return func(opts *cache) {
+		opts.methodTTL[method] = ttl
+	}
+}
+
+// WithCacheNegativeTTL sets the time "DID not found" results are cached for. Zero TTL disables negative caching.
+func WithCacheNegativeTTL(ttl time.Duration) CacheOption {
+	return func(opts *cache) {
+		opts.negativeTTL = ttl
+	}
+}
+
+// WithCacheMaxEntries sets the maximal number of cached DID documents,
+// the least recently used ones are evicted first.
+func WithCacheMaxEntries(maxEntries int) CacheOption {
+	return func(opts *cache) {
+		opts.maxEntries = maxEntries
+	}
+}
+
+// WithCacheStore persists the cache in the given store, so the cache survives restarts.
+// Use CacheStoreName to open the store from a storage provider.
+func WithCacheStore(s storage.Store) CacheOption {
+	return func(opts *cache) {
+		opts.store = s
+	}
+}
+
+// WithCache enables caching of DID documents resolved by the registry. Only resolutions without DID method
+// options are cached as options (e.g. a version of the document) may change the resolution result.
+func WithCache(opts ...CacheOption) Option {
+	return func(r *Registry) {
+		r.cache = newCache(opts...)
+	}
+}
+
+// cache is a size bounded LRU cache of DID resolution results.
+type cache struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	methodTTL   map[string]time.Duration
+	maxEntries  int
+	store       storage.Store
+	now         func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	DID        string          `json:"did"`
+	Resolution json.RawMessage `json:"resolution,omitempty"`
+	NotFound   bool            `json:"notFound,omitempty"`
+	Expires    time.Time       `json:"expires"`
+}
+
+func newCache(opts ...CacheOption) *cache {
+	c := &cache{
+		ttl:         defaultCacheTTL,
+		negativeTTL: defaultCacheNegativeTTL,
+		methodTTL:   map[string]time.Duration{},
+		maxEntries:  defaultCacheMaxEntries,
+		now:         time.Now,
+		entries:     map[string]*list.Element{},
+		lru:         list.New(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// get returns cached resolution of the DID. The found flag is false when the DID is not cached,
+// otherwise either the resolution or vdrapi.ErrNotFound is returned.
+func (c *cache) get(did string) (*diddoc.DocResolution, bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry := c.lookup(did)
+	if entry == nil {
+		return nil, false, nil
+	}
+
+	if entry.NotFound {
+		return nil, true, vdrapi.ErrNotFound
+	}
+
+	// the resolution is parsed on every hit, so callers never share (and modify) the cached document.
+	docResolution, err := diddoc.ParseDocumentResolution(entry.Resolution)
+	if err != nil {
+		logger.Warnf("parse cached DID document of %s: %s", did, err)
+
+		c.remove(did)
+
+		return nil, false, nil
+	}
+
+	return docResolution, true, nil
+}
+
+func (c *cache) lookup(did string) *cacheEntry {
+	if elem, ok := c.entries[did]; ok {
+		entry := elem.Value.(*cacheEntry) //nolint:errcheck,forcetypeassert
+
+		if c.now().Before(entry.Expires) {
+			c.lru.MoveToFront(elem)
+
+			return entry
+		}
+
+		c.remove(did)
+
+		return nil
+	}
+
+	entry := c.load(did)
+	if entry != nil {
+		c.add(entry)
+	}
+
+	return entry
+}
+
+// put caches the result of DID resolution. Only successful and "not found" resolutions are cached.
+func (c *cache) put(method, did string, docResolution *diddoc.DocResolution, resolveErr error) {
+	ttl := c.entryTTL(method, docResolution, resolveErr)
+	if ttl <= 0 {
+		return
+	}
+
+	entry := &cacheEntry{
+		DID:      did,
+		NotFound: resolveErr != nil,
+		Expires:  c.now().Add(ttl),
+	}
+
+	if docResolution != nil && resolveErr == nil {
+		resolution, err := docResolution.JSONBytes()
+		if err != nil {
+			logger.Warnf("cache DID document of %s: %s", did, err)
+
+			return
+		}
+
+		entry.Resolution = resolution
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.remove(did)
+	c.add(entry)
+	c.persist(entry)
+}
+
+func (c *cache) entryTTL(method string, docResolution *diddoc.DocResolution, resolveErr error) time.Duration {
+	if resolveErr != nil {
+		if !errors.Is(resolveErr, vdrapi.ErrNotFound) {
+			return 0
+		}
+
+		return c.negativeTTL
+	}
+
+	if docResolution == nil {
+		return 0
+	}
+
+	ttl := c.ttl
+	if methodTTL, ok := c.methodTTL[method]; ok {
+		ttl = methodTTL
+	}
+
+	if docResolution.DocumentMetadata != nil && docResolution.DocumentMetadata.NextUpdate != nil {
+		if untilUpdate := docResolution.DocumentMetadata.NextUpdate.Sub(c.now()); untilUpdate < ttl {
+			ttl = untilUpdate
+		}
+	}
+
+	return ttl
+}
+
+// invalidate removes the DID from the cache.
+func (c *cache) invalidate(did string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.remove(did)
+}
+
+func (c *cache) add(entry *cacheEntry) {
+	c.entries[entry.DID] = c.lru.PushFront(entry)
+
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back().Value.(*cacheEntry).DID) //nolint:forcetypeassert
+	}
+}
+
+func (c *cache) remove(did string) {
+	if elem, ok := c.entries[did]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, did)
+	}
+
+	if c.store != nil {
+		if err := c.store.Delete(did); err != nil {
+			logger.Warnf("delete cached DID document of %s: %s", did, err)
+		}
+	}
+}
+
+func (c *cache) persist(entry *cacheEntry) {
+	if c.store == nil {
+		return
+	}
+
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		logger.Warnf("persist cached DID document of %s: %s", entry.DID, err)
+
+		return
+	}
+
+	if err = c.store.Put(entry.DID, entryBytes); err != nil {
+		logger.Warnf("persist cached DID document of %s: %s", entry.DID, err)
+	}
+}
+
+// load returns the not expired cache entry from the store.
+func (c *cache) load(did string) *cacheEntry {
+	if c.store == nil {
+		return nil
+	}
+
+	entryBytes, err := c.store.Get(did)
+	if err != nil {
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			logger.Warnf("load cached DID document of %s: %s", did, err)
+		}
+
+		return nil
+	}
+
+	entry, err := parseCacheEntry(entryBytes)
+	if err != nil {
+		logger.Warnf("load cached DID document of %s: %s", did, err)
+
+		return nil
+	}
+
+	if !c.now().Before(entry.Expires) {
+		c.remove(did)
+
+		return nil
+	}
+
+	return entry
+}
+
+func parseCacheEntry(entryBytes []byte) (*cacheEntry, error) {
+	entry := &cacheEntry{}
+
+	if err := json.Unmarshal(entryBytes, entry); err != nil {
+		return nil, fmt.Errorf("unmarshal cache entry: %w", err)
+	}
+
+	if !entry.NotFound {
+		if _, err := diddoc.ParseDocumentResolution(entry.Resolution); err != nil {
+			return nil, fmt.Errorf("parse document resolution: %w", err)
+		}
+	}
+
+	return entry, nil
+}
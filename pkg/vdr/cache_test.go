@@ -0,0 +1,304 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+type countingVDR struct {
+	mockvdr.MockVDR
+	reads map[string]int
+}
+
+func newCountingVDR(readFunc func(didID string) (*did.DocResolution, error)) *countingVDR {
+	v := &countingVDR{reads: map[string]int{}}
+	v.AcceptValue = true
+	v.ReadFunc = func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+		v.reads[didID]++
+
+		return readFunc(didID)
+	}
+
+	return v
+}
+
+func resolveDoc(didID string) (*did.DocResolution, error) {
+	if didID == "did:example:unknown" {
+		return nil, vdrapi.ErrNotFound
+	}
+
+	if didID == "did:example:failure" {
+		return nil, errors.New("read error")
+	}
+
+	return &did.DocResolution{DIDDocument: &did.Doc{Context: []string{did.ContextV1}, ID: didID}}, nil
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	t.Run("caches resolved DID document until TTL expires", func(t *testing.T) {
+		v := newCountingVDR(resolveDoc)
+		registry := New(WithVDR(v), WithCache(WithCacheTTL(time.Minute)))
+
+		now := time.Now()
+		registry.cache.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			docResolution, err := registry.Resolve("did:example:123")
+			require.NoError(t, err)
+			require.Equal(t, "did:example:123", docResolution.DIDDocument.ID)
+		}
+
+		require.Equal(t, 1, v.reads["did:example:123"])
+
+		now = now.Add(time.Minute)
+
+		_, err := registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 2, v.reads["did:example:123"])
+	})
+
+	t.Run("cached DID document is not shared between callers", func(t *testing.T) {
+		v := newCountingVDR(resolveDoc)
+		registry := New(WithVDR(v), WithCache())
+
+		docResolution, err := registry.Resolve("did:example:123")
+		require.NoError(t, err)
+
+		docResolution.DIDDocument.ID = "did:example:modified"
+
+		docResolution, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", docResolution.DIDDocument.ID)
+
+		docResolution.DIDDocument.Service = append(docResolution.DIDDocument.Service, did.Service{ID: "#modified"})
+
+		docResolution, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", docResolution.DIDDocument.ID)
+		require.Empty(t, docResolution.DIDDocument.Service)
+		require.Equal(t, 1, v.reads["did:example:123"])
+	})
+
+	t.Run("resolution with options is not cached", func(t *testing.T) {
+		v := newCountingVDR(resolveDoc)
+		registry := New(WithVDR(v), WithCache())
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve("did:example:123", vdrapi.WithOption(vdrapi.VersionIDOpt, "1"))
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 2, v.reads["did:example:123"])
+	})
+
+	t.Run("TTL of DID method", func(t *testing.T) {
+		v := newCountingVDR(resolveDoc)
+		registry := New(WithVDR(v), WithCache(WithCacheMethodTTL("example", 0)))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve("did:example:123")
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 2, v.reads["did:example:123"])
+	})
+
+	t.Run("TTL from next update of document metadata", func(t *testing.T) {
+		now := time.Now()
+		nextUpdate := now.Add(time.Second)
+
+		v := newCountingVDR(func(didID string) (*did.DocResolution, error) {
+			return &did.DocResolution{
+				DIDDocument:      &did.Doc{ID: didID},
+				DocumentMetadata: &did.DocumentMetadata{NextUpdate: &nextUpdate},
+			}, nil
+		})
+		registry := New(WithVDR(v), WithCache(WithCacheTTL(time.Hour)))
+		registry.cache.now = func() time.Time { return now }
+
+		_, err := registry.Resolve("did:example:123")
+		require.NoError(t, err)
+
+		now = now.Add(time.Second)
+
+		_, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 2, v.reads["did:example:123"])
+	})
+
+	t.Run("negative caching", func(t *testing.T) {
+		v := newCountingVDR(resolveDoc)
+		registry := New(WithVDR(v), WithCache(WithCacheNegativeTTL(time.Minute)))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve("did:example:unknown")
+			require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		}
+
+		require.Equal(t, 1, v.reads["did:example:unknown"])
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve("did:example:failure")
+			require.EqualError(t, err, "did method read failed failed: read error")
+		}
+
+		require.Equal(t, 2, v.reads["did:example:failure"])
+
+		registry = New(WithVDR(v), WithCache(WithCacheNegativeTTL(0)))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve("did:example:unknown")
+			require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		}
+
+		require.Equal(t, 3, v.reads["did:example:unknown"])
+	})
+
+	t.Run("evicts least recently used DID document", func(t *testing.T) {
+		v := newCountingVDR(resolveDoc)
+		registry := New(WithVDR(v), WithCache(WithCacheMaxEntries(2)))
+
+		for _, didID := range []string{"did:example:1", "did:example:2", "did:example:1", "did:example:3"} {
+			_, err := registry.Resolve(didID)
+			require.NoError(t, err)
+		}
+
+		require.Len(t, registry.cache.entries, 2)
+
+		_, err := registry.Resolve("did:example:1")
+		require.NoError(t, err)
+		require.Equal(t, 1, v.reads["did:example:1"])
+
+		_, err = registry.Resolve("did:example:2")
+		require.NoError(t, err)
+		require.Equal(t, 2, v.reads["did:example:2"])
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+		v := newCountingVDR(resolveDoc)
+		registry := New(WithVDR(v), WithCache())
+
+		resolve := func() {
+			_, err := registry.Resolve("did:example:123")
+			require.NoError(t, err)
+		}
+
+		resolve()
+		registry.InvalidateCache("did:example:123")
+		resolve()
+		require.Equal(t, 2, v.reads["did:example:123"])
+
+		require.NoError(t, registry.Update(&did.Doc{ID: "did:example:123"}))
+		resolve()
+		require.Equal(t, 3, v.reads["did:example:123"])
+
+		require.NoError(t, registry.Deactivate("did:example:123"))
+		resolve()
+		require.Equal(t, 4, v.reads["did:example:123"])
+
+		v.UpdateFunc = func(*did.Doc, ...vdrapi.DIDMethodOption) error {
+			return errors.New("update error")
+		}
+
+		require.EqualError(t, registry.Update(&did.Doc{ID: "did:example:123"}), "update error")
+		resolve()
+		require.Equal(t, 4, v.reads["did:example:123"])
+
+		New().InvalidateCache("did:example:123")
+	})
+
+	t.Run("created DID is not served from negative cache", func(t *testing.T) {
+		created := false
+
+		v := newCountingVDR(func(didID string) (*did.DocResolution, error) {
+			if !created {
+				return nil, vdrapi.ErrNotFound
+			}
+
+			return resolveDoc(didID)
+		})
+		v.CreateFunc = func(doc *did.Doc, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			created = true
+
+			return &did.DocResolution{DIDDocument: doc}, nil
+		}
+
+		registry := New(WithVDR(v), WithCache())
+
+		_, err := registry.Resolve("did:example:123")
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+
+		_, err = registry.Create("example", &did.Doc{ID: "did:example:123"})
+		require.NoError(t, err)
+
+		docResolution, err := registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", docResolution.DIDDocument.ID)
+		require.Equal(t, 2, v.reads["did:example:123"])
+	})
+
+	t.Run("persists cache in storage", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		v := newCountingVDR(resolveDoc)
+		store, err := provider.OpenStore(CacheStoreName)
+		require.NoError(t, err)
+
+		registry := New(WithVDR(v), WithCache(WithCacheStore(store)))
+
+		_, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+
+		_, err = registry.Resolve("did:example:unknown")
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+
+		restarted := New(WithVDR(v), WithCache(WithCacheStore(store)))
+
+		docResolution, err := restarted.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", docResolution.DIDDocument.ID)
+		require.Equal(t, 1, v.reads["did:example:123"])
+
+		_, err = restarted.Resolve("did:example:unknown")
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		require.Equal(t, 1, v.reads["did:example:unknown"])
+
+		restarted.cache.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+		_, err = restarted.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 2, v.reads["did:example:123"])
+	})
+
+	t.Run("invalid persisted cache entry is ignored", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		store, err := provider.OpenStore(CacheStoreName)
+		require.NoError(t, err)
+		require.NoError(t, store.Put("did:example:123", []byte("{")))
+		require.NoError(t, store.Put("did:example:456", []byte(`{"did":"did:example:456","resolution":"{"}`)))
+
+		v := newCountingVDR(resolveDoc)
+		registry := New(WithVDR(v), WithCache(WithCacheStore(store)))
+
+		for _, didID := range []string{"did:example:123", "did:example:456"} {
+			_, err = registry.Resolve(didID)
+			require.NoError(t, err)
+			require.Equal(t, 1, v.reads[didID])
+		}
+	})
+}
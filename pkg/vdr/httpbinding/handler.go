@@ -0,0 +1,291 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpbinding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	// IdentifiersPath is the path of the Universal Resolver driver endpoint, the DID or DID URL follows it.
+	IdentifiersPath = "/1.0/identifiers/"
+
+	// ResolutionMediaType is the media type of DID resolution and DID URL dereferencing results.
+	ResolutionMediaType = `application/ld+json;profile="https://w3id.org/did-resolution"`
+
+	didJSON  = "application/did+json"
+	ldJSON   = "application/ld+json"
+	jsonType = "application/json"
+
+	resolutionProfile = "https://w3id.org/did-resolution"
+)
+
+// ResolverHandler serves DID resolution and DID URL dereferencing of the VDR registry in the shape of
+// the Universal Resolver driver API: GET /1.0/identifiers/{did}. The result is returned as the DID resolution
+// result (the default) or as the DID document or the dereferenced content, depending on the Accept header.
+type ResolverHandler struct {
+	registry vdrapi.Registry
+}
+
+// NewResolverHandler creates an HTTP handler resolving DIDs through the given registry.
+func NewResolverHandler(registry vdrapi.Registry) *ResolverHandler {
+	return &ResolverHandler{registry: registry}
+}
+
+// ServeHTTP resolves the DID or dereferences the DID URL following IdentifiersPath in the request path.
+// Query of the request is the query of DID URL.
+func (h *ResolverHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	escapedPath := req.URL.EscapedPath()
+
+	i := strings.Index(escapedPath, IdentifiersPath)
+	if i < 0 {
+		rw.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	identifier := unescapeIdentifier(escapedPath[i+len(IdentifiersPath):])
+	if req.URL.RawQuery != "" {
+		identifier += "?" + req.URL.RawQuery
+	}
+
+	mediaType, ok := negotiate(req.Header.Get("Accept"))
+	if !ok {
+		writeError(rw, http.StatusNotAcceptable, ErrorRepresentationNotSupported,
+			fmt.Errorf("none of the media types %s is supported", req.Header.Get("Accept")))
+
+		return
+	}
+
+	didURL, err := did.ParseDIDURL(identifier)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, ErrorInvalidDID, err)
+
+		return
+	}
+
+	result, err := vdrapi.Dereference(h.registry, identifier)
+	if err != nil {
+		status, code := errorCode(err)
+		if status == http.StatusInternalServerError {
+			logger.Errorf("resolve %s: %s", didURL.DID.String(), err)
+		}
+
+		writeError(rw, status, code, err)
+
+		return
+	}
+
+	content, err := result.Content()
+	if err != nil {
+		logger.Errorf("resolve %s: %s", didURL.DID.String(), err)
+		writeError(rw, http.StatusInternalServerError, ErrorInternal, err)
+
+		return
+	}
+
+	status := http.StatusOK
+	if result.ContentMetadata != nil && result.ContentMetadata.Deactivated {
+		status = http.StatusGone
+	}
+
+	if mediaType == ResolutionMediaType {
+		writeResult(rw, status, result, content)
+
+		return
+	}
+
+	contentType := result.DereferencingMetadata.ContentType
+
+	if contentType == did.DIDLDJSONContentType {
+		if mediaType == did.URIListContentType {
+			writeError(rw, http.StatusNotAcceptable, ErrorRepresentationNotSupported,
+				fmt.Errorf("%s is not supported for %s content", mediaType, contentType))
+
+			return
+		}
+
+		contentType = mediaType
+	}
+
+	write(rw, status, contentType, content)
+}
+
+// identifierEscapes are the escapes of the request path undone to get the DID URL, other percent-encoded
+// characters are part of the DID (e.g. the port of did:web:example.com%3A3000) and are kept as they are.
+var identifierEscapes = map[string]string{"%25": "%", "%23": "#", "%3F": "?"} //nolint:gochecknoglobals
+
+// unescapeIdentifier decodes the URL layer of the escaped DID URL taken from the request path.
+func unescapeIdentifier(escaped string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(escaped); i++ {
+		if escaped[i] == '%' && i+3 <= len(escaped) {
+			if c, ok := identifierEscapes[strings.ToUpper(escaped[i:i+3])]; ok {
+				sb.WriteString(c)
+
+				i += 2
+
+				continue
+			}
+		}
+
+		sb.WriteByte(escaped[i])
+	}
+
+	return sb.String()
+}
+
+// negotiate selects the media type of the response by the Accept header, the DID resolution result is the default.
+func negotiate(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return ResolutionMediaType, true
+	}
+
+	type mediaRange struct {
+		mediaType string
+		params    map[string]string
+		quality   float64
+	}
+
+	var ranges []mediaRange
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil || quality <= 0 {
+				continue
+			}
+		}
+
+		ranges = append(ranges, mediaRange{mediaType: mediaType, params: params, quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	for _, r := range ranges {
+		switch r.mediaType {
+		case ldJSON:
+			if strings.Contains(r.params["profile"], resolutionProfile) {
+				return ResolutionMediaType, true
+			}
+
+			return ldJSON, true
+		case "*/*", "application/*":
+			return ResolutionMediaType, true
+		case did.DIDLDJSONContentType, didJSON, jsonType, did.URIListContentType:
+			return r.mediaType, true
+		}
+	}
+
+	return "", false
+}
+
+func errorCode(err error) (int, string) {
+	switch {
+	case errors.Is(err, vdrapi.ErrNotFound), errors.Is(err, did.ErrContentNotFound):
+		return http.StatusNotFound, ErrorNotFound
+	case errors.Is(err, vdrapi.ErrMethodNotSupported), errors.Is(err, vdrapi.ErrDereferenceNotSupported):
+		return http.StatusNotImplemented, ErrorMethodNotSupported
+	case errors.Is(err, did.ErrInvalidDIDURL):
+		return http.StatusBadRequest, ErrorInvalidDIDURL
+	default:
+		return http.StatusInternalServerError, ErrorInternal
+	}
+}
+
+// writeResult writes the DID resolution result of the DID document, or the DID URL dereferencing result
+// of other content.
+func writeResult(rw http.ResponseWriter, status int, result *did.DereferenceResult, content []byte) {
+	var res interface{}
+
+	if result.DIDDocument != nil {
+		res = &ResolutionResult{
+			Context:               ResolutionContext,
+			DIDDocument:           content,
+			DIDResolutionMetadata: &ResolutionMetadata{ContentType: did.DIDLDJSONContentType},
+			DIDDocumentMetadata:   documentMetadata(result.ContentMetadata),
+		}
+	} else {
+		contentStream := json.RawMessage(content)
+
+		if result.DereferencingMetadata.ContentType == did.URIListContentType {
+			// the service endpoint URL is a JSON string in the result
+			contentStream, _ = json.Marshal(string(content)) //nolint:errcheck
+		}
+
+		res = &DereferencingResult{
+			Context:               ResolutionContext,
+			ContentStream:         contentStream,
+			DereferencingMetadata: &ResolutionMetadata{ContentType: result.DereferencingMetadata.ContentType},
+			ContentMetadata:       documentMetadata(result.ContentMetadata),
+		}
+	}
+
+	resBytes, err := json.Marshal(res)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, ErrorInternal, err)
+
+		return
+	}
+
+	write(rw, status, ResolutionMediaType, resBytes)
+}
+
+func writeError(rw http.ResponseWriter, status int, code string, err error) {
+	resBytes, e := json.Marshal(&ResolutionResult{
+		Context:               ResolutionContext,
+		DIDResolutionMetadata: &ResolutionMetadata{Error: code, ErrorMessage: err.Error()},
+		DIDDocumentMetadata:   &did.DocumentMetadata{},
+	})
+	if e != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	write(rw, status, ResolutionMediaType, resBytes)
+}
+
+func write(rw http.ResponseWriter, status int, contentType string, body []byte) {
+	rw.Header().Set("Content-Type", contentType)
+	rw.WriteHeader(status)
+
+	if _, err := rw.Write(body); err != nil {
+		logger.Errorf("write DID resolution response: %s", err)
+	}
+}
+
+func documentMetadata(md *did.DocumentMetadata) *did.DocumentMetadata {
+	if md == nil {
+		return &did.DocumentMetadata{}
+	}
+
+	return md
+}
@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpbinding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+func TestResolverHandler(t *testing.T) {
+	didDoc, err := did.ParseDocument([]byte(doc))
+	require.NoError(t, err)
+
+	didDoc.VerificationMethod[0].ID = didDoc.ID + "#keys-1"
+	didDoc.Service = []did.Service{
+		{ID: didDoc.ID + "#hub", Type: "IdentityHub", ServiceEndpoint: "https://hub.example.com"},
+	}
+
+	registry := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			switch didID {
+			case didDoc.ID:
+				return &did.DocResolution{
+					DIDDocument:      didDoc,
+					DocumentMetadata: &did.DocumentMetadata{VersionID: "1"},
+				}, nil
+			case "did:peer:deactivated":
+				return &did.DocResolution{
+					DIDDocument:      &did.Doc{Context: []string{did.ContextV1}, ID: didID},
+					DocumentMetadata: &did.DocumentMetadata{Deactivated: true},
+				}, nil
+			case "did:unknown:123":
+				return nil, fmt.Errorf("did method unknown not supported for vdr: %w", vdrapi.ErrMethodNotSupported)
+			case "did:peer:fails":
+				return nil, errors.New("resolver error")
+			case "did:web:example.com%3A3000":
+				return &did.DocResolution{DIDDocument: &did.Doc{Context: []string{did.ContextV1}, ID: didID}}, nil
+			default:
+				return nil, vdrapi.ErrNotFound
+			}
+		},
+	}
+
+	handler := NewResolverHandler(registry)
+
+	serve := func(method, path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+
+		return rw
+	}
+
+	resolutionResult := func(t *testing.T, rw *httptest.ResponseRecorder) *ResolutionResult {
+		t.Helper()
+
+		require.Equal(t, ResolutionMediaType, rw.Header().Get("Content-Type"))
+
+		res := &ResolutionResult{}
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), res))
+		require.Equal(t, ResolutionContext, res.Context)
+
+		return res
+	}
+
+	t.Run("test resolution result", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", ResolutionMediaType, "text/html, " + ResolutionMediaType} {
+			rw := serve(http.MethodGet, IdentifiersPath+didDoc.ID, accept)
+			require.Equal(t, http.StatusOK, rw.Code, accept)
+
+			res := resolutionResult(t, rw)
+			require.Equal(t, did.DIDLDJSONContentType, res.DIDResolutionMetadata.ContentType)
+			require.Equal(t, "1", res.DIDDocumentMetadata.VersionID)
+
+			resolved, err := did.ParseDocument(res.DIDDocument)
+			require.NoError(t, err)
+			require.Equal(t, didDoc.ID, resolved.ID)
+		}
+	})
+
+	t.Run("test DID document", func(t *testing.T) {
+		for _, accept := range []string{did.DIDLDJSONContentType, didJSON, "application/json;q=0.5, text/html;q=0.9"} {
+			rw := serve(http.MethodGet, "/agent"+IdentifiersPath+didDoc.ID, accept)
+			require.Equal(t, http.StatusOK, rw.Code)
+
+			resolved, err := did.ParseDocument(rw.Body.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, didDoc.ID, resolved.ID)
+		}
+
+		rw := serve(http.MethodGet, IdentifiersPath+didDoc.ID, "application/json;q=0.5, "+didJSON)
+		require.Equal(t, didJSON, rw.Header().Get("Content-Type"))
+	})
+
+	t.Run("test dereference DID URL", func(t *testing.T) {
+		rw := serve(http.MethodGet, IdentifiersPath+didDoc.ID+"%23keys-1", did.DIDLDJSONContentType)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Contains(t, rw.Body.String(), `"publicKeyBase58":"H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"`)
+
+		rw = serve(http.MethodGet, IdentifiersPath+didDoc.ID+"?service=hub&relativeRef=%2Fpath", "")
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		res := &DereferencingResult{}
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), res))
+		require.Equal(t, did.URIListContentType, res.DereferencingMetadata.ContentType)
+		require.Equal(t, `"https://hub.example.com/path"`, string(res.ContentStream))
+
+		rw = serve(http.MethodGet, IdentifiersPath+didDoc.ID+"?service=hub", did.URIListContentType)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, did.URIListContentType, rw.Header().Get("Content-Type"))
+		require.Equal(t, "https://hub.example.com", rw.Body.String())
+
+		rw = serve(http.MethodGet, IdentifiersPath+didDoc.ID+"%23hub", ResolutionMediaType)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), res))
+		require.Contains(t, string(res.ContentStream), `"serviceEndpoint":"https://hub.example.com"`)
+	})
+
+	t.Run("test percent-encoded DID", func(t *testing.T) {
+		for _, path := range []string{"did:web:example.com%3A3000", "did:web:example.com%253A3000"} {
+			rw := serve(http.MethodGet, IdentifiersPath+path, did.DIDLDJSONContentType)
+			require.Equal(t, http.StatusOK, rw.Code, path)
+			require.Contains(t, rw.Body.String(), `"id":"did:web:example.com%3A3000"`, path)
+		}
+
+		// the encoded colon is not the same DID as the colon
+		require.Equal(t, http.StatusNotFound, serve(http.MethodGet, IdentifiersPath+"did:web:example.com:3000", "").Code)
+	})
+
+	t.Run("test deactivated DID", func(t *testing.T) {
+		rw := serve(http.MethodGet, IdentifiersPath+"did:peer:deactivated", "")
+		require.Equal(t, http.StatusGone, rw.Code)
+		require.True(t, resolutionResult(t, rw).DIDDocumentMetadata.Deactivated)
+	})
+
+	t.Run("test errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			path, accept string
+			status       int
+			code         string
+		}{
+			{IdentifiersPath + "did:peer:123", "", http.StatusNotFound, ErrorNotFound},
+			{IdentifiersPath + didDoc.ID + "%23keys-3", "", http.StatusNotFound, ErrorNotFound},
+			{IdentifiersPath + "did:unknown:123", "", http.StatusNotImplemented, ErrorMethodNotSupported},
+			{IdentifiersPath + "invalid", "", http.StatusBadRequest, ErrorInvalidDID},
+			{IdentifiersPath + didDoc.ID + "?service=hub&relativeRef=https%3A%2F%2Fevil.example.com", "",
+				http.StatusBadRequest, ErrorInvalidDIDURL},
+			{IdentifiersPath + "did:peer:fails", "", http.StatusInternalServerError, ErrorInternal},
+			{IdentifiersPath + didDoc.ID, "text/html", http.StatusNotAcceptable, ErrorRepresentationNotSupported},
+			{IdentifiersPath + didDoc.ID, did.URIListContentType, http.StatusNotAcceptable,
+				ErrorRepresentationNotSupported},
+		} {
+			rw := serve(http.MethodGet, tc.path, tc.accept)
+			require.Equal(t, tc.status, rw.Code, tc.path)
+
+			res := resolutionResult(t, rw)
+			require.Equal(t, tc.code, res.DIDResolutionMetadata.Error, tc.path)
+			require.NotEmpty(t, res.DIDResolutionMetadata.ErrorMessage)
+			require.Equal(t, "null", string(res.DIDDocument))
+		}
+
+		require.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPost, IdentifiersPath+didDoc.ID, "").Code)
+		require.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/1.0/other/"+didDoc.ID, "").Code)
+	})
+
+	t.Run("test resolve through http binding VDR", func(t *testing.T) {
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		v, err := New(server.URL + "/1.0/identifiers")
+		require.NoError(t, err)
+
+		docResolution, err := v.Read(didDoc.ID)
+		require.NoError(t, err)
+		require.Equal(t, didDoc.ID, docResolution.DIDDocument.ID)
+
+		docResolution, err = v.Read("did:web:example.com%3A3000")
+		require.NoError(t, err)
+		require.Equal(t, "did:web:example.com%3A3000", docResolution.DIDDocument.ID)
+
+		_, err = v.Read("did:peer:123")
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpbinding
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// Error codes of DID resolution and DID URL dereferencing metadata
+// (https://w3c-ccg.github.io/did-resolution/#errors).
+const (
+	ErrorInvalidDID                 = "invalidDid"
+	ErrorInvalidDIDURL              = "invalidDidUrl"
+	ErrorNotFound                   = "notFound"
+	ErrorMethodNotSupported         = "methodNotSupported"
+	ErrorRepresentationNotSupported = "representationNotSupported"
+	ErrorInternal                   = "internalError"
+)
+
+// ResolutionContext is the JSON-LD context of DID resolution and DID URL dereferencing results.
+const ResolutionContext = "https://w3id.org/did-resolution/v1"
+
+// ResolutionResult is the DID resolution result (https://w3c-ccg.github.io/did-resolution/#did-resolution-result).
+type ResolutionResult struct {
+	Context               string                `json:"@context"`
+	DIDDocument           json.RawMessage       `json:"didDocument"`
+	DIDResolutionMetadata *ResolutionMetadata   `json:"didResolutionMetadata"`
+	DIDDocumentMetadata   *did.DocumentMetadata `json:"didDocumentMetadata"`
+}
+
+// DereferencingResult is the DID URL dereferencing result
+// (https://w3c-ccg.github.io/did-resolution/#did-url-dereferencing-result).
+type DereferencingResult struct {
+	Context               string                `json:"@context"`
+	ContentStream         json.RawMessage       `json:"contentStream"`
+	DereferencingMetadata *ResolutionMetadata   `json:"dereferencingMetadata"`
+	ContentMetadata       *did.DocumentMetadata `json:"contentMetadata"`
+}
+
+// ResolutionMetadata is metadata about the DID resolution or the DID URL dereferencing process.
+type ResolutionMetadata struct {
+	ContentType  string `json:"contentType,omitempty"`
+	Error        string `json:"error,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	schemaResV1                = "https://w3id.org/did-resolution/v1"
+	jws2020Context             = "https://w3id.org/security/suites/jws-2020/v1"
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	jsonWebKey2020             = "JsonWebKey2020"
+	x25519Curve                = "X25519"
+	vmFragment                 = "#0"
+)
+
+// Create new DID document for didDoc.
+// didDoc must contain a VerificationMethod of JsonWebKey2020 or Ed25519VerificationKey2018 type, the DID is built
+// from the public JWK of its first verification method.
+func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if didDoc == nil || len(didDoc.VerificationMethod) == 0 {
+		return nil, fmt.Errorf("verification method is empty")
+	}
+
+	var (
+		key *jwk.JWK
+		err error
+	)
+
+	switch didDoc.VerificationMethod[0].Type {
+	case jsonWebKey2020:
+		key = didDoc.VerificationMethod[0].JSONWebKey()
+		if key == nil {
+			return nil, fmt.Errorf("verification method has no JWK")
+		}
+	case ed25519VerificationKey2018:
+		key, err = jwksupport.PubKeyBytesToJWK(didDoc.VerificationMethod[0].Value, kms.ED25519Type)
+		if err != nil {
+			return nil, fmt.Errorf("convert public key to JWK: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("not supported public key type: %s", didDoc.VerificationMethod[0].Type)
+	}
+
+	keyBytes, err := key.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshal JWK: %w", err)
+	}
+
+	didJWK := fmt.Sprintf("did:%s:%s", DIDMethod, base64.RawURLEncoding.EncodeToString(keyBytes))
+
+	doc, err := createDoc(didJWK, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+// createDoc creates DID document of did:jwk, X25519 keys are only used for key agreement.
+func createDoc(didJWK string, key *jwk.JWK) (*did.Doc, error) {
+	vm, err := did.NewVerificationMethodFromJWK(didJWK+vmFragment, jsonWebKey2020, didJWK, key)
+	if err != nil {
+		return nil, fmt.Errorf("create verification method: %w", err)
+	}
+
+	doc := &did.Doc{
+		Context:            []string{did.ContextV1, jws2020Context},
+		ID:                 didJWK,
+		VerificationMethod: []did.VerificationMethod{*vm},
+	}
+
+	if key.Crv == x25519Curve {
+		doc.KeyAgreement = []did.Verification{*did.NewReferencedVerification(vm, did.KeyAgreement)}
+
+		return doc, nil
+	}
+
+	doc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+	doc.AssertionMethod = []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)}
+	doc.CapabilityDelegation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityDelegation)}
+	doc.CapabilityInvocation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityInvocation)}
+
+	return doc, nil
+}
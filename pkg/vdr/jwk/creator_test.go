@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+)
+
+func TestCreate(t *testing.T) {
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	v := New()
+
+	t.Run("create from Ed25519VerificationKey2018", func(t *testing.T) {
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2018, "", pubKey),
+		}})
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:jwk:"))
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, doc.ID+"#0", doc.VerificationMethod[0].ID)
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+		require.Equal(t, []byte(pubKey), doc.VerificationMethod[0].Value)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Empty(t, doc.KeyAgreement)
+
+		// DID document is expanded from DID.
+		resolved, err := v.Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc.VerificationMethod[0].Value, resolved.DIDDocument.VerificationMethod[0].Value)
+	})
+
+	t.Run("create from X25519 JsonWebKey2020", func(t *testing.T) {
+		key, err := jwksupport.JWKFromX25519Key(make([]byte, 32))
+		require.NoError(t, err)
+
+		vm, err := did.NewVerificationMethodFromJWK("#key-1", jsonWebKey2020, "", key)
+		require.NoError(t, err)
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+		require.Len(t, docResolution.DIDDocument.KeyAgreement, 1)
+		require.Empty(t, docResolution.DIDDocument.Authentication)
+	})
+
+	t.Run("create from P-256 JsonWebKey2020", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		key, err := jwksupport.JWKFromKey(&privKey.PublicKey)
+		require.NoError(t, err)
+
+		vm, err := did.NewVerificationMethodFromJWK("#key-1", jsonWebKey2020, "", key)
+		require.NoError(t, err)
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+		require.Len(t, docResolution.DIDDocument.AssertionMethod, 1)
+		require.Equal(t, "P-256", docResolution.DIDDocument.VerificationMethod[0].JSONWebKey().Crv)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{})
+		require.EqualError(t, err, "verification method is empty")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key-1", "Bls12381G2Key2020", "", pubKey),
+		}})
+		require.EqualError(t, err, "not supported public key type: Bls12381G2Key2020")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key-1", jsonWebKey2020, "", pubKey),
+		}})
+		require.EqualError(t, err, "verification method has no JWK")
+	})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// Read expands did:jwk value to a DID document.
+func (v *VDR) Read(didJWK string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	parsed, err := did.Parse(didJWK)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: failed to parse DID: %w", err)
+	}
+
+	if parsed.Method != DIDMethod {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method: %s", parsed.Method)
+	}
+
+	keyBytes, err := base64.RawURLEncoding.DecodeString(parsed.MethodSpecificID)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method ID: %w", err)
+	}
+
+	key := &jwk.JWK{}
+
+	err = key.UnmarshalJSON(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: invalid JWK: %w", err)
+	}
+
+	if !key.IsPublic() {
+		return nil, fmt.Errorf("jwk vdr Read: JWK must be a public key")
+	}
+
+	doc, err := createDoc(didJWK, key)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: %w", err)
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	v := New()
+
+	t.Run("P-256 key", func(t *testing.T) {
+		// did:jwk example of https://github.com/quartzjer/did-jwk/blob/main/spec.md.
+		const didJWK = "did:jwk:eyJjcnYiOiJQLTI1NiIsImt0eSI6IkVDIiwieCI6ImFjYklRaXVNczNpOF91c3pFakoydHBUdFJNNEVVM3l" +
+			"6OTFQSDZDZEgyVjAiLCJ5IjoiX0tjeUxqOXZXTXB0bm1LdG00NkdxRHo4d2Y3NEk1TEtncmwyR3pIM25TRSJ9"
+
+		docResolution, err := v.Read(didJWK)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, didJWK, doc.ID)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, didJWK+"#0", doc.VerificationMethod[0].ID)
+		require.Equal(t, "P-256", doc.VerificationMethod[0].JSONWebKey().Crv)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.CapabilityInvocation, 1)
+		require.Len(t, doc.CapabilityDelegation, 1)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := v.Read("invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse DID")
+
+		_, err = v.Read("did:key:z6MknC1wwS6DEYwtGbZZo2QvjQjkh2qSBjb4GYmbye8dv4S5")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid did:jwk method")
+
+		_, err = v.Read("did:jwk:invalid.base64")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid did:jwk method ID")
+
+		_, err = v.Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(`{"kty":"invalid"}`)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid JWK")
+
+		private := `{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",` +
+			`"d":"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A"}`
+
+		_, err = v.Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(private)))
+		require.EqualError(t, err, "jwk vdr Read: JWK must be a public key")
+	})
+}
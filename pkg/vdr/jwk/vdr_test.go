@@ -0,0 +1,35 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+var _ vdr.VDR = (*VDR)(nil) // verify interface compliance
+
+func TestAccept(t *testing.T) {
+	v := New()
+	require.True(t, v.Accept("jwk"))
+	require.False(t, v.Accept("other"))
+}
+
+func TestUpdateDeactivateClose(t *testing.T) {
+	v := New()
+
+	err := v.Update(nil)
+	require.EqualError(t, err, "not supported")
+
+	err = v.Deactivate("")
+	require.EqualError(t, err, "not supported")
+
+	require.NoError(t, v.Close())
+}
@@ -20,6 +20,8 @@ const (
 	schemaResV1                = "https://w3id.org/did-resolution/v1"
 	schemaDIDV1                = "https://w3id.org/did/v1"
 	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	ed25519VerificationKey2020 = did.Ed25519VerificationKey2020
+	ed25519Suite2020Context    = "https://w3id.org/security/suites/ed25519-2020/v1"
 	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
 	bls12381G2Key2020          = "Bls12381G2Key2020"
 	jsonWebKey2020             = "JsonWebKey2020"
@@ -65,7 +67,7 @@ func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocR
 	publicKey = did.NewVerificationMethodFromBytes(keyID, didDoc.VerificationMethod[0].Type, didKey,
 		didDoc.VerificationMethod[0].Value)
 
-	if didDoc.VerificationMethod[0].Type == ed25519VerificationKey2018 {
+	if isEd25519KeyType(didDoc.VerificationMethod[0].Type) {
 		keyAgr, err = keyAgreementFromEd25519(didKey, didDoc.VerificationMethod[0].Value)
 		if err != nil {
 			return nil, err
@@ -90,7 +92,7 @@ func getKeyCode(verificationMethod *did.VerificationMethod) (uint64, error) {
 	var keyCode uint64
 
 	switch verificationMethod.Type {
-	case ed25519VerificationKey2018:
+	case ed25519VerificationKey2018, ed25519VerificationKey2020:
 		keyCode = fingerprint.ED25519PubKeyMultiCodec
 	case bls12381G2Key2020:
 		keyCode = fingerprint.BLS12381g2PubKeyMultiCodec
@@ -101,10 +103,21 @@ func getKeyCode(verificationMethod *did.VerificationMethod) (uint64, error) {
 	return keyCode, nil
 }
 
+func isEd25519KeyType(keyType string) bool {
+	return keyType == ed25519VerificationKey2018 || keyType == ed25519VerificationKey2020
+}
+
 func createDoc(pubKey, keyAgreement *did.VerificationMethod, didKey string) *did.Doc {
 	// Created/Updated time
 	t := time.Now()
 
+	context := []string{schemaDIDV1}
+
+	// Ed25519VerificationKey2020 terms are not defined by the DID context.
+	if pubKey.Type == ed25519VerificationKey2020 {
+		context = append(context, ed25519Suite2020Context)
+	}
+
 	kaVerification := make([]did.Verification, 0)
 
 	if keyAgreement != nil {
@@ -112,7 +125,7 @@ func createDoc(pubKey, keyAgreement *did.VerificationMethod, didKey string) *did
 	}
 
 	return &did.Doc{
-		Context:              []string{schemaDIDV1},
+		Context:              context,
 		ID:                   didKey,
 		VerificationMethod:   []did.VerificationMethod{*pubKey},
 		Authentication:       []did.Verification{*did.NewReferencedVerification(pubKey, did.Authentication)},
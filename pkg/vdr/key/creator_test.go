@@ -61,6 +61,21 @@ func TestBuild(t *testing.T) {
 		assertEd25519Doc(t, docResolution.DIDDocument)
 	})
 
+	t.Run("build with Ed25519VerificationKey2020 key type", func(t *testing.T) {
+		v := New()
+
+		pubKey := did.VerificationMethod{
+			Type:  ed25519VerificationKey2020,
+			Value: base58.Decode(pubKeyBase58Ed25519),
+		}
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}})
+		require.NoError(t, err)
+		require.NotNil(t, docResolution.DIDDocument)
+
+		assertEd25519VerificationKey2020Doc(t, docResolution.DIDDocument)
+	})
+
 	t.Run("build with BLS12381G2 key type", func(t *testing.T) {
 		v := New()
 
@@ -204,6 +219,34 @@ func assertEd25519Doc(t *testing.T, doc *did.Doc) {
 		agreementKeyID, x25519KeyAgreementKey2019, keyAgreementBase58)
 }
 
+func assertEd25519VerificationKey2020Doc(t *testing.T, doc *did.Doc) {
+	t.Helper()
+
+	const (
+		didKey   = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+		didKeyID = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH#z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH" //nolint:lll
+
+		pubKeyBase58 = "B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u"
+	)
+
+	require.Equal(t, []string{schemaDIDV1, ed25519Suite2020Context}, doc.Context)
+	require.Equal(t, didKey, doc.ID)
+	require.Len(t, doc.VerificationMethod, 1)
+	require.Equal(t, didKeyID, doc.VerificationMethod[0].ID)
+	require.Equal(t, ed25519VerificationKey2020, doc.VerificationMethod[0].Type)
+	require.Equal(t, base58.Decode(pubKeyBase58), doc.VerificationMethod[0].Value)
+	require.Len(t, doc.KeyAgreement, 1)
+	require.Equal(t, x25519KeyAgreementKey2019, doc.KeyAgreement[0].VerificationMethod.Type)
+
+	docBytes, err := doc.JSONBytes()
+	require.NoError(t, err)
+	require.Contains(t, string(docBytes), `"publicKeyMultibase":"z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"`)
+
+	parsed, err := did.ParseDocument(docBytes)
+	require.NoError(t, err)
+	require.Equal(t, doc.VerificationMethod[0].Value, parsed.VerificationMethod[0].Value)
+}
+
 func assertBBSDoc(t *testing.T, doc *did.Doc) {
 	// did key from  https://w3c-ccg.github.io/did-method-key/#example-6
 	const (
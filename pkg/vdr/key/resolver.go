@@ -19,7 +19,19 @@ import (
 )
 
 // Read expands did:key value to a DID document.
-func (v *VDR) Read(didKey string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+// Ed25519 keys are resolved as Ed25519VerificationKey2018 unless VerificationMethodType option says otherwise.
+func (v *VDR) Read(didKey string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	readDIDOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+	// Apply options
+	for _, opt := range opts {
+		opt(readDIDOpts)
+	}
+
+	ed25519KeyType, err := getEd25519KeyType(readDIDOpts)
+	if err != nil {
+		return nil, fmt.Errorf("vdr Read: %w", err)
+	}
+
 	parsed, err := did.Parse(didKey)
 	if err != nil {
 		return nil, fmt.Errorf("pub:key vdr Read: failed to parse DID document: %w", err)
@@ -38,7 +50,7 @@ func (v *VDR) Read(didKey string, _ ...vdrapi.DIDMethodOption) (*did.DocResoluti
 		return nil, fmt.Errorf("pub:key vdr Read: failed to get key fingerPrint: %w", err)
 	}
 
-	didDoc, err := createDIDDocFromPubKey(parsed.MethodSpecificID, code, pubKeyBytes)
+	didDoc, err := createDIDDocFromPubKey(parsed.MethodSpecificID, code, pubKeyBytes, ed25519KeyType)
 	if err != nil {
 		return nil, fmt.Errorf("creating did document from public key failed: %w", err)
 	}
@@ -46,10 +58,24 @@ func (v *VDR) Read(didKey string, _ ...vdrapi.DIDMethodOption) (*did.DocResoluti
 	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: didDoc}, nil
 }
 
-func createDIDDocFromPubKey(kid string, code uint64, pubKeyBytes []byte) (*did.Doc, error) {
+func getEd25519KeyType(opts *vdrapi.DIDMethodOpts) (string, error) {
+	vmType, ok := opts.Values[VerificationMethodType]
+	if !ok {
+		return ed25519VerificationKey2018, nil
+	}
+
+	keyType, ok := vmType.(string)
+	if !ok || !isEd25519KeyType(keyType) {
+		return "", fmt.Errorf("unsupported verification method type: %v", vmType)
+	}
+
+	return keyType, nil
+}
+
+func createDIDDocFromPubKey(kid string, code uint64, pubKeyBytes []byte, ed25519KeyType string) (*did.Doc, error) {
 	switch code {
 	case fingerprint.ED25519PubKeyMultiCodec:
-		return createEd25519DIDDoc(kid, pubKeyBytes)
+		return createEd25519DIDDoc(kid, ed25519KeyType, pubKeyBytes)
 	case fingerprint.BLS12381g2PubKeyMultiCodec, fingerprint.BLS12381g1g2PubKeyMultiCodec:
 		return createBase58DIDDoc(kid, bls12381G2Key2020, pubKeyBytes)
 	case fingerprint.P256PubKeyMultiCodec, fingerprint.P384PubKeyMultiCodec, fingerprint.P521PubKeyMultiCodec:
@@ -114,7 +140,7 @@ func createJSONWebKey2020DIDDoc(kid string, code uint64, pubKeyBytes []byte) (*d
 	return didDoc, nil
 }
 
-func createEd25519DIDDoc(kid string, pubKeyBytes []byte) (*did.Doc, error) {
+func createEd25519DIDDoc(kid, keyType string, pubKeyBytes []byte) (*did.Doc, error) {
 	didKey := fmt.Sprintf("did:key:%s", kid)
 
 	// did:key can't add non converted encryption key as keyAgreement (unless it's added as an option just like creator,
@@ -127,7 +153,7 @@ func createEd25519DIDDoc(kid string, pubKeyBytes []byte) (*did.Doc, error) {
 	}
 
 	keyID := fmt.Sprintf("%s#%s", didKey, kid)
-	publicKey := did.NewVerificationMethodFromBytes(keyID, ed25519VerificationKey2018, didKey, pubKeyBytes)
+	publicKey := did.NewVerificationMethodFromBytes(keyID, keyType, didKey, pubKeyBytes)
 
 	didDoc := createDoc(publicKey, keyAgr, didKey)
 
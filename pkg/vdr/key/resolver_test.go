@@ -14,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
@@ -70,6 +71,24 @@ func TestReadEd25519(t *testing.T) {
 
 		assertEd25519Doc(t, docResolution.DIDDocument)
 	})
+
+	t.Run("resolve as Ed25519VerificationKey2020", func(t *testing.T) {
+		v := New()
+
+		docResolution, err := v.Read(didEd25519,
+			vdrapi.WithOption(VerificationMethodType, "Ed25519VerificationKey2020"))
+		require.NoError(t, err)
+		require.NotNil(t, docResolution.DIDDocument)
+
+		assertEd25519VerificationKey2020Doc(t, docResolution.DIDDocument)
+	})
+
+	t.Run("resolve with unsupported verification method type", func(t *testing.T) {
+		v := New()
+
+		_, err := v.Read(didEd25519, vdrapi.WithOption(VerificationMethodType, "JsonWebKey2020"))
+		require.EqualError(t, err, "vdr Read: unsupported verification method type: JsonWebKey2020")
+	})
 }
 
 func TestReadBBS(t *testing.T) {
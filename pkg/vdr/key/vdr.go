@@ -20,6 +20,9 @@ const (
 	EncryptionKey = "encryptionKey"
 	// KeyType option to create a new kms key for DIDDocs with empty VerificationMethod.
 	KeyType = "keyType"
+	// VerificationMethodType option to resolve Ed25519 keys as verification methods of the given type,
+	// either Ed25519VerificationKey2018 (default) or Ed25519VerificationKey2020.
+	VerificationMethodType = "verificationMethodType"
 )
 
 // VDR implements did:key method support.
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
@@ -24,6 +25,7 @@ type Registry struct {
 	vdr                []vdrapi.VDR
 	defServiceEndpoint string
 	defServiceType     string
+	cache              *cache
 }
 
 // New return new instance of vdr.
@@ -51,6 +53,23 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 		return nil, err
 	}
 
+	// resolutions with options are not cached as options may change the resolution result
+	if r.cache == nil || len(opts) > 0 {
+		return r.read(method, did, opts...)
+	}
+
+	if didDocResolution, found, cacheErr := r.cache.get(did); found {
+		return didDocResolution, cacheErr
+	}
+
+	didDocResolution, err := r.read(method, did)
+	r.cache.put(didMethod, did, didDocResolution, err)
+
+	return didDocResolution, err
+}
+
+func (r *Registry) read(method vdrapi.VDR, did string,
+	opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
 	// Obtain the DID Document
 	didDocResolution, err := method.Read(did, opts...)
 	if err != nil {
@@ -64,6 +83,107 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 	return didDocResolution, nil
 }
 
+// Dereference DID URL (https://w3c-ccg.github.io/did-resolution/#dereferencing).
+// DID document is resolved with the version requested by versionId or versionTime query of DID URL,
+// then the verification method or service selected by fragment or the service endpoint URL selected
+// by service query is returned.
+// Versioned DID URL fails with did.ErrContentNotFound unless metadata of the resolved DID document
+// shows it is the requested version.
+func (r *Registry) Dereference(didURL string, opts ...vdrapi.DIDMethodOption) (*diddoc.DereferenceResult, error) {
+	parsed, err := diddoc.ParseDIDURL(didURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse DID URL: %w", err)
+	}
+
+	versionOpts, versionTime, err := versionOptions(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	docResolution, err := r.Resolve(parsed.DID.String(), append(opts, versionOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if versionID := parsed.Queries[diddoc.VersionIDQuery]; len(versionID) > 0 {
+		err = checkVersionID(docResolution.DocumentMetadata, versionID[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if versionTime != nil {
+		err = checkVersionTime(docResolution.DocumentMetadata, *versionTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return docResolution.Dereference(parsed)
+}
+
+func versionOptions(didURL *diddoc.DIDURL) ([]vdrapi.DIDMethodOption, *time.Time, error) {
+	var (
+		opts        []vdrapi.DIDMethodOption
+		versionTime *time.Time
+	)
+
+	if versionID := didURL.Queries[diddoc.VersionIDQuery]; len(versionID) > 0 {
+		opts = append(opts, vdrapi.WithOption(vdrapi.VersionIDOpt, versionID[0]))
+	}
+
+	if versionTimeQuery := didURL.Queries[diddoc.VersionTimeQuery]; len(versionTimeQuery) > 0 {
+		t, err := time.Parse(time.RFC3339, versionTimeQuery[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse versionTime: %w", diddoc.ErrInvalidDIDURL)
+		}
+
+		opts = append(opts, vdrapi.WithOption(vdrapi.VersionTimeOpt, t))
+		versionTime = &t
+	}
+
+	return opts, versionTime, nil
+}
+
+// checkVersionID fails if the did method resolved no version or another version than requested.
+func checkVersionID(metadata *diddoc.DocumentMetadata, versionID string) error {
+	if metadata == nil || metadata.VersionID == "" {
+		return fmt.Errorf("did method resolved no version %s: %w", versionID, diddoc.ErrContentNotFound)
+	}
+
+	if metadata.VersionID != versionID {
+		return fmt.Errorf("did method resolved version %s instead of %s: %w",
+			metadata.VersionID, versionID, diddoc.ErrContentNotFound)
+	}
+
+	return nil
+}
+
+// checkVersionTime fails unless the resolved DID document was updated (or created) not after the requested time
+// and was not updated again until then.
+func checkVersionTime(metadata *diddoc.DocumentMetadata, versionTime time.Time) error {
+	var since *time.Time
+
+	if metadata != nil {
+		since = metadata.Updated
+		if since == nil {
+			since = metadata.Created
+		}
+	}
+
+	if since == nil {
+		return fmt.Errorf("did method resolved no version valid at %s: %w",
+			versionTime.Format(time.RFC3339), diddoc.ErrContentNotFound)
+	}
+
+	if since.After(versionTime) || (metadata.NextUpdate != nil && !metadata.NextUpdate.After(versionTime)) {
+		return fmt.Errorf("did method resolved version %s which is not valid at %s: %w", since.Format(time.RFC3339),
+			versionTime.Format(time.RFC3339), diddoc.ErrContentNotFound)
+	}
+
+	return nil
+}
+
 // Update did document.
 func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
 	didMethod, err := GetDidMethod(didDoc.ID)
@@ -77,7 +197,13 @@ func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) er
 		return err
 	}
 
-	return method.Update(didDoc, opts...)
+	if err = method.Update(didDoc, opts...); err != nil {
+		return err
+	}
+
+	r.InvalidateCache(didDoc.ID)
+
+	return nil
 }
 
 // Deactivate did document.
@@ -93,7 +219,21 @@ func (r *Registry) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error
 		return err
 	}
 
-	return method.Deactivate(did, opts...)
+	if err = method.Deactivate(did, opts...); err != nil {
+		return err
+	}
+
+	r.InvalidateCache(did)
+
+	return nil
+}
+
+// InvalidateCache removes the cached DID document of the DID, so the next resolution reads it from the DID method.
+// DID documents created, updated or deactivated through the registry are invalidated automatically.
+func (r *Registry) InvalidateCache(did string) {
+	if r.cache != nil {
+		r.cache.invalidate(did)
+	}
 }
 
 // Create a new DID Document and store it in this registry.
@@ -115,6 +255,10 @@ func (r *Registry) Create(didMethod string, did *diddoc.Doc,
 		return nil, err
 	}
 
+	if didDocResolution != nil && didDocResolution.DIDDocument != nil {
+		r.InvalidateCache(didDocResolution.DIDDocument.ID)
+	}
+
 	return didDocResolution, nil
 }
 
@@ -150,7 +294,7 @@ func (r *Registry) resolveVDR(method string) (vdrapi.VDR, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("did method %s not supported for vdr", method)
+	return nil, fmt.Errorf("did method %s not supported for vdr: %w", method, vdrapi.ErrMethodNotSupported)
 }
 
 // WithVDR adds did method implementation for store.
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package vdr
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -110,6 +112,112 @@ func TestRegistry_Resolve(t *testing.T) {
 	})
 }
 
+func TestRegistry_Dereference(t *testing.T) {
+	const didID = "did:example:123"
+
+	var readOpts *vdrapi.DIDMethodOpts
+
+	updated := time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)
+	nextUpdate := time.Date(2021, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	registry := New(WithVDR(&mockvdr.MockVDR{
+		AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			readOpts = &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+			// Apply options
+			for _, opt := range opts {
+				opt(readOpts)
+			}
+
+			return &did.DocResolution{
+				DIDDocument: &did.Doc{
+					ID:                 didID,
+					VerificationMethod: []did.VerificationMethod{{ID: didID + "#key-1", Value: []byte("key")}},
+					Service:            []did.Service{{ID: didID + "#agent", ServiceEndpoint: "https://example.com/"}},
+				},
+				DocumentMetadata: &did.DocumentMetadata{VersionID: "2", Updated: &updated, NextUpdate: &nextUpdate},
+			}, nil
+		},
+	}))
+
+	t.Run("verification method", func(t *testing.T) {
+		result, err := registry.Dereference(didID+"#key-1", vdrapi.WithOption("k1", "v1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("key"), result.VerificationMethod.Value)
+		require.Equal(t, "v1", readOpts.Values["k1"])
+		require.Equal(t, did.DIDLDJSONContentType, result.DereferencingMetadata.ContentType)
+	})
+
+	t.Run("service endpoint", func(t *testing.T) {
+		result, err := registry.Dereference(didID + "?service=agent&relativeRef=%2Fmessages")
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/messages", result.ServiceEndpoint)
+	})
+
+	t.Run("versioned DID document", func(t *testing.T) {
+		result, err := registry.Dereference(didID + "?versionId=2")
+		require.NoError(t, err)
+		require.Equal(t, didID, result.DIDDocument.ID)
+		require.Equal(t, "2", result.ContentMetadata.VersionID)
+		require.Equal(t, "2", readOpts.Values[vdrapi.VersionIDOpt])
+
+		result, err = registry.Dereference(didID + "?versionTime=2021-05-10T17:00:00Z#key-1")
+		require.NoError(t, err)
+		require.NotNil(t, result.VerificationMethod)
+		require.Equal(t, time.Date(2021, 5, 10, 17, 0, 0, 0, time.UTC), readOpts.Values[vdrapi.VersionTimeOpt])
+	})
+
+	t.Run("did method resolved another version", func(t *testing.T) {
+		_, err := registry.Dereference(didID + "?versionId=1")
+		require.EqualError(t, err, "did method resolved version 2 instead of 1: DID URL content not found")
+		require.True(t, errors.Is(err, did.ErrContentNotFound))
+
+		_, err = registry.Dereference(didID + "?versionTime=2021-05-01T00:00:00Z")
+		require.True(t, errors.Is(err, did.ErrContentNotFound))
+		require.Contains(t, err.Error(), "which is not valid at 2021-05-01T00:00:00Z")
+
+		_, err = registry.Dereference(didID + "?versionTime=2021-07-01T00:00:00Z")
+		require.True(t, errors.Is(err, did.ErrContentNotFound))
+	})
+
+	t.Run("did method resolved no version metadata", func(t *testing.T) {
+		unversioned := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+			},
+		}))
+
+		_, err := unversioned.Dereference(didID + "?versionId=1")
+		require.EqualError(t, err, "did method resolved no version 1: DID URL content not found")
+
+		_, err = unversioned.Dereference(didID + "?versionTime=2021-05-10T17:00:00Z")
+		require.EqualError(t, err,
+			"did method resolved no version valid at 2021-05-10T17:00:00Z: DID URL content not found")
+	})
+
+	t.Run("invalid versionTime", func(t *testing.T) {
+		_, err := registry.Dereference(didID + "?versionTime=yesterday")
+		require.True(t, errors.Is(err, did.ErrInvalidDIDURL))
+	})
+
+	t.Run("invalid DID URL", func(t *testing.T) {
+		_, err := registry.Dereference("not a did")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse DID URL")
+	})
+
+	t.Run("content not found", func(t *testing.T) {
+		_, err := registry.Dereference(didID + "#key-2")
+		require.True(t, errors.Is(err, did.ErrContentNotFound))
+	})
+
+	t.Run("resolve error", func(t *testing.T) {
+		_, err := New().Dereference(didID + "#key-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did method example not supported for vdr")
+		require.True(t, errors.Is(err, vdrapi.ErrMethodNotSupported))
+	})
+}
+
 func TestRegistry_Update(t *testing.T) {
 	t.Run("test invalid did input", func(t *testing.T) {
 		registry := New()
@@ -0,0 +1,260 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jcs"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	// UpdatePublicKeyOpt is the public key (*jwk.JWK or a crypto public key) the update commitment is made to.
+	// The key is generated through the KMS if not given.
+	UpdatePublicKeyOpt = "updatePublicKey"
+	// RecoveryPublicKeyOpt is the public key (*jwk.JWK or a crypto public key) the recovery commitment is made to.
+	// The key is generated through the KMS if not given.
+	RecoveryPublicKeyOpt = "recoveryPublicKey"
+	// KeyTypeOpt is the KMS key type of generated keys, ED25519 by default.
+	KeyTypeOpt = "keyType"
+
+	jsonWebKey2020 = "JsonWebKey2020"
+)
+
+// Create creates the long-form DID of the DID document locally, without anchoring it. The public keys of the
+// document must have JWKs or be Ed25519 keys, their verification relationships become the key purposes.
+// When the document has no verification methods, a key is generated through the KMS and used for authentication
+// and assertion. Keys generated through the KMS have KMS key IDs built from the public keys.
+func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	didOpts := applyOptions(opts...)
+
+	if didDoc == nil {
+		didDoc = &did.Doc{}
+	}
+
+	doc, err := v.sidetreeDocument(didDoc, didOpts)
+	if err != nil {
+		return nil, fmt.Errorf("create did:%s: %w", v.namespace, err)
+	}
+
+	updateKey, err := v.commitmentKey(didOpts, UpdatePublicKeyOpt)
+	if err != nil {
+		return nil, fmt.Errorf("create did:%s: update key: %w", v.namespace, err)
+	}
+
+	recoveryKey, err := v.commitmentKey(didOpts, RecoveryPublicKeyOpt)
+	if err != nil {
+		return nil, fmt.Errorf("create did:%s: recovery key: %w", v.namespace, err)
+	}
+
+	longForm, err := v.longFormDID(doc, updateKey, recoveryKey)
+	if err != nil {
+		return nil, fmt.Errorf("create did:%s: %w", v.namespace, err)
+	}
+
+	return v.Read(longForm)
+}
+
+// longFormDID builds the create operation of the document and encodes it into the long-form DID.
+func (v *VDR) longFormDID(doc *Document, updateKey, recoveryKey *jwk.JWK) (string, error) {
+	updateCommitment, err := Commitment(updateKey)
+	if err != nil {
+		return "", fmt.Errorf("update commitment: %w", err)
+	}
+
+	recoveryCommitment, err := Commitment(recoveryKey)
+	if err != nil {
+		return "", fmt.Errorf("recovery commitment: %w", err)
+	}
+
+	delta := &Delta{
+		Patches:          []Patch{{Action: PatchActionReplace, Document: doc}},
+		UpdateCommitment: updateCommitment,
+	}
+
+	deltaHash, err := Hash(delta)
+	if err != nil {
+		return "", fmt.Errorf("delta hash: %w", err)
+	}
+
+	suffixData := &SuffixData{DeltaHash: deltaHash, RecoveryCommitment: recoveryCommitment}
+
+	suffix, err := Hash(suffixData)
+	if err != nil {
+		return "", fmt.Errorf("DID suffix: %w", err)
+	}
+
+	state, err := jcs.Canonicalize(map[string]interface{}{"suffixData": suffixData, "delta": delta})
+	if err != nil {
+		return "", fmt.Errorf("canonicalize initial state: %w", err)
+	}
+
+	return v.didPrefix() + suffix + ":" + base64.RawURLEncoding.EncodeToString(state), nil
+}
+
+// sidetreeDocument converts the verification methods and services of the DID document to the Sidetree document.
+func (v *VDR) sidetreeDocument(didDoc *did.Doc, didOpts *vdrapi.DIDMethodOpts) (*Document, error) {
+	doc := &Document{}
+
+	for i := range didDoc.VerificationMethod {
+		key, err := sidetreePublicKey(&didDoc.VerificationMethod[i])
+		if err != nil {
+			return nil, err
+		}
+
+		doc.PublicKeys = append(doc.PublicKeys, *key)
+	}
+
+	relationships := []struct {
+		purpose       string
+		verifications []did.Verification
+	}{
+		{PurposeAuthentication, didDoc.Authentication},
+		{PurposeAssertionMethod, didDoc.AssertionMethod},
+		{PurposeCapabilityInvocation, didDoc.CapabilityInvocation},
+		{PurposeCapabilityDelegation, didDoc.CapabilityDelegation},
+		{PurposeKeyAgreement, didDoc.KeyAgreement},
+	}
+
+	for _, r := range relationships {
+		for i := range r.verifications {
+			if err := doc.addPurpose(&r.verifications[i].VerificationMethod, r.purpose); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(doc.PublicKeys) == 0 {
+		key, err := v.createKey(didOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		doc.PublicKeys = []PublicKey{{
+			ID:           key.KeyID,
+			Type:         jsonWebKey2020,
+			PublicKeyJWK: key.JWK,
+			Purposes:     []string{PurposeAuthentication, PurposeAssertionMethod},
+		}}
+	}
+
+	for _, svc := range didDoc.Service {
+		doc.Services = append(doc.Services, Service{
+			ID:              fragment(svc.ID),
+			Type:            svc.Type,
+			ServiceEndpoint: svc.ServiceEndpoint,
+		})
+	}
+
+	return doc, nil
+}
+
+// addPurpose adds the purpose to the public key of the verification method, embedded verification methods
+// are added as public keys.
+func (d *Document) addPurpose(vm *did.VerificationMethod, purpose string) error {
+	id := fragment(vm.ID)
+
+	for i := range d.PublicKeys {
+		if d.PublicKeys[i].ID == id {
+			d.PublicKeys[i].Purposes = append(d.PublicKeys[i].Purposes, purpose)
+
+			return nil
+		}
+	}
+
+	key, err := sidetreePublicKey(vm)
+	if err != nil {
+		return err
+	}
+
+	key.Purposes = []string{purpose}
+	d.PublicKeys = append(d.PublicKeys, *key)
+
+	return nil
+}
+
+func sidetreePublicKey(vm *did.VerificationMethod) (*PublicKey, error) {
+	j := vm.JSONWebKey()
+
+	if j == nil {
+		if vm.Type != did.Ed25519VerificationKey2020 && vm.Type != "Ed25519VerificationKey2018" {
+			return nil, fmt.Errorf("public key %s: JWK is required for %s", vm.ID, vm.Type)
+		}
+
+		var err error
+
+		j, err = jwksupport.JWKFromKey(ed25519.PublicKey(vm.Value))
+		if err != nil {
+			return nil, fmt.Errorf("public key %s: %w", vm.ID, err)
+		}
+	}
+
+	return &PublicKey{ID: fragment(vm.ID), Type: vm.Type, PublicKeyJWK: j}, nil
+}
+
+// commitmentKey returns the update or recovery public key given by the option, or generates one.
+func (v *VDR) commitmentKey(didOpts *vdrapi.DIDMethodOpts, opt string) (*jwk.JWK, error) {
+	switch k := didOpts.Values[opt].(type) {
+	case nil:
+		key, err := v.createKey(didOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		return key.JWK, nil
+	case *jwk.JWK:
+		return k, nil
+	default:
+		return jwksupport.JWKFromKey(k)
+	}
+}
+
+type createdKey struct {
+	KeyID string
+	JWK   *jwk.JWK
+}
+
+func (v *VDR) createKey(didOpts *vdrapi.DIDMethodOpts) (*createdKey, error) {
+	if v.keyManager == nil {
+		return nil, errors.New("key manager is required to generate keys")
+	}
+
+	keyType := kms.ED25519Type
+
+	switch kt := didOpts.Values[KeyTypeOpt].(type) {
+	case kms.KeyType:
+		keyType = kt
+	case string:
+		keyType = kms.KeyType(kt)
+	}
+
+	kid, pubKey, err := v.keyManager.CreateAndExportPubKeyBytes(keyType)
+	if err != nil {
+		return nil, fmt.Errorf("create key: %w", err)
+	}
+
+	j, err := jwksupport.PubKeyBytesToJWK(pubKey, keyType)
+	if err != nil {
+		return nil, fmt.Errorf("convert public key to JWK: %w", err)
+	}
+
+	return &createdKey{KeyID: kid, JWK: j}, nil
+}
+
+// fragment returns the fragment of the DID URL, or the ID itself if it has no fragment.
+func fragment(id string) string {
+	return id[strings.LastIndex(id, "#")+1:]
+}
@@ -0,0 +1,197 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jcs"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockprotocol "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func TestCreate(t *testing.T) {
+	t.Run("test create long-form DID with generated keys", func(t *testing.T) {
+		v := New("ion", WithKeyManager(newKMS(t)))
+
+		docResolution, err := v.Create(nil)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		parts := strings.Split(doc.ID, ":")
+		require.Len(t, parts, 4)
+		require.Equal(t, "ion", parts[1])
+
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+		require.NotNil(t, doc.VerificationMethod[0].JSONWebKey())
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Equal(t, doc.VerificationMethod[0].ID, doc.Authentication[0].VerificationMethod.ID)
+
+		metadata := docResolution.DocumentMetadata
+		require.Equal(t, []string{"did:ion:" + parts[2]}, metadata.EquivalentID)
+		require.Empty(t, metadata.CanonicalID)
+		require.False(t, metadata.Method.Published)
+		require.NotEmpty(t, metadata.Method.UpdateCommitment)
+		require.NotEmpty(t, metadata.Method.RecoveryCommitment)
+
+		// the long-form DID embeds the create operation, its suffix is the hash of the suffix data.
+		state, err := base64.RawURLEncoding.DecodeString(parts[3])
+		require.NoError(t, err)
+
+		initial := &initialState{}
+		require.NoError(t, json.Unmarshal(state, initial))
+
+		suffix, err := Hash(initial.SuffixData)
+		require.NoError(t, err)
+		require.Equal(t, parts[2], suffix)
+
+		canonical, err := jcs.Canonicalize(json.RawMessage(state))
+		require.NoError(t, err)
+		require.Equal(t, string(canonical), string(state))
+	})
+
+	t.Run("test create long-form DID of the document", func(t *testing.T) {
+		updateKey, _ := newKey(t)
+		recoveryKey, _ := newKey(t)
+		signingKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		agreementKey, err := jwksupport.JWKFromX25519Key(make([]byte, 32))
+		require.NoError(t, err)
+
+		vm := did.NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", "", signingKey)
+		kaVM, err := did.NewVerificationMethodFromJWK("#key-2", jsonWebKey2020, "", agreementKey)
+		require.NoError(t, err)
+
+		didDoc := &did.Doc{
+			VerificationMethod: []did.VerificationMethod{*vm},
+			Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+			CapabilityInvocation: []did.Verification{
+				*did.NewReferencedVerification(vm, did.CapabilityInvocation),
+			},
+			KeyAgreement: []did.Verification{*did.NewEmbeddedVerification(kaVM, did.KeyAgreement)},
+			Service:      []did.Service{{ID: "#hub", Type: "IdentityHub", ServiceEndpoint: "https://hub.example.com"}},
+		}
+
+		v := New("ion:test")
+
+		docResolution, err := v.Create(didDoc, vdrapi.WithOption(UpdatePublicKeyOpt, updateKey),
+			vdrapi.WithOption(RecoveryPublicKeyOpt, recoveryKey))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:ion:test:"))
+		require.Len(t, doc.VerificationMethod, 2)
+		require.Equal(t, doc.ID+"#key-1", doc.VerificationMethod[0].ID)
+		require.Equal(t, []byte(signingKey), doc.VerificationMethod[0].Value)
+		require.Equal(t, doc.ID+"#key-1", doc.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, doc.ID+"#key-1", doc.CapabilityInvocation[0].VerificationMethod.ID)
+		require.Equal(t, doc.ID+"#key-2", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Empty(t, doc.AssertionMethod)
+		require.Equal(t, doc.ID+"#hub", doc.Service[0].ID)
+		require.Equal(t, "https://hub.example.com", doc.Service[0].ServiceEndpoint)
+
+		updateCommitment, err := Commitment(updateKey)
+		require.NoError(t, err)
+		require.Equal(t, updateCommitment, docResolution.DocumentMetadata.Method.UpdateCommitment)
+
+		recoveryCommitment, err := Commitment(recoveryKey)
+		require.NoError(t, err)
+		require.Equal(t, recoveryCommitment, docResolution.DocumentMetadata.Method.RecoveryCommitment)
+
+		// the document serializes and resolves to itself
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		parsed, err := did.ParseDocument(docBytes)
+		require.NoError(t, err)
+		require.Equal(t, doc.VerificationMethod[0].ID, parsed.VerificationMethod[0].ID)
+
+		resolved, err := v.Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc, resolved.DIDDocument)
+	})
+
+	t.Run("test create with crypto public keys", func(t *testing.T) {
+		updateKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		v := New("ion", WithKeyManager(newKMS(t)))
+
+		docResolution, err := v.Create(nil, vdrapi.WithOption(UpdatePublicKeyOpt, updateKey),
+			vdrapi.WithOption(KeyTypeOpt, string(kms.ECDSAP256TypeIEEEP1363)))
+		require.NoError(t, err)
+		require.Equal(t, "P-256", docResolution.DIDDocument.VerificationMethod[0].JSONWebKey().Crv)
+	})
+
+	t.Run("test create failures", func(t *testing.T) {
+		v := New("ion")
+
+		_, err := v.Create(nil)
+		require.EqualError(t, err, "create did:ion: key manager is required to generate keys")
+
+		updateKey, _ := newKey(t)
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", "", []byte("key")),
+		}}, vdrapi.WithOption(UpdatePublicKeyOpt, updateKey))
+		require.EqualError(t, err, "create did:ion: recovery key: key manager is required to generate keys")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key-1", "Bls12381G2Key2020", "", []byte("key")),
+		}})
+		require.EqualError(t, err, "create did:ion: public key #key-1: JWK is required for Bls12381G2Key2020")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", "", []byte("key")),
+		}}, vdrapi.WithOption(UpdatePublicKeyOpt, "invalid"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create did:ion: update key: create JWK")
+
+		v = New("ion", WithKeyManager(&mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("kms error")}))
+
+		_, err = v.Create(nil)
+		require.EqualError(t, err, "create did:ion: create key: kms error")
+
+		v = New("ion", WithKeyManager(&mockkms.KeyManager{CrAndExportPubKeyValue: []byte("invalid")}))
+
+		_, err = v.Create(nil, vdrapi.WithOption(KeyTypeOpt, kms.ECDSAP256TypeDER))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "convert public key to JWK")
+	})
+}
+
+func newKMS(t *testing.T) kms.KeyManager {
+	t.Helper()
+
+	kmsProv := &mockprotocol.MockProvider{
+		StoreProvider: mem.NewProvider(),
+		CustomLock:    &noop.NoLock{},
+	}
+
+	customKMS, err := localkms.New("local-lock://primary/test/", kmsProv)
+	require.NoError(t, err)
+
+	return customKMS
+}
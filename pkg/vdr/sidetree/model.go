@@ -0,0 +1,158 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/multiformats/go-multihash"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jcs"
+)
+
+// OperationType is the type of Sidetree operation.
+type OperationType string
+
+const (
+	// OperationTypeCreate creates the DID.
+	OperationTypeCreate OperationType = "create"
+	// OperationTypeUpdate updates the DID document, it is signed by the update key.
+	OperationTypeUpdate OperationType = "update"
+	// OperationTypeRecover replaces the DID document, it is signed by the recovery key.
+	OperationTypeRecover OperationType = "recover"
+	// OperationTypeDeactivate deactivates the DID, it is signed by the recovery key.
+	OperationTypeDeactivate OperationType = "deactivate"
+)
+
+// Patch actions of the delta.
+const (
+	PatchActionReplace          = "replace"
+	PatchActionAddPublicKeys    = "add-public-keys"
+	PatchActionRemovePublicKeys = "remove-public-keys"
+	PatchActionAddServices      = "add-services"
+	PatchActionRemoveServices   = "remove-services"
+)
+
+// multihashCode is the hash algorithm of the Sidetree protocol, SHA2-256.
+const multihashCode = multihash.SHA2_256
+
+// Operation is the Sidetree operation request. Suffix data and delta are kept as they were received,
+// since their hashes are computed over the JCS representation of the received JSON.
+type Operation struct {
+	Type        OperationType   `json:"type"`
+	DIDSuffix   string          `json:"didSuffix,omitempty"`
+	RevealValue string          `json:"revealValue,omitempty"`
+	SuffixData  json.RawMessage `json:"suffixData,omitempty"`
+	Delta       json.RawMessage `json:"delta,omitempty"`
+	// SignedData is the compact JWS of update, recover and deactivate operations.
+	SignedData string `json:"signedData,omitempty"`
+}
+
+// SuffixData is the suffix data of the create operation, the DID unique suffix is its hash.
+type SuffixData struct {
+	DeltaHash          string `json:"deltaHash"`
+	RecoveryCommitment string `json:"recoveryCommitment"`
+	Type               string `json:"type,omitempty"`
+}
+
+// Delta is the set of patches applied to the DID document, together with the commitment to the next update key.
+type Delta struct {
+	Patches          []Patch `json:"patches"`
+	UpdateCommitment string  `json:"updateCommitment"`
+}
+
+// Patch is a change of the DID document, its fields depend on the action.
+type Patch struct {
+	Action     string      `json:"action"`
+	Document   *Document   `json:"document,omitempty"`
+	PublicKeys []PublicKey `json:"publicKeys,omitempty"`
+	Services   []Service   `json:"services,omitempty"`
+	IDs        []string    `json:"ids,omitempty"`
+}
+
+// Document is the Sidetree representation of the DID document state.
+type Document struct {
+	PublicKeys []PublicKey `json:"publicKeys,omitempty"`
+	Services   []Service   `json:"services,omitempty"`
+}
+
+// PublicKey of the DID document. ID is the fragment of the verification method ID.
+type PublicKey struct {
+	ID           string   `json:"id"`
+	Type         string   `json:"type"`
+	PublicKeyJWK *jwk.JWK `json:"publicKeyJwk"`
+	Purposes     []string `json:"purposes,omitempty"`
+}
+
+// Service of the DID document. ID is the fragment of the service ID.
+type Service struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	ServiceEndpoint string `json:"serviceEndpoint"`
+}
+
+// signedData is the payload of the JWS signed by the update or the recovery key.
+type signedData struct {
+	DIDSuffix          string   `json:"didSuffix,omitempty"`
+	UpdateKey          *jwk.JWK `json:"updateKey,omitempty"`
+	RecoveryKey        *jwk.JWK `json:"recoveryKey,omitempty"`
+	RecoveryCommitment string   `json:"recoveryCommitment,omitempty"`
+	DeltaHash          string   `json:"deltaHash,omitempty"`
+}
+
+// initialState is the create operation encoded into the long-form DID.
+type initialState struct {
+	SuffixData json.RawMessage `json:"suffixData"`
+	Delta      json.RawMessage `json:"delta"`
+}
+
+// Commitment returns the commitment to the public key: the encoded multihash of the hash of the JCS
+// representation of the key. The key is revealed by the operation the commitment is used up by.
+func Commitment(key *jwk.JWK) (string, error) {
+	data, err := jcs.Canonicalize(key)
+	if err != nil {
+		return "", fmt.Errorf("canonicalize public key: %w", err)
+	}
+
+	digest := sha256.Sum256(data)
+
+	return encodedMultihash(digest[:])
+}
+
+// RevealValue returns the reveal value of the public key: the encoded multihash of the JCS representation of the key.
+func RevealValue(key *jwk.JWK) (string, error) {
+	data, err := jcs.Canonicalize(key)
+	if err != nil {
+		return "", fmt.Errorf("canonicalize public key: %w", err)
+	}
+
+	return encodedMultihash(data)
+}
+
+// Hash returns the encoded multihash of the JCS representation of the value. The DID unique suffix is
+// the hash of the suffix data, the delta hash is the hash of the delta.
+func Hash(v interface{}) (string, error) {
+	data, err := jcs.Canonicalize(v)
+	if err != nil {
+		return "", fmt.Errorf("canonicalize: %w", err)
+	}
+
+	return encodedMultihash(data)
+}
+
+func encodedMultihash(data []byte) (string, error) {
+	mh, err := multihash.Sum(data, multihashCode, -1)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(mh), nil
+}
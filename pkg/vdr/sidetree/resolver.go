@@ -0,0 +1,125 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+var logger = log.New("aries-framework/pkg/vdr/sidetree")
+
+// Read resolves the DID. A long-form DID is resolved offline from the create operation it embeds, unless the
+// operation store knows the DID as published. A short-form DID is resolved from the operations of the operation store.
+func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if !strings.HasPrefix(didID, v.didPrefix()) {
+		return nil, fmt.Errorf("resolve %s: not a did:%s DID", didID, v.namespace)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(didID, v.didPrefix()), ":")
+	if len(parts) > 2 || parts[0] == "" { //nolint:gomnd
+		return nil, fmt.Errorf("resolve %s: invalid DID", didID)
+	}
+
+	suffix := parts[0]
+
+	var create *Operation
+
+	if len(parts) == 2 { //nolint:gomnd
+		op, err := parseLongFormState(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s: %w", didID, err)
+		}
+
+		create = op
+	}
+
+	ops, published, err := v.publishedOperations(suffix)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", didID, err)
+	}
+
+	if !published {
+		if create == nil {
+			return nil, fmt.Errorf("resolve %s: %w", didID, vdrapi.ErrNotFound)
+		}
+
+		ops = []*Operation{create}
+	}
+
+	state, err := applyOperations(suffix, ops)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", didID, err)
+	}
+
+	doc, err := state.toDocument(didID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", didID, err)
+	}
+
+	shortForm := v.didPrefix() + suffix
+
+	metadata := &did.DocumentMetadata{
+		Deactivated: state.deactivated,
+		Method: &did.MethodMetadata{
+			Published:          published,
+			UpdateCommitment:   state.updateCommitment,
+			RecoveryCommitment: state.recoveryCommitment,
+		},
+	}
+
+	if published {
+		metadata.CanonicalID = shortForm
+	}
+
+	if didID != shortForm {
+		metadata.EquivalentID = []string{shortForm}
+	}
+
+	return &did.DocResolution{DIDDocument: doc, DocumentMetadata: metadata}, nil
+}
+
+// publishedOperations returns the anchored operations of the DID, if the DID is known to the operation store.
+func (v *VDR) publishedOperations(suffix string) ([]*Operation, bool, error) {
+	if v.operationStore == nil {
+		return nil, false, nil
+	}
+
+	ops, err := v.operationStore.Get(suffix)
+	if err != nil {
+		if errors.Is(err, vdrapi.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("get operations: %w", err)
+	}
+
+	return ops, true, nil
+}
+
+// parseLongFormState decodes the create operation from the initial state of the long-form DID.
+func parseLongFormState(encoded string) (*Operation, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode long-form initial state: %w", err)
+	}
+
+	state := &initialState{}
+
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("unmarshal long-form initial state: %w", err)
+	}
+
+	return &Operation{Type: OperationTypeCreate, SuffixData: state.SuffixData, Delta: state.Delta}, nil
+}
@@ -0,0 +1,369 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jcs"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+type operationStore map[string][]*Operation
+
+func (s operationStore) Get(suffix string) ([]*Operation, error) {
+	ops, ok := s[suffix]
+	if !ok {
+		return nil, vdrapi.ErrNotFound
+	}
+
+	return ops, nil
+}
+
+type failingStore struct{}
+
+func (failingStore) Get(string) ([]*Operation, error) {
+	return nil, errors.New("store error")
+}
+
+// testDID is a long-form DID with its update and recovery keys.
+type testDID struct {
+	longForm    string
+	suffix      string
+	create      *Operation
+	updateKey   *jwk.JWK
+	updateSK    ed25519.PrivateKey
+	recoveryKey *jwk.JWK
+	recoverySK  ed25519.PrivateKey
+}
+
+func newTestDID(t *testing.T, v *VDR) *testDID {
+	t.Helper()
+
+	d := &testDID{}
+	d.updateKey, d.updateSK = newKey(t)
+	d.recoveryKey, d.recoverySK = newKey(t)
+
+	signingKey, _ := newKey(t)
+
+	doc := &Document{PublicKeys: []PublicKey{{
+		ID: "key-1", Type: jsonWebKey2020, PublicKeyJWK: signingKey, Purposes: []string{PurposeAuthentication},
+	}}}
+
+	longForm, err := v.longFormDID(doc, d.updateKey, d.recoveryKey)
+	require.NoError(t, err)
+
+	parts := strings.Split(strings.TrimPrefix(longForm, v.didPrefix()), ":")
+
+	d.longForm = longForm
+	d.suffix = parts[0]
+	d.create, err = parseLongFormState(parts[1])
+	require.NoError(t, err)
+
+	return d
+}
+
+func TestRead(t *testing.T) {
+	t.Run("test resolve long-form DID offline", func(t *testing.T) {
+		v := New("ion")
+		d := newTestDID(t, v)
+
+		docResolution, err := v.Read(d.longForm)
+		require.NoError(t, err)
+		require.Equal(t, d.longForm, docResolution.DIDDocument.ID)
+		require.Len(t, docResolution.DIDDocument.Authentication, 1)
+		require.Equal(t, []string{"did:ion:" + d.suffix}, docResolution.DocumentMetadata.EquivalentID)
+		require.False(t, docResolution.DocumentMetadata.Method.Published)
+
+		// long-form DIDs not known to the operation store are resolved offline too
+		docResolution, err = New("ion", WithOperationStore(operationStore{})).Read(d.longForm)
+		require.NoError(t, err)
+		require.False(t, docResolution.DocumentMetadata.Method.Published)
+	})
+
+	t.Run("test resolve long-form DID with delta not matching delta hash", func(t *testing.T) {
+		v := New("ion")
+		d := newTestDID(t, v)
+
+		state, err := jcs.Canonicalize(map[string]interface{}{
+			"suffixData": d.create.SuffixData,
+			"delta":      &Delta{UpdateCommitment: "other"},
+		})
+		require.NoError(t, err)
+
+		docResolution, err := v.Read("did:ion:" + d.suffix + ":" + base64.RawURLEncoding.EncodeToString(state))
+		require.NoError(t, err)
+		require.Empty(t, docResolution.DIDDocument.VerificationMethod)
+		require.Empty(t, docResolution.DocumentMetadata.Method.UpdateCommitment)
+	})
+
+	t.Run("test resolve short-form DID", func(t *testing.T) {
+		v := New("ion")
+		d := newTestDID(t, v)
+
+		_, err := v.Read("did:ion:" + d.suffix)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+
+		v = New("ion", WithOperationStore(operationStore{d.suffix: {d.create}}))
+
+		docResolution, err := v.Read("did:ion:" + d.suffix)
+		require.NoError(t, err)
+		require.Equal(t, "did:ion:"+d.suffix, docResolution.DIDDocument.ID)
+		require.Equal(t, "did:ion:"+d.suffix+"#key-1", docResolution.DIDDocument.VerificationMethod[0].ID)
+		require.Equal(t, "did:ion:"+d.suffix, docResolution.DocumentMetadata.CanonicalID)
+		require.Empty(t, docResolution.DocumentMetadata.EquivalentID)
+		require.True(t, docResolution.DocumentMetadata.Method.Published)
+
+		docResolution, err = v.Read(d.longForm)
+		require.NoError(t, err)
+		require.Equal(t, d.longForm, docResolution.DIDDocument.ID)
+		require.Equal(t, "did:ion:"+d.suffix, docResolution.DocumentMetadata.CanonicalID)
+		require.True(t, docResolution.DocumentMetadata.Method.Published)
+	})
+
+	t.Run("test resolve updated DID", func(t *testing.T) {
+		v := New("ion")
+		d := newTestDID(t, v)
+
+		nextUpdateKey, nextUpdateSK := newKey(t)
+		newKey2, _ := newKey(t)
+
+		update := updateOperation(t, d.suffix, d.updateKey, d.updateSK, nextUpdateKey,
+			Patch{Action: PatchActionAddPublicKeys, PublicKeys: []PublicKey{{
+				ID: "key-2", Type: jsonWebKey2020, PublicKeyJWK: newKey2, Purposes: []string{PurposeAssertionMethod},
+			}}},
+			Patch{Action: PatchActionAddServices, Services: []Service{{
+				ID: "hub", Type: "IdentityHub", ServiceEndpoint: "https://hub.example.com",
+			}}})
+
+		// the update key is used up, the operation signed by it again is ignored
+		replayed := updateOperation(t, d.suffix, d.updateKey, d.updateSK, nextUpdateKey,
+			Patch{Action: PatchActionRemoveServices, IDs: []string{"hub"}})
+
+		second := updateOperation(t, d.suffix, nextUpdateKey, nextUpdateSK, d.updateKey,
+			Patch{Action: PatchActionRemovePublicKeys, IDs: []string{"key-1"}})
+
+		// operations signed by keys not committed to, of other DIDs or with invalid patches are ignored
+		forged := updateOperation(t, d.suffix, newKey2, nextUpdateSK, newKey2,
+			Patch{Action: PatchActionRemovePublicKeys, IDs: []string{"key-2"}})
+		otherDID := updateOperation(t, "other", d.updateKey, d.updateSK, newKey2)
+		invalidPatch := updateOperation(t, d.suffix, d.updateKey, d.updateSK, newKey2,
+			Patch{Action: "ietf-json-patch"})
+
+		v = New("ion", WithOperationStore(operationStore{
+			d.suffix: {d.create, update, replayed, forged, otherDID, second, invalidPatch},
+		}))
+
+		docResolution, err := v.Read("did:ion:" + d.suffix)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, doc.ID+"#key-2", doc.VerificationMethod[0].ID)
+		require.Empty(t, doc.Authentication)
+		require.Equal(t, doc.ID+"#key-2", doc.AssertionMethod[0].VerificationMethod.ID)
+		require.Equal(t, doc.ID+"#hub", doc.Service[0].ID)
+
+		// the invalid patch leaves the document as is, but uses up the update key
+		commitment, err := Commitment(newKey2)
+		require.NoError(t, err)
+		require.Equal(t, commitment, docResolution.DocumentMetadata.Method.UpdateCommitment)
+	})
+
+	t.Run("test resolve recovered and deactivated DID", func(t *testing.T) {
+		v := New("ion")
+		d := newTestDID(t, v)
+
+		nextRecoveryKey, nextRecoverySK := newKey(t)
+		nextUpdateKey, _ := newKey(t)
+		recoveredKey, _ := newKey(t)
+
+		recoverCommitment, err := Commitment(nextRecoveryKey)
+		require.NoError(t, err)
+
+		updateCommitment, err := Commitment(nextUpdateKey)
+		require.NoError(t, err)
+
+		delta := &Delta{
+			Patches: []Patch{{Action: PatchActionReplace, Document: &Document{PublicKeys: []PublicKey{{
+				ID: "recovered", Type: jsonWebKey2020, PublicKeyJWK: recoveredKey,
+				Purposes: []string{PurposeCapabilityDelegation},
+			}}}}},
+			UpdateCommitment: updateCommitment,
+		}
+
+		deltaHash, err := Hash(delta)
+		require.NoError(t, err)
+
+		recoverOp := &Operation{
+			Type:        OperationTypeRecover,
+			DIDSuffix:   d.suffix,
+			RevealValue: revealValue(t, d.recoveryKey),
+			Delta:       marshal(t, delta),
+			SignedData: signJWS(t, d.recoverySK, &signedData{
+				RecoveryKey: d.recoveryKey, RecoveryCommitment: recoverCommitment, DeltaHash: deltaHash,
+			}),
+		}
+
+		// the operation without the reveal value is ignored
+		unrevealedOp := *recoverOp
+		unrevealedOp.RevealValue = ""
+
+		v = New("ion", WithOperationStore(operationStore{d.suffix: {d.create, &unrevealedOp}}))
+
+		docResolution, err := v.Read("did:ion:" + d.suffix)
+		require.NoError(t, err)
+		require.Equal(t, commitment(t, d.recoveryKey), docResolution.DocumentMetadata.Method.RecoveryCommitment)
+
+		v = New("ion", WithOperationStore(operationStore{d.suffix: {d.create, recoverOp}}))
+
+		docResolution, err = v.Read("did:ion:" + d.suffix)
+		require.NoError(t, err)
+		require.Len(t, docResolution.DIDDocument.VerificationMethod, 1)
+		require.Equal(t, "did:ion:"+d.suffix+"#recovered", docResolution.DIDDocument.VerificationMethod[0].ID)
+		require.Len(t, docResolution.DIDDocument.CapabilityDelegation, 1)
+		require.Equal(t, recoverCommitment, docResolution.DocumentMetadata.Method.RecoveryCommitment)
+		require.Equal(t, updateCommitment, docResolution.DocumentMetadata.Method.UpdateCommitment)
+
+		deactivateOp := &Operation{
+			Type:        OperationTypeDeactivate,
+			DIDSuffix:   d.suffix,
+			RevealValue: revealValue(t, nextRecoveryKey),
+			SignedData:  signJWS(t, nextRecoverySK, &signedData{DIDSuffix: d.suffix, RecoveryKey: nextRecoveryKey}),
+		}
+
+		// the deactivate operation signed by the used up recovery key is ignored
+		staleDeactivate := &Operation{
+			Type:        OperationTypeDeactivate,
+			DIDSuffix:   d.suffix,
+			RevealValue: revealValue(t, d.recoveryKey),
+			SignedData:  signJWS(t, d.recoverySK, &signedData{DIDSuffix: d.suffix, RecoveryKey: d.recoveryKey}),
+		}
+
+		v = New("ion", WithOperationStore(operationStore{d.suffix: {d.create, recoverOp, staleDeactivate}}))
+
+		docResolution, err = v.Read("did:ion:" + d.suffix)
+		require.NoError(t, err)
+		require.False(t, docResolution.DocumentMetadata.Deactivated)
+
+		v = New("ion", WithOperationStore(operationStore{d.suffix: {d.create, recoverOp, deactivateOp}}))
+
+		docResolution, err = v.Read("did:ion:" + d.suffix)
+		require.NoError(t, err)
+		require.True(t, docResolution.DocumentMetadata.Deactivated)
+		require.Empty(t, docResolution.DIDDocument.VerificationMethod)
+		require.Empty(t, docResolution.DocumentMetadata.Method.RecoveryCommitment)
+	})
+
+	t.Run("test resolve failures", func(t *testing.T) {
+		v := New("ion")
+		d := newTestDID(t, v)
+
+		_, err := v.Read("did:example:123")
+		require.EqualError(t, err, "resolve did:example:123: not a did:ion DID")
+
+		_, err = v.Read("did:ion:a:b:c")
+		require.EqualError(t, err, "resolve did:ion:a:b:c: invalid DID")
+
+		_, err = v.Read("did:ion:a:!")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode long-form initial state")
+
+		_, err = v.Read("did:ion:a:" + base64.RawURLEncoding.EncodeToString([]byte("[]")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal long-form initial state")
+
+		_, err = v.Read("did:ion:other:" + strings.Split(d.longForm, ":")[3])
+		require.EqualError(t, err, "resolve did:ion:other:"+strings.Split(d.longForm, ":")[3]+
+			": DID suffix does not match suffix data")
+
+		_, err = New("ion", WithOperationStore(failingStore{})).Read(d.longForm)
+		require.EqualError(t, err, "resolve "+d.longForm+": get operations: store error")
+
+		_, err = New("ion", WithOperationStore(operationStore{d.suffix: {}})).Read(d.longForm)
+		require.EqualError(t, err, "resolve "+d.longForm+": create operation not found")
+	})
+}
+
+func newKey(t *testing.T) (*jwk.JWK, ed25519.PrivateKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	j, err := jwksupport.JWKFromKey(pub)
+	require.NoError(t, err)
+
+	return j, priv
+}
+
+func updateOperation(t *testing.T, suffix string, updateKey *jwk.JWK, signer ed25519.PrivateKey,
+	nextUpdateKey *jwk.JWK, patches ...Patch) *Operation {
+	t.Helper()
+
+	commitment, err := Commitment(nextUpdateKey)
+	require.NoError(t, err)
+
+	delta := &Delta{Patches: patches, UpdateCommitment: commitment}
+
+	deltaHash, err := Hash(delta)
+	require.NoError(t, err)
+
+	return &Operation{
+		Type:        OperationTypeUpdate,
+		DIDSuffix:   suffix,
+		RevealValue: revealValue(t, updateKey),
+		Delta:       marshal(t, delta),
+		SignedData:  signJWS(t, signer, &signedData{UpdateKey: updateKey, DeltaHash: deltaHash}),
+	}
+}
+
+func commitment(t *testing.T, key *jwk.JWK) string {
+	t.Helper()
+
+	c, err := Commitment(key)
+	require.NoError(t, err)
+
+	return c
+}
+
+func revealValue(t *testing.T, key *jwk.JWK) string {
+	t.Helper()
+
+	reveal, err := RevealValue(key)
+	require.NoError(t, err)
+
+	return reveal
+}
+
+func signJWS(t *testing.T, signer ed25519.PrivateKey, payload interface{}) string {
+	t.Helper()
+
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"EdDSA"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(marshal(t, payload))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(signer, []byte(signingInput)))
+}
+
+func marshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return b
+}
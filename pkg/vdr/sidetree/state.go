@@ -0,0 +1,458 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const jwsPartsCount = 3
+
+// Verification relationships of public keys.
+const (
+	PurposeAuthentication       = "authentication"
+	PurposeAssertionMethod      = "assertionMethod"
+	PurposeCapabilityInvocation = "capabilityInvocation"
+	PurposeCapabilityDelegation = "capabilityDelegation"
+	PurposeKeyAgreement         = "keyAgreement"
+)
+
+// didState is the state of the DID after the operations are applied.
+type didState struct {
+	document           Document
+	updateCommitment   string
+	recoveryCommitment string
+	deactivated        bool
+}
+
+// applyOperations applies the operations of the DID in the anchoring order. The first operation creates the DID,
+// subsequent operations which are not valid are ignored as required by the Sidetree protocol.
+func applyOperations(suffix string, ops []*Operation) (*didState, error) {
+	if len(ops) == 0 || ops[0].Type != OperationTypeCreate {
+		return nil, errors.New("create operation not found")
+	}
+
+	state, err := applyCreate(suffix, ops[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range ops[1:] {
+		if state.deactivated {
+			break
+		}
+
+		if err = state.apply(suffix, op); err != nil {
+			logger.Debugf("ignore %s operation of DID %s: %v", op.Type, suffix, err)
+		}
+	}
+
+	return state, nil
+}
+
+func applyCreate(suffix string, op *Operation) (*didState, error) {
+	suffixData := &SuffixData{}
+
+	if err := json.Unmarshal(op.SuffixData, suffixData); err != nil {
+		return nil, fmt.Errorf("unmarshal suffix data: %w", err)
+	}
+
+	computed, err := Hash(op.SuffixData)
+	if err != nil {
+		return nil, fmt.Errorf("hash suffix data: %w", err)
+	}
+
+	if computed != suffix {
+		return nil, errors.New("DID suffix does not match suffix data")
+	}
+
+	state := &didState{recoveryCommitment: suffixData.RecoveryCommitment}
+
+	// the DID is created with the empty document if the delta does not match the delta hash.
+	delta, err := parseDelta(op.Delta, suffixData.DeltaHash)
+	if err != nil {
+		logger.Debugf("ignore delta of DID %s: %v", suffix, err)
+
+		return state, nil
+	}
+
+	state.applyDelta(delta)
+
+	return state, nil
+}
+
+func (s *didState) apply(suffix string, op *Operation) error {
+	if op.DIDSuffix != suffix {
+		return errors.New("DID suffix does not match")
+	}
+
+	switch op.Type {
+	case OperationTypeUpdate:
+		return s.applyUpdate(op)
+	case OperationTypeRecover:
+		return s.applyRecover(op)
+	case OperationTypeDeactivate:
+		return s.applyDeactivate(suffix, op)
+	default:
+		return fmt.Errorf("unexpected operation type %s", op.Type)
+	}
+}
+
+func (s *didState) applyUpdate(op *Operation) error {
+	signed, err := verifySignedData(op.SignedData, func(d *signedData) *jwk.JWK { return d.UpdateKey })
+	if err != nil {
+		return err
+	}
+
+	if err = checkCommitment(signed.UpdateKey, s.updateCommitment, op.RevealValue); err != nil {
+		return fmt.Errorf("update key: %w", err)
+	}
+
+	delta, err := parseDelta(op.Delta, signed.DeltaHash)
+	if err != nil {
+		return err
+	}
+
+	s.applyDelta(delta)
+
+	return nil
+}
+
+func (s *didState) applyRecover(op *Operation) error {
+	signed, err := verifySignedData(op.SignedData, func(d *signedData) *jwk.JWK { return d.RecoveryKey })
+	if err != nil {
+		return err
+	}
+
+	if err = checkCommitment(signed.RecoveryKey, s.recoveryCommitment, op.RevealValue); err != nil {
+		return fmt.Errorf("recovery key: %w", err)
+	}
+
+	s.recoveryCommitment = signed.RecoveryCommitment
+	s.document = Document{}
+	s.updateCommitment = ""
+
+	// the document is recovered empty if the delta does not match the delta hash.
+	delta, err := parseDelta(op.Delta, signed.DeltaHash)
+	if err != nil {
+		logger.Debugf("ignore delta of recover operation: %v", err)
+
+		return nil
+	}
+
+	s.applyDelta(delta)
+
+	return nil
+}
+
+func (s *didState) applyDeactivate(suffix string, op *Operation) error {
+	signed, err := verifySignedData(op.SignedData, func(d *signedData) *jwk.JWK { return d.RecoveryKey })
+	if err != nil {
+		return err
+	}
+
+	if signed.DIDSuffix != suffix {
+		return errors.New("signed DID suffix does not match")
+	}
+
+	if err = checkCommitment(signed.RecoveryKey, s.recoveryCommitment, op.RevealValue); err != nil {
+		return fmt.Errorf("recovery key: %w", err)
+	}
+
+	*s = didState{deactivated: true}
+
+	return nil
+}
+
+// applyDelta applies the patches of the delta. The document is left as is if any of the patches fails,
+// the update commitment is taken from the delta anyway.
+func (s *didState) applyDelta(delta *Delta) {
+	s.updateCommitment = delta.UpdateCommitment
+
+	doc, err := applyPatches(s.document, delta.Patches)
+	if err != nil {
+		logger.Debugf("ignore patches: %v", err)
+
+		return
+	}
+
+	s.document = doc
+}
+
+func applyPatches(doc Document, patches []Patch) (Document, error) {
+	result := Document{
+		PublicKeys: append([]PublicKey(nil), doc.PublicKeys...),
+		Services:   append([]Service(nil), doc.Services...),
+	}
+
+	for i := range patches {
+		p := &patches[i]
+
+		switch p.Action {
+		case PatchActionReplace:
+			if p.Document == nil {
+				return doc, errors.New("replace patch: document is missing")
+			}
+
+			result = Document{}
+
+			if err := result.addPublicKeys(p.Document.PublicKeys); err != nil {
+				return doc, fmt.Errorf("replace patch: %w", err)
+			}
+
+			if err := result.addServices(p.Document.Services); err != nil {
+				return doc, fmt.Errorf("replace patch: %w", err)
+			}
+		case PatchActionAddPublicKeys:
+			if err := result.addPublicKeys(p.PublicKeys); err != nil {
+				return doc, fmt.Errorf("%s patch: %w", p.Action, err)
+			}
+		case PatchActionRemovePublicKeys:
+			result.PublicKeys = removePublicKeys(result.PublicKeys, p.IDs)
+		case PatchActionAddServices:
+			if err := result.addServices(p.Services); err != nil {
+				return doc, fmt.Errorf("%s patch: %w", p.Action, err)
+			}
+		case PatchActionRemoveServices:
+			result.Services = removeServices(result.Services, p.IDs)
+		default:
+			return doc, fmt.Errorf("patch action %s is not supported", p.Action)
+		}
+	}
+
+	return result, nil
+}
+
+// addPublicKeys adds the public keys, a key with the same ID is replaced.
+func (d *Document) addPublicKeys(keys []PublicKey) error {
+	for _, key := range keys {
+		if key.ID == "" || key.PublicKeyJWK == nil {
+			return errors.New("public key ID and JWK are required")
+		}
+
+		for _, purpose := range key.Purposes {
+			if _, err := relationship(purpose); err != nil {
+				return err
+			}
+		}
+
+		d.PublicKeys = append(removePublicKeys(d.PublicKeys, []string{key.ID}), key)
+	}
+
+	return nil
+}
+
+// addServices adds the services, a service with the same ID is replaced.
+func (d *Document) addServices(services []Service) error {
+	for _, svc := range services {
+		if svc.ID == "" || svc.Type == "" {
+			return errors.New("service ID and type are required")
+		}
+
+		d.Services = append(removeServices(d.Services, []string{svc.ID}), svc)
+	}
+
+	return nil
+}
+
+func removePublicKeys(keys []PublicKey, ids []string) []PublicKey {
+	var result []PublicKey
+
+	for _, key := range keys {
+		if !contains(ids, key.ID) {
+			result = append(result, key)
+		}
+	}
+
+	return result
+}
+
+func removeServices(services []Service, ids []string) []Service {
+	var result []Service
+
+	for _, svc := range services {
+		if !contains(ids, svc.ID) {
+			result = append(result, svc)
+		}
+	}
+
+	return result
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseDelta(raw json.RawMessage, deltaHash string) (*Delta, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("delta is missing")
+	}
+
+	computed, err := Hash(raw)
+	if err != nil {
+		return nil, fmt.Errorf("hash delta: %w", err)
+	}
+
+	if computed != deltaHash {
+		return nil, errors.New("delta does not match delta hash")
+	}
+
+	delta := &Delta{}
+
+	if err = json.Unmarshal(raw, delta); err != nil {
+		return nil, fmt.Errorf("unmarshal delta: %w", err)
+	}
+
+	return delta, nil
+}
+
+// verifySignedData verifies the compact JWS of the operation with the key revealed by its payload.
+func verifySignedData(jws string, signingKey func(*signedData) *jwk.JWK) (*signedData, error) {
+	parts := strings.Split(jws, ".")
+	if len(parts) != jwsPartsCount {
+		return nil, errors.New("signed data is not a compact JWS")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decode signed data payload: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("decode signed data signature: %w", err)
+	}
+
+	signed := &signedData{}
+
+	if err = json.Unmarshal(payload, signed); err != nil {
+		return nil, fmt.Errorf("unmarshal signed data: %w", err)
+	}
+
+	key := signingKey(signed)
+	if key == nil {
+		return nil, errors.New("signing key is missing in signed data")
+	}
+
+	pubKeyVerifier := verifier.NewCompositePublicKeyVerifier([]verifier.SignatureVerifier{
+		verifier.NewEd25519SignatureVerifier(),
+		verifier.NewECDSAES256SignatureVerifier(),
+		verifier.NewECDSASecp256k1SignatureVerifier(),
+	})
+
+	err = pubKeyVerifier.Verify(&verifier.PublicKey{Type: jsonWebKey2020, JWK: key},
+		[]byte(parts[0]+"."+parts[1]), signature)
+	if err != nil {
+		return nil, fmt.Errorf("verify signed data: %w", err)
+	}
+
+	return signed, nil
+}
+
+// checkCommitment checks that the revealed key is the one committed to.
+func checkCommitment(key *jwk.JWK, commitment, revealValue string) error {
+	computed, err := Commitment(key)
+	if err != nil {
+		return err
+	}
+
+	if commitment == "" || computed != commitment {
+		return errors.New("key does not match the commitment")
+	}
+
+	if revealValue == "" {
+		return errors.New("missing reveal value")
+	}
+
+	reveal, err := RevealValue(key)
+	if err != nil {
+		return err
+	}
+
+	if reveal != revealValue {
+		return errors.New("key does not match the reveal value")
+	}
+
+	return nil
+}
+
+func relationship(purpose string) (did.VerificationRelationship, error) {
+	switch purpose {
+	case PurposeAuthentication:
+		return did.Authentication, nil
+	case PurposeAssertionMethod:
+		return did.AssertionMethod, nil
+	case PurposeCapabilityInvocation:
+		return did.CapabilityInvocation, nil
+	case PurposeCapabilityDelegation:
+		return did.CapabilityDelegation, nil
+	case PurposeKeyAgreement:
+		return did.KeyAgreement, nil
+	default:
+		return 0, fmt.Errorf("public key purpose %s is not supported", purpose)
+	}
+}
+
+// toDocument builds the DID document of the state, IDs of its public keys and services are fragments of the DID.
+func (s *didState) toDocument(didID string) (*did.Doc, error) {
+	doc := &did.Doc{Context: []string{did.ContextV1}, ID: didID}
+
+	for _, key := range s.document.PublicKeys {
+		vm, err := did.NewVerificationMethodFromJWK(didID+"#"+key.ID, key.Type, didID, key.PublicKeyJWK)
+		if err != nil {
+			return nil, fmt.Errorf("public key %s: %w", key.ID, err)
+		}
+
+		doc.VerificationMethod = append(doc.VerificationMethod, *vm)
+
+		for _, purpose := range key.Purposes {
+			r, err := relationship(purpose)
+			if err != nil {
+				return nil, err
+			}
+
+			v := *did.NewReferencedVerification(vm, r)
+
+			switch r { //nolint:exhaustive
+			case did.Authentication:
+				doc.Authentication = append(doc.Authentication, v)
+			case did.AssertionMethod:
+				doc.AssertionMethod = append(doc.AssertionMethod, v)
+			case did.CapabilityInvocation:
+				doc.CapabilityInvocation = append(doc.CapabilityInvocation, v)
+			case did.CapabilityDelegation:
+				doc.CapabilityDelegation = append(doc.CapabilityDelegation, v)
+			case did.KeyAgreement:
+				doc.KeyAgreement = append(doc.KeyAgreement, v)
+			}
+		}
+	}
+
+	for _, svc := range s.document.Services {
+		doc.Service = append(doc.Service, did.Service{
+			ID:              didID + "#" + svc.ID,
+			Type:            svc.Type,
+			ServiceEndpoint: svc.ServiceEndpoint,
+		})
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"fmt"
+	"strings"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// OperationStore provides anchored operations of published DIDs, e.g. from a Sidetree node or a local cache of
+// anchored batches.
+type OperationStore interface {
+	// Get returns the operations of the DID with the unique suffix in the order they were anchored,
+	// starting with the create operation. vdrapi.ErrNotFound is returned if the DID is not published.
+	Get(suffix string) ([]*Operation, error)
+}
+
+// VDR implements Sidetree based DID methods, e.g. did:ion. Long-form DIDs are created and resolved locally,
+// short-form DIDs are resolved from the operations of the operation store.
+type VDR struct {
+	namespace      string
+	keyManager     kms.KeyManager
+	operationStore OperationStore
+}
+
+// Option configures the Sidetree VDR.
+type Option func(opts *VDR)
+
+// WithKeyManager sets the key manager update, recovery and document keys are generated with
+// when not given by the create options.
+func WithKeyManager(km kms.KeyManager) Option {
+	return func(opts *VDR) {
+		opts.keyManager = km
+	}
+}
+
+// WithOperationStore sets the store short-form DIDs are resolved from. Without the operation store
+// only long-form DIDs can be resolved.
+func WithOperationStore(s OperationStore) Option {
+	return func(opts *VDR) {
+		opts.operationStore = s
+	}
+}
+
+// New creates a new VDR of the DID method namespace, e.g. "ion" or "ion:test" for did:ion:test DIDs.
+func New(namespace string, opts ...Option) *VDR {
+	v := &VDR{namespace: namespace}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Accept method of the VDR interface.
+func (v *VDR) Accept(method string) bool {
+	return method == strings.SplitN(v.namespace, ":", 2)[0]
+}
+
+// Update did doc. Sidetree operations are anchored by a node, the VDR does not submit them.
+func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Deactivate did doc. Sidetree operations are anchored by a node, the VDR does not submit them.
+func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Close method of the VDR interface.
+func (v *VDR) Close() error {
+	return nil
+}
+
+func (v *VDR) didPrefix() string {
+	return "did:" + v.namespace + ":"
+}
+
+func applyOptions(opts ...vdrapi.DIDMethodOption) *vdrapi.DIDMethodOpts {
+	didOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+
+	for _, opt := range opts {
+		opt(didOpts)
+	}
+
+	return didOpts
+}
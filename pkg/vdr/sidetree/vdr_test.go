@@ -0,0 +1,24 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVDRMethods(t *testing.T) {
+	t.Run("test base vdr methods", func(t *testing.T) {
+		v := New("ion:test")
+		require.True(t, v.Accept("ion"))
+		require.False(t, v.Accept("web"))
+		require.EqualError(t, v.Update(nil), "not supported")
+		require.EqualError(t, v.Deactivate("did:ion:test:123"), "not supported")
+		require.NoError(t, v.Close())
+	})
+}
@@ -7,13 +7,189 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
-// Create creates a did:web diddoc (unsupported at the moment).
+const (
+	// DomainOpt is the domain (and optional port) of the created DID, used when the DID document has no ID.
+	DomainOpt = "domain"
+	// PathOpt is the optional path of the created DID, e.g. "issuers/1" for did:web:example.com:issuers:1.
+	PathOpt = "path"
+	// KeyTypeOpt is the KMS key type of generated keys, ED25519 by default.
+	KeyTypeOpt = "keyType"
+	// RotateKeyOpt is the ID of the verification method which key is replaced by a new one on update.
+	RotateKeyOpt = "rotateKey"
+
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	bls12381G2Key2020          = "Bls12381G2Key2020"
+	jsonWebKey2020             = "JsonWebKey2020"
+)
+
+// Create creates a did:web DID document and hosts it. The DID is taken from the document ID or built from
+// DomainOpt and PathOpt. When the document has no verification methods, a key is generated through the KMS
+// and used for authentication and assertion.
 func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
-	return nil, fmt.Errorf("error building did:web did doc --> build not supported in http binding vdr")
+	if err := v.hostingStore(); err != nil {
+		return nil, err
+	}
+
+	didOpts := applyOptions(opts...)
+
+	if didDoc == nil {
+		didDoc = &did.Doc{}
+	}
+
+	if didDoc.ID == "" {
+		id, err := buildDID(didOpts)
+		if err != nil {
+			return nil, fmt.Errorf("create did:web document: %w", err)
+		}
+
+		didDoc.ID = id
+	}
+
+	if !strings.HasPrefix(didDoc.ID, "did:"+namespace+":") {
+		return nil, fmt.Errorf("create did:web document: %s is not a did:web DID", didDoc.ID)
+	}
+
+	if _, err := splitDIDWeb(didDoc.ID); err != nil {
+		return nil, fmt.Errorf("create did:web document: %w", err)
+	}
+
+	if _, err := v.getRecord(didDoc.ID); err == nil {
+		return nil, fmt.Errorf("create did:web document: %s already exists", didDoc.ID)
+	}
+
+	if didDoc.Context == nil {
+		didDoc.Context = []string{did.ContextV1}
+	}
+
+	if len(didDoc.VerificationMethod) == 0 {
+		vm, err := v.createVerificationMethod(didDoc.ID, didOpts)
+		if err != nil {
+			return nil, fmt.Errorf("create did:web document: %w", err)
+		}
+
+		didDoc.VerificationMethod = []did.VerificationMethod{*vm}
+		didDoc.Authentication = append(didDoc.Authentication, *did.NewReferencedVerification(vm, did.Authentication))
+		didDoc.AssertionMethod = append(didDoc.AssertionMethod,
+			*did.NewReferencedVerification(vm, did.AssertionMethod))
+	}
+
+	if err := v.publish(didDoc, 1, false); err != nil {
+		return nil, err
+	}
+
+	return &did.DocResolution{
+		DIDDocument:      didDoc,
+		DocumentMetadata: &did.DocumentMetadata{VersionID: "1"},
+	}, nil
+}
+
+// buildDID builds did:web DID of the domain and the path.
+func buildDID(didOpts *vdrapi.DIDMethodOpts) (string, error) {
+	domain, ok := didOpts.Values[DomainOpt].(string)
+	if !ok || domain == "" {
+		return "", errors.New("DID document ID or domain option is required")
+	}
+
+	id := "did:" + namespace + ":" + url.QueryEscape(domain)
+
+	if path, ok := didOpts.Values[PathOpt].(string); ok && strings.Trim(path, "/:") != "" {
+		id += ":" + strings.ReplaceAll(strings.Trim(path, "/:"), "/", ":")
+	}
+
+	if _, err := splitDIDWeb(id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (v *VDR) createVerificationMethod(didID string, didOpts *vdrapi.DIDMethodOpts) (*did.VerificationMethod, error) {
+	if v.keyManager == nil {
+		return nil, errors.New("key manager is required to generate keys")
+	}
+
+	keyType := kms.ED25519Type
+
+	switch kt := didOpts.Values[KeyTypeOpt].(type) {
+	case kms.KeyType:
+		keyType = kt
+	case string:
+		keyType = kms.KeyType(kt)
+	}
+
+	kid, pubKey, err := v.keyManager.CreateAndExportPubKeyBytes(keyType)
+	if err != nil {
+		return nil, fmt.Errorf("create key: %w", err)
+	}
+
+	vmID := didID + "#" + kid
+
+	switch keyType { //nolint:exhaustive
+	case kms.ED25519Type:
+		return did.NewVerificationMethodFromBytes(vmID, ed25519VerificationKey2018, didID, pubKey), nil
+	case kms.BLS12381G2Type:
+		return did.NewVerificationMethodFromBytes(vmID, bls12381G2Key2020, didID, pubKey), nil
+	default:
+		j, err := jwksupport.PubKeyBytesToJWK(pubKey, keyType)
+		if err != nil {
+			return nil, fmt.Errorf("convert public key to JWK: %w", err)
+		}
+
+		return did.NewVerificationMethodFromJWK(vmID, jsonWebKey2020, didID, j)
+	}
+}
+
+// rotateKey replaces the verification method by the one with a newly generated key,
+// verification relationships are updated to the new verification method.
+func (v *VDR) rotateKey(didDoc *did.Doc, vmID string, didOpts *vdrapi.DIDMethodOpts) error {
+	if strings.HasPrefix(vmID, "#") {
+		vmID = didDoc.ID + vmID
+	}
+
+	relationships := [][]did.Verification{
+		didDoc.Authentication, didDoc.AssertionMethod, didDoc.CapabilityDelegation,
+		didDoc.CapabilityInvocation, didDoc.KeyAgreement,
+	}
+
+	var rotated []*did.VerificationMethod
+
+	for i := range didDoc.VerificationMethod {
+		if didDoc.VerificationMethod[i].ID == vmID {
+			rotated = append(rotated, &didDoc.VerificationMethod[i])
+		}
+	}
+
+	for _, verifications := range relationships {
+		for i := range verifications {
+			if verifications[i].VerificationMethod.ID == vmID {
+				rotated = append(rotated, &verifications[i].VerificationMethod)
+			}
+		}
+	}
+
+	if len(rotated) == 0 {
+		return fmt.Errorf("rotate key: verification method %s not found", vmID)
+	}
+
+	vm, err := v.createVerificationMethod(didDoc.ID, didOpts)
+	if err != nil {
+		return fmt.Errorf("rotate key %s: %w", vmID, err)
+	}
+
+	for _, r := range rotated {
+		*r = *vm
+	}
+
+	return nil
 }
@@ -7,17 +7,199 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockprotocol "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 func TestCreateDID(t *testing.T) {
-	t.Run("test create did failure", func(t *testing.T) {
+	t.Run("test create did without storage provider", func(t *testing.T) {
 		v := New()
-		d, err := v.Create(nil, nil)
+		d, err := v.Create(nil)
 		require.Nil(t, d)
+		require.EqualError(t, err, "did:web document management requires a storage provider")
+	})
+
+	t.Run("test create did with generated key", func(t *testing.T) {
+		dir := t.TempDir()
+		store := mem.NewProvider()
+		v := New(WithStorageProvider(store), WithKeyManager(newKMS(t, store)), WithDocumentDir(dir))
+
+		docResolution, err := v.Create(nil, vdrapi.WithOption(DomainOpt, "example.com:8080"))
+		require.NoError(t, err)
+		require.Equal(t, "1", docResolution.DocumentMetadata.VersionID)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, "did:web:example.com%3A8080", doc.ID)
+		require.Equal(t, []string{did.ContextV1}, doc.Context)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, ed25519VerificationKey2018, doc.VerificationMethod[0].Type)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Equal(t, doc.VerificationMethod[0].ID, doc.Authentication[0].VerificationMethod.ID)
+		require.NotNil(t, doc.Created)
+
+		hosted, err := did.ParseDocument(readFile(t, filepath.Join(dir, ".well-known", "did.json")))
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, hosted.ID)
+		require.Equal(t, doc.VerificationMethod[0].Value, hosted.VerificationMethod[0].Value)
+
+		_, err = v.Create(&did.Doc{ID: doc.ID})
+		require.EqualError(t, err, "create did:web document: did:web:example.com%3A8080 already exists")
+	})
+
+	t.Run("test create path-based did with JWK", func(t *testing.T) {
+		dir := t.TempDir()
+		store := mem.NewProvider()
+		v := New(WithStorageProvider(store), WithKeyManager(newKMS(t, store)), WithDocumentDir(dir))
+
+		docResolution, err := v.Create(nil, vdrapi.WithOption(DomainOpt, "example.com"),
+			vdrapi.WithOption(PathOpt, "/issuers/1/"), vdrapi.WithOption(KeyTypeOpt, string(kms.ECDSAP256TypeIEEEP1363)))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, "did:web:example.com:issuers:1", doc.ID)
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+		require.NotNil(t, doc.VerificationMethod[0].JSONWebKey())
+		require.FileExists(t, filepath.Join(dir, "issuers", "1", "did.json"))
+
+		docResolution, err = v.Create(nil, vdrapi.WithOption(DomainOpt, "example.com"),
+			vdrapi.WithOption(PathOpt, "issuers:2"), vdrapi.WithOption(KeyTypeOpt, kms.BLS12381G2Type))
+		require.NoError(t, err)
+		require.Equal(t, "did:web:example.com:issuers:2", docResolution.DIDDocument.ID)
+		require.Equal(t, bls12381G2Key2020, docResolution.DIDDocument.VerificationMethod[0].Type)
+	})
+
+	t.Run("test create did with given verification method", func(t *testing.T) {
+		v := New(WithStorageProvider(mem.NewProvider()))
+
+		vm := did.NewVerificationMethodFromBytes("did:web:example.com#key-1", ed25519VerificationKey2018,
+			"did:web:example.com", []byte("key"))
+
+		docResolution, err := v.Create(&did.Doc{ID: "did:web:example.com", VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+		require.Equal(t, []did.VerificationMethod{*vm}, docResolution.DIDDocument.VerificationMethod)
+	})
+
+	t.Run("test create did failures", func(t *testing.T) {
+		v := New(WithStorageProvider(mem.NewProvider()))
+
+		_, err := v.Create(&did.Doc{})
+		require.EqualError(t, err, "create did:web document: DID document ID or domain option is required")
+
+		_, err = v.Create(&did.Doc{ID: "did:example:123"})
+		require.EqualError(t, err, "create did:web document: did:example:123 is not a did:web DID")
+
+		_, err = v.Create(&did.Doc{ID: "did:web:example.com"})
+		require.EqualError(t, err, "create did:web document: key manager is required to generate keys")
+
+		v = New(WithStorageProvider(mem.NewProvider()), WithKeyManager(&mockkms.KeyManager{
+			CrAndExportPubKeyErr: errors.New("kms error"),
+		}))
+
+		_, err = v.Create(&did.Doc{ID: "did:web:example.com"})
+		require.EqualError(t, err, "create did:web document: create key: kms error")
+
+		v = New(WithStorageProvider(mem.NewProvider()), WithKeyManager(&mockkms.KeyManager{
+			CrAndExportPubKeyValue: []byte("invalid"),
+		}))
+
+		_, err = v.Create(&did.Doc{ID: "did:web:example.com"}, vdrapi.WithOption(KeyTypeOpt, kms.ECDSAP256TypeDER))
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "build not supported in http binding vdr")
+		require.Contains(t, err.Error(), "convert public key to JWK")
+
+		provider := mockstorage.NewMockStoreProvider()
+		provider.FailNamespace = StoreName
+
+		_, err = New(WithStorageProvider(provider)).Create(&did.Doc{ID: "did:web:example.com"})
+		require.EqualError(t, err, "open store: failed to open store for name space didweb")
+
+		provider = mockstorage.NewMockStoreProvider()
+		provider.Store.ErrBatch = errors.New("batch error")
+
+		_, err = New(WithStorageProvider(provider), WithKeyManager(newKMS(t, mem.NewProvider()))).
+			Create(&did.Doc{ID: "did:web:example.com"})
+		require.EqualError(t, err, "publish did:web document: store: batch error")
+
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, ioutil.WriteFile(file, []byte{}, 0o600))
+
+		_, err = New(WithStorageProvider(mem.NewProvider()), WithKeyManager(newKMS(t, mem.NewProvider())),
+			WithDocumentDir(file)).Create(&did.Doc{ID: "did:web:example.com"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "write did:web document")
+	})
+
+	t.Run("test create did with path escaping the document directory", func(t *testing.T) {
+		dir := t.TempDir()
+		v := New(WithStorageProvider(mem.NewProvider()), WithKeyManager(newKMS(t, mem.NewProvider())),
+			WithDocumentDir(filepath.Join(dir, "docs")))
+
+		for _, path := range []string{"../outside", "issuers/./1", "issuers//1", "issuers/.."} {
+			_, err := v.Create(nil, vdrapi.WithOption(DomainOpt, "example.com"), vdrapi.WithOption(PathOpt, path))
+			require.Error(t, err, path)
+			require.Contains(t, err.Error(), "invalid did:web", path)
+		}
+
+		_, err := v.Create(nil, vdrapi.WithOption(DomainOpt, "example.com"), vdrapi.WithOption(PathOpt, `..\outside`))
+		require.Error(t, err)
+
+		for _, id := range []string{"did:web:example.com:..:outside", "did:web:example.com:issuers::1"} {
+			_, err := v.Create(&did.Doc{ID: id})
+			require.Error(t, err, id)
+			require.Contains(t, err.Error(), "invalid did:web path component", id)
+		}
+
+		_, err = v.Create(&did.Doc{ID: "did:web:example.com%2F..%2F..:issuers:1"})
+		require.EqualError(t, err, `create did:web document: invalid did:web domain "example.com/../.."`)
+
+		require.False(t, fileExists(filepath.Join(dir, "outside", "did.json")))
+
+		_, err = v.documentFile("/../outside/did.json")
+		require.EqualError(t, err, "document path /../outside/did.json is outside of the document directory")
 	})
 }
+
+func newKMS(t *testing.T, store spi.Provider) kms.KeyManager {
+	t.Helper()
+
+	kmsProv := &mockprotocol.MockProvider{
+		StoreProvider: store,
+		CustomLock:    &noop.NoLock{},
+	}
+
+	customKMS, err := localkms.New("local-lock://primary/test/", kmsProv)
+	require.NoError(t, err)
+
+	return customKMS
+}
+
+func readFile(t *testing.T, file string) []byte {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(filepath.Clean(file))
+	require.NoError(t, err)
+
+	return data
+}
+
+func fileExists(file string) bool {
+	_, err := os.Stat(file)
+
+	return err == nil
+}
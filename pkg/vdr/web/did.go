@@ -23,31 +23,75 @@ const (
 func parseDIDWeb(id string, useHTTP bool) (string, string, error) {
 	var address, host string
 
+	pathComponents, err := splitDIDWeb(id)
+	if err != nil {
+		return address, host, err
+	}
+
+	host = strings.Split(pathComponents[0], ":")[0]
+
+	protocol := "https://"
+	if useHTTP {
+		protocol = "http://"
+	}
+
+	address = protocol + pathComponents[0] + componentsPath(pathComponents)
+
+	return address, host, nil
+}
+
+// didDocumentLocation returns the host (domain and optional port, in lower case) and the URL path the did:web
+// DID document is hosted at.
+func didDocumentLocation(id string) (string, string, error) {
+	pathComponents, err := splitDIDWeb(id)
+	if err != nil {
+		return "", "", err
+	}
+
+	return strings.ToLower(pathComponents[0]), componentsPath(pathComponents), nil
+}
+
+// splitDIDWeb returns the domain followed by the path components of the did:web DID.
+func splitDIDWeb(id string) ([]string, error) {
 	parsedDID, err := did.Parse(id)
 	if err != nil {
-		return address, host, fmt.Errorf("invalid did, does not conform to generic did standard --> %w", err)
+		return nil, fmt.Errorf("invalid did, does not conform to generic did standard --> %w", err)
 	}
 
 	pathComponents := strings.Split(parsedDID.MethodSpecificID, ":")
 
 	pathComponents[0], err = url.QueryUnescape(pathComponents[0])
 	if err != nil {
-		return address, host, fmt.Errorf("error parsing did:web did")
+		return nil, fmt.Errorf("error parsing did:web did")
 	}
 
-	host = strings.Split(pathComponents[0], ":")[0]
+	if err = checkComponents(pathComponents); err != nil {
+		return nil, err
+	}
 
-	protocol := "https://"
-	if useHTTP {
-		protocol = "http://"
+	return pathComponents, nil
+}
+
+// checkComponents rejects domain and path components which would not map onto a single URL path segment,
+// e.g. ".." escaping the location of the DID document.
+func checkComponents(pathComponents []string) error {
+	if pathComponents[0] == "" || strings.ContainsAny(pathComponents[0], `/\`) {
+		return fmt.Errorf("invalid did:web domain %q", pathComponents[0])
 	}
 
-	switch len(pathComponents) {
-	case 1:
-		address = protocol + pathComponents[0] + defaultPath
-	default:
-		address = protocol + strings.Join(pathComponents, "/") + documentPath
+	for _, component := range pathComponents[1:] {
+		if component == "" || component == "." || component == ".." || strings.ContainsAny(component, `/\`) {
+			return fmt.Errorf("invalid did:web path component %q", component)
+		}
 	}
 
-	return address, host, nil
+	return nil
+}
+
+func componentsPath(pathComponents []string) string {
+	if len(pathComponents) == 1 {
+		return defaultPath
+	}
+
+	return "/" + strings.Join(pathComponents[1:], "/") + documentPath
 }
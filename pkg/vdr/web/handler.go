@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const didJSONContentType = "application/did+json"
+
+// Handler serves DID documents hosted by the did:web VDR sharing the same storage provider.
+type Handler struct {
+	store storage.Store
+}
+
+// NewHandler creates an HTTP handler of did:web documents kept in the given storage provider.
+// It serves GET requests of /.well-known/did.json and <path>/did.json of the DIDs of the request host.
+func NewHandler(p storage.Provider) (*Handler, error) {
+	s, err := p.OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	return &Handler{store: s}, nil
+}
+
+// ServeHTTP writes the DID document hosted at the request host and path.
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !strings.HasSuffix(req.URL.Path, documentPath) {
+		rw.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	rec, err := getRecord(h.store, recordKey(req.Host, req.URL.Path))
+	if err != nil {
+		if errors.Is(err, vdrapi.ErrNotFound) {
+			rw.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		logger.Errorf("serve did:web document %s: %s", req.URL.Path, err)
+		rw.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	if rec.Deactivated {
+		rw.WriteHeader(http.StatusGone)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", didJSONContentType)
+
+	if _, err = rw.Write(rec.Document); err != nil {
+		logger.Errorf("serve did:web document %s: %s", req.URL.Path, err)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestHandler(t *testing.T) {
+	store := mem.NewProvider()
+	v := New(WithStorageProvider(store), WithKeyManager(newKMS(t, store)))
+
+	docResolution, err := v.Create(nil, vdrapi.WithOption(DomainOpt, "example.com"),
+		vdrapi.WithOption(PathOpt, "issuers/1"))
+	require.NoError(t, err)
+
+	handler, err := NewHandler(store)
+	require.NoError(t, err)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(method, path, nil))
+
+		return rw
+	}
+
+	t.Run("test serve hosted document", func(t *testing.T) {
+		rw := serve(http.MethodGet, "/issuers/1/did.json")
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, didJSONContentType, rw.Header().Get("Content-Type"))
+
+		doc, err := did.ParseDocument(rw.Body.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, docResolution.DIDDocument.ID, doc.ID)
+
+		require.Equal(t, http.StatusOK, serve(http.MethodGet, "https://EXAMPLE.com/issuers/1/did.json").Code)
+	})
+
+	t.Run("test serve document of the request host", func(t *testing.T) {
+		other, err := v.Create(nil, vdrapi.WithOption(DomainOpt, "another.com:8080"),
+			vdrapi.WithOption(PathOpt, "issuers/1"))
+		require.NoError(t, err)
+
+		rw := serve(http.MethodGet, "https://another.com:8080/issuers/1/did.json")
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		doc, err := did.ParseDocument(rw.Body.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, other.DIDDocument.ID, doc.ID)
+
+		rw = serve(http.MethodGet, "/issuers/1/did.json")
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		doc, err = did.ParseDocument(rw.Body.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, docResolution.DIDDocument.ID, doc.ID)
+	})
+
+	t.Run("test not found", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, serve(http.MethodGet, "https://another.com/issuers/1/did.json").Code)
+		require.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/.well-known/did.json").Code)
+		require.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/issuers/1").Code)
+		require.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPost, "/issuers/1/did.json").Code)
+	})
+
+	t.Run("test deactivated document", func(t *testing.T) {
+		require.NoError(t, v.Deactivate(docResolution.DIDDocument.ID))
+		require.Equal(t, http.StatusGone, serve(http.MethodGet, "/issuers/1/did.json").Code)
+	})
+
+	t.Run("test store errors", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+		provider.FailNamespace = StoreName
+
+		_, err := NewHandler(provider)
+		require.EqualError(t, err, "open store: failed to open store for name space didweb")
+
+		provider = mockstorage.NewMockStoreProvider()
+		provider.Store.ErrGet = errors.New("get error")
+
+		h, err := NewHandler(provider)
+		require.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/.well-known/did.json", nil))
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+	})
+}
@@ -7,10 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
@@ -27,15 +29,12 @@ const (
 
 var logger = log.New("aries-framework/pkg/vdr/web")
 
-// Read resolves a did:web did.
+// Read resolves a did:web did. DID documents hosted by this VDR are read from the store,
+// which also keeps their previous versions.
 func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
 	httpClient := &http.Client{}
 
-	didOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
-	// Apply options
-	for _, opt := range opts {
-		opt(didOpts)
-	}
+	didOpts := applyOptions(opts...)
 
 	k, ok := didOpts.Values[HTTPClientOpt]
 	if ok {
@@ -58,6 +57,13 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 		return nil, fmt.Errorf("error resolving did:web did --> could not parse did:web did --> %w", err)
 	}
 
+	if v.storageProvider != nil {
+		docResolution, e := v.readHosted(didID, didOpts)
+		if !errors.Is(e, vdrapi.ErrNotFound) {
+			return docResolution, e
+		}
+	}
+
 	resp, err := httpClient.Get(address)
 	if err != nil {
 		return nil, fmt.Errorf("error resolving did:web did --> http request unsuccessful --> %w", err)
@@ -82,6 +88,40 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 	return &did.DocResolution{DIDDocument: doc}, nil
 }
 
+func (v *VDR) readHosted(didID string, didOpts *vdrapi.DIDMethodOpts) (*did.DocResolution, error) {
+	var rec *docRecord
+
+	err := v.hostingStore()
+	if err != nil {
+		return nil, err
+	}
+
+	if versionID, ok := didOpts.Values[vdrapi.VersionIDOpt].(string); ok {
+		rec, err = v.getVersion(didID, versionID)
+	} else {
+		rec, err = v.getRecord(didID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := did.ParseDocument(rec.Document)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:web did --> error parsing did doc --> %w", err)
+	}
+
+	return &did.DocResolution{
+		DIDDocument: doc,
+		DocumentMetadata: &did.DocumentMetadata{
+			VersionID:   strconv.Itoa(rec.VersionID),
+			Created:     &rec.Created,
+			Updated:     &rec.Updated,
+			Deactivated: rec.Deactivated,
+		},
+	}, nil
+}
+
 func closeResponseBody(respBody io.Closer) {
 	e := respBody.Close()
 	if e != nil {
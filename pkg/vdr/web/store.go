@@ -0,0 +1,215 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	documentDirPerm  = 0o755
+	documentFilePerm = 0o644
+)
+
+// docRecord is a version of the hosted DID document. The latest version is stored by the record key (the host
+// followed by the document path), previous versions by the record key followed by the version ID query.
+type docRecord struct {
+	DID         string          `json:"did"`
+	VersionID   int             `json:"versionId"`
+	Document    json.RawMessage `json:"document"`
+	Created     time.Time       `json:"created"`
+	Updated     time.Time       `json:"updated"`
+	Deactivated bool            `json:"deactivated,omitempty"`
+}
+
+func recordKey(host, path string) string {
+	return strings.ToLower(host) + path
+}
+
+func versionKey(key string, versionID int) string {
+	return key + "?versionId=" + strconv.Itoa(versionID)
+}
+
+// publish stores the new version of the DID document and writes it into the document directory.
+func (v *VDR) publish(doc *did.Doc, versionID int, deactivated bool) error {
+	host, path, err := didDocumentLocation(doc.ID)
+	if err != nil {
+		return fmt.Errorf("publish did:web document: %w", err)
+	}
+
+	key := recordKey(host, path)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	if doc.Created == nil {
+		doc.Created = &now
+	}
+
+	doc.Updated = &now
+
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("publish did:web document: %w", err)
+	}
+
+	rec := &docRecord{
+		DID:         doc.ID,
+		VersionID:   versionID,
+		Document:    docBytes,
+		Created:     *doc.Created,
+		Updated:     now,
+		Deactivated: deactivated,
+	}
+
+	recBytes, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("publish did:web document: %w", err)
+	}
+
+	err = v.store.Batch([]storage.Operation{
+		{Key: versionKey(key, versionID), Value: recBytes},
+		{Key: key, Value: recBytes},
+	})
+	if err != nil {
+		return fmt.Errorf("publish did:web document: store: %w", err)
+	}
+
+	if deactivated {
+		return v.removeDocumentFile(path)
+	}
+
+	return v.writeDocumentFile(path, docBytes)
+}
+
+// getRecord returns the latest version of the hosted DID document.
+func (v *VDR) getRecord(didID string) (*docRecord, error) {
+	host, path, err := didDocumentLocation(didID)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := getRecord(v.store, recordKey(host, path))
+	if err != nil {
+		return nil, err
+	}
+
+	// the letter case of the domain is not part of the record key
+	if rec.DID != didID {
+		return nil, vdrapi.ErrNotFound
+	}
+
+	return rec, nil
+}
+
+// getVersion returns the given version of the hosted DID document.
+func (v *VDR) getVersion(didID, versionID string) (*docRecord, error) {
+	host, path, err := didDocumentLocation(didID)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := strconv.Atoi(versionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version ID %s: %w", versionID, vdrapi.ErrNotFound)
+	}
+
+	rec, err := getRecord(v.store, versionKey(recordKey(host, path), n))
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.DID != didID {
+		return nil, vdrapi.ErrNotFound
+	}
+
+	return rec, nil
+}
+
+func getRecord(store storage.Store, key string) (*docRecord, error) {
+	recBytes, err := store.Get(key)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, vdrapi.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("get did:web document: %w", err)
+	}
+
+	rec := &docRecord{}
+
+	if err = json.Unmarshal(recBytes, rec); err != nil {
+		return nil, fmt.Errorf("unmarshal did:web document record: %w", err)
+	}
+
+	return rec, nil
+}
+
+func (v *VDR) writeDocumentFile(path string, docBytes []byte) error {
+	if v.documentDir == "" {
+		return nil
+	}
+
+	file, err := v.documentFile(path)
+	if err != nil {
+		return fmt.Errorf("write did:web document: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), documentDirPerm); err != nil {
+		return fmt.Errorf("write did:web document: %w", err)
+	}
+
+	// DID documents are public
+	if err := ioutil.WriteFile(file, docBytes, documentFilePerm); err != nil { //nolint:gosec
+		return fmt.Errorf("write did:web document: %w", err)
+	}
+
+	return nil
+}
+
+func (v *VDR) removeDocumentFile(path string) error {
+	if v.documentDir == "" {
+		return nil
+	}
+
+	file, err := v.documentFile(path)
+	if err != nil {
+		return fmt.Errorf("remove did:web document: %w", err)
+	}
+
+	err = os.Remove(file)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove did:web document: %w", err)
+	}
+
+	return nil
+}
+
+// documentFile returns the file the DID document of the URL path is written to, it has to be inside
+// of the document directory.
+func (v *VDR) documentFile(path string) (string, error) {
+	dir := filepath.Clean(v.documentDir)
+	file := filepath.Join(dir, filepath.FromSlash(path))
+
+	rel, err := filepath.Rel(dir, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("document path %s is outside of the document directory", path)
+	}
+
+	return file, nil
+}
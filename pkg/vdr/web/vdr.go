@@ -7,22 +7,88 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
 	namespace = "web"
+
+	// StoreName is the name of the store hosted did:web documents are kept in.
+	StoreName = "didweb"
 )
 
+var errManagementNotConfigured = errors.New("did:web document management requires a storage provider")
+
 // VDR implements the VDR interface.
-type VDR struct{}
+type VDR struct {
+	keyManager      kms.KeyManager
+	storageProvider storage.Provider
+	documentDir     string
+
+	openStore sync.Once
+	store     storage.Store
+	storeErr  error
+}
+
+// Option configures the did:web VDR.
+type Option func(opts *VDR)
+
+// WithKeyManager sets the key manager keys of created DID documents are generated with.
+func WithKeyManager(km kms.KeyManager) Option {
+	return func(opts *VDR) {
+		opts.keyManager = km
+	}
+}
+
+// WithStorageProvider sets the storage provider hosted DID documents and their versions are kept in.
+// DID documents can be created, updated and deactivated only if the storage provider is set.
+func WithStorageProvider(p storage.Provider) Option {
+	return func(opts *VDR) {
+		opts.storageProvider = p
+	}
+}
+
+// WithDocumentDir sets the directory hosted DID documents are written to. The directory is the web root
+// of the did:web domain, e.g. did:web:example.com:issuers:1 is written to <dir>/issuers/1/did.json.
+func WithDocumentDir(dir string) Option {
+	return func(opts *VDR) {
+		opts.documentDir = dir
+	}
+}
 
 // New creates a new VDR struct.
-func New() *VDR {
-	return &VDR{}
+func New(opts ...Option) *VDR {
+	v := &VDR{}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// hostingStore opens the store of hosted DID documents.
+func (v *VDR) hostingStore() error {
+	if v.storageProvider == nil {
+		return errManagementNotConfigured
+	}
+
+	v.openStore.Do(func() {
+		v.store, v.storeErr = v.storageProvider.OpenStore(StoreName)
+	})
+
+	if v.storeErr != nil {
+		return fmt.Errorf("open store: %w", v.storeErr)
+	}
+
+	return nil
 }
 
 // Accept method of the VDR interface.
@@ -30,17 +96,74 @@ func (v *VDR) Accept(method string) bool {
 	return method == namespace
 }
 
-// Update did doc.
+// Update did doc. The key of the verification method given by RotateKeyOpt is replaced by a new one.
 func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	if err := v.hostingStore(); err != nil {
+		return err
+	}
+
+	didOpts := applyOptions(opts...)
+
+	rec, err := v.getRecord(didDoc.ID)
+	if err != nil {
+		return fmt.Errorf("update did:web document: %w", err)
+	}
+
+	if rec.Deactivated {
+		return fmt.Errorf("update did:web document: %s is deactivated", didDoc.ID)
+	}
+
+	if didDoc.Context == nil {
+		didDoc.Context = []string{diddoc.ContextV1}
+	}
+
+	if vmID, ok := didOpts.Values[RotateKeyOpt].(string); ok {
+		err = v.rotateKey(didDoc, vmID, didOpts)
+		if err != nil {
+			return fmt.Errorf("update did:web document: %w", err)
+		}
+	}
+
+	created := rec.Created
+	didDoc.Created = &created
+
+	return v.publish(didDoc, rec.VersionID+1, false)
 }
 
-// Deactivate did doc.
+// Deactivate did doc. The document is removed from the hosting directory, but its versions are kept.
 func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	if err := v.hostingStore(); err != nil {
+		return err
+	}
+
+	rec, err := v.getRecord(did)
+	if err != nil {
+		return fmt.Errorf("deactivate did:web document: %w", err)
+	}
+
+	if rec.Deactivated {
+		return nil
+	}
+
+	doc, err := diddoc.ParseDocument(rec.Document)
+	if err != nil {
+		return fmt.Errorf("deactivate did:web document: %w", err)
+	}
+
+	return v.publish(doc, rec.VersionID+1, true)
 }
 
 // Close method of the VDR interface.
 func (v *VDR) Close() error {
 	return nil
 }
+
+func applyOptions(opts ...vdrapi.DIDMethodOption) *vdrapi.DIDMethodOpts {
+	didOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+
+	for _, opt := range opts {
+		opt(didOpts)
+	}
+
+	return didOpts
+}
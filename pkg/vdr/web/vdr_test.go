@@ -7,9 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 func TestVDRMethods(t *testing.T) {
@@ -23,19 +28,111 @@ func TestVDRMethods(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	t.Run("test update", func(t *testing.T) {
+	t.Run("test update without storage provider", func(t *testing.T) {
 		v := New()
 		err := v.Update(nil)
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "not supported")
+		require.EqualError(t, err, "did:web document management requires a storage provider")
+	})
+
+	t.Run("test update and rotate key", func(t *testing.T) {
+		dir := t.TempDir()
+		store := mem.NewProvider()
+		v := New(WithStorageProvider(store), WithKeyManager(newKMS(t, store)), WithDocumentDir(dir))
+
+		docResolution, err := v.Create(nil, vdrapi.WithOption(DomainOpt, "example.com"),
+			vdrapi.WithOption(PathOpt, "issuer"))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		oldVM := doc.VerificationMethod[0]
+
+		doc.Service = []did.Service{{ID: doc.ID + "#svc", Type: "LinkedDomains", ServiceEndpoint: "https://example.com"}}
+
+		require.NoError(t, v.Update(doc, vdrapi.WithOption(RotateKeyOpt, "#"+oldVM.ID[len(doc.ID)+1:])))
+
+		current, err := v.Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, "2", current.DocumentMetadata.VersionID)
+		require.Len(t, current.DIDDocument.Service, 1)
+		require.Len(t, current.DIDDocument.VerificationMethod, 1)
+
+		newVM := current.DIDDocument.VerificationMethod[0]
+		require.NotEqual(t, oldVM.ID, newVM.ID)
+		require.NotEqual(t, oldVM.Value, newVM.Value)
+		require.Equal(t, newVM.ID, current.DIDDocument.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, newVM.ID, current.DIDDocument.AssertionMethod[0].VerificationMethod.ID)
+
+		hosted, err := did.ParseDocument(readFile(t, filepath.Join(dir, "issuer", "did.json")))
+		require.NoError(t, err)
+		require.Equal(t, newVM.ID, hosted.VerificationMethod[0].ID)
+
+		previous, err := v.Read(doc.ID, vdrapi.WithOption(vdrapi.VersionIDOpt, "1"))
+		require.NoError(t, err)
+		require.Equal(t, "1", previous.DocumentMetadata.VersionID)
+		require.Equal(t, oldVM.ID, previous.DIDDocument.VerificationMethod[0].ID)
+		require.Empty(t, previous.DIDDocument.Service)
+	})
+
+	t.Run("test update failures", func(t *testing.T) {
+		store := mem.NewProvider()
+		v := New(WithStorageProvider(store))
+
+		err := v.Update(&did.Doc{ID: "did:web:example.com"})
+		require.EqualError(t, err, "update did:web document: DID does not exist")
+
+		vm := did.NewVerificationMethodFromBytes("did:web:example.com#key-1", ed25519VerificationKey2018,
+			"did:web:example.com", []byte("key"))
+		doc := &did.Doc{ID: "did:web:example.com", VerificationMethod: []did.VerificationMethod{*vm}}
+
+		_, err = v.Create(doc)
+		require.NoError(t, err)
+
+		err = v.Update(doc, vdrapi.WithOption(RotateKeyOpt, "did:web:example.com#key-2"))
+		require.EqualError(t, err,
+			"update did:web document: rotate key: verification method did:web:example.com#key-2 not found")
+
+		err = v.Update(doc, vdrapi.WithOption(RotateKeyOpt, "#key-1"))
+		require.EqualError(t, err,
+			"update did:web document: rotate key did:web:example.com#key-1: key manager is required to generate keys")
+
+		require.NoError(t, v.Deactivate(doc.ID))
+
+		err = v.Update(doc)
+		require.EqualError(t, err, "update did:web document: did:web:example.com is deactivated")
 	})
 }
 
 func TestDeactivate(t *testing.T) {
-	t.Run("test deactivate", func(t *testing.T) {
+	t.Run("test deactivate without storage provider", func(t *testing.T) {
 		v := New()
 		err := v.Deactivate("")
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "not supported")
+		require.EqualError(t, err, "did:web document management requires a storage provider")
+	})
+
+	t.Run("test deactivate", func(t *testing.T) {
+		dir := t.TempDir()
+		store := mem.NewProvider()
+		v := New(WithStorageProvider(store), WithKeyManager(newKMS(t, store)), WithDocumentDir(dir))
+
+		docResolution, err := v.Create(nil, vdrapi.WithOption(DomainOpt, "example.com"))
+		require.NoError(t, err)
+
+		didID := docResolution.DIDDocument.ID
+		file := filepath.Join(dir, ".well-known", "did.json")
+		require.True(t, fileExists(file))
+
+		require.NoError(t, v.Deactivate(didID))
+		require.False(t, fileExists(file))
+
+		deactivated, err := v.Read(didID)
+		require.NoError(t, err)
+		require.True(t, deactivated.DocumentMetadata.Deactivated)
+		require.Equal(t, "2", deactivated.DocumentMetadata.VersionID)
+
+		// deactivation is idempotent
+		require.NoError(t, v.Deactivate(didID))
+
+		err = v.Deactivate("did:web:other.com")
+		require.EqualError(t, err, "deactivate did:web document: DID does not exist")
 	})
 }
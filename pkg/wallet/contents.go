@@ -15,7 +15,6 @@ import (
 	"fmt"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/bluele/gcache"
 
@@ -51,17 +50,26 @@ const (
 	// Key content type for handling key data models.
 	// https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 	Key ContentType = "key"
+
+	// ManagedDID content type for handling DIDs created and managed by wallet,
+	// which binds DID verification methods to keys of wallet key manager.
+	ManagedDID ContentType = "managedDID"
+
+	// SecuredCredential content type for handling credentials as secured by their issuers, e.g. JWT and SD-JWT
+	// credentials, saved with same ID as the credential content read from them.
+	SecuredCredential ContentType = "securedCredential"
 )
 
 // IsValid checks if underlying content type is supported.
 func (ct ContentType) IsValid() error {
 	switch ct {
-	case Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key:
+	case Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key, ManagedDID, SecuredCredential:
 		return nil
 	}
 
 	return fmt.Errorf("invalid content type '%s', supported types are %s", ct,
-		[]ContentType{Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key})
+		[]ContentType{Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key, ManagedDID,
+			SecuredCredential})
 }
 
 // Name of the content type.
@@ -72,8 +80,14 @@ func (ct ContentType) Name() string {
 const (
 	// collectionMappingKeyPrefix is db name space for saving collection ID to wallet content mappings.
 	collectionMappingKeyPrefix = "collectionmapping"
+
+	// kmsKeyIDPrefix is db name space for saving IDs of the keys added to wallet key manager.
+	kmsKeyIDPrefix = "kmskey"
 )
 
+// errContentExists when content with same type and id is already saved in wallet.
+var errContentExists = errors.New("content with same type and id already exists in this wallet")
+
 // keyContent is wallet content for key type
 // https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 type keyContent struct {
@@ -123,15 +137,32 @@ func newContentStore(p storage.Provider, pr *profile) *contentStore {
 }
 
 func (cs *contentStore) Open(auth string, opts *unlockOpts) error {
+	// reuse store already opened by other active session of this profile.
+	if store, err := storeManager().get(cs.storeID); err == nil {
+		cs.lock.Lock()
+		defer cs.lock.Unlock()
+
+		cs.updateStoreHandles(store)
+
+		return nil
+	}
+
 	store, err := cs.provider.OpenStore(auth, opts, storage.StoreConfiguration{TagNames: []string{
 		Collection.Name(), Credential.Name(), Connection.Name(), DIDResolutionResponse.Name(), Connection.Name(), Key.Name(),
+		kmsKeyIDPrefix, searchIssuedTag, searchExpiresTag, searchNameTag,
 	}})
 	if err != nil {
 		return err
 	}
 
-	// store instances needs to be cached to share unlock session between multiple instances of wallet.
-	if err := storeManager().persist(cs.storeID, store, opts.tokenExpiry); err != nil {
+	// contents saved without search index are not found by search filters.
+	if err := reindexSearch(store); err != nil {
+		logger.Warnf("failed to index wallet contents for search: %s", err)
+	}
+
+	// store instances needs to be cached to share unlock session between multiple instances of wallet,
+	// cached store will be released when last session of the wallet user ends.
+	if err := storeManager().persist(cs.storeID, store); err != nil {
 		return err
 	}
 
@@ -185,7 +216,7 @@ func (cs *contentStore) Save(auth string, ct ContentType, content []byte, option
 	}
 
 	switch ct {
-	case Collection, Metadata, Connection, Credential:
+	case Collection, Metadata, Connection, Credential, ManagedDID, SecuredCredential:
 		key, err := getContentID(content)
 		if err != nil {
 			return err
@@ -196,7 +227,9 @@ func (cs *contentStore) Save(auth string, ct ContentType, content []byte, option
 			return err
 		}
 
-		return cs.safeSave(auth, getContentKeyPrefix(ct, key), content, storage.Tag{Name: ct.Name()})
+		tags := append([]storage.Tag{{Name: ct.Name()}}, searchIndexTags(ct, content, opts.collectionID, key)...)
+
+		return cs.safeSave(auth, getContentKeyPrefix(ct, key), content, tags...)
 	case DIDResolutionResponse:
 		// verify did resolution result before storing and also use DID ID as content key
 		docRes, err := did.ParseDocumentResolution(content)
@@ -209,7 +242,10 @@ func (cs *contentStore) Save(auth string, ct ContentType, content []byte, option
 			return err
 		}
 
-		return cs.safeSave(auth, getContentKeyPrefix(ct, docRes.DIDDocument.ID), content, storage.Tag{Name: ct.Name()})
+		tags := append([]storage.Tag{{Name: ct.Name()}},
+			searchIndexTags(ct, content, opts.collectionID, docRes.DIDDocument.ID)...)
+
+		return cs.safeSave(auth, getContentKeyPrefix(ct, docRes.DIDDocument.ID), content, tags...)
 	case Key:
 		// never save keys in store, just import them into kms
 		var key keyContent
@@ -219,10 +255,11 @@ func (cs *contentStore) Save(auth string, ct ContentType, content []byte, option
 			return fmt.Errorf("failed to read key contents: %w", err)
 		}
 
-		return saveKey(auth, &key)
+		return cs.saveKey(auth, &key)
 	default:
 		return fmt.Errorf("invalid content type '%s', supported types are %s", ct,
-			[]ContentType{Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key})
+			[]ContentType{Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key, ManagedDID,
+				SecuredCredential})
 	}
 }
 
@@ -243,7 +280,7 @@ func (cs *contentStore) safeSave(auth, key string, content []byte, tags ...stora
 		return err
 	}
 
-	return errors.New("content with same type and id already exists in this wallet")
+	return errContentExists
 }
 
 // mapCollection maps given collection to given content.
@@ -270,24 +307,85 @@ func (cs *contentStore) mapCollection(auth, key, collectionID string, ct Content
 		storage.Tag{Name: base64.StdEncoding.EncodeToString([]byte(collectionID))})
 }
 
-func saveKey(auth string, key *keyContent) error {
+func (cs *contentStore) saveKey(auth string, key *keyContent) error {
 	if len(key.PrivateKeyJwk) > 0 {
-		err := importKeyJWK(auth, key)
+		kid, err := importKeyJWK(auth, key)
 		if err != nil {
 			return fmt.Errorf("failed to import private key jwk: %w", err)
 		}
+
+		err = cs.SaveKeyID(auth, kid)
+		if err != nil {
+			return err
+		}
 	}
 
 	if key.PrivateKeyBase58 != "" {
-		err := importKeyBase58(auth, key)
+		kid, err := importKeyBase58(auth, key)
 		if err != nil {
 			return fmt.Errorf("failed to import private key base58: %w", err)
 		}
+
+		err = cs.SaveKeyID(auth, kid)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// SaveKeyID remembers ID of the key added to wallet key manager, key material is never saved in content store.
+func (cs *contentStore) SaveKeyID(auth, kid string) error {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	store, err := cs.open(auth)
+	if err != nil {
+		return err
+	}
+
+	return store.Put(getKMSKeyIDPrefix(kid), []byte(kid), storage.Tag{Name: kmsKeyIDPrefix})
+}
+
+// GetKeyIDs returns IDs of all the keys added to wallet key manager.
+func (cs *contentStore) GetKeyIDs(auth string) ([]string, error) {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	store, err := cs.open(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := store.Query(kmsKeyIDPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var kids []string
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		val, err := iter.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		kids = append(kids, string(val))
+	}
+
+	return kids, nil
+}
+
 // Remove to remove wallet content from wallet contents store.
 func (cs *contentStore) Remove(auth, key string, ct ContentType) error {
 	cs.lock.RLock()
@@ -298,12 +396,19 @@ func (cs *contentStore) Remove(auth, key string, ct ContentType) error {
 		return err
 	}
 
-	// delete mapping
-	err = store.Delete(getCollectionMappingKeyPrefix(key))
-	if err != nil {
+	// delete mapping, unless it maps content of another type saved with same ID.
+	mapping, err := store.Get(getCollectionMappingKeyPrefix(key))
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
 		return err
 	}
 
+	if err == nil && string(mapping) == ct.Name() {
+		err = store.Delete(getCollectionMappingKeyPrefix(key))
+		if err != nil {
+			return err
+		}
+	}
+
 	// delete from store
 	return store.Delete(getContentKeyPrefix(ct, key))
 }
@@ -422,6 +527,92 @@ func (cs *contentStore) GetAllByCollection(auth,
 	return result, nil
 }
 
+// Search returns wallet contents of given type matching given search options.
+// Contents are filtered by storage tags, only contents of requested page are read from store.
+func (cs *contentStore) Search(auth string, ct ContentType, opts *searchOpts) (*SearchResult, error) {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	store, err := cs.open(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := opts.terms()
+
+	// query by first equality filter, remaining filters are matched against tags of query results.
+	expression := ct.Name()
+	if len(terms) > 0 {
+		expression = fmt.Sprintf("%s:%s", terms[0], ct.Name())
+	}
+
+	matches, err := searchMatches(store, expression, terms, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.sort(matches)
+
+	result := &SearchResult{Total: len(matches), Contents: []*SearchResultItem{}}
+
+	for _, match := range opts.page(matches) {
+		val, err := store.Get(match.key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read search result: %w", err)
+		}
+
+		result.Contents = append(result.Contents, &SearchResultItem{
+			ID:      removeKeyPrefix(ct.Name(), match.key),
+			Content: val,
+		})
+	}
+
+	return result, nil
+}
+
+func searchMatches(store storage.Store, expression string, terms []string,
+	opts *searchOpts) ([]*searchMatch, error) {
+	iter, err := store.Query(expression, storage.WithPageSize(searchPageSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contents: %w", err)
+	}
+
+	defer func() {
+		if e := iter.Close(); e != nil {
+			logger.Warnf("failed to close iterator: %s", e)
+		}
+	}()
+
+	var matches []*searchMatch
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query contents: %w", err)
+		}
+
+		if !ok {
+			break
+		}
+
+		key, err := iter.Key()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query contents: %w", err)
+		}
+
+		tags, err := iter.Tags()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query contents: %w", err)
+		}
+
+		if match, ok := opts.match(key, tags, terms); ok {
+			matches = append(matches, match)
+		}
+	}
+
+	return matches, nil
+}
+
 func getContentID(content []byte) (string, error) {
 	var cid contentID
 	if err := json.Unmarshal(content, &cid); err != nil {
@@ -448,6 +639,11 @@ func getCollectionMappingKeyPrefix(key string) string {
 	return fmt.Sprintf("%s_%s", collectionMappingKeyPrefix, key)
 }
 
+// getKMSKeyIDPrefix returns key prefix by wallet key manager key ID.
+func getKMSKeyIDPrefix(kid string) string {
+	return fmt.Sprintf("%s_%s", kmsKeyIDPrefix, kid)
+}
+
 // removeContentKeyPrefix removes content key prefix.
 func removeKeyPrefix(prefix, key string) string {
 	return strings.Replace(key, fmt.Sprintf("%s_", prefix), "", 1)
@@ -483,6 +679,26 @@ func (v *walletVDR) Resolve(didID string, opts ...vdr.DIDMethodOption) (*did.Doc
 	return v.Registry.Resolve(didID, opts...)
 }
 
+// Dereference dereferences DID URL against DID document from wallet content store or vdr registry.
+// DID documents are stored in wallet without versions, so versioned DID URLs are dereferenced by vdr registry.
+func (v *walletVDR) Dereference(didURL string, opts ...vdr.DIDMethodOption) (*did.DereferenceResult, error) {
+	parsed, err := did.ParseDIDURL(didURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse DID URL: %w", err)
+	}
+
+	if parsed.HasVersion() {
+		return vdr.Dereference(v.Registry, didURL, opts...)
+	}
+
+	docResolution, err := v.Resolve(parsed.DID.String(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return docResolution.Dereference(parsed)
+}
+
 //nolint:gochecknoglobals
 var (
 	walletStoreInstance *walletStoreManager
@@ -506,12 +722,8 @@ type walletStoreManager struct {
 	gstore gcache.Cache
 }
 
-func (ws *walletStoreManager) persist(id string, store storage.Store, expiration time.Duration) error {
-	if expiration == 0 {
-		expiration = defaultCacheExpiry
-	}
-
-	return ws.gstore.SetWithExpire(id, store, expiration)
+func (ws *walletStoreManager) persist(id string, store storage.Store) error {
+	return ws.gstore.Set(id, store)
 }
 
 func (ws *walletStoreManager) get(id string) (storage.Store, error) {
@@ -150,8 +150,13 @@ func TestContentTypes(t *testing.T) {
 		}{
 			{
 				name:     "validation success",
-				inputs:   []string{"collection", "credential", "didResolutionResponse", "metadata", "connection", "key"},
-				expected: []ContentType{Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key},
+				inputs: []string{
+					"collection", "credential", "didResolutionResponse", "metadata", "connection", "key",
+					"securedCredential",
+				},
+				expected: []ContentType{
+					Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key, SecuredCredential,
+				},
 			},
 			{
 				name:   "validation error",
@@ -199,7 +204,8 @@ func TestContentStores(t *testing.T) {
 		// open store
 		require.NoError(t, contentStore.Open(token, &unlockOpts{}))
 		require.EqualValues(t, sp.config.TagNames,
-			[]string{"collection", "credential", "connection", "didResolutionResponse", "connection", "key"})
+			[]string{"collection", "credential", "connection", "didResolutionResponse", "connection", "key", "kmskey",
+				"wsi_issued", "wsi_expires", "wsi_name"})
 
 		// close store
 		require.True(t, contentStore.Close())
@@ -228,7 +234,9 @@ func TestContentStores(t *testing.T) {
 			},
 		}
 
-		tkn, err := keyManager().createKeyManager(profileInfo, sp, &unlockOpts{passphrase: samplePassPhrase})
+		tkn, err := keyManager().createKeyManager(profileInfo, sp, &unlockOpts{
+			passphrase: samplePassPhrase, disableSessionPersistence: true,
+		})
 		require.NoError(t, err)
 		require.NotEmpty(t, tkn)
 
@@ -380,6 +388,8 @@ func TestContentStores(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, tkn)
 
+		require.NoError(t, contentStore.Open(tkn, &unlockOpts{}))
+
 		// import base58 private key
 		err = contentStore.Save(tkn, Key, []byte(sampleKeyContentBase58Valid))
 		require.NoError(t, err)
@@ -388,6 +398,11 @@ func TestContentStores(t *testing.T) {
 		err = contentStore.Save(tkn, Key, []byte(sampleKeyContentJwkValid))
 		require.NoError(t, err)
 
+		// imported keys are recorded in store
+		kids, err := contentStore.GetKeyIDs(tkn)
+		require.NoError(t, err)
+		require.Len(t, kids, 2)
+
 		// import using invalid auth token
 		err = contentStore.Save(tkn+"invalid", Key, []byte(sampleKeyContentBase58Valid))
 		require.True(t, errors.Is(err, ErrWalletLocked))
@@ -549,6 +564,30 @@ func TestContentStores(t *testing.T) {
 		require.True(t, errors.Is(err, storage.ErrDataNotFound))
 	})
 
+	t.Run("remove from store - keeps collection of content of another type", func(t *testing.T) {
+		sp := getMockStorageProvider()
+
+		contentStore := newContentStore(sp, &profile{ID: uuid.New().String()})
+		require.NotEmpty(t, contentStore)
+
+		require.NoError(t, contentStore.Open(token, &unlockOpts{}))
+
+		collectionID := "did:example:collection"
+		require.NoError(t, contentStore.Save(token, Collection,
+			[]byte(fmt.Sprintf(`{"id": "%s", "type": "Vault"}`, collectionID))))
+
+		require.NoError(t, contentStore.Save(token, Credential, []byte(`{"id": "http://example.edu/credentials/1"}`),
+			AddByCollection(collectionID)))
+		require.NoError(t, contentStore.Save(token, SecuredCredential,
+			[]byte(`{"id": "http://example.edu/credentials/1", "credential": "eyJ"}`)))
+
+		require.NoError(t, contentStore.Remove(token, "http://example.edu/credentials/1", SecuredCredential))
+
+		mapped, err := contentStore.GetAllByCollection(token, collectionID, Credential)
+		require.NoError(t, err)
+		require.Len(t, mapped, 1)
+	})
+
 	t.Run("remove from store - failure", func(t *testing.T) {
 		sp := getMockStorageProvider()
 		sp.Store.ErrDelete = errors.New(sampleContenttErr)
@@ -727,10 +766,29 @@ func TestContentDIDResolver(t *testing.T) {
 		require.Equal(t, "did:key:z6MknC1wwS6DEYwtGbZZo2QvjQjkh2qSBjb4GYmbye8dv4S5", didDoc.DIDDocument.ID)
 		require.NotEmpty(t, didDoc.DIDDocument.Authentication)
 
+		authKeyID := didDoc.DIDDocument.Authentication[0].VerificationMethod.ID
+
 		didDoc, err = contentVDR.Resolve("did:key:invalid")
 		require.Error(t, err)
 		require.Equal(t, vdrapi.ErrNotFound, err)
 		require.Empty(t, didDoc)
+
+		// dereference key of the stored DID.
+		result, err := contentVDR.Dereference(authKeyID)
+		require.NoError(t, err)
+		require.Equal(t, authKeyID, result.VerificationMethod.ID)
+
+		_, err = contentVDR.Dereference("did:key:invalid#key-1")
+		require.Equal(t, vdrapi.ErrNotFound, err)
+
+		// versioned DID URLs are dereferenced by vdr registry.
+		_, err = contentVDR.Dereference(
+			"did:key:z6MknC1wwS6DEYwtGbZZo2QvjQjkh2qSBjb4GYmbye8dv4S5?versionId=1#key-1")
+		require.Equal(t, vdrapi.ErrNotFound, err)
+
+		_, err = contentVDR.Dereference("invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse DID URL")
 	})
 
 	t.Run("create new content store - errors", func(t *testing.T) {
@@ -0,0 +1,575 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// verification method types of wallet keys.
+const (
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	bls12381G2Key2020          = "Bls12381G2Key2020"
+)
+
+// errDIDNotFound is returned when DID is not managed by wallet.
+var errDIDNotFound = errors.New("DID not found in wallet")
+
+// CreateDID creates a new DID of given method through wallet VDR, the DID document is built from a newly created
+// wallet key which stays bound to verification methods of the DID for signing.
+// Created DID document will be saved to wallet contents as 'DIDResolutionResponse' and DID will be
+// recorded as 'managedDID' content.
+//
+// Args:
+// 		- authToken: authorization for performing operation.
+// 		- method: DID method, like 'key', 'jwk', 'peer', 'web'.
+// 		- options: options for creating DID, like key type, method options or setting DID as default.
+//
+// Returns:
+// 		- DID resolution of created DID.
+// 		- error if operation fails.
+//
+func (c *Wallet) CreateDID(authToken, method string, options ...CreateDIDOptions) (*did.DocResolution, error) {
+	opts := &createDIDOpts{keyType: kms.ED25519Type}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	kid, pubKey, err := c.createKey(authToken, opts.keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := newKeyVerificationMethod("#"+kid, opts.keyType, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	docResolution, err := c.vdr.Create(method, &did.Doc{
+		VerificationMethod: []did.VerificationMethod{*vm},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+		AssertionMethod:    []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)},
+	}, opts.methodOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DID: %w", err)
+	}
+
+	didDoc := docResolution.DIDDocument
+
+	bindings := bindKey(didDoc, kid, opts.keyType, vm)
+	if len(bindings) == 0 {
+		return nil, fmt.Errorf("created DID '%s' has no verification method of wallet key", didDoc.ID)
+	}
+
+	if opts.setDefault {
+		err = c.unsetDefaultDID(authToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+
+	err = c.saveDIDResolution(authToken, docResolution, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.saveDIDRecord(authToken, &DIDRecord{
+		ID:          didDoc.ID,
+		Method:      method,
+		Default:     opts.setDefault,
+		KeyBindings: bindings,
+		Created:     &now,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return docResolution, nil
+}
+
+// ListDIDs returns all DIDs created and managed by wallet.
+//
+// Args:
+// 		- authToken: authorization for performing operation.
+//
+// Returns:
+// 		- DID records ordered by creation time.
+// 		- error if operation fails.
+//
+func (c *Wallet) ListDIDs(authToken string) ([]*DIDRecord, error) {
+	contents, err := c.contents.GetAll(authToken, ManagedDID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managed DIDs: %w", err)
+	}
+
+	records := make([]*DIDRecord, 0, len(contents))
+
+	for _, content := range contents {
+		var record DIDRecord
+
+		err = json.Unmarshal(content, &record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read managed DID: %w", err)
+		}
+
+		records = append(records, &record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Created != nil && records[j].Created != nil && !records[i].Created.Equal(*records[j].Created) {
+			return records[i].Created.Before(*records[j].Created)
+		}
+
+		return records[i].ID < records[j].ID
+	})
+
+	return records, nil
+}
+
+// SetDefaultDID sets given wallet DID as default DID for signing, which will be used as controller
+// when 'controller' is missing in proof options.
+//
+// Args:
+// 		- authToken: authorization for performing operation.
+// 		- didID: ID of the DID created by wallet.
+//
+// Returns:
+// 		- error if operation fails.
+//
+func (c *Wallet) SetDefaultDID(authToken, didID string) error {
+	record, err := c.getDIDRecord(authToken, didID)
+	if err != nil {
+		return err
+	}
+
+	if record.Deactivated {
+		return fmt.Errorf("DID '%s' is deactivated", didID)
+	}
+
+	if record.Default {
+		return nil
+	}
+
+	err = c.unsetDefaultDID(authToken)
+	if err != nil {
+		return err
+	}
+
+	record.Default = true
+
+	return c.saveDIDRecord(authToken, record, true)
+}
+
+// UpdateDID updates given wallet DID through wallet VDR and saves updated DID document to wallet contents.
+//
+// Args:
+// 		- authToken: authorization for performing operation.
+// 		- didID: ID of the DID created by wallet.
+// 		- options: options for updating DID, like updated DID document, key rotation or method options.
+//
+// Returns:
+// 		- DID resolution of updated DID.
+// 		- error if operation fails.
+//
+func (c *Wallet) UpdateDID(authToken, didID string, options ...UpdateDIDOptions) (*did.DocResolution, error) {
+	opts := &updateDIDOpts{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	record, err := c.getDIDRecord(authToken, didID)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.Deactivated {
+		return nil, fmt.Errorf("DID '%s' is deactivated", didID)
+	}
+
+	docResolution, err := c.getDIDResolution(authToken, didID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.didDoc != nil {
+		if opts.didDoc.ID != didID {
+			return nil, fmt.Errorf("DID document ID '%s' doesn't match DID '%s'", opts.didDoc.ID, didID)
+		}
+
+		docResolution.DIDDocument = opts.didDoc
+	}
+
+	bindings := record.KeyBindings
+
+	if opts.rotateKeys {
+		bindings, err = c.rotateKeys(authToken, docResolution.DIDDocument, record.KeyBindings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.vdr.Update(docResolution.DIDDocument, opts.methodOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update DID: %w", err)
+	}
+
+	err = c.saveDIDResolution(authToken, docResolution, true)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	record.KeyBindings = bindings
+	record.Updated = &now
+
+	err = c.saveDIDRecord(authToken, record, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return docResolution, nil
+}
+
+// DeactivateDID deactivates given wallet DID through wallet VDR, DID document of the DID will be removed from wallet
+// contents and DID can not be used for signing anymore.
+//
+// Args:
+// 		- authToken: authorization for performing operation.
+// 		- didID: ID of the DID created by wallet.
+// 		- options: DID method specific options.
+//
+// Returns:
+// 		- error if operation fails.
+//
+func (c *Wallet) DeactivateDID(authToken, didID string, options ...vdr.DIDMethodOption) error {
+	record, err := c.getDIDRecord(authToken, didID)
+	if err != nil {
+		return err
+	}
+
+	if record.Deactivated {
+		return fmt.Errorf("DID '%s' is already deactivated", didID)
+	}
+
+	err = c.vdr.Deactivate(didID, options...)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate DID: %w", err)
+	}
+
+	err = c.contents.Remove(authToken, didID, DIDResolutionResponse)
+	if err != nil {
+		return fmt.Errorf("failed to remove DID resolution: %w", err)
+	}
+
+	now := time.Now().UTC()
+	record.Deactivated = true
+	record.Default = false
+	record.Updated = &now
+
+	return c.saveDIDRecord(authToken, record, true)
+}
+
+// createKey creates a new key in wallet key manager and remembers its key ID for exporting wallet keys.
+func (c *Wallet) createKey(authToken string, keyType kms.KeyType) (string, []byte, error) {
+	kmgr, err := keyManager().getKeyManger(authToken)
+	if err != nil {
+		return "", nil, ErrInvalidAuthToken
+	}
+
+	kid, pubBytes, err := kmgr.CreateAndExportPubKeyBytes(keyType)
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = c.contents.SaveKeyID(authToken, kid)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to save key ID: %w", err)
+	}
+
+	return kid, pubBytes, nil
+}
+
+// rotateKeys creates new wallet keys for given key bindings and replaces bound verification methods of
+// given DID document.
+func (c *Wallet) rotateKeys(authToken string, didDoc *did.Doc, bindings []*DIDKeyBinding) ([]*DIDKeyBinding, error) {
+	rotated := make([]*DIDKeyBinding, 0, len(bindings))
+
+	for _, binding := range bindings {
+		kid, pubKey, err := c.createKey(authToken, binding.KeyType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rotate key of '%s': %w", binding.VerificationMethod, err)
+		}
+
+		replace := func(vm *did.VerificationMethod) error {
+			if absoluteVerificationMethodID(didDoc.ID, vm.ID) != binding.VerificationMethod {
+				return nil
+			}
+
+			updated, err := newKeyVerificationMethod(vm.ID, binding.KeyType, pubKey)
+			if err != nil {
+				return err
+			}
+
+			updated.Controller = vm.Controller
+			*vm = *updated
+
+			return nil
+		}
+
+		for i := range didDoc.VerificationMethod {
+			if err = replace(&didDoc.VerificationMethod[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, verifications := range [][]did.Verification{
+			didDoc.Authentication, didDoc.AssertionMethod, didDoc.CapabilityDelegation,
+			didDoc.CapabilityInvocation, didDoc.KeyAgreement,
+		} {
+			for i := range verifications {
+				if err = replace(&verifications[i].VerificationMethod); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		rotated = append(rotated, &DIDKeyBinding{
+			VerificationMethod: binding.VerificationMethod,
+			KeyID:              kid,
+			KeyType:            binding.KeyType,
+		})
+	}
+
+	return rotated, nil
+}
+
+// getDIDRecord returns managed DID record of given DID.
+func (c *Wallet) getDIDRecord(authToken, didID string) (*DIDRecord, error) {
+	content, err := c.contents.Get(authToken, didID, ManagedDID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, fmt.Errorf("'%s': %w", didID, errDIDNotFound)
+		}
+
+		return nil, fmt.Errorf("failed to get managed DID: %w", err)
+	}
+
+	var record DIDRecord
+
+	err = json.Unmarshal(content, &record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read managed DID: %w", err)
+	}
+
+	return &record, nil
+}
+
+// findDIDRecord returns managed DID record of given DID or nil if given DID is not managed by the wallet.
+// Like wallet VDR, only a locked wallet fails lookup of managed DIDs.
+func (c *Wallet) findDIDRecord(authToken, didID string) (*DIDRecord, error) {
+	record, err := c.getDIDRecord(authToken, didID)
+	if errors.Is(err, ErrWalletLocked) {
+		return nil, err
+	} else if err != nil {
+		return nil, nil
+	}
+
+	return record, nil
+}
+
+// saveDIDRecord saves managed DID record to wallet contents, replaces existing record if 'replace' is true.
+func (c *Wallet) saveDIDRecord(authToken string, record *DIDRecord, replace bool) error {
+	content, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal managed DID: %w", err)
+	}
+
+	if replace {
+		err = c.contents.Remove(authToken, record.ID, ManagedDID)
+		if err != nil {
+			return fmt.Errorf("failed to replace managed DID: %w", err)
+		}
+	}
+
+	err = c.contents.Save(authToken, ManagedDID, content)
+	if err != nil {
+		return fmt.Errorf("failed to save managed DID: %w", err)
+	}
+
+	return nil
+}
+
+// getDIDResolution returns DID resolution of given DID from wallet contents.
+func (c *Wallet) getDIDResolution(authToken, didID string) (*did.DocResolution, error) {
+	content, err := c.contents.Get(authToken, didID, DIDResolutionResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DID resolution: %w", err)
+	}
+
+	docResolution, err := did.ParseDocumentResolution(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DID resolution: %w", err)
+	}
+
+	return docResolution, nil
+}
+
+// saveDIDResolution saves DID resolution to wallet contents, replaces existing resolution if 'replace' is true.
+func (c *Wallet) saveDIDResolution(authToken string, docResolution *did.DocResolution, replace bool) error {
+	content, err := docResolution.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("failed to marshal DID resolution: %w", err)
+	}
+
+	if replace {
+		err = c.contents.Remove(authToken, docResolution.DIDDocument.ID, DIDResolutionResponse)
+		if err != nil {
+			return fmt.Errorf("failed to replace DID resolution: %w", err)
+		}
+	}
+
+	err = c.contents.Save(authToken, DIDResolutionResponse, content)
+	if err != nil {
+		return fmt.Errorf("failed to save DID resolution: %w", err)
+	}
+
+	return nil
+}
+
+// defaultDID returns default wallet DID for signing, returns nil if there is no default DID.
+func (c *Wallet) defaultDID(authToken string) (*DIDRecord, error) {
+	records, err := c.ListDIDs(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if record.Default {
+			return record, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// unsetDefaultDID removes current default wallet DID.
+func (c *Wallet) unsetDefaultDID(authToken string) error {
+	record, err := c.defaultDID(authToken)
+	if err != nil || record == nil {
+		return err
+	}
+
+	record.Default = false
+
+	return c.saveDIDRecord(authToken, record, true)
+}
+
+// signingKeyID returns ID of the wallet key for signing with given verification method, key bindings of wallet DIDs
+// are used if found, otherwise fragment of the verification method is used as key ID.
+func (c *Wallet) signingKeyID(authToken, verificationMethod string) (string, error) {
+	vmSplit := strings.Split(verificationMethod, "#")
+
+	if len(vmSplit) != vmSectionCount {
+		return "", errors.New("invalid verification method format")
+	}
+
+	record, err := c.findDIDRecord(authToken, vmSplit[0])
+	if err != nil {
+		return "", err
+	}
+
+	if record == nil {
+		return vmSplit[vmSectionCount-1], nil
+	}
+
+	for _, binding := range record.KeyBindings {
+		if binding.VerificationMethod == verificationMethod {
+			return binding.KeyID, nil
+		}
+	}
+
+	return vmSplit[vmSectionCount-1], nil
+}
+
+// newKeyVerificationMethod creates verification method for given wallet public key.
+func newKeyVerificationMethod(id string, keyType kms.KeyType, pubKey []byte) (*did.VerificationMethod, error) {
+	switch keyType { // nolint:exhaustive
+	case kms.ED25519Type:
+		return did.NewVerificationMethodFromBytes(id, ed25519VerificationKey2018, "", pubKey), nil
+	case kms.BLS12381G2Type:
+		return did.NewVerificationMethodFromBytes(id, bls12381G2Key2020, "", pubKey), nil
+	default:
+		j, err := jwksupport.PubKeyBytesToJWK(pubKey, keyType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert public key to JWK: %w", err)
+		}
+
+		vm, err := did.NewVerificationMethodFromJWK(id, jsonWebKey2020, "", j)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create verification method: %w", err)
+		}
+
+		return vm, nil
+	}
+}
+
+// bindKey returns key bindings of verification methods of given DID document which are created from given
+// wallet key, DID methods either keep verification method ID or its public key.
+func bindKey(didDoc *did.Doc, kid string, keyType kms.KeyType, keyVM *did.VerificationMethod) []*DIDKeyBinding {
+	var bindings []*DIDKeyBinding
+
+	bound := make(map[string]bool)
+
+	vms := append([]did.VerificationMethod{}, didDoc.VerificationMethod...)
+
+	for _, verifications := range didDoc.VerificationMethods() {
+		for _, verification := range verifications {
+			if verification.Embedded {
+				vms = append(vms, verification.VerificationMethod)
+			}
+		}
+	}
+
+	for _, vm := range vms {
+		id := absoluteVerificationMethodID(didDoc.ID, vm.ID)
+
+		if bound[id] || (!strings.HasSuffix(vm.ID, keyVM.ID) && !bytes.Equal(vm.Value, keyVM.Value)) {
+			continue
+		}
+
+		bound[id] = true
+
+		bindings = append(bindings, &DIDKeyBinding{VerificationMethod: id, KeyID: kid, KeyType: keyType})
+	}
+
+	return bindings
+}
+
+// absoluteVerificationMethodID returns absolute DID URL of given verification method ID.
+func absoluteVerificationMethodID(didID, vmID string) string {
+	if strings.HasPrefix(vmID, "#") {
+		return didID + vmID
+	}
+
+	return vmID
+}
@@ -0,0 +1,291 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+)
+
+func TestWallet_CreateDID(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newDIDMockProvider(t, nil))
+
+	t.Run("create did:key as default DID and issue credential", func(t *testing.T) {
+		docResolution, err := walletInstance.CreateDID(token, key.DIDMethod, WithDefaultDID())
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(docResolution.DIDDocument.ID, "did:key:"))
+
+		records, err := walletInstance.ListDIDs(token)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, docResolution.DIDDocument.ID, records[0].ID)
+		require.Equal(t, key.DIDMethod, records[0].Method)
+		require.True(t, records[0].Default)
+		require.NotEmpty(t, records[0].Created)
+		require.Len(t, records[0].KeyBindings, 1)
+		require.Equal(t, docResolution.DIDDocument.VerificationMethod[0].ID,
+			records[0].KeyBindings[0].VerificationMethod)
+		require.Equal(t, kms.ED25519Type, records[0].KeyBindings[0].KeyType)
+
+		stored, err := walletInstance.Get(token, DIDResolutionResponse, docResolution.DIDDocument.ID)
+		require.NoError(t, err)
+		require.NotEmpty(t, stored)
+
+		proofOptions := &ProofOptions{}
+
+		vc, err := walletInstance.Issue(token, []byte(sampleUDCVC), proofOptions)
+		require.NoError(t, err)
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, docResolution.DIDDocument.ID, proofOptions.Controller)
+		require.Equal(t, records[0].KeyBindings[0].VerificationMethod, vc.Proofs[0]["verificationMethod"])
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		ok, err := walletInstance.Verify(token, WithRawCredentialToVerify(vcBytes))
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("create did:jwk and set as default DID", func(t *testing.T) {
+		docResolution, err := walletInstance.CreateDID(token, jwk.DIDMethod,
+			WithDIDKeyType(kms.ECDSAP256TypeIEEEP1363))
+		require.NoError(t, err)
+
+		didID := docResolution.DIDDocument.ID
+		require.True(t, strings.HasPrefix(didID, "did:jwk:"))
+
+		require.NoError(t, walletInstance.SetDefaultDID(token, didID))
+
+		records, err := walletInstance.ListDIDs(token)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.False(t, records[0].Default)
+		require.Equal(t, didID, records[1].ID)
+		require.True(t, records[1].Default)
+		require.Equal(t, didID+"#0", records[1].KeyBindings[0].VerificationMethod)
+
+		vc, err := walletInstance.Issue(token, []byte(sampleUDCVC), &ProofOptions{ProofType: JSONWebSignature2020})
+		require.NoError(t, err)
+		require.Equal(t, didID+"#0", vc.Proofs[0]["verificationMethod"])
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		ok, err := walletInstance.Verify(token, WithRawCredentialToVerify(vcBytes))
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("create DID failures", func(t *testing.T) {
+		docResolution, err := walletInstance.CreateDID(token, "unsupported")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create DID")
+		require.Empty(t, docResolution)
+
+		docResolution, err = walletInstance.CreateDID(token, key.DIDMethod, WithDIDKeyType("invalid"))
+		require.Error(t, err)
+		require.Empty(t, docResolution)
+
+		docResolution, err = walletInstance.CreateDID(sampleFakeTkn, key.DIDMethod)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+		require.Empty(t, docResolution)
+
+		err = walletInstance.SetDefaultDID(token, "did:example:unknown")
+		require.True(t, errors.Is(err, errDIDNotFound))
+	})
+}
+
+func TestWallet_UpdateDID(t *testing.T) {
+	var updated *did.Doc
+
+	walletInstance, token := newOpenedWallet(t, newDIDMockProvider(t, &mockvdr.MockVDRegistry{
+		UpdateFunc: func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
+			if updated != nil && updated.ID == didDoc.ID {
+				return errors.New(sampleWalletErr)
+			}
+
+			updated = didDoc
+
+			return nil
+		},
+	}))
+
+	docResolution, err := walletInstance.CreateDID(token, key.DIDMethod, WithDefaultDID())
+	require.NoError(t, err)
+
+	didID := docResolution.DIDDocument.ID
+
+	t.Run("update DID with key rotation", func(t *testing.T) {
+		records, err := walletInstance.ListDIDs(token)
+		require.NoError(t, err)
+
+		previousKeyID := records[0].KeyBindings[0].KeyID
+
+		result, err := walletInstance.UpdateDID(token, didID, WithKeyRotation())
+		require.NoError(t, err)
+		require.Equal(t, updated, result.DIDDocument)
+		require.NotEqual(t, docResolution.DIDDocument.VerificationMethod[0].Value,
+			result.DIDDocument.VerificationMethod[0].Value)
+		require.Equal(t, result.DIDDocument.VerificationMethod[0].Value,
+			result.DIDDocument.AssertionMethod[0].VerificationMethod.Value)
+
+		records, err = walletInstance.ListDIDs(token)
+		require.NoError(t, err)
+		require.NotEqual(t, previousKeyID, records[0].KeyBindings[0].KeyID)
+		require.NotEmpty(t, records[0].Updated)
+
+		// credential is signed by rotated key and verified against updated DID document saved in wallet.
+		vc, err := walletInstance.Issue(token, []byte(sampleUDCVC), &ProofOptions{})
+		require.NoError(t, err)
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		ok, err := walletInstance.Verify(token, WithRawCredentialToVerify(vcBytes))
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("update DID failures", func(t *testing.T) {
+		result, err := walletInstance.UpdateDID(token, didID, WithUpdatedDIDDocument(&did.Doc{ID: "did:example:123"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "doesn't match DID")
+		require.Empty(t, result)
+
+		result, err = walletInstance.UpdateDID(token, "did:example:unknown")
+		require.True(t, errors.Is(err, errDIDNotFound))
+		require.Empty(t, result)
+
+		result, err = walletInstance.UpdateDID(token, didID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleWalletErr)
+		require.Empty(t, result)
+	})
+}
+
+func TestWallet_DeactivateDID(t *testing.T) {
+	var deactivated []string
+
+	walletInstance, token := newOpenedWallet(t, newDIDMockProvider(t, &mockvdr.MockVDRegistry{
+		DeactivateFunc: func(didID string, opts ...vdrapi.DIDMethodOption) error {
+			if strings.HasPrefix(didID, "did:jwk:") {
+				return errors.New(sampleWalletErr)
+			}
+
+			deactivated = append(deactivated, didID)
+
+			return nil
+		},
+	}))
+
+	docResolution, err := walletInstance.CreateDID(token, key.DIDMethod, WithDefaultDID())
+	require.NoError(t, err)
+
+	didID := docResolution.DIDDocument.ID
+
+	t.Run("deactivate default DID", func(t *testing.T) {
+		require.NoError(t, walletInstance.DeactivateDID(token, didID))
+		require.Equal(t, []string{didID}, deactivated)
+
+		records, err := walletInstance.ListDIDs(token)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.True(t, records[0].Deactivated)
+		require.False(t, records[0].Default)
+
+		_, err = walletInstance.Get(token, DIDResolutionResponse, didID)
+		require.Error(t, err)
+
+		vc, err := walletInstance.Issue(token, []byte(sampleUDCVC), &ProofOptions{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "'controller' is required")
+		require.Empty(t, vc)
+
+		vc, err = walletInstance.Issue(token, []byte(sampleUDCVC), &ProofOptions{Controller: didID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is deactivated")
+		require.Empty(t, vc)
+	})
+
+	t.Run("deactivate DID failures", func(t *testing.T) {
+		err := walletInstance.DeactivateDID(token, didID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already deactivated")
+
+		err = walletInstance.SetDefaultDID(token, didID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is deactivated")
+
+		_, err = walletInstance.UpdateDID(token, didID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is deactivated")
+
+		err = walletInstance.DeactivateDID(token, "did:example:unknown")
+		require.True(t, errors.Is(err, errDIDNotFound))
+
+		jwkResolution, err := walletInstance.CreateDID(token, jwk.DIDMethod)
+		require.NoError(t, err)
+
+		err = walletInstance.DeactivateDID(token, jwkResolution.DIDDocument.ID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleWalletErr)
+	})
+}
+
+// newDIDMockProvider returns mock provider with real crypto and VDR registry of 'did:key' and 'did:jwk' methods,
+// update and deactivate functions of given mock registry will be used if provided.
+func newDIDMockProvider(t *testing.T, registry *mockvdr.MockVDRegistry) *mockprovider.Provider {
+	t.Helper()
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	vdrRegistry := vdr.New(vdr.WithVDR(key.New()), vdr.WithVDR(jwk.New()))
+
+	if registry == nil {
+		registry = &mockvdr.MockVDRegistry{}
+	}
+
+	registry.CreateFunc = vdrRegistry.Create
+	registry.ResolveFunc = vdrRegistry.Resolve
+
+	mockctx := newMockProvider(t)
+	mockctx.CryptoValue = cryptoSvc
+	mockctx.VDRegistryValue = registry
+
+	return mockctx
+}
+
+// ensure DID records are valid wallet content.
+func TestDIDRecord_Content(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newDIDMockProvider(t, nil))
+
+	record, err := json.Marshal(&DIDRecord{ID: "did:example:123", Method: "example"})
+	require.NoError(t, err)
+
+	require.NoError(t, walletInstance.Add(token, ManagedDID, record))
+
+	records, err := walletInstance.ListDIDs(token)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "did:example:123", records[0].ID)
+}
@@ -0,0 +1,365 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	gojose "github.com/square/go-jose/v3"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+// ImportConflictPolicy is policy for handling imported wallet contents which are already present in wallet.
+type ImportConflictPolicy string
+
+const (
+	// ImportSkipExisting keeps contents already present in wallet and skips imported ones.
+	ImportSkipExisting ImportConflictPolicy = "skip"
+
+	// ImportReplaceExisting replaces contents already present in wallet by imported ones.
+	// Keys already present in wallet key manager are never replaced.
+	ImportReplaceExisting ImportConflictPolicy = "replace"
+
+	// ImportFailOnConflict fails import if any of the imported contents is already present in wallet.
+	ImportFailOnConflict ImportConflictPolicy = "fail"
+)
+
+// exported wallet constants.
+const (
+	walletContext       = "https://w3id.org/wallet/v1"
+	encryptedWalletType = "EncryptedWallet"
+	walletContentsType  = "UniversalWallet2020"
+	uuidURNPrefix       = "urn:uuid:"
+)
+
+// order in which content types are exported and imported, collections have to be imported before the contents
+// mapped to them.
+// nolint:gochecknoglobals
+var exportedContentTypes = []ContentType{
+	Key, Collection, Metadata, DIDResolutionResponse, ManagedDID, Connection, Credential, SecuredCredential,
+}
+
+// encryptedWallet is exported wallet, wallet contents are encrypted as JWE.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#export
+type encryptedWallet struct {
+	Context                 []string        `json:"@context"`
+	ID                      string          `json:"id"`
+	Type                    []string        `json:"type"`
+	EncryptedWalletContents json.RawMessage `json:"encryptedWalletContents"`
+}
+
+// walletContents is plaintext of the encrypted wallet contents.
+type walletContents struct {
+	Context  []string         `json:"@context"`
+	ID       string           `json:"id"`
+	Type     []string         `json:"type"`
+	Contents []*walletContent `json:"contents"`
+}
+
+// walletContent is exported wallet content along with its content type and collection.
+type walletContent struct {
+	ContentType  ContentType     `json:"contentType"`
+	CollectionID string          `json:"collectionID,omitempty"`
+	Content      json.RawMessage `json:"content"`
+}
+
+func (ip ImportConflictPolicy) isValid() error {
+	switch ip {
+	case ImportSkipExisting, ImportReplaceExisting, ImportFailOnConflict:
+		return nil
+	}
+
+	return fmt.Errorf("invalid import conflict policy '%s', supported policies are %s", ip,
+		[]ImportConflictPolicy{ImportSkipExisting, ImportReplaceExisting, ImportFailOnConflict})
+}
+
+// exportContents reads all the wallet contents and exportable keys of wallet key manager.
+func (c *Wallet) exportContents(auth, id string, opts *exportOpts) (*walletContents, error) {
+	collections, err := c.contents.GetAll(auth, Collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	// content storage key -> collection ID.
+	collectionMapping := make(map[string]string)
+
+	for collectionID := range collections {
+		for _, ct := range exportedContentTypes[1:] {
+			mapped, e := c.contents.GetAllByCollection(auth, collectionID, ct)
+			if e != nil {
+				return nil, fmt.Errorf("failed to get contents of collection '%s': %w", collectionID, e)
+			}
+
+			for key := range mapped {
+				collectionMapping[getContentKeyPrefix(ct, key)] = collectionID
+			}
+		}
+	}
+
+	result := &walletContents{
+		Context: []string{walletContext},
+		ID:      id,
+		Type:    []string{walletContentsType},
+	}
+
+	keys, err := c.exportKeys(auth, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Contents = append(result.Contents, keys...)
+
+	for _, ct := range exportedContentTypes[1:] {
+		all, e := c.contents.GetAll(auth, ct)
+		if e != nil {
+			return nil, fmt.Errorf("failed to get %s contents: %w", ct, e)
+		}
+
+		ids := make([]string, 0, len(all))
+		for key := range all {
+			ids = append(ids, key)
+		}
+
+		sort.Strings(ids)
+
+		for _, key := range ids {
+			result.Contents = append(result.Contents, &walletContent{
+				ContentType:  ct,
+				CollectionID: collectionMapping[getContentKeyPrefix(ct, key)],
+				Content:      all[key],
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// exportKeys exports keys added to wallet key manager, keys of remote key manager and the key encrypting
+// exported wallet, which has to be present in wallet it is imported to, are skipped.
+// Fails with 'ErrKeyNotExportable' naming all the other keys of local key manager which can't be exported,
+// unless skipping of those keys is requested.
+func (c *Wallet) exportKeys(auth string, opts *exportOpts) ([]*walletContent, error) {
+	kids, err := c.contents.GetKeyIDs(auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key IDs: %w", err)
+	}
+
+	sort.Strings(kids)
+
+	var notExportable []string
+
+	contents := make([]*walletContent, 0, len(kids))
+
+	for _, kid := range kids {
+		key, err := exportKey(auth, kid)
+		if errors.Is(err, errRemoteKey) {
+			logger.Debugf("skipping key while exporting wallet: %s", err)
+
+			continue
+		}
+
+		if errors.Is(err, ErrKeyNotExportable) && kid == opts.keyID && opts.passphrase == "" {
+			continue
+		}
+
+		if errors.Is(err, ErrKeyNotExportable) {
+			if opts.skipUnexportableKeys {
+				logger.Warnf("skipping key while exporting wallet: %s", err)
+			}
+
+			notExportable = append(notExportable, kid)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to export key: %w", err)
+		}
+
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal key '%s': %w", kid, err)
+		}
+
+		contents = append(contents, &walletContent{ContentType: Key, Content: keyBytes})
+	}
+
+	if len(notExportable) > 0 && !opts.skipUnexportableKeys {
+		return nil, fmt.Errorf("keys %s: %w", strings.Join(notExportable, ", "), ErrKeyNotExportable)
+	}
+
+	return contents, nil
+}
+
+// importContents imports wallet contents in the order of their content types.
+func (c *Wallet) importContents(auth string, contents []*walletContent, policy ImportConflictPolicy) error {
+	order := make(map[ContentType]int, len(exportedContentTypes))
+	for i, ct := range exportedContentTypes {
+		order[ct] = i
+	}
+
+	sorted := make([]*walletContent, len(contents))
+	copy(sorted, contents)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order[sorted[i].ContentType] < order[sorted[j].ContentType]
+	})
+
+	for _, content := range sorted {
+		if err := content.ContentType.IsValid(); err != nil {
+			return err
+		}
+
+		if err := c.importContent(auth, content, policy); err != nil {
+			return fmt.Errorf("failed to import %s content: %w", content.ContentType, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Wallet) importContent(auth string, content *walletContent, policy ImportConflictPolicy) error {
+	if content.ContentType == Key {
+		var key keyContent
+
+		err := json.Unmarshal(content.Content, &key)
+		if err != nil {
+			return fmt.Errorf("failed to read key contents: %w", err)
+		}
+
+		exists, err := keyExists(auth, &key)
+		if err != nil {
+			return err
+		}
+
+		// keys with same key ID have same key material, so existing keys are never replaced.
+		if exists {
+			if policy == ImportFailOnConflict {
+				return fmt.Errorf("key '%s': %w", key.ID, errContentExists)
+			}
+
+			return nil
+		}
+
+		return c.contents.Save(auth, Key, content.Content)
+	}
+
+	var options []AddContentOptions
+	if content.CollectionID != "" {
+		options = append(options, AddByCollection(content.CollectionID))
+	}
+
+	err := c.contents.Save(auth, content.ContentType, content.Content, options...)
+	if !errors.Is(err, errContentExists) {
+		return err
+	}
+
+	switch policy {
+	case ImportSkipExisting:
+		return nil
+	case ImportReplaceExisting:
+		key, err := getContentKey(content.ContentType, content.Content)
+		if err != nil {
+			return err
+		}
+
+		err = c.contents.Remove(auth, key, content.ContentType)
+		if err != nil {
+			return fmt.Errorf("failed to remove existing content: %w", err)
+		}
+
+		return c.contents.Save(auth, content.ContentType, content.Content, options...)
+	default:
+		return err
+	}
+}
+
+// encryptContents encrypts wallet contents as JWE by key derived from passphrase or by key manager key.
+func (c *Wallet) encryptContents(auth string, contents []byte, opts *exportOpts) (json.RawMessage, error) {
+	if opts.passphrase != "" {
+		encrypter, err := gojose.NewEncrypter(gojose.A256GCM, gojose.Recipient{
+			Algorithm: gojose.PBES2_HS512_A256KW,
+			Key:       []byte(opts.passphrase),
+		}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create JWE encrypter: %w", err)
+		}
+
+		jwe, err := encrypter.Encrypt(contents)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.RawMessage(jwe.FullSerialize()), nil
+	}
+
+	keyMgr, err := keyManager().getKeyManger(auth)
+	if err != nil {
+		return nil, ErrInvalidAuthToken
+	}
+
+	encrypter, err := getJWSEncrypter(opts.keyID, keyMgr, c.walletCrypto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWE encrypter: %w", err)
+	}
+
+	jwe, err := encrypter.Encrypt(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	serialized, err := jwe.FullSerialize(json.Marshal)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(serialized), nil
+}
+
+// decryptContents decrypts JWE of wallet contents by key derived from passphrase or by key manager key.
+func (c *Wallet) decryptContents(auth string, contents json.RawMessage, opts *importOpts) ([]byte, error) {
+	if opts.passphrase != "" {
+		jwe, err := gojose.ParseEncrypted(string(contents))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWE: %w", err)
+		}
+
+		return jwe.Decrypt([]byte(opts.passphrase))
+	}
+
+	keyMgr, err := keyManager().getKeyManger(auth)
+	if err != nil {
+		return nil, ErrInvalidAuthToken
+	}
+
+	jwe, err := jose.Deserialize(string(contents))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWE: %w", err)
+	}
+
+	return jose.NewJWEDecrypt(nil, c.walletCrypto, keyMgr).Decrypt(jwe)
+}
+
+// getContentKey returns storage key of the wallet content.
+func getContentKey(ct ContentType, content []byte) (string, error) {
+	if ct == DIDResolutionResponse {
+		docRes, err := did.ParseDocumentResolution(content)
+		if err != nil {
+			return "", fmt.Errorf("invalid DID resolution response model: %w", err)
+		}
+
+		return docRes.DIDDocument.ID, nil
+	}
+
+	return getContentID(content)
+}
@@ -0,0 +1,330 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+const (
+	sampleExportPassphrase = "sample-export-passphrase"
+	sampleCollectionID     = "did:example:acme123456789abcdefghi"
+	sampleCollection       = `{
+		"@context": ["https://w3id.org/wallet/v1"],
+		"id": "did:example:acme123456789abcdefghi",
+		"type": "Organization",
+		"name": "Acme Corp."
+	}`
+	sampleConnection = `{
+		"@context": ["https://w3id.org/wallet/v1"],
+		"id": "did:example:connection123",
+		"type": "Connection",
+		"name": "Acme Corp. connection"
+	}`
+	sampleCredentialFmt = `{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"id": "%s",
+		"type": ["VerifiableCredential"],
+		"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}
+	}`
+)
+
+func TestWallet_ExportImport(t *testing.T) {
+	source, sourceToken := newOpenedWallet(t, newMockProvider(t))
+
+	// wallet contents
+	require.NoError(t, source.Add(sourceToken, Collection, []byte(sampleCollection)))
+	require.NoError(t, source.Add(sourceToken, Metadata, []byte(sampleContentValid)))
+	require.NoError(t, source.Add(sourceToken, DIDResolutionResponse, []byte(sampleDocResolutionResponse)))
+	require.NoError(t, source.Add(sourceToken, Connection, []byte(sampleConnection)))
+	require.NoError(t, source.Add(sourceToken, Credential, []byte(fmt.Sprintf(sampleCredentialFmt, "urn:uuid:vc-1"))))
+	require.NoError(t, source.Add(sourceToken, Credential, []byte(fmt.Sprintf(sampleCredentialFmt, "urn:uuid:vc-2")),
+		AddByCollection(sampleCollectionID)))
+
+	// wallet keys
+	require.NoError(t, source.Add(sourceToken, Key, []byte(sampleKeyContentJwkValid)))
+
+	var kids []string
+
+	for _, keyType := range []kms.KeyType{kms.ED25519Type, kms.ECDSAP256TypeIEEEP1363, kms.BLS12381G2Type} {
+		keyPair, err := source.CreateKeyPair(sourceToken, keyType)
+		require.NoError(t, err)
+
+		kids = append(kids, keyPair.KeyID)
+	}
+
+	// key agreement keys can't be exported
+	_, err := source.CreateKeyPair(sourceToken, kms.NISTP256ECDHKWType)
+	require.NoError(t, err)
+
+	_, err = source.Export(sourceToken, WithExportPassphrase(sampleExportPassphrase))
+	require.ErrorIs(t, err, ErrKeyNotExportable)
+
+	exported, err := source.Export(sourceToken, WithExportPassphrase(sampleExportPassphrase),
+		WithExportSkippingUnexportableKeys())
+	require.NoError(t, err)
+	require.NotContains(t, string(exported), "urn:uuid:vc-1")
+
+	var locked encryptedWallet
+	require.NoError(t, json.Unmarshal(exported, &locked))
+	require.Equal(t, []string{encryptedWalletType}, locked.Type)
+
+	t.Run("import into new wallet", func(t *testing.T) {
+		target, targetToken := newOpenedWallet(t, newMockProvider(t))
+
+		require.NoError(t, target.Import(targetToken, exported, WithImportPassphrase(sampleExportPassphrase)))
+
+		for ct, count := range map[ContentType]int{
+			Collection: 1, Metadata: 1, DIDResolutionResponse: 1, Connection: 1, Credential: 2,
+		} {
+			contents, e := target.GetAll(targetToken, ct)
+			require.NoError(t, e)
+			require.Len(t, contents, count, ct)
+		}
+
+		credentials, err := target.GetAll(targetToken, Credential, FilterByCollection(sampleCollectionID))
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		require.Contains(t, credentials, "urn:uuid:vc-2")
+
+		sourceKeyManager, err := keyManager().getKeyManger(sourceToken)
+		require.NoError(t, err)
+
+		targetKeyManager, err := keyManager().getKeyManger(targetToken)
+		require.NoError(t, err)
+
+		for _, kid := range append(kids, "z6MkiEh8RQL83nkPo8ehDeX7") {
+			expected, e := sourceKeyManager.ExportPubKeyBytes(kid)
+			require.NoError(t, e)
+
+			imported, e := targetKeyManager.ExportPubKeyBytes(kid)
+			require.NoError(t, e)
+			require.Equal(t, expected, imported)
+		}
+
+		// imported keys can be exported again
+		keyIDs, err := target.contents.GetKeyIDs(targetToken)
+		require.NoError(t, err)
+		require.Len(t, keyIDs, len(kids)+1)
+
+		// contents already present in wallet
+		require.NoError(t, target.Import(targetToken, exported, WithImportPassphrase(sampleExportPassphrase)))
+		require.NoError(t, target.Import(targetToken, exported, WithImportPassphrase(sampleExportPassphrase),
+			WithImportConflictPolicy(ImportReplaceExisting)))
+
+		err = target.Import(targetToken, exported, WithImportPassphrase(sampleExportPassphrase),
+			WithImportConflictPolicy(ImportFailOnConflict))
+		require.True(t, errors.Is(err, errContentExists))
+
+		credentials, err = target.GetAll(targetToken, Credential, FilterByCollection(sampleCollectionID))
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+	})
+
+	t.Run("replace existing contents", func(t *testing.T) {
+		target, targetToken := newOpenedWallet(t, newMockProvider(t))
+
+		const changedName = "Acme Corporation"
+
+		require.NoError(t, target.Add(targetToken, Collection, []byte(fmt.Sprintf(`{
+			"@context": ["https://w3id.org/wallet/v1"],
+			"id": "%s",
+			"type": "Organization",
+			"name": "%s"
+		}`, sampleCollectionID, changedName))))
+
+		require.NoError(t, target.Import(targetToken, exported, WithImportPassphrase(sampleExportPassphrase)))
+
+		collection, err := target.Get(targetToken, Collection, sampleCollectionID)
+		require.NoError(t, err)
+		require.Contains(t, string(collection), changedName)
+
+		require.NoError(t, target.Import(targetToken, exported, WithImportPassphrase(sampleExportPassphrase),
+			WithImportConflictPolicy(ImportReplaceExisting)))
+
+		collection, err = target.Get(targetToken, Collection, sampleCollectionID)
+		require.NoError(t, err)
+		require.NotContains(t, string(collection), changedName)
+	})
+
+	t.Run("import with invalid passphrase", func(t *testing.T) {
+		target, targetToken := newOpenedWallet(t, newMockProvider(t))
+
+		err := target.Import(targetToken, exported, WithImportPassphrase("invalid"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decrypt wallet contents")
+	})
+}
+
+func TestWallet_ExportImportByKeyManagerKey(t *testing.T) {
+	mockctx := newMockProvider(t)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx.CryptoValue = cryptoSvc
+
+	walletInstance, token := newOpenedWallet(t, mockctx)
+
+	require.NoError(t, walletInstance.Add(token, Metadata, []byte(sampleContentValid)))
+
+	keyPair, err := walletInstance.CreateKeyPair(token, kms.NISTP256ECDHKWType)
+	require.NoError(t, err)
+
+	exported, err := walletInstance.Export(token, WithExportKeyID(keyPair.KeyID))
+	require.NoError(t, err)
+
+	require.NoError(t, walletInstance.Remove(token, Metadata, "did:example:123456789abcdefghi"))
+
+	require.NoError(t, walletInstance.Import(token, exported))
+
+	contents, err := walletInstance.GetAll(token, Metadata)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+
+	// key manager of other wallet doesn't have the key.
+	otherctx := newMockProvider(t)
+	otherctx.CryptoValue = cryptoSvc
+
+	other, otherToken := newOpenedWallet(t, otherctx)
+
+	err = other.Import(otherToken, exported)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decrypt wallet contents")
+
+	_, err = walletInstance.Export(token, WithExportKeyID("invalid"), WithExportSkippingUnexportableKeys())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to create JWE encrypter")
+}
+
+func TestWallet_ExportImportFailures(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newMockProvider(t))
+
+	t.Run("export failures", func(t *testing.T) {
+		_, err := walletInstance.Export(token)
+		require.EqualError(t, err, "passphrase or key ID is required for encrypting wallet contents")
+
+		_, err = walletInstance.Export(sampleFakeTkn, WithExportPassphrase(sampleExportPassphrase))
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+	})
+
+	t.Run("export fails on keys which can't be exported", func(t *testing.T) {
+		other, otherToken := newOpenedWallet(t, newMockProvider(t))
+
+		_, _, err := other.createKey(otherToken, kms.ED25519Type)
+		require.NoError(t, err)
+
+		x25519KID, _, err := other.createKey(otherToken, kms.X25519ECDHKWType)
+		require.NoError(t, err)
+
+		p256KID, _, err := other.createKey(otherToken, kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		_, err = other.Export(otherToken, WithExportPassphrase(sampleExportPassphrase))
+		require.ErrorIs(t, err, ErrKeyNotExportable)
+		require.Contains(t, err.Error(), x25519KID)
+		require.Contains(t, err.Error(), p256KID)
+	})
+
+	t.Run("import failures", func(t *testing.T) {
+		exported, err := walletInstance.Export(token, WithExportPassphrase(sampleExportPassphrase))
+		require.NoError(t, err)
+
+		err = walletInstance.Import(token, exported, WithImportConflictPolicy("invalid"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid import conflict policy 'invalid'")
+
+		err = walletInstance.Import(token, []byte("invalid"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid exported wallet")
+
+		err = walletInstance.Import(token, []byte(`{"id": "urn:uuid:123"}`))
+		require.EqualError(t, err, "invalid exported wallet: encrypted wallet contents not found")
+
+		err = walletInstance.Import(sampleFakeTkn, exported)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		err = walletInstance.Import(token, exported)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decrypt wallet contents")
+
+		for _, tc := range []struct {
+			content *walletContent
+			err     string
+		}{
+			{
+				content: &walletContent{ContentType: "invalid", Content: []byte(sampleContentValid)},
+				err:     "invalid content type 'invalid'",
+			},
+			{
+				content: &walletContent{ContentType: Key, Content: []byte(`"invalid"`)},
+				err:     "failed to import key content: failed to read key contents",
+			},
+			{
+				content: &walletContent{ContentType: Credential, Content: []byte(`"invalid"`)},
+				err:     "failed to import credential content: failed to read content to be saved",
+			},
+			{
+				content: &walletContent{
+					ContentType: Credential, CollectionID: "invalid", Content: []byte(sampleContentValid),
+				},
+				err: "failed to find existing collection with ID 'invalid'",
+			},
+		} {
+			err := walletInstance.Import(token, encryptWalletContents(t, walletInstance, token, tc.content),
+				WithImportPassphrase(sampleExportPassphrase))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		}
+	})
+}
+
+func newOpenedWallet(t *testing.T, mockctx *mockprovider.Provider) (*Wallet, string) {
+	t.Helper()
+
+	user := uuid.New().String()
+
+	require.NoError(t, CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase)))
+
+	walletInstance, err := New(user, mockctx)
+	require.NoError(t, err)
+
+	token, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { walletInstance.Close() })
+
+	return walletInstance, token
+}
+
+func encryptWalletContents(t *testing.T, walletInstance *Wallet, token string, contents ...*walletContent) []byte {
+	t.Helper()
+
+	contentBytes, err := json.Marshal(&walletContents{Contents: contents})
+	require.NoError(t, err)
+
+	encrypted, err := walletInstance.encryptContents(token, contentBytes,
+		&exportOpts{passphrase: sampleExportPassphrase})
+	require.NoError(t, err)
+
+	exported, err := json.Marshal(&encryptedWallet{EncryptedWalletContents: encrypted})
+	require.NoError(t, err)
+
+	return exported
+}
@@ -8,10 +8,14 @@ package wallet
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"strings"
 	"sync"
@@ -19,11 +23,19 @@ import (
 
 	"github.com/bluele/gcache"
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
 	"github.com/google/tink/go/subtle/random"
 	"github.com/google/uuid"
+	gojose "github.com/square/go-jose/v3"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
@@ -51,13 +63,24 @@ const (
 	Bls12381G1Key2020          = "bls12381g1key2020"
 )
 
+// key type of the exported keys.
+const jsonWebKey2020 = "JsonWebKey2020"
+
+// type URLs of the private keys which can be exported from local key manager.
+const (
+	ed25519PrivateKeyTypeURL = "type.googleapis.com/google.crypto.tink.Ed25519PrivateKey"
+	ecdsaPrivateKeyTypeURL   = "type.googleapis.com/google.crypto.tink.EcdsaPrivateKey"
+	bbsPrivateKeyTypeURL     = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey"
+)
+
 // supported JWK curves for jwk private key import.
 // nolint: gochecknoglobals
 var jwkCurves = map[string]kms.KeyType{
-	"Ed25519":    kms.ED25519Type,
-	"P-256":      kms.ECDSAP256TypeIEEEP1363,
-	"P-384":      kms.ECDSAP384TypeIEEEP1363,
-	"BLS12381G2": kms.BLS12381G2Type,
+	"Ed25519":     kms.ED25519Type,
+	"P-256":       kms.ECDSAP256TypeIEEEP1363,
+	"P-384":       kms.ECDSAP384TypeIEEEP1363,
+	"BLS12381G2":  kms.BLS12381G2Type,
+	"BLS12381_G2": kms.BLS12381G2Type,
 }
 
 // errors.
@@ -70,6 +93,13 @@ var (
 
 	// ErrInvalidAuthToken when auth token provided to wallet is unable to unlock key manager.
 	ErrInvalidAuthToken = errors.New("invalid auth token")
+
+	// ErrKeyNotExportable when key material of a key of local key manager can't be exported from wallet,
+	// e.g. key agreement keys, which fails wallet export.
+	ErrKeyNotExportable = errors.New("key material is not exportable")
+
+	// errRemoteKey when key material of a key can't be exported as key is kept by remote key manager.
+	errRemoteKey = errors.New("key is kept by remote key manager")
 )
 
 // walletKMSInstance is key manager store singleton - access only via keyManager()
@@ -82,17 +112,17 @@ var (
 func keyManager() *walletKeyManager {
 	kmsStoreOnce.Do(func() {
 		walletKMSInstance = &walletKeyManager{
-			gstore: gcache.New(0).Build(),
+			sessions: make(map[string]*session),
 		}
 	})
 
 	return walletKMSInstance
 }
 
-// walletKeyManager manages key manager instances in cache.
-// underlying gcache is threasafe, no need of locks.
+// walletKeyManager manages key manager instances of unlocked wallet sessions.
 type walletKeyManager struct {
-	gstore gcache.Cache
+	sessions map[string]*session
+	lock     sync.Mutex
 }
 
 func (k *walletKeyManager) createKeyManager(profileInfo *profile,
@@ -101,10 +131,18 @@ func (k *walletKeyManager) createKeyManager(profileInfo *profile,
 		return "", fmt.Errorf("invalid wallet profile")
 	}
 
-	// get user from cache if token already exists
-	token, _ := k.getKeyMangerToken(profileInfo.User) //nolint: errcheck
-	if token != "" {
-		return "", ErrAlreadyUnlocked
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	k.purgeExpired(profileInfo.User)
+
+	// only one session per user is allowed unless concurrent session is requested.
+	if !opts.concurrentSession {
+		for _, s := range k.sessions {
+			if s.User == profileInfo.User {
+				return "", ErrAlreadyUnlocked
+			}
+		}
 	}
 
 	var err error
@@ -125,56 +163,68 @@ func (k *walletKeyManager) createKeyManager(profileInfo *profile,
 	}
 
 	// generate token
-	token = uuid.New().String()
-
-	// save key manager
-	err = k.saveKeyManger(profileInfo.User, token, keyManager, opts.tokenExpiry)
-	if err != nil {
-		return "", fmt.Errorf("failed to persist local key manager: %w", err)
-	}
+	s := newSession(profileInfo.User, profileInfo.ID, uuid.New().String(), keyManager, opts)
 
-	return token, nil
-}
+	// persist session details
+	if !opts.disableSessionPersistence {
+		s.store, err = newSessionStore(storeProvider)
+		if err != nil {
+			return "", err
+		}
 
-// TODO refresh expiry on each access.
-func (k *walletKeyManager) saveKeyManger(user, key string, manager kms.KeyManager, expiration time.Duration) error {
-	if expiration == 0 {
-		expiration = defaultCacheExpiry
+		err = s.store.put(&s.Session)
+		if err != nil {
+			return "", fmt.Errorf("failed to persist wallet session: %w", err)
+		}
 	}
 
-	err := k.gstore.SetWithExpire(user, key, expiration)
-	if err != nil {
-		return err
-	}
+	k.sessions[s.token] = s
 
-	return k.gstore.SetWithExpire(key, manager, expiration)
+	return s.token, nil
 }
 
-func (k *walletKeyManager) getKeyManger(key string) (kms.KeyManager, error) {
-	val, err := k.gstore.Get(key)
+func (k *walletKeyManager) getKeyManger(token string) (kms.KeyManager, error) {
+	s, err := k.getSession(token)
 	if err != nil {
 		return nil, err
 	}
 
-	return val.(kms.KeyManager), nil
+	return s.keyManager, nil
 }
 
 func (k *walletKeyManager) getKeyMangerToken(user string) (string, error) {
-	val, err := k.gstore.Get(user)
-	if err != nil {
-		return "", err
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	k.purgeExpired(user)
+
+	for token, s := range k.sessions {
+		if s.User == user {
+			return token, nil
+		}
 	}
 
-	return val.(string), nil
+	return "", gcache.KeyNotFoundError
 }
 
+// removeKeyManager ends all sessions of given user, returns false if no active session found.
 func (k *walletKeyManager) removeKeyManager(user string) bool {
-	token, _ := k.getKeyMangerToken(user) //nolint: errcheck
-	if token != "" {
-		return k.gstore.Remove(token) && k.gstore.Remove(user)
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	k.purgeExpired(user)
+
+	removed := false
+
+	for _, s := range k.sessions {
+		if s.User == user {
+			k.endSession(s, false)
+
+			removed = true
+		}
 	}
 
-	return false
+	return removed
 }
 
 // createMasterLock creates master lock from secret lock service provided.
@@ -252,7 +302,7 @@ type kmsSigner struct {
 	multiMsg  bool
 }
 
-func newKMSSigner(authToken string, c crypto.Crypto, opts *ProofOptions) (*kmsSigner, error) {
+func newKMSSigner(authToken string, c crypto.Crypto, keyID string, opts *ProofOptions) (*kmsSigner, error) {
 	keyManager, err := keyManager().getKeyManger(authToken)
 	if err != nil {
 		if errors.Is(err, gcache.KeyNotFoundError) {
@@ -262,13 +312,7 @@ func newKMSSigner(authToken string, c crypto.Crypto, opts *ProofOptions) (*kmsSi
 		return nil, fmt.Errorf("failed to get key manager: %w", err)
 	}
 
-	vmSplit := strings.Split(opts.VerificationMethod, "#")
-
-	if len(vmSplit) != vmSectionCount {
-		return nil, errors.New("invalid verification method format")
-	}
-
-	keyHandler, err := keyManager.Get(vmSplit[vmSectionCount-1])
+	keyHandler, err := keyManager.Get(keyID)
 	if err != nil {
 		return nil, err
 	}
@@ -304,69 +348,193 @@ func (s *kmsSigner) Sign(data []byte) ([]byte, error) {
 
 // importKeyJWK imports private key jwk found in key contents,
 // supported curve types - Ed25519, P-256, BLS12381G2.
-func importKeyJWK(auth string, key *keyContent) error {
+func importKeyJWK(auth string, key *keyContent) (string, error) {
 	keyManager, err := keyManager().getKeyManger(auth)
 	if err != nil {
 		if errors.Is(err, gcache.KeyNotFoundError) {
-			return ErrWalletLocked
+			return "", ErrWalletLocked
 		}
 
-		return fmt.Errorf("failed to get key manager: %w", err)
+		return "", fmt.Errorf("failed to get key manager: %w", err)
 	}
 
 	var j jwk.JWK
 	if e := j.UnmarshalJSON(key.PrivateKeyJwk); e != nil {
-		return fmt.Errorf("failed to unmarshal jwk : %w", e)
+		return "", fmt.Errorf("failed to unmarshal jwk : %w", e)
 	}
 
 	keyType, ok := jwkCurves[j.Crv]
 	if !ok {
-		return fmt.Errorf("unsupported Key type %s", j.Crv)
+		return "", fmt.Errorf("unsupported Key type %s", j.Crv)
 	}
 
-	_, _, err = keyManager.ImportPrivateKey(j.Key, keyType, kms.WithKeyID(getKIDFromJWK(key.ID, &j)))
+	kid, _, err := keyManager.ImportPrivateKey(j.Key, keyType, kms.WithKeyID(getKIDFromJWK(key.ID, &j)))
 	if err != nil {
-		return fmt.Errorf("failed to import jwk key : %w", err)
+		return "", fmt.Errorf("failed to import jwk key : %w", err)
 	}
 
-	return nil
+	return kid, nil
 }
 
 // importKeyBase58 imports private key base58 found in key contents,
 // supported types - Ed25519Signature2018, Bls12381G1Key2020.
-func importKeyBase58(auth string, key *keyContent) error {
+func importKeyBase58(auth string, key *keyContent) (string, error) {
 	keyManager, err := keyManager().getKeyManger(auth)
 	if err != nil {
 		if errors.Is(err, gcache.KeyNotFoundError) {
-			return ErrWalletLocked
+			return "", ErrWalletLocked
 		}
 
-		return fmt.Errorf("failed to get key manager: %w", err)
+		return "", fmt.Errorf("failed to get key manager: %w", err)
 	}
 
 	switch strings.ToLower(key.KeyType) {
 	case Ed25519VerificationKey2018:
 		edPriv := ed25519.PrivateKey(base58.Decode(key.PrivateKeyBase58))
 
-		_, _, err := keyManager.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(getKID(key.ID)))
+		kid, _, err := keyManager.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(getKID(key.ID)))
 		if err != nil {
-			return fmt.Errorf("failed to import Ed25519Signature2018 key : %w", err)
+			return "", fmt.Errorf("failed to import Ed25519Signature2018 key : %w", err)
 		}
+
+		return kid, nil
 	case Bls12381G1Key2020:
 		blsKey, err := bbs12381g2pub.UnmarshalPrivateKey(base58.Decode(key.PrivateKeyBase58))
 		if err != nil {
-			return fmt.Errorf("failed to unmarshal %s private key : %w", kms.BLS12381G2Type, err)
+			return "", fmt.Errorf("failed to unmarshal %s private key : %w", kms.BLS12381G2Type, err)
 		}
 
-		_, _, err = keyManager.ImportPrivateKey(blsKey, kms.BLS12381G2, kms.WithKeyID(getKID(key.ID)))
+		kid, _, err := keyManager.ImportPrivateKey(blsKey, kms.BLS12381G2, kms.WithKeyID(getKID(key.ID)))
 		if err != nil {
-			return fmt.Errorf("failed to import Ed25519Signature2018 key : %w", err)
+			return "", fmt.Errorf("failed to import Ed25519Signature2018 key : %w", err)
+		}
+
+		return kid, nil
+	default:
+		return "", errors.New("only Ed25519VerificationKey2018 &  Bls12381G1Key2020 are supported in base58 format")
+	}
+}
+
+// keyExists checks if key manager already has a key with the key ID of the key content,
+// returns false if key ID of the key content is to be generated by key manager.
+func keyExists(auth string, key *keyContent) (bool, error) {
+	keyManager, err := keyManager().getKeyManger(auth)
+	if err != nil {
+		return false, ErrWalletLocked
+	}
+
+	kid := getKID(key.ID)
+
+	if len(key.PrivateKeyJwk) > 0 {
+		var j jwk.JWK
+		if e := j.UnmarshalJSON(key.PrivateKeyJwk); e != nil {
+			return false, fmt.Errorf("failed to unmarshal jwk : %w", e)
+		}
+
+		kid = getKIDFromJWK(key.ID, &j)
+	}
+
+	if kid == "" {
+		return false, nil
+	}
+
+	_, err = keyManager.Get(kid)
+
+	return err == nil, nil
+}
+
+// exportKey exports private key of the wallet key manager key as wallet key content.
+// Only keys of local key manager can be exported, supported key types - Ed25519, ECDSA and BLS12381G2.
+func exportKey(auth, kid string) (*keyContent, error) {
+	keyManager, err := keyManager().getKeyManger(auth)
+	if err != nil {
+		return nil, ErrWalletLocked
+	}
+
+	kh, err := keyManager.Get(kid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key '%s' : %w", kid, err)
+	}
+
+	handle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, fmt.Errorf("key '%s': %w", kid, errRemoteKey)
+	}
+
+	privKey, err := getPrivateKey(handle)
+	if err != nil {
+		return nil, fmt.Errorf("key '%s': %w", kid, err)
+	}
+
+	privateKeyJWK, err := json.Marshal(&jwk.JWK{JSONWebKey: gojose.JSONWebKey{Key: privKey, KeyID: kid}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key '%s' : %w", kid, err)
+	}
+
+	return &keyContent{ID: "#" + kid, KeyType: jsonWebKey2020, PrivateKeyJwk: privateKeyJWK}, nil
+}
+
+// getPrivateKey reads private key of the primary key of the keyset.
+func getPrivateKey(kh *keyset.Handle) (interface{}, error) {
+	mem := &keyset.MemReaderWriter{}
+
+	if err := insecurecleartextkeyset.Write(kh, mem); err != nil {
+		return nil, fmt.Errorf("failed to read keyset : %w", err)
+	}
+
+	for _, key := range mem.Keyset.Key {
+		if key.KeyId != mem.Keyset.PrimaryKeyId {
+			continue
+		}
+
+		switch key.KeyData.TypeUrl {
+		case ed25519PrivateKeyTypeURL:
+			privKey := &ed25519pb.Ed25519PrivateKey{}
+			if err := proto.Unmarshal(key.KeyData.Value, privKey); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal Ed25519 private key : %w", err)
+			}
+
+			return ed25519.NewKeyFromSeed(privKey.KeyValue), nil
+		case ecdsaPrivateKeyTypeURL:
+			privKey := &ecdsapb.EcdsaPrivateKey{}
+			if err := proto.Unmarshal(key.KeyData.Value, privKey); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal ECDSA private key : %w", err)
+			}
+
+			return getECDSAPrivateKey(privKey)
+		case bbsPrivateKeyTypeURL:
+			privKey := &bbspb.BBSPrivateKey{}
+			if err := proto.Unmarshal(key.KeyData.Value, privKey); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal BBS+ private key : %w", err)
+			}
+
+			return bbs12381g2pub.UnmarshalPrivateKey(privKey.KeyValue)
 		}
+	}
+
+	return nil, ErrKeyNotExportable
+}
+
+func getECDSAPrivateKey(privKey *ecdsapb.EcdsaPrivateKey) (*ecdsa.PrivateKey, error) {
+	var curve elliptic.Curve
+
+	switch privKey.PublicKey.Params.Curve {
+	case commonpb.EllipticCurveType_NIST_P256:
+		curve = elliptic.P256()
+	case commonpb.EllipticCurveType_NIST_P384:
+		curve = elliptic.P384()
 	default:
-		return errors.New("only Ed25519VerificationKey2018 &  Bls12381G1Key2020 are supported in base58 format")
+		return nil, ErrKeyNotExportable
 	}
 
-	return nil
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(privKey.PublicKey.X),
+			Y:     new(big.Int).SetBytes(privKey.PublicKey.Y),
+		},
+		D: new(big.Int).SetBytes(privKey.KeyValue),
+	}, nil
 }
 
 func getKID(id string) string {
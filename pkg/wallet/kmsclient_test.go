@@ -29,6 +29,16 @@ const (
 	sampleKeyMgrErr     = "sample-keymgr-err"
 )
 
+// saveKeyManger saves given key manager as a wallet session of given user and token.
+func (k *walletKeyManager) saveKeyManger(user, token string, manager kmsapi.KeyManager, expiration time.Duration) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	k.sessions[token] = newSession(user, "", token, manager, &unlockOpts{tokenExpiry: expiration})
+
+	return nil
+}
+
 func TestKeyManagerStore(t *testing.T) {
 	t.Run("test key manager instance", func(t *testing.T) {
 		require.NotEmpty(t, keyManager())
@@ -362,14 +372,14 @@ func TestImportKeyJWK(t *testing.T) {
 			tc := test
 			t.Run(tc.name, func(t *testing.T) {
 				if tc.error != "" {
-					err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: tc.sampleJWK, ID: tc.ID})
+					_, err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: tc.sampleJWK, ID: tc.ID})
 					require.Error(t, err)
 					require.Contains(t, err.Error(), tc.error)
 
 					return
 				}
 
-				err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: tc.sampleJWK, ID: tc.ID})
+				_, err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: tc.sampleJWK, ID: tc.ID})
 				require.NoError(t, err)
 
 				kmgr, err := keyManager().getKeyManger(tkn)
@@ -383,7 +393,7 @@ func TestImportKeyJWK(t *testing.T) {
 	})
 
 	t.Run("test key ID already exists", func(t *testing.T) {
-		err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
+		_, err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
 							"kty": "OKP",
 							"d":"Dq5t2WS3OMzcpkh8AyVxJs5r9v4L39ocIz9CpUOqM40",
 							"crv": "Ed25519",
@@ -393,7 +403,7 @@ func TestImportKeyJWK(t *testing.T) {
 		require.NoError(t, err)
 
 		// import different key with same key ID
-		err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
+		_, err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
       						"kty": "EC",
       						"crv": "P-384",
       						"x": "eQbMauiHc9HuiqXT894gW5XTCrOpeY8cjLXAckfRtdVBLzVHKaiXAAxBFeVrSB75",
@@ -405,7 +415,7 @@ func TestImportKeyJWK(t *testing.T) {
 		require.Contains(t, err.Error(), "requested ID 'z6MkiEh8RQL83nkPo8ehDeX7' already exists")
 
 		// import different key with same content ID (missing kid)
-		err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
+		_, err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
       						"kty": "EC",
       						"crv": "P-384",
       						"x": "eQbMauiHc9HuiqXT894gW5XTCrOpeY8cjLXAckfRtdVBLzVHKaiXAAxBFeVrSB75",
@@ -416,7 +426,7 @@ func TestImportKeyJWK(t *testing.T) {
 		require.Contains(t, err.Error(), "requested ID 'z6MkiEh8RQL83nkPo8ehDeX7' already exists")
 
 		// no KID
-		err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
+		_, err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
 							"kty": "OKP",
 							"d":"Dq5t2WS3OMzcpkh8AyVxJs5r9v4L39ocIz9CpUOqM40",
 							"crv": "Ed25519",
@@ -426,7 +436,7 @@ func TestImportKeyJWK(t *testing.T) {
 	})
 
 	t.Run("test key manager errors", func(t *testing.T) {
-		err := importKeyJWK(tkn+"invalid", &keyContent{PrivateKeyJwk: []byte(`{
+		_, err := importKeyJWK(tkn+"invalid", &keyContent{PrivateKeyJwk: []byte(`{
 							"kty": "OKP",
 							"d":"Dq5t2WS3OMzcpkh8AyVxJs5r9v4L39ocIz9CpUOqM40",
 							"crv": "Ed25519",
@@ -498,7 +508,7 @@ func TestImportKeyBase58(t *testing.T) {
 			tc := test
 			t.Run(tc.name, func(t *testing.T) {
 				if tc.error != "" {
-					err := importKeyBase58(tkn, &keyContent{
+					_, err := importKeyBase58(tkn, &keyContent{
 						ID:               tc.ID,
 						PrivateKeyBase58: tc.keyBase58,
 						KeyType:          tc.keyType,
@@ -509,7 +519,7 @@ func TestImportKeyBase58(t *testing.T) {
 					return
 				}
 
-				err := importKeyBase58(tkn, &keyContent{
+				_, err := importKeyBase58(tkn, &keyContent{
 					ID:               tc.ID,
 					PrivateKeyBase58: tc.keyBase58,
 					KeyType:          tc.keyType,
@@ -527,14 +537,14 @@ func TestImportKeyBase58(t *testing.T) {
 	})
 
 	t.Run("test key ID already exists", func(t *testing.T) {
-		err := importKeyBase58(tkn, &keyContent{
+		_, err := importKeyBase58(tkn, &keyContent{
 			ID:               "did:example:123#z6MkiEh8RQL83nkPo8ehDeE4",
 			PrivateKeyBase58: "zJRjGFZydU5DBdS2p5qbiUzDFAxbXTkjiDuGPksMBbY5TNyEsGfK4a4WGKjBCh1zeNryeuKtPotp8W1ESnwP71y",
 			KeyType:          "Ed25519VerificationKey2018",
 		})
 		require.NoError(t, err)
 
-		err = importKeyBase58(tkn, &keyContent{
+		_, err = importKeyBase58(tkn, &keyContent{
 			ID:               "did:example:123#z6MkiEh8RQL83nkPo8ehDeE4",
 			PrivateKeyBase58: "zJRjGFZydU5DBdS2p5qbiUzDFAxbXTkjiDuGPksMBbY5TNyEsGfK4a4WGKjBCh1zeNryeuKtPotp8W1ESnwP71y",
 			KeyType:          "Ed25519VerificationKey2018",
@@ -544,7 +554,7 @@ func TestImportKeyBase58(t *testing.T) {
 	})
 
 	t.Run("test key manager errors", func(t *testing.T) {
-		err := importKeyBase58(tkn+"invalid", &keyContent{
+		_, err := importKeyBase58(tkn+"invalid", &keyContent{
 			ID:               "did:example:123#z6MkiEh8RQL83nkPo8ehDeE5",
 			PrivateKeyBase58: "zJRjGFZydU5DBdS2p5qbiUzDFAxbXTkjiDuGPksMBbY5TNyEsGfK4a4WGKjBCh1zeNryeuKtPotp8W1ESnwP71y",
 			KeyType:          "Ed25519VerificationKey2018",
@@ -561,7 +571,7 @@ func TestImportKeyBase58(t *testing.T) {
 			&mockkms.KeyManager{ImportPrivateKeyErr: sampleErr}, 0)
 		require.NoError(t, err)
 
-		err = importKeyBase58(mockToken, &keyContent{
+		_, err = importKeyBase58(mockToken, &keyContent{
 			ID:               "did:example:123#z6MkiEh8RQL83nkPo8ehDeE5",
 			PrivateKeyBase58: "zJRjGFZydU5DBdS2p5qbiUzDFAxbXTkjiDuGPksMBbY5TNyEsGfK4a4WGKjBCh1zeNryeuKtPotp8W1ESnwP71y",
 			KeyType:          "Ed25519VerificationKey2018",
@@ -569,7 +579,7 @@ func TestImportKeyBase58(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, errors.Is(err, sampleErr))
 
-		err = importKeyBase58(mockToken, &keyContent{
+		_, err = importKeyBase58(mockToken, &keyContent{
 			ID:               "did:example:123#z6MkiEh8RQL83nkPo8ehDeE5",
 			PrivateKeyBase58: "6gsgGpdx7p1nYoKJ4b5fKt1xEomWdnemg9nJFX6mqNCh",
 			KeyType:          "Bls12381G1Key2020",
@@ -586,22 +596,13 @@ func TestKMSSigner(t *testing.T) {
 
 	t.Run("test kms signer errors", func(t *testing.T) {
 		// invalid auth
-		signer, err := newKMSSigner("invalid", &mockcrypto.Crypto{}, &ProofOptions{})
+		signer, err := newKMSSigner("invalid", &mockcrypto.Crypto{}, "", &ProofOptions{})
 		require.True(t, errors.Is(err, ErrWalletLocked))
 		require.Empty(t, signer)
 
-		// invalid verification method
-		signer, err = newKMSSigner(token, &mockcrypto.Crypto{}, &ProofOptions{
-			VerificationMethod: "invalid",
-		})
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "invalid verification method format")
-		require.Empty(t, signer)
-
 		// sign error
-		signer, err = newKMSSigner(token, &mockcrypto.Crypto{SignErr: errors.New(sampleKeyMgrErr)}, &ProofOptions{
-			VerificationMethod: "did:example#123",
-		})
+		signer, err = newKMSSigner(token, &mockcrypto.Crypto{SignErr: errors.New(sampleKeyMgrErr)}, "123",
+			&ProofOptions{VerificationMethod: "did:example#123"})
 		require.NoError(t, err)
 
 		res, err := signer.Sign([]byte("1234"))
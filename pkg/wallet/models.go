@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
 // QueryParams contains credential queries for querying credential from wallet.
@@ -31,6 +32,7 @@ type QueryParams struct {
 //
 type ProofOptions struct {
 	// Controller is a DID to be for signing. This option is required for issue/prove wallet features.
+	// Optional if wallet has a default DID, which will then be used as controller.
 	Controller string `json:"controller,omitempty"`
 	// VerificationMethod is the URI of the verificationMethod used for the proof.
 	// Optional, by default Controller public key matching 'assertion' for issue or 'authentication' for prove functions.
@@ -47,6 +49,9 @@ type ProofOptions struct {
 	// ProofType is signature type used for signing.
 	// Optional, by default proof will be generated in Ed25519Signature2018 format.
 	ProofType string `json:"proofType,omitempty"`
+	// Cryptosuite is the cryptosuite of 'DataIntegrityProof' proof type, 'eddsa-rdfc-2022' or 'ecdsa-rdfc-2019'.
+	// Optional, by default 'eddsa-rdfc-2022' will be used for 'DataIntegrityProof'.
+	Cryptosuite string `json:"cryptosuite,omitempty"`
 	// ProofRepresentation is type of proof data expected, (Refer verifiable.SignatureProofValue)
 	// Optional, by default proof will be represented as 'verifiable.SignatureProofValue'.
 	ProofRepresentation *verifiable.SignatureRepresentation `json:"proofRepresentation,omitempty"`
@@ -110,3 +115,31 @@ type CredentialInteractionStatus struct {
 	// Optional web redirect URL info sent by verifier.
 	RedirectURL string `json:"url,omitempty"`
 }
+
+// DIDRecord is wallet content of 'managedDID' type, model of a DID created and managed by wallet.
+type DIDRecord struct {
+	// ID of the DID.
+	ID string `json:"id"`
+	// Method of the DID.
+	Method string `json:"method"`
+	// Default is true if this DID is default DID for signing.
+	Default bool `json:"default,omitempty"`
+	// Deactivated is true if this DID is deactivated.
+	Deactivated bool `json:"deactivated,omitempty"`
+	// KeyBindings binds verification methods of the DID to wallet keys.
+	KeyBindings []*DIDKeyBinding `json:"keyBindings,omitempty"`
+	// Created time of the DID.
+	Created *time.Time `json:"created,omitempty"`
+	// Updated time of the DID.
+	Updated *time.Time `json:"updated,omitempty"`
+}
+
+// DIDKeyBinding binds a verification method of a wallet DID to a wallet key.
+type DIDKeyBinding struct {
+	// VerificationMethod is ID of the verification method.
+	VerificationMethod string `json:"verificationMethod"`
+	// KeyID is ID of the wallet key.
+	KeyID string `json:"keyID"`
+	// KeyType is type of the wallet key.
+	KeyType kms.KeyType `json:"keyType"`
+}
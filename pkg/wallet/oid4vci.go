@@ -0,0 +1,1303 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// PreAuthorizedCodeGrantType is grant type of OpenID4VCI pre-authorized code flow.
+	PreAuthorizedCodeGrantType = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+
+	// AuthorizationCodeGrantType is grant type of OpenID4VCI authorization code flow.
+	AuthorizationCodeGrantType = "authorization_code"
+
+	// JWTVCJSONFormat is OpenID4VCI format of W3C credentials secured as JWT.
+	JWTVCJSONFormat = "jwt_vc_json"
+
+	// LDPVCFormat is OpenID4VCI format of W3C credentials secured with linked data proofs.
+	LDPVCFormat = "ldp_vc"
+
+	// SDJWTVCFormat is OpenID4VCI format of credentials secured as SD-JWT.
+	SDJWTVCFormat = "vc+sd-jwt"
+
+	credentialOfferParam    = "credential_offer"
+	credentialOfferURIParam = "credential_offer_uri"
+
+	credentialIssuerMetadataPath = "/.well-known/openid-credential-issuer"
+	oauthServerMetadataPath      = "/.well-known/oauth-authorization-server"
+	openIDConfigurationPath      = "/.well-known/openid-configuration"
+
+	issuanceContentType   = "application/json"
+	formContentType       = "application/x-www-form-urlencoded"
+	bearerTokenType       = "Bearer"
+	proofJWTType          = "openid4vci-proof+jwt"
+	proofTypeJWT          = "jwt"
+	openIDCredentialType  = "openid_credential"
+	pkceChallengeMethod   = "S256"
+	pkceVerifierLength    = 32
+	stateLength           = 16
+	invalidProofErrorCode = "invalid_proof"
+	invalidNonceErrorCode = "invalid_nonce"
+
+	sdJWTSeparator       = "~"
+	sdJWTDigestAlgorithm = "sha-256"
+	sdJWTDigestsClaim    = "_sd"
+	sdJWTAlgorithmClaim  = "_sd_alg"
+	sdJWTArrayElement    = "..."
+	sdJWTClaimDisclosure = 3
+	sdJWTArrayDisclosure = 2
+
+	verifiableCredentialContext = "https://www.w3.org/2018/credentials/v1"
+)
+
+// ErrTxCodeRequired is returned when pre-authorized code grant of credential offer requires a transaction code
+// which isn't provided.
+var ErrTxCodeRequired = errors.New("transaction code is required by credential offer")
+
+// CredentialOffer is credential offer of an OpenID4VCI credential issuer.
+// https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0-13.html#name-credential-offer-parameters
+type CredentialOffer struct {
+	// CredentialIssuer is URL of the credential issuer.
+	CredentialIssuer string `json:"credential_issuer"`
+
+	// CredentialConfigurationIDs are IDs of offered credentials in metadata of the credential issuer.
+	CredentialConfigurationIDs []string `json:"credential_configuration_ids"`
+
+	// Grants are grants the wallet can use to obtain access token, wallet decides the grant if not provided.
+	Grants *CredentialOfferGrants `json:"grants,omitempty"`
+}
+
+// CredentialOfferGrants contains grants of a credential offer.
+type CredentialOfferGrants struct {
+	AuthorizationCode *AuthorizationCodeGrant `json:"authorization_code,omitempty"`
+	PreAuthorizedCode *PreAuthorizedCodeGrant `json:"urn:ietf:params:oauth:grant-type:pre-authorized_code,omitempty"`
+}
+
+// AuthorizationCodeGrant is authorization code grant of a credential offer.
+type AuthorizationCodeGrant struct {
+	// IssuerState binds authorization request to the credential offer.
+	IssuerState string `json:"issuer_state,omitempty"`
+
+	// AuthorizationServer to be used if credential issuer uses more than one authorization server.
+	AuthorizationServer string `json:"authorization_server,omitempty"`
+}
+
+// PreAuthorizedCodeGrant is pre-authorized code grant of a credential offer.
+type PreAuthorizedCodeGrant struct {
+	PreAuthorizedCode string `json:"pre-authorized_code"`
+
+	// TxCode describes transaction code sent to holder out of band, if required by the issuer.
+	TxCode *TxCode `json:"tx_code,omitempty"`
+
+	// AuthorizationServer to be used if credential issuer uses more than one authorization server.
+	AuthorizationServer string `json:"authorization_server,omitempty"`
+}
+
+// TxCode describes transaction code of pre-authorized code grant.
+type TxCode struct {
+	InputMode   string `json:"input_mode,omitempty"`
+	Length      int    `json:"length,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// IssuanceAuthorization is authorization request of OpenID4VCI authorization code flow,
+// to be kept by the wallet until the holder is redirected back with authorization code.
+type IssuanceAuthorization struct {
+	// Offer being authorized.
+	Offer *CredentialOffer `json:"offer"`
+
+	// CredentialConfigurationIDs are IDs of offered credentials being requested.
+	CredentialConfigurationIDs []string `json:"credentialConfigurationIds"`
+
+	// AuthorizationURL to which holder has to be redirected for authorizing issuance.
+	AuthorizationURL string `json:"authorizationUrl"`
+
+	// State to be returned with authorization code.
+	State string `json:"state"`
+
+	// CodeVerifier is PKCE code verifier of the authorization request.
+	CodeVerifier string `json:"codeVerifier"`
+
+	ClientID    string `json:"clientId"`
+	RedirectURI string `json:"redirectUri"`
+}
+
+// IssuedCredential is credential received from OpenID4VCI credential issuer and added to wallet.
+// JWT and SD-JWT credentials are saved in this form as 'SecuredCredential' wallet content.
+type IssuedCredential struct {
+	// ID of the credential content in wallet.
+	ID string `json:"id"`
+
+	// ConfigurationID of the credential in metadata of the credential issuer.
+	ConfigurationID string `json:"configurationId"`
+
+	// Format of the issued credential.
+	Format string `json:"format"`
+
+	// Credential as issued, JWT and SD-JWT credentials are strings.
+	Credential json.RawMessage `json:"credential"`
+
+	// content to be added to wallet.
+	content json.RawMessage
+}
+
+// credentialIssuerMetadata is metadata of OpenID4VCI credential issuer.
+type credentialIssuerMetadata struct {
+	CredentialIssuer                  string                              `json:"credential_issuer"`
+	AuthorizationServers              []string                            `json:"authorization_servers,omitempty"`
+	CredentialEndpoint                string                              `json:"credential_endpoint"`
+	NonceEndpoint                     string                              `json:"nonce_endpoint,omitempty"`
+	CredentialConfigurationsSupported map[string]*credentialConfiguration `json:"credential_configurations_supported"`
+}
+
+// credentialConfiguration is metadata of a credential supported by credential issuer.
+type credentialConfiguration struct {
+	Format               string                `json:"format"`
+	Scope                string                `json:"scope,omitempty"`
+	CredentialDefinition *credentialDefinition `json:"credential_definition,omitempty"`
+	VCT                  string                `json:"vct,omitempty"`
+}
+
+// credentialDefinition describes W3C credential of a credential configuration, claims of the credential aren't
+// requested explicitly.
+type credentialDefinition struct {
+	Context []interface{} `json:"@context,omitempty"`
+	Type    []string      `json:"type"`
+}
+
+// authorizationServerMetadata is metadata of OAuth authorization server.
+type authorizationServerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// authorizationDetail requests a credential in authorization request.
+type authorizationDetail struct {
+	Type                      string `json:"type"`
+	CredentialConfigurationID string `json:"credential_configuration_id"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	CNonce      string `json:"c_nonce,omitempty"`
+}
+
+type nonceResponse struct {
+	CNonce string `json:"c_nonce"`
+}
+
+type credentialRequest struct {
+	Format               string                  `json:"format"`
+	CredentialDefinition *credentialDefinition   `json:"credential_definition,omitempty"`
+	VCT                  string                  `json:"vct,omitempty"`
+	Proof                *credentialRequestProof `json:"proof"`
+}
+
+type credentialRequestProof struct {
+	ProofType string `json:"proof_type"`
+	JWT       string `json:"jwt"`
+}
+
+// credentialResponse is response of credential endpoint, a single 'credential' is returned by draft issuers
+// and 'credentials' by issuers supporting batch issuance.
+type credentialResponse struct {
+	Credential    json.RawMessage `json:"credential,omitempty"`
+	Credentials   []*issuedItem   `json:"credentials,omitempty"`
+	TransactionID string          `json:"transaction_id,omitempty"`
+	CNonce        string          `json:"c_nonce,omitempty"`
+}
+
+type issuedItem struct {
+	Credential json.RawMessage `json:"credential"`
+}
+
+// proofClaims are claims of proof of possession JWT.
+type proofClaims struct {
+	Issuer   string `json:"iss,omitempty"`
+	Audience string `json:"aud"`
+	IssuedAt int64  `json:"iat"`
+	Nonce    string `json:"nonce,omitempty"`
+}
+
+// oauthError is error response of authorization server or credential issuer.
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	CNonce      string `json:"c_nonce,omitempty"`
+	status      int
+}
+
+func (e *oauthError) Error() string {
+	if e.Description == "" {
+		return fmt.Sprintf("%s (status %d)", e.Code, e.status)
+	}
+
+	return fmt.Sprintf("%s: %s (status %d)", e.Code, e.Description, e.status)
+}
+
+// jwtSigner signs JWTs with wallet keys.
+type jwtSigner struct {
+	*kmsSigner
+	alg string
+}
+
+func (s *jwtSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: s.alg}
+}
+
+// ParseCredentialOffer parses OpenID4VCI credential offer, which can be passed by value in 'credential_offer'
+// parameter of an offer URL, by reference in 'credential_offer_uri' parameter or as offer JSON itself.
+//
+//	Args:
+//		- offer: credential offer URL, like 'openid-credential-offer://?credential_offer=...', or offer JSON.
+//		- options: options for fetching offer passed by reference, like HTTP client.
+//
+//	Returns parsed credential offer.
+func ParseCredentialOffer(offer string, options ...IssuanceOptions) (*CredentialOffer, error) {
+	opts := newIssuanceOpts(options)
+
+	data := []byte(strings.TrimSpace(offer))
+
+	if !bytes.HasPrefix(data, []byte("{")) {
+		offerURL, err := url.Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse credential offer: %w", err)
+		}
+
+		switch query := offerURL.Query(); {
+		case query.Get(credentialOfferParam) != "":
+			data = []byte(query.Get(credentialOfferParam))
+		case query.Get(credentialOfferURIParam) != "":
+			data, err = getIssuanceResource(opts.httpClient, query.Get(credentialOfferURIParam))
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch credential offer: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("failed to parse credential offer: '%s' or '%s' is required",
+				credentialOfferParam, credentialOfferURIParam)
+		}
+	}
+
+	var credentialOffer CredentialOffer
+
+	if err := json.Unmarshal(data, &credentialOffer); err != nil {
+		return nil, fmt.Errorf("failed to parse credential offer: %w", err)
+	}
+
+	if err := credentialOffer.validate(); err != nil {
+		return nil, fmt.Errorf("failed to parse credential offer: %w", err)
+	}
+
+	return &credentialOffer, nil
+}
+
+// RequestCredentialsByPreAuthorizedCode runs OpenID4VCI pre-authorized code flow for given credential offer and
+// adds issued credentials to wallet.
+// Proof of possession is signed by the default wallet DID unless controller is provided in options.
+// JWT and SD-JWT credentials are verified and added to wallet in their JSON form with all disclosed claims,
+// which is a copy for display and queries, the credential as issued is saved as 'SecuredCredential' content
+// of same ID. Linked data proof credentials are verified and added as issued.
+//
+//	Args:
+//		- authToken: authorization for performing operation.
+//		- offer: credential offer having pre-authorized code grant.
+//		- options: options for requesting credentials, like transaction code, controller or HTTP client.
+//
+//	Returns issued credentials, or error wrapping 'ErrTxCodeRequired' if transaction code is required but missing.
+func (c *Wallet) RequestCredentialsByPreAuthorizedCode(authToken string, offer *CredentialOffer,
+	options ...IssuanceOptions) ([]*IssuedCredential, error) {
+	opts := newIssuanceOpts(options)
+
+	if err := offer.validate(); err != nil {
+		return nil, fmt.Errorf("invalid credential offer: %w", err)
+	}
+
+	if offer.Grants == nil || offer.Grants.PreAuthorizedCode == nil {
+		return nil, errors.New("credential offer doesn't have pre-authorized code grant")
+	}
+
+	grant := offer.Grants.PreAuthorizedCode
+
+	if grant.TxCode != nil && opts.txCode == "" {
+		return nil, ErrTxCodeRequired
+	}
+
+	configurationIDs, err := offer.selectConfigurations(opts.configurationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	proofOpts, err := c.issuanceProofOptions(authToken, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := fetchCredentialIssuerMetadata(opts.httpClient, offer.CredentialIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", PreAuthorizedCodeGrantType)
+	params.Set("pre-authorized_code", grant.PreAuthorizedCode)
+
+	if opts.txCode != "" {
+		params.Set("tx_code", opts.txCode)
+	}
+
+	token, err := requestAccessToken(opts.httpClient, metadata.authorizationServer(grant.AuthorizationServer), params)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.requestCredentials(authToken, &issuanceRequest{
+		opts:             opts,
+		proofOpts:        proofOpts,
+		metadata:         metadata,
+		token:            token,
+		configurationIDs: configurationIDs,
+	})
+}
+
+// CreateIssuanceAuthorization creates authorization request of OpenID4VCI authorization code flow for given
+// credential offer, using PKCE with 'S256' code challenge method.
+// Holder has to be redirected to authorization URL of the result, which has to be kept until holder is redirected
+// back with authorization code for calling 'RequestCredentialsByAuthorizationCode'.
+//
+//	Args:
+//		- offer: credential offer, having authorization code grant or no grants.
+//		- clientID: OAuth client ID of the wallet.
+//		- redirectURI: URI to which holder is redirected back with authorization code.
+//		- options: options for authorization, like credentials to be requested or HTTP client.
+//
+//	Returns authorization request.
+func CreateIssuanceAuthorization(offer *CredentialOffer, clientID, redirectURI string,
+	options ...IssuanceOptions) (*IssuanceAuthorization, error) {
+	opts := newIssuanceOpts(options)
+
+	if err := offer.validate(); err != nil {
+		return nil, fmt.Errorf("invalid credential offer: %w", err)
+	}
+
+	if clientID == "" || redirectURI == "" {
+		return nil, errors.New("client ID and redirect URI are required for authorization")
+	}
+
+	grant := &AuthorizationCodeGrant{}
+
+	if offer.Grants != nil {
+		if offer.Grants.AuthorizationCode == nil {
+			return nil, errors.New("credential offer doesn't have authorization code grant")
+		}
+
+		grant = offer.Grants.AuthorizationCode
+	}
+
+	configurationIDs, err := offer.selectConfigurations(opts.configurationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := fetchCredentialIssuerMetadata(opts.httpClient, offer.CredentialIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	serverMetadata, err := fetchAuthorizationServerMetadata(opts.httpClient,
+		metadata.authorizationServer(grant.AuthorizationServer))
+	if err != nil {
+		return nil, err
+	}
+
+	if serverMetadata.AuthorizationEndpoint == "" {
+		return nil, errors.New("authorization server doesn't have authorization endpoint")
+	}
+
+	authorization := &IssuanceAuthorization{
+		Offer:                      offer,
+		CredentialConfigurationIDs: configurationIDs,
+		ClientID:                   clientID,
+		RedirectURI:                redirectURI,
+	}
+
+	if authorization.CodeVerifier, err = randomString(pkceVerifierLength); err != nil {
+		return nil, err
+	}
+
+	if authorization.State, err = randomString(stateLength); err != nil {
+		return nil, err
+	}
+
+	authorization.AuthorizationURL, err = authorizationURL(serverMetadata.AuthorizationEndpoint, metadata,
+		authorization, grant.IssuerState)
+	if err != nil {
+		return nil, err
+	}
+
+	return authorization, nil
+}
+
+// RequestCredentialsByAuthorizationCode completes OpenID4VCI authorization code flow by exchanging authorization
+// code for access token and adds issued credentials to wallet.
+// Proof of possession is signed by the default wallet DID unless controller is provided in options.
+// JWT and SD-JWT credentials are verified and added to wallet in their JSON form with all disclosed claims,
+// which is a copy for display and queries, the credential as issued is saved as 'SecuredCredential' content
+// of same ID. Linked data proof credentials are verified and added as issued.
+//
+//	Args:
+//		- authToken: authorization for performing operation.
+//		- authorization: authorization request created by 'CreateIssuanceAuthorization'.
+//		- code: authorization code received on redirect URI.
+//		- state: state received on redirect URI.
+//		- options: options for requesting credentials, like controller or HTTP client.
+//
+//	Returns issued credentials.
+func (c *Wallet) RequestCredentialsByAuthorizationCode(authToken string, authorization *IssuanceAuthorization,
+	code, state string, options ...IssuanceOptions) ([]*IssuedCredential, error) {
+	opts := newIssuanceOpts(options)
+
+	if authorization == nil || authorization.Offer == nil {
+		return nil, errors.New("invalid authorization, credential offer is required")
+	}
+
+	if code == "" {
+		return nil, errors.New("authorization code is required")
+	}
+
+	if state != authorization.State {
+		return nil, errors.New("state doesn't match state of authorization request")
+	}
+
+	proofOpts, err := c.issuanceProofOptions(authToken, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := fetchCredentialIssuerMetadata(opts.httpClient, authorization.Offer.CredentialIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	var authorizationServer string
+
+	if grants := authorization.Offer.Grants; grants != nil && grants.AuthorizationCode != nil {
+		authorizationServer = grants.AuthorizationCode.AuthorizationServer
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", AuthorizationCodeGrantType)
+	params.Set("code", code)
+	params.Set("redirect_uri", authorization.RedirectURI)
+	params.Set("client_id", authorization.ClientID)
+	params.Set("code_verifier", authorization.CodeVerifier)
+
+	token, err := requestAccessToken(opts.httpClient, metadata.authorizationServer(authorizationServer), params)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.requestCredentials(authToken, &issuanceRequest{
+		opts:             opts,
+		proofOpts:        proofOpts,
+		metadata:         metadata,
+		token:            token,
+		configurationIDs: authorization.CredentialConfigurationIDs,
+		clientID:         authorization.ClientID,
+	})
+}
+
+// issuanceRequest contains state of requesting credentials from credential endpoint.
+type issuanceRequest struct {
+	opts             *issuanceOpts
+	proofOpts        *ProofOptions
+	metadata         *credentialIssuerMetadata
+	token            *tokenResponse
+	configurationIDs []string
+	clientID         string
+}
+
+// requestCredentials requests given credentials from credential endpoint and adds them to wallet,
+// no credential is added unless all the credentials are issued and verified.
+func (c *Wallet) requestCredentials(authToken string, request *issuanceRequest) ([]*IssuedCredential, error) {
+	if request.token.TokenType != "" && !strings.EqualFold(request.token.TokenType, bearerTokenType) {
+		return nil, fmt.Errorf("unsupported access token type '%s'", request.token.TokenType)
+	}
+
+	nonce := request.token.CNonce
+
+	if nonce == "" && request.metadata.NonceEndpoint != "" {
+		var resp nonceResponse
+
+		if err := callIssuanceEndpoint(request.opts.httpClient, http.MethodPost, request.metadata.NonceEndpoint,
+			nil, "", "", &resp); err != nil {
+			return nil, fmt.Errorf("failed to get nonce: %w", err)
+		}
+
+		nonce = resp.CNonce
+	}
+
+	var issued []*IssuedCredential
+
+	for _, id := range request.configurationIDs {
+		conf, ok := request.metadata.CredentialConfigurationsSupported[id]
+		if !ok || conf == nil {
+			return nil, fmt.Errorf("credential configuration '%s' isn't supported by credential issuer", id)
+		}
+
+		resp, err := c.requestCredential(authToken, request, conf, nonce)
+		if err != nil {
+			return nil, fmt.Errorf("failed to request credential '%s': %w", id, err)
+		}
+
+		if resp.CNonce != "" {
+			nonce = resp.CNonce
+		}
+
+		credentials, err := c.readIssuedCredentials(authToken, id, conf.Format, resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read credential '%s': %w", id, err)
+		}
+
+		issued = append(issued, credentials...)
+	}
+
+	for _, credential := range issued {
+		if err := c.Add(authToken, Credential, credential.content, AddByCollection(request.opts.collectionID)); err != nil {
+			return nil, fmt.Errorf("failed to add issued credential to wallet: %w", err)
+		}
+
+		if credential.Format == LDPVCFormat {
+			continue
+		}
+
+		secured, err := json.Marshal(credential)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal issued credential: %w", err)
+		}
+
+		if err := c.Add(authToken, SecuredCredential, secured); err != nil {
+			return nil, fmt.Errorf("failed to add issued credential to wallet: %w", err)
+		}
+	}
+
+	return issued, nil
+}
+
+// requestCredential requests a credential from credential endpoint, request is retried once with fresh nonce
+// if issuer rejects the proof providing a new nonce.
+func (c *Wallet) requestCredential(authToken string, request *issuanceRequest, conf *credentialConfiguration,
+	nonce string) (*credentialResponse, error) {
+	for retried := false; ; retried = true {
+		proofJWT, err := c.createProofJWT(authToken, request.proofOpts, request.metadata.CredentialIssuer,
+			request.clientID, nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		reqBytes, err := json.Marshal(&credentialRequest{
+			Format:               conf.Format,
+			CredentialDefinition: conf.CredentialDefinition,
+			VCT:                  conf.VCT,
+			Proof:                &credentialRequestProof{ProofType: proofTypeJWT, JWT: proofJWT},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal credential request: %w", err)
+		}
+
+		var resp credentialResponse
+
+		err = callIssuanceEndpoint(request.opts.httpClient, http.MethodPost, request.metadata.CredentialEndpoint,
+			reqBytes, issuanceContentType, request.token.AccessToken, &resp)
+
+		var oauthErr *oauthError
+
+		if !retried && errors.As(err, &oauthErr) && oauthErr.CNonce != "" &&
+			(oauthErr.Code == invalidProofErrorCode || oauthErr.Code == invalidNonceErrorCode) {
+			nonce = oauthErr.CNonce
+
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &resp, nil
+	}
+}
+
+// readIssuedCredentials verifies credentials of credential response and prepares them to be added to wallet.
+func (c *Wallet) readIssuedCredentials(authToken, configurationID, format string,
+	resp *credentialResponse) ([]*IssuedCredential, error) {
+	raws := make([]json.RawMessage, 0, len(resp.Credentials)+1)
+
+	if len(resp.Credential) > 0 {
+		raws = append(raws, resp.Credential)
+	}
+
+	for _, item := range resp.Credentials {
+		if item != nil && len(item.Credential) > 0 {
+			raws = append(raws, item.Credential)
+		}
+	}
+
+	if len(raws) == 0 {
+		if resp.TransactionID != "" {
+			return nil, errors.New("deferred credential issuance isn't supported")
+		}
+
+		return nil, errors.New("no credential found in credential response")
+	}
+
+	issued := make([]*IssuedCredential, len(raws))
+
+	for i, raw := range raws {
+		content, err := c.issuedCredentialContent(authToken, format, raw)
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := getContentID(content)
+		if err != nil {
+			return nil, err
+		}
+
+		issued[i] = &IssuedCredential{
+			ID:              id,
+			ConfigurationID: configurationID,
+			Format:          format,
+			Credential:      raw,
+			content:         content,
+		}
+	}
+
+	return issued, nil
+}
+
+// issuedCredentialContent verifies issued credential of given format and returns its wallet content.
+func (c *Wallet) issuedCredentialContent(authToken, format string, raw json.RawMessage) (json.RawMessage, error) {
+	fetcher := verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher()
+
+	switch format {
+	case LDPVCFormat:
+		_, err := verifiable.ParseCredential(raw, verifiable.WithPublicKeyFetcher(fetcher),
+			verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+		if err != nil {
+			return nil, fmt.Errorf("credential verification failed: %w", err)
+		}
+
+		return raw, nil
+	case JWTVCJSONFormat:
+		var vcJWT string
+
+		if err := json.Unmarshal(raw, &vcJWT); err != nil {
+			return nil, fmt.Errorf("JWT credential is expected to be a string: %w", err)
+		}
+
+		vc, err := verifiable.ParseCredential([]byte(vcJWT), verifiable.WithPublicKeyFetcher(fetcher),
+			verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+		if err != nil {
+			return nil, fmt.Errorf("credential verification failed: %w", err)
+		}
+
+		return vc.MarshalJSON()
+	case SDJWTVCFormat:
+		var sdJWT string
+
+		if err := json.Unmarshal(raw, &sdJWT); err != nil {
+			return nil, fmt.Errorf("SD-JWT credential is expected to be a string: %w", err)
+		}
+
+		claims, err := parseSDJWT(sdJWT, fetcher)
+		if err != nil {
+			return nil, fmt.Errorf("credential verification failed: %w", err)
+		}
+
+		vc, err := c.sdJWTCredential(claims)
+		if err != nil {
+			return nil, err
+		}
+
+		return vc.MarshalJSON()
+	default:
+		return nil, fmt.Errorf("unsupported credential format '%s'", format)
+	}
+}
+
+// sdJWTCredential returns W3C credential of given disclosed SD-JWT claims, credential is read from 'vc' claim
+// if present, otherwise claims of SD-JWT VC are mapped to a W3C credential of type given by 'vct' claim.
+// The credential isn't signed by the issuer, it is a copy of the SD-JWT for display and queries.
+func (c *Wallet) sdJWTCredential(claims map[string]interface{}) (*verifiable.Credential, error) {
+	if _, ok := claims["vc"]; !ok {
+		var err error
+
+		if claims, err = sdJWTVCClaims(claims); err != nil {
+			return nil, err
+		}
+	}
+
+	// issuer signature is already verified, credential is read from claims as unsecured JWT.
+	token, err := jwt.NewUnsecured(claims, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SD-JWT credential: %w", err)
+	}
+
+	unsecured, err := token.Serialize(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SD-JWT credential: %w", err)
+	}
+
+	vc, err := verifiable.ParseCredential([]byte(unsecured), verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SD-JWT credential: %w", err)
+	}
+
+	return vc, nil
+}
+
+// issuanceProofOptions resolves controller and verification method for signing proof of possession.
+func (c *Wallet) issuanceProofOptions(authToken string, opts *issuanceOpts) (*ProofOptions, error) {
+	proofOpts := &ProofOptions{Controller: opts.controller, VerificationMethod: opts.verificationMethod}
+
+	if err := c.validateProofOption(authToken, proofOpts, did.Authentication); err != nil {
+		return nil, fmt.Errorf("invalid proof of possession options: %w", err)
+	}
+
+	return proofOpts, nil
+}
+
+// createProofJWT creates proof of possession JWT signed by wallet key of given verification method.
+// ECDSA keys are expected to produce IEEE P1363 signatures as required by JWS.
+func (c *Wallet) createProofJWT(authToken string, proofOpts *ProofOptions, audience, clientID,
+	nonce string) (string, error) {
+	alg, err := c.jwsAlgorithm(authToken, proofOpts.VerificationMethod)
+	if err != nil {
+		return "", err
+	}
+
+	keyID, err := c.signingKeyID(authToken, proofOpts.VerificationMethod)
+	if err != nil {
+		return "", err
+	}
+
+	s, err := newKMSSigner(authToken, c.walletCrypto, keyID, proofOpts)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := jwt.NewSigned(&proofClaims{
+		Issuer:   clientID,
+		Audience: audience,
+		IssuedAt: time.Now().Unix(),
+		Nonce:    nonce,
+	}, jose.Headers{
+		jose.HeaderType:  proofJWTType,
+		jose.HeaderKeyID: proofOpts.VerificationMethod,
+	}, &jwtSigner{kmsSigner: s, alg: alg})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign proof of possession: %w", err)
+	}
+
+	return token.Serialize(false)
+}
+
+// jwsAlgorithm returns JWS algorithm for signing with key of given verification method.
+func (c *Wallet) jwsAlgorithm(authToken, verificationMethod string) (string, error) {
+	vm, err := newContentBasedVDR(authToken, c.vdr, c.contents).Dereference(verificationMethod)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve verification method key: %w", err)
+	}
+
+	if vm.VerificationMethod == nil {
+		return "", fmt.Errorf("'%s' is not a verification method", verificationMethod)
+	}
+
+	if vm.VerificationMethod.Type == ed25519VerificationKey2018 {
+		return "EdDSA", nil
+	}
+
+	if j := vm.VerificationMethod.JSONWebKey(); j != nil {
+		switch j.Crv {
+		case "Ed25519":
+			return "EdDSA", nil
+		case "P-256":
+			return "ES256", nil
+		case "P-384":
+			return "ES384", nil
+		case "P-521":
+			return "ES512", nil
+		case "secp256k1":
+			return "ES256K", nil
+		}
+	}
+
+	return "", fmt.Errorf("verification method key of type '%s' isn't supported for signing JWT",
+		vm.VerificationMethod.Type)
+}
+
+func newIssuanceOpts(options []IssuanceOptions) *issuanceOpts {
+	opts := &issuanceOpts{httpClient: http.DefaultClient}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
+
+// validate validates credential offer.
+func (o *CredentialOffer) validate() error {
+	if o == nil || o.CredentialIssuer == "" {
+		return errors.New("'credential_issuer' is required")
+	}
+
+	if len(o.CredentialConfigurationIDs) == 0 {
+		return errors.New("'credential_configuration_ids' is required")
+	}
+
+	return nil
+}
+
+// selectConfigurations returns given offered credential configurations, or all offered ones if none given.
+func (o *CredentialOffer) selectConfigurations(ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return o.CredentialConfigurationIDs, nil
+	}
+
+	for _, id := range ids {
+		if !contains(o.CredentialConfigurationIDs, id) {
+			return nil, fmt.Errorf("credential configuration '%s' isn't offered", id)
+		}
+	}
+
+	return ids, nil
+}
+
+// authorizationServer returns authorization server of given grant, first authorization server of credential issuer
+// or credential issuer itself.
+func (m *credentialIssuerMetadata) authorizationServer(grantServer string) string {
+	if grantServer != "" {
+		return grantServer
+	}
+
+	if len(m.AuthorizationServers) > 0 {
+		return m.AuthorizationServers[0]
+	}
+
+	return m.CredentialIssuer
+}
+
+func fetchCredentialIssuerMetadata(client HTTPClient, issuer string) (*credentialIssuerMetadata, error) {
+	var metadata credentialIssuerMetadata
+
+	err := callIssuanceEndpoint(client, http.MethodGet, strings.TrimSuffix(issuer, "/")+credentialIssuerMetadataPath,
+		nil, "", "", &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential issuer metadata: %w", err)
+	}
+
+	if strings.TrimSuffix(metadata.CredentialIssuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, fmt.Errorf("credential issuer metadata is of different issuer '%s'", metadata.CredentialIssuer)
+	}
+
+	if metadata.CredentialEndpoint == "" {
+		return nil, errors.New("credential issuer metadata doesn't have credential endpoint")
+	}
+
+	return &metadata, nil
+}
+
+// fetchAuthorizationServerMetadata fetches OAuth authorization server metadata, falling back to OpenID provider
+// configuration.
+func fetchAuthorizationServerMetadata(client HTTPClient, server string) (*authorizationServerMetadata, error) {
+	var (
+		metadata authorizationServerMetadata
+		err      error
+	)
+
+	for _, path := range []string{oauthServerMetadataPath, openIDConfigurationPath} {
+		err = callIssuanceEndpoint(client, http.MethodGet, strings.TrimSuffix(server, "/")+path, nil, "", "", &metadata)
+		if err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authorization server metadata: %w", err)
+	}
+
+	if metadata.TokenEndpoint == "" {
+		return nil, errors.New("authorization server metadata doesn't have token endpoint")
+	}
+
+	return &metadata, nil
+}
+
+func requestAccessToken(client HTTPClient, server string, params url.Values) (*tokenResponse, error) {
+	metadata, err := fetchAuthorizationServerMetadata(client, server)
+	if err != nil {
+		return nil, err
+	}
+
+	var token tokenResponse
+
+	err = callIssuanceEndpoint(client, http.MethodPost, metadata.TokenEndpoint, []byte(params.Encode()),
+		formContentType, "", &token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, errors.New("failed to get access token: token response doesn't have access token")
+	}
+
+	return &token, nil
+}
+
+// authorizationURL returns authorization endpoint URL with parameters of given authorization request,
+// credentials are requested by authorization details and by scope if credential configuration has one.
+func authorizationURL(endpoint string, metadata *credentialIssuerMetadata, authorization *IssuanceAuthorization,
+	issuerState string) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	details := make([]*authorizationDetail, len(authorization.CredentialConfigurationIDs))
+
+	var scopes []string
+
+	for i, id := range authorization.CredentialConfigurationIDs {
+		details[i] = &authorizationDetail{Type: openIDCredentialType, CredentialConfigurationID: id}
+
+		if conf := metadata.CredentialConfigurationsSupported[id]; conf != nil && conf.Scope != "" {
+			scopes = append(scopes, conf.Scope)
+		}
+	}
+
+	detailsBytes, err := json.Marshal(details)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal authorization details: %w", err)
+	}
+
+	challenge := sha256.Sum256([]byte(authorization.CodeVerifier))
+
+	params := endpointURL.Query()
+	params.Set("response_type", "code")
+	params.Set("client_id", authorization.ClientID)
+	params.Set("redirect_uri", authorization.RedirectURI)
+	params.Set("state", authorization.State)
+	params.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	params.Set("code_challenge_method", pkceChallengeMethod)
+	params.Set("authorization_details", string(detailsBytes))
+
+	if len(scopes) > 0 {
+		params.Set("scope", strings.Join(scopes, " "))
+	}
+
+	if issuerState != "" {
+		params.Set("issuer_state", issuerState)
+	}
+
+	endpointURL.RawQuery = params.Encode()
+
+	return endpointURL.String(), nil
+}
+
+func getIssuanceResource(client HTTPClient, endpoint string) ([]byte, error) {
+	var data json.RawMessage
+
+	if err := callIssuanceEndpoint(client, http.MethodGet, endpoint, nil, "", "", &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// callIssuanceEndpoint calls endpoint of credential issuer or authorization server and reads JSON response into v,
+// OAuth error responses are returned as 'oauthError'.
+func callIssuanceEndpoint(client HTTPClient, method, endpoint string, body []byte, contentType,
+	accessToken string, v interface{}) error {
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", issuanceContentType)
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if accessToken != "" {
+		req.Header.Set("Authorization", bearerTokenType+" "+accessToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		if e := resp.Body.Close(); e != nil {
+			logger.Warnf("failed to close issuance response body: %s", e)
+		}
+	}()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		oauthErr := &oauthError{status: resp.StatusCode}
+
+		if e := json.Unmarshal(respBytes, oauthErr); e == nil && oauthErr.Code != "" {
+			return oauthErr
+		}
+
+		return fmt.Errorf("'%s' responded with status %d: %s", endpoint, resp.StatusCode, respBytes)
+	}
+
+	if err = json.Unmarshal(respBytes, v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
+
+func randomString(length int) (string, error) {
+	b := make([]byte, length)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// parseSDJWT verifies issuer signed JWT of given SD-JWT and returns its claims with all the disclosed claims.
+// https://datatracker.ietf.org/doc/html/draft-ietf-oauth-selective-disclosure-jwt
+func parseSDJWT(sdJWT string, fetcher verifiable.PublicKeyFetcher) (map[string]interface{}, error) {
+	parts := strings.Split(sdJWT, sdJWTSeparator)
+
+	// signing key has to be of the issuer, even if given by absolute DID URL.
+	resolver := jwt.KeyResolverFunc(func(issuer, kid string) (*verifier.PublicKey, error) {
+		if strings.HasPrefix(kid, "did:") && strings.Split(kid, "#")[0] != issuer {
+			return nil, fmt.Errorf("key '%s' isn't a key of issuer '%s'", kid, issuer)
+		}
+
+		return fetcher(issuer, kid)
+	})
+
+	// issuer signed JWT is typed, like 'vc+sd-jwt', hence parsed as JWS.
+	jws, err := jose.ParseJWS(parts[0], jwt.NewVerifier(resolver))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT: %w", err)
+	}
+
+	var claims map[string]interface{}
+
+	if err = json.Unmarshal(jws.Payload, &claims); err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT: %w", err)
+	}
+
+	if alg, ok := claims[sdJWTAlgorithmClaim]; ok && alg != sdJWTDigestAlgorithm {
+		return nil, fmt.Errorf("unsupported SD-JWT digest algorithm '%v'", alg)
+	}
+
+	delete(claims, sdJWTAlgorithmClaim)
+
+	disclosures := make(map[string][]interface{})
+
+	for _, part := range parts[1:] {
+		if part == "" {
+			continue
+		}
+
+		decoded, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SD-JWT disclosure: %w", err)
+		}
+
+		var disclosure []interface{}
+
+		if err = json.Unmarshal(decoded, &disclosure); err != nil {
+			return nil, fmt.Errorf("invalid SD-JWT disclosure: %w", err)
+		}
+
+		if len(disclosure) != sdJWTClaimDisclosure && len(disclosure) != sdJWTArrayDisclosure {
+			return nil, errors.New("invalid SD-JWT disclosure: unexpected number of elements")
+		}
+
+		digest := sha256.Sum256([]byte(part))
+		disclosures[base64.RawURLEncoding.EncodeToString(digest[:])] = disclosure
+	}
+
+	disclosed, err := applyDisclosures(claims, disclosures)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(disclosures) > 0 {
+		return nil, errors.New("invalid SD-JWT: disclosure isn't referenced by any digest")
+	}
+
+	return disclosed.(map[string]interface{}), nil
+}
+
+// applyDisclosures replaces digests of given value with claims of disclosures, used disclosures are removed
+// from the map.
+func applyDisclosures(value interface{}, disclosures map[string][]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+
+		for name, claim := range v {
+			if name == sdJWTDigestsClaim {
+				continue
+			}
+
+			resolved, err := applyDisclosures(claim, disclosures)
+			if err != nil {
+				return nil, err
+			}
+
+			result[name] = resolved
+		}
+
+		digests, _ := v[sdJWTDigestsClaim].([]interface{}) //nolint:errcheck
+
+		for _, digest := range digests {
+			disclosure, ok := disclosures[fmt.Sprint(digest)]
+			if !ok {
+				// decoy digest or claim not disclosed.
+				continue
+			}
+
+			delete(disclosures, fmt.Sprint(digest))
+
+			name, ok := disclosure[1].(string)
+			if len(disclosure) != sdJWTClaimDisclosure || !ok {
+				return nil, errors.New("invalid SD-JWT: object digest refers to array element disclosure")
+			}
+
+			if _, exists := result[name]; exists {
+				return nil, fmt.Errorf("invalid SD-JWT: disclosed claim '%s' already exists", name)
+			}
+
+			resolved, err := applyDisclosures(disclosure[2], disclosures)
+			if err != nil {
+				return nil, err
+			}
+
+			result[name] = resolved
+		}
+
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+
+		for _, element := range v {
+			if ref, ok := element.(map[string]interface{}); ok && len(ref) == 1 && ref[sdJWTArrayElement] != nil {
+				disclosure, found := disclosures[fmt.Sprint(ref[sdJWTArrayElement])]
+				if !found {
+					continue
+				}
+
+				delete(disclosures, fmt.Sprint(ref[sdJWTArrayElement]))
+
+				if len(disclosure) != sdJWTArrayDisclosure {
+					return nil, errors.New("invalid SD-JWT: array digest refers to claim disclosure")
+				}
+
+				element = disclosure[1]
+			}
+
+			resolved, err := applyDisclosures(element, disclosures)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, resolved)
+		}
+
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// sdJWTVCClaims maps claims of SD-JWT VC to JWT claims of W3C credential, non registered claims become claims
+// of credential subject.
+func sdJWTVCClaims(claims map[string]interface{}) (map[string]interface{}, error) {
+	vct, ok := claims["vct"].(string)
+	if !ok || vct == "" {
+		return nil, errors.New("SD-JWT credential has neither 'vc' nor 'vct' claim")
+	}
+
+	if _, ok = claims["iat"]; !ok {
+		if _, ok = claims["nbf"]; !ok {
+			return nil, errors.New("SD-JWT credential has neither 'iat' nor 'nbf' claim")
+		}
+	}
+
+	registered := map[string]bool{
+		"iss": true, "sub": true, "iat": true, "nbf": true, "exp": true, "jti": true,
+		"vct": true, "cnf": true, "status": true,
+	}
+
+	subject := make(map[string]interface{})
+
+	if sub, ok := claims["sub"]; ok {
+		subject["id"] = sub
+	}
+
+	for name, claim := range claims {
+		if !registered[name] {
+			subject[name] = claim
+		}
+	}
+
+	result := map[string]interface{}{
+		"vc": map[string]interface{}{
+			"@context":          []string{verifiableCredentialContext},
+			"type":              []string{"VerifiableCredential", vct},
+			"credentialSubject": subject,
+		},
+	}
+
+	for _, name := range []string{"iss", "sub", "iat", "nbf", "exp", "jti"} {
+		if claim, ok := claims[name]; ok {
+			result[name] = claim
+		}
+	}
+
+	return result, nil
+}
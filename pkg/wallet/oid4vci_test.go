@@ -0,0 +1,902 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	sampleIssuanceJWTConfig   = "UniversityDegree_jwt"
+	sampleIssuanceLDPConfig   = "UniversityDegree_ldp"
+	sampleIssuanceSDJWTConfig = "Identity_sd"
+	sampleIssuancePreAuthCode = "sample-pre-authorized-code"
+	sampleIssuanceAuthCode    = "sample-authorization-code"
+	sampleIssuanceTxCode      = "493536"
+	sampleIssuanceAccessToken = "sample-access-token"
+	sampleIssuanceClientID    = "sample-wallet"
+	sampleIssuanceRedirectURI = "https://wallet.example/callback"
+)
+
+func TestParseCredentialOffer(t *testing.T) {
+	issuer := newStubIssuer(t)
+
+	offerJSON := fmt.Sprintf(`{"credential_issuer": "%s", "credential_configuration_ids": ["%s"],
+		"grants": {"urn:ietf:params:oauth:grant-type:pre-authorized_code": {
+			"pre-authorized_code": "%s", "tx_code": {"length": 6, "input_mode": "numeric"}}}}`,
+		issuer.server.URL, sampleIssuanceJWTConfig, sampleIssuancePreAuthCode)
+
+	t.Run("parse credential offer by value", func(t *testing.T) {
+		for _, raw := range []string{
+			offerJSON,
+			"openid-credential-offer://?credential_offer=" + url.QueryEscape(offerJSON),
+		} {
+			offer, err := ParseCredentialOffer(raw)
+			require.NoError(t, err)
+			require.Equal(t, issuer.server.URL, offer.CredentialIssuer)
+			require.Equal(t, []string{sampleIssuanceJWTConfig}, offer.CredentialConfigurationIDs)
+			require.Equal(t, sampleIssuancePreAuthCode, offer.Grants.PreAuthorizedCode.PreAuthorizedCode)
+			require.Equal(t, 6, offer.Grants.PreAuthorizedCode.TxCode.Length)
+			require.Nil(t, offer.Grants.AuthorizationCode)
+		}
+	})
+
+	t.Run("parse credential offer by reference", func(t *testing.T) {
+		issuer.offer = offerJSON
+
+		offer, err := ParseCredentialOffer("openid-credential-offer://?credential_offer_uri="+
+			url.QueryEscape(issuer.server.URL+"/offer"), WithIssuanceHTTPClient(issuer.server.Client()))
+		require.NoError(t, err)
+		require.Equal(t, issuer.server.URL, offer.CredentialIssuer)
+		require.Equal(t, sampleIssuancePreAuthCode, offer.Grants.PreAuthorizedCode.PreAuthorizedCode)
+
+		offer, err = ParseCredentialOffer("openid-credential-offer://?credential_offer_uri=" +
+			url.QueryEscape(issuer.server.URL+"/unknown"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to fetch credential offer")
+		require.Empty(t, offer)
+	})
+
+	t.Run("parse invalid credential offers", func(t *testing.T) {
+		for _, raw := range []string{
+			"openid-credential-offer://?other=value",
+			"%%",
+			`{"credential_issuer": 1}`,
+			`{"credential_configuration_ids": ["a"]}`,
+			`{"credential_issuer": "https://issuer.example"}`,
+		} {
+			offer, err := ParseCredentialOffer(raw)
+			require.Error(t, err, raw)
+			require.Contains(t, err.Error(), "failed to parse credential offer")
+			require.Empty(t, offer)
+		}
+	})
+}
+
+func TestWallet_RequestCredentialsByPreAuthorizedCode(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newDIDMockProvider(t, nil))
+
+	holderDID, err := walletInstance.CreateDID(token, key.DIDMethod, WithDefaultDID())
+	require.NoError(t, err)
+
+	issuer := newStubIssuer(t)
+
+	newOffer := func(txCode bool, ids ...string) *CredentialOffer {
+		offer := &CredentialOffer{
+			CredentialIssuer:           issuer.server.URL,
+			CredentialConfigurationIDs: ids,
+			Grants: &CredentialOfferGrants{PreAuthorizedCode: &PreAuthorizedCodeGrant{
+				PreAuthorizedCode: sampleIssuancePreAuthCode,
+			}},
+		}
+
+		if txCode {
+			offer.Grants.PreAuthorizedCode.TxCode = &TxCode{Length: len(sampleIssuanceTxCode)}
+		}
+
+		return offer
+	}
+
+	t.Run("request credentials of all formats", func(t *testing.T) {
+		collectionID := "http://example.edu/collections/issued"
+		require.NoError(t, walletInstance.Add(token, Collection,
+			[]byte(fmt.Sprintf(`{"@context": ["https://w3id.org/wallet/v1"], "id": "%s", "type": "Vault"}`,
+				collectionID))))
+
+		issued, err := walletInstance.RequestCredentialsByPreAuthorizedCode(token,
+			newOffer(true, sampleIssuanceJWTConfig, sampleIssuanceLDPConfig, sampleIssuanceSDJWTConfig),
+			WithIssuanceHTTPClient(issuer.server.Client()), WithIssuanceTxCode(sampleIssuanceTxCode),
+			WithIssuanceCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, issued, 3)
+
+		require.Equal(t, JWTVCJSONFormat, issued[0].Format)
+		require.Equal(t, LDPVCFormat, issued[1].Format)
+		require.Equal(t, SDJWTVCFormat, issued[2].Format)
+
+		// holder binding of all proofs, nonce is rotated by each credential response.
+		require.Len(t, issuer.proofs, 3)
+
+		for i, proof := range issuer.proofs {
+			require.Equal(t, holderDID.DIDDocument.VerificationMethod[0].ID, proof.kid)
+			require.Equal(t, fmt.Sprintf("nonce-%d", i), proof.nonce)
+		}
+
+		for _, credential := range issued {
+			require.NotEmpty(t, credential.ID)
+			require.NotEmpty(t, credential.Credential)
+
+			stored, err := walletInstance.Get(token, Credential, credential.ID)
+			require.NoError(t, err)
+
+			vc, err := verifiable.ParseCredential(stored, verifiable.WithDisabledProofCheck(),
+				verifiable.WithJSONLDDocumentLoader(walletInstance.jsonldDocumentLoader))
+			require.NoError(t, err)
+			require.Equal(t, issuer.did, vc.Issuer.ID)
+			require.Equal(t, holderDID.DIDDocument.ID, vc.Subject.([]verifiable.Subject)[0].ID)
+		}
+
+		var jwtVC string
+		require.NoError(t, json.Unmarshal(issued[0].Credential, &jwtVC))
+		require.True(t, jwt.IsJWS(jwtVC))
+
+		// disclosed claims of SD-JWT credential.
+		stored, err := walletInstance.Get(token, Credential, issued[2].ID)
+		require.NoError(t, err)
+		require.Contains(t, string(stored), `"given_name":"John"`)
+		require.Contains(t, string(stored), `"family_name":"Doe"`)
+		require.Contains(t, string(stored), `"nationalities":["DE"]`)
+		require.NotContains(t, string(stored), sdJWTDigestsClaim)
+
+		collection, err := walletInstance.GetAll(token, Credential, FilterByCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, collection, 3)
+
+		// JWT and SD-JWT credentials are kept as issued.
+		fetcher := verifiable.NewVDRKeyResolver(walletInstance.vdr).PublicKeyFetcher()
+
+		var securedJWT IssuedCredential
+
+		stored, err = walletInstance.Get(token, SecuredCredential, issued[0].ID)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(stored, &securedJWT))
+		require.Equal(t, issued[0].Credential, securedJWT.Credential)
+		require.NoError(t, json.Unmarshal(securedJWT.Credential, &jwtVC))
+
+		vc, err := verifiable.ParseCredential([]byte(jwtVC), verifiable.WithPublicKeyFetcher(fetcher),
+			verifiable.WithJSONLDDocumentLoader(walletInstance.jsonldDocumentLoader))
+		require.NoError(t, err)
+		require.Equal(t, issued[0].ID, vc.ID)
+
+		var (
+			securedSDJWT IssuedCredential
+			sdJWT        string
+		)
+
+		stored, err = walletInstance.Get(token, SecuredCredential, issued[2].ID)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(stored, &securedSDJWT))
+		require.Equal(t, SDJWTVCFormat, securedSDJWT.Format)
+		require.NoError(t, json.Unmarshal(securedSDJWT.Credential, &sdJWT))
+		require.Len(t, strings.Split(sdJWT, sdJWTSeparator), 4)
+
+		claims, err := parseSDJWT(sdJWT, fetcher)
+		require.NoError(t, err)
+		require.Equal(t, "John", claims["given_name"])
+
+		_, err = walletInstance.Get(token, SecuredCredential, issued[1].ID)
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+
+		// credential as issued is removed along with the credential.
+		require.NoError(t, walletInstance.Remove(token, Credential, issued[0].ID))
+
+		_, err = walletInstance.Get(token, SecuredCredential, issued[0].ID)
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+
+	t.Run("request selected credential with retry on invalid nonce", func(t *testing.T) {
+		issuer.reset()
+		issuer.rejectNonce = true
+
+		jwkDID, err := walletInstance.CreateDID(token, jwk.DIDMethod, WithDIDKeyType(kms.ECDSAP256TypeIEEEP1363))
+		require.NoError(t, err)
+
+		issued, err := walletInstance.RequestCredentialsByPreAuthorizedCode(token,
+			newOffer(false, sampleIssuanceJWTConfig, sampleIssuanceSDJWTConfig),
+			WithIssuanceHTTPClient(issuer.server.Client()), WithIssuanceCredentials(sampleIssuanceSDJWTConfig),
+			WithIssuanceController(jwkDID.DIDDocument.ID, ""))
+		require.NoError(t, err)
+		require.Len(t, issued, 1)
+		require.Equal(t, sampleIssuanceSDJWTConfig, issued[0].ConfigurationID)
+		require.Len(t, issuer.proofs, 2)
+		require.Equal(t, "ES256", issuer.proofs[1].alg)
+	})
+
+	t.Run("request credentials failures", func(t *testing.T) {
+		issuer.reset()
+
+		client := WithIssuanceHTTPClient(issuer.server.Client())
+
+		issued, err := walletInstance.RequestCredentialsByPreAuthorizedCode(token,
+			newOffer(true, sampleIssuanceJWTConfig), client)
+		require.True(t, errors.Is(err, ErrTxCodeRequired))
+		require.Empty(t, issued)
+
+		issued, err = walletInstance.RequestCredentialsByPreAuthorizedCode(token,
+			newOffer(true, sampleIssuanceJWTConfig), client, WithIssuanceTxCode("000000"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get access token: invalid_grant")
+		require.Empty(t, issued)
+
+		issued, err = walletInstance.RequestCredentialsByPreAuthorizedCode(token, &CredentialOffer{
+			CredentialIssuer: issuer.server.URL, CredentialConfigurationIDs: []string{sampleIssuanceJWTConfig},
+		}, client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "doesn't have pre-authorized code grant")
+		require.Empty(t, issued)
+
+		issued, err = walletInstance.RequestCredentialsByPreAuthorizedCode(token, nil, client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid credential offer")
+		require.Empty(t, issued)
+
+		issued, err = walletInstance.RequestCredentialsByPreAuthorizedCode(token,
+			newOffer(false, sampleIssuanceJWTConfig), client, WithIssuanceCredentials("unknown"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential configuration 'unknown' isn't offered")
+		require.Empty(t, issued)
+
+		issued, err = walletInstance.RequestCredentialsByPreAuthorizedCode(token,
+			newOffer(false, "unknown"), client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential configuration 'unknown' isn't supported by credential issuer")
+		require.Empty(t, issued)
+
+		issued, err = walletInstance.RequestCredentialsByPreAuthorizedCode(token,
+			newOffer(false, sampleIssuanceJWTConfig), client, WithIssuanceController("did:example:unknown", ""))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid proof of possession options")
+		require.Empty(t, issued)
+
+		issued, err = walletInstance.RequestCredentialsByPreAuthorizedCode(sampleFakeTkn,
+			newOffer(false, sampleIssuanceJWTConfig), client)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+		require.Empty(t, issued)
+
+		offer := newOffer(false, sampleIssuanceJWTConfig)
+		offer.CredentialIssuer = issuer.server.URL + "/unknown"
+		issued, err = walletInstance.RequestCredentialsByPreAuthorizedCode(token, offer, client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get credential issuer metadata")
+		require.Empty(t, issued)
+	})
+
+	t.Run("issued credentials failing verification aren't added", func(t *testing.T) {
+		issuer.reset()
+		issuer.tamper = true
+
+		for _, id := range []string{sampleIssuanceJWTConfig, sampleIssuanceLDPConfig, sampleIssuanceSDJWTConfig} {
+			issued, err := walletInstance.RequestCredentialsByPreAuthorizedCode(token, newOffer(false, id),
+				WithIssuanceHTTPClient(issuer.server.Client()))
+			require.Error(t, err, id)
+			require.Contains(t, err.Error(), "credential verification failed")
+			require.Empty(t, issued)
+		}
+	})
+
+	t.Run("deferred issuance isn't supported", func(t *testing.T) {
+		issuer.reset()
+		issuer.deferred = true
+
+		issued, err := walletInstance.RequestCredentialsByPreAuthorizedCode(token,
+			newOffer(false, sampleIssuanceJWTConfig), WithIssuanceHTTPClient(issuer.server.Client()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "deferred credential issuance isn't supported")
+		require.Empty(t, issued)
+	})
+}
+
+func TestWallet_RequestCredentialsByAuthorizationCode(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newDIDMockProvider(t, nil))
+
+	_, err := walletInstance.CreateDID(token, key.DIDMethod, WithDefaultDID())
+	require.NoError(t, err)
+
+	issuer := newStubIssuer(t)
+	client := WithIssuanceHTTPClient(issuer.server.Client())
+
+	offer := &CredentialOffer{
+		CredentialIssuer:           issuer.server.URL,
+		CredentialConfigurationIDs: []string{sampleIssuanceJWTConfig, sampleIssuanceLDPConfig},
+		Grants: &CredentialOfferGrants{AuthorizationCode: &AuthorizationCodeGrant{
+			IssuerState: "sample-issuer-state",
+		}},
+	}
+
+	t.Run("authorize and request credentials", func(t *testing.T) {
+		authorization, err := CreateIssuanceAuthorization(offer, sampleIssuanceClientID, sampleIssuanceRedirectURI,
+			client, WithIssuanceCredentials(sampleIssuanceLDPConfig))
+		require.NoError(t, err)
+		require.NotEmpty(t, authorization.State)
+		require.NotEmpty(t, authorization.CodeVerifier)
+
+		authorizationURL, err := url.Parse(authorization.AuthorizationURL)
+		require.NoError(t, err)
+		require.Equal(t, issuer.server.URL+"/authorize", authorizationURL.Scheme+"://"+authorizationURL.Host+
+			authorizationURL.Path)
+
+		params := authorizationURL.Query()
+		require.Equal(t, "code", params.Get("response_type"))
+		require.Equal(t, sampleIssuanceClientID, params.Get("client_id"))
+		require.Equal(t, sampleIssuanceRedirectURI, params.Get("redirect_uri"))
+		require.Equal(t, authorization.State, params.Get("state"))
+		require.Equal(t, "S256", params.Get("code_challenge_method"))
+		require.Equal(t, "sample-issuer-state", params.Get("issuer_state"))
+		require.Equal(t, "UniversityDegreeLDP", params.Get("scope"))
+		require.JSONEq(t, `[{"type": "openid_credential", "credential_configuration_id": "UniversityDegree_ldp"}]`,
+			params.Get("authorization_details"))
+
+		// authorization server redirects back with code.
+		issuer.codeChallenge = params.Get("code_challenge")
+
+		issued, err := walletInstance.RequestCredentialsByAuthorizationCode(token, authorization,
+			sampleIssuanceAuthCode, authorization.State, client)
+		require.NoError(t, err)
+		require.Len(t, issued, 1)
+		require.Equal(t, sampleIssuanceLDPConfig, issued[0].ConfigurationID)
+
+		require.Len(t, issuer.proofs, 1)
+		require.Equal(t, sampleIssuanceClientID, issuer.proofs[0].iss)
+
+		_, err = walletInstance.Get(token, Credential, issued[0].ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("authorize failures", func(t *testing.T) {
+		authorization, err := CreateIssuanceAuthorization(offer, "", sampleIssuanceRedirectURI, client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "client ID and redirect URI are required")
+		require.Empty(t, authorization)
+
+		authorization, err = CreateIssuanceAuthorization(&CredentialOffer{
+			CredentialIssuer: issuer.server.URL, CredentialConfigurationIDs: []string{sampleIssuanceJWTConfig},
+			Grants: &CredentialOfferGrants{PreAuthorizedCode: &PreAuthorizedCodeGrant{}},
+		}, sampleIssuanceClientID, sampleIssuanceRedirectURI, client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "doesn't have authorization code grant")
+		require.Empty(t, authorization)
+
+		authorization, err = CreateIssuanceAuthorization(&CredentialOffer{}, sampleIssuanceClientID,
+			sampleIssuanceRedirectURI, client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid credential offer")
+		require.Empty(t, authorization)
+
+		// authorization server metadata isn't found at given authorization server.
+		authorization, err = CreateIssuanceAuthorization(&CredentialOffer{
+			CredentialIssuer: issuer.server.URL, CredentialConfigurationIDs: []string{sampleIssuanceJWTConfig},
+			Grants: &CredentialOfferGrants{AuthorizationCode: &AuthorizationCodeGrant{
+				AuthorizationServer: issuer.server.URL + "/unknown",
+			}},
+		}, sampleIssuanceClientID, sampleIssuanceRedirectURI, client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get authorization server metadata")
+		require.Empty(t, authorization)
+	})
+
+	t.Run("request credentials failures", func(t *testing.T) {
+		authorization, err := CreateIssuanceAuthorization(&CredentialOffer{
+			CredentialIssuer: issuer.server.URL, CredentialConfigurationIDs: []string{sampleIssuanceJWTConfig},
+		}, sampleIssuanceClientID, sampleIssuanceRedirectURI, client)
+		require.NoError(t, err)
+
+		issuer.codeChallenge = "different-challenge"
+
+		issued, err := walletInstance.RequestCredentialsByAuthorizationCode(token, authorization,
+			sampleIssuanceAuthCode, authorization.State, client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid_grant: code verifier doesn't match")
+		require.Empty(t, issued)
+
+		issued, err = walletInstance.RequestCredentialsByAuthorizationCode(token, authorization,
+			sampleIssuanceAuthCode, "other-state", client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "state doesn't match")
+		require.Empty(t, issued)
+
+		issued, err = walletInstance.RequestCredentialsByAuthorizationCode(token, authorization, "",
+			authorization.State, client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "authorization code is required")
+		require.Empty(t, issued)
+
+		issued, err = walletInstance.RequestCredentialsByAuthorizationCode(token, nil, sampleIssuanceAuthCode,
+			authorization.State, client)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential offer is required")
+		require.Empty(t, issued)
+	})
+}
+
+func TestParseSDJWT(t *testing.T) {
+	issuer := newStubIssuer(t)
+	fetcher := verifiable.NewVDRKeyResolver(vdr.New(vdr.WithVDR(key.New()))).PublicKeyFetcher()
+
+	given := issuer.disclosure(t, "salt-1", "given_name", "John")
+	nested := issuer.disclosure(t, "salt-2", "street", "Main St")
+	element := issuer.disclosure(t, "salt-3", "DE")
+
+	sign := func(claims map[string]interface{}, disclosures ...string) string {
+		return issuer.sign(t, claims) + sdJWTSeparator + strings.Join(disclosures, sdJWTSeparator) + sdJWTSeparator
+	}
+
+	t.Run("disclose claims", func(t *testing.T) {
+		claims, err := parseSDJWT(sign(map[string]interface{}{
+			"iss":         issuer.did,
+			"_sd_alg":     "sha-256",
+			"_sd":         []interface{}{sdJWTDigest(given), "decoy-digest"},
+			"address":     map[string]interface{}{"_sd": []interface{}{sdJWTDigest(nested)}},
+			"citizenship": []interface{}{map[string]interface{}{"...": sdJWTDigest(element)}, "FR"},
+		}, given, nested, element), fetcher)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"iss":         issuer.did,
+			"given_name":  "John",
+			"address":     map[string]interface{}{"street": "Main St"},
+			"citizenship": []interface{}{"DE", "FR"},
+		}, claims)
+
+		// undisclosed claims are left out.
+		claims, err = parseSDJWT(sign(map[string]interface{}{
+			"iss": issuer.did, "_sd": []interface{}{sdJWTDigest(given)},
+		}), fetcher)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"iss": issuer.did}, claims)
+	})
+
+	t.Run("invalid SD-JWTs", func(t *testing.T) {
+		for _, sdJWT := range []string{
+			"invalid",
+			sign(map[string]interface{}{"iss": issuer.did, "_sd_alg": "sha-512"}),
+			sign(map[string]interface{}{"iss": issuer.did}, "%%%"),
+			sign(map[string]interface{}{"iss": issuer.did}, base64.RawURLEncoding.EncodeToString([]byte(`{}`))),
+			sign(map[string]interface{}{"iss": issuer.did}, base64.RawURLEncoding.EncodeToString([]byte(`["a"]`))),
+			sign(map[string]interface{}{"iss": issuer.did}, given),
+			sign(map[string]interface{}{"iss": issuer.did, "_sd": []interface{}{sdJWTDigest(element)}}, element),
+			sign(map[string]interface{}{
+				"iss": issuer.did, "list": []interface{}{map[string]interface{}{"...": sdJWTDigest(given)}},
+			}, given),
+			sign(map[string]interface{}{
+				"iss": issuer.did, "given_name": "Jane", "_sd": []interface{}{sdJWTDigest(given)},
+			}, given),
+		} {
+			claims, err := parseSDJWT(sdJWT, fetcher)
+			require.Error(t, err, sdJWT)
+			require.Empty(t, claims)
+		}
+	})
+}
+
+// stubIssuer is OpenID4VCI credential issuer and authorization server for tests.
+type stubIssuer struct {
+	server *httptest.Server
+	did    string
+	kid    string
+	signer signature.Signer
+	offer  string
+
+	codeChallenge string
+	rejectNonce   bool
+	tamper        bool
+	deferred      bool
+
+	lock    sync.Mutex
+	nonces  int
+	proofs  []*stubProof
+	holders *verifiable.VDRKeyResolver
+}
+
+// stubProof is proof of possession received by stub issuer.
+type stubProof struct {
+	alg   string
+	kid   string
+	iss   string
+	nonce string
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	t.Helper()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	didKey, kid := fingerprint.CreateDIDKey(pubKey)
+
+	issuer := &stubIssuer{
+		did:     didKey,
+		kid:     kid,
+		signer:  signature.GetEd25519Signer(privKey, pubKey),
+		holders: verifiable.NewVDRKeyResolver(newDIDMockProvider(t, nil).VDRegistry()),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(credentialIssuerMetadataPath, issuer.metadata)
+	mux.HandleFunc(oauthServerMetadataPath, issuer.serverMetadata)
+	mux.HandleFunc("/offer", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(issuer.offer)) //nolint:errcheck
+	})
+	mux.HandleFunc("/token", issuer.token)
+	mux.HandleFunc("/credential", func(rw http.ResponseWriter, req *http.Request) {
+		issuer.credential(t, rw, req)
+	})
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+func (s *stubIssuer) reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.nonces = 0
+	s.proofs = nil
+	s.rejectNonce, s.tamper, s.deferred = false, false, false
+}
+
+func (s *stubIssuer) metadata(rw http.ResponseWriter, _ *http.Request) {
+	writeStubResponse(rw, http.StatusOK, map[string]interface{}{
+		"credential_issuer":   s.server.URL,
+		"credential_endpoint": s.server.URL + "/credential",
+		"credential_configurations_supported": map[string]interface{}{
+			sampleIssuanceJWTConfig: map[string]interface{}{
+				"format": JWTVCJSONFormat,
+				"credential_definition": map[string]interface{}{
+					"type": []string{"VerifiableCredential", "UniversityDegreeCredential"},
+				},
+			},
+			sampleIssuanceLDPConfig: map[string]interface{}{
+				"format": LDPVCFormat,
+				"scope":  "UniversityDegreeLDP",
+				"credential_definition": map[string]interface{}{
+					"@context": []string{verifiableCredentialContext, "https://www.w3.org/2018/credentials/examples/v1"},
+					"type":     []string{"VerifiableCredential", "UniversityDegreeCredential"},
+				},
+			},
+			sampleIssuanceSDJWTConfig: map[string]interface{}{
+				"format": SDJWTVCFormat,
+				"vct":    "https://credentials.example.com/identity_credential",
+			},
+		},
+	})
+}
+
+func (s *stubIssuer) serverMetadata(rw http.ResponseWriter, _ *http.Request) {
+	writeStubResponse(rw, http.StatusOK, map[string]interface{}{
+		"issuer":                 s.server.URL,
+		"authorization_endpoint": s.server.URL + "/authorize",
+		"token_endpoint":         s.server.URL + "/token",
+	})
+}
+
+func (s *stubIssuer) token(rw http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		writeStubResponse(rw, http.StatusBadRequest, &oauthError{Code: "invalid_request"})
+
+		return
+	}
+
+	switch req.PostForm.Get("grant_type") {
+	case PreAuthorizedCodeGrantType:
+		txCode := req.PostForm.Get("tx_code")
+
+		if req.PostForm.Get("pre-authorized_code") != sampleIssuancePreAuthCode ||
+			(txCode != "" && txCode != sampleIssuanceTxCode) {
+			writeStubResponse(rw, http.StatusBadRequest, &oauthError{Code: "invalid_grant"})
+
+			return
+		}
+	case AuthorizationCodeGrantType:
+		challenge := sha256.Sum256([]byte(req.PostForm.Get("code_verifier")))
+
+		if req.PostForm.Get("code") != sampleIssuanceAuthCode ||
+			req.PostForm.Get("client_id") != sampleIssuanceClientID ||
+			req.PostForm.Get("redirect_uri") != sampleIssuanceRedirectURI ||
+			base64.RawURLEncoding.EncodeToString(challenge[:]) != s.codeChallenge {
+			writeStubResponse(rw, http.StatusBadRequest, &oauthError{
+				Code: "invalid_grant", Description: "code verifier doesn't match",
+			})
+
+			return
+		}
+	default:
+		writeStubResponse(rw, http.StatusBadRequest, &oauthError{Code: "unsupported_grant_type"})
+
+		return
+	}
+
+	writeStubResponse(rw, http.StatusOK, &tokenResponse{
+		AccessToken: sampleIssuanceAccessToken,
+		TokenType:   "bearer",
+		CNonce:      s.nextNonce(),
+	})
+}
+
+func (s *stubIssuer) credential(t *testing.T, rw http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "Bearer "+sampleIssuanceAccessToken {
+		writeStubResponse(rw, http.StatusUnauthorized, &oauthError{Code: "invalid_token"})
+
+		return
+	}
+
+	var request credentialRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		writeStubResponse(rw, http.StatusBadRequest, &oauthError{Code: "invalid_credential_request"})
+
+		return
+	}
+
+	proof, err := s.verifyProof(request.Proof)
+	if err != nil {
+		writeStubResponse(rw, http.StatusBadRequest, &oauthError{
+			Code: invalidProofErrorCode, Description: err.Error(),
+		})
+
+		return
+	}
+
+	s.lock.Lock()
+	s.proofs = append(s.proofs, proof)
+	expectedNonce, reject := fmt.Sprintf("nonce-%d", s.nonces-1), s.rejectNonce
+	s.rejectNonce = false
+	s.lock.Unlock()
+
+	if proof.nonce != expectedNonce || reject {
+		writeStubResponse(rw, http.StatusBadRequest, &oauthError{Code: invalidNonceErrorCode, CNonce: s.nextNonce()})
+
+		return
+	}
+
+	if s.deferred {
+		writeStubResponse(rw, http.StatusAccepted, &credentialResponse{TransactionID: "sample-transaction"})
+
+		return
+	}
+
+	holder := strings.Split(proof.kid, "#")[0]
+
+	var credential interface{}
+
+	switch request.Format {
+	case JWTVCJSONFormat:
+		credential = s.jwtCredential(t, holder)
+	case LDPVCFormat:
+		credential = s.ldpCredential(t, holder)
+	case SDJWTVCFormat:
+		credential = s.sdJWTCredential(t, holder, request.VCT)
+	}
+
+	writeStubResponse(rw, http.StatusOK, &credentialResponse{
+		Credentials: []*issuedItem{{Credential: mustMarshal(t, credential)}},
+		CNonce:      s.nextNonce(),
+	})
+}
+
+func (s *stubIssuer) verifyProof(proof *credentialRequestProof) (*stubProof, error) {
+	if proof == nil || proof.ProofType != proofTypeJWT {
+		return nil, errors.New("jwt proof is required")
+	}
+
+	jws, err := jose.ParseJWS(proof.JWT, jose.SignatureVerifierFunc(
+		func(headers jose.Headers, _, signingInput, sig []byte) error {
+			kid, _ := headers.KeyID()
+			vm := strings.Split(kid, "#")
+
+			if len(vm) != vmSectionCount {
+				return errors.New("invalid kid")
+			}
+
+			pubKey, err := s.holders.PublicKeyFetcher()(vm[0], "#"+vm[1])
+			if err != nil {
+				return err
+			}
+
+			alg, _ := headers.Algorithm()
+
+			if alg == "EdDSA" {
+				return jwt.VerifyEdDSA(pubKey, signingInput, sig)
+			}
+
+			return jwt.NewVerifier(jwt.KeyResolverFunc(func(_, _ string) (*verifier.PublicKey, error) {
+				return pubKey, nil
+			})).Verify(headers, []byte(`{"iss": "holder"}`), signingInput, sig)
+		}))
+	if err != nil {
+		return nil, err
+	}
+
+	if typ, _ := jws.ProtectedHeaders.Type(); typ != proofJWTType {
+		return nil, fmt.Errorf("unexpected proof type '%s'", typ)
+	}
+
+	var claims proofClaims
+
+	if err = json.Unmarshal(jws.Payload, &claims); err != nil {
+		return nil, err
+	}
+
+	if claims.Audience != s.server.URL || claims.IssuedAt == 0 {
+		return nil, errors.New("invalid proof claims")
+	}
+
+	kid, _ := jws.ProtectedHeaders.KeyID()
+	alg, _ := jws.ProtectedHeaders.Algorithm()
+
+	return &stubProof{alg: alg, kid: kid, iss: claims.Issuer, nonce: claims.Nonce}, nil
+}
+
+func (s *stubIssuer) nextNonce() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.nonces++
+
+	return fmt.Sprintf("nonce-%d", s.nonces-1)
+}
+
+func (s *stubIssuer) newCredential(t *testing.T, holder string) *verifiable.Credential {
+	t.Helper()
+
+	return &verifiable.Credential{
+		Context: []string{verifiableCredentialContext, "https://www.w3.org/2018/credentials/examples/v1"},
+		ID:      "http://example.edu/credentials/" + uuid.New().String(),
+		Types:   []string{"VerifiableCredential", "UniversityDegreeCredential"},
+		Issuer:  verifiable.Issuer{ID: s.did},
+		Issued:  util.NewTime(time.Now().UTC().Truncate(time.Second)),
+		Subject: []verifiable.Subject{{ID: holder}},
+	}
+}
+
+func (s *stubIssuer) jwtCredential(t *testing.T, holder string) string {
+	claims, err := s.newCredential(t, holder).JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWT, err := claims.MarshalJWS(verifiable.EdDSA, s.signer, s.kid)
+	require.NoError(t, err)
+
+	if s.tamper {
+		vcJWT += "tampered"
+	}
+
+	return vcJWT
+}
+
+func (s *stubIssuer) ldpCredential(t *testing.T, holder string) *verifiable.Credential {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	vc := s.newCredential(t, holder)
+
+	require.NoError(t, vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           Ed25519Signature2018,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(s.signer)),
+		SignatureRepresentation: verifiable.SignatureJWS,
+		VerificationMethod:      s.kid,
+	}, jsonld.WithDocumentLoader(loader)))
+
+	if s.tamper {
+		vc.ID += "/tampered"
+	}
+
+	return vc
+}
+
+func (s *stubIssuer) sdJWTCredential(t *testing.T, holder, vct string) string {
+	given := s.disclosure(t, "salt-given", "given_name", "John")
+	nationality := s.disclosure(t, "salt-nationality", "DE")
+
+	issuer := s.did
+	if s.tamper {
+		issuer = "did:example:unknown"
+	}
+
+	return s.sign(t, map[string]interface{}{
+		"iss":           issuer,
+		"sub":           holder,
+		"iat":           time.Now().Unix(),
+		"vct":           vct,
+		"_sd_alg":       sdJWTDigestAlgorithm,
+		"_sd":           []interface{}{sdJWTDigest(given)},
+		"family_name":   "Doe",
+		"nationalities": []interface{}{map[string]interface{}{"...": sdJWTDigest(nationality)}},
+	}) + sdJWTSeparator + given + sdJWTSeparator + nationality + sdJWTSeparator
+}
+
+func (s *stubIssuer) disclosure(t *testing.T, values ...interface{}) string {
+	return base64.RawURLEncoding.EncodeToString(mustMarshal(t, values))
+}
+
+func (s *stubIssuer) sign(t *testing.T, claims map[string]interface{}) string {
+	token, err := jwt.NewSigned(claims, jose.Headers{jose.HeaderKeyID: s.kid},
+		&stubJWTSigner{signer: s.signer})
+	require.NoError(t, err)
+
+	serialized, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	return serialized
+}
+
+// stubJWTSigner signs JWTs with ed25519 key of stub issuer.
+type stubJWTSigner struct {
+	signer signature.Signer
+}
+
+func (s *stubJWTSigner) Sign(data []byte) ([]byte, error) {
+	return s.signer.Sign(data)
+}
+
+func (s *stubJWTSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA"}
+}
+
+func sdJWTDigest(disclosure string) string {
+	digest := sha256.Sum256([]byte(disclosure))
+
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func writeStubResponse(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Set("Content-Type", issuanceContentType)
+	rw.WriteHeader(status)
+
+	_ = json.NewEncoder(rw).Encode(v) //nolint:errcheck
+}
+
+func mustMarshal(t *testing.T, v interface{}) json.RawMessage {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return data
+}
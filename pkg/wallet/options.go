@@ -12,7 +12,10 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/component/storage/edv"
 	"github.com/hyperledger/aries-framework-go/pkg/client/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/webkms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 )
@@ -79,8 +82,12 @@ type unlockOpts struct {
 	authToken  string
 	webkmsOpts []webkms.Opt
 
-	// expiry
-	tokenExpiry time.Duration
+	// session options
+	tokenExpiry               time.Duration
+	idleTimeout               time.Duration
+	concurrentSession         bool
+	lockOnBackground          bool
+	disableSessionPersistence bool
 
 	// edv opts
 	edvOpts []edv.RESTProviderOption
@@ -116,7 +123,7 @@ func WithUnlockByAuthorizationToken(url string) UnlockOptions {
 	}
 }
 
-// WithUnlockExpiry time duration after which wallet key manager will be expired.
+// WithUnlockExpiry time duration after which wallet key manager will be expired irrespective of session activity.
 // Wallet should be reopened by using 'client.Open()' once expired or a new instance needs to be created.
 func WithUnlockExpiry(tokenExpiry time.Duration) UnlockOptions {
 	return func(opts *unlockOpts) {
@@ -124,6 +131,39 @@ func WithUnlockExpiry(tokenExpiry time.Duration) UnlockOptions {
 	}
 }
 
+// WithUnlockIdleTimeout time duration of inactivity after which wallet session will be expired,
+// every wallet operation performed using the session token resets idle timer.
+// Unlike 'WithUnlockExpiry', session can be kept alive by using it.
+func WithUnlockIdleTimeout(idleTimeout time.Duration) UnlockOptions {
+	return func(opts *unlockOpts) {
+		opts.idleTimeout = idleTimeout
+	}
+}
+
+// WithConcurrentSession option allows opening a new wallet session even if wallet is already unlocked
+// by other sessions, by default wallet can be unlocked only once until it is closed or expired.
+func WithConcurrentSession() UnlockOptions {
+	return func(opts *unlockOpts) {
+		opts.concurrentSession = true
+	}
+}
+
+// WithUnlockLockOnBackground option marks wallet session to be locked by 'LockBackgroundSessions()',
+// typically used by mobile wallet applications to lock wallet when application goes to background.
+func WithUnlockLockOnBackground() UnlockOptions {
+	return func(opts *unlockOpts) {
+		opts.lockOnBackground = true
+	}
+}
+
+// WithoutSessionPersistence option disables persisting details of wallet session in wallet storage.
+// Sessions which are not persisted can't be listed or revoked by other wallet instances sharing the same storage.
+func WithoutSessionPersistence() UnlockOptions {
+	return func(opts *unlockOpts) {
+		opts.disableSessionPersistence = true
+	}
+}
+
 // WithUnlockWebKMSOptions can be used to provide custom aries web kms options for unlocking wallet.
 // This option can be used to set web kms client http header function instead of using WithUnlockByAuthorizationToken.
 func WithUnlockWebKMSOptions(webkmsOpts ...webkms.Opt) UnlockOptions {
@@ -297,6 +337,205 @@ func FilterByCollection(collectionID string) GetAllContentsOptions {
 	}
 }
 
+// searchOpts contains options for searching wallet contents.
+type searchOpts struct {
+	// equality filters.
+	types        []string
+	issuer       string
+	subject      map[string]string
+	collectionID string
+	text         string
+
+	// range filters.
+	issuedFrom  *time.Time
+	issuedTo    *time.Time
+	expiresFrom *time.Time
+	expiresTo   *time.Time
+
+	// sorting & pagination.
+	sortBy         SortField
+	sortDescending bool
+	offset         int
+	limit          int
+}
+
+// SearchOptions is option for searching wallet contents.
+type SearchOptions func(opts *searchOpts)
+
+// WithTypeFilter option for searching wallet contents having all given types.
+func WithTypeFilter(types ...string) SearchOptions {
+	return func(opts *searchOpts) {
+		opts.types = append(opts.types, types...)
+	}
+}
+
+// WithIssuerFilter option for searching wallet contents issued by given issuer ID.
+func WithIssuerFilter(issuer string) SearchOptions {
+	return func(opts *searchOpts) {
+		opts.issuer = issuer
+	}
+}
+
+// WithSubjectFilter option for searching wallet contents having credential subject with given property value.
+// Only top level string, number and boolean properties of credential subjects can be searched,
+// use property 'id' for searching by subject ID.
+func WithSubjectFilter(property, value string) SearchOptions {
+	return func(opts *searchOpts) {
+		if opts.subject == nil {
+			opts.subject = make(map[string]string)
+		}
+
+		opts.subject[property] = value
+	}
+}
+
+// WithCollectionFilter option for searching wallet contents added to given collection.
+func WithCollectionFilter(collectionID string) SearchOptions {
+	return func(opts *searchOpts) {
+		opts.collectionID = collectionID
+	}
+}
+
+// WithTextFilter option for searching wallet contents whose name or description contains all words of given text.
+// Words are matched as whole words irrespective of their case.
+func WithTextFilter(text string) SearchOptions {
+	return func(opts *searchOpts) {
+		opts.text = text
+	}
+}
+
+// WithIssuanceDateRange option for searching wallet contents issued within given time range.
+// Either of the range boundaries can be nil.
+func WithIssuanceDateRange(from, to *time.Time) SearchOptions {
+	return func(opts *searchOpts) {
+		opts.issuedFrom = from
+		opts.issuedTo = to
+	}
+}
+
+// WithExpirationDateRange option for searching wallet contents expiring within given time range.
+// Either of the range boundaries can be nil.
+func WithExpirationDateRange(from, to *time.Time) SearchOptions {
+	return func(opts *searchOpts) {
+		opts.expiresFrom = from
+		opts.expiresTo = to
+	}
+}
+
+// WithSortBy option for sorting search results by given field.
+// Contents without value for sort field are always sorted last.
+func WithSortBy(field SortField, descending bool) SearchOptions {
+	return func(opts *searchOpts) {
+		opts.sortBy = field
+		opts.sortDescending = descending
+	}
+}
+
+// WithPagination option for getting a page of search results, limit '0' returns all results from offset.
+func WithPagination(offset, limit int) SearchOptions {
+	return func(opts *searchOpts) {
+		opts.offset = offset
+		opts.limit = limit
+	}
+}
+
+// interactOpts contains options for interacting with verifier through VPR interact services.
+type interactOpts struct {
+	// HTTP client for sending presentation to interact service.
+	httpClient HTTPClient
+	// type of the interact service to be used.
+	serviceType string
+	// interact service endpoint is confirmed by the caller even if its origin differs from request domain.
+	serviceConfirmed bool
+}
+
+// InteractOptions is option for interacting with verifier through VPR interact services.
+type InteractOptions func(opts *interactOpts)
+
+// WithInteractHTTPClient option for providing HTTP client for sending presentation to interact service.
+// Default HTTP client will be used if not provided.
+func WithInteractHTTPClient(client HTTPClient) InteractOptions {
+	return func(opts *interactOpts) {
+		opts.httpClient = client
+	}
+}
+
+// WithInteractServiceType option for choosing interact service of presentation request by type,
+// like 'UnmediatedHttpPresentationService2021' or 'MediatedHttpPresentationService2021'.
+func WithInteractServiceType(serviceType string) InteractOptions {
+	return func(opts *interactOpts) {
+		opts.serviceType = serviceType
+	}
+}
+
+// WithInteractServiceConfirmed option for sending presentation to interact service endpoint
+// even if its origin differs from domain of the presentation request.
+// Use it only when caller has confirmed that the endpoint belongs to the verifier of the request domain,
+// otherwise presentation bound to domain of another verifier can be replayed.
+func WithInteractServiceConfirmed() InteractOptions {
+	return func(opts *interactOpts) {
+		opts.serviceConfirmed = true
+	}
+}
+
+// issuanceOpts contains options for requesting credentials from OpenID4VCI credential issuers.
+type issuanceOpts struct {
+	// HTTP client for calling issuer and authorization server.
+	httpClient HTTPClient
+	// transaction code of pre-authorized code grant.
+	txCode string
+	// DID and verification method for signing proof of possession.
+	controller         string
+	verificationMethod string
+	// IDs of offered credential configurations to be requested.
+	configurationIDs []string
+	// ID of the collection to which issued credentials are added.
+	collectionID string
+}
+
+// IssuanceOptions is option for requesting credentials from OpenID4VCI credential issuers.
+type IssuanceOptions func(opts *issuanceOpts)
+
+// WithIssuanceHTTPClient option for providing HTTP client for calling credential issuer and authorization server.
+// Default HTTP client will be used if not provided.
+func WithIssuanceHTTPClient(client HTTPClient) IssuanceOptions {
+	return func(opts *issuanceOpts) {
+		opts.httpClient = client
+	}
+}
+
+// WithIssuanceTxCode option for providing transaction code (PIN) received by holder out of band,
+// required if pre-authorized code grant of the credential offer asks for one.
+func WithIssuanceTxCode(txCode string) IssuanceOptions {
+	return func(opts *issuanceOpts) {
+		opts.txCode = txCode
+	}
+}
+
+// WithIssuanceController option for DID and optionally its verification method for signing proof of possession
+// of the credentials. Default wallet DID will be used if not provided.
+func WithIssuanceController(controller, verificationMethod string) IssuanceOptions {
+	return func(opts *issuanceOpts) {
+		opts.controller = controller
+		opts.verificationMethod = verificationMethod
+	}
+}
+
+// WithIssuanceCredentials option for requesting only given credential configurations of the credential offer.
+// All offered credentials will be requested if not provided.
+func WithIssuanceCredentials(configurationIDs ...string) IssuanceOptions {
+	return func(opts *issuanceOpts) {
+		opts.configurationIDs = configurationIDs
+	}
+}
+
+// WithIssuanceCollection option for adding issued credentials to given wallet collection.
+func WithIssuanceCollection(collectionID string) IssuanceOptions {
+	return func(opts *issuanceOpts) {
+		opts.collectionID = collectionID
+	}
+}
+
 // connectOpts contains options for wallet's DIDComm connect features.
 type connectOpts struct {
 	outofband.EventOptions
@@ -440,3 +679,220 @@ func WaitForDone(timeout time.Duration) ConcludeInteractionOptions {
 		}
 	}
 }
+
+// exportOpts contains options for exporting wallet contents.
+type exportOpts struct {
+	// passphrase from which the wallet contents encryption key is derived.
+	passphrase string
+	// ID of the key agreement key of wallet key manager to encrypt wallet contents.
+	keyID string
+	// skip keys of wallet key manager which can't be exported instead of failing export.
+	skipUnexportableKeys bool
+}
+
+// ExportOptions is option for exporting wallet contents.
+type ExportOptions func(opts *exportOpts)
+
+// WithExportPassphrase option for encrypting exported wallet contents by key derived from passphrase (PBES2).
+// This option takes precedence when provided along with other options.
+func WithExportPassphrase(passphrase string) ExportOptions {
+	return func(opts *exportOpts) {
+		opts.passphrase = passphrase
+	}
+}
+
+// WithExportKeyID option for encrypting exported wallet contents by key agreement key of wallet key manager
+// (ex: key of 'NISTP256ECDHKW' type). Key has to be present in key manager of the wallet to be imported to.
+func WithExportKeyID(kid string) ExportOptions {
+	return func(opts *exportOpts) {
+		opts.keyID = kid
+	}
+}
+
+// WithExportSkippingUnexportableKeys option for exporting wallet even if it has keys of local key manager which
+// can't be exported, like key agreement keys. Exported wallet doesn't contain those keys, so DIDs and connections
+// using them aren't usable in wallet it is imported to.
+func WithExportSkippingUnexportableKeys() ExportOptions {
+	return func(opts *exportOpts) {
+		opts.skipUnexportableKeys = true
+	}
+}
+
+// importOpts contains options for importing wallet contents.
+type importOpts struct {
+	// passphrase used for exporting wallet contents.
+	passphrase string
+	// policy for handling contents already present in wallet.
+	conflictPolicy ImportConflictPolicy
+}
+
+// ImportOptions is option for importing wallet contents.
+type ImportOptions func(opts *importOpts)
+
+// WithImportPassphrase option for decrypting wallet contents exported by using 'WithExportPassphrase' option.
+// If not provided then wallet contents will be decrypted by wallet key manager.
+func WithImportPassphrase(passphrase string) ImportOptions {
+	return func(opts *importOpts) {
+		opts.passphrase = passphrase
+	}
+}
+
+// WithImportConflictPolicy option for handling imported contents which are already present in wallet.
+// By default, contents already present in wallet are skipped.
+func WithImportConflictPolicy(policy ImportConflictPolicy) ImportOptions {
+	return func(opts *importOpts) {
+		opts.conflictPolicy = policy
+	}
+}
+
+// credentialApplicationOpts contains options for creating credential application from wallet.
+type credentialApplicationOpts struct {
+	// selected credential IDs mapped by input descriptor ID of the presentation definition of the manifest.
+	selection map[string][]string
+	// options for signing credential application.
+	proofOptions *ProofOptions
+}
+
+// CredentialApplicationOptions is option for creating credential application from wallet.
+type CredentialApplicationOptions func(opts *credentialApplicationOpts)
+
+// WithApplicationSelection option for submitting wallet credentials selected by holder for input descriptors of
+// presentation definition of credential manifest, usually among the candidates returned by
+// MatchPresentationDefinition. If not provided then all matching wallet credentials will be submitted.
+func WithApplicationSelection(selection map[string][]string) CredentialApplicationOptions {
+	return func(opts *credentialApplicationOpts) {
+		opts.selection = selection
+	}
+}
+
+// WithApplicationProof option for signing credential application, challenge and domain of the credential
+// manifest offer are used if not provided.
+func WithApplicationProof(proofOptions *ProofOptions) CredentialApplicationOptions {
+	return func(opts *credentialApplicationOpts) {
+		opts.proofOptions = proofOptions
+	}
+}
+
+// resolveManifestOpts contains options for resolving credential manifest output descriptors.
+type resolveManifestOpts struct {
+	// credential fulfillment received from issuer.
+	fulfillment *verifiable.Presentation
+	// raw credential fulfillment received from issuer.
+	rawFulfillment json.RawMessage
+	// credential to be resolved against output descriptors of the manifest.
+	credential *verifiable.Credential
+	// raw credential to be resolved against output descriptors of the manifest.
+	rawCredential json.RawMessage
+	// ID of the stored credential to be resolved against output descriptors of the manifest.
+	credentialID string
+}
+
+// ResolveManifestOption is option for resolving credential manifest output descriptors from wallet.
+type ResolveManifestOption func(opts *resolveManifestOpts)
+
+// ResolveFulfillment option for resolving output descriptors of credential fulfillment presentation.
+func ResolveFulfillment(fulfillment *verifiable.Presentation) ResolveManifestOption {
+	return func(opts *resolveManifestOpts) {
+		opts.fulfillment = fulfillment
+	}
+}
+
+// ResolveRawFulfillment option for resolving output descriptors of raw credential fulfillment presentation.
+func ResolveRawFulfillment(fulfillment json.RawMessage) ResolveManifestOption {
+	return func(opts *resolveManifestOpts) {
+		opts.rawFulfillment = fulfillment
+	}
+}
+
+// ResolveCredential option for resolving output descriptors of manifest for a credential.
+func ResolveCredential(credential *verifiable.Credential) ResolveManifestOption {
+	return func(opts *resolveManifestOpts) {
+		opts.credential = credential
+	}
+}
+
+// ResolveRawCredential option for resolving output descriptors of manifest for a raw credential.
+func ResolveRawCredential(credential json.RawMessage) ResolveManifestOption {
+	return func(opts *resolveManifestOpts) {
+		opts.rawCredential = credential
+	}
+}
+
+// ResolveCredentialID option for resolving output descriptors of manifest for a credential stored in wallet.
+func ResolveCredentialID(credentialID string) ResolveManifestOption {
+	return func(opts *resolveManifestOpts) {
+		opts.credentialID = credentialID
+	}
+}
+
+// createDIDOpts contains options for creating DID from wallet.
+type createDIDOpts struct {
+	// type of the wallet key to be created for the DID.
+	keyType kms.KeyType
+	// set created DID as default DID for signing.
+	setDefault bool
+	// DID method specific options.
+	methodOptions []vdr.DIDMethodOption
+}
+
+// CreateDIDOptions is option for creating DID from wallet.
+type CreateDIDOptions func(opts *createDIDOpts)
+
+// WithDIDKeyType option for type of the wallet key to be created for the DID.
+// If not provided then key of type 'ED25519' will be created.
+func WithDIDKeyType(keyType kms.KeyType) CreateDIDOptions {
+	return func(opts *createDIDOpts) {
+		opts.keyType = keyType
+	}
+}
+
+// WithDefaultDID option for setting created DID as default DID for signing.
+func WithDefaultDID() CreateDIDOptions {
+	return func(opts *createDIDOpts) {
+		opts.setDefault = true
+	}
+}
+
+// WithCreateDIDMethodOptions option for DID method specific options for creating DID, like hosting options of
+// 'did:web' or request builder of 'did:orb'.
+func WithCreateDIDMethodOptions(options ...vdr.DIDMethodOption) CreateDIDOptions {
+	return func(opts *createDIDOpts) {
+		opts.methodOptions = options
+	}
+}
+
+// updateDIDOpts contains options for updating DID from wallet.
+type updateDIDOpts struct {
+	// updated DID document.
+	didDoc *did.Doc
+	// rotate wallet keys bound to DID.
+	rotateKeys bool
+	// DID method specific options.
+	methodOptions []vdr.DIDMethodOption
+}
+
+// UpdateDIDOptions is option for updating DID from wallet.
+type UpdateDIDOptions func(opts *updateDIDOpts)
+
+// WithUpdatedDIDDocument option for DID document to replace the current DID document of the DID.
+// If not provided then current DID document saved in wallet will be updated.
+func WithUpdatedDIDDocument(didDoc *did.Doc) UpdateDIDOptions {
+	return func(opts *updateDIDOpts) {
+		opts.didDoc = didDoc
+	}
+}
+
+// WithKeyRotation option for rotating wallet keys bound to verification methods of the DID, a new wallet key of
+// the same type will be created for each bound verification method.
+func WithKeyRotation() UpdateDIDOptions {
+	return func(opts *updateDIDOpts) {
+		opts.rotateKeys = true
+	}
+}
+
+// WithUpdateDIDMethodOptions option for DID method specific options for updating DID.
+func WithUpdateDIDMethodOptions(options ...vdr.DIDMethodOption) UpdateDIDOptions {
+	return func(opts *updateDIDOpts) {
+		opts.methodOptions = options
+	}
+}
@@ -0,0 +1,590 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// searchTermTagPrefix is prefix of the tags indexing wallet contents for equality filters.
+	searchTermTagPrefix = "wsi_"
+
+	// tags indexing wallet contents for range filters and sorting.
+	searchIssuedTag  = "wsi_issued"
+	searchExpiresTag = "wsi_expires"
+	searchNameTag    = "wsi_name"
+
+	// values longer than this are not indexed.
+	maxIndexedValueLength = 256
+
+	// number of leading characters of content name used for sorting.
+	maxSortNameLength = 64
+
+	// page size used while iterating search results from store.
+	searchPageSize = 100
+
+	// searchIndexVersionKey is key of the version of search index of wallet contents.
+	searchIndexVersionKey = "searchindexversion"
+
+	// searchIndexVersion is incremented when contents saved earlier have to be indexed again.
+	searchIndexVersion = "1"
+)
+
+// indexedContentTypes are content types indexed for search.
+// nolint:gochecknoglobals
+var indexedContentTypes = []ContentType{
+	Collection, Metadata, Connection, Credential, ManagedDID, SecuredCredential, DIDResolutionResponse,
+}
+
+// search index fields.
+const (
+	searchFieldID         = "id"
+	searchFieldType       = "type"
+	searchFieldIssuer     = "issuer"
+	searchFieldSubject    = "subject."
+	searchFieldCollection = "collection"
+	searchFieldText       = "text"
+)
+
+// SortField is field of wallet contents by which search results can be sorted.
+type SortField string
+
+const (
+	// SortByIssuanceDate sorts search results by 'issuanceDate' or 'validFrom' of wallet contents.
+	SortByIssuanceDate SortField = "issuanceDate"
+
+	// SortByExpirationDate sorts search results by 'expirationDate' or 'validUntil' of wallet contents.
+	SortByExpirationDate SortField = "expirationDate"
+
+	// SortByName sorts search results by 'name' of wallet contents.
+	SortByName SortField = "name"
+)
+
+// SearchResult is result of wallet content search.
+type SearchResult struct {
+	// Total number of contents matching search filters, irrespective of pagination.
+	Total int `json:"total"`
+
+	// Contents found in requested page of search results.
+	Contents []*SearchResultItem `json:"contents"`
+}
+
+// SearchResultItem is wallet content found by wallet content search.
+type SearchResultItem struct {
+	// ID of the content.
+	ID string `json:"id"`
+
+	// Content is raw wallet content.
+	Content json.RawMessage `json:"content"`
+}
+
+// indexedContent is set of wallet content fields indexed for search.
+type indexedContent struct {
+	ID                string          `json:"id"`
+	Type              json.RawMessage `json:"type"`
+	Issuer            json.RawMessage `json:"issuer"`
+	CredentialSubject json.RawMessage `json:"credentialSubject"`
+	Name              json.RawMessage `json:"name"`
+	Description       json.RawMessage `json:"description"`
+	IssuanceDate      string          `json:"issuanceDate"`
+	ValidFrom         string          `json:"validFrom"`
+	ExpirationDate    string          `json:"expirationDate"`
+	ValidUntil        string          `json:"validUntil"`
+}
+
+// searchIndexTags returns storage tags indexing given wallet content for search.
+// Equality filters are indexed as tag names derived from field and value, tag values are content type
+// so that contents of a content type can be queried by single tag.
+// Range filters and sort fields are indexed as static tag names with sortable tag values.
+func searchIndexTags(ct ContentType, content []byte, collectionID, contentID string) []storage.Tag {
+	var indexed indexedContent
+
+	// contents which are not JSON objects are saved without search index.
+	if err := json.Unmarshal(content, &indexed); err != nil {
+		return nil
+	}
+
+	if indexed.ID == "" {
+		indexed.ID = contentID
+	}
+
+	terms := map[string]struct{}{}
+
+	addTerm := func(field, value string) {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "" && len(value) <= maxIndexedValueLength {
+			terms[searchTermTag(field, value)] = struct{}{}
+		}
+	}
+
+	addTerm(searchFieldID, indexed.ID)
+	addTerm(searchFieldCollection, collectionID)
+
+	for _, t := range stringValues(indexed.Type) {
+		addTerm(searchFieldType, t)
+	}
+
+	addTerm(searchFieldIssuer, objectID(indexed.Issuer))
+
+	for _, subject := range subjects(indexed.CredentialSubject) {
+		for property, value := range subject {
+			if scalar, ok := scalarValue(value); ok {
+				addTerm(searchFieldSubject+property, scalar)
+			}
+		}
+	}
+
+	name := stringValue(indexed.Name)
+
+	for _, token := range textTokens(name + " " + stringValue(indexed.Description)) {
+		addTerm(searchFieldText, token)
+	}
+
+	tags := make([]storage.Tag, 0, len(terms))
+
+	for term := range terms {
+		tags = append(tags, storage.Tag{Name: term, Value: ct.Name()})
+	}
+
+	// sort for deterministic tags.
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+
+	if issued, ok := unixTime(indexed.IssuanceDate, indexed.ValidFrom); ok {
+		tags = append(tags, storage.Tag{Name: searchIssuedTag, Value: issued})
+	}
+
+	if expires, ok := unixTime(indexed.ExpirationDate, indexed.ValidUntil); ok {
+		tags = append(tags, storage.Tag{Name: searchExpiresTag, Value: expires})
+	}
+
+	if name != "" {
+		sortName := strings.ToLower(name)
+		if len(sortName) > maxSortNameLength {
+			sortName = sortName[:maxSortNameLength]
+		}
+
+		// hex encoding preserves sort order and avoids characters not supported in tag values.
+		tags = append(tags, storage.Tag{Name: searchNameTag, Value: hex.EncodeToString([]byte(sortName))})
+	}
+
+	return tags
+}
+
+// reindexSearch indexes wallet contents saved without search index, e.g. by earlier versions of wallet.
+// Contents are indexed once per search index version.
+func reindexSearch(store storage.Store) error {
+	version, err := store.Get(searchIndexVersionKey)
+	if err == nil && string(version) == searchIndexVersion {
+		return nil
+	} else if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("failed to get search index version: %w", err)
+	}
+
+	var collections map[string]string
+
+	for _, ct := range indexedContentTypes {
+		unindexed, err := queryContents(store, ct.Name(), func(tags []storage.Tag) bool {
+			return !hasSearchTermTag(tags)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find %s contents to index: %w", ct, err)
+		}
+
+		if len(unindexed) > 0 && collections == nil {
+			if collections, err = collectionMappings(store); err != nil {
+				return err
+			}
+		}
+
+		for key, content := range unindexed {
+			contentID := removeKeyPrefix(ct.Name(), key)
+			tags := append([]storage.Tag{{Name: ct.Name()}},
+				searchIndexTags(ct, content, collections[key], contentID)...)
+
+			if err := store.Put(key, content, tags...); err != nil {
+				return fmt.Errorf("failed to index %s content: %w", ct, err)
+			}
+		}
+	}
+
+	return store.Put(searchIndexVersionKey, []byte(searchIndexVersion))
+}
+
+// collectionMappings returns IDs of collections mapped to wallet contents by content storage key.
+func collectionMappings(store storage.Store) (map[string]string, error) {
+	collections, err := queryContents(store, Collection.Name(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	result := make(map[string]string)
+
+	for key := range collections {
+		collectionID := removeKeyPrefix(Collection.Name(), key)
+
+		mappings, err := queryContents(store, base64.StdEncoding.EncodeToString([]byte(collectionID)), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contents of collection '%s': %w", collectionID, err)
+		}
+
+		for mappingKey, ct := range mappings {
+			contentID := removeKeyPrefix(collectionMappingKeyPrefix, mappingKey)
+			result[getContentKeyPrefix(ContentType(ct), contentID)] = collectionID
+		}
+	}
+
+	return result, nil
+}
+
+// queryContents returns values by storage key of store entries having given tag, filtered by their tags if
+// filter is given.
+func queryContents(store storage.Store, tag string, filter func([]storage.Tag) bool) (map[string][]byte, error) {
+	iter, err := store.Query(tag, storage.WithPageSize(searchPageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if e := iter.Close(); e != nil {
+			logger.Warnf("failed to close iterator: %s", e)
+		}
+	}()
+
+	result := make(map[string][]byte)
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return result, nil
+		}
+
+		if filter != nil {
+			tags, err := iter.Tags()
+			if err != nil {
+				return nil, err
+			}
+
+			if !filter(tags) {
+				continue
+			}
+		}
+
+		key, err := iter.Key()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := iter.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = value
+	}
+}
+
+func hasSearchTermTag(tags []storage.Tag) bool {
+	for _, tag := range tags {
+		if strings.HasPrefix(tag.Name, searchTermTagPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// searchTermTag returns tag name indexing given field and value.
+func searchTermTag(field, value string) string {
+	digest := sha256.Sum256([]byte(field + "=" + value))
+
+	return searchTermTagPrefix + hex.EncodeToString(digest[:])
+}
+
+// terms returns tag names of all equality filters of search options.
+func (opts *searchOpts) terms() []string {
+	var terms []string
+
+	addTerm := func(field, value string) {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "" {
+			terms = append(terms, searchTermTag(field, value))
+		}
+	}
+
+	addTerm(searchFieldCollection, opts.collectionID)
+	addTerm(searchFieldIssuer, opts.issuer)
+
+	for _, t := range opts.types {
+		addTerm(searchFieldType, t)
+	}
+
+	for property, value := range opts.subject {
+		addTerm(searchFieldSubject+property, value)
+	}
+
+	for _, token := range textTokens(opts.text) {
+		addTerm(searchFieldText, token)
+	}
+
+	return terms
+}
+
+// searchMatch is a wallet content matching search filters.
+type searchMatch struct {
+	key     string
+	issued  *int64
+	expires *int64
+	name    string
+}
+
+// match returns search match for given content tags if they satisfy all search filters.
+func (opts *searchOpts) match(key string, tags []storage.Tag, terms []string) (*searchMatch, bool) {
+	tagValues := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagValues[tag.Name] = tag.Value
+	}
+
+	for _, term := range terms {
+		if _, ok := tagValues[term]; !ok {
+			return nil, false
+		}
+	}
+
+	result := &searchMatch{key: key, name: tagValues[searchNameTag]}
+
+	if v, err := strconv.ParseInt(tagValues[searchIssuedTag], 10, 64); err == nil {
+		result.issued = &v
+	}
+
+	if v, err := strconv.ParseInt(tagValues[searchExpiresTag], 10, 64); err == nil {
+		result.expires = &v
+	}
+
+	if !inRange(result.issued, opts.issuedFrom, opts.issuedTo) ||
+		!inRange(result.expires, opts.expiresFrom, opts.expiresTo) {
+		return nil, false
+	}
+
+	return result, true
+}
+
+// sort sorts search matches by sort field of search options, matches without sort field value are sorted last.
+// Matches are sorted by content key if sort field is not provided or sort field values are equal.
+func (opts *searchOpts) sort(matches []*searchMatch) {
+	compare := func(i, j int) int {
+		switch opts.sortBy {
+		case SortByIssuanceDate:
+			return compareTimes(matches[i].issued, matches[j].issued)
+		case SortByExpirationDate:
+			return compareTimes(matches[i].expires, matches[j].expires)
+		case SortByName:
+			return compareNames(matches[i].name, matches[j].name)
+		default:
+			return 0
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if c := compare(i, j); c != 0 {
+			if opts.sortDescending && c != missingSortValue && c != -missingSortValue {
+				return c > 0
+			}
+
+			return c < 0
+		}
+
+		return matches[i].key < matches[j].key
+	})
+}
+
+// missingSortValue is comparison result when one of the compared values is missing,
+// which always sorts missing values last irrespective of sort direction.
+const missingSortValue = 2
+
+func compareTimes(a, b *int64) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return missingSortValue
+	case b == nil:
+		return -missingSortValue
+	case *a < *b:
+		return -1
+	case *a > *b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareNames(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return missingSortValue
+	case b == "":
+		return -missingSortValue
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// validate validates search options.
+func (opts *searchOpts) validate() error {
+	switch opts.sortBy {
+	case "", SortByIssuanceDate, SortByExpirationDate, SortByName:
+	default:
+		return fmt.Errorf("invalid sort field '%s', supported fields are %s", opts.sortBy,
+			[]SortField{SortByIssuanceDate, SortByExpirationDate, SortByName})
+	}
+
+	if opts.offset < 0 || opts.limit < 0 {
+		return fmt.Errorf("pagination offset and limit can't be negative")
+	}
+
+	return nil
+}
+
+// page returns requested page of search matches, limit '0' returns all matches from offset.
+func (opts *searchOpts) page(matches []*searchMatch) []*searchMatch {
+	if opts.offset >= len(matches) {
+		return nil
+	}
+
+	matches = matches[opts.offset:]
+
+	if opts.limit > 0 && opts.limit < len(matches) {
+		matches = matches[:opts.limit]
+	}
+
+	return matches
+}
+
+func inRange(value *int64, from, to *time.Time) bool {
+	if from == nil && to == nil {
+		return true
+	}
+
+	if value == nil {
+		return false
+	}
+
+	return (from == nil || *value >= from.Unix()) && (to == nil || *value <= to.Unix())
+}
+
+// unixTime returns first valid RFC3339 time among given values as unix time string.
+func unixTime(values ...string) (string, bool) {
+	for _, value := range values {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return strconv.FormatInt(t.Unix(), 10), true
+		}
+	}
+
+	return "", false
+}
+
+// textTokens splits given text into lower case words for free text search.
+func textTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// stringValue returns string value of given raw JSON, empty if it is not a JSON string.
+func stringValue(raw json.RawMessage) string {
+	var value string
+
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return ""
+	}
+
+	return value
+}
+
+// stringValues returns string values of given raw JSON string or array of strings.
+func stringValues(raw json.RawMessage) []string {
+	if value := stringValue(raw); value != "" {
+		return []string{value}
+	}
+
+	var values []string
+
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil
+	}
+
+	return values
+}
+
+// objectID returns ID of given raw JSON, which can be an ID string or an object with 'id' property.
+func objectID(raw json.RawMessage) string {
+	if value := stringValue(raw); value != "" {
+		return value
+	}
+
+	var obj contentID
+
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return ""
+	}
+
+	return obj.ID
+}
+
+// subjects returns credential subjects of given raw JSON, which can be a subject ID, an object or array of objects.
+func subjects(raw json.RawMessage) []map[string]interface{} {
+	if id := stringValue(raw); id != "" {
+		return []map[string]interface{}{{searchFieldID: id}}
+	}
+
+	var subject map[string]interface{}
+
+	if err := json.Unmarshal(raw, &subject); err == nil {
+		return []map[string]interface{}{subject}
+	}
+
+	var result []map[string]interface{}
+
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil
+	}
+
+	return result
+}
+
+// scalarValue returns string representation of given JSON value if it is a string, number or boolean.
+func scalarValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,371 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	sampleSearchVCFmt = `{
+      "@context": ["https://www.w3.org/2018/credentials/v1"],
+      "id": "%s",
+      "type": ["VerifiableCredential", "%s"],
+      "name": "%s",
+      "description": "%s",
+      "issuer": {"id": "%s"},
+      "issuanceDate": "%s",
+      "credentialSubject": {"id": "did:example:holder", "degree": "%s", "graduated": true, "year": 2020}
+    }`
+	sampleSearchVCExpiringFmt = `{
+      "@context": ["https://www.w3.org/2018/credentials/v1"],
+      "id": "%s",
+      "type": ["VerifiableCredential", "%s"],
+      "issuer": "%s",
+      "issuanceDate": "%s",
+      "expirationDate": "%s",
+      "credentialSubject": "did:example:holder"
+    }`
+	sampleSearchIssuerA = "did:example:university"
+	sampleSearchIssuerB = "did:example:dmv"
+)
+
+func TestWallet_Search(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newMockProvider(t))
+
+	contents := []string{
+		fmt.Sprintf(sampleSearchVCFmt, "http://example.edu/credentials/1", "UniversityDegreeCredential",
+			"Bachelor Degree", "Bachelor of Science and Arts", sampleSearchIssuerA, "2019-06-01T00:00:00Z", "BSc"),
+		fmt.Sprintf(sampleSearchVCFmt, "http://example.edu/credentials/2", "UniversityDegreeCredential",
+			"Master Degree", "Master of Science", sampleSearchIssuerA, "2021-06-01T00:00:00Z", "MSc"),
+		fmt.Sprintf(sampleSearchVCExpiringFmt, "http://example.gov/credentials/3", "DriversLicense",
+			sampleSearchIssuerB, "2020-01-01T00:00:00Z", "2025-01-01T00:00:00Z"),
+	}
+
+	require.NoError(t, walletInstance.Add(token, Collection, []byte(sampleCollection)))
+
+	for i, content := range contents {
+		var opts []AddContentOptions
+		if i == 0 {
+			opts = append(opts, AddByCollection(sampleCollectionID))
+		}
+
+		require.NoError(t, walletInstance.Add(token, Credential, []byte(content), opts...))
+	}
+
+	ids := func(result *SearchResult) []string {
+		var r []string
+		for _, item := range result.Contents {
+			r = append(r, item.ID)
+		}
+
+		return r
+	}
+
+	t.Run("search without filters", func(t *testing.T) {
+		result, err := walletInstance.Search(token, Credential)
+		require.NoError(t, err)
+		require.Equal(t, 3, result.Total)
+		require.Equal(t, []string{
+			"http://example.edu/credentials/1", "http://example.edu/credentials/2", "http://example.gov/credentials/3",
+		}, ids(result))
+		require.JSONEq(t, contents[0], string(result.Contents[0].Content))
+	})
+
+	t.Run("search by field filters", func(t *testing.T) {
+		result, err := walletInstance.Search(token, Credential, WithTypeFilter("UniversityDegreeCredential"))
+		require.NoError(t, err)
+		require.Equal(t, 2, result.Total)
+
+		result, err = walletInstance.Search(token, Credential, WithIssuerFilter(sampleSearchIssuerB))
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://example.gov/credentials/3"}, ids(result))
+
+		result, err = walletInstance.Search(token, Credential,
+			WithTypeFilter("VerifiableCredential", "UniversityDegreeCredential"),
+			WithSubjectFilter("degree", "msc"), WithSubjectFilter("graduated", "true"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://example.edu/credentials/2"}, ids(result))
+
+		result, err = walletInstance.Search(token, Credential, WithSubjectFilter("id", "did:example:holder"))
+		require.NoError(t, err)
+		require.Equal(t, 3, result.Total)
+
+		result, err = walletInstance.Search(token, Credential, WithCollectionFilter(sampleCollectionID))
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://example.edu/credentials/1"}, ids(result))
+
+		result, err = walletInstance.Search(token, Credential, WithIssuerFilter(sampleSearchIssuerB),
+			WithTypeFilter("UniversityDegreeCredential"))
+		require.NoError(t, err)
+		require.Zero(t, result.Total)
+		require.Empty(t, result.Contents)
+	})
+
+	t.Run("search by free text", func(t *testing.T) {
+		result, err := walletInstance.Search(token, Credential, WithTextFilter("science"))
+		require.NoError(t, err)
+		require.Equal(t, 2, result.Total)
+
+		result, err = walletInstance.Search(token, Credential, WithTextFilter("bachelor, ARTS"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://example.edu/credentials/1"}, ids(result))
+
+		result, err = walletInstance.Search(token, Credential, WithTextFilter("sci"))
+		require.NoError(t, err)
+		require.Zero(t, result.Total)
+	})
+
+	t.Run("search by date ranges", func(t *testing.T) {
+		from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		result, err := walletInstance.Search(token, Credential, WithIssuanceDateRange(&from, &to))
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://example.gov/credentials/3"}, ids(result))
+
+		result, err = walletInstance.Search(token, Credential, WithIssuanceDateRange(&from, nil))
+		require.NoError(t, err)
+		require.Equal(t, 2, result.Total)
+
+		result, err = walletInstance.Search(token, Credential, WithExpirationDateRange(nil, &to))
+		require.NoError(t, err)
+		require.Zero(t, result.Total)
+
+		result, err = walletInstance.Search(token, Credential, WithExpirationDateRange(&from, nil))
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://example.gov/credentials/3"}, ids(result))
+	})
+
+	t.Run("search with sorting and pagination", func(t *testing.T) {
+		result, err := walletInstance.Search(token, Credential, WithSortBy(SortByIssuanceDate, true))
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"http://example.edu/credentials/2", "http://example.gov/credentials/3", "http://example.edu/credentials/1",
+		}, ids(result))
+
+		// contents without name are sorted last.
+		result, err = walletInstance.Search(token, Credential, WithSortBy(SortByName, true))
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"http://example.edu/credentials/2", "http://example.edu/credentials/1", "http://example.gov/credentials/3",
+		}, ids(result))
+
+		result, err = walletInstance.Search(token, Credential, WithSortBy(SortByExpirationDate, false),
+			WithPagination(0, 1))
+		require.NoError(t, err)
+		require.Equal(t, 3, result.Total)
+		require.Equal(t, []string{"http://example.gov/credentials/3"}, ids(result))
+
+		result, err = walletInstance.Search(token, Credential, WithSortBy(SortByIssuanceDate, false),
+			WithPagination(1, 5))
+		require.NoError(t, err)
+		require.Equal(t, 3, result.Total)
+		require.Equal(t, []string{"http://example.gov/credentials/3", "http://example.edu/credentials/2"}, ids(result))
+
+		result, err = walletInstance.Search(token, Credential, WithPagination(3, 1))
+		require.NoError(t, err)
+		require.Equal(t, 3, result.Total)
+		require.Empty(t, result.Contents)
+	})
+
+	t.Run("search other content types", func(t *testing.T) {
+		result, err := walletInstance.Search(token, Collection, WithTextFilter("ACME"))
+		require.NoError(t, err)
+		require.Equal(t, []string{sampleCollectionID}, ids(result))
+
+		result, err = walletInstance.Search(token, Metadata)
+		require.NoError(t, err)
+		require.Zero(t, result.Total)
+	})
+
+	t.Run("search failures", func(t *testing.T) {
+		result, err := walletInstance.Search(token, "invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid content type")
+		require.Empty(t, result)
+
+		result, err = walletInstance.Search(token, Key)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "can't be searched")
+		require.Empty(t, result)
+
+		result, err = walletInstance.Search(token, Credential, WithSortBy("invalid", false))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid sort field")
+		require.Empty(t, result)
+
+		result, err = walletInstance.Search(token, Credential, WithPagination(-1, 0))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "can't be negative")
+		require.Empty(t, result)
+
+		result, err = walletInstance.Search(sampleFakeTkn, Credential)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+		require.Empty(t, result)
+	})
+}
+
+func TestContentStore_Search(t *testing.T) {
+	sp := mockstorage.NewMockStoreProvider()
+	token, contentStore := newSearchContentStore(t, sp)
+
+	vc := fmt.Sprintf(sampleSearchVCFmt, "http://example.edu/credentials/1", "UniversityDegreeCredential",
+		"Bachelor Degree", "Bachelor of Science and Arts", sampleSearchIssuerA, "2019-06-01T00:00:00Z", "BSc")
+	require.NoError(t, contentStore.Save(token, Credential, []byte(vc)))
+
+	t.Run("contents are indexed by storage tags", func(t *testing.T) {
+		entry, ok := sp.Store.Store[getContentKeyPrefix(Credential, "http://example.edu/credentials/1")]
+		require.True(t, ok)
+		require.Contains(t, entry.Tags, storage.Tag{Name: Credential.Name()})
+		require.Contains(t, entry.Tags, storage.Tag{
+			Name:  searchTermTag(searchFieldIssuer, sampleSearchIssuerA),
+			Value: Credential.Name(),
+		})
+		require.Contains(t, entry.Tags, storage.Tag{Name: searchIssuedTag, Value: "1559347200"})
+
+		for _, tag := range entry.Tags {
+			require.NotContains(t, tag.Name, ":")
+			require.NotContains(t, tag.Value, ":")
+		}
+	})
+
+	t.Run("contents without search index", func(t *testing.T) {
+		require.Empty(t, searchIndexTags(Credential, []byte("[]"), "", ""))
+
+		require.NoError(t, sp.Store.Put(getContentKeyPrefix(Credential, "legacy"), []byte(sampleContentNoID),
+			storage.Tag{Name: Credential.Name()}))
+
+		result, err := contentStore.Search(token, Credential, &searchOpts{})
+		require.NoError(t, err)
+		require.Equal(t, 2, result.Total)
+
+		result, err = contentStore.Search(token, Credential, &searchOpts{types: []string{"VerifiableCredential"}})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Total)
+	})
+
+	t.Run("store failures", func(t *testing.T) {
+		sp.Store.ErrQuery = errors.New(sampleContenttErr)
+		result, err := contentStore.Search(token, Credential, &searchOpts{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleContenttErr)
+		require.Empty(t, result)
+
+		sp.Store.ErrQuery = nil
+		sp.Store.ErrNext = errors.New(sampleContenttErr)
+		result, err = contentStore.Search(token, Credential, &searchOpts{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleContenttErr)
+		require.Empty(t, result)
+
+		sp.Store.ErrNext = nil
+		sp.Store.ErrKey = errors.New(sampleContenttErr)
+		result, err = contentStore.Search(token, Credential, &searchOpts{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleContenttErr)
+		require.Empty(t, result)
+
+		sp.Store.ErrKey = nil
+		sp.Store.ErrGet = errors.New(sampleContenttErr)
+		result, err = contentStore.Search(token, Credential, &searchOpts{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read search result")
+		require.Empty(t, result)
+
+		sp.Store.ErrGet = nil
+
+		result, err = contentStore.Search(sampleFakeTkn, Credential, &searchOpts{})
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+		require.Empty(t, result)
+	})
+}
+
+func TestContentStore_ReindexSearch(t *testing.T) {
+	const collectionID = "did:example:collection:1"
+
+	sp := mockstorage.NewMockStoreProvider()
+
+	vc := fmt.Sprintf(sampleSearchVCFmt, "http://example.edu/credentials/1", "UniversityDegreeCredential",
+		"Bachelor Degree", "Bachelor of Science and Arts", sampleSearchIssuerA, "2019-06-01T00:00:00Z", "BSc")
+
+	// contents saved without search index.
+	require.NoError(t, sp.Store.Put(getContentKeyPrefix(Collection, collectionID),
+		[]byte(fmt.Sprintf(`{"id":"%s","type":"Vault"}`, collectionID)), storage.Tag{Name: Collection.Name()}))
+	require.NoError(t, sp.Store.Put(getContentKeyPrefix(Credential, "http://example.edu/credentials/1"),
+		[]byte(vc), storage.Tag{Name: Credential.Name()}))
+	require.NoError(t, sp.Store.Put(getCollectionMappingKeyPrefix("http://example.edu/credentials/1"),
+		[]byte(Credential.Name()), storage.Tag{Name: base64.StdEncoding.EncodeToString([]byte(collectionID))}))
+
+	token, contentStore := newSearchContentStore(t, sp)
+
+	version, err := sp.Store.Get(searchIndexVersionKey)
+	require.NoError(t, err)
+	require.Equal(t, searchIndexVersion, string(version))
+
+	result, err := contentStore.Search(token, Credential, &searchOpts{
+		types:        []string{"UniversityDegreeCredential"},
+		issuer:       sampleSearchIssuerA,
+		collectionID: collectionID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Total)
+
+	result, err = contentStore.Search(token, Collection, &searchOpts{types: []string{"Vault"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Total)
+
+	t.Run("contents are indexed once", func(t *testing.T) {
+		require.NoError(t, sp.Store.Put(getContentKeyPrefix(Credential, "legacy"), []byte(sampleContentNoID),
+			storage.Tag{Name: Credential.Name()}))
+		require.NoError(t, reindexSearch(sp.Store))
+
+		entry, ok := sp.Store.Store[getContentKeyPrefix(Credential, "legacy")]
+		require.True(t, ok)
+		require.Equal(t, []storage.Tag{{Name: Credential.Name()}}, entry.Tags)
+	})
+
+	t.Run("store failures", func(t *testing.T) {
+		store := mockstorage.NewMockStoreProvider().Store
+
+		store.ErrGet = errors.New(sampleContenttErr)
+		err := reindexSearch(store)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get search index version")
+
+		store.ErrGet = nil
+		store.ErrQuery = errors.New(sampleContenttErr)
+		err = reindexSearch(store)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleContenttErr)
+	})
+}
+
+func newSearchContentStore(t *testing.T, sp *mockstorage.MockStoreProvider) (string, *contentStore) {
+	t.Helper()
+
+	token := uuid.New().String()
+
+	require.NoError(t, keyManager().saveKeyManger(uuid.New().String(), token,
+		&mockkms.KeyManager{}, 1000*time.Millisecond))
+
+	contentStore := newContentStore(sp, &profile{ID: uuid.New().String()})
+	require.NoError(t, contentStore.Open(token, &unlockOpts{}))
+
+	return token, contentStore
+}
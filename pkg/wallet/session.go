@@ -0,0 +1,400 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bluele/gcache"
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	sessionStoreName      = "vcwallet_sessions"
+	sessionStoreKeyPrefix = "vcwallet_session_%s"
+	sessionProfileTag     = "vcwallet_session_profile"
+)
+
+// ErrSessionNotFound when wallet session to be revoked is not found or already expired.
+var ErrSessionNotFound = errors.New("wallet session not found")
+
+// Session contains details of an unlocked wallet session.
+// Session token and key manager of the session are never part of session details.
+type Session struct {
+	// ID of the session, remains same for the lifetime of the session even if session token is refreshed.
+	ID string `json:"id"`
+
+	// User ID of the wallet profile user.
+	User string `json:"user"`
+
+	// ProfileID of the wallet profile unlocked by this session.
+	ProfileID string `json:"profileID,omitempty"`
+
+	// Created time of the session.
+	Created time.Time `json:"created"`
+
+	// LastAccessed time of the session.
+	// For sessions found only in session store, this is the time when session was created or last refreshed.
+	LastAccessed time.Time `json:"lastAccessed"`
+
+	// Expires is absolute expiry time of the session, session expires at this time irrespective of its activity.
+	Expires time.Time `json:"expires"`
+
+	// IdleTimeout is the duration of inactivity after which session expires, zero value disables idle timeout.
+	IdleTimeout time.Duration `json:"idleTimeout,omitempty"`
+
+	// LockOnBackground is true if session has to be locked when wallet application goes to background.
+	LockOnBackground bool `json:"lockOnBackground,omitempty"`
+}
+
+func (s *Session) expired(now time.Time) bool {
+	return now.After(s.Expires) || (s.IdleTimeout > 0 && now.Sub(s.LastAccessed) > s.IdleTimeout)
+}
+
+// session is an unlocked wallet session holding key manager instance of the wallet user.
+type session struct {
+	Session
+	token      string
+	keyManager kms.KeyManager
+	store      *sessionStore
+}
+
+// newSession creates a new wallet session for given user and key manager.
+func newSession(user, profileID, token string, manager kms.KeyManager, opts *unlockOpts) *session {
+	now := time.Now()
+
+	expiry := opts.tokenExpiry
+	if expiry == 0 {
+		expiry = defaultCacheExpiry
+	}
+
+	return &session{
+		Session: Session{
+			ID:               uuid.New().String(),
+			User:             user,
+			ProfileID:        profileID,
+			Created:          now,
+			LastAccessed:     now,
+			Expires:          now.Add(expiry),
+			IdleTimeout:      opts.idleTimeout,
+			LockOnBackground: opts.lockOnBackground,
+		},
+		token:      token,
+		keyManager: manager,
+	}
+}
+
+// LockBackgroundSessions locks all wallet sessions which are unlocked with 'WithUnlockLockOnBackground()' option.
+// Typically used by mobile wallet applications when application goes to background.
+//
+//	Returns number of sessions locked.
+func LockBackgroundSessions() int {
+	return keyManager().lockBackgroundSessions()
+}
+
+// getSession returns active session for given token and refreshes its last access time.
+// returns 'gcache.KeyNotFoundError' if session is not found, expired or revoked.
+func (k *walletKeyManager) getSession(token string) (*session, error) {
+	now := time.Now()
+
+	k.lock.Lock()
+
+	s, ok := k.sessions[token]
+	if ok && s.expired(now) {
+		k.endSession(s, true)
+
+		ok = false
+	}
+
+	if ok {
+		s.LastAccessed = now
+	}
+
+	k.lock.Unlock()
+
+	if !ok {
+		return nil, gcache.KeyNotFoundError
+	}
+
+	// session persisted in session store might have been revoked by other wallet instance.
+	if s.store != nil && s.store.revoked(s.ID) {
+		k.lock.Lock()
+		k.endSession(s, true)
+		k.lock.Unlock()
+
+		return nil, gcache.KeyNotFoundError
+	}
+
+	return s, nil
+}
+
+// activeSessions returns active sessions of given user.
+func (k *walletKeyManager) activeSessions(user string) []*Session {
+	now := time.Now()
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	var result []*Session
+
+	for _, s := range k.sessions {
+		if s.User != user {
+			continue
+		}
+
+		if s.expired(now) {
+			k.endSession(s, true)
+
+			continue
+		}
+
+		sessionInfo := s.Session
+		result = append(result, &sessionInfo)
+	}
+
+	return result
+}
+
+// refreshSession replaces token of the session with a new token and resets its idle timer,
+// absolute expiry of the session remains unchanged.
+func (k *walletKeyManager) refreshSession(token string) (string, error) {
+	s, err := k.getSession(token)
+	if err != nil {
+		return "", err
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if _, ok := k.sessions[token]; !ok {
+		return "", gcache.KeyNotFoundError
+	}
+
+	refreshed := *s
+	refreshed.token = uuid.New().String()
+	refreshed.LastAccessed = time.Now()
+
+	if refreshed.store != nil {
+		if err := refreshed.store.put(&refreshed.Session); err != nil {
+			return "", fmt.Errorf("failed to persist wallet session: %w", err)
+		}
+	}
+
+	delete(k.sessions, token)
+	k.sessions[refreshed.token] = &refreshed
+
+	return refreshed.token, nil
+}
+
+// revokeSession revokes session of given user by session ID.
+// returns true if session is found in active sessions of this wallet key manager.
+func (k *walletKeyManager) revokeSession(user, sessionID string) bool {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	for _, s := range k.sessions {
+		if s.User == user && s.ID == sessionID {
+			k.endSession(s, true)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// lockBackgroundSessions ends all sessions to be locked when wallet application goes to background.
+func (k *walletKeyManager) lockBackgroundSessions() int {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	count := 0
+
+	for _, s := range k.sessions {
+		if s.LockOnBackground {
+			k.endSession(s, true)
+
+			count++
+		}
+	}
+
+	return count
+}
+
+// endSession removes given session and its persisted record,
+// releases wallet content store of the profile if it is the last session of wallet user and 'releaseStore=true'.
+// caller should hold the lock.
+func (k *walletKeyManager) endSession(s *session, releaseStore bool) {
+	delete(k.sessions, s.token)
+
+	if s.store != nil {
+		if err := s.store.delete(s.ID); err != nil {
+			logger.Warnf("failed to remove persisted wallet session: %s", err)
+		}
+	}
+
+	if !releaseStore || s.ProfileID == "" {
+		return
+	}
+
+	for _, active := range k.sessions {
+		if active.User == s.User {
+			return
+		}
+	}
+
+	storeManager().delete(s.ProfileID)
+}
+
+// purgeExpired removes all expired sessions of given user.
+// caller should hold the lock.
+func (k *walletKeyManager) purgeExpired(user string) {
+	now := time.Now()
+
+	for _, s := range k.sessions {
+		if s.User == user && s.expired(now) {
+			k.endSession(s, true)
+		}
+	}
+}
+
+// sessionStore persists details of wallet sessions,
+// which allows wallet sessions to be listed & revoked across wallet instances sharing same storage.
+// Session tokens and key managers are never persisted.
+type sessionStore struct {
+	store storage.Store
+}
+
+// newSessionStore opens wallet session store from given storage provider.
+func newSessionStore(provider storage.Provider) (*sessionStore, error) {
+	store, err := provider.OpenStore(sessionStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session store: %w", err)
+	}
+
+	err = provider.SetStoreConfig(sessionStoreName, storage.StoreConfiguration{TagNames: []string{sessionProfileTag}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set session store config: %w", err)
+	}
+
+	return &sessionStore{store: store}, nil
+}
+
+func (s *sessionStore) put(session *Session) error {
+	sessionBytes, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Put(getSessionKey(session.ID), sessionBytes,
+		storage.Tag{Name: sessionProfileTag, Value: session.ProfileID})
+}
+
+func (s *sessionStore) get(id string) (*Session, error) {
+	sessionBytes, err := s.store.Get(getSessionKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	var result Session
+
+	err = json.Unmarshal(sessionBytes, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// revoked returns true only if session record is not found in store,
+// other store errors are ignored to keep wallet usable during temporary storage failures.
+func (s *sessionStore) revoked(id string) bool {
+	_, err := s.store.Get(getSessionKey(id))
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		logger.Warnf("failed to get persisted wallet session: %s", err)
+
+		return false
+	}
+
+	return err != nil
+}
+
+func (s *sessionStore) delete(id string) error {
+	return s.store.Delete(getSessionKey(id))
+}
+
+// list returns all unexpired sessions of given profile persisted in store, expired sessions found will be removed.
+func (s *sessionStore) list(profileID string) ([]*Session, error) {
+	iter, err := s.store.Query(fmt.Sprintf("%s:%s", sessionProfileTag, profileID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query session store: %w", err)
+	}
+
+	defer func() {
+		if e := iter.Close(); e != nil {
+			logger.Warnf("failed to close iterator: %s", e)
+		}
+	}()
+
+	var result []*Session
+
+	now := time.Now()
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read session store: %w", err)
+		}
+
+		if !ok {
+			break
+		}
+
+		val, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read session store: %w", err)
+		}
+
+		var sessionInfo Session
+
+		err = json.Unmarshal(val, &sessionInfo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read session store: %w", err)
+		}
+
+		// only absolute expiry can be verified for sessions of other wallet instances.
+		if now.After(sessionInfo.Expires) {
+			if e := s.delete(sessionInfo.ID); e != nil {
+				logger.Warnf("failed to remove expired wallet session: %s", e)
+			}
+
+			continue
+		}
+
+		result = append(result, &sessionInfo)
+	}
+
+	return result, nil
+}
+
+func getSessionKey(id string) string {
+	return fmt.Sprintf(sessionStoreKeyPrefix, id)
+}
+
+// sortSessions sorts sessions by created time.
+func sortSessions(sessions []*Session) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].Created.Before(sessions[j].Created)
+	})
+}
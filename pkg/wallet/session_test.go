@@ -0,0 +1,305 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestWallet_ConcurrentSessions(t *testing.T) {
+	mockctx := newMockProvider(t)
+	walletInstance, token1 := newOpenedWallet(t, mockctx)
+
+	// wallet can be unlocked only once by default.
+	token, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.True(t, errors.Is(err, ErrAlreadyUnlocked))
+	require.Empty(t, token)
+
+	token2, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase), WithConcurrentSession())
+	require.NoError(t, err)
+	require.NotEqual(t, token1, token2)
+
+	// contents added in one session are accessible from other session.
+	require.NoError(t, walletInstance.Add(token1, Metadata, []byte(sampleContentValid)))
+
+	_, err = walletInstance.Get(token2, Metadata, "did:example:123456789abcdefghi")
+	require.NoError(t, err)
+
+	sessions, err := walletInstance.Sessions()
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.True(t, sessions[0].Created.Before(sessions[1].Created) || sessions[0].Created.Equal(sessions[1].Created))
+
+	for _, s := range sessions {
+		require.NotEmpty(t, s.ID)
+		require.Equal(t, walletInstance.userID, s.User)
+		require.Equal(t, walletInstance.profile.ID, s.ProfileID)
+	}
+
+	// revoke first session.
+	require.NoError(t, walletInstance.RevokeSession(sessions[0].ID))
+	require.True(t, errors.Is(walletInstance.RevokeSession(sessions[0].ID), ErrSessionNotFound))
+
+	sessions, err = walletInstance.Sessions()
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	_, err = walletInstance.Get(token1, Metadata, "did:example:123456789abcdefghi")
+	require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+	_, err = walletInstance.Get(token2, Metadata, "did:example:123456789abcdefghi")
+	require.NoError(t, err)
+
+	// close ends all sessions.
+	require.True(t, walletInstance.Close())
+
+	sessions, err = walletInstance.Sessions()
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
+
+func TestWallet_SessionTimeouts(t *testing.T) {
+	t.Run("idle timeout", func(t *testing.T) {
+		walletInstance, _ := newOpenedWallet(t, newMockProvider(t))
+		require.True(t, walletInstance.Close())
+
+		token, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase),
+			WithUnlockIdleTimeout(200*time.Millisecond))
+		require.NoError(t, err)
+
+		// every access resets idle timer.
+		for i := 0; i < 3; i++ {
+			time.Sleep(100 * time.Millisecond)
+
+			_, err = walletInstance.GetAll(token, Metadata)
+			require.NoError(t, err)
+		}
+
+		time.Sleep(300 * time.Millisecond)
+
+		_, err = walletInstance.GetAll(token, Metadata)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		// expired session allows wallet to be unlocked again.
+		token, err = walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		_, err = walletInstance.GetAll(token, Metadata)
+		require.NoError(t, err)
+	})
+
+	t.Run("absolute timeout", func(t *testing.T) {
+		walletInstance, _ := newOpenedWallet(t, newMockProvider(t))
+		require.True(t, walletInstance.Close())
+
+		token, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase),
+			WithUnlockIdleTimeout(time.Minute), WithUnlockExpiry(200*time.Millisecond))
+		require.NoError(t, err)
+
+		_, err = walletInstance.GetAll(token, Metadata)
+		require.NoError(t, err)
+
+		time.Sleep(300 * time.Millisecond)
+
+		_, err = walletInstance.GetAll(token, Metadata)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		sessions, err := walletInstance.Sessions()
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+	})
+}
+
+func TestWallet_RefreshSession(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newMockProvider(t))
+
+	sessions, err := walletInstance.Sessions()
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	refreshed, err := walletInstance.RefreshSession(token)
+	require.NoError(t, err)
+	require.NotEqual(t, token, refreshed)
+
+	_, err = walletInstance.GetAll(token, Metadata)
+	require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+	_, err = walletInstance.GetAll(refreshed, Metadata)
+	require.NoError(t, err)
+
+	refreshedSessions, err := walletInstance.Sessions()
+	require.NoError(t, err)
+	require.Len(t, refreshedSessions, 1)
+	require.Equal(t, sessions[0].ID, refreshedSessions[0].ID)
+	require.Equal(t, sessions[0].Expires, refreshedSessions[0].Expires)
+
+	// refresh with invalid tokens.
+	newToken, err := walletInstance.RefreshSession(token)
+	require.True(t, errors.Is(err, ErrInvalidAuthToken))
+	require.Empty(t, newToken)
+
+	otherWallet, otherToken := newOpenedWallet(t, newMockProvider(t))
+	require.NotEmpty(t, otherWallet)
+
+	newToken, err = walletInstance.RefreshSession(otherToken)
+	require.True(t, errors.Is(err, ErrInvalidAuthToken))
+	require.Empty(t, newToken)
+}
+
+func TestLockBackgroundSessions(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newMockProvider(t))
+
+	backgroundToken, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase),
+		WithConcurrentSession(), WithUnlockLockOnBackground())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, LockBackgroundSessions())
+	require.Zero(t, LockBackgroundSessions())
+
+	_, err = walletInstance.GetAll(backgroundToken, Metadata)
+	require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+	_, err = walletInstance.GetAll(token, Metadata)
+	require.NoError(t, err)
+}
+
+func TestWallet_SessionPersistence(t *testing.T) {
+	t.Run("sessions of other wallet instances", func(t *testing.T) {
+		mockctx := newMockProvider(t)
+		walletInstance, token := newOpenedWallet(t, mockctx)
+
+		store, err := newSessionStore(mockctx.StorageProvider())
+		require.NoError(t, err)
+
+		remote := &Session{
+			ID:        uuid.New().String(),
+			User:      walletInstance.userID,
+			ProfileID: walletInstance.profile.ID,
+			Created:   time.Now(),
+			Expires:   time.Now().Add(time.Minute),
+		}
+
+		expired := &Session{
+			ID:        uuid.New().String(),
+			User:      walletInstance.userID,
+			ProfileID: walletInstance.profile.ID,
+			Expires:   time.Now().Add(-time.Minute),
+		}
+
+		otherProfile := &Session{
+			ID:        uuid.New().String(),
+			User:      uuid.New().String(),
+			ProfileID: uuid.New().String(),
+			Expires:   time.Now().Add(time.Minute),
+		}
+
+		require.NoError(t, store.put(remote))
+		require.NoError(t, store.put(expired))
+		require.NoError(t, store.put(otherProfile))
+
+		sessions, err := walletInstance.Sessions()
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		require.Equal(t, remote.ID, sessions[1].ID)
+
+		require.True(t, errors.Is(walletInstance.RevokeSession(otherProfile.ID), ErrSessionNotFound))
+		require.NoError(t, walletInstance.RevokeSession(remote.ID))
+
+		sessions, err = walletInstance.Sessions()
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+
+		// local session revoked by other wallet instance.
+		require.NoError(t, store.delete(sessions[0].ID))
+
+		_, err = walletInstance.GetAll(token, Metadata)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+	})
+
+	t.Run("session persistence disabled", func(t *testing.T) {
+		mockctx := newMockProvider(t)
+		walletInstance, _ := newOpenedWallet(t, mockctx)
+		require.True(t, walletInstance.Close())
+
+		token, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase), WithoutSessionPersistence())
+		require.NoError(t, err)
+
+		sessions, err := walletInstance.Sessions()
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+
+		store, err := newSessionStore(mockctx.StorageProvider())
+		require.NoError(t, err)
+
+		persisted, err := store.list(walletInstance.profile.ID)
+		require.NoError(t, err)
+		require.Empty(t, persisted)
+
+		_, err = walletInstance.GetAll(token, Metadata)
+		require.NoError(t, err)
+	})
+
+	t.Run("session store failures", func(t *testing.T) {
+		mockctx := newMockProvider(t)
+		walletInstance, _ := newOpenedWallet(t, mockctx)
+		require.True(t, walletInstance.Close())
+
+		mockStore, ok := mockctx.StorageProviderValue.(*mockstorage.MockStoreProvider)
+		require.True(t, ok)
+
+		mockStore.Store.ErrPut = errors.New(sampleWalletErr)
+
+		token, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to persist wallet session")
+		require.Empty(t, token)
+
+		mockStore.Store.ErrPut = nil
+
+		token, err = walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		// temporary store failures don't lock the wallet.
+		mockStore.Store.ErrGet = errors.New(sampleWalletErr)
+
+		_, err = walletInstance.GetAll(token, Metadata)
+		require.NoError(t, err)
+
+		err = walletInstance.RevokeSession(uuid.New().String())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleWalletErr)
+
+		mockStore.Store.ErrGet = nil
+		mockStore.Store.ErrQuery = errors.New(sampleWalletErr)
+
+		sessions, err := walletInstance.Sessions()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleWalletErr)
+		require.Empty(t, sessions)
+
+		mockStore.Store.ErrQuery = nil
+		mockStore.ErrOpenStoreHandle = errors.New(sampleWalletErr)
+
+		sessions, err = walletInstance.Sessions()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open session store")
+		require.Empty(t, sessions)
+
+		err = walletInstance.RevokeSession(uuid.New().String())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open session store")
+
+		mockStore.ErrOpenStoreHandle = nil
+	})
+}
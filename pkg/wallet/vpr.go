@@ -0,0 +1,548 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// UnmediatedHTTPPresentationService is VPR interact service type for exchanges in which wallet sends presentation
+	// directly to the verifier and continues the exchange with the response received.
+	UnmediatedHTTPPresentationService = "UnmediatedHttpPresentationService2021"
+
+	// MediatedHTTPPresentationService is VPR interact service type for exchanges in which verifier may require
+	// holder to continue the exchange through a web page given by redirect URL.
+	MediatedHTTPPresentationService = "MediatedHttpPresentationService2021"
+
+	vprContentType = "application/json"
+
+	// maxInteractResponseSize is the limit of interact service response size.
+	maxInteractResponseSize = 1 << 20
+)
+
+var (
+	// ErrNoSupportedInteractService when presentation request doesn't contain any supported interact service.
+	ErrNoSupportedInteractService = errors.New("no supported interact service found in presentation request")
+
+	// ErrInteractServiceDomainMismatch when origin of interact service endpoint differs from domain
+	// of the presentation request.
+	ErrInteractServiceDomainMismatch = errors.New("interact service endpoint doesn't match presentation request domain")
+)
+
+// HTTPClient represents an HTTP client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// VerifiablePresentationRequest is verifiable presentation request of a verifier.
+// https://w3c-ccg.github.io/vp-request-spec/#format
+type VerifiablePresentationRequest struct {
+	// Query contains one or more credential queries, queries of the request are combined into a single presentation.
+	Query []*VPRQuery `json:"query"`
+
+	// Challenge to be included in proof of the response presentation.
+	Challenge string `json:"challenge,omitempty"`
+
+	// Domain to be included in proof of the response presentation.
+	Domain string `json:"domain,omitempty"`
+
+	// Interact contains services through which response presentation can be sent to verifier.
+	Interact *VPRInteract `json:"interact,omitempty"`
+}
+
+// VPRQuery is a query of verifiable presentation request.
+type VPRQuery struct {
+	QueryParams
+
+	// AcceptedMethods contains DID methods accepted for authentication by 'DIDAuth' query.
+	AcceptedMethods []*VPRAcceptedMethod `json:"acceptedMethods,omitempty"`
+}
+
+// VPRAcceptedMethod is DID method accepted by 'DIDAuth' query.
+type VPRAcceptedMethod struct {
+	Method string `json:"method"`
+}
+
+// VPRInteract contains interact services of verifiable presentation request.
+type VPRInteract struct {
+	Service []*VPRInteractService `json:"service"`
+}
+
+// VPRInteractService is interact service of verifiable presentation request.
+type VPRInteractService struct {
+	Type            string `json:"type"`
+	ServiceEndpoint string `json:"serviceEndpoint"`
+}
+
+// InteractResponse is response of verifier to the presentation sent through VPR interact service.
+type InteractResponse struct {
+	// Presentation sent to verifier.
+	Presentation *verifiable.Presentation `json:"presentation"`
+
+	// VerifiablePresentation received from verifier, usually containing credentials issued in exchange.
+	VerifiablePresentation json.RawMessage `json:"verifiablePresentation,omitempty"`
+
+	// VerifiablePresentationRequest received from verifier for continuing the exchange.
+	VerifiablePresentationRequest *VerifiablePresentationRequest `json:"verifiablePresentationRequest,omitempty"`
+
+	// RedirectURL where holder has to continue the exchange, usually received from mediated interact services.
+	RedirectURL string `json:"redirectUrl,omitempty"`
+}
+
+// vprQueryMessage is verifiable presentation request query, in which 'credentialQuery' can be an object or an array.
+type vprQueryMessage struct {
+	Type            string               `json:"type"`
+	CredentialQuery json.RawMessage      `json:"credentialQuery,omitempty"`
+	AcceptedMethods []*VPRAcceptedMethod `json:"acceptedMethods,omitempty"`
+}
+
+// vprMessage is verifiable presentation request message.
+type vprMessage struct {
+	Query     json.RawMessage `json:"query"`
+	Challenge string          `json:"challenge,omitempty"`
+	Domain    string          `json:"domain,omitempty"`
+	Interact  *VPRInteract    `json:"interact,omitempty"`
+}
+
+// vprExchangeMessage is message exchanged with VPR interact services.
+type vprExchangeMessage struct {
+	VerifiablePresentationRequest json.RawMessage `json:"verifiablePresentationRequest,omitempty"`
+	VerifiablePresentation        json.RawMessage `json:"verifiablePresentation,omitempty"`
+	RedirectURL                   string          `json:"redirectUrl,omitempty"`
+}
+
+// CreatePresentationResponse creates signed presentation responding to given verifiable presentation request.
+// Credentials matching all credential queries of the request are combined into a single presentation,
+// which is signed with challenge and domain of the request.
+// If request contains 'DIDAuth' query with accepted DID methods and controller is not provided in proof options,
+// then default wallet DID or any other wallet DID of accepted methods will be used for signing.
+//
+//	Args:
+//		- authToken: authorization for performing operation.
+//		- request: verifiable presentation request.
+//		- proofOptions: options for signing the presentation, challenge and domain are taken from request.
+//
+//	Returns signed presentation, or error wrapping 'ErrQueryNoResultFound' if credential queries aren't satisfied.
+func (c *Wallet) CreatePresentationResponse(authToken string, request *VerifiablePresentationRequest,
+	proofOptions *ProofOptions) (*verifiable.Presentation, error) {
+	if err := request.validate(); err != nil {
+		return nil, err
+	}
+
+	presentation, err := c.queryPresentationRequest(authToken, request)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &ProofOptions{}
+	if proofOptions != nil {
+		*opts = *proofOptions
+	}
+
+	if request.Challenge != "" {
+		opts.Challenge = request.Challenge
+	}
+
+	if request.Domain != "" {
+		opts.Domain = request.Domain
+	}
+
+	if err = c.selectAcceptedController(authToken, opts, request.acceptedMethods()); err != nil {
+		return nil, err
+	}
+
+	return c.Prove(authToken, opts, WithPresentationToProve(presentation))
+}
+
+// RespondPresentationRequest creates signed presentation responding to given verifiable presentation request and
+// sends it to interact service of the request.
+// Unmediated HTTP interact service is preferred over mediated HTTP interact service,
+// unless service type is provided in options.
+// Presentation is sent only to interact service endpoint of the request domain origin,
+// unless the endpoint is confirmed in options.
+//
+//	Args:
+//		- authToken: authorization for performing operation.
+//		- request: verifiable presentation request having interact services.
+//		- proofOptions: options for signing the presentation, challenge and domain are taken from request.
+//		- options: options for interacting with verifier, like HTTP client or interact service type.
+//
+//	Returns response of interact service containing presentation sent and optionally presentation,
+//	next presentation request or redirect URL received from verifier.
+func (c *Wallet) RespondPresentationRequest(authToken string, request *VerifiablePresentationRequest,
+	proofOptions *ProofOptions, options ...InteractOptions) (*InteractResponse, error) {
+	opts := &interactOpts{httpClient: http.DefaultClient}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	if err := request.validate(); err != nil {
+		return nil, err
+	}
+
+	service, err := request.interactService(opts.serviceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.serviceConfirmed {
+		if err = request.checkInteractDomain(service); err != nil {
+			return nil, err
+		}
+	}
+
+	presentation, err := c.CreatePresentationResponse(authToken, request, proofOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return sendInteractPresentation(opts.httpClient, service, presentation)
+}
+
+// queryPresentationRequest runs credential queries of presentation request and combines results into a presentation.
+func (c *Wallet) queryPresentationRequest(authToken string,
+	request *VerifiablePresentationRequest) (*verifiable.Presentation, error) {
+	var params []*QueryParams
+
+	for _, query := range request.Query {
+		// DID authentication is satisfied by signing the presentation.
+		if qType, err := GetQueryType(query.Type); err == nil && qType != DIDAuth {
+			params = append(params, &query.QueryParams)
+		}
+	}
+
+	if len(params) == 0 {
+		return verifiable.NewPresentation()
+	}
+
+	results, err := c.Query(authToken, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return combinePresentations(results)
+}
+
+// selectAcceptedController validates controller of proof options against given accepted DID methods,
+// if controller is not provided then a wallet DID of accepted methods is selected preferring default DID.
+func (c *Wallet) selectAcceptedController(authToken string, opts *ProofOptions, methods []string) error {
+	if len(methods) == 0 {
+		return nil
+	}
+
+	accepted := func(didID string) bool {
+		parsed, err := did.Parse(didID)
+
+		return err == nil && contains(methods, parsed.Method)
+	}
+
+	if opts.Controller != "" {
+		if !accepted(opts.Controller) {
+			return fmt.Errorf("controller '%s' is not of accepted DID methods %v", opts.Controller, methods)
+		}
+
+		return nil
+	}
+
+	records, err := c.ListDIDs(authToken)
+	if err != nil {
+		return err
+	}
+
+	// default DID goes first.
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Default && !records[j].Default })
+
+	for _, record := range records {
+		if !record.Deactivated && accepted(record.ID) {
+			opts.Controller = record.ID
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no wallet DID found of accepted DID methods %v", methods)
+}
+
+// ParseVerifiablePresentationRequest parses verifiable presentation request.
+// Request can be the presentation request itself or a message containing request under
+// 'verifiablePresentationRequest' property, like the ones received from VC-API exchanges.
+// Query and credential query of the request can be an object or an array.
+func ParseVerifiablePresentationRequest(data []byte) (*VerifiablePresentationRequest, error) {
+	var exchange vprExchangeMessage
+
+	if err := json.Unmarshal(data, &exchange); err != nil {
+		return nil, fmt.Errorf("failed to parse presentation request: %w", err)
+	}
+
+	if len(exchange.VerifiablePresentationRequest) > 0 {
+		data = exchange.VerifiablePresentationRequest
+	}
+
+	var msg vprMessage
+
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse presentation request: %w", err)
+	}
+
+	queries, err := parseVPRQueries(msg.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse presentation request: %w", err)
+	}
+
+	return &VerifiablePresentationRequest{
+		Query:     queries,
+		Challenge: msg.Challenge,
+		Domain:    msg.Domain,
+		Interact:  msg.Interact,
+	}, nil
+}
+
+func parseVPRQueries(raw json.RawMessage) ([]*VPRQuery, error) {
+	var msgs []*vprQueryMessage
+
+	if err := unmarshalObjectOrArray(raw, &msgs); err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	if len(msgs) == 0 {
+		return nil, errors.New("'query' is required")
+	}
+
+	queries := make([]*VPRQuery, len(msgs))
+
+	for i, msg := range msgs {
+		if _, err := GetQueryType(msg.Type); err != nil {
+			return nil, err
+		}
+
+		query := &VPRQuery{QueryParams: QueryParams{Type: msg.Type}, AcceptedMethods: msg.AcceptedMethods}
+
+		if len(msg.CredentialQuery) > 0 {
+			if err := unmarshalObjectOrArray(msg.CredentialQuery, &query.Query); err != nil {
+				return nil, fmt.Errorf("invalid credential query: %w", err)
+			}
+		}
+
+		queries[i] = query
+	}
+
+	return queries, nil
+}
+
+// unmarshalObjectOrArray unmarshals given JSON object or array of objects into given slice pointer.
+func unmarshalObjectOrArray(raw json.RawMessage, v interface{}) error {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		trimmed = append(append([]byte{'['}, trimmed...), ']')
+	}
+
+	return json.Unmarshal(trimmed, v)
+}
+
+// validate validates verifiable presentation request.
+func (r *VerifiablePresentationRequest) validate() error {
+	if r == nil || len(r.Query) == 0 {
+		return errors.New("invalid presentation request, 'query' is required")
+	}
+
+	for _, query := range r.Query {
+		if query == nil {
+			return errors.New("invalid presentation request, query can't be empty")
+		}
+
+		if _, err := GetQueryType(query.Type); err != nil {
+			return fmt.Errorf("invalid presentation request: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// acceptedMethods returns DID methods accepted by 'DIDAuth' queries of the request, empty if all methods are accepted.
+func (r *VerifiablePresentationRequest) acceptedMethods() []string {
+	var methods []string
+
+	for _, query := range r.Query {
+		qType, err := GetQueryType(query.Type)
+		if err != nil || qType != DIDAuth {
+			continue
+		}
+
+		for _, accepted := range query.AcceptedMethods {
+			if accepted != nil && accepted.Method != "" {
+				methods = append(methods, accepted.Method)
+			}
+		}
+	}
+
+	return methods
+}
+
+// interactService returns interact service of given type or a supported interact service if type is not given,
+// unmediated service is preferred over mediated service.
+func (r *VerifiablePresentationRequest) interactService(serviceType string) (*VPRInteractService, error) {
+	if r.Interact == nil {
+		return nil, ErrNoSupportedInteractService
+	}
+
+	supported := []string{UnmediatedHTTPPresentationService, MediatedHTTPPresentationService}
+	if serviceType != "" {
+		supported = []string{serviceType}
+	}
+
+	for _, t := range supported {
+		for _, service := range r.Interact.Service {
+			if service != nil && service.Type == t && service.ServiceEndpoint != "" {
+				return service, nil
+			}
+		}
+	}
+
+	return nil, ErrNoSupportedInteractService
+}
+
+// checkInteractDomain checks that interact service endpoint is of request domain origin,
+// domain is either an origin URL or a host with optional port.
+func (r *VerifiablePresentationRequest) checkInteractDomain(service *VPRInteractService) error {
+	if r.Domain == "" {
+		return nil
+	}
+
+	endpoint, err := url.Parse(service.ServiceEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to parse interact service endpoint: %w", err)
+	}
+
+	if domain, e := url.Parse(r.Domain); e == nil && domain.Scheme != "" && domain.Host != "" {
+		if strings.EqualFold(domain.Scheme, endpoint.Scheme) && strings.EqualFold(domain.Host, endpoint.Host) {
+			return nil
+		}
+	} else if strings.EqualFold(r.Domain, endpoint.Host) || strings.EqualFold(r.Domain, endpoint.Hostname()) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: endpoint '%s', domain '%s'", ErrInteractServiceDomainMismatch,
+		service.ServiceEndpoint, r.Domain)
+}
+
+// combinePresentations combines query result presentations into a single presentation to be signed,
+// presentation exchange result is used as base presentation to keep its presentation submission valid.
+func combinePresentations(results []*verifiable.Presentation) (*verifiable.Presentation, error) {
+	var base *verifiable.Presentation
+
+	for _, result := range results {
+		if _, ok := result.CustomFields["presentation_submission"]; !ok {
+			continue
+		}
+
+		if base != nil {
+			return nil, errors.New("only one 'PresentationExchange' query is supported in presentation request")
+		}
+
+		base = result
+	}
+
+	if base == nil {
+		base = results[0]
+	}
+
+	for _, result := range results {
+		if result == base {
+			continue
+		}
+
+		for _, credential := range result.Credentials() {
+			vc, ok := credential.(*verifiable.Credential)
+			if !ok {
+				return nil, fmt.Errorf("unexpected credential type '%T' in query result", credential)
+			}
+
+			base.AddCredentials(vc)
+		}
+	}
+
+	return base, nil
+}
+
+// sendInteractPresentation sends presentation to given interact service and returns response of the service.
+func sendInteractPresentation(client HTTPClient, service *VPRInteractService,
+	presentation *verifiable.Presentation) (*InteractResponse, error) {
+	vpBytes, err := presentation.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal presentation: %w", err)
+	}
+
+	reqBytes, err := json.Marshal(&vprExchangeMessage{VerifiablePresentation: vpBytes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal interact request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, service.ServiceEndpoint, bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create interact request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", vprContentType)
+	req.Header.Set("Accept", vprContentType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send presentation to interact service: %w", err)
+	}
+
+	defer func() {
+		if e := resp.Body.Close(); e != nil {
+			logger.Warnf("failed to close interact response body: %s", e)
+		}
+	}()
+
+	respBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxInteractResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read interact response: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("interact service responded with status %d: %s", resp.StatusCode, respBytes)
+	}
+
+	result := &InteractResponse{Presentation: presentation}
+
+	if len(bytes.TrimSpace(respBytes)) == 0 {
+		return result, nil
+	}
+
+	var msg vprExchangeMessage
+
+	if err = json.Unmarshal(respBytes, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse interact response: %w", err)
+	}
+
+	result.VerifiablePresentation = msg.VerifiablePresentation
+	result.RedirectURL = msg.RedirectURL
+
+	if len(msg.VerifiablePresentationRequest) > 0 {
+		result.VerifiablePresentationRequest, err = ParseVerifiablePresentationRequest(msg.VerifiablePresentationRequest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse interact response: %w", err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,430 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+)
+
+const (
+	sampleVPRCredentialFmt = `{
+      "@context": ["https://www.w3.org/2018/credentials/v1", "https://www.w3.org/2018/credentials/examples/v1"],
+      "id": "%s",
+      "type": ["VerifiableCredential", "%s"],
+      "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+      "issuanceDate": "2010-01-01T19:23:24Z",
+      "credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}
+    }`
+	sampleVPRQueryByExample = `{
+      "type": "QueryByExample",
+      "credentialQuery": {
+        "reason": "Please present your degree.",
+        "example": {
+          "@context": ["https://www.w3.org/2018/credentials/v1"],
+          "type": ["UniversityDegreeCredential"]
+        }
+      }
+    }`
+	sampleVPRFmt = `{
+      "query": [%s],
+      "challenge": "sample-vpr-challenge",
+      "domain": "sample.verifier.example",
+      "interact": {
+        "service": [
+          {"type": "MediatedHttpPresentationService2021", "serviceEndpoint": "%s/mediated"},
+          {"type": "UnmediatedHttpPresentationService2021", "serviceEndpoint": "%s/unmediated"}
+        ]
+      }
+    }`
+	sampleVPRDIDAuthFmt = `{"type": "DIDAuth", "acceptedMethods": [{"method": "%s"}]}`
+)
+
+func TestParseVerifiablePresentationRequest(t *testing.T) {
+	t.Run("parse presentation request", func(t *testing.T) {
+		request, err := ParseVerifiablePresentationRequest([]byte(fmt.Sprintf(sampleVPRFmt,
+			sampleVPRQueryByExample+", "+fmt.Sprintf(sampleVPRDIDAuthFmt, "key"), "https://a", "https://a")))
+		require.NoError(t, err)
+		require.Len(t, request.Query, 2)
+		require.Equal(t, "QueryByExample", request.Query[0].Type)
+		require.Len(t, request.Query[0].Query, 1)
+		require.Equal(t, "DIDAuth", request.Query[1].Type)
+		require.Empty(t, request.Query[1].Query)
+		require.Equal(t, "sample-vpr-challenge", request.Challenge)
+		require.Equal(t, "sample.verifier.example", request.Domain)
+		require.Len(t, request.Interact.Service, 2)
+		require.Equal(t, []string{"key"}, request.acceptedMethods())
+
+		service, err := request.interactService("")
+		require.NoError(t, err)
+		require.Equal(t, "https://a/unmediated", service.ServiceEndpoint)
+
+		service, err = request.interactService(MediatedHTTPPresentationService)
+		require.NoError(t, err)
+		require.Equal(t, "https://a/mediated", service.ServiceEndpoint)
+
+		service, err = request.interactService("unknown")
+		require.True(t, errors.Is(err, ErrNoSupportedInteractService))
+		require.Empty(t, service)
+	})
+
+	t.Run("parse presentation request of exchange with single query", func(t *testing.T) {
+		request, err := ParseVerifiablePresentationRequest([]byte(`{"verifiablePresentationRequest": {
+			"query": {"type": "QueryByFrame", "credentialQuery": [{"frame": {}}, {"frame": {}}]}}}`))
+		require.NoError(t, err)
+		require.Len(t, request.Query, 1)
+		require.Len(t, request.Query[0].Query, 2)
+		require.Empty(t, request.acceptedMethods())
+
+		service, err := request.interactService("")
+		require.True(t, errors.Is(err, ErrNoSupportedInteractService))
+		require.Empty(t, service)
+	})
+
+	t.Run("parse invalid presentation requests", func(t *testing.T) {
+		for _, raw := range []string{
+			`--`,
+			`{"verifiablePresentationRequest": "invalid"}`,
+			`{"query": "invalid"}`,
+			`{"query": []}`,
+			`{}`,
+			`{"query": {"type": "unknown"}}`,
+			`{"query": {"type": "QueryByExample", "credentialQuery": "invalid"}}`,
+		} {
+			request, err := ParseVerifiablePresentationRequest([]byte(raw))
+			require.Error(t, err, raw)
+			require.Contains(t, err.Error(), "failed to parse presentation request")
+			require.Empty(t, request)
+		}
+	})
+}
+
+func TestWallet_CreatePresentationResponse(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newDIDMockProvider(t, nil))
+
+	keyDID, err := walletInstance.CreateDID(token, key.DIDMethod, WithDefaultDID())
+	require.NoError(t, err)
+
+	jwkDID, err := walletInstance.CreateDID(token, jwk.DIDMethod)
+	require.NoError(t, err)
+
+	for i, vcType := range []string{"UniversityDegreeCredential", "RelationshipCredential"} {
+		require.NoError(t, walletInstance.Add(token, Credential,
+			[]byte(fmt.Sprintf(sampleVPRCredentialFmt, fmt.Sprintf("http://example.edu/credentials/%d", i), vcType))))
+	}
+
+	parse := func(t *testing.T, queries ...string) *VerifiablePresentationRequest {
+		t.Helper()
+
+		request, err := ParseVerifiablePresentationRequest([]byte(fmt.Sprintf(sampleVPRFmt,
+			strings.Join(queries, ","), "https://a", "https://a")))
+		require.NoError(t, err)
+
+		return request
+	}
+
+	t.Run("respond to query by example and DID authentication", func(t *testing.T) {
+		request := parse(t, sampleVPRQueryByExample, fmt.Sprintf(sampleVPRDIDAuthFmt, jwk.DIDMethod))
+
+		vp, err := walletInstance.CreatePresentationResponse(token, request, nil)
+		require.NoError(t, err)
+		require.Equal(t, jwkDID.DIDDocument.ID, vp.Holder)
+		require.Len(t, vp.Credentials(), 1)
+		require.Len(t, vp.Proofs, 1)
+		require.Equal(t, "sample-vpr-challenge", vp.Proofs[0]["challenge"])
+		require.Equal(t, "sample.verifier.example", vp.Proofs[0]["domain"])
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		ok, err := walletInstance.Verify(token, WithRawPresentationToVerify(vpBytes))
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("respond to DID authentication with default DID", func(t *testing.T) {
+		request := parse(t, `{"type": "DIDAuth"}`)
+
+		vp, err := walletInstance.CreatePresentationResponse(token, request, &ProofOptions{
+			Challenge: "ignored",
+		})
+		require.NoError(t, err)
+		require.Equal(t, keyDID.DIDDocument.ID, vp.Holder)
+		require.Empty(t, vp.Credentials())
+		require.Equal(t, "sample-vpr-challenge", vp.Proofs[0]["challenge"])
+	})
+
+	t.Run("respond to multiple queries", func(t *testing.T) {
+		request := parse(t, sampleVPRQueryByExample, strings.ReplaceAll(sampleVPRQueryByExample,
+			"UniversityDegreeCredential", "RelationshipCredential"), fmt.Sprintf(sampleVPRDIDAuthFmt, key.DIDMethod))
+
+		vp, err := walletInstance.CreatePresentationResponse(token, request,
+			&ProofOptions{Controller: keyDID.DIDDocument.ID})
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 2)
+	})
+
+	t.Run("create presentation response failures", func(t *testing.T) {
+		vp, err := walletInstance.CreatePresentationResponse(token, nil, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "'query' is required")
+		require.Empty(t, vp)
+
+		vp, err = walletInstance.CreatePresentationResponse(token, &VerifiablePresentationRequest{
+			Query: []*VPRQuery{{QueryParams: QueryParams{Type: "unknown"}}},
+		}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported query type")
+		require.Empty(t, vp)
+
+		vp, err = walletInstance.CreatePresentationResponse(token, parse(t, strings.ReplaceAll(sampleVPRQueryByExample,
+			"UniversityDegreeCredential", "PermanentResidentCard")), nil)
+		require.True(t, errors.Is(err, ErrQueryNoResultFound))
+		require.Empty(t, vp)
+
+		vp, err = walletInstance.CreatePresentationResponse(token, parse(t, fmt.Sprintf(sampleVPRDIDAuthFmt, "web")), nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no wallet DID found of accepted DID methods")
+		require.Empty(t, vp)
+
+		vp, err = walletInstance.CreatePresentationResponse(token, parse(t, fmt.Sprintf(sampleVPRDIDAuthFmt, "key")),
+			&ProofOptions{Controller: jwkDID.DIDDocument.ID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not of accepted DID methods")
+		require.Empty(t, vp)
+
+		vp, err = walletInstance.CreatePresentationResponse(sampleFakeTkn, parse(t, sampleVPRQueryByExample), nil)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+		require.Empty(t, vp)
+	})
+}
+
+func TestWallet_RespondPresentationRequest(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newDIDMockProvider(t, nil))
+
+	_, err := walletInstance.CreateDID(token, key.DIDMethod, WithDefaultDID())
+	require.NoError(t, err)
+
+	require.NoError(t, walletInstance.Add(token, Credential,
+		[]byte(fmt.Sprintf(sampleVPRCredentialFmt, "http://example.edu/credentials/1", "UniversityDegreeCredential"))))
+
+	var received []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.URL.Path)
+
+		var msg vprExchangeMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil || len(msg.VerifiablePresentation) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		switch r.URL.Path {
+		case "/unmediated":
+			_, _ = io.WriteString(w, `{"verifiablePresentation": {"type": "VerifiablePresentation"},
+				"verifiablePresentationRequest": {"query": {"type": "DIDAuth"}, "challenge": "next"}}`)
+		case "/mediated":
+			_, _ = io.WriteString(w, `{"redirectUrl": "https://verifier.example/continue"}`)
+		case "/empty":
+		case "/invalid":
+			_, _ = io.WriteString(w, `--`)
+		case "/invalid-request":
+			_, _ = io.WriteString(w, `{"verifiablePresentationRequest": {"query": []}}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = io.WriteString(w, sampleWalletErr)
+		}
+	}))
+	defer server.Close()
+
+	// presentation is bound to domain of the verifier hosting interact services.
+	serverDomain := strings.TrimPrefix(server.URL, "http://")
+
+	request, err := ParseVerifiablePresentationRequest([]byte(fmt.Sprintf(sampleVPRFmt, sampleVPRQueryByExample,
+		server.URL, server.URL)))
+	require.NoError(t, err)
+
+	request.Domain = serverDomain
+
+	t.Run("respond through unmediated interact service", func(t *testing.T) {
+		response, err := walletInstance.RespondPresentationRequest(token, request, nil,
+			WithInteractHTTPClient(server.Client()))
+		require.NoError(t, err)
+		require.Equal(t, []string{"/unmediated"}, received)
+		require.NotEmpty(t, response.Presentation)
+		require.Len(t, response.Presentation.Proofs, 1)
+		require.JSONEq(t, `{"type": "VerifiablePresentation"}`, string(response.VerifiablePresentation))
+		require.Equal(t, "next", response.VerifiablePresentationRequest.Challenge)
+		require.Empty(t, response.RedirectURL)
+
+		// continue exchange with next request.
+		_, err = walletInstance.RespondPresentationRequest(token, &VerifiablePresentationRequest{
+			Query:    response.VerifiablePresentationRequest.Query,
+			Interact: request.Interact,
+		}, nil, WithInteractHTTPClient(server.Client()))
+		require.NoError(t, err)
+	})
+
+	t.Run("respond through mediated interact service", func(t *testing.T) {
+		response, err := walletInstance.RespondPresentationRequest(token, request, nil,
+			WithInteractHTTPClient(server.Client()), WithInteractServiceType(MediatedHTTPPresentationService))
+		require.NoError(t, err)
+		require.Equal(t, "https://verifier.example/continue", response.RedirectURL)
+		require.Empty(t, response.VerifiablePresentationRequest)
+	})
+
+	t.Run("respond through interact service with empty response", func(t *testing.T) {
+		request, err := ParseVerifiablePresentationRequest([]byte(fmt.Sprintf(sampleVPRFmt, sampleVPRQueryByExample,
+			server.URL, server.URL+"/empty#")))
+		require.NoError(t, err)
+
+		request.Domain = server.URL
+
+		response, err := walletInstance.RespondPresentationRequest(token, request, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, response.Presentation)
+		require.Empty(t, response.VerifiablePresentation)
+	})
+
+	t.Run("respond presentation request failures", func(t *testing.T) {
+		for path, errMsg := range map[string]string{
+			"/invalid":         "failed to parse interact response",
+			"/invalid-request": "failed to parse interact response",
+			"/error":           "interact service responded with status 500: " + sampleWalletErr,
+		} {
+			request, err := ParseVerifiablePresentationRequest([]byte(fmt.Sprintf(sampleVPRFmt, sampleVPRQueryByExample,
+				server.URL, server.URL+path+"#")))
+			require.NoError(t, err)
+
+			request.Domain = serverDomain
+
+			response, err := walletInstance.RespondPresentationRequest(token, request, nil)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), errMsg)
+			require.Empty(t, response)
+		}
+
+		response, err := walletInstance.RespondPresentationRequest(token, &VerifiablePresentationRequest{
+			Query: request.Query,
+		}, nil)
+		require.True(t, errors.Is(err, ErrNoSupportedInteractService))
+		require.Empty(t, response)
+
+		response, err = walletInstance.RespondPresentationRequest(token, nil, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "'query' is required")
+		require.Empty(t, response)
+
+		response, err = walletInstance.RespondPresentationRequest(sampleFakeTkn, request, nil)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+		require.Empty(t, response)
+
+		response, err = walletInstance.RespondPresentationRequest(token, &VerifiablePresentationRequest{
+			Query: request.Query,
+			Interact: &VPRInteract{Service: []*VPRInteractService{{
+				Type: UnmediatedHTTPPresentationService, ServiceEndpoint: "http://[::1]:namedport",
+			}}},
+		}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create interact request")
+		require.Empty(t, response)
+
+		response, err = walletInstance.RespondPresentationRequest(token, &VerifiablePresentationRequest{
+			Query:  request.Query,
+			Domain: request.Domain,
+			Interact: &VPRInteract{Service: []*VPRInteractService{{
+				Type: UnmediatedHTTPPresentationService, ServiceEndpoint: "%",
+			}}},
+		}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse interact service endpoint")
+		require.Empty(t, response)
+
+		response, err = walletInstance.RespondPresentationRequest(token, request, nil,
+			WithInteractHTTPClient(&mockHTTPClient{err: errors.New(sampleWalletErr)}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to send presentation to interact service")
+		require.Empty(t, response)
+	})
+
+	t.Run("respond through interact service of another domain", func(t *testing.T) {
+		received = nil
+
+		for _, domain := range []string{
+			"sample.verifier.example", "http://sample.verifier.example", "https://" + serverDomain,
+		} {
+			request.Domain = domain
+
+			response, err := walletInstance.RespondPresentationRequest(token, request, nil,
+				WithInteractHTTPClient(server.Client()))
+			require.True(t, errors.Is(err, ErrInteractServiceDomainMismatch))
+			require.Empty(t, response)
+		}
+
+		require.Empty(t, received)
+
+		response, err := walletInstance.RespondPresentationRequest(token, request, nil,
+			WithInteractHTTPClient(server.Client()), WithInteractServiceConfirmed())
+		require.NoError(t, err)
+		require.NotEmpty(t, response.Presentation)
+		require.Equal(t, []string{"/unmediated"}, received)
+	})
+}
+
+func TestCombinePresentations(t *testing.T) {
+	vc := &verifiable.Credential{ID: "http://example.edu/credentials/1"}
+
+	first, err := verifiable.NewPresentation(verifiable.WithCredentials(vc))
+	require.NoError(t, err)
+
+	submission, err := verifiable.NewPresentation()
+	require.NoError(t, err)
+
+	submission.CustomFields = verifiable.CustomFields{"presentation_submission": map[string]interface{}{}}
+
+	combined, err := combinePresentations([]*verifiable.Presentation{first, submission})
+	require.NoError(t, err)
+	require.Equal(t, submission, combined)
+	require.Len(t, combined.Credentials(), 1)
+
+	combined, err = combinePresentations([]*verifiable.Presentation{submission, submission})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "only one 'PresentationExchange' query is supported")
+	require.Empty(t, combined)
+
+	raw, err := verifiable.NewPresentation()
+	require.NoError(t, err)
+
+	raw.AddCredentials(vc)
+	raw.Credentials()[0] = json.RawMessage(`{}`)
+
+	combined, err = combinePresentations([]*verifiable.Presentation{first, raw})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected credential type")
+	require.Empty(t, combined)
+}
+
+type mockHTTPClient struct {
+	err error
+}
+
+func (m *mockHTTPClient) Do(*http.Request) (*http.Response, error) {
+	return nil, m.err
+}
@@ -29,13 +29,19 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	didexchangeSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	issuecredentialsvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	issuecredentialmw "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/dataintegrity"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -51,6 +57,10 @@ const (
 	JSONWebSignature2020 = "JsonWebSignature2020"
 	// BbsBlsSignature2020 BBS signature suite.
 	BbsBlsSignature2020 = "BbsBlsSignature2020"
+	// DataIntegrityProof data integrity proof, signature is defined by the cryptosuite.
+	DataIntegrityProof = "DataIntegrityProof"
+	// Ed25519Signature2020 ed25519 signature suite with multibase encoded proof value.
+	Ed25519Signature2020 = "Ed25519Signature2020"
 )
 
 // miscellaneous constants.
@@ -60,6 +70,8 @@ const (
 	msgEventBufferSize = 10
 	ldJSONMimeType     = "application/ld+json"
 
+	credentialApplicationType = "CredentialApplication"
+
 	// protocol states.
 	stateNameAbandoned  = "abandoned"
 	stateNameAbandoning = "abandoning"
@@ -258,6 +270,9 @@ func CreateDataVaultKeyPairs(userID string, ctx provider, options ...UnlockOptio
 		opt(opts)
 	}
 
+	// temporary session for creating key pairs, doesn't need to be persisted.
+	opts.disableSessionPersistence = true
+
 	// unlock key manager
 	token, err := keyManager().createKeyManager(profile, ctx.StorageProvider(), opts)
 	if err != nil {
@@ -372,17 +387,104 @@ func (c *Wallet) Open(options ...UnlockOptions) (string, error) {
 	return token, nil
 }
 
-// Close expires token issued to this VC wallet, removes the key manager instance and closes wallet content store.
+// Close expires all tokens issued to this VC wallet, removes the key manager instances and closes wallet content store.
 // returns false if token is not found or already expired for this wallet user.
 func (c *Wallet) Close() bool {
 	return keyManager().removeKeyManager(c.userID) && c.contents.Close()
 }
 
+// Sessions returns all active sessions of this wallet.
+// Sessions persisted by other wallet instances sharing the same storage are also included.
+//
+//	Returns list of wallet sessions sorted by created time.
+func (c *Wallet) Sessions() ([]*Session, error) {
+	sessions := keyManager().activeSessions(c.userID)
+
+	store, err := newSessionStore(c.storeProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	persisted, err := store.list(c.profile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	local := make(map[string]bool)
+	for _, s := range sessions {
+		local[s.ID] = true
+	}
+
+	for _, s := range persisted {
+		if !local[s.ID] {
+			sessions = append(sessions, s)
+		}
+	}
+
+	sortSessions(sessions)
+
+	return sessions, nil
+}
+
+// RefreshSession replaces given session token with a new token, old token can't be used once refreshed.
+// Idle timer of the session will be reset, but absolute expiry of the session remains unchanged.
+//
+//	Args:
+//		- auth: token of the session to be refreshed.
+//
+//	Returns new token of the session.
+func (c *Wallet) RefreshSession(auth string) (string, error) {
+	s, err := keyManager().getSession(auth)
+	if err != nil || s.User != c.userID {
+		return "", ErrInvalidAuthToken
+	}
+
+	return keyManager().refreshSession(auth)
+}
+
+// RevokeSession revokes wallet session by session ID.
+// Sessions persisted by other wallet instances sharing the same storage can also be revoked,
+// those sessions will be locked on their next use.
+//
+//	Args:
+//		- sessionID: ID of the session to be revoked.
+//
+//	Returns error if session is not found.
+func (c *Wallet) RevokeSession(sessionID string) error {
+	if keyManager().revokeSession(c.userID, sessionID) {
+		return nil
+	}
+
+	store, err := newSessionStore(c.storeProvider)
+	if err != nil {
+		return err
+	}
+
+	s, err := store.get(sessionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return ErrSessionNotFound
+		}
+
+		return fmt.Errorf("failed to get wallet session: %w", err)
+	}
+
+	if s.ProfileID != c.profile.ID {
+		return ErrSessionNotFound
+	}
+
+	return store.delete(sessionID)
+}
+
 // Export produces a serialized exported wallet representation.
-// Only ciphertext wallet contents can be exported.
+// All wallet contents and the key material of keys added to wallet key manager are encrypted as JWE.
+// Keys of remote key manager can't be exported, they remain in remote key server. Export fails with
+// 'ErrKeyNotExportable' if wallet has keys of local key manager which can't be exported, like key agreement keys,
+// unless 'WithExportSkippingUnexportableKeys' option is given.
 //
 //	Args:
-//		- auth: token to be used to lock the wallet before exporting.
+//		- auth: token for accessing wallet contents.
+//		- options: options for encrypting wallet contents, either passphrase or key manager key ID is required.
 //
 //	Returns exported locked wallet.
 //
@@ -392,18 +494,52 @@ func (c *Wallet) Close() bool {
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#DIDResolutionResponse
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
+//	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Wallet) Export(auth string) (json.RawMessage, error) {
-	// TODO to be added #2433
-	return nil, fmt.Errorf("to be implemented")
+func (c *Wallet) Export(auth string, options ...ExportOptions) (json.RawMessage, error) {
+	opts := &exportOpts{}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	if opts.passphrase == "" && opts.keyID == "" {
+		return nil, errors.New("passphrase or key ID is required for encrypting wallet contents")
+	}
+
+	id := uuidURNPrefix + uuid.New().String()
+
+	contents, err := c.exportContents(auth, id, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export wallet contents: %w", err)
+	}
+
+	contentBytes, err := json.Marshal(contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wallet contents: %w", err)
+	}
+
+	encrypted, err := c.encryptContents(auth, contentBytes, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt wallet contents: %w", err)
+	}
+
+	return json.Marshal(&encryptedWallet{
+		Context:                 []string{walletContext},
+		ID:                      id,
+		Type:                    []string{encryptedWalletType},
+		EncryptedWalletContents: encrypted,
+	})
 }
 
 // Import Takes a serialized exported wallet representation as input
 // and imports all contents into wallet.
+// Typically used for restoring exported wallet into a new wallet profile.
 //
 //	Args:
-//		- contents: wallet content to be imported.
-//		- auth: token used while exporting the wallet.
+//		- auth: token for accessing wallet contents.
+//		- contents: exported wallet to be imported.
+//		- options: options for decrypting wallet contents and for handling contents already present in wallet.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -413,9 +549,41 @@ func (c *Wallet) Export(auth string) (json.RawMessage, error) {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Wallet) Import(auth string, contents json.RawMessage) error {
-	// TODO to be added #2433
-	return fmt.Errorf("to be implemented")
+func (c *Wallet) Import(auth string, contents json.RawMessage, options ...ImportOptions) error {
+	opts := &importOpts{conflictPolicy: ImportSkipExisting}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	if err := opts.conflictPolicy.isValid(); err != nil {
+		return err
+	}
+
+	var locked encryptedWallet
+
+	err := json.Unmarshal(contents, &locked)
+	if err != nil {
+		return fmt.Errorf("invalid exported wallet: %w", err)
+	}
+
+	if len(locked.EncryptedWalletContents) == 0 {
+		return errors.New("invalid exported wallet: encrypted wallet contents not found")
+	}
+
+	decrypted, err := c.decryptContents(auth, locked.EncryptedWalletContents, opts)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt wallet contents: %w", err)
+	}
+
+	var unlocked walletContents
+
+	err = json.Unmarshal(decrypted, &unlocked)
+	if err != nil {
+		return fmt.Errorf("failed to read wallet contents: %w", err)
+	}
+
+	return c.importContents(auth, unlocked.Contents, opts.conflictPolicy)
 }
 
 // Add adds given data model to wallet contents store.
@@ -442,7 +610,16 @@ func (c *Wallet) Add(authToken string, contentType ContentType, content json.Raw
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //
 func (c *Wallet) Remove(authToken string, contentType ContentType, contentID string) error {
-	return c.contents.Remove(authToken, contentID, contentType)
+	if err := c.contents.Remove(authToken, contentID, contentType); err != nil {
+		return err
+	}
+
+	// credential as issued is removed along with credential read from it.
+	if contentType == Credential {
+		return c.contents.Remove(authToken, contentID, SecuredCredential)
+	}
+
+	return nil
 }
 
 // Get fetches a wallet content by content ID.
@@ -482,6 +659,40 @@ func (c *Wallet) GetAll(authToken string, contentType ContentType, options ...Ge
 	return c.contents.GetAll(authToken, contentType)
 }
 
+// Search searches wallet contents of given type by field, tag, date range and free text filters.
+// Search is performed on storage tags indexed while adding contents to wallet,
+// so only the contents of requested page are read from wallet store.
+//
+//	Args:
+//		- authToken: authorization for performing operation.
+//		- contentType: type of the wallet contents to be searched.
+//		- options: search filters, sorting and pagination options.
+//
+//	Returns search result containing total number of matching contents and contents of requested page.
+//
+// Note: contents added to wallet before search index was introduced can only be found by search without filters.
+func (c *Wallet) Search(authToken string, contentType ContentType, options ...SearchOptions) (*SearchResult, error) {
+	if err := contentType.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if contentType == Key {
+		return nil, fmt.Errorf("content type '%s' can't be searched", contentType)
+	}
+
+	opts := &searchOpts{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.contents.Search(authToken, contentType, opts)
+}
+
 // Query runs query against wallet credential contents and returns presentation containing credential results.
 //
 // This function may return multiple presentations as query result based on combination of query types used.
@@ -506,6 +717,288 @@ func (c *Wallet) Query(authToken string, params ...*QueryParams) ([]*verifiable.
 	return query.PerformQuery(vcContents)
 }
 
+// MatchPresentationDefinition evaluates wallet credentials against presentation definition without selecting any
+// of them. Result lists for each submission requirement and input descriptor the candidate credentials, the checks
+// they failed and the disclosure they would be presented with, so that holder can choose the credentials to present.
+//
+//	Args:
+//		- auth token for unlocking kms.
+//		- presentation definition.
+//
+// https://identity.foundation/presentation-exchange
+//
+func (c *Wallet) MatchPresentationDefinition(authToken string,
+	definition json.RawMessage) ([]*presexch.MatchSubmissionRequirement, error) {
+	pd, err := presexch.ParsePresentationDefinition(definition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse presentation definition: %w", err)
+	}
+
+	credentials, err := c.allCredentials(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return pd.MatchSubmissionRequirement(credentials, c.jsonldDocumentLoader,
+		presexch.WithCredentialOptions(c.presentationExchangeCredentialOptions(authToken)...))
+}
+
+// PresentSelection creates presentation of the wallet credentials selected by holder for input descriptors of
+// presentation definition, usually among the candidates returned by MatchPresentationDefinition.
+// Resulting presentation can be signed by Prove.
+//
+//	Args:
+//		- auth token for unlocking kms.
+//		- presentation definition.
+//		- selected credential IDs mapped by input descriptor ID.
+//
+func (c *Wallet) PresentSelection(authToken string, definition json.RawMessage,
+	selection map[string][]string) (*verifiable.Presentation, error) {
+	pd, err := presexch.ParsePresentationDefinition(definition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse presentation definition: %w", err)
+	}
+
+	selected, err := c.selectedCredentials(authToken, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	presentation, err := pd.CreateVPFromSelection(selected, c.jsonldDocumentLoader,
+		c.presentationExchangeCredentialOptions(authToken)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to present selected credentials: %w", err)
+	}
+
+	return presentation, nil
+}
+
+// CreateCredentialApplication creates credential application for the credential manifest attached to an
+// issue credential 3.0 offer (usually the offer returned by ProposeCredential). Wallet credentials are submitted
+// for the presentation definition of the manifest and resulting application can be sent to issuer by
+// RequestCredential.
+//
+//	Args:
+//		- auth token for unlocking kms.
+//		- offer credential message holding credential manifest.
+//		- options for selecting credentials to be submitted and signing credential application.
+//
+// https://identity.foundation/credential-manifest/#credential-application
+//
+func (c *Wallet) CreateCredentialApplication(authToken string, offer *service.DIDCommMsgMap,
+	options ...CredentialApplicationOptions) (*verifiable.Presentation, error) {
+	opts := &credentialApplicationOpts{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	if offer == nil {
+		return nil, errors.New("offer credential message is mandatory")
+	}
+
+	manifestOffer, err := issuecredentialmw.ParseCredentialManifestOffer(offer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credential manifest offer: %w", err)
+	}
+
+	presentation, err := c.presentCredentialManifest(authToken, manifestOffer.CredentialManifest, opts.selection)
+	if err != nil {
+		return nil, err
+	}
+
+	application, err := cm.PresentCredentialApplication(manifestOffer.CredentialManifest,
+		cm.WithExistingPresentationForPresentCredentialApplication(presentation))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential application: %w", err)
+	}
+
+	if opts.proofOptions == nil {
+		return application, nil
+	}
+
+	proofOptions := *opts.proofOptions
+
+	if manifestOffer.Options != nil {
+		if proofOptions.Challenge == "" {
+			proofOptions.Challenge = manifestOffer.Options.Challenge
+		}
+
+		if proofOptions.Domain == "" {
+			proofOptions.Domain = manifestOffer.Options.Domain
+		}
+	}
+
+	return c.Prove(authToken, &proofOptions, WithPresentationToProve(application))
+}
+
+// ResolveCredentialManifest resolves output descriptors of given credential manifest for display, from a
+// credential fulfillment received from issuer or from a credential.
+//
+//	Args:
+//		- auth token for unlocking kms.
+//		- credential manifest.
+//		- fulfillment or credential to be resolved.
+//
+// Returns: resolved data display descriptors of each output descriptor for each credential.
+//
+// https://identity.foundation/credential-manifest/wallet-rendering
+//
+func (c *Wallet) ResolveCredentialManifest(authToken string, manifest json.RawMessage,
+	resolve ResolveManifestOption) ([][]cm.ResolvedDataDisplayDescriptor, error) {
+	opts := &resolveManifestOpts{}
+
+	if resolve != nil {
+		resolve(opts)
+	}
+
+	credentialManifest := &cm.CredentialManifest{}
+
+	err := json.Unmarshal(manifest, credentialManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credential manifest: %w", err)
+	}
+
+	credentialOpts := []verifiable.CredentialOpt{
+		verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader),
+	}
+
+	if opts.fulfillment == nil && len(opts.rawFulfillment) > emptyRawLength {
+		opts.fulfillment, err = verifiable.ParsePresentation(opts.rawFulfillment,
+			verifiable.WithPresDisabledProofCheck(), verifiable.WithPresJSONLDDocumentLoader(c.jsonldDocumentLoader))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse credential fulfillment: %w", err)
+		}
+	}
+
+	if opts.fulfillment != nil {
+		return credentialManifest.ResolveFulfillmentVC(opts.fulfillment, credentialOpts...)
+	}
+
+	if opts.credential == nil {
+		raw := opts.rawCredential
+
+		if opts.credentialID != "" {
+			raw, err = c.contents.Get(authToken, opts.credentialID, Credential)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get credential: %w", err)
+			}
+		}
+
+		if len(raw) <= emptyRawLength {
+			return nil, errors.New("no fulfillment or credential to resolve")
+		}
+
+		opts.credential, err = verifiable.ParseCredential(raw, credentialOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse credential: %w", err)
+		}
+	}
+
+	resolved, err := credentialManifest.ResolveOutputDescriptors(opts.credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credential: %w", err)
+	}
+
+	return [][]cm.ResolvedDataDisplayDescriptor{resolved}, nil
+}
+
+// presentCredentialManifest creates presentation of wallet credentials for presentation definition of the
+// credential manifest.
+func (c *Wallet) presentCredentialManifest(authToken string, manifest *cm.CredentialManifest,
+	selection map[string][]string) (*verifiable.Presentation, error) {
+	pd := manifest.PresentationDefinition
+	if pd == nil {
+		return verifiable.NewPresentation()
+	}
+
+	if selection != nil {
+		selected, err := c.selectedCredentials(authToken, selection)
+		if err != nil {
+			return nil, err
+		}
+
+		presentation, err := pd.CreateVPFromSelection(selected, c.jsonldDocumentLoader,
+			c.presentationExchangeCredentialOptions(authToken)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to present selected credentials: %w", err)
+		}
+
+		return presentation, nil
+	}
+
+	credentials, err := c.allCredentials(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	presentation, err := pd.CreateVP(credentials, c.jsonldDocumentLoader,
+		c.presentationExchangeCredentialOptions(authToken)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to present credentials for credential manifest: %w", err)
+	}
+
+	return presentation, nil
+}
+
+// allCredentials returns all the credentials stored in wallet.
+func (c *Wallet) allCredentials(authToken string) ([]*verifiable.Credential, error) {
+	vcContents, err := c.contents.GetAll(authToken, Credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query credentials: %w", err)
+	}
+
+	credentials := make([]*verifiable.Credential, 0, len(vcContents))
+
+	for _, raw := range vcContents {
+		credential, e := verifiable.ParseCredential(raw, verifiable.WithDisabledProofCheck(),
+			verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+		if e != nil {
+			return nil, fmt.Errorf("failed to parse credential: %w", e)
+		}
+
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, nil
+}
+
+// selectedCredentials returns the wallet credentials selected by holder mapped by input descriptor ID.
+func (c *Wallet) selectedCredentials(authToken string,
+	selection map[string][]string) (map[string][]*verifiable.Credential, error) {
+	selected := make(map[string][]*verifiable.Credential, len(selection))
+
+	for descriptorID, credentialIDs := range selection {
+		for _, id := range credentialIDs {
+			raw, e := c.contents.Get(authToken, id, Credential)
+			if e != nil {
+				return nil, fmt.Errorf("failed to get selected credential: %w", e)
+			}
+
+			credential, e := verifiable.ParseCredential(raw, verifiable.WithDisabledProofCheck(),
+				verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+			if e != nil {
+				return nil, fmt.Errorf("failed to parse selected credential: %w", e)
+			}
+
+			selected[descriptorID] = append(selected[descriptorID], credential)
+		}
+	}
+
+	return selected, nil
+}
+
+// presentationExchangeCredentialOptions returns options for parsing disclosed credentials, public keys of BBS+
+// signatures are resolved for selective disclosure.
+func (c *Wallet) presentationExchangeCredentialOptions(authToken string) []verifiable.CredentialOpt {
+	return []verifiable.CredentialOpt{
+		verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader),
+		verifiable.WithPublicKeyFetcher(
+			verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher()),
+	}
+}
+
 // Issue adds proof to a Verifiable Credential.
 //
 //	Args:
@@ -625,12 +1118,7 @@ func (c *Wallet) Derive(authToken string, credential CredentialToDerive, options
 //		- keyType: type of the key to be created.
 //
 func (c *Wallet) CreateKeyPair(authToken string, keyType kms.KeyType) (*KeyPair, error) {
-	kmgr, err := keyManager().getKeyManger(authToken)
-	if err != nil {
-		return nil, ErrInvalidAuthToken
-	}
-
-	kid, pubBytes, err := kmgr.CreateAndExportPubKeyBytes(keyType)
+	kid, pubBytes, err := c.createKey(authToken, keyType)
 	if err != nil {
 		return nil, err
 	}
@@ -887,6 +1375,9 @@ func (c *Wallet) ProposeCredential(authToken string, invitation *GenericInvitati
 // Currently Supporting : 0453-issueCredentialV2
 // https://github.com/hyperledger/aries-rfcs/blob/main/features/0453-issue-credential-v2/README.md
 //
+// Credential application created by CreateCredentialApplication is sent as credential manifest application
+// attachment, for replying to issue credential 3.0 offers holding credential manifest.
+//
 // Args:
 // 		- authToken: authorization for performing operation.
 // 		- thID: thread ID (action ID) of offer credential message previously received.
@@ -912,18 +1403,26 @@ func (c *Wallet) RequestCredential(authToken, thID string, options ...ConcludeIn
 
 	attachmentID := uuid.New().String()
 
+	attachment := decorator.GenericAttachment{
+		ID: attachmentID,
+		Data: decorator.AttachmentData{
+			JSON: presentation,
+		},
+	}
+
+	// credential application is sent in the attachment format of credential manifest issuance.
+	if opts.presentation != nil && isCredentialApplication(opts.presentation) {
+		attachment = issuecredentialmw.NewCredentialApplicationAttachment(presentation)
+		attachment.ID = attachmentID
+	}
+
 	err := c.issueCredentialClient.AcceptOffer(thID, &issuecredential.RequestCredential{
 		Type: issuecredentialsvc.RequestCredentialMsgTypeV2,
 		Formats: []issuecredentialsvc.Format{{
 			AttachID: attachmentID,
 			Format:   ldJSONMimeType,
 		}},
-		Attachments: []decorator.GenericAttachment{{
-			ID: attachmentID,
-			Data: decorator.AttachmentData{
-				JSON: presentation,
-			},
-		}},
+		Attachments: []decorator.GenericAttachment{attachment},
 	})
 	if err != nil {
 		return nil, err
@@ -1092,7 +1591,12 @@ func (c *Wallet) verifyPresentation(authToken string, presentation json.RawMessa
 
 func (c *Wallet) addLinkedDataProof(authToken string, p provable, opts *ProofOptions,
 	relationship did.VerificationRelationship) error {
-	s, err := newKMSSigner(authToken, c.walletCrypto, opts)
+	keyID, err := c.signingKeyID(authToken, opts.VerificationMethod)
+	if err != nil {
+		return err
+	}
+
+	s, err := newKMSSigner(authToken, c.walletCrypto, keyID, opts)
 	if err != nil {
 		return err
 	}
@@ -1108,6 +1612,13 @@ func (c *Wallet) addLinkedDataProof(authToken string, p provable, opts *ProofOpt
 		addContext(p, bbsContext)
 
 		signatureSuite = bbsblssignature2020.New(suite.WithSigner(s))
+	case Ed25519Signature2020:
+		signatureSuite = ed25519signature2020.New(suite.WithSigner(s))
+	case DataIntegrityProof:
+		signatureSuite, err = c.getDataIntegritySuite(authToken, s, opts)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unsupported signature type '%s'", opts.ProofType)
 	}
@@ -1131,29 +1642,100 @@ func (c *Wallet) addLinkedDataProof(authToken string, p provable, opts *ProofOpt
 	return nil
 }
 
+// getDataIntegritySuite returns the signature suite of the cryptosuite from proof options.
+// For ecdsa-rdfc-2019, the curve of the verification method key defines the suite.
+func (c *Wallet) getDataIntegritySuite(authToken string, s *kmsSigner, opts *ProofOptions) (signer.SignatureSuite, error) {
+	switch opts.Cryptosuite {
+	case dataintegrity.EdDSARDFC2022:
+		return dataintegrity.NewEdDSARDFC2022(suite.WithSigner(s)), nil
+	case dataintegrity.ECDSARDFC2019:
+		vm, err := newContentBasedVDR(authToken, c.vdr, c.contents).Dereference(opts.VerificationMethod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve verification method key: %w", err)
+		}
+
+		if vm.VerificationMethod == nil {
+			return nil, fmt.Errorf("'%s' is not a verification method", opts.VerificationMethod)
+		}
+
+		pubKey := &verifier.PublicKey{
+			Type:  vm.VerificationMethod.Type,
+			Value: vm.VerificationMethod.Value,
+			JWK:   vm.VerificationMethod.JSONWebKey(),
+		}
+
+		for _, ecdsaSuite := range []*dataintegrity.Suite{
+			dataintegrity.NewECDSARDFC2019P256(suite.WithSigner(s)),
+			dataintegrity.NewECDSARDFC2019P384(suite.WithSigner(s)),
+		} {
+			if ecdsaSuite.AcceptPublicKey(pubKey) {
+				return ecdsaSuite, nil
+			}
+		}
+
+		return nil, fmt.Errorf("verification method key is not supported by cryptosuite '%s'", opts.Cryptosuite)
+	default:
+		return nil, fmt.Errorf("unsupported cryptosuite '%s'", opts.Cryptosuite)
+	}
+}
+
 func (c *Wallet) validateProofOption(authToken string, opts *ProofOptions, method did.VerificationRelationship) error {
-	if opts == nil || opts.Controller == "" {
+	if opts == nil {
 		return errors.New("invalid proof option, 'controller' is required")
 	}
 
-	resolvedDoc, err := newContentBasedVDR(authToken, c.vdr, c.contents).Resolve(opts.Controller)
+	if opts.Controller == "" {
+		defaultDID, err := c.defaultDID(authToken)
+		if err != nil {
+			return err
+		}
+
+		if defaultDID == nil {
+			return errors.New("invalid proof option, 'controller' is required")
+		}
+
+		opts.Controller = defaultDID.ID
+	}
+
+	record, err := c.findDIDRecord(authToken, opts.Controller)
 	if err != nil {
 		return err
 	}
 
-	err = c.validateVerificationMethod(resolvedDoc.DIDDocument, opts, method)
+	if record != nil && record.Deactivated {
+		return fmt.Errorf("invalid proof option, controller '%s' is deactivated", opts.Controller)
+	}
+
+	resolvedDoc, err := newContentBasedVDR(authToken, c.vdr, c.contents).Resolve(opts.Controller)
 	if err != nil {
 		return err
 	}
 
-	if opts.ProofRepresentation == nil {
-		opts.ProofRepresentation = &defaultSignatureRepresentation
+	err = c.validateVerificationMethod(resolvedDoc.DIDDocument, opts, method)
+	if err != nil {
+		return err
 	}
 
 	if opts.ProofType == "" {
 		opts.ProofType = Ed25519Signature2018
 	}
 
+	if opts.ProofType == DataIntegrityProof && opts.Cryptosuite == "" {
+		opts.Cryptosuite = dataintegrity.EdDSARDFC2022
+	}
+
+	if opts.ProofType == DataIntegrityProof || opts.ProofType == Ed25519Signature2020 {
+		// these proofs carry signature in 'proofValue' only.
+		if opts.ProofRepresentation == nil {
+			proofValue := verifiable.SignatureProofValue
+			opts.ProofRepresentation = &proofValue
+		}
+	}
+
+	if opts.ProofRepresentation == nil {
+		opts.ProofRepresentation = &defaultSignatureRepresentation
+	}
+
 	return nil
 }
 
@@ -1162,12 +1744,12 @@ func (c *Wallet) validateVerificationMethod(didDoc *did.Doc, opts *ProofOptions,
 	vms := didDoc.VerificationMethods(relationship)[relationship]
 
 	for _, vm := range vms {
-		if opts.VerificationMethod == "" {
-			opts.VerificationMethod = vm.VerificationMethod.ID
-			return nil
-		}
+		// relative verification method IDs are resolved against DID for signing.
+		vmID := absoluteVerificationMethodID(didDoc.ID, vm.VerificationMethod.ID)
 
-		if opts.VerificationMethod == vm.VerificationMethod.ID {
+		if opts.VerificationMethod == "" || opts.VerificationMethod == vm.VerificationMethod.ID ||
+			opts.VerificationMethod == vmID {
+			opts.VerificationMethod = vmID
 			return nil
 		}
 	}
@@ -1348,6 +1930,17 @@ func waitCredInteractionCompletion(ctx context.Context, didStateMsgs chan servic
 	}
 }
 
+// isCredentialApplication checks if the presentation is a credential application.
+func isCredentialApplication(presentation *verifiable.Presentation) bool {
+	for _, t := range presentation.Type {
+		if t == credentialApplicationType {
+			return true
+		}
+	}
+
+	return false
+}
+
 // addContext adds context if not found in given data model.
 func addContext(v interface{}, ldcontext string) {
 	if vc, ok := v.(*verifiable.Credential); ok {
@@ -7,7 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package wallet
 
 import (
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
 	_ "embed"
 	"encoding/base64"
@@ -33,10 +36,13 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	issuecredentialsvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	issuecredentialmw "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
 	outofbandSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 	oobv2 "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
 	presentproofSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -58,20 +64,20 @@ import (
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/pbkdf2"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 // nolint: lll
 const (
-	sampleUserID       = "sample-user01"
-	sampleFakeTkn      = "fake-auth-tkn"
-	toBeImplementedErr = "to be implemented"
-	sampleWalletErr    = "sample wallet err"
-	sampleCreatedDate  = "2020-12-25"
-	sampleChallenge    = "sample-challenge"
-	sampleDomain       = "sample-domain"
-	sampleUDCVC        = `{
+	sampleUserID      = "sample-user01"
+	sampleFakeTkn     = "fake-auth-tkn"
+	sampleWalletErr   = "sample wallet err"
+	sampleCreatedDate = "2020-12-25"
+	sampleChallenge   = "sample-challenge"
+	sampleDomain      = "sample-domain"
+	sampleUDCVC       = `{
       "@context": [
         "https://www.w3.org/2018/credentials/v1",
         "https://www.w3.org/2018/credentials/examples/v1",
@@ -846,35 +852,6 @@ func TestWallet_OpenClose(t *testing.T) {
 	})
 }
 
-func TestWallet_Export(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
-	require.NoError(t, err)
-
-	walletInstance, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, walletInstance)
-	require.NoError(t, err)
-
-	result, err := walletInstance.Export("")
-	require.Empty(t, result)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
-}
-
-func TestWallet_Import(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
-	require.NoError(t, err)
-
-	walletInstance, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, walletInstance)
-	require.NoError(t, err)
-
-	err = walletInstance.Import("", nil)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
-}
-
 func TestWallet_Add(t *testing.T) {
 	mockctx := newMockProvider(t)
 	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
@@ -1244,6 +1221,329 @@ func TestWallet_Query(t *testing.T) {
 	})
 }
 
+func TestWallet_MatchPresentationDefinition(t *testing.T) {
+	walletInstance, token := newOpenedWallet(t, newMockProvider(t))
+
+	const (
+		degreeVC = `{
+			"@context": ["https://www.w3.org/2018/credentials/v1"],
+			"id": "urn:uuid:degree",
+			"type": ["VerifiableCredential"],
+			"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+			"issuanceDate": "2010-01-01T19:23:24Z",
+			"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21", "degree": "MIT", "name": "Jayden"}
+		}`
+		definition = `{
+			"id": "degree-definition",
+			"input_descriptors": [{
+				"id": "degree",
+				"constraints": {
+					"limit_disclosure": "required",
+					"fields": [{"path": ["$.credentialSubject.degree"]}]
+				}
+			}]
+		}`
+	)
+
+	require.NoError(t, walletInstance.Add(token, Credential, []byte(degreeVC)))
+	require.NoError(t, walletInstance.Add(token, Credential,
+		[]byte(fmt.Sprintf(sampleCredentialFmt, "urn:uuid:no-degree"))))
+
+	t.Run("match and present selection", func(t *testing.T) {
+		result, err := walletInstance.MatchPresentationDefinition(token, []byte(definition))
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.True(t, result[0].Satisfied)
+
+		descriptor := result[0].Descriptors[0]
+		require.Len(t, descriptor.Candidates, 2)
+		require.Len(t, descriptor.MatchedVCs, 1)
+		require.Equal(t, "urn:uuid:degree", descriptor.MatchedVCs[0].ID)
+
+		for _, candidate := range descriptor.Candidates {
+			if candidate.Credential.ID == "urn:uuid:degree" {
+				require.Empty(t, candidate.Failures)
+				require.NotContains(t, candidate.Disclosure.Subject.([]verifiable.Subject)[0].CustomFields, "name")
+
+				continue
+			}
+
+			require.Len(t, candidate.Failures, 1)
+			require.Equal(t, presexch.CheckFields, candidate.Failures[0].Check)
+		}
+
+		vp, err := walletInstance.PresentSelection(token, []byte(definition),
+			map[string][]string{"degree": {"urn:uuid:degree"}})
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		_, err = walletInstance.PresentSelection(token, []byte(definition),
+			map[string][]string{"degree": {"urn:uuid:no-degree"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "selected credentials do not satisfy input descriptor 'degree'")
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		_, err := walletInstance.MatchPresentationDefinition(token, []byte(`{}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse presentation definition")
+
+		_, err = walletInstance.MatchPresentationDefinition(sampleFakeTkn, []byte(definition))
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		_, err = walletInstance.PresentSelection(token, []byte(`{}`), nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse presentation definition")
+
+		_, err = walletInstance.PresentSelection(token, []byte(definition),
+			map[string][]string{"degree": {"urn:uuid:unknown"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get selected credential")
+	})
+}
+
+func TestWallet_CredentialManifest(t *testing.T) {
+	const (
+		identityVC = `{
+			"@context": ["https://www.w3.org/2018/credentials/v1"],
+			"id": "urn:uuid:identity",
+			"type": ["VerifiableCredential"],
+			"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+			"issuanceDate": "2010-01-01T19:23:24Z",
+			"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21", "name": "Jayden Doe"}
+		}`
+		manifestJSON = `{
+			"id": "driver-license-manifest",
+			"version": "0.1.0",
+			"issuer": {"id": "did:example:issuer", "name": "Example DMV"},
+			"output_descriptors": [{
+				"id": "driver_license_output",
+				"schema": "https://example.org/driver-license.json",
+				"display": {
+					"title": {"text": "Driver License"},
+					"properties": [{
+						"path": ["$.credentialSubject.name"],
+						"schema": {"type": "string"},
+						"label": "Name"
+					}]
+				}
+			}],
+			"presentation_definition": {
+				"id": "identity-definition",
+				"input_descriptors": [{
+					"id": "identity_input",
+					"schema": [{"uri": "https://www.w3.org/2018/credentials#VerifiableCredential"}],
+					"constraints": {"fields": [{"path": ["$.credentialSubject.name"]}]}
+				}]
+			}
+		}`
+	)
+
+	manifest := &cm.CredentialManifest{}
+	require.NoError(t, json.Unmarshal([]byte(manifestJSON), manifest))
+
+	newOffer := func(t *testing.T, manifest *cm.CredentialManifest) *service.DIDCommMsgMap {
+		t.Helper()
+
+		params, err := issuecredentialmw.NewCredentialManifestOffer(manifest,
+			&issuecredentialmw.ManifestOptions{Challenge: sampleChallenge, Domain: sampleDomain})
+		require.NoError(t, err)
+
+		offer := service.NewDIDCommMsgMap(params.AsV3())
+
+		return &offer
+	}
+
+	t.Run("create credential application", func(t *testing.T) {
+		walletInstance, token := newOpenedWallet(t, newMockProvider(t))
+
+		require.NoError(t, walletInstance.Add(token, Credential, []byte(identityVC)))
+		require.NoError(t, walletInstance.Add(token, Credential,
+			[]byte(fmt.Sprintf(sampleCredentialFmt, "urn:uuid:other"))))
+
+		application, err := walletInstance.CreateCredentialApplication(token, newOffer(t, manifest))
+		require.NoError(t, err)
+		require.Contains(t, application.Type, credentialApplicationType)
+		require.Contains(t, application.CustomFields, "credential_application")
+		require.Contains(t, application.CustomFields, "presentation_submission")
+		require.Len(t, application.Credentials(), 1)
+
+		raw, err := application.MarshalJSON()
+		require.NoError(t, err)
+
+		received, err := verifiable.ParsePresentation(raw, verifiable.WithPresDisabledProofCheck(),
+			verifiable.WithPresJSONLDDocumentLoader(walletInstance.jsonldDocumentLoader))
+		require.NoError(t, err)
+
+		_, err = cm.ValidateCredentialApplication(received, manifest, walletInstance.jsonldDocumentLoader,
+			presexch.WithCredentialOptions(verifiable.WithDisabledProofCheck(),
+				verifiable.WithJSONLDDocumentLoader(walletInstance.jsonldDocumentLoader)))
+		require.NoError(t, err)
+
+		application, err = walletInstance.CreateCredentialApplication(token, newOffer(t, manifest),
+			WithApplicationSelection(map[string][]string{"identity_input": {"urn:uuid:identity"}}))
+		require.NoError(t, err)
+		require.Len(t, application.Credentials(), 1)
+
+		noDefinition := *manifest
+		noDefinition.PresentationDefinition = nil
+
+		application, err = walletInstance.CreateCredentialApplication(token, newOffer(t, &noDefinition))
+		require.NoError(t, err)
+		require.Contains(t, application.Type, credentialApplicationType)
+		require.Empty(t, application.Credentials())
+	})
+
+	t.Run("create signed credential application", func(t *testing.T) {
+		mockctx := newMockProvider(t)
+		mockctx.VDRegistryValue = &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return key.New().Read(didID)
+			},
+		}
+		mockctx.CryptoValue = &cryptomock.Crypto{}
+
+		walletInstance, token := newOpenedWallet(t, mockctx)
+
+		kmgr, err := keyManager().getKeyManger(token)
+		require.NoError(t, err)
+
+		_, _, err = kmgr.ImportPrivateKey(ed25519.PrivateKey(base58.Decode(pkBase58)), kms.ED25519,
+			kms.WithKeyID(kid))
+		require.NoError(t, err)
+
+		require.NoError(t, walletInstance.Add(token, Credential, []byte(identityVC)))
+
+		application, err := walletInstance.CreateCredentialApplication(token, newOffer(t, manifest),
+			WithApplicationProof(&ProofOptions{Controller: didKey}))
+		require.NoError(t, err)
+		require.Equal(t, didKey, application.Holder)
+		require.Len(t, application.Proofs, 1)
+		require.Equal(t, sampleChallenge, application.Proofs[0]["challenge"])
+		require.Equal(t, sampleDomain, application.Proofs[0]["domain"])
+	})
+
+	t.Run("request credential with credential application", func(t *testing.T) {
+		mockctx := newMockProvider(t)
+
+		var request *issuecredentialsvc.RequestCredentialV3
+
+		mockctx.ServiceMap[issuecredentialsvc.Name] = &mockissuecredential.MockIssueCredentialSvc{
+			ActionContinueFunc: func(piID string, opts ...issuecredentialsvc.Opt) error {
+				md := &issuecredentialsvc.MetaData{}
+				md.IsV3 = true
+
+				for _, opt := range opts {
+					opt(md)
+				}
+
+				request = md.RequestCredentialV3()
+
+				return nil
+			},
+		}
+
+		walletInstance, token := newOpenedWallet(t, mockctx)
+
+		require.NoError(t, walletInstance.Add(token, Credential, []byte(identityVC)))
+
+		application, err := walletInstance.CreateCredentialApplication(token, newOffer(t, manifest))
+		require.NoError(t, err)
+
+		response, err := walletInstance.RequestCredential(token, uuid.New().String(), FromPresentation(application))
+		require.NoError(t, err)
+		require.Equal(t, model.AckStatusPENDING, response.Status)
+
+		require.NotNil(t, request)
+		require.Len(t, request.Attachments, 1)
+		require.Equal(t, issuecredentialmw.CredentialApplicationAttachmentFormat, request.Attachments[0].Format)
+	})
+
+	t.Run("resolve credential manifest", func(t *testing.T) {
+		walletInstance, token := newOpenedWallet(t, newMockProvider(t))
+
+		require.NoError(t, walletInstance.Add(token, Credential, []byte(identityVC)))
+
+		vc, err := verifiable.ParseCredential([]byte(identityVC), verifiable.WithDisabledProofCheck(),
+			verifiable.WithJSONLDDocumentLoader(walletInstance.jsonldDocumentLoader))
+		require.NoError(t, err)
+
+		vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vc))
+		require.NoError(t, err)
+
+		fulfillment, err := cm.PresentCredentialFulfillment(manifest,
+			cm.WithExistingPresentationForPresentCredentialFulfillment(vp))
+		require.NoError(t, err)
+
+		rawFulfillment, err := fulfillment.MarshalJSON()
+		require.NoError(t, err)
+
+		for _, resolve := range []ResolveManifestOption{
+			ResolveFulfillment(fulfillment),
+			ResolveRawFulfillment(rawFulfillment),
+			ResolveCredential(vc),
+			ResolveRawCredential([]byte(identityVC)),
+			ResolveCredentialID("urn:uuid:identity"),
+		} {
+			resolved, err := walletInstance.ResolveCredentialManifest(token, []byte(manifestJSON), resolve)
+			require.NoError(t, err)
+			require.Len(t, resolved, 1)
+			require.Len(t, resolved[0], 1)
+			require.Equal(t, "Driver License", resolved[0][0].Title)
+			require.Equal(t, []interface{}{"Jayden Doe"}, resolved[0][0].Properties)
+		}
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		walletInstance, token := newOpenedWallet(t, newMockProvider(t))
+
+		_, err := walletInstance.CreateCredentialApplication(token, nil)
+		require.EqualError(t, err, "offer credential message is mandatory")
+
+		invalidOffer := service.NewDIDCommMsgMap(issuecredentialsvc.OfferCredentialV3{
+			Type: issuecredentialsvc.OfferCredentialMsgTypeV3,
+		})
+
+		_, err = walletInstance.CreateCredentialApplication(token, &invalidOffer)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read credential manifest offer")
+
+		_, err = walletInstance.CreateCredentialApplication(token, newOffer(t, manifest))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to present credentials for credential manifest")
+
+		_, err = walletInstance.CreateCredentialApplication(token, newOffer(t, manifest),
+			WithApplicationSelection(map[string][]string{"identity_input": {"urn:uuid:unknown"}}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get selected credential")
+
+		_, err = walletInstance.CreateCredentialApplication(sampleFakeTkn, newOffer(t, manifest))
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		_, err = walletInstance.ResolveCredentialManifest(token, []byte(`{}`), ResolveRawCredential([]byte(identityVC)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read credential manifest")
+
+		_, err = walletInstance.ResolveCredentialManifest(token, []byte(manifestJSON), nil)
+		require.EqualError(t, err, "no fulfillment or credential to resolve")
+
+		_, err = walletInstance.ResolveCredentialManifest(token, []byte(manifestJSON),
+			ResolveRawFulfillment([]byte(`{"type": "invalid"}`)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse credential fulfillment")
+
+		_, err = walletInstance.ResolveCredentialManifest(token, []byte(manifestJSON),
+			ResolveCredentialID("urn:uuid:unknown"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get credential")
+
+		_, err = walletInstance.ResolveCredentialManifest(token, []byte(manifestJSON),
+			ResolveRawCredential([]byte(`{"id": "urn:uuid:invalid"}`)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse credential")
+	})
+}
+
 func TestWallet_Issue(t *testing.T) {
 	user := uuid.New().String()
 	customVDR := &mockvdr.MockVDRegistry{
@@ -2077,6 +2377,198 @@ func Test_AddContext(t *testing.T) {
 	require.Len(t, vc.Context, 4)
 }
 
+func TestWallet_DataIntegrityProof(t *testing.T) {
+	user := uuid.New().String()
+	customVDR := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			if strings.HasPrefix(didID, "did:key:") {
+				return key.New().Read(didID)
+			}
+
+			return nil, fmt.Errorf("did not found")
+		},
+	}
+
+	sampleCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = customVDR
+	mockctx.CryptoValue = sampleCrypto
+
+	err = CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	walletInstance, err := New(user, mockctx)
+	require.NoError(t, err)
+
+	tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	defer walletInstance.Close()
+
+	// import keys manually
+	kmgr, err := keyManager().getKeyManger(tkn)
+	require.NoError(t, err)
+
+	edPriv := ed25519.PrivateKey(base58.Decode(pkBase58))
+	// nolint: errcheck, gosec
+	kmgr.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(kid))
+
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	ecJWK, err := jwksupport.JWKFromKey(&ecPriv.PublicKey)
+	require.NoError(t, err)
+
+	ecDIDKey, ecKeyID, err := fingerprint.CreateDIDKeyByJwk(ecJWK)
+	require.NoError(t, err)
+
+	_, _, err = kmgr.ImportPrivateKey(ecPriv, kms.ECDSAP384TypeIEEEP1363,
+		kms.WithKeyID(strings.Split(ecKeyID, "#")[1]))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		controller  string
+		cryptosuite string
+	}{
+		{"eddsa-rdfc-2022 by default", didKey, ""},
+		{"ecdsa-rdfc-2019 with P-384 key", ecDIDKey, "ecdsa-rdfc-2019"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			vc, err := walletInstance.Issue(tkn, []byte(sampleUDCVC), &ProofOptions{
+				Controller:  tc.controller,
+				ProofType:   DataIntegrityProof,
+				Cryptosuite: tc.cryptosuite,
+			})
+			require.NoError(t, err)
+			require.Len(t, vc.Proofs, 1)
+			require.Equal(t, DataIntegrityProof, vc.Proofs[0]["type"])
+			require.NotEmpty(t, vc.Proofs[0]["cryptosuite"])
+			require.NotEmpty(t, vc.Proofs[0]["proofValue"])
+
+			vcBytes, err := vc.MarshalJSON()
+			require.NoError(t, err)
+
+			ok, err := walletInstance.Verify(tkn, WithRawCredentialToVerify(vcBytes))
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			vp, err := walletInstance.Prove(tkn, &ProofOptions{
+				Controller:  tc.controller,
+				ProofType:   DataIntegrityProof,
+				Cryptosuite: tc.cryptosuite,
+			}, WithCredentialsToProve(vc))
+			require.NoError(t, err)
+			require.Len(t, vp.Proofs, 1)
+			require.Equal(t, DataIntegrityProof, vp.Proofs[0]["type"])
+
+			vpBytes, err := vp.MarshalJSON()
+			require.NoError(t, err)
+
+			ok, err = walletInstance.Verify(tkn, WithRawPresentationToVerify(vpBytes))
+			require.NoError(t, err)
+			require.True(t, ok)
+		})
+	}
+
+	t.Run("unsupported cryptosuite", func(t *testing.T) {
+		_, err := walletInstance.Issue(tkn, []byte(sampleUDCVC), &ProofOptions{
+			Controller:  didKey,
+			ProofType:   DataIntegrityProof,
+			Cryptosuite: "bbs-2023",
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported cryptosuite 'bbs-2023'")
+	})
+
+	t.Run("ecdsa-rdfc-2019 with Ed25519 key", func(t *testing.T) {
+		_, err := walletInstance.Issue(tkn, []byte(sampleUDCVC), &ProofOptions{
+			Controller:  didKey,
+			ProofType:   DataIntegrityProof,
+			Cryptosuite: "ecdsa-rdfc-2019",
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verification method key is not supported by cryptosuite 'ecdsa-rdfc-2019'")
+	})
+}
+
+func TestWallet_Ed25519Signature2020(t *testing.T) {
+	user := uuid.New().String()
+	customVDR := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			if strings.HasPrefix(didID, "did:key:") {
+				return key.New().Read(didID)
+			}
+
+			return nil, fmt.Errorf("did not found")
+		},
+	}
+
+	sampleCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = customVDR
+	mockctx.CryptoValue = sampleCrypto
+
+	err = CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	walletInstance, err := New(user, mockctx)
+	require.NoError(t, err)
+
+	tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	defer walletInstance.Close()
+
+	// import keys manually
+	kmgr, err := keyManager().getKeyManger(tkn)
+	require.NoError(t, err)
+
+	edPriv := ed25519.PrivateKey(base58.Decode(pkBase58))
+	// nolint: errcheck, gosec
+	kmgr.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(kid))
+
+	vc, err := walletInstance.Issue(tkn, []byte(sampleUDCVC), &ProofOptions{
+		Controller: didKey,
+		ProofType:  Ed25519Signature2020,
+	})
+	require.NoError(t, err)
+	require.Len(t, vc.Proofs, 1)
+	require.Equal(t, Ed25519Signature2020, vc.Proofs[0]["type"])
+	require.True(t, strings.HasPrefix(vc.Proofs[0]["proofValue"].(string), "z"))
+	require.NotContains(t, vc.Proofs[0], "jws")
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	ok, err := walletInstance.Verify(tkn, WithRawCredentialToVerify(vcBytes))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	vp, err := walletInstance.Prove(tkn, &ProofOptions{
+		Controller: didKey,
+		ProofType:  Ed25519Signature2020,
+	}, WithCredentialsToProve(vc))
+	require.NoError(t, err)
+	require.Len(t, vp.Proofs, 1)
+	require.Equal(t, Ed25519Signature2020, vp.Proofs[0]["type"])
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	ok, err = walletInstance.Verify(tkn, WithRawPresentationToVerify(vpBytes))
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
 func TestWallet_Verify(t *testing.T) {
 	user := uuid.New().String()
 	customVDR := &mockvdr.MockVDRegistry{